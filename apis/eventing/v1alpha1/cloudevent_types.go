@@ -17,7 +17,7 @@ limitations under the License.
 package v1alpha1
 
 // CloudEventType contains the list of cloudevent types
-// +kubebuilder:validation:Enum=keda.scaledobject.ready.v1;keda.scaledobject.failed.v1;keda.scaledobject.removed.v1;keda.scaledjob.ready.v1;keda.scaledjob.failed.v1;keda.scaledjob.removed.v1;keda.authentication.triggerauthentication.created.v1;keda.authentication.triggerauthentication.updated.v1;keda.authentication.triggerauthentication.removed.v1;keda.authentication.clustertriggerauthentication.created.v1;keda.authentication.clustertriggerauthentication.updated.v1;keda.authentication.clustertriggerauthentication.removed.v1
+// +kubebuilder:validation:Enum=keda.scaledobject.ready.v1;keda.scaledobject.failed.v1;keda.scaledobject.removed.v1;keda.scaledobject.trigger.activated.v1;keda.scaledobject.trigger.deactivated.v1;keda.scaledobject.trigger.failed.v1;keda.scaledobject.fallback.entered.v1;keda.scaledobject.fallback.exited.v1;keda.scaledjob.ready.v1;keda.scaledjob.failed.v1;keda.scaledjob.removed.v1;keda.scaledjob.trigger.activated.v1;keda.scaledjob.trigger.deactivated.v1;keda.scaledjob.trigger.failed.v1;keda.authentication.triggerauthentication.created.v1;keda.authentication.triggerauthentication.updated.v1;keda.authentication.triggerauthentication.removed.v1;keda.authentication.clustertriggerauthentication.created.v1;keda.authentication.clustertriggerauthentication.updated.v1;keda.authentication.clustertriggerauthentication.removed.v1
 
 type CloudEventType string
 
@@ -31,6 +31,21 @@ const (
 	// ScaledObjectRemovedType is for event when removed ScaledObject
 	ScaledObjectRemovedType CloudEventType = "keda.scaledobject.removed.v1"
 
+	// ScaledObjectTriggerActivatedType is for event when a trigger of a ScaledObject becomes active
+	ScaledObjectTriggerActivatedType CloudEventType = "keda.scaledobject.trigger.activated.v1"
+
+	// ScaledObjectTriggerDeactivatedType is for event when a trigger of a ScaledObject is no longer active
+	ScaledObjectTriggerDeactivatedType CloudEventType = "keda.scaledobject.trigger.deactivated.v1"
+
+	// ScaledObjectTriggerFailedType is for event when a scaler of a ScaledObject starts failing
+	ScaledObjectTriggerFailedType CloudEventType = "keda.scaledobject.trigger.failed.v1"
+
+	// ScaledObjectFallbackEnteredType is for event when a ScaledObject falls back to fallback.replicas
+	ScaledObjectFallbackEnteredType CloudEventType = "keda.scaledobject.fallback.entered.v1"
+
+	// ScaledObjectFallbackExitedType is for event when a ScaledObject no longer falls back
+	ScaledObjectFallbackExitedType CloudEventType = "keda.scaledobject.fallback.exited.v1"
+
 	// ScaledJobReadyType is for event when a new ScaledJob is ready
 	ScaledJobReadyType CloudEventType = "keda.scaledjob.ready.v1"
 
@@ -40,6 +55,15 @@ const (
 	// ScaledJobRemovedType is for event when removed ScaledJob
 	ScaledJobRemovedType CloudEventType = "keda.scaledjob.removed.v1"
 
+	// ScaledJobTriggerActivatedType is for event when a trigger of a ScaledJob becomes active
+	ScaledJobTriggerActivatedType CloudEventType = "keda.scaledjob.trigger.activated.v1"
+
+	// ScaledJobTriggerDeactivatedType is for event when a trigger of a ScaledJob is no longer active
+	ScaledJobTriggerDeactivatedType CloudEventType = "keda.scaledjob.trigger.deactivated.v1"
+
+	// ScaledJobTriggerFailedType is for event when a scaler of a ScaledJob starts failing
+	ScaledJobTriggerFailedType CloudEventType = "keda.scaledjob.trigger.failed.v1"
+
 	// TriggerAuthenticationCreatedType is for event when a new TriggerAuthentication is created
 	TriggerAuthenticationCreatedType CloudEventType = "keda.authentication.triggerauthentication.created.v1"
 
@@ -61,5 +85,10 @@ const (
 
 var AllEventTypes = []CloudEventType{
 	ScaledObjectFailedType, ScaledObjectReadyType, ScaledObjectRemovedType,
+	ScaledObjectTriggerActivatedType, ScaledObjectTriggerDeactivatedType, ScaledObjectTriggerFailedType,
+	ScaledObjectFallbackEnteredType, ScaledObjectFallbackExitedType,
 	ScaledJobFailedType, ScaledJobReadyType, ScaledJobRemovedType,
+	ScaledJobTriggerActivatedType, ScaledJobTriggerDeactivatedType, ScaledJobTriggerFailedType,
+	TriggerAuthenticationCreatedType, TriggerAuthenticationUpdatedType, TriggerAuthenticationRemovedType,
+	ClusterTriggerAuthenticationCreatedType, ClusterTriggerAuthenticationUpdatedType, ClusterTriggerAuthenticationRemovedType,
 }
@@ -104,6 +104,12 @@ type Destination struct {
 
 	// +optional
 	AzureEventGridTopic *AzureEventGridTopicSpec `json:"azureEventGridTopic"`
+
+	// +optional
+	Kafka *CloudEventKafka `json:"kafka"`
+
+	// +optional
+	NATS *CloudEventNATS `json:"nats"`
 }
 
 type CloudEventHTTP struct {
@@ -114,6 +120,26 @@ type AzureEventGridTopicSpec struct {
 	Endpoint string `json:"endpoint"`
 }
 
+// CloudEventKafka defines the Kafka topic the events are produced to
+type CloudEventKafka struct {
+	// BootstrapServers are the brokers of the Kafka cluster, eg: kafka-0.kafka:9092
+	BootstrapServers []string `json:"bootstrapServers"`
+
+	Topic string `json:"topic"`
+}
+
+// CloudEventNATS defines the NATS subject the events are published to
+type CloudEventNATS struct {
+	// URL of the NATS server, eg: nats://nats.nats:4222
+	URL string `json:"url"`
+
+	Subject string `json:"subject"`
+
+	// JetStream waits for the acknowledgement of the stream the subject is bound to
+	// +optional
+	JetStream bool `json:"jetStream,omitempty"`
+}
+
 // EventSubscription defines filters for events
 type EventSubscription struct {
 	// +optional
@@ -27,6 +27,8 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
 var cloudeventsourcelog = logf.Log.WithName("cloudeventsource-validation-webhook")
@@ -51,7 +53,8 @@ var _ webhook.Validator = &CloudEventSource{}
 func (ces *CloudEventSource) ValidateCreate() (admission.Warnings, error) {
 	val, _ := json.MarshalIndent(ces, "", "  ")
 	cloudeventsourcelog.Info(fmt.Sprintf("validating cloudeventsource creation for %s", string(val)))
-	return validateSpec(&ces.Spec)
+	warnings, err := validateSpec(&ces.Spec)
+	return kedav1alpha1.AdmissionResult(ces.Namespace, warnings, err)
 }
 
 func (ces *CloudEventSource) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
@@ -63,7 +66,8 @@ func (ces *CloudEventSource) ValidateUpdate(old runtime.Object) (admission.Warni
 		cloudeventsourcelog.V(1).Info("finalizer removal, skipping validation")
 		return nil, nil
 	}
-	return validateSpec(&ces.Spec)
+	warnings, err := validateSpec(&ces.Spec)
+	return kedav1alpha1.AdmissionResult(ces.Namespace, warnings, err)
 }
 
 func (ces *CloudEventSource) ValidateDelete() (admission.Warnings, error) {
@@ -78,7 +82,8 @@ var _ webhook.Validator = &ClusterCloudEventSource{}
 func (cces *ClusterCloudEventSource) ValidateCreate() (admission.Warnings, error) {
 	val, _ := json.MarshalIndent(cces, "", "  ")
 	cloudeventsourcelog.Info(fmt.Sprintf("validating clustercloudeventsource creation for %s", string(val)))
-	return validateSpec(&cces.Spec)
+	warnings, err := validateSpec(&cces.Spec)
+	return kedav1alpha1.AdmissionResult("", warnings, err)
 }
 
 func (cces *ClusterCloudEventSource) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
@@ -90,7 +95,8 @@ func (cces *ClusterCloudEventSource) ValidateUpdate(old runtime.Object) (admissi
 		cloudeventsourcelog.V(1).Info("finalizer removal, skipping validation")
 		return nil, nil
 	}
-	return validateSpec(&cces.Spec)
+	warnings, err := validateSpec(&cces.Spec)
+	return kedav1alpha1.AdmissionResult("", warnings, err)
 }
 
 func (cces *ClusterCloudEventSource) ValidateDelete() (admission.Warnings, error) {
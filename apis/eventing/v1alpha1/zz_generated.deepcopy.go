@@ -55,6 +55,41 @@ func (in *CloudEventHTTP) DeepCopy() *CloudEventHTTP {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventKafka) DeepCopyInto(out *CloudEventKafka) {
+	*out = *in
+	if in.BootstrapServers != nil {
+		in, out := &in.BootstrapServers, &out.BootstrapServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventKafka.
+func (in *CloudEventKafka) DeepCopy() *CloudEventKafka {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventKafka)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventNATS) DeepCopyInto(out *CloudEventNATS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventNATS.
+func (in *CloudEventNATS) DeepCopy() *CloudEventNATS {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventNATS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudEventSource) DeepCopyInto(out *CloudEventSource) {
 	*out = *in
@@ -228,6 +263,16 @@ func (in *Destination) DeepCopyInto(out *Destination) {
 		*out = new(AzureEventGridTopicSpec)
 		**out = **in
 	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(CloudEventKafka)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NATS != nil {
+		in, out := &in.NATS, &out.NATS
+		*out = new(CloudEventNATS)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Destination.
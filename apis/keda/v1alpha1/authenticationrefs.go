@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	metricscollector "github.com/kedacore/keda/v2/pkg/metricscollector/webhook"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	triggerAuthenticationKind        = "TriggerAuthentication"
+	clusterTriggerAuthenticationKind = "ClusterTriggerAuthentication"
+)
+
+// apiReader reads the secrets from the API server, they aren't cached by the webhooks
+var apiReader client.Reader
+
+// verifyAuthenticationRefs checks the TriggerAuthentications referenced by the triggers exist and so do the keys
+// of the secrets they reference. The missing TriggerAuthentications and secrets are only warned about as they may
+// be created after the scalable object, like the ones which can't be read, while a key missing from a secret is denied.
+func verifyAuthenticationRefs(incomingObject interface{}, action string, _ bool) (admission.Warnings, error) {
+	var triggers []ScaleTriggers
+	var name string
+	var namespace string
+	switch obj := incomingObject.(type) {
+	case *ScaledObject:
+		triggers = obj.Spec.Triggers
+		name = obj.Name
+		namespace = obj.Namespace
+	case *ScaledJob:
+		triggers = obj.Spec.Triggers
+		name = obj.Name
+		namespace = obj.Namespace
+	default:
+		return nil, fmt.Errorf("unknown scalable object type %v", incomingObject)
+	}
+	if kc == nil || apiReader == nil {
+		return nil, nil
+	}
+
+	var warnings admission.Warnings
+	for i, trigger := range triggers {
+		if trigger.AuthenticationRef == nil {
+			continue
+		}
+		triggerWarnings, err := verifyAuthenticationRef(context.Background(), namespace, trigger.AuthenticationRef)
+		for _, warning := range triggerWarnings {
+			warnings = append(warnings, fmt.Sprintf("trigger %d: %s", i, warning))
+		}
+		if err != nil {
+			err = fmt.Errorf("invalid authenticationRef of the trigger %d: %w", i, err)
+			scaledobjectlog.WithValues("name", name).Error(err, "validation error")
+			metricscollector.RecordScaledObjectValidatingErrors(namespace, action, "incorrect-authentication-ref")
+			return warnings, err
+		}
+	}
+	return warnings, nil
+}
+
+func verifyAuthenticationRef(ctx context.Context, namespace string, ref *AuthenticationRef) (admission.Warnings, error) {
+	var spec *TriggerAuthenticationSpec
+	secretNamespace := namespace
+	switch ref.Kind {
+	case "", triggerAuthenticationKind:
+		ta := &TriggerAuthentication{}
+		if err := kc.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, ta); err != nil {
+			return admission.Warnings{referenceWarning(triggerAuthenticationKind, ref.Name, err)}, nil
+		}
+		spec = &ta.Spec
+	case clusterTriggerAuthenticationKind:
+		cta := &ClusterTriggerAuthentication{}
+		if err := kc.Get(ctx, types.NamespacedName{Name: ref.Name}, cta); err != nil {
+			return admission.Warnings{referenceWarning(clusterTriggerAuthenticationKind, ref.Name, err)}, nil
+		}
+		clusterObjectNamespace, err := kedautil.GetClusterObjectNamespace()
+		if err != nil {
+			return admission.Warnings{fmt.Sprintf("the secrets of the ClusterTriggerAuthentication %q can't be verified: %s", ref.Name, err)}, nil
+		}
+		spec = &cta.Spec
+		secretNamespace = clusterObjectNamespace
+	default:
+		return nil, nil
+	}
+
+	var warnings admission.Warnings
+	secrets := map[string]*corev1.Secret{}
+	for _, secretRef := range spec.SecretTargetRef {
+		secret, checked := secrets[secretRef.Name]
+		if !checked {
+			secret = &corev1.Secret{}
+			if err := apiReader.Get(ctx, types.NamespacedName{Name: secretRef.Name, Namespace: secretNamespace}, secret); err != nil {
+				warnings = append(warnings, fmt.Sprintf("the secret %q referenced by the %s %q: %s", secretRef.Name, ref.kind(), ref.Name, referenceError(err)))
+				secret = nil
+			}
+			secrets[secretRef.Name] = secret
+		}
+		if secret == nil {
+			continue
+		}
+		if _, ok := secret.Data[secretRef.Key]; !ok {
+			return warnings, fmt.Errorf("the key %q of the secret %q referenced by the %s %q for the parameter %q doesn't exist",
+				secretRef.Key, secretRef.Name, ref.kind(), ref.Name, secretRef.Parameter)
+		}
+	}
+	return warnings, nil
+}
+
+// kind returns the kind of the referenced resource, defaulted to TriggerAuthentication
+func (ref *AuthenticationRef) kind() string {
+	if ref.Kind == "" {
+		return triggerAuthenticationKind
+	}
+	return ref.Kind
+}
+
+func referenceWarning(kind, name string, err error) string {
+	return fmt.Sprintf("the %s %q: %s", kind, name, referenceError(err))
+}
+
+// referenceError describes why a referenced resource couldn't be read
+func referenceError(err error) string {
+	switch {
+	case apierrors.IsNotFound(err):
+		return "doesn't exist"
+	case apierrors.IsForbidden(err):
+		return "can't be verified, reading it isn't allowed"
+	default:
+		return fmt.Sprintf("can't be verified: %s", err)
+	}
+}
@@ -0,0 +1,77 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestVerifyAuthenticationRefs(t *testing.T) {
+	defer func(c client.Client, r client.Reader) { kc, apiReader = c, r }(kc, apiReader)
+	t.Setenv("KEDA_CLUSTER_OBJECT_NAMESPACE", "keda")
+
+	scheme := runtime.NewScheme()
+	assert.NoError(t, AddToScheme(scheme))
+	assert.NoError(t, corev1.AddToScheme(scheme))
+	kc = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&TriggerAuthentication{
+			ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: "default"},
+			Spec: TriggerAuthenticationSpec{SecretTargetRef: []AuthSecretTargetRef{
+				{Parameter: "username", Name: "credentials", Key: "username"},
+				{Parameter: "password", Name: "credentials", Key: "password"},
+			}},
+		},
+		&TriggerAuthentication{
+			ObjectMeta: metav1.ObjectMeta{Name: "missing-secret", Namespace: "default"},
+			Spec: TriggerAuthenticationSpec{SecretTargetRef: []AuthSecretTargetRef{
+				{Parameter: "password", Name: "missing", Key: "password"},
+			}},
+		},
+		&ClusterTriggerAuthentication{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-auth"},
+			Spec: TriggerAuthenticationSpec{SecretTargetRef: []AuthSecretTargetRef{
+				{Parameter: "token", Name: "cluster-credentials", Key: "token"},
+			}},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "default"},
+			Data:       map[string][]byte{"username": []byte("user")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-credentials", Namespace: "keda"},
+			Data:       map[string][]byte{"token": []byte("token")},
+		},
+	).Build()
+	apiReader = kc
+
+	scaledObject := func(refs ...*AuthenticationRef) *ScaledObject {
+		so := &ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+		for _, ref := range refs {
+			so.Spec.Triggers = append(so.Spec.Triggers, ScaleTriggers{Type: "prometheus", AuthenticationRef: ref})
+		}
+		return so
+	}
+
+	warnings, err := verifyAuthenticationRefs(scaledObject(nil, &AuthenticationRef{Name: "cluster-auth", Kind: "ClusterTriggerAuthentication"}), "create", false)
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	// the missing resources may be created afterwards
+	warnings, err = verifyAuthenticationRefs(scaledObject(&AuthenticationRef{Name: "unknown"}, &AuthenticationRef{Name: "missing-secret"}), "create", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`trigger 0: the TriggerAuthentication "unknown": doesn't exist`,
+		`trigger 1: the secret "missing" referenced by the TriggerAuthentication "missing-secret": doesn't exist`,
+	}, []string(warnings))
+
+	_, err = verifyAuthenticationRefs(&ScaledJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "default"},
+		Spec:       ScaledJobSpec{Triggers: []ScaleTriggers{{Type: "prometheus", AuthenticationRef: &AuthenticationRef{Name: "auth"}}}},
+	}, "create", false)
+	assert.EqualError(t, err, `invalid authenticationRef of the trigger 0: the key "password" of the secret "credentials" referenced by the TriggerAuthentication "auth" for the parameter "password" doesn't exist`)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// scalingRuleCostLimit bounds the cost of the evaluation of a rule, so a rule can't stall the admission
+const scalingRuleCostLimit = 1000000
+
+var (
+	scalingRuleEnv = sync.OnceValues(func() (*cel.Env, error) {
+		return cel.NewEnv(cel.Variable("object", cel.DynType))
+	})
+	// scalingRulePrograms are the rules already compiled, by expression
+	scalingRulePrograms sync.Map
+)
+
+// scalingRuleProgram returns the compiled rule
+func scalingRuleProgram(expression string) (cel.Program, error) {
+	if program, ok := scalingRulePrograms.Load(expression); ok {
+		return program.(cel.Program), nil
+	}
+	env, err := scalingRuleEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	program, err := env.Program(ast, cel.CostLimit(scalingRuleCostLimit))
+	if err != nil {
+		return nil, err
+	}
+	scalingRulePrograms.Store(expression, program)
+	return program, nil
+}
+
+// ruleViolations returns the messages of the rules of the policy the ScaledObject or ScaledJob doesn't satisfy
+func (p *ClusterScalingPolicy) ruleViolations(scalableObject client.Object, kind string) []string {
+	if len(p.Spec.Rules) == 0 {
+		return nil
+	}
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(scalableObject)
+	if err != nil {
+		return []string{fmt.Sprintf("the rules can't be evaluated: %s", err)}
+	}
+	// the objects read from the cache don't carry their type
+	object["apiVersion"] = SchemeGroupVersion.String()
+	object["kind"] = kind
+
+	var violations []string
+	for _, rule := range p.Spec.Rules {
+		satisfied, err := rule.evaluate(object)
+		switch {
+		case err != nil:
+			violations = append(violations, fmt.Sprintf("the rule %q can't be evaluated: %s", rule.Expression, err))
+		case !satisfied && rule.Message != "":
+			violations = append(violations, rule.Message)
+		case !satisfied:
+			violations = append(violations, fmt.Sprintf("the rule %q isn't satisfied", rule.Expression))
+		}
+	}
+	return violations
+}
+
+func (rule ScalingRule) evaluate(object map[string]interface{}) (bool, error) {
+	program, err := scalingRuleProgram(rule.Expression)
+	if err != nil {
+		return false, err
+	}
+	value, _, err := program.Eval(map[string]interface{}{"object": object})
+	if err != nil {
+		return false, err
+	}
+	satisfied, ok := value.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("it evaluates to %s instead of a bool", value.Type().TypeName())
+	}
+	return satisfied, nil
+}
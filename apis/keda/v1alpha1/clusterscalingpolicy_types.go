@@ -0,0 +1,278 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=clusterscalingpolicies,scope=Cluster,shortName=csp
+// +kubebuilder:printcolumn:name="Triggers",type="string",JSONPath=".spec.allowedTriggerTypes"
+// +kubebuilder:printcolumn:name="Max",type="integer",JSONPath=".spec.maxReplicaCount"
+// +kubebuilder:printcolumn:name="MinPollingInterval",type="integer",JSONPath=".spec.minPollingInterval"
+// +kubebuilder:printcolumn:name="Fallback",type="boolean",JSONPath=".spec.requireFallback"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterScalingPolicy restricts the ScaledObjects and ScaledJobs of the cluster it selects
+type ClusterScalingPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterScalingPolicySpec `json:"spec"`
+}
+
+// ClusterScalingPolicySpec selects the ScaledObjects and ScaledJobs the policy applies to and the rules they have to follow
+type ClusterScalingPolicySpec struct {
+	// Namespaces are the namespaces the policy applies to, it applies to all of them if empty
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Selector selects the ScaledObjects and ScaledJobs the policy applies to by their labels, it applies to all of them if not set
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// AllowedTriggerTypes are the only types of the triggers allowed, all of them are allowed if empty
+	// +optional
+	AllowedTriggerTypes []string `json:"allowedTriggerTypes,omitempty"`
+	// MaxReplicaCount is the highest maxReplicaCount allowed
+	// +optional
+	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
+	// MinPollingInterval is the lowest pollingInterval allowed, in seconds
+	// +optional
+	MinPollingInterval *int32 `json:"minPollingInterval,omitempty"`
+	// RequireFallback requires the ScaledObjects to define a fallback, it doesn't apply to the ScaledJobs
+	// +optional
+	RequireFallback bool `json:"requireFallback,omitempty"`
+	// Defaults are applied to the ScaledObjects omitting them when they are admitted
+	// +optional
+	Defaults *ScaledObjectDefaults `json:"defaults,omitempty"`
+	// Rules are the CEL expressions the ScaledObjects and ScaledJobs have to satisfy
+	// +optional
+	Rules []ScalingRule `json:"rules,omitempty"`
+}
+
+// ScalingRule is a CEL expression evaluated against the ScaledObjects and ScaledJobs, which are
+// bound to the object variable, e.g. has(object.spec.cooldownPeriod) && object.spec.cooldownPeriod >= 300
+type ScalingRule struct {
+	// Expression has to evaluate to true for the ScaledObject or ScaledJob to follow the rule
+	Expression string `json:"expression"`
+	// Message is the violation reported when the expression evaluates to false
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ScaledObjectDefaults are the settings applied to the ScaledObjects which don't set them
+type ScaledObjectDefaults struct {
+	// +optional
+	Fallback *Fallback `json:"fallback,omitempty"`
+	// Behavior is the behavior of the HPA of the ScaledObjects
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+	// PollingInterval is the pollingInterval of the ScaledObjects, in seconds
+	// +optional
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+	// IdleReplicaCount is the idleReplicaCount of the ScaledObjects, it is only applied if it is lower than their minReplicaCount
+	// +optional
+	IdleReplicaCount *int32 `json:"idleReplicaCount,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterScalingPolicyList is a list of ClusterScalingPolicy resources
+type ClusterScalingPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ClusterScalingPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterScalingPolicy{}, &ClusterScalingPolicyList{})
+}
+
+// Selects returns true if the policy applies to the ScaledObject or ScaledJob
+func (p *ClusterScalingPolicy) Selects(scalableObject client.Object) (bool, error) {
+	if len(p.Spec.Namespaces) > 0 && !slices.Contains(p.Spec.Namespaces, scalableObject.GetNamespace()) {
+		return false, nil
+	}
+	if p.Spec.Selector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(p.Spec.Selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid selector of the ClusterScalingPolicy %s: %w", p.Name, err)
+	}
+	return selector.Matches(labels.Set(scalableObject.GetLabels())), nil
+}
+
+// Violations returns the rules of the policy the ScaledObject or ScaledJob doesn't follow
+func (p *ClusterScalingPolicy) Violations(scalableObject client.Object) []string {
+	var triggers []ScaleTriggers
+	var maxReplicaCount int32
+	var pollingInterval *int32
+	var violations []string
+	var kind string
+	switch obj := scalableObject.(type) {
+	case *ScaledObject:
+		kind = "ScaledObject"
+		triggers = obj.Spec.Triggers
+		maxReplicaCount = obj.GetHPAMaxReplicas()
+		pollingInterval = obj.Spec.PollingInterval
+		if p.Spec.RequireFallback && obj.Spec.Fallback == nil {
+			violations = append(violations, "a fallback is required")
+		}
+	case *ScaledJob:
+		kind = "ScaledJob"
+		triggers = obj.Spec.Triggers
+		maxReplicaCount = defaultScaledJobMaxReplicaCount
+		if obj.Spec.MaxReplicaCount != nil {
+			maxReplicaCount = *obj.Spec.MaxReplicaCount
+		}
+		pollingInterval = obj.Spec.PollingInterval
+	default:
+		return []string{fmt.Sprintf("unknown scalable object type %T", scalableObject)}
+	}
+
+	if len(p.Spec.AllowedTriggerTypes) > 0 {
+		for _, trigger := range triggers {
+			if !slices.Contains(p.Spec.AllowedTriggerTypes, trigger.Type) {
+				violations = append(violations, fmt.Sprintf("trigger type %q is not allowed, allowed types are %v", trigger.Type, p.Spec.AllowedTriggerTypes))
+			}
+		}
+	}
+	if p.Spec.MaxReplicaCount != nil && maxReplicaCount > *p.Spec.MaxReplicaCount {
+		violations = append(violations, fmt.Sprintf("maxReplicaCount=%d must not be greater than %d", maxReplicaCount, *p.Spec.MaxReplicaCount))
+	}
+	if p.Spec.MinPollingInterval != nil {
+		interval := int32(defaultPollingInterval)
+		if pollingInterval != nil {
+			interval = *pollingInterval
+		}
+		if interval < *p.Spec.MinPollingInterval {
+			violations = append(violations, fmt.Sprintf("pollingInterval=%d must not be lower than %d", interval, *p.Spec.MinPollingInterval))
+		}
+	}
+	return append(violations, p.ruleViolations(scalableObject, kind)...)
+}
+
+// CheckScalingPolicies checks the ScaledObject or ScaledJob follows the ClusterScalingPolicies which apply to it,
+// returns an error listing the rules it doesn't follow
+func CheckScalingPolicies(ctx context.Context, c client.Client, scalableObject client.Object) error {
+	policies := &ClusterScalingPolicyList{}
+	if err := c.List(ctx, policies); err != nil {
+		return err
+	}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		selected, err := policy.Selects(scalableObject)
+		if err != nil {
+			return err
+		}
+		if !selected {
+			continue
+		}
+		if violations := policy.Violations(scalableObject); len(violations) > 0 {
+			return fmt.Errorf("the ClusterScalingPolicy %s is violated: %s", policy.Name, strings.Join(violations, ", "))
+		}
+	}
+	return nil
+}
+
+// ApplyDefaults sets the defaults of the policy the ScaledObject omits, the pollingInterval is raised to the
+// minPollingInterval of the policy if it is omitted too. It returns the fields which have been set.
+// The defaults the ScaledObject wouldn't be valid with, like a fallback for its cpu trigger, aren't applied.
+func (p *ClusterScalingPolicy) ApplyDefaults(so *ScaledObject) []string {
+	var applied []string
+	defaults := p.Spec.Defaults
+	if defaults == nil {
+		defaults = &ScaledObjectDefaults{}
+	}
+
+	if so.Spec.PollingInterval == nil {
+		interval := defaults.PollingInterval
+		if interval == nil && p.Spec.MinPollingInterval != nil && *p.Spec.MinPollingInterval > defaultPollingInterval {
+			interval = p.Spec.MinPollingInterval
+		}
+		if interval != nil {
+			so.Spec.PollingInterval = ptr.To(*interval)
+			applied = append(applied, "pollingInterval")
+		}
+	}
+	if so.Spec.Fallback == nil && defaults.Fallback != nil {
+		so.Spec.Fallback = defaults.Fallback.DeepCopy()
+		if CheckFallbackValid(so) == nil {
+			applied = append(applied, "fallback")
+		} else {
+			so.Spec.Fallback = nil
+		}
+	}
+	if so.Spec.IdleReplicaCount == nil && defaults.IdleReplicaCount != nil {
+		so.Spec.IdleReplicaCount = ptr.To(*defaults.IdleReplicaCount)
+		if CheckReplicaCountBoundsAreValid(so) == nil {
+			applied = append(applied, "idleReplicaCount")
+		} else {
+			so.Spec.IdleReplicaCount = nil
+		}
+	}
+	if defaults.Behavior != nil && (so.Spec.Advanced == nil || so.Spec.Advanced.HorizontalPodAutoscalerConfig == nil ||
+		so.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior == nil) {
+		if so.Spec.Advanced == nil {
+			so.Spec.Advanced = &AdvancedConfig{}
+		}
+		if so.Spec.Advanced.HorizontalPodAutoscalerConfig == nil {
+			so.Spec.Advanced.HorizontalPodAutoscalerConfig = &HorizontalPodAutoscalerConfig{}
+		}
+		so.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior = defaults.Behavior.DeepCopy()
+		applied = append(applied, "advanced.horizontalPodAutoscalerConfig.behavior")
+	}
+	return applied
+}
+
+// ApplyScalingDefaults applies the defaults of the ClusterScalingPolicies which apply to the ScaledObject,
+// the policies are applied by name so the first one setting a field wins. It returns the fields which have been set.
+func ApplyScalingDefaults(ctx context.Context, c client.Client, so *ScaledObject) ([]string, error) {
+	policies := &ClusterScalingPolicyList{}
+	if err := c.List(ctx, policies); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(policies.Items, func(a, b ClusterScalingPolicy) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	// the policies are all selected before any is applied so the ScaledObject is left untouched on errors
+	var selected []*ClusterScalingPolicy
+	for i := range policies.Items {
+		ok, err := policies.Items[i].Selects(so)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			selected = append(selected, &policies.Items[i])
+		}
+	}
+	var applied []string
+	for _, policy := range selected {
+		applied = append(applied, policy.ApplyDefaults(so)...)
+	}
+	return applied, nil
+}
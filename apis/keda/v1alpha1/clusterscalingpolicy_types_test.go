@@ -0,0 +1,205 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClusterScalingPolicySelects(t *testing.T) {
+	so := &ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a", Labels: map[string]string{"tier": "frontend"}}}
+
+	tests := []struct {
+		name     string
+		spec     ClusterScalingPolicySpec
+		selected bool
+	}{
+		{name: "all", spec: ClusterScalingPolicySpec{}, selected: true},
+		{name: "namespace", spec: ClusterScalingPolicySpec{Namespaces: []string{"team-a"}}, selected: true},
+		{name: "other namespace", spec: ClusterScalingPolicySpec{Namespaces: []string{"team-b"}}, selected: false},
+		{
+			name:     "labels",
+			spec:     ClusterScalingPolicySpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "frontend"}}},
+			selected: true,
+		},
+		{
+			name:     "other labels",
+			spec:     ClusterScalingPolicySpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "backend"}}},
+			selected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := &ClusterScalingPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}, Spec: test.spec}
+			selected, err := policy.Selects(so)
+			assert.NoError(t, err)
+			assert.Equal(t, test.selected, selected)
+		})
+	}
+}
+
+func TestClusterScalingPolicyViolations(t *testing.T) {
+	policy := &ClusterScalingPolicy{Spec: ClusterScalingPolicySpec{
+		AllowedTriggerTypes: []string{"prometheus", "cron"},
+		MaxReplicaCount:     ptr.To[int32](50),
+		MinPollingInterval:  ptr.To[int32](15),
+		RequireFallback:     true,
+	}}
+
+	so := &ScaledObject{Spec: ScaledObjectSpec{
+		Triggers: []ScaleTriggers{{Type: "prometheus"}},
+		Fallback: &Fallback{FailureThreshold: 3, Replicas: 1},
+	}}
+	// the default maxReplicaCount is over the limit
+	assert.Equal(t, []string{"maxReplicaCount=100 must not be greater than 50"}, policy.Violations(so))
+
+	so.Spec.MaxReplicaCount = ptr.To[int32](20)
+	assert.Empty(t, policy.Violations(so))
+
+	so.Spec.Triggers = append(so.Spec.Triggers, ScaleTriggers{Type: "kafka"})
+	so.Spec.PollingInterval = ptr.To[int32](5)
+	so.Spec.Fallback = nil
+	assert.Equal(t, []string{
+		"a fallback is required",
+		`trigger type "kafka" is not allowed, allowed types are [prometheus cron]`,
+		"pollingInterval=5 must not be lower than 15",
+	}, policy.Violations(so))
+
+	// the fallback isn't required for the ScaledJobs
+	sj := &ScaledJob{Spec: ScaledJobSpec{
+		Triggers:        []ScaleTriggers{{Type: "cron"}},
+		MaxReplicaCount: ptr.To[int32](10),
+	}}
+	assert.Empty(t, policy.Violations(sj))
+}
+
+func TestCheckScalingPolicies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&ClusterScalingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+			Spec:       ClusterScalingPolicySpec{Namespaces: []string{"team-a"}, MaxReplicaCount: ptr.To[int32](10)},
+		},
+		&ClusterScalingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-b"},
+			Spec:       ClusterScalingPolicySpec{Namespaces: []string{"team-b"}, MaxReplicaCount: ptr.To[int32](100)},
+		},
+	).Build()
+
+	so := &ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-b"}}
+	assert.NoError(t, CheckScalingPolicies(context.TODO(), c, so))
+
+	so.Namespace = "team-a"
+	assert.EqualError(t, CheckScalingPolicies(context.TODO(), c, so), "the ClusterScalingPolicy team-a is violated: maxReplicaCount=100 must not be greater than 10")
+}
+
+func TestClusterScalingPolicyApplyDefaults(t *testing.T) {
+	behavior := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.To[int32](600)},
+	}
+	policy := &ClusterScalingPolicy{Spec: ClusterScalingPolicySpec{
+		MinPollingInterval: ptr.To[int32](60),
+		Defaults: &ScaledObjectDefaults{
+			Fallback:         &Fallback{FailureThreshold: 3, Replicas: 2},
+			Behavior:         behavior,
+			IdleReplicaCount: ptr.To[int32](0),
+		},
+	}}
+
+	so := &ScaledObject{Spec: ScaledObjectSpec{
+		MinReplicaCount: ptr.To[int32](1),
+		Triggers:        []ScaleTriggers{{Type: "prometheus", MetricType: autoscalingv2.AverageValueMetricType}},
+	}}
+	assert.Equal(t, []string{"pollingInterval", "fallback", "idleReplicaCount", "advanced.horizontalPodAutoscalerConfig.behavior"}, policy.ApplyDefaults(so))
+	assert.Equal(t, int32(60), *so.Spec.PollingInterval)
+	assert.Equal(t, &Fallback{FailureThreshold: 3, Replicas: 2}, so.Spec.Fallback)
+	assert.Equal(t, int32(0), *so.Spec.IdleReplicaCount)
+	assert.Equal(t, behavior, so.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior)
+	// the defaults are copied
+	so.Spec.Fallback.Replicas = 5
+	assert.Equal(t, int32(2), policy.Spec.Defaults.Fallback.Replicas)
+
+	// the settings of the ScaledObject are kept
+	assert.Empty(t, policy.ApplyDefaults(so))
+	assert.Equal(t, int32(5), so.Spec.Fallback.Replicas)
+
+	// the defaults the ScaledObject wouldn't be valid with aren't applied
+	so = &ScaledObject{Spec: ScaledObjectSpec{
+		PollingInterval: ptr.To[int32](90),
+		Triggers:        []ScaleTriggers{{Type: "cpu", MetricType: autoscalingv2.UtilizationMetricType}},
+	}}
+	assert.Equal(t, []string{"advanced.horizontalPodAutoscalerConfig.behavior"}, policy.ApplyDefaults(so))
+	assert.Nil(t, so.Spec.Fallback)
+	assert.Nil(t, so.Spec.IdleReplicaCount)
+	assert.Equal(t, int32(90), *so.Spec.PollingInterval)
+}
+
+func TestApplyScalingDefaults(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&ClusterScalingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-team-a"},
+			Spec:       ClusterScalingPolicySpec{Namespaces: []string{"team-a"}, Defaults: &ScaledObjectDefaults{PollingInterval: ptr.To[int32](15)}},
+		},
+		&ClusterScalingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "b-cluster"},
+			Spec:       ClusterScalingPolicySpec{Defaults: &ScaledObjectDefaults{PollingInterval: ptr.To[int32](45), IdleReplicaCount: ptr.To[int32](0)}},
+		},
+	).Build()
+
+	so := &ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"}, Spec: ScaledObjectSpec{MinReplicaCount: ptr.To[int32](2)}}
+	applied, err := ApplyScalingDefaults(context.TODO(), c, so)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"pollingInterval", "idleReplicaCount"}, applied)
+	assert.Equal(t, int32(15), *so.Spec.PollingInterval)
+	assert.Equal(t, int32(0), *so.Spec.IdleReplicaCount)
+
+	so = &ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-b"}}
+	applied, err = ApplyScalingDefaults(context.TODO(), c, so)
+	assert.NoError(t, err)
+	// the idleReplicaCount isn't applied as the minReplicaCount is 0
+	assert.Equal(t, []string{"pollingInterval"}, applied)
+	assert.Equal(t, int32(45), *so.Spec.PollingInterval)
+}
+
+func TestClusterScalingPolicyRules(t *testing.T) {
+	policy := &ClusterScalingPolicy{Spec: ClusterScalingPolicySpec{Rules: []ScalingRule{
+		{
+			Expression: `object.kind != "ScaledObject" || (has(object.spec.cooldownPeriod) && object.spec.cooldownPeriod >= 300)`,
+			Message:    "the cooldownPeriod must be at least 300 seconds",
+		},
+		{Expression: `object.spec.triggers.all(t, t.type != "cpu")`},
+	}}}
+
+	so := &ScaledObject{Spec: ScaledObjectSpec{
+		CooldownPeriod: ptr.To[int32](300),
+		Triggers:       []ScaleTriggers{{Type: "prometheus"}},
+	}}
+	assert.Empty(t, policy.Violations(so))
+
+	so.Spec.CooldownPeriod = nil
+	so.Spec.Triggers = append(so.Spec.Triggers, ScaleTriggers{Type: "cpu"})
+	assert.Equal(t, []string{
+		"the cooldownPeriod must be at least 300 seconds",
+		`the rule "object.spec.triggers.all(t, t.type != \"cpu\")" isn't satisfied`,
+	}, policy.Violations(so))
+
+	// the ScaledJobs are bound with their kind too
+	sj := &ScaledJob{Spec: ScaledJobSpec{Triggers: []ScaleTriggers{{Type: "prometheus"}}}}
+	assert.Empty(t, policy.Violations(sj))
+
+	policy.Spec.Rules = []ScalingRule{{Expression: "object.spec.pollingInterval"}, {Expression: "object.spec.("}, {Expression: "1 + 1"}}
+	violations := policy.Violations(sj)
+	assert.Len(t, violations, 3)
+	assert.Contains(t, violations[0], `the rule "object.spec.pollingInterval" can't be evaluated: no such key: pollingInterval`)
+	assert.Contains(t, violations[1], `the rule "object.spec.(" can't be evaluated: ERROR`)
+	assert.Equal(t, `the rule "1 + 1" can't be evaluated: it evaluates to int instead of a bool`, violations[2])
+}
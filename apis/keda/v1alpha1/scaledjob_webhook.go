@@ -17,15 +17,20 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	metricscollector "github.com/kedacore/keda/v2/pkg/metricscollector/webhook"
 )
 
 var scaledjoblog = logf.Log.WithName("scaledjob-validation-webhook")
@@ -44,7 +49,8 @@ var _ webhook.Validator = &ScaledJob{}
 func (s *ScaledJob) ValidateCreate() (admission.Warnings, error) {
 	val, _ := json.MarshalIndent(s, "", "  ")
 	scaledjoblog.Info(fmt.Sprintf("validating scaledjob creation for %s", string(val)))
-	return nil, verifyTriggers(s, "create", false)
+	warnings, err := validateScaledJob(s, "create")
+	return AdmissionResult(s.Namespace, warnings, err)
 }
 
 func (s *ScaledJob) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
@@ -56,13 +62,54 @@ func (s *ScaledJob) ValidateUpdate(old runtime.Object) (admission.Warnings, erro
 		scaledjoblog.V(1).Info("finalizer removal, skipping validation")
 		return nil, nil
 	}
-	return nil, verifyTriggers(s, "update", false)
+	warnings, err := validateScaledJob(s, "update")
+	return AdmissionResult(s.Namespace, warnings, err)
 }
 
 func (s *ScaledJob) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+func validateScaledJob(s *ScaledJob, action string) (admission.Warnings, error) {
+	if err := verifyTriggers(s, action, false); err != nil {
+		return nil, err
+	}
+	if err := verifyScaledJobs(s, action); err != nil {
+		return nil, err
+	}
+	if err := verifyScalingPolicies(s, action, false); err != nil {
+		return nil, err
+	}
+	return verifyAuthenticationRefs(s, action, false)
+}
+
+// verifyScaledJobs checks there isn't another ScaledJob creating the same jobs for the same triggers,
+// the jobs would be created twice for each event
+func verifyScaledJobs(incomingSj *ScaledJob, action string) error {
+	sjList := &ScaledJobList{}
+	opt := &client.ListOptions{
+		Namespace: incomingSj.Namespace,
+	}
+	err := kc.List(context.Background(), sjList, opt)
+	if err != nil {
+		return err
+	}
+
+	for _, sj := range sjList.Items {
+		if sj.Name == incomingSj.Name {
+			continue
+		}
+		if equality.Semantic.DeepEqual(sj.Spec.Triggers, incomingSj.Spec.Triggers) &&
+			equality.Semantic.DeepEqual(sj.Spec.JobTargetRef, incomingSj.Spec.JobTargetRef) {
+			err = fmt.Errorf("the jobs are already created for the same triggers by the ScaledJob '%s'", sj.Name)
+			scaledjoblog.Error(err, "validation error")
+			metricscollector.RecordScaledObjectValidatingErrors(incomingSj.Namespace, action, "other-scaled-job")
+			return err
+		}
+	}
+	return nil
+}
+
 func isScaledJobRemovingFinalizer(om metav1.ObjectMeta, oldOm metav1.ObjectMeta, spec ScaledJobSpec, oldSpec ScaledJobSpec) bool {
 	taSpec, _ := json.MarshalIndent(spec, "", "  ")
 	oldTaSpec, _ := json.MarshalIndent(oldSpec, "", "  ")
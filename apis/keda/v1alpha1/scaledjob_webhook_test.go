@@ -41,6 +41,27 @@ var _ = It("should validate empty triggers in ScaledJob", func() {
 	}).Should(HaveOccurred())
 })
 
+var _ = It("shouldn't validate the sj creation when there is another sj creating the same jobs", func() {
+
+	namespaceName := "scaledjob-duplicated"
+	namespace := createNamespace(namespaceName)
+
+	err := k8sClient.Create(context.Background(), namespace)
+	Expect(err).ToNot(HaveOccurred())
+
+	triggers := []ScaleTriggers{{Type: "cron", Metadata: map[string]string{"timezone": "UTC", "start": "0 * * * *", "end": "30 * * * *", "desiredReplicas": "1"}}}
+	sj := createScaledJob(sjName, namespaceName, triggers)
+	sj2 := createScaledJob(sjName, namespaceName, triggers)
+	sj2.Name = "test-sj2"
+
+	err = k8sClient.Create(context.Background(), sj2)
+	Expect(err).ToNot(HaveOccurred())
+
+	Eventually(func() error {
+		return k8sClient.Create(context.Background(), sj)
+	}).Should(HaveOccurred())
+})
+
 // -------------------------------------------------------------------------- //
 // ----------------------------- HELP FUNCTIONS ----------------------------- //
 // -------------------------------------------------------------------------- //
@@ -22,6 +22,7 @@ import (
 	"strconv"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -59,10 +60,20 @@ const PausedAnnotation = "autoscaling.keda.sh/paused"
 
 // HealthStatus is the status for a ScaledObject's health
 type HealthStatus struct {
+	// NumberOfFailures is the number of consecutive errors getting the metric
 	// +optional
 	NumberOfFailures *int32 `json:"numberOfFailures,omitempty"`
 	// +optional
 	Status HealthStatusType `json:"status,omitempty"`
+	// LastValue is the value of the metric on the last successful poll
+	// +optional
+	LastValue *resource.Quantity `json:"lastValue,omitempty"`
+	// LastSuccessTime is the time of the last successful poll of the metric
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+	// IsActive is the activation of the metric on the last successful poll
+	// +optional
+	IsActive *bool `json:"isActive,omitempty"`
 }
 
 // HealthStatusType is an indication of whether the health status is happy or failing
@@ -109,8 +120,22 @@ type ScaledObjectSpec struct {
 type Fallback struct {
 	FailureThreshold int32 `json:"failureThreshold"`
 	Replicas         int32 `json:"replicas"`
+	// Behavior is how the metric is replaced on fallback, static scales to the replicas
+	// while lastKnownGood serves the last value retained by the metrics history of the operator
+	// and scales to the replicas only when no value has been retained
+	// +optional
+	// +kubebuilder:validation:Enum=static;lastKnownGood
+	Behavior FallbackBehavior `json:"behavior,omitempty"`
 }
 
+// FallbackBehavior describes how the metric is replaced on fallback
+type FallbackBehavior string
+
+const (
+	FallbackBehaviorStatic        FallbackBehavior = "static"
+	FallbackBehaviorLastKnownGood FallbackBehavior = "lastKnownGood"
+)
+
 // AdvancedConfig specifies advance scaling options
 type AdvancedConfig struct {
 	// +optional
@@ -119,6 +144,10 @@ type AdvancedConfig struct {
 	RestoreToOriginalReplicaCount bool `json:"restoreToOriginalReplicaCount,omitempty"`
 	// +optional
 	ScalingModifiers ScalingModifiers `json:"scalingModifiers,omitempty"`
+	// DirectScaling scales the target without a HPA, KEDA computes the replicas from the cpu/memory
+	// triggers itself and applies the behavior of horizontalPodAutoscalerConfig
+	// +optional
+	DirectScaling bool `json:"directScaling,omitempty"`
 }
 
 // ScalingModifiers describes advanced scaling logic options like formula
@@ -233,6 +262,11 @@ func (so *ScaledObject) IsUsingModifiers() bool {
 	return so.Spec.Advanced != nil && !reflect.DeepEqual(so.Spec.Advanced.ScalingModifiers, ScalingModifiers{})
 }
 
+// IsUsingDirectScaling determines whether the scale target is scaled directly, without a HPA
+func (so *ScaledObject) IsUsingDirectScaling() bool {
+	return so.Spec.Advanced != nil && so.Spec.Advanced.DirectScaling
+}
+
 // getHPAMinReplicas returns MinReplicas based on definition in ScaledObject or default value if not defined
 func (so *ScaledObject) GetHPAMinReplicas() *int32 {
 	if so.Spec.MinReplicaCount != nil && *so.Spec.MinReplicaCount > 0 {
@@ -270,8 +304,26 @@ func CheckReplicaCountBoundsAreValid(scaledObject *ScaledObject) error {
 	return nil
 }
 
+// CheckDirectScalingValid checks that the ScaledObject scaled directly only has resource triggers,
+// their replicas are computed by KEDA the way the HPA would
+func CheckDirectScalingValid(scaledObject *ScaledObject) error {
+	if !scaledObject.IsUsingDirectScaling() {
+		return nil
+	}
+
+	if scaledObject.IsUsingModifiers() {
+		return fmt.Errorf("directScaling can't be used with scalingModifiers")
+	}
+	for _, trigger := range scaledObject.Spec.Triggers {
+		if !IsResourceTrigger(trigger.Type) {
+			return fmt.Errorf("type is %s, but directScaling is only supported by the cpu, memory & ephemeral-storage scalers", trigger.Type)
+		}
+	}
+	return nil
+}
+
 // CheckFallbackValid checks that the fallback supports scalers with an AverageValue metric target.
-// Consequently, it does not support CPU & memory scalers, or scalers targeting a Value metric type.
+// Consequently, it does not support CPU, memory & ephemeral-storage scalers, or scalers targeting a Value metric type.
 func CheckFallbackValid(scaledObject *ScaledObject) error {
 	if scaledObject.Spec.Fallback == nil {
 		return nil
@@ -283,8 +335,8 @@ func CheckFallbackValid(scaledObject *ScaledObject) error {
 	}
 
 	for _, trigger := range scaledObject.Spec.Triggers {
-		if trigger.Type == cpuString || trigger.Type == memoryString {
-			return fmt.Errorf("type is %s , but fallback it is not supported by the CPU & memory scalers", trigger.Type)
+		if IsResourceTrigger(trigger.Type) {
+			return fmt.Errorf("type is %s , but fallback it is not supported by the CPU, memory & ephemeral-storage scalers", trigger.Type)
 		}
 		if trigger.MetricType != autoscalingv2.AverageValueMetricType {
 			return fmt.Errorf("MetricType=%s, but Fallback can only be enabled for triggers with metric of type AverageValue", trigger.MetricType)
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestCheckDirectScalingValid(t *testing.T) {
+	tests := []struct {
+		name      string
+		advanced  *AdvancedConfig
+		triggers  []ScaleTriggers
+		expectErr bool
+	}{
+		{
+			name:     "not scaled directly",
+			triggers: []ScaleTriggers{{Type: "kafka"}},
+		},
+		{
+			name:     "resource triggers",
+			advanced: &AdvancedConfig{DirectScaling: true},
+			triggers: []ScaleTriggers{{Type: cpuString}, {Type: memoryString}, {Type: ephemeralStorageString}},
+		},
+		{
+			name:      "other triggers",
+			advanced:  &AdvancedConfig{DirectScaling: true},
+			triggers:  []ScaleTriggers{{Type: cpuString}, {Type: "kafka"}},
+			expectErr: true,
+		},
+		{
+			name:      "scaling modifiers",
+			advanced:  &AdvancedConfig{DirectScaling: true, ScalingModifiers: ScalingModifiers{Formula: "cpu * 2"}},
+			triggers:  []ScaleTriggers{{Type: cpuString}},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaledObject := &ScaledObject{Spec: ScaledObjectSpec{Advanced: test.advanced, Triggers: test.triggers}}
+			err := CheckDirectScalingValid(scaledObject)
+			if test.expectErr && err == nil {
+				t.Error("expected an error")
+			}
+			if !test.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
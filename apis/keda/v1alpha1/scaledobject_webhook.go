@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -48,12 +49,25 @@ var restMapper meta.RESTMapper
 
 var memoryString = "memory"
 var cpuString = "cpu"
+var ephemeralStorageString = "ephemeral-storage"
+
+// triggerMetadataValidator validates the metadata of a trigger against its scaler, the scalers
+// can't be referenced from the API types so it is registered by the admission webhooks
+var triggerMetadataValidator func(trigger ScaleTriggers) error
+
+// RegisterTriggerMetadataValidator registers the validator of the metadata of the triggers of the
+// ScaledObjects and ScaledJobs which are admitted
+func RegisterTriggerMetadataValidator(validator func(trigger ScaleTriggers) error) {
+	triggerMetadataValidator = validator
+}
 
 func (so *ScaledObject) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	kc = mgr.GetClient()
 	restMapper = mgr.GetRESTMapper()
+	apiReader = mgr.GetAPIReader()
 	return ctrl.NewWebhookManagedBy(mgr).
 		WithValidator(&ScaledObjectCustomValidator{}).
+		WithDefaulter(&ScaledObjectCustomDefaulter{}).
 		For(so).
 		Complete()
 }
@@ -69,7 +83,8 @@ func (socv ScaledObjectCustomValidator) ValidateCreate(ctx context.Context, obj
 		return nil, err
 	}
 	so := obj.(*ScaledObject)
-	return so.ValidateCreate(request.DryRun)
+	warnings, err = so.ValidateCreate(request.DryRun)
+	return AdmissionResult(so.Namespace, warnings, err)
 }
 
 func (socv ScaledObjectCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (warnings admission.Warnings, err error) {
@@ -79,7 +94,8 @@ func (socv ScaledObjectCustomValidator) ValidateUpdate(ctx context.Context, oldO
 	}
 	so := newObj.(*ScaledObject)
 	old := oldObj.(*ScaledObject)
-	return so.ValidateUpdate(old, request.DryRun)
+	warnings, err = so.ValidateUpdate(old, request.DryRun)
+	return AdmissionResult(so.Namespace, warnings, err)
 }
 
 func (socv ScaledObjectCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
@@ -93,6 +109,30 @@ func (socv ScaledObjectCustomValidator) ValidateDelete(ctx context.Context, obj
 
 var _ webhook.CustomValidator = &ScaledObjectCustomValidator{}
 
+// +kubebuilder:webhook:path=/mutate-keda-sh-v1alpha1-scaledobject,mutating=true,failurePolicy=ignore,sideEffects=None,groups=keda.sh,resources=scaledobjects,verbs=create;update,versions=v1alpha1,name=mscaledobject.kb.io,admissionReviewVersions=v1
+
+// ScaledObjectCustomDefaulter is a custom defaulter for ScaledObject objects, it applies the defaults of the ClusterScalingPolicies
+type ScaledObjectCustomDefaulter struct{}
+
+func (socd ScaledObjectCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	so := obj.(*ScaledObject)
+	if !so.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	applied, err := ApplyScalingDefaults(ctx, kc, so)
+	if err != nil {
+		// the ScaledObject is admitted as it is, the policies still validate it
+		scaledobjectlog.Error(err, "error applying the defaults of the ClusterScalingPolicies", "name", so.Name, "namespace", so.Namespace)
+		return nil
+	}
+	if len(applied) > 0 {
+		scaledobjectlog.V(1).Info("applied the defaults of the ClusterScalingPolicies", "name", so.Name, "namespace", so.Namespace, "fields", applied)
+	}
+	return nil
+}
+
+var _ webhook.CustomDefaulter = &ScaledObjectCustomDefaulter{}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (so *ScaledObject) ValidateCreate(dryRun *bool) (admission.Warnings, error) {
 	val, _ := json.MarshalIndent(so, "", "  ")
@@ -136,6 +176,7 @@ func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.W
 		verifyHpas,
 		verifyReplicaCount,
 		verifyFallback,
+		verifyDirectScaling,
 	}
 
 	for i := range verifyFunctions {
@@ -147,6 +188,7 @@ func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.W
 
 	verifyCommonFunctions := []func(interface{}, string, bool) error{
 		verifyTriggers,
+		verifyScalingPolicies,
 	}
 
 	for i := range verifyCommonFunctions {
@@ -156,8 +198,13 @@ func validateWorkload(so *ScaledObject, action string, dryRun bool) (admission.W
 		}
 	}
 
+	warnings, err := verifyAuthenticationRefs(so, action, dryRun)
+	if err != nil {
+		return warnings, err
+	}
+
 	scaledobjectlog.V(1).Info(fmt.Sprintf("scaledobject %s is valid", so.Name))
-	return nil, nil
+	return warnings, nil
 }
 
 func verifyReplicaCount(incomingSo *ScaledObject, action string, _ bool) error {
@@ -178,6 +225,15 @@ func verifyFallback(incomingSo *ScaledObject, action string, _ bool) error {
 	return nil
 }
 
+func verifyDirectScaling(incomingSo *ScaledObject, action string, _ bool) error {
+	err := CheckDirectScalingValid(incomingSo)
+	if err != nil {
+		scaledobjectlog.WithValues("name", incomingSo.Name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "incorrect-direct-scaling")
+	}
+	return err
+}
+
 func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 	var triggers []ScaleTriggers
 	var name string
@@ -199,6 +255,40 @@ func verifyTriggers(incomingObject interface{}, action string, _ bool) error {
 	if err != nil {
 		scaledobjectlog.WithValues("name", name).Error(err, "validation error")
 		metricscollector.RecordScaledObjectValidatingErrors(namespace, action, "incorrect-triggers")
+		return err
+	}
+
+	err = validateTriggersMetadata(triggers)
+	if err != nil {
+		scaledobjectlog.WithValues("name", name).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(namespace, action, "incorrect-trigger-metadata")
+	}
+	return err
+}
+
+// validateTriggersMetadata validates the metadata of the triggers with the registered validator
+func validateTriggersMetadata(triggers []ScaleTriggers) error {
+	if triggerMetadataValidator == nil {
+		return nil
+	}
+	for i, trigger := range triggers {
+		if err := triggerMetadataValidator(trigger); err != nil {
+			return fmt.Errorf("invalid metadata of the trigger %d of type %q: %w", i, trigger.Type, err)
+		}
+	}
+	return nil
+}
+
+func verifyScalingPolicies(incomingObject interface{}, action string, _ bool) error {
+	obj, ok := incomingObject.(client.Object)
+	if !ok {
+		return fmt.Errorf("unknown scalable object type %v", incomingObject)
+	}
+
+	err := CheckScalingPolicies(context.Background(), kc, obj)
+	if err != nil {
+		scaledobjectlog.WithValues("name", obj.GetName()).Error(err, "validation error")
+		metricscollector.RecordScaledObjectValidatingErrors(obj.GetNamespace(), action, "scaling-policy")
 	}
 	return err
 }
@@ -219,6 +309,10 @@ func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
 		scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource from incoming ScaledObject", "apiVersion", incomingSo.Spec.ScaleTargetRef.APIVersion, "kind", incomingSo.Spec.ScaleTargetRef.Kind)
 		return err
 	}
+	incomingSoTargets, err := scaleTargets(context.Background(), incomingSo.Namespace, incomingSoGckr, incomingSo.Spec.ScaleTargetRef.Name)
+	if err != nil {
+		return err
+	}
 
 	for _, hpa := range hpaList.Items {
 		if hpa.ObjectMeta.Annotations[ValidationsHpaOwnershipAnnotation] == "false" {
@@ -229,12 +323,16 @@ func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
 
 		hpaGckr, err := ParseGVKR(restMapper, hpa.Spec.ScaleTargetRef.APIVersion, hpa.Spec.ScaleTargetRef.Kind)
 		if err != nil {
-			scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource from HPA", "hpaName", hpa.Name, "apiVersion", hpa.Spec.ScaleTargetRef.APIVersion, "kind", hpa.Spec.ScaleTargetRef.Kind)
+			// the scale target of the HPA isn't served anymore, it doesn't scale any workload
+			scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource from HPA, skipping it", "hpaName", hpa.Name, "apiVersion", hpa.Spec.ScaleTargetRef.APIVersion, "kind", hpa.Spec.ScaleTargetRef.Kind)
+			continue
+		}
+		hpaTargets, err := scaleTargets(context.Background(), hpa.Namespace, hpaGckr, hpa.Spec.ScaleTargetRef.Name)
+		if err != nil {
 			return err
 		}
 
-		if hpaGckr.GVKString() == incomingSoGckr.GVKString() &&
-			hpa.Spec.ScaleTargetRef.Name == incomingSo.Spec.ScaleTargetRef.Name {
+		if target, found := overlappingScaleTarget(incomingSoTargets, hpaTargets); found {
 			owned := false
 			for _, owner := range hpa.OwnerReferences {
 				if owner.Kind == incomingSo.Kind {
@@ -250,7 +348,7 @@ func verifyHpas(incomingSo *ScaledObject, action string, _ bool) error {
 					incomingSo.Spec.Advanced.HorizontalPodAutoscalerConfig.Name == hpa.Name {
 					scaledobjectlog.Info(fmt.Sprintf("%s hpa ownership being transferred to %s", hpa.Name, incomingSo.Name))
 				} else {
-					err = fmt.Errorf("the workload '%s' of type '%s' is already managed by the hpa '%s'", incomingSo.Spec.ScaleTargetRef.Name, incomingSoGckr.GVKString(), hpa.Name)
+					err = fmt.Errorf("the workload '%s' of type '%s' is already managed by the hpa '%s'", target.name, target.groupKind, hpa.Name)
 					scaledobjectlog.Error(err, "validation error")
 					metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "other-hpa")
 					return err
@@ -276,6 +374,10 @@ func verifyScaledObjects(incomingSo *ScaledObject, action string, _ bool) error
 		scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource from incoming ScaledObject", "apiVersion", incomingSo.Spec.ScaleTargetRef.APIVersion, "kind", incomingSo.Spec.ScaleTargetRef.Kind)
 		return err
 	}
+	incomingSoTargets, err := scaleTargets(context.Background(), incomingSo.Namespace, incomingSoGckr, incomingSo.Spec.ScaleTargetRef.Name)
+	if err != nil {
+		return err
+	}
 
 	for _, so := range soList.Items {
 		if so.Name == incomingSo.Name {
@@ -286,13 +388,17 @@ func verifyScaledObjects(incomingSo *ScaledObject, action string, _ bool) error
 
 		soGckr, err := ParseGVKR(restMapper, so.Spec.ScaleTargetRef.APIVersion, so.Spec.ScaleTargetRef.Kind)
 		if err != nil {
-			scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource from ScaledObject", "soName", so.Name, "apiVersion", so.Spec.ScaleTargetRef.APIVersion, "kind", so.Spec.ScaleTargetRef.Kind)
+			// the scale target of the ScaledObject isn't served anymore, it doesn't scale any workload
+			scaledobjectlog.Error(err, "Failed to parse Group, Version, Kind, Resource from ScaledObject, skipping it", "soName", so.Name, "apiVersion", so.Spec.ScaleTargetRef.APIVersion, "kind", so.Spec.ScaleTargetRef.Kind)
+			continue
+		}
+		soTargets, err := scaleTargets(context.Background(), so.Namespace, soGckr, so.Spec.ScaleTargetRef.Name)
+		if err != nil {
 			return err
 		}
 
-		if soGckr.GVKString() == incomingSoGckr.GVKString() &&
-			so.Spec.ScaleTargetRef.Name == incomingSo.Spec.ScaleTargetRef.Name {
-			err = fmt.Errorf("the workload '%s' of type '%s' is already managed by the ScaledObject '%s'", so.Spec.ScaleTargetRef.Name, incomingSoGckr.GVKString(), so.Name)
+		if target, found := overlappingScaleTarget(incomingSoTargets, soTargets); found {
+			err = fmt.Errorf("the workload '%s' of type '%s' is already managed by the ScaledObject '%s'", target.name, target.groupKind, so.Name)
 			scaledobjectlog.Error(err, "validation error")
 			metricscollector.RecordScaledObjectValidatingErrors(incomingSo.Namespace, action, "other-scaled-object")
 			return err
@@ -319,7 +425,7 @@ func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string, dryRun bool
 
 	var podSpec *corev1.PodSpec
 	for _, trigger := range incomingSo.Spec.Triggers {
-		if trigger.Type == cpuString || trigger.Type == memoryString {
+		if IsResourceTrigger(trigger.Type) {
 			if podSpec == nil {
 				key := types.NamespacedName{
 					Namespace: incomingSo.Namespace,
@@ -352,11 +458,11 @@ func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string, dryRun bool
 			}
 			conainerName := trigger.Metadata["containerName"]
 			for _, container := range podSpec.Containers {
-				if conainerName != "" && container.Name != conainerName {
+				if conainerName != "" && !containerNameMatches(conainerName, container.Name) {
 					continue
 				}
 
-				if trigger.Type == cpuString || trigger.Type == memoryString {
+				if IsResourceTrigger(trigger.Type) {
 					// Fail if neither pod's container spec has particular resource limit specified, nor a default limit is
 					// specified in LimitRange in the same namespace as the deployment
 					resourceType := corev1.ResourceName(trigger.Type)
@@ -375,7 +481,7 @@ func verifyCPUMemoryScalers(incomingSo *ScaledObject, action string, dryRun bool
 			// return an error because it will never scale to zero
 			scaleToZeroErr := true
 			for _, trig := range incomingSo.Spec.Triggers {
-				if trig.Type != cpuString && trig.Type != memoryString {
+				if !IsResourceTrigger(trig.Type) {
 					scaleToZeroErr = false
 					break
 				}
@@ -444,10 +550,7 @@ func validateScalingModifiersFormula(so *ScaledObject) (*vm.Program, error) {
 	// defined (have names)
 	triggersMap := make(map[string]float64)
 	for _, trig := range so.Spec.Triggers {
-		// if resource metrics are given, skip
-		if trig.Type == cpuString || trig.Type == memoryString {
-			continue
-		}
+		// the values of the resource metrics are consumed too
 		if trig.Name != "" {
 			triggersMap[trig.Name] = dummyValue
 		}
@@ -544,3 +647,26 @@ func isContainerResourceLimitSet(ctx context.Context, namespace string, triggerT
 
 	return false
 }
+
+// IsResourceTrigger returns whether the trigger scales on the resources of the pods of the scale target
+func IsResourceTrigger(triggerType string) bool {
+	return triggerType == cpuString || triggerType == memoryString || triggerType == ephemeralStorageString
+}
+
+// containerNameMatches returns whether the containerName of a cpu/memory trigger, a name, a regular expression
+// or a comma separated list of them, matches the whole name of the container
+func containerNameMatches(containerName, name string) bool {
+	for _, item := range strings.Split(containerName, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if item == name {
+			return true
+		}
+		if matched, err := regexp.MatchString("^(?:"+item+")$", name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
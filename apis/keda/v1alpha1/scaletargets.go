@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	argoRolloutsGroup = "argoproj.io"
+	argoRolloutKind   = "Rollout"
+)
+
+// scaleTarget is a workload scaled by a ScaledObject or a HPA, it is identified by its group
+// and kind as the same workload may be served in several versions
+type scaleTarget struct {
+	groupKind schema.GroupKind
+	name      string
+}
+
+// scaleTargets returns the workloads scaled through the scale target: the scale target itself and,
+// for an Argo Rollout, the workload it references through its workloadRef as it scales it in its place
+func scaleTargets(ctx context.Context, namespace string, gvkr GroupVersionKindResource, name string) ([]scaleTarget, error) {
+	targets := []scaleTarget{{groupKind: gvkr.GroupVersionKind().GroupKind(), name: name}}
+	if gvkr.Group != argoRolloutsGroup || gvkr.Kind != argoRolloutKind {
+		return targets, nil
+	}
+
+	rollout := &unstructured.Unstructured{}
+	rollout.SetGroupVersionKind(gvkr.GroupVersionKind())
+	err := kc.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, rollout)
+	if apierrors.IsNotFound(err) {
+		return targets, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	workloadRef, found, err := unstructured.NestedStringMap(rollout.Object, "spec", "workloadRef")
+	if err != nil || !found {
+		return targets, err
+	}
+	groupVersion, err := schema.ParseGroupVersion(workloadRef["apiVersion"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid workloadRef of the Rollout '%s': %w", name, err)
+	}
+	return append(targets, scaleTarget{
+		groupKind: schema.GroupKind{Group: groupVersion.Group, Kind: workloadRef["kind"]},
+		name:      workloadRef["name"],
+	}), nil
+}
+
+// overlappingScaleTarget returns the workload scaled through both of the scale targets, if any
+func overlappingScaleTarget(targets, otherTargets []scaleTarget) (scaleTarget, bool) {
+	for _, target := range targets {
+		for _, otherTarget := range otherTargets {
+			if target == otherTarget {
+				return target, true
+			}
+		}
+	}
+	return scaleTarget{}, false
+}
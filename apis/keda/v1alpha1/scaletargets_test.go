@@ -0,0 +1,52 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestScaleTargets(t *testing.T) {
+	defer func(c client.Client) { kc = c }(kc)
+	rollout := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "argoproj.io/v1alpha1",
+		"kind":       "Rollout",
+		"metadata":   map[string]interface{}{"name": "rollout", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"workloadRef": map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "workload"},
+		},
+	}}
+	kc = fake.NewClientBuilder().WithObjects(rollout).Build()
+
+	rolloutGvkr := GroupVersionKindResource{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout", Resource: "rollouts"}
+	rolloutTargets, err := scaleTargets(context.Background(), "default", rolloutGvkr, "rollout")
+	assert.NoError(t, err)
+	assert.Equal(t, []scaleTarget{
+		{groupKind: schema.GroupKind{Group: "argoproj.io", Kind: "Rollout"}, name: "rollout"},
+		{groupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"}, name: "workload"},
+	}, rolloutTargets)
+
+	// the workload is scaled through the Rollout referencing it
+	deploymentGvkr := GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"}
+	deploymentTargets, err := scaleTargets(context.Background(), "default", deploymentGvkr, "workload")
+	assert.NoError(t, err)
+	target, found := overlappingScaleTarget(deploymentTargets, rolloutTargets)
+	assert.True(t, found)
+	assert.Equal(t, "workload", target.name)
+
+	// the same workload is served in several versions
+	otherVersionTargets, err := scaleTargets(context.Background(), "default", GroupVersionKindResource{Group: "argoproj.io", Version: "v1beta1", Kind: "Rollout"}, "rollout")
+	assert.NoError(t, err)
+	_, found = overlappingScaleTarget(otherVersionTargets, rolloutTargets)
+	assert.True(t, found)
+
+	missingTargets, err := scaleTargets(context.Background(), "default", rolloutGvkr, "missing")
+	assert.NoError(t, err)
+	_, found = overlappingScaleTarget(deploymentTargets, missingTargets)
+	assert.False(t, found)
+}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -103,3 +104,20 @@ func TestValidateTriggers(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTriggersMetadata(t *testing.T) {
+	triggers := []ScaleTriggers{
+		{Type: "cron", Metadata: map[string]string{"start": "0 8 * * *"}},
+		{Type: "prometheus", Metadata: map[string]string{"threshold": "ten"}},
+	}
+	assert.NoError(t, validateTriggersMetadata(triggers))
+
+	RegisterTriggerMetadataValidator(func(trigger ScaleTriggers) error {
+		if trigger.Type == "prometheus" {
+			return fmt.Errorf("bad threshold")
+		}
+		return nil
+	})
+	defer RegisterTriggerMetadataValidator(nil)
+	assert.EqualError(t, validateTriggersMetadata(triggers), `invalid metadata of the trigger 1 of type "prometheus": bad threshold`)
+}
@@ -55,7 +55,8 @@ var _ webhook.Validator = &TriggerAuthentication{}
 func (ta *TriggerAuthentication) ValidateCreate() (admission.Warnings, error) {
 	val, _ := json.MarshalIndent(ta, "", "  ")
 	triggerauthenticationlog.Info(fmt.Sprintf("validating triggerauthentication creation for %s", string(val)))
-	return validateSpec(&ta.Spec)
+	warnings, err := validateSpec(&ta.Spec)
+	return AdmissionResult(ta.Namespace, warnings, err)
 }
 
 func (ta *TriggerAuthentication) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
@@ -67,7 +68,8 @@ func (ta *TriggerAuthentication) ValidateUpdate(old runtime.Object) (admission.W
 		triggerauthenticationlog.V(1).Info("finalizer removal, skipping validation")
 		return nil, nil
 	}
-	return validateSpec(&ta.Spec)
+	warnings, err := validateSpec(&ta.Spec)
+	return AdmissionResult(ta.Namespace, warnings, err)
 }
 
 func (ta *TriggerAuthentication) ValidateDelete() (admission.Warnings, error) {
@@ -82,7 +84,8 @@ var _ webhook.Validator = &ClusterTriggerAuthentication{}
 func (cta *ClusterTriggerAuthentication) ValidateCreate() (admission.Warnings, error) {
 	val, _ := json.MarshalIndent(cta, "", "  ")
 	triggerauthenticationlog.Info(fmt.Sprintf("validating clustertriggerauthentication creation for %s", string(val)))
-	return validateSpec(&cta.Spec)
+	warnings, err := validateSpec(&cta.Spec)
+	return AdmissionResult("", warnings, err)
 }
 
 func (cta *ClusterTriggerAuthentication) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
@@ -95,7 +98,8 @@ func (cta *ClusterTriggerAuthentication) ValidateUpdate(old runtime.Object) (adm
 		return nil, nil
 	}
 
-	return validateSpec(&cta.Spec)
+	warnings, err := validateSpec(&cta.Spec)
+	return AdmissionResult("", warnings, err)
 }
 
 func (cta *ClusterTriggerAuthentication) ValidateDelete() (admission.Warnings, error) {
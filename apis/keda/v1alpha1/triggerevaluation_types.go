@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=triggerevaluations,scope=Namespaced,shortName=te
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.trigger.type"
+// +kubebuilder:printcolumn:name="Authentication",type="string",JSONPath=".spec.trigger.authenticationRef.name"
+// +kubebuilder:printcolumn:name="Active",type="boolean",JSONPath=".status.isActive"
+// +kubebuilder:printcolumn:name="Error",type="string",JSONPath=".status.error"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TriggerEvaluation evaluates a trigger once, so it can be validated before it is added to a ScaledObject
+type TriggerEvaluation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TriggerEvaluationSpec   `json:"spec"`
+	Status TriggerEvaluationStatus `json:"status,omitempty"`
+}
+
+// TriggerEvaluationSpec is the trigger to evaluate
+type TriggerEvaluationSpec struct {
+	// ScaleTargetRef is the workload the environment of the trigger is resolved from
+	// +optional
+	ScaleTargetRef *ScaleTarget  `json:"scaleTargetRef,omitempty"`
+	Trigger        ScaleTriggers `json:"trigger"`
+}
+
+// TriggerEvaluationStatus is the outcome of the evaluation of the trigger
+// +optional
+type TriggerEvaluationStatus struct {
+	// ObservedGeneration is the generation of the spec which has been evaluated
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +optional
+	EvaluationTime *metav1.Time `json:"evaluationTime,omitempty"`
+	// ResolvedMetadata is the metadata the scaler has been built with, the values
+	// resolved from the environment and the authentication are redacted
+	// +optional
+	ResolvedMetadata map[string]string `json:"resolvedMetadata,omitempty"`
+	// +optional
+	Metrics []TriggerEvaluationMetric `json:"metrics,omitempty"`
+	// +optional
+	IsActive *bool `json:"isActive,omitempty"`
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// TriggerEvaluationMetric is a metric returned by the scaler of the trigger
+type TriggerEvaluationMetric struct {
+	MetricName string `json:"metricName"`
+	// +optional
+	Value *resource.Quantity `json:"value,omitempty"`
+	// +optional
+	Target *resource.Quantity `json:"target,omitempty"`
+	// +optional
+	IsActive bool `json:"isActive,omitempty"`
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TriggerEvaluationList is a list of TriggerEvaluation resources
+type TriggerEvaluationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []TriggerEvaluation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TriggerEvaluation{}, &TriggerEvaluationList{})
+}
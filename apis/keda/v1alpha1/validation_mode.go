@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ValidationMode is how the admission webhooks handle the objects failing their validation
+type ValidationMode string
+
+const (
+	// ValidationModeDeny denies the objects failing the validation
+	ValidationModeDeny ValidationMode = "deny"
+	// ValidationModeWarn admits the objects failing the validation, returning their errors as warnings
+	ValidationModeWarn ValidationMode = "warn"
+)
+
+var validationMode = ValidationModeDeny
+
+// validationModeNamespaces are the namespaces whose mode differs from the global one
+var validationModeNamespaces map[string]ValidationMode
+
+// SetValidationMode sets the global validation mode of the admission webhooks and the modes of the namespaces
+// which don't follow it, so the validation can be enforced namespace by namespace
+func SetValidationMode(mode ValidationMode, namespaces map[string]ValidationMode) error {
+	if err := mode.validate(); err != nil {
+		return err
+	}
+	for namespace, namespaceMode := range namespaces {
+		if err := namespaceMode.validate(); err != nil {
+			return fmt.Errorf("invalid validation mode of the namespace %s: %w", namespace, err)
+		}
+	}
+	validationMode = mode
+	validationModeNamespaces = namespaces
+	return nil
+}
+
+func (mode ValidationMode) validate() error {
+	switch mode {
+	case ValidationModeDeny, ValidationModeWarn:
+		return nil
+	default:
+		return fmt.Errorf("unknown validation mode %q, it must be %q or %q", mode, ValidationModeDeny, ValidationModeWarn)
+	}
+}
+
+// AdmissionResult returns the outcome of the validation of an object of the namespace, the empty one for the cluster
+// scoped objects. The validation error is returned as a warning if the namespace is in the warn mode.
+func AdmissionResult(namespace string, warnings admission.Warnings, err error) (admission.Warnings, error) {
+	if err == nil {
+		return warnings, nil
+	}
+	mode, found := validationModeNamespaces[namespace]
+	if !found {
+		mode = validationMode
+	}
+	if mode != ValidationModeWarn {
+		return warnings, err
+	}
+	scaledobjectlog.V(1).Info("admitting the object failing the validation in the warn mode", "namespace", namespace, "error", err.Error())
+	return append(warnings, fmt.Sprintf("the object would be denied: %s", err)), nil
+}
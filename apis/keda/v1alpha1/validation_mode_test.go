@@ -0,0 +1,40 @@
+package v1alpha1
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestAdmissionResult(t *testing.T) {
+	defer func() { assert.NoError(t, SetValidationMode(ValidationModeDeny, nil)) }()
+	validationErr := errors.New("invalid trigger")
+
+	warnings, err := AdmissionResult("team-a", admission.Warnings{"warning"}, validationErr)
+	assert.Equal(t, validationErr, err)
+	assert.Equal(t, admission.Warnings{"warning"}, warnings)
+
+	assert.NoError(t, SetValidationMode(ValidationModeDeny, map[string]ValidationMode{"team-a": ValidationModeWarn}))
+	warnings, err = AdmissionResult("team-a", admission.Warnings{"warning"}, validationErr)
+	assert.NoError(t, err)
+	assert.Equal(t, admission.Warnings{"warning", "the object would be denied: invalid trigger"}, warnings)
+	_, err = AdmissionResult("team-b", nil, validationErr)
+	assert.Equal(t, validationErr, err)
+
+	// the namespaces can be enforced one by one
+	assert.NoError(t, SetValidationMode(ValidationModeWarn, map[string]ValidationMode{"team-a": ValidationModeDeny}))
+	_, err = AdmissionResult("team-a", nil, validationErr)
+	assert.Equal(t, validationErr, err)
+	warnings, err = AdmissionResult("", nil, validationErr)
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+
+	warnings, err = AdmissionResult("team-b", nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	assert.EqualError(t, SetValidationMode("audit", nil), `unknown validation mode "audit", it must be "deny" or "warn"`)
+	assert.EqualError(t, SetValidationMode(ValidationModeDeny, map[string]ValidationMode{"team-a": "off"}), `invalid validation mode of the namespace team-a: unknown validation mode "off", it must be "deny" or "warn"`)
+}
@@ -23,6 +23,7 @@ package v1alpha1
 import (
 	"k8s.io/api/autoscaling/v2"
 	"k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -354,6 +355,114 @@ func (in *AzureKeyVaultSecret) DeepCopy() *AzureKeyVaultSecret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScalingPolicy) DeepCopyInto(out *ClusterScalingPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScalingPolicy.
+func (in *ClusterScalingPolicy) DeepCopy() *ClusterScalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScalingPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScalingPolicyList) DeepCopyInto(out *ClusterScalingPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterScalingPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScalingPolicyList.
+func (in *ClusterScalingPolicyList) DeepCopy() *ClusterScalingPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScalingPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterScalingPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScalingPolicySpec) DeepCopyInto(out *ClusterScalingPolicySpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedTriggerTypes != nil {
+		in, out := &in.AllowedTriggerTypes, &out.AllowedTriggerTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxReplicaCount != nil {
+		in, out := &in.MaxReplicaCount, &out.MaxReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinPollingInterval != nil {
+		in, out := &in.MinPollingInterval, &out.MinPollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(ScaledObjectDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ScalingRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScalingPolicySpec.
+func (in *ClusterScalingPolicySpec) DeepCopy() *ClusterScalingPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScalingPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterTriggerAuthentication) DeepCopyInto(out *ClusterTriggerAuthentication) {
 	*out = *in
@@ -602,6 +711,20 @@ func (in *HealthStatus) DeepCopyInto(out *HealthStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.LastValue != nil {
+		in, out := &in.LastValue, &out.LastValue
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.IsActive != nil {
+		in, out := &in.IsActive, &out.IsActive
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthStatus.
@@ -855,6 +978,21 @@ func (in *ScaledObject) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaledObjectCustomDefaulter) DeepCopyInto(out *ScaledObjectCustomDefaulter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectCustomDefaulter.
+func (in *ScaledObjectCustomDefaulter) DeepCopy() *ScaledObjectCustomDefaulter {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaledObjectCustomDefaulter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaledObjectCustomValidator) DeepCopyInto(out *ScaledObjectCustomValidator) {
 	*out = *in
@@ -870,6 +1008,41 @@ func (in *ScaledObjectCustomValidator) DeepCopy() *ScaledObjectCustomValidator {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaledObjectDefaults) DeepCopyInto(out *ScaledObjectDefaults) {
+	*out = *in
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(Fallback)
+		**out = **in
+	}
+	if in.Behavior != nil {
+		in, out := &in.Behavior, &out.Behavior
+		*out = new(v2.HorizontalPodAutoscalerBehavior)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleReplicaCount != nil {
+		in, out := &in.IdleReplicaCount, &out.IdleReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectDefaults.
+func (in *ScaledObjectDefaults) DeepCopy() *ScaledObjectDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaledObjectDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaledObjectList) DeepCopyInto(out *ScaledObjectList) {
 	*out = *in
@@ -1035,6 +1208,21 @@ func (in *ScalingModifiers) DeepCopy() *ScalingModifiers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingRule) DeepCopyInto(out *ScalingRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingRule.
+func (in *ScalingRule) DeepCopy() *ScalingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalingStrategy) DeepCopyInto(out *ScalingStrategy) {
 	*out = *in
@@ -1204,6 +1392,149 @@ func (in *TriggerAuthenticationStatus) DeepCopy() *TriggerAuthenticationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerEvaluation) DeepCopyInto(out *TriggerEvaluation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerEvaluation.
+func (in *TriggerEvaluation) DeepCopy() *TriggerEvaluation {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerEvaluation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TriggerEvaluation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerEvaluationList) DeepCopyInto(out *TriggerEvaluationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TriggerEvaluation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerEvaluationList.
+func (in *TriggerEvaluationList) DeepCopy() *TriggerEvaluationList {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerEvaluationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TriggerEvaluationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerEvaluationMetric) DeepCopyInto(out *TriggerEvaluationMetric) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerEvaluationMetric.
+func (in *TriggerEvaluationMetric) DeepCopy() *TriggerEvaluationMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerEvaluationMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerEvaluationSpec) DeepCopyInto(out *TriggerEvaluationSpec) {
+	*out = *in
+	if in.ScaleTargetRef != nil {
+		in, out := &in.ScaleTargetRef, &out.ScaleTargetRef
+		*out = new(ScaleTarget)
+		**out = **in
+	}
+	in.Trigger.DeepCopyInto(&out.Trigger)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerEvaluationSpec.
+func (in *TriggerEvaluationSpec) DeepCopy() *TriggerEvaluationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerEvaluationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerEvaluationStatus) DeepCopyInto(out *TriggerEvaluationStatus) {
+	*out = *in
+	if in.EvaluationTime != nil {
+		in, out := &in.EvaluationTime, &out.EvaluationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ResolvedMetadata != nil {
+		in, out := &in.ResolvedMetadata, &out.ResolvedMetadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]TriggerEvaluationMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IsActive != nil {
+		in, out := &in.IsActive, &out.IsActive
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerEvaluationStatus.
+func (in *TriggerEvaluationStatus) DeepCopy() *TriggerEvaluationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerEvaluationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueFromSecret) DeepCopyInto(out *ValueFromSecret) {
 	*out = *in
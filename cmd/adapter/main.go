@@ -44,6 +44,7 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
 	kedaprovider "github.com/kedacore/keda/v2/pkg/provider"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -68,6 +69,7 @@ var (
 	metricsServiceAddr          string
 	profilingAddr               string
 	metricsServiceGRPCAuthority string
+	enableOpenTelemetryTracing  bool
 )
 
 func (a *Adapter) makeProvider(ctx context.Context) (provider.ExternalMetricsProvider, <-chan struct{}, error) {
@@ -249,6 +251,7 @@ func main() {
 	cmd.Flags().Float32Var(&adapterClientRequestQPS, "kube-api-qps", 20.0, "Set the QPS rate for throttling requests sent to the apiserver")
 	cmd.Flags().IntVar(&adapterClientRequestBurst, "kube-api-burst", 30, "Set the burst for throttling requests sent to the apiserver")
 	cmd.Flags().BoolVar(&disableCompression, "disable-compression", true, "Disable response compression for k8s restAPI in client-go. ")
+	cmd.Flags().BoolVar(&enableOpenTelemetryTracing, "enable-opentelemetry-tracing", false, "Enable the opentelemetry tracing of keda-metrics-apiserver.")
 
 	if err := cmd.Flags().Parse(os.Args); err != nil {
 		return
@@ -261,6 +264,20 @@ func main() {
 		return
 	}
 
+	if enableOpenTelemetryTracing {
+		shutdownTracing, tracingErr := tracing.NewTracerProvider(ctx, "keda-metrics-apiserver")
+		if tracingErr != nil {
+			err = tracingErr
+			logger.Error(err, "unable to set up opentelemetry tracing")
+			return
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				logger.Error(err, "error shutting down opentelemetry tracing")
+			}
+		}()
+	}
+
 	kedaProvider, stopCh, err := cmd.makeProvider(ctx)
 	if err != nil {
 		logger.Error(err, "making provider")
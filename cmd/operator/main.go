@@ -17,8 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
+	"path"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -30,11 +32,13 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -42,13 +46,24 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	eventingcontrollers "github.com/kedacore/keda/v2/controllers/eventing"
 	kedacontrollers "github.com/kedacore/keda/v2/controllers/keda"
+	"github.com/kedacore/keda/v2/pkg/audit"
 	"github.com/kedacore/keda/v2/pkg/certificates"
 	"github.com/kedacore/keda/v2/pkg/eventemitter"
+	"github.com/kedacore/keda/v2/pkg/eventpolicy"
+	"github.com/kedacore/keda/v2/pkg/eventrecorder"
+	"github.com/kedacore/keda/v2/pkg/githubwebhook"
 	"github.com/kedacore/keda/v2/pkg/k8s"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
+	"github.com/kedacore/keda/v2/pkg/metricshistory"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
+	"github.com/kedacore/keda/v2/pkg/notification"
+	"github.com/kedacore/keda/v2/pkg/otlpreceiver"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/concurrency"
+	"github.com/kedacore/keda/v2/pkg/sharding"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
+	"github.com/kedacore/keda/v2/pkg/webhookreceiver"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -68,6 +83,7 @@ func init() {
 func main() {
 	var enablePrometheusMetrics bool
 	var enableOpenTelemetryMetrics bool
+	var enableOpenTelemetryTracing bool
 	var metricsAddr string
 	var probeAddr string
 	var metricsServiceAddr string
@@ -85,9 +101,25 @@ func main() {
 	var k8sClusterDomain string
 	var enableCertRotation bool
 	var validatingWebhookName string
+	var mutatingWebhookName string
 	var caDirs []string
+	var auditLogOptions audit.Options
+	var notificationConfigFile string
+	var githubWebhookOptions githubwebhook.Options
+	var otlpReceiverOptions otlpreceiver.Options
+	var webhookReceiverOptions webhookreceiver.Options
+	var shardingOptions sharding.Options
+	var receiverPeersService string
+	var eventPolicyConfigFile string
+	var scalersDebugAddr string
+	var metricsHistoryOptions metricshistory.Options
+	var triggerConcurrencyOptions concurrency.Options
+	var scalersCacheMaxTriggers int
+	var pollingScheduleOptions scaling.PollingScheduleOptions
+	var lazyScalersMinPollingInterval time.Duration
 	pflag.BoolVar(&enablePrometheusMetrics, "enable-prometheus-metrics", true, "Enable the prometheus metric of keda-operator.")
 	pflag.BoolVar(&enableOpenTelemetryMetrics, "enable-opentelemetry-metrics", false, "Enable the opentelemetry metric of keda-operator.")
+	pflag.BoolVar(&enableOpenTelemetryTracing, "enable-opentelemetry-tracing", false, "Enable the opentelemetry tracing of keda-operator.")
 	pflag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the prometheus metric endpoint binds to.")
 	pflag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	pflag.StringVar(&metricsServiceAddr, "metrics-service-bind-address", ":9666", "The address the gRPRC Metrics Service endpoint binds to.")
@@ -107,7 +139,42 @@ func main() {
 	pflag.StringVar(&k8sClusterDomain, "k8s-cluster-domain", "cluster.local", "Kubernetes cluster domain. Defaults to cluster.local")
 	pflag.BoolVar(&enableCertRotation, "enable-cert-rotation", false, "enable automatic generation and rotation of TLS certificates/keys")
 	pflag.StringVar(&validatingWebhookName, "validating-webhook-name", "keda-admission", "ValidatingWebhookConfiguration name. Defaults to keda-admission")
+	pflag.StringVar(&mutatingWebhookName, "mutating-webhook-name", "keda-admission", "MutatingWebhookConfiguration name. Defaults to keda-admission")
 	pflag.StringArrayVar(&caDirs, "ca-dir", []string{"/custom/ca"}, "Directory with CA certificates for scalers to authenticate TLS connections. Can be specified multiple times. Defaults to /custom/ca")
+	pflag.StringVar(&auditLogOptions.Sink, "audit-log-sink", "", "Sink of the scaling decisions audit log: stdout, file or http. Defaults to disabled")
+	pflag.StringVar(&auditLogOptions.FilePath, "audit-log-file-path", "/var/log/keda/audit.log", "File the audit log is written to with the file sink")
+	pflag.IntVar(&auditLogOptions.MaxSize, "audit-log-max-size", 100, "Size in megabytes of the audit log file before it is rotated")
+	pflag.IntVar(&auditLogOptions.MaxAge, "audit-log-max-age", 7, "Number of days the rotated audit log files are retained, 0 retains them regardless of their age")
+	pflag.IntVar(&auditLogOptions.MaxBackups, "audit-log-max-backups", 5, "Number of the rotated audit log files retained, 0 retains all of them")
+	pflag.BoolVar(&auditLogOptions.Compress, "audit-log-compress", false, "Compress the rotated audit log files")
+	pflag.StringVar(&auditLogOptions.HTTPURL, "audit-log-http-url", "", "Endpoint the audit log is posted to with the http sink")
+	pflag.DurationVar(&auditLogOptions.HTTPTimeout, "audit-log-http-timeout", 3*time.Second, "Timeout of each post to the audit log endpoint")
+	pflag.StringVar(&scalersDebugAddr, "scalers-debug-bind-address", "", "The address the state of the scalers cache is served on /debug/scalers over HTTPS with the certificate of the cert-dir, callers need to be authorized to get this non-resource URL. Defaults to disabled")
+	pflag.IntVar(&metricsHistoryOptions.Size, "metrics-history-size", 0, "Number of the last samples of each trigger metric retained for the lastKnownGood fallback and the scalers debug endpoint. Defaults to disabled")
+	pflag.StringVar(&metricsHistoryOptions.ConfigMapName, "metrics-history-configmap", "", "Prefix of the ConfigMaps in the namespace of the operator the metrics history is persisted to, one for each ScaledObject, so it survives restarts. Defaults to in memory only")
+	pflag.DurationVar(&metricsHistoryOptions.PersistInterval, "metrics-history-persist-interval", time.Minute, "How often the metrics history is persisted to its ConfigMaps")
+	pflag.StringVar(&eventPolicyConfigFile, "event-policy-config-file", "", "Configuration file of the deduplication and rate limiting of the Kubernetes events. Defaults to limiting the repeated scaler and check failures")
+	pflag.IntVar(&shardingOptions.Shards, "shards", 0, "Number of shards the ScaledObjects and ScaledJobs are spread across, each replica of the operator claims a share of them. Defaults to disabled, only the leader reconciles them")
+	pflag.DurationVar(&shardingOptions.LeaseDuration, "shard-lease-duration", 15*time.Second, "How long a shard is held by a replica once it renewed its lease")
+	pflag.DurationVar(&shardingOptions.RenewPeriod, "shard-renew-period", 5*time.Second, "How often the replicas renew the leases of their shards and balance them")
+	pflag.IntVar(&triggerConcurrencyOptions.Workers, "trigger-evaluation-workers", 0, "Number of triggers evaluated concurrently across all the ScaledObjects and ScaledJobs. Defaults to unbounded")
+	pflag.IntVar(&scalersCacheMaxTriggers, "scalers-cache-max-triggers", 0, "Number of triggers whose scalers are kept in the scalers cache, the scalers of the least recently used ScaledObjects and ScaledJobs are evicted and built again on their next use once it's exceeded. Defaults to unbounded")
+	pflag.BoolVar(&pollingScheduleOptions.Spread, "polling-spread", false, "Spread the polling ticks of the ScaledObjects and ScaledJobs over their pollingInterval, so the ones started at once don't keep polling at once")
+	pflag.BoolVar(&pollingScheduleOptions.SpreadPerHost, "polling-spread-per-host", false, "Spread the polling ticks evenly among the ScaledObjects and ScaledJobs of the same backend host, read from the metadata of their triggers")
+	pflag.Float64Var(&pollingScheduleOptions.Jitter, "polling-jitter", 0, "Maximum delay or advance of each polling tick, as a fraction of the pollingInterval between 0 and 0.5. Defaults to no jitter")
+	pflag.DurationVar(&lazyScalersMinPollingInterval, "lazy-scalers-min-polling-interval", 0, "Minimum pollingInterval of the inactive ScaledObjects and ScaledJobs whose scalers are built only for their evaluations and closed afterwards, e.g. 5m. Defaults to disabled")
+	pflag.StringToIntVar(&triggerConcurrencyOptions.TriggerTypeLimits, "trigger-type-concurrency", nil, "Number of triggers of a type evaluated concurrently, as type=limit pairs, e.g. prometheus=10,kafka=5. Defaults to unbounded")
+	pflag.StringVar(&githubWebhookOptions.BindAddress, "github-webhook-bind-address", "", "The address the receiver of the GitHub webhook deliveries for the github-webhook scaler binds to, the deliveries are validated with the secret in KEDA_GITHUB_WEBHOOK_SECRET. Defaults to disabled")
+	pflag.StringVar(&receiverPeersService, "receiver-peers-service", "", "The headless Service resolving to the replicas of the operator, the deliveries, exports and pushes received by the GitHub webhook, OTLP and webhook receivers are forwarded to the other replicas so the one evaluating the scalers has them. Defaults to disabled")
+	pflag.StringVar(&otlpReceiverOptions.BindAddress, "otlp-receiver-bind-address", "", "The address the OTLP/gRPC receiver of the metrics pushed for the otel scaler binds to, it serves the certificate of the cert-dir. Defaults to disabled")
+	pflag.StringVar(&otlpReceiverOptions.TokenSecret, "otlp-receiver-token-secret", "keda-otlp-receiver", "The name of the Secret of each namespace whose token key holds the bearer token the exports of the series of the namespace to the OTLP receiver are authenticated with, the namespace is set by the x-keda-namespace metadata of the exports")
+	pflag.DurationVar(&otlpReceiverOptions.SeriesTTL, "otlp-receiver-series-ttl", 5*time.Minute, "How long a series pushed to the OTLP receiver is kept since its last data point")
+	pflag.IntVar(&otlpReceiverOptions.MaxSeries, "otlp-receiver-max-series", 10000, "Number of series kept by the OTLP receiver, the data points of the new series past it are rejected. 0 keeps all of them")
+	pflag.StringVar(&webhookReceiverOptions.BindAddress, "webhook-receiver-bind-address", "", "The address the HTTPS receiver of the metrics pushed for the webhook-push scaler binds to, it serves the certificate of the cert-dir. Defaults to disabled")
+	pflag.StringVar(&webhookReceiverOptions.TokenSecret, "webhook-receiver-token-secret", "keda-webhook-receiver", "The name of the Secret of each namespace whose token key holds the bearer token the pushes of the metrics of the namespace to the webhook receiver are authenticated with")
+	pflag.DurationVar(&webhookReceiverOptions.ValueTTL, "webhook-receiver-value-ttl", 5*time.Minute, "How long the value of a metric pushed to the webhook receiver is kept since it was pushed")
+	pflag.IntVar(&webhookReceiverOptions.MaxMetrics, "webhook-receiver-max-metrics", 10000, "Number of metrics kept by the webhook receiver, the pushes of the new metrics past it are rejected. 0 keeps all of them")
+	pflag.StringVar(&notificationConfigFile, "notification-config-file", "", "Configuration file of the notifications sent on persistent trigger errors, fallback and maxReplicaCount. Defaults to disabled")
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
@@ -144,11 +211,81 @@ func main() {
 	cfg.Burst = adapterClientRequestBurst
 	cfg.DisableCompression = disableCompression
 
+	kubeClientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		setupLog.Error(err, "Unable to create kube clientset")
+		os.Exit(1)
+	}
+
 	if !enablePrometheusMetrics {
 		metricsAddr = "0"
 	}
 	metricscollector.NewMetricsCollectors(enablePrometheusMetrics, enableOpenTelemetryMetrics)
 
+	shutdownTracing := func(context.Context) error { return nil }
+	if enableOpenTelemetryTracing {
+		shutdownTracing, err = tracing.NewTracerProvider(ctx, "keda-operator")
+		if err != nil {
+			setupLog.Error(err, "unable to set up opentelemetry tracing")
+			os.Exit(1)
+		}
+	}
+
+	shutdownAuditLog := func() error { return nil }
+	if auditLogOptions.Sink != "" {
+		shutdownAuditLog, err = audit.NewAuditLog(auditLogOptions)
+		if err != nil {
+			setupLog.Error(err, "unable to set up the audit log")
+			os.Exit(1)
+		}
+	}
+
+	shutdownNotifications := func() error { return nil }
+	if notificationConfigFile != "" {
+		shutdownNotifications, err = notification.NewNotifier(notificationConfigFile)
+		if err != nil {
+			setupLog.Error(err, "unable to set up the notifications")
+			os.Exit(1)
+		}
+	}
+
+	shutdownGitHubWebhook := func() error { return nil }
+	if githubWebhookOptions.BindAddress != "" {
+		githubWebhookOptions.Secret = os.Getenv("KEDA_GITHUB_WEBHOOK_SECRET")
+		githubWebhookOptions.PeersService = receiverPeersService
+		shutdownGitHubWebhook, err = githubwebhook.NewReceiver(githubWebhookOptions)
+		if err != nil {
+			setupLog.Error(err, "unable to set up the github webhook receiver")
+			os.Exit(1)
+		}
+	}
+
+	shutdownOTLPReceiver := func() error { return nil }
+	if otlpReceiverOptions.BindAddress != "" {
+		otlpReceiverOptions.KubeClient = kubeClientset
+		otlpReceiverOptions.CertFile = path.Join(certDir, "tls.crt")
+		otlpReceiverOptions.KeyFile = path.Join(certDir, "tls.key")
+		otlpReceiverOptions.PeersService = receiverPeersService
+		shutdownOTLPReceiver, err = otlpreceiver.NewReceiver(otlpReceiverOptions)
+		if err != nil {
+			setupLog.Error(err, "unable to set up the otlp receiver")
+			os.Exit(1)
+		}
+	}
+
+	shutdownWebhookReceiver := func() error { return nil }
+	if webhookReceiverOptions.BindAddress != "" {
+		webhookReceiverOptions.KubeClient = kubeClientset
+		webhookReceiverOptions.CertFile = path.Join(certDir, "tls.crt")
+		webhookReceiverOptions.KeyFile = path.Join(certDir, "tls.key")
+		webhookReceiverOptions.PeersService = receiverPeersService
+		shutdownWebhookReceiver, err = webhookreceiver.NewReceiver(webhookReceiverOptions)
+		if err != nil {
+			setupLog.Error(err, "unable to set up the webhook receiver")
+			os.Exit(1)
+		}
+	}
+
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme: scheme,
 		Metrics: server.Options{
@@ -194,13 +331,21 @@ func main() {
 	}
 
 	globalHTTPTimeout := time.Duration(globalHTTPTimeoutMS) * time.Millisecond
-	eventRecorder := mgr.GetEventRecorderFor("keda-operator")
-
-	kubeClientset, err := kubernetes.NewForConfig(cfg)
+	eventPolicyConfig, err := eventpolicy.LoadConfig(eventPolicyConfigFile)
 	if err != nil {
-		setupLog.Error(err, "Unable to create kube clientset")
+		setupLog.Error(err, "unable to load the event policies")
 		os.Exit(1)
 	}
+
+	operatorEventRecorder := eventrecorder.NewRecorder(kubeClientset.EventsV1(), mgr.GetScheme(), "keda-operator")
+	scaleHandlerEventRecorder := eventrecorder.NewRecorder(kubeClientset.EventsV1(), mgr.GetScheme(), "scale-handler")
+	for _, recorder := range []*eventrecorder.Recorder{operatorEventRecorder, scaleHandlerEventRecorder} {
+		if err := mgr.Add(recorder); err != nil {
+			setupLog.Error(err, "unable to set up the event recorder")
+			os.Exit(1)
+		}
+	}
+	eventRecorder := eventpolicy.NewRecorder(operatorEventRecorder, eventPolicyConfig)
 	objectNamespace, err := kedautil.GetClusterObjectNamespace()
 	if err != nil {
 		setupLog.Error(err, "Unable to get cluster object namespace")
@@ -211,14 +356,85 @@ func main() {
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClientset, 1*time.Hour, kubeinformers.WithNamespace(objectNamespace))
 	secretInformer := kubeInformerFactory.Core().V1().Secrets()
 
+	shutdownMetricsHistory := func() error { return nil }
+	if metricsHistoryOptions.Size > 0 {
+		metricsHistoryOptions.Namespace = kedautil.GetPodNamespace()
+		shutdownMetricsHistory, err = metricshistory.NewMetricsHistory(metricsHistoryOptions, kubeClientset)
+		if err != nil {
+			setupLog.Error(err, "unable to set up the metrics history")
+			os.Exit(1)
+		}
+	}
+
 	scaleClient, kubeVersion, err := k8s.InitScaleClient(mgr)
 	if err != nil {
 		setupLog.Error(err, "unable to init scale client")
 		os.Exit(1)
 	}
 
-	scaledHandler := scaling.NewScaleHandler(mgr.GetClient(), scaleClient, mgr.GetScheme(), globalHTTPTimeout, eventRecorder, secretInformer.Lister())
+	// the ScaledObjects and ScaledJobs are reconciled by all the replicas when they are sharded,
+	// each replica reconciles the ones of its shards
+	var shardCoordinator *sharding.Coordinator
+	var shardedNeedLeaderElection *bool
+	if shardingOptions.Shards > 0 {
+		shardingOptions.Name = "keda-operator"
+		shardingOptions.Namespace = kedautil.GetPodNamespace()
+		shardingOptions.Identity, err = os.Hostname()
+		if err != nil {
+			setupLog.Error(err, "unable to get the identity of the replica for the sharding")
+			os.Exit(1)
+		}
+		shardCoordinator, err = sharding.NewCoordinator(kubeClientset.CoordinationV1(), shardingOptions)
+		if err != nil {
+			setupLog.Error(err, "unable to set up the sharding")
+			os.Exit(1)
+		}
+		if err := mgr.Add(shardCoordinator); err != nil {
+			setupLog.Error(err, "unable to set up the sharding")
+			os.Exit(1)
+		}
+		shardedNeedLeaderElection = ptr.To(false)
+	}
+
+	if err := concurrency.Configure(triggerConcurrencyOptions); err != nil {
+		setupLog.Error(err, "invalid trigger evaluation concurrency")
+		os.Exit(1)
+	}
+	if err := scaling.ConfigureScalersCache(scalersCacheMaxTriggers); err != nil {
+		setupLog.Error(err, "invalid scalers cache size")
+		os.Exit(1)
+	}
+	if err := scaling.ConfigurePollingSchedule(pollingScheduleOptions); err != nil {
+		setupLog.Error(err, "invalid polling schedule")
+		os.Exit(1)
+	}
+	if err := scaling.ConfigureLazyScalers(lazyScalersMinPollingInterval); err != nil {
+		setupLog.Error(err, "invalid lazy scalers")
+		os.Exit(1)
+	}
+
 	eventEmitter := eventemitter.NewEventEmitter(mgr.GetClient(), eventRecorder, k8sClusterName, secretInformer.Lister())
+	scaledHandler := scaling.NewScaleHandler(mgr.GetClient(), scaleClient, mgr.GetScheme(), globalHTTPTimeout, eventRecorder, secretInformer.Lister(), eventEmitter, mgr.GetCache())
+
+	if scalersDebugAddr != "" {
+		// the scalers cache reveals the configuration of the triggers, so the callers are
+		// authenticated and authorized against the API server
+		authFilter, err := filters.WithAuthenticationAndAuthorization(cfg, mgr.GetHTTPClient())
+		if err != nil {
+			setupLog.Error(err, "unable to set up the authentication of the scalers debug endpoint")
+			os.Exit(1)
+		}
+		debugHandler, err := authFilter(setupLog, scaling.NewScalersDebugHandler(scaledHandler))
+		if err != nil {
+			setupLog.Error(err, "unable to set up the authentication of the scalers debug endpoint")
+			os.Exit(1)
+		}
+		debugCertificate := kedautil.NewServingCertificate(path.Join(certDir, "tls.crt"), path.Join(certDir, "tls.key"))
+		if err := mgr.Add(scaling.NewScalersDebugServer(scalersDebugAddr, debugCertificate.ServerConfig(), debugHandler)); err != nil {
+			setupLog.Error(err, "unable to set up the scalers debug endpoint")
+			os.Exit(1)
+		}
+	}
 
 	if err = (&kedacontrollers.ScaledObjectReconciler{
 		Client:       mgr.GetClient(),
@@ -226,8 +442,10 @@ func main() {
 		ScaleClient:  scaleClient,
 		ScaleHandler: scaledHandler,
 		EventEmitter: eventEmitter,
+		Sharding:     shardCoordinator,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: scaledObjectMaxReconciles,
+		NeedLeaderElection:      shardedNeedLeaderElection,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledObject")
 		os.Exit(1)
@@ -237,10 +455,13 @@ func main() {
 		Scheme:            mgr.GetScheme(),
 		GlobalHTTPTimeout: globalHTTPTimeout,
 		EventEmitter:      eventEmitter,
+		EventRecorder:     eventpolicy.NewRecorder(scaleHandlerEventRecorder, eventPolicyConfig),
 		SecretsLister:     secretInformer.Lister(),
 		SecretsSynced:     secretInformer.Informer().HasSynced,
+		Sharding:          shardCoordinator,
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: scaledJobMaxReconciles,
+		NeedLeaderElection:      shardedNeedLeaderElection,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledJob")
 		os.Exit(1)
@@ -252,6 +473,13 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "TriggerAuthentication")
 		os.Exit(1)
 	}
+	if err = (&kedacontrollers.TriggerEvaluationReconciler{
+		Client:       mgr.GetClient(),
+		ScaleHandler: scaledHandler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TriggerEvaluation")
+		os.Exit(1)
+	}
 	if err = (&kedacontrollers.ClusterTriggerAuthenticationReconciler{
 		Client:       mgr.GetClient(),
 		EventHandler: eventEmitter,
@@ -296,6 +524,7 @@ func main() {
 			CAName:                "KEDA",
 			CAOrganization:        "KEDAORG",
 			ValidatingWebhookName: validatingWebhookName,
+			MutatingWebhookName:   mutatingWebhookName,
 			APIServiceName:        "v1beta1.external.metrics.k8s.io",
 			Logger:                setupLog,
 			Ready:                 certReady,
@@ -310,7 +539,8 @@ func main() {
 
 	kedautil.SetCACertDirs(caDirs)
 
-	grpcServer := metricsservice.NewGrpcServer(&scaledHandler, metricsServiceAddr, certDir, certReady)
+	// the metrics of all the ScaledObjects are served by each replica when they are sharded
+	grpcServer := metricsservice.NewGrpcServer(&scaledHandler, metricsServiceAddr, certDir, certReady, shardCoordinator)
 	if err := mgr.Add(&grpcServer); err != nil {
 		setupLog.Error(err, "unable to set up Metrics Service gRPC server")
 		os.Exit(1)
@@ -329,4 +559,26 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "error shutting down opentelemetry tracing")
+	}
+	if err := shutdownAuditLog(); err != nil {
+		setupLog.Error(err, "error shutting down the audit log")
+	}
+	if err := shutdownNotifications(); err != nil {
+		setupLog.Error(err, "error shutting down the notifications")
+	}
+	if err := shutdownMetricsHistory(); err != nil {
+		setupLog.Error(err, "error shutting down the metrics history")
+	}
+	if err := shutdownGitHubWebhook(); err != nil {
+		setupLog.Error(err, "error shutting down the github webhook receiver")
+	}
+	if err := shutdownOTLPReceiver(); err != nil {
+		setupLog.Error(err, "error shutting down the otlp receiver")
+	}
+	if err := shutdownWebhookReceiver(); err != nil {
+		setupLog.Error(err, "error shutting down the webhook receiver")
+	}
 }
@@ -37,6 +37,7 @@ import (
 	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/k8s"
+	"github.com/kedacore/keda/v2/pkg/scalers"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 	//+kubebuilder:scaffold:imports
 )
@@ -62,6 +63,8 @@ func main() {
 	var webhooksClientRequestBurst int
 	var certDir string
 	var webhooksPort int
+	var validationMode string
+	var validationModeNamespaces map[string]string
 
 	pflag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	pflag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -70,6 +73,8 @@ func main() {
 	pflag.IntVar(&webhooksClientRequestBurst, "kube-api-burst", 30, "Set the burst for throttling requests sent to the apiserver")
 	pflag.StringVar(&certDir, "cert-dir", "/certs", "Webhook certificates dir to use. Defaults to /certs")
 	pflag.IntVar(&webhooksPort, "port", 9443, "Port number to serve webhooks. Defaults to 9443")
+	pflag.StringVar(&validationMode, "validation-mode", string(kedav1alpha1.ValidationModeDeny), "How the objects failing the validation are handled: deny, or warn to admit them with warnings. Defaults to deny")
+	pflag.StringToStringVar(&validationModeNamespaces, "validation-mode-namespaces", nil, "Validation modes of the namespaces which don't follow the global one, e.g. team-a=warn,team-b=deny")
 
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
@@ -78,6 +83,15 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	namespaceModes := make(map[string]kedav1alpha1.ValidationMode, len(validationModeNamespaces))
+	for namespace, mode := range validationModeNamespaces {
+		namespaceModes[namespace] = kedav1alpha1.ValidationMode(mode)
+	}
+	if err := kedav1alpha1.SetValidationMode(kedav1alpha1.ValidationMode(validationMode), namespaceModes); err != nil {
+		setupLog.Error(err, "invalid validation mode")
+		os.Exit(1)
+	}
+
 	ctx := ctrl.SetupSignalHandler()
 
 	cfg := ctrl.GetConfigOrDie()
@@ -135,6 +149,8 @@ func main() {
 }
 
 func setupWebhook(mgr manager.Manager) {
+	kedav1alpha1.RegisterTriggerMetadataValidator(scalers.ValidateTriggerMetadata)
+
 	// setup webhooks
 	if err := (&kedav1alpha1.ScaledObject{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "ScaledObject")
@@ -301,16 +301,9 @@ func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context,
 			}
 			status.CompositeScalerName = compMetricName
 
-			// overwrite external metrics in returned array with composite metric ONLY (keep resource metrics)
-			finalHpaSpecs := []autoscalingv2.MetricSpec{}
-			// keep resource specs
-			for _, rm := range scaledObjectMetricSpecs {
-				if rm.Resource != nil {
-					finalHpaSpecs = append(finalHpaSpecs, rm)
-				}
-			}
-			finalHpaSpecs = append(finalHpaSpecs, compositeSpec)
-			scaledObjectMetricSpecs = finalHpaSpecs
+			// overwrite the metrics in returned array with composite metric ONLY, the cpu/memory scalers consumed
+			// by the formula expose external metrics too, the HPA would count them twice
+			scaledObjectMetricSpecs = []autoscalingv2.MetricSpec{compositeSpec}
 		}
 	}
 	err = kedastatus.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status)
@@ -31,10 +31,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
@@ -46,7 +48,9 @@ import (
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 	"github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -59,11 +63,15 @@ type ScaledJobReconciler struct {
 	Scheme            *runtime.Scheme
 	GlobalHTTPTimeout time.Duration
 	EventEmitter      eventemitter.EventHandler
+	// EventRecorder records the events of the scalers, defaults to the recorder of the manager
+	EventRecorder record.EventRecorder
 
 	scaledJobGenerations *sync.Map
 	scaleHandler         scaling.ScaleHandler
 	SecretsLister        corev1listers.SecretLister
 	SecretsSynced        cache.InformerSynced
+	// Sharding restricts the ScaledJobs reconciled to the shards of the replica, all of them are reconciled if nil
+	Sharding *sharding.Coordinator
 }
 
 type scaledJobMetricsData struct {
@@ -83,23 +91,40 @@ func init() {
 
 // SetupWithManager initializes the ScaledJobReconciler instance and starts a new controller managed by the passed Manager instance.
 func (r *ScaledJobReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
-	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, mgr.GetEventRecorderFor("scale-handler"), r.SecretsLister)
+	if r.EventRecorder == nil {
+		r.EventRecorder = mgr.GetEventRecorderFor("scale-handler")
+	}
+	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, r.EventRecorder, r.SecretsLister, r.EventEmitter, mgr.GetCache())
 	r.scaledJobGenerations = &sync.Map{}
-	return ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		// Ignore updates to ScaledJob Status (in this case metadata.Generation does not change)
 		// so reconcile loop is not started on Status updates
 		For(&kedav1alpha1.ScaledJob{}, builder.WithPredicates(
+			kedacontrollerutil.ShardPredicate(r.Sharding),
 			predicate.Or(
 				kedacontrollerutil.PausedPredicate{},
 				predicate.GenerationChangedPredicate{},
-			))).
+			)))
+	if r.Sharding != nil {
+		controllerBuilder = controllerBuilder.WatchesRawSource(
+			kedacontrollerutil.ShardEventsSource(r.Client, r.Sharding, func() client.ObjectList { return &kedav1alpha1.ScaledJobList{} }),
+			&handler.EnqueueRequestForObject{})
+	}
+	return controllerBuilder.
 		WithEventFilter(util.IgnoreOtherNamespaces()).
 		Complete(r)
 }
 
 // Reconcile performs reconciliation on the identified ScaledJob resource based on the request information passed, returns the result and an error (if any).
 func (r *ScaledJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartSpan(ctx, "ScaledJob.Reconcile", tracing.ScalableObjectAttributes("ScaledJob", req.Namespace, req.Name)...)
+	result, err := r.reconcile(ctx, req)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+func (r *ScaledJobReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	reqLogger := log.FromContext(ctx)
 
 	// Fetch the ScaledJob instance
@@ -117,6 +142,11 @@ func (r *ScaledJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
+	if !r.Sharding.Owns(req.Namespace, req.Name) {
+		reqLogger.V(1).Info("ScaledJob belongs to a shard of another replica, stopping its scale loop")
+		return ctrl.Result{}, r.stopScaleLoop(ctx, reqLogger, scaledJob)
+	}
+
 	reqLogger.Info("Reconciling ScaledJob")
 
 	// Check if the ScaledJob instance is marked to be deleted, which is
@@ -191,6 +221,11 @@ func (r *ScaledJobReconciler) reconcileScaledJob(ctx context.Context, logger log
 		return "ScaledJob doesn't have correct triggers specification", err
 	}
 
+	err = kedav1alpha1.CheckScalingPolicies(ctx, r.Client, scaledJob)
+	if err != nil {
+		return "ScaledJob doesn't follow the ClusterScalingPolicies", err
+	}
+
 	// nosemgrep: trailofbits.go.invalid-usage-of-modified-variable.invalid-usage-of-modified-variable
 	msg, err := r.deletePreviousVersionScaleJobs(ctx, logger, scaledJob)
 	if err != nil {
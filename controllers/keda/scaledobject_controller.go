@@ -38,6 +38,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
@@ -50,11 +51,14 @@ import (
 	"github.com/kedacore/keda/v2/pkg/fallback"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/sharding"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 	"github.com/kedacore/keda/v2/pkg/util"
 )
 
 // +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects;scaledobjects/finalizers;scaledobjects/status,verbs="*"
+// +kubebuilder:rbac:groups=keda.sh,resources=clusterscalingpolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs="*"
 // +kubebuilder:rbac:groups="",resources=configmaps;configmaps/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs="*"
@@ -65,6 +69,9 @@ import (
 // +kubebuilder:rbac:groups="apps",resources=deployments;statefulsets,verbs=list;watch
 // +kubebuilder:rbac:groups="coordination.k8s.io",namespace=keda,resources=leases,verbs="*"
 // +kubebuilder:rbac:groups="",resources="limitranges",verbs=list;watch
+// +kubebuilder:rbac:groups="",resources="nodes",verbs=list;watch
+// +kubebuilder:rbac:groups="authentication.k8s.io",resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups="authorization.k8s.io",resources=subjectaccessreviews,verbs=create
 
 // ScaledObjectReconciler reconciles a ScaledObject object
 type ScaledObjectReconciler struct {
@@ -73,6 +80,8 @@ type ScaledObjectReconciler struct {
 	ScaleClient  scale.ScalesGetter
 	ScaleHandler scaling.ScaleHandler
 	EventEmitter eventemitter.EventHandler
+	// Sharding restricts the ScaledObjects reconciled to the shards of the replica, all of them are reconciled if nil
+	Sharding *sharding.Coordinator
 
 	restMapper               meta.RESTMapper
 	scaledObjectsGenerations *sync.Map
@@ -122,19 +131,26 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 		return fmt.Errorf("ScaledObjectReconciler.EventEmitter is not initialized")
 	}
 	// Start controller
-	return ctrl.NewControllerManagedBy(mgr).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		// predicate.GenerationChangedPredicate{} ignore updates to ScaledObject Status
 		// (in this case metadata.Generation does not change)
 		// so reconcile loop is not started on Status updates
 		For(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(
+			kedacontrollerutil.ShardPredicate(r.Sharding),
 			predicate.Or(
 				kedacontrollerutil.PausedPredicate{},
 				kedacontrollerutil.PausedReplicasPredicate{},
 				kedacontrollerutil.ScaleObjectReadyConditionPredicate{},
 				predicate.GenerationChangedPredicate{},
 			),
-		)).
+		))
+	if r.Sharding != nil {
+		controllerBuilder = controllerBuilder.WatchesRawSource(
+			kedacontrollerutil.ShardEventsSource(r.Client, r.Sharding, func() client.ObjectList { return &kedav1alpha1.ScaledObjectList{} }),
+			&handler.EnqueueRequestForObject{})
+	}
+	return controllerBuilder.
 		WithEventFilter(util.IgnoreOtherNamespaces()).
 		// Trigger a reconcile only when the HPA spec,label or annotation changes.
 		// Ignore updates to HPA status
@@ -149,6 +165,13 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 
 // Reconcile performs reconciliation on the identified ScaledObject resource based on the request information passed, returns the result and an error (if any).
 func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartSpan(ctx, "ScaledObject.Reconcile", tracing.ScalableObjectAttributes("ScaledObject", req.Namespace, req.Name)...)
+	result, err := r.reconcile(ctx, req)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+func (r *ScaledObjectReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	reqLogger := log.FromContext(ctx)
 	// Fetch the ScaledObject instance
 	scaledObject := &kedav1alpha1.ScaledObject{}
@@ -165,6 +188,11 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
+	if !r.Sharding.Owns(req.Namespace, req.Name) {
+		reqLogger.V(1).Info("ScaledObject belongs to a shard of another replica, stopping its scale loop")
+		return ctrl.Result{}, r.stopScaleLoop(ctx, reqLogger, scaledObject)
+	}
+
 	reqLogger.Info("Reconciling ScaledObject")
 
 	// Check if the ScaledObject instance is marked to be deleted, which is
@@ -282,10 +310,28 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		return "ScaledObject doesn't have correct triggers specification", err
 	}
 
-	// Create a new HPA or update existing one according to ScaledObject
-	newHPACreated, err := r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
+	err = kedav1alpha1.CheckScalingPolicies(ctx, r.Client, scaledObject)
+	if err != nil {
+		return "ScaledObject doesn't follow the ClusterScalingPolicies", err
+	}
+
+	err = kedav1alpha1.CheckDirectScalingValid(scaledObject)
 	if err != nil {
-		return "failed to ensure HPA is correctly created for ScaledObject", err
+		return "ScaledObject doesn't have correct directScaling specification", err
+	}
+
+	newHPACreated := false
+	if scaledObject.IsUsingDirectScaling() {
+		// The scale target is scaled by the scale loop itself, the HPA created before is removed
+		if deleted, err := r.ensureHPAForScaledObjectIsDeleted(ctx, logger, scaledObject); !deleted {
+			return "failed to delete HPA for ScaledObject scaled directly", err
+		}
+	} else {
+		// Create a new HPA or update existing one according to ScaledObject
+		newHPACreated, err = r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
+		if err != nil {
+			return "failed to ensure HPA is correctly created for ScaledObject", err
+		}
 	}
 	scaleObjectSpecChanged := false
 	if !newHPACreated {
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/util"
+)
+
+// triggerEvaluationTimeout bounds the building of the scaler and the queries of its metrics
+const triggerEvaluationTimeout = 30 * time.Second
+
+// TriggerEvaluationReconciler reconciles a TriggerEvaluation object
+type TriggerEvaluationReconciler struct {
+	client.Client
+	ScaleHandler scaling.ScaleHandler
+}
+
+// +kubebuilder:rbac:groups=keda.sh,resources=triggerevaluations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=keda.sh,resources=triggerevaluations/status,verbs=get;update;patch
+
+// Reconcile evaluates the trigger of the identified TriggerEvaluation once for each generation of its spec
+// and reports the outcome in its status, returns the result and an error (if any).
+func (r *TriggerEvaluationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	evaluation := &kedav1alpha1.TriggerEvaluation{}
+	err := r.Client.Get(ctx, req.NamespacedName, evaluation)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		reqLogger.Error(err, "Failed to get TriggerEvaluation")
+		return ctrl.Result{}, err
+	}
+	if evaluation.GetDeletionTimestamp() != nil || evaluation.Status.ObservedGeneration == evaluation.Generation {
+		return ctrl.Result{}, nil
+	}
+
+	evaluationCtx, cancel := context.WithTimeout(ctx, triggerEvaluationTimeout)
+	defer cancel()
+	status := r.ScaleHandler.EvaluateTrigger(evaluationCtx, evaluation)
+	if status.Error != "" {
+		reqLogger.V(1).Info("Trigger evaluation failed", "error", status.Error)
+	}
+
+	patch := client.MergeFrom(evaluation.DeepCopy())
+	evaluation.Status = status
+	if err := r.Client.Status().Patch(ctx, evaluation, patch); err != nil {
+		reqLogger.Error(err, "Failed to patch TriggerEvaluation status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TriggerEvaluationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kedav1alpha1.TriggerEvaluation{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WithEventFilter(util.IgnoreOtherNamespaces()).
+		Complete(r)
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"context"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/kedacore/keda/v2/pkg/sharding"
+)
+
+// ShardPredicate filters the events of the objects belonging to the shards of the other replicas
+func ShardPredicate(coordinator *sharding.Coordinator) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return coordinator.Owns(object.GetNamespace(), object.GetName())
+	})
+}
+
+// ShardEventsSource returns a source of the events of the objects of the shards the replica acquires or
+// releases, so they are reconciled again and their scale loops are started or stopped. The objects are
+// listed in the background so the coordinator isn't held up renewing its leases.
+func ShardEventsSource(c client.Client, coordinator *sharding.Coordinator, newList func() client.ObjectList) source.Source {
+	events := make(chan event.GenericEvent)
+	coordinator.Notify(func(shards []int) {
+		go func() {
+			list := newList()
+			if err := c.List(context.Background(), list); err != nil {
+				logf.Log.WithName("sharding").Error(err, "error listing the objects of the shards", "shards", shards)
+				return
+			}
+			_ = meta.EachListItem(list, func(item runtime.Object) error {
+				object := item.(client.Object)
+				if slices.Contains(shards, sharding.ShardOf(object.GetNamespace(), object.GetName(), coordinator.Shards())) {
+					events <- event.GenericEvent{Object: object}
+				}
+				return nil
+			})
+		}()
+	})
+	return &source.Channel{Source: events}
+}
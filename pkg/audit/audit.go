@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("audit_log")
+
+// Actions taken by KEDA on a scaling decision
+const (
+	ActionNone              = "none"
+	ActionPaused            = "paused"
+	ActionActivate          = "activate"
+	ActionDeactivate        = "deactivate"
+	ActionDelegateToHPA     = "delegateToHPA"
+	ActionFallback          = "fallback"
+	ActionScaleToMinReplica = "scaleToMinReplicas"
+	ActionScaleDirectly     = "scaleDirectly"
+	ActionTriggerError      = "triggerError"
+	ActionCreateJobs        = "createJobs"
+)
+
+const defaultQueueSize = 1024
+
+// TriggerInput is the outcome of a single trigger evaluated for a scaling decision
+type TriggerInput struct {
+	Name     string             `json:"name,omitempty"`
+	Type     string             `json:"type"`
+	Index    int                `json:"index"`
+	Metrics  map[string]float64 `json:"metrics,omitempty"`
+	IsActive bool               `json:"isActive"`
+	Error    string             `json:"error,omitempty"`
+}
+
+// Inputs are the values a scaling decision was computed from
+type Inputs struct {
+	Triggers      []TriggerInput `json:"triggers"`
+	Formula       string         `json:"formula,omitempty"`
+	FormulaResult *float64       `json:"formulaResult,omitempty"`
+}
+
+// Decision is a single record of the audit log, it describes why and how
+// a ScaledObject or a ScaledJob has been scaled
+type Decision struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Kind        string    `json:"kind"`
+	Namespace   string    `json:"namespace"`
+	Name        string    `json:"name"`
+	ScaleTarget string    `json:"scaleTarget,omitempty"`
+	Inputs
+	IsActive bool `json:"isActive"`
+	IsError  bool `json:"isError"`
+	// HPA is set when the replica count above the activation is left to the HPA
+	HPA string `json:"hpa,omitempty"`
+	// UsageRatio is the ratio of the resource metrics to their targets the replicas of the ScaledObjects
+	// scaled directly are computed from
+	UsageRatio      *float64 `json:"usageRatio,omitempty"`
+	MinReplicas     int64    `json:"minReplicas"`
+	MaxReplicas     int64    `json:"maxReplicas"`
+	CurrentReplicas int64    `json:"currentReplicas"`
+	DesiredReplicas int64    `json:"desiredReplicas"`
+	Action          string   `json:"action"`
+}
+
+// Sink is a destination of the audit log
+type Sink interface {
+	Write(decision *Decision) error
+	Close() error
+}
+
+var (
+	lock   sync.RWMutex
+	queue  chan *Decision
+	stopWg sync.WaitGroup
+)
+
+// NewAuditLog starts writing the recorded decisions to the sink configured by the options,
+// the decisions are queued and written in the background not to slow the scale loop down.
+// It returns a function flushing the queue and closing the sink on shutdown.
+func NewAuditLog(opts Options) (func() error, error) {
+	sink, err := NewSink(opts)
+	if err != nil {
+		return nil, err
+	}
+	return start(sink, defaultQueueSize), nil
+}
+
+func start(sink Sink, queueSize int) func() error {
+	lock.Lock()
+	defer lock.Unlock()
+	q := make(chan *Decision, queueSize)
+	queue = q
+
+	stopWg.Add(1)
+	go func() {
+		defer stopWg.Done()
+		for decision := range q {
+			if err := sink.Write(decision); err != nil {
+				log.Error(err, "error writing to the audit log", "namespace", decision.Namespace, "name", decision.Name)
+			}
+		}
+	}()
+
+	return func() error {
+		lock.Lock()
+		queue = nil
+		lock.Unlock()
+		close(q)
+		stopWg.Wait()
+		return sink.Close()
+	}
+}
+
+// Enabled returns whether the audit log has been set up for this component
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return queue != nil
+}
+
+// Record queues the decision to the audit log, the decision is dropped if the queue is full
+func Record(decision *Decision) {
+	lock.RLock()
+	defer lock.RUnlock()
+	if queue == nil {
+		return
+	}
+	if decision.Timestamp.IsZero() {
+		decision.Timestamp = time.Now().UTC()
+	}
+	select {
+	case queue <- decision:
+	default:
+		log.Error(fmt.Errorf("audit log queue is full"), "dropping scaling decision", "namespace", decision.Namespace, "name", decision.Name)
+	}
+}
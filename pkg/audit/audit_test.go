@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memorySink struct {
+	decisions []*Decision
+	closed    bool
+}
+
+func (s *memorySink) Write(decision *Decision) error {
+	s.decisions = append(s.decisions, decision)
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestRecord(t *testing.T) {
+	// nothing is recorded until the audit log is set up
+	Record(&Decision{Name: "ignored"})
+	assert.False(t, Enabled())
+
+	sink := &memorySink{}
+	stop := start(sink, 10)
+	assert.True(t, Enabled())
+
+	formulaResult := 12.5
+	Record(&Decision{
+		Kind:      "ScaledObject",
+		Namespace: "default",
+		Name:      "so",
+		Inputs: Inputs{
+			Triggers:      []TriggerInput{{Type: "kafka", Index: 0, Metrics: map[string]float64{"s0-kafka-topic": 25}, IsActive: true}},
+			Formula:       "s0 / 2",
+			FormulaResult: &formulaResult,
+		},
+		Action: ActionDelegateToHPA,
+	})
+	assert.NoError(t, stop())
+	assert.False(t, Enabled())
+
+	assert.True(t, sink.closed)
+	assert.Len(t, sink.decisions, 1)
+	assert.Equal(t, "so", sink.decisions[0].Name)
+	assert.False(t, sink.decisions[0].Timestamp.IsZero())
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewSink(Options{Sink: SinkFile, FilePath: path, MaxSize: 1, MaxAge: 1, MaxBackups: 1})
+	assert.NoError(t, err)
+
+	assert.NoError(t, sink.Write(&Decision{Name: "first", Action: ActionActivate, Inputs: Inputs{Triggers: []TriggerInput{{Type: "cron"}}}}))
+	assert.NoError(t, sink.Write(&Decision{Name: "second", Action: ActionDeactivate}))
+	assert.NoError(t, sink.Close())
+
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		names = append(names, record["name"].(string))
+	}
+	assert.Equal(t, []string{"first", "second"}, names)
+}
+
+func TestHTTPSink(t *testing.T) {
+	var received []Decision
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var decision Decision
+		if r.Header.Get("Content-Type") != "application/json" || json.NewDecoder(r.Body).Decode(&decision) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, decision)
+		if decision.Name == "rejected" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	sink, err := NewSink(Options{Sink: SinkHTTP, HTTPURL: server.URL})
+	assert.NoError(t, err)
+
+	assert.NoError(t, sink.Write(&Decision{Name: "so", Action: ActionFallback, DesiredReplicas: 3}))
+	assert.Error(t, sink.Write(&Decision{Name: "rejected"}))
+	assert.NoError(t, sink.Close())
+
+	assert.Len(t, received, 2)
+	assert.Equal(t, ActionFallback, received[0].Action)
+	assert.Equal(t, int64(3), received[0].DesiredReplicas)
+}
+
+func TestNewSink(t *testing.T) {
+	testCases := []struct {
+		name    string
+		opts    Options
+		isError bool
+	}{
+		{"stdout", Options{Sink: SinkStdout}, false},
+		{"file without path", Options{Sink: SinkFile}, true},
+		{"file with negative retention", Options{Sink: SinkFile, FilePath: "audit.log", MaxAge: -1}, true},
+		{"http without url", Options{Sink: SinkHTTP}, true},
+		{"unknown sink", Options{Sink: "kafka"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewSink(tc.opts)
+			if tc.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// Supported audit log sinks
+const (
+	SinkStdout = "stdout"
+	SinkFile   = "file"
+	SinkHTTP   = "http"
+)
+
+// Options configures the sink of the audit log
+type Options struct {
+	Sink string
+
+	// FilePath is the file the decisions are written to with the file sink
+	FilePath string
+	// MaxSize is the size in megabytes of the file before it is rotated
+	MaxSize int
+	// MaxAge is the number of days the rotated files are retained, 0 retains them regardless of their age
+	MaxAge int
+	// MaxBackups is the number of the rotated files retained, 0 retains all of them
+	MaxBackups int
+	// Compress gzips the rotated files
+	Compress bool
+
+	// HTTPURL is the endpoint the decisions are posted to with the http sink
+	HTTPURL string
+	// HTTPTimeout is the timeout of each post to the endpoint
+	HTTPTimeout time.Duration
+}
+
+// NewSink creates the audit log sink configured by the options
+func NewSink(opts Options) (Sink, error) {
+	switch opts.Sink {
+	case SinkStdout:
+		return newWriterSink(nopCloser{os.Stdout}), nil
+	case SinkFile:
+		if opts.FilePath == "" {
+			return nil, fmt.Errorf("the file path is required with the %s audit log sink", SinkFile)
+		}
+		if opts.MaxSize < 0 || opts.MaxAge < 0 || opts.MaxBackups < 0 {
+			return nil, fmt.Errorf("the audit log retention can't be negative")
+		}
+		return newWriterSink(&lumberjack.Logger{
+			Filename:   opts.FilePath,
+			MaxSize:    opts.MaxSize,
+			MaxAge:     opts.MaxAge,
+			MaxBackups: opts.MaxBackups,
+			Compress:   opts.Compress,
+		}), nil
+	case SinkHTTP:
+		if _, err := url.ParseRequestURI(opts.HTTPURL); err != nil {
+			return nil, fmt.Errorf("invalid audit log http url: %w", err)
+		}
+		return &httpSink{
+			url:    opts.HTTPURL,
+			client: kedautil.CreateHTTPClient(opts.HTTPTimeout, false),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported audit log sink %q, must be one of %s, %s or %s", opts.Sink, SinkStdout, SinkFile, SinkHTTP)
+	}
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+// writerSink writes each decision as a line of JSON
+type writerSink struct {
+	lock    sync.Mutex
+	writer  io.WriteCloser
+	encoder *json.Encoder
+}
+
+func newWriterSink(writer io.WriteCloser) *writerSink {
+	return &writerSink{writer: writer, encoder: json.NewEncoder(writer)}
+}
+
+func (s *writerSink) Write(decision *Decision) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.encoder.Encode(decision)
+}
+
+func (s *writerSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.writer.Close()
+}
+
+// httpSink posts each decision as JSON to the endpoint
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *httpSink) Write(decision *Decision) error {
+	body, err := json.Marshal(decision)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit log endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
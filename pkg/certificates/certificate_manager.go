@@ -35,6 +35,7 @@ import (
 
 // +kubebuilder:rbac:groups=apiregistration.k8s.io,resources=apiservices,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="",namespace=keda,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 
 type CertManager struct {
@@ -47,6 +48,7 @@ type CertManager struct {
 	CAName                string
 	CAOrganization        string
 	ValidatingWebhookName string
+	MutatingWebhookName   string
 	APIServiceName        string
 	Logger                logr.Logger
 	Ready                 chan struct{}
@@ -59,6 +61,10 @@ func (cm CertManager) AddCertificateRotation(ctx context.Context, mgr manager.Ma
 			Name: cm.ValidatingWebhookName,
 			Type: rotator.Validating,
 		},
+		{
+			Name: cm.MutatingWebhookName,
+			Type: rotator.Mutating,
+		},
 		{
 			Name: cm.APIServiceName,
 			Type: rotator.APIService,
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// ******************************* DESCRIPTION ****************************** \\
+// CloudEventKafkaHandler focuses on producing the CloudEventSource to a Kafka
+// topic, the events follow the binary content mode of the CloudEvents Kafka
+// protocol binding.
+// ************************************************************************** \\
+
+package eventemitter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventemitter/eventdata"
+	"github.com/kedacore/keda/v2/pkg/scalers/kafka"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	kafkaSASLPlaintext   = "plaintext"
+	kafkaSASLSCRAMSHA256 = "scram_sha256"
+	kafkaSASLSCRAMSHA512 = "scram_sha512"
+)
+
+type CloudEventKafkaHandler struct {
+	logger       logr.Logger
+	topic        string
+	producer     sarama.SyncProducer
+	clusterName  string
+	activeStatus metav1.ConditionStatus
+}
+
+func NewCloudEventKafkaHandler(clusterName string, spec *eventingv1alpha1.CloudEventKafka, authParams map[string]string, podIdentity kedav1alpha1.AuthPodIdentity, logger logr.Logger) (*CloudEventKafkaHandler, error) {
+	if len(spec.BootstrapServers) == 0 {
+		return nil, fmt.Errorf("bootstrapServers cannot be empty")
+	}
+	if spec.Topic == "" {
+		return nil, fmt.Errorf("topic cannot be empty")
+	}
+	if podIdentity.Provider != "" && podIdentity.Provider != kedav1alpha1.PodIdentityProviderNone {
+		return nil, fmt.Errorf("pod identity %s is not supported by the kafka destination", podIdentity.Provider)
+	}
+
+	config, err := getKafkaProducerConfig(authParams)
+	if err != nil {
+		return nil, err
+	}
+
+	producer, err := sarama.NewSyncProducer(spec.BootstrapServers, config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kafka producer: %w", err)
+	}
+
+	logger.Info("Create new cloudevents kafka handler with topic: " + spec.Topic)
+	return newCloudEventKafkaHandler(clusterName, spec.Topic, producer, logger), nil
+}
+
+func newCloudEventKafkaHandler(clusterName, topic string, producer sarama.SyncProducer, logger logr.Logger) *CloudEventKafkaHandler {
+	return &CloudEventKafkaHandler{
+		logger:       logger,
+		topic:        topic,
+		producer:     producer,
+		clusterName:  clusterName,
+		activeStatus: metav1.ConditionTrue,
+	}
+}
+
+// getKafkaProducerConfig returns the producer config with the sasl and tls settings
+// of the authentication, they are named after the ones of the kafka scaler
+func getKafkaProducerConfig(authParams map[string]string) (*sarama.Config, error) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Timeout = 10 * time.Second
+
+	sasl := strings.TrimSpace(authParams["sasl"])
+	switch sasl {
+	case "", "none":
+	case kafkaSASLPlaintext, kafkaSASLSCRAMSHA256, kafkaSASLSCRAMSHA512:
+		if authParams["username"] == "" || authParams["password"] == "" {
+			return nil, fmt.Errorf("username and password are required with sasl %s", sasl)
+		}
+		config.Net.SASL.Enable = true
+		config.Net.SASL.User = authParams["username"]
+		config.Net.SASL.Password = authParams["password"]
+		switch sasl {
+		case kafkaSASLPlaintext:
+			config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		case kafkaSASLSCRAMSHA256:
+			config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &kafka.XDGSCRAMClient{HashGeneratorFcn: kafka.SHA256} }
+			config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+		case kafkaSASLSCRAMSHA512:
+			config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &kafka.XDGSCRAMClient{HashGeneratorFcn: kafka.SHA512} }
+			config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+		}
+	default:
+		return nil, fmt.Errorf("sasl %s is not supported by the kafka destination", sasl)
+	}
+
+	switch authParams["tls"] {
+	case "", "disable":
+	case "enable":
+		tlsConfig, err := kedautil.NewTLSConfigWithPassword(authParams["cert"], authParams["key"], authParams["keyPassword"], authParams["ca"], authParams["unsafeSsl"] == "true")
+		if err != nil {
+			return nil, err
+		}
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = tlsConfig
+	default:
+		return nil, fmt.Errorf("tls must be enable or disable, got %s", authParams["tls"])
+	}
+
+	return config, nil
+}
+
+func (c *CloudEventKafkaHandler) SetActiveStatus(status metav1.ConditionStatus) {
+	c.activeStatus = status
+}
+
+func (c *CloudEventKafkaHandler) GetActiveStatus() metav1.ConditionStatus {
+	return c.activeStatus
+}
+
+func (c *CloudEventKafkaHandler) CloseHandler() {
+	c.logger.V(1).Info("Closing CloudEvent Kafka handler")
+	if err := c.producer.Close(); err != nil {
+		c.logger.Error(err, "Failed to close the kafka producer")
+	}
+}
+
+func (c *CloudEventKafkaHandler) EmitEvent(eventData eventdata.EventData, failureFunc func(eventData eventdata.EventData, err error)) {
+	event, err := newCloudEvent(c.clusterName, eventData)
+	if err != nil {
+		c.logger.Error(err, "Failed to create CloudEvent")
+		return
+	}
+
+	headers := []sarama.RecordHeader{
+		{Key: []byte("content-type"), Value: []byte(event.DataContentType())},
+		{Key: []byte("ce_specversion"), Value: []byte(event.SpecVersion())},
+		{Key: []byte("ce_id"), Value: []byte(event.ID())},
+		{Key: []byte("ce_source"), Value: []byte(event.Source())},
+		{Key: []byte("ce_type"), Value: []byte(event.Type())},
+		{Key: []byte("ce_subject"), Value: []byte(event.Subject())},
+		{Key: []byte("ce_time"), Value: []byte(event.Time().Format(time.RFC3339Nano))},
+	}
+
+	// the subject is used as the key, so the events of a scaled object keep their order
+	_, _, err = c.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   c.topic,
+		Key:     sarama.StringEncoder(event.Subject()),
+		Value:   sarama.ByteEncoder(event.Data()),
+		Headers: headers,
+	})
+	if err != nil {
+		c.logger.Error(err, "Failed to produce event to Kafka")
+		failureFunc(eventData, err)
+		return
+	}
+
+	c.logger.V(1).Info("Successfully produced event to Kafka")
+}
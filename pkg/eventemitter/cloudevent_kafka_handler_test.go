@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventemitter
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+
+	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventemitter/eventdata"
+)
+
+type parseKafkaProducerConfigTestData struct {
+	name       string
+	authParams map[string]string
+	isError    bool
+	mechanism  sarama.SASLMechanism
+}
+
+var parseKafkaProducerConfigTestDataset = []parseKafkaProducerConfigTestData{
+	{"no auth", map[string]string{}, false, ""},
+	{"plaintext", map[string]string{"sasl": "plaintext", "username": "user", "password": "pass"}, false, sarama.SASLTypePlaintext},
+	{"scram_sha512 with tls", map[string]string{"sasl": "scram_sha512", "username": "user", "password": "pass", "tls": "enable"}, false, sarama.SASLTypeSCRAMSHA512},
+	{"sasl without password", map[string]string{"sasl": "scram_sha256", "username": "user"}, true, ""},
+	{"unsupported sasl", map[string]string{"sasl": "gssapi"}, true, ""},
+	{"invalid tls", map[string]string{"tls": "yes"}, true, ""},
+}
+
+func TestGetKafkaProducerConfig(t *testing.T) {
+	for _, testData := range parseKafkaProducerConfigTestDataset {
+		t.Run(testData.name, func(t *testing.T) {
+			config, err := getKafkaProducerConfig(testData.authParams)
+			if testData.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testData.mechanism, config.Net.SASL.Mechanism)
+			assert.Equal(t, testData.authParams["tls"] == "enable", config.Net.TLS.Enable)
+		})
+	}
+}
+
+func TestNewCloudEventKafkaHandlerErrors(t *testing.T) {
+	_, err := NewCloudEventKafkaHandler("test", &eventingv1alpha1.CloudEventKafka{Topic: "events"}, nil, kedav1alpha1.AuthPodIdentity{}, logger)
+	assert.Error(t, err)
+
+	_, err = NewCloudEventKafkaHandler("test", &eventingv1alpha1.CloudEventKafka{BootstrapServers: []string{"localhost:9092"}}, nil, kedav1alpha1.AuthPodIdentity{}, logger)
+	assert.Error(t, err)
+
+	_, err = NewCloudEventKafkaHandler("test", &eventingv1alpha1.CloudEventKafka{BootstrapServers: []string{"localhost:9092"}, Topic: "events"}, nil, kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload}, logger)
+	assert.Error(t, err)
+}
+
+func TestCloudEventKafkaHandlerEmitEvent(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	h := newCloudEventKafkaHandler("test", "events", producer, logger)
+	eventData := eventdata.EventData{
+		Namespace:      "default",
+		ObjectName:     "so",
+		ObjectType:     "scaledobject",
+		CloudEventType: eventingv1alpha1.ScaledObjectReadyType,
+		Reason:         "ScaledObjectReady",
+		Message:        "ScaledObject is ready for scaling",
+		Time:           time.Now().UTC(),
+	}
+
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		headers := map[string]string{}
+		for _, header := range msg.Headers {
+			headers[string(header.Key)] = string(header.Value)
+		}
+		if headers["ce_type"] != string(eventingv1alpha1.ScaledObjectReadyType) || headers["ce_specversion"] != "1.0" || headers["ce_id"] == "" {
+			return errors.New("missing CloudEvent headers")
+		}
+		if headers["content-type"] != "application/json" {
+			return errors.New("unexpected content type " + headers["content-type"])
+		}
+		key, _ := msg.Key.Encode()
+		if string(key) != headers["ce_subject"] || string(key) != "/test/default/scaledobject/so" {
+			return errors.New("unexpected key " + string(key))
+		}
+		value, _ := msg.Value.Encode()
+		data := EmitData{}
+		if err := json.Unmarshal(value, &data); err != nil || data.Reason != "ScaledObjectReady" {
+			return errors.New("unexpected data " + string(value))
+		}
+		return nil
+	})
+	h.EmitEvent(eventData, func(eventData eventdata.EventData, err error) {
+		t.Errorf("unexpected failure %v", err)
+	})
+
+	// the failure is reported to be retried
+	producer.ExpectSendMessageAndFail(sarama.ErrNotEnoughReplicas)
+	failed := false
+	h.EmitEvent(eventData, func(eventData eventdata.EventData, err error) {
+		failed = true
+	})
+	assert.True(t, failed)
+
+	h.CloseHandler()
+}
@@ -0,0 +1,340 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// ******************************* DESCRIPTION ****************************** \\
+// CloudEventNATSHandler focuses on publishing the CloudEventSource to a NATS
+// subject, the events follow the structured content mode of the CloudEvents
+// NATS protocol binding. With JetStream the handler waits for the
+// acknowledgement of the stream, so the events lost by the stream are retried.
+// ************************************************************************** \\
+
+package eventemitter
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventemitter/eventdata"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const natsTimeout = 5 * time.Second
+
+type CloudEventNATSHandler struct {
+	logger       logr.Logger
+	address      string
+	subject      string
+	jetStream    bool
+	connectInfo  natsConnectInfo
+	tlsConfig    *tls.Config
+	clusterName  string
+	activeStatus metav1.ConditionStatus
+
+	// the connection is shared by the events of the handler which are emitted concurrently
+	lock   sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// natsConnectInfo is the CONNECT message of the NATS client protocol
+type natsConnectInfo struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name"`
+	Lang      string `json:"lang"`
+	Protocol  int    `json:"protocol"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+// natsServerInfo is the part of the INFO message of the server the handler relies on
+type natsServerInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+// natsPubAck is the acknowledgement of a message published to a JetStream stream
+type natsPubAck struct {
+	Stream string `json:"stream"`
+	Error  *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error,omitempty"`
+}
+
+func NewCloudEventNATSHandler(clusterName string, spec *eventingv1alpha1.CloudEventNATS, authParams map[string]string, podIdentity kedav1alpha1.AuthPodIdentity, logger logr.Logger) (*CloudEventNATSHandler, error) {
+	if spec.Subject == "" || strings.ContainsAny(spec.Subject, " \t\r\n") {
+		return nil, fmt.Errorf("subject cannot be empty or contain whitespaces")
+	}
+	if podIdentity.Provider != "" && podIdentity.Provider != kedav1alpha1.PodIdentityProviderNone {
+		return nil, fmt.Errorf("pod identity %s is not supported by the nats destination", podIdentity.Provider)
+	}
+
+	serverURL, err := url.Parse(spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nats url: %w", err)
+	}
+	if serverURL.Scheme != "nats" && serverURL.Scheme != "tls" {
+		return nil, fmt.Errorf("nats url scheme must be nats or tls, got %q", serverURL.Scheme)
+	}
+	address := serverURL.Host
+	if serverURL.Port() == "" {
+		address = net.JoinHostPort(serverURL.Hostname(), "4222")
+	}
+
+	connectInfo := natsConnectInfo{
+		Name:      "keda-operator",
+		Lang:      "go",
+		Protocol:  1,
+		User:      authParams["username"],
+		Pass:      authParams["password"],
+		AuthToken: authParams["token"],
+	}
+	if serverURL.User != nil && connectInfo.User == "" {
+		connectInfo.User = serverURL.User.Username()
+		connectInfo.Pass, _ = serverURL.User.Password()
+	}
+
+	var tlsConfig *tls.Config
+	if serverURL.Scheme == "tls" || authParams["tls"] == "enable" {
+		tlsConfig, err = kedautil.NewTLSConfigWithPassword(authParams["cert"], authParams["key"], authParams["keyPassword"], authParams["ca"], authParams["unsafeSsl"] == "true")
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ServerName = serverURL.Hostname()
+	}
+
+	logger.Info("Create new cloudevents nats handler with subject: " + spec.Subject)
+	return &CloudEventNATSHandler{
+		logger:       logger,
+		address:      address,
+		subject:      spec.Subject,
+		jetStream:    spec.JetStream,
+		connectInfo:  connectInfo,
+		tlsConfig:    tlsConfig,
+		clusterName:  clusterName,
+		activeStatus: metav1.ConditionTrue,
+	}, nil
+}
+
+func (c *CloudEventNATSHandler) SetActiveStatus(status metav1.ConditionStatus) {
+	c.activeStatus = status
+}
+
+func (c *CloudEventNATSHandler) GetActiveStatus() metav1.ConditionStatus {
+	return c.activeStatus
+}
+
+func (c *CloudEventNATSHandler) CloseHandler() {
+	c.logger.V(1).Info("Closing CloudEvent NATS handler")
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.closeConnection()
+}
+
+func (c *CloudEventNATSHandler) EmitEvent(eventData eventdata.EventData, failureFunc func(eventData eventdata.EventData, err error)) {
+	event, err := newCloudEvent(c.clusterName, eventData)
+	if err != nil {
+		c.logger.Error(err, "Failed to create CloudEvent")
+		return
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		c.logger.Error(err, "Failed to encode CloudEvent")
+		return
+	}
+
+	if err := c.publish(payload); err != nil {
+		c.logger.Error(err, "Failed to publish event to NATS")
+		failureFunc(eventData, err)
+		return
+	}
+
+	c.logger.V(1).Info("Successfully published event to NATS")
+}
+
+func (c *CloudEventNATSHandler) publish(payload []byte) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.conn == nil {
+		if err := c.connect(); err != nil {
+			c.closeConnection()
+			return err
+		}
+	}
+
+	var err error
+	if c.jetStream {
+		err = c.publishToStream(payload)
+	} else {
+		err = c.publishToSubject(payload)
+	}
+	if err != nil {
+		// the connection is established again with the next event
+		c.closeConnection()
+	}
+	return err
+}
+
+// publishToSubject publishes the payload and flushes the connection with a PING,
+// errors of the server (eg. permissions violation) are reported before the PONG
+func (c *CloudEventNATSHandler) publishToSubject(payload []byte) error {
+	if err := c.write(fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", c.subject, len(payload), payload)); err != nil {
+		return err
+	}
+	_, err := c.readUntil("PONG")
+	return err
+}
+
+// publishToStream publishes the payload with a reply subject and waits for the acknowledgement of the stream
+func (c *CloudEventNATSHandler) publishToStream(payload []byte) error {
+	inbox := "_INBOX." + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if err := c.write(fmt.Sprintf("SUB %s 1\r\nPUB %s %s %d\r\n%s\r\n", inbox, c.subject, inbox, len(payload), payload)); err != nil {
+		return err
+	}
+	defer func() {
+		_ = c.write("UNSUB 1\r\n")
+	}()
+
+	line, err := c.readUntil("MSG")
+	if err != nil {
+		return err
+	}
+	// MSG <subject> <sid> [reply-to] <#bytes>
+	fields := strings.Fields(line)
+	size, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil {
+		return fmt.Errorf("invalid nats message %q", line)
+	}
+	body := make([]byte, size+2)
+	if _, err := io.ReadFull(c.reader, body); err != nil {
+		return err
+	}
+
+	ack := natsPubAck{}
+	if err := json.Unmarshal(body[:size], &ack); err != nil {
+		return fmt.Errorf("invalid jetstream acknowledgement: %w", err)
+	}
+	if ack.Error != nil {
+		return fmt.Errorf("jetstream rejected the event: %s (%d)", ack.Error.Description, ack.Error.Code)
+	}
+	if ack.Stream == "" {
+		return fmt.Errorf("no jetstream stream acknowledged the event on subject %s", c.subject)
+	}
+	return nil
+}
+
+// connect dials the server, upgrades the connection to TLS when needed and authenticates the handler
+func (c *CloudEventNATSHandler) connect() error {
+	conn, err := net.DialTimeout("tcp", c.address, natsTimeout)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	info := natsServerInfo{}
+	if !strings.HasPrefix(line, "INFO ") || json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info) != nil {
+		return fmt.Errorf("unexpected nats server greeting %q", line)
+	}
+
+	if info.TLSRequired || c.tlsConfig != nil {
+		tlsConfig := c.tlsConfig
+		if tlsConfig == nil {
+			return fmt.Errorf("the nats server requires tls, set tls to enable in the authentication")
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		_ = tlsConn.SetDeadline(time.Now().Add(natsTimeout))
+		if err := tlsConn.Handshake(); err != nil {
+			return err
+		}
+		c.conn = tlsConn
+		c.reader = bufio.NewReader(tlsConn)
+	}
+
+	connectInfo, err := json.Marshal(c.connectInfo)
+	if err != nil {
+		return err
+	}
+	if err := c.write(fmt.Sprintf("CONNECT %s\r\nPING\r\n", connectInfo)); err != nil {
+		return err
+	}
+	_, err = c.readUntil("PONG")
+	return err
+}
+
+// readUntil reads the protocol messages until the expected one, answering the PINGs of the server
+func (c *CloudEventNATSHandler) readUntil(operation string) (string, error) {
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case strings.HasPrefix(line, operation):
+			return line, nil
+		case strings.HasPrefix(line, "-ERR"):
+			return "", fmt.Errorf("nats server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		case line == "PING":
+			if err := c.write("PONG\r\n"); err != nil {
+				return "", err
+			}
+		}
+	}
+}
+
+func (c *CloudEventNATSHandler) readLine() (string, error) {
+	_ = c.conn.SetReadDeadline(time.Now().Add(natsTimeout))
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *CloudEventNATSHandler) write(data string) error {
+	_ = c.conn.SetWriteDeadline(time.Now().Add(natsTimeout))
+	_, err := io.WriteString(c.conn, data)
+	return err
+}
+
+func (c *CloudEventNATSHandler) closeConnection() {
+	if c.conn != nil {
+		_ = c.conn.Close()
+	}
+	c.conn = nil
+	c.reader = nil
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventemitter
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventemitter/eventdata"
+)
+
+// fakeNATSServer implements the part of the NATS protocol used by the handler
+type fakeNATSServer struct {
+	listener  net.Listener
+	lock      sync.Mutex
+	connects  []string
+	published map[string][]string
+}
+
+func newFakeNATSServer(t *testing.T) *fakeNATSServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeNATSServer{listener: listener, published: map[string][]string{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *fakeNATSServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	_, _ = io.WriteString(conn, `INFO {"server_id":"fake","jetstream":true}`+"\r\n")
+	subscriptions := map[string]string{}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "CONNECT":
+			s.lock.Lock()
+			s.connects = append(s.connects, strings.TrimPrefix(strings.TrimSpace(line), "CONNECT "))
+			s.lock.Unlock()
+		case "PING":
+			_, _ = io.WriteString(conn, "PONG\r\n")
+		case "SUB":
+			subscriptions[fields[1]] = fields[2]
+		case "PUB":
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			subject := fields[1]
+			if subject == "denied" {
+				_, _ = io.WriteString(conn, "-ERR 'Permissions Violation for Publish to \"denied\"'\r\n")
+				continue
+			}
+			s.lock.Lock()
+			s.published[subject] = append(s.published[subject], string(payload[:size]))
+			s.lock.Unlock()
+			if len(fields) == 4 {
+				ack := `{"stream":"KEDA","seq":1}`
+				if subject == "unbound" {
+					ack = `{"error":{"code":503,"description":"no stream"}}`
+				}
+				_, _ = io.WriteString(conn, "PING\r\n")
+				_, _ = fmt.Fprintf(conn, "MSG %s %s %d\r\n%s\r\n", fields[2], subscriptions[fields[2]], len(ack), ack)
+			}
+		}
+	}
+}
+
+func (s *fakeNATSServer) url() string {
+	return "nats://" + s.listener.Addr().String()
+}
+
+func (s *fakeNATSServer) getPublished(subject string) []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.published[subject]
+}
+
+var testNATSEventData = eventdata.EventData{
+	Namespace:      "default",
+	ObjectName:     "so",
+	ObjectType:     "scaledobject",
+	CloudEventType: eventingv1alpha1.ScaledObjectTriggerActivatedType,
+	Reason:         "KEDATriggerActivated",
+	Message:        "trigger 0 (cron) is active",
+	Time:           time.Now().UTC(),
+}
+
+func TestNewCloudEventNATSHandlerErrors(t *testing.T) {
+	specs := []eventingv1alpha1.CloudEventNATS{
+		{URL: "nats://localhost:4222"},
+		{URL: "nats://localhost:4222", Subject: "keda events"},
+		{URL: "http://localhost:4222", Subject: "keda.events"},
+		{URL: "://", Subject: "keda.events"},
+	}
+	for _, spec := range specs {
+		_, err := NewCloudEventNATSHandler("test", &spec, nil, kedav1alpha1.AuthPodIdentity{}, logger)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestCloudEventNATSHandlerEmitEvent(t *testing.T) {
+	server := newFakeNATSServer(t)
+
+	h, err := NewCloudEventNATSHandler("test", &eventingv1alpha1.CloudEventNATS{URL: server.url(), Subject: "keda.events"}, map[string]string{"username": "keda", "password": "secret"}, kedav1alpha1.AuthPodIdentity{}, logger)
+	assert.NoError(t, err)
+	defer h.CloseHandler()
+
+	h.EmitEvent(testNATSEventData, func(eventData eventdata.EventData, err error) {
+		t.Errorf("unexpected failure %v", err)
+	})
+
+	published := server.getPublished("keda.events")
+	assert.Len(t, published, 1)
+	event := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(published[0]), &event))
+	assert.Equal(t, string(eventingv1alpha1.ScaledObjectTriggerActivatedType), event["type"])
+	assert.Equal(t, "/test/default/scaledobject/so", event["subject"])
+
+	assert.Len(t, server.connects, 1)
+	assert.Contains(t, server.connects[0], `"user":"keda","pass":"secret"`)
+
+	// the connection is reused
+	h.EmitEvent(testNATSEventData, func(eventData eventdata.EventData, err error) {
+		t.Errorf("unexpected failure %v", err)
+	})
+	assert.Len(t, server.getPublished("keda.events"), 2)
+	assert.Len(t, server.connects, 1)
+}
+
+func TestCloudEventNATSHandlerEmitEventErrors(t *testing.T) {
+	server := newFakeNATSServer(t)
+
+	testCases := []struct {
+		subject   string
+		jetStream bool
+	}{
+		{"denied", false},
+		{"unbound", true},
+	}
+	for _, tc := range testCases {
+		h, err := NewCloudEventNATSHandler("test", &eventingv1alpha1.CloudEventNATS{URL: server.url(), Subject: tc.subject, JetStream: tc.jetStream}, nil, kedav1alpha1.AuthPodIdentity{}, logger)
+		assert.NoError(t, err)
+
+		var emitErr error
+		h.EmitEvent(testNATSEventData, func(eventData eventdata.EventData, err error) {
+			emitErr = err
+		})
+		assert.Error(t, emitErr, tc.subject)
+		h.CloseHandler()
+	}
+}
+
+func TestCloudEventNATSHandlerEmitEventToStream(t *testing.T) {
+	server := newFakeNATSServer(t)
+
+	h, err := NewCloudEventNATSHandler("test", &eventingv1alpha1.CloudEventNATS{URL: server.url(), Subject: "keda.stream", JetStream: true}, map[string]string{"token": "s3cr3t"}, kedav1alpha1.AuthPodIdentity{}, logger)
+	assert.NoError(t, err)
+	defer h.CloseHandler()
+
+	h.EmitEvent(testNATSEventData, func(eventData eventdata.EventData, err error) {
+		t.Errorf("unexpected failure %v", err)
+	})
+	assert.Len(t, server.getPublished("keda.stream"), 1)
+	assert.Contains(t, server.connects[0], `"auth_token":"s3cr3t"`)
+}
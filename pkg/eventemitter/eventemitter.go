@@ -74,6 +74,7 @@ type EventHandler interface {
 	DeleteCloudEventSource(cloudEventSource eventingv1alpha1.CloudEventSourceInterface) error
 	HandleCloudEventSource(ctx context.Context, cloudEventSource eventingv1alpha1.CloudEventSourceInterface) error
 	Emit(object runtime.Object, namesapce string, eventType string, cloudeventType eventingv1alpha1.CloudEventType, reason string, message string)
+	EmitWithAnnotations(object runtime.Object, namespace string, eventType string, cloudeventType eventingv1alpha1.CloudEventType, reason string, message string, annotations map[string]string)
 }
 
 // EventDataHandler defines the behavior for different event handlers
@@ -93,6 +94,8 @@ type EmitData struct {
 const (
 	cloudEventHandlerTypeHTTP                = "http"
 	cloudEventHandlerTypeAzureEventGridTopic = "azureEventGridTopic"
+	cloudEventHandlerTypeKafka               = "kafka"
+	cloudEventHandlerTypeNATS                = "nats"
 )
 
 // NewEventEmitter creates a new EventEmitter
@@ -228,6 +231,36 @@ func (e *EventEmitter) createEventHandlers(ctx context.Context, cloudEventSource
 		return
 	}
 
+	if spec.Destination.Kafka != nil {
+		eventHandler, err := NewCloudEventKafkaHandler(clusterName, spec.Destination.Kafka, authParams, podIdentity, initializeLogger(cloudEventSourceI, "cloudevent_kafka"))
+		if err != nil {
+			e.log.Error(err, "create CloudEvent Kafka handler failed")
+			return
+		}
+
+		eventHandlerKey := newEventHandlerKey(key, cloudEventHandlerTypeKafka)
+		if h, ok := e.eventHandlersCache[eventHandlerKey]; ok {
+			h.CloseHandler()
+		}
+		e.eventHandlersCache[eventHandlerKey] = eventHandler
+		return
+	}
+
+	if spec.Destination.NATS != nil {
+		eventHandler, err := NewCloudEventNATSHandler(clusterName, spec.Destination.NATS, authParams, podIdentity, initializeLogger(cloudEventSourceI, "cloudevent_nats"))
+		if err != nil {
+			e.log.Error(err, "create CloudEvent NATS handler failed")
+			return
+		}
+
+		eventHandlerKey := newEventHandlerKey(key, cloudEventHandlerTypeNATS)
+		if h, ok := e.eventHandlersCache[eventHandlerKey]; ok {
+			h.CloseHandler()
+		}
+		e.eventHandlersCache[eventHandlerKey] = eventHandler
+		return
+	}
+
 	e.log.Info("No destionation is defined in CloudEventSource", "CloudEventSource", cloudEventSourceI.GetName())
 }
 
@@ -259,6 +292,22 @@ func (e *EventEmitter) clearEventHandlersCache(cloudEventSource eventingv1alpha1
 			delete(e.eventHandlersCache, eventHandlerKey)
 		}
 	}
+
+	if spec.Destination.Kafka != nil {
+		eventHandlerKey := newEventHandlerKey(key, cloudEventHandlerTypeKafka)
+		if eventHandler, found := e.eventHandlersCache[eventHandlerKey]; found {
+			eventHandler.CloseHandler()
+			delete(e.eventHandlersCache, eventHandlerKey)
+		}
+	}
+
+	if spec.Destination.NATS != nil {
+		eventHandlerKey := newEventHandlerKey(key, cloudEventHandlerTypeNATS)
+		if eventHandler, found := e.eventHandlersCache[eventHandlerKey]; found {
+			eventHandler.CloseHandler()
+			delete(e.eventHandlersCache, eventHandlerKey)
+		}
+	}
 }
 
 // checkIfEventHandlersExist will check if the event handlers that were created by passing CloudEventSource exist
@@ -329,7 +378,16 @@ func (e *EventEmitter) checkEventHandlers(ctx context.Context, cloudEventSourceI
 
 // Emit is emitting event to both local kubernetes and custom CloudEventSource handler. After emit event to local kubernetes, event will inqueue and waitng for handler's consuming.
 func (e *EventEmitter) Emit(object runtime.Object, namesapce string, eventType string, cloudeventType eventingv1alpha1.CloudEventType, reason, message string) {
-	e.recorder.Event(object, eventType, reason, message)
+	e.EmitWithAnnotations(object, namesapce, eventType, cloudeventType, reason, message, nil)
+}
+
+// EmitWithAnnotations is emitting event like Emit, the local kubernetes event carries the annotations describing it.
+func (e *EventEmitter) EmitWithAnnotations(object runtime.Object, namespace string, eventType string, cloudeventType eventingv1alpha1.CloudEventType, reason, message string, annotations map[string]string) {
+	if len(annotations) > 0 {
+		e.recorder.AnnotatedEventf(object, annotations, eventType, reason, "%s", message)
+	} else {
+		e.recorder.Event(object, eventType, reason, message)
+	}
 
 	e.eventHandlersCacheLock.RLock()
 	defer e.eventHandlersCacheLock.RUnlock()
@@ -340,7 +398,7 @@ func (e *EventEmitter) Emit(object runtime.Object, namesapce string, eventType s
 	objectName, _ := meta.NewAccessor().Name(object)
 	objectType, _ := meta.NewAccessor().Kind(object)
 	eventData := eventdata.EventData{
-		Namespace:      namesapce,
+		Namespace:      namespace,
 		CloudEventType: cloudeventType,
 		ObjectName:     strings.ToLower(objectName),
 		ObjectType:     strings.ToLower(objectType),
@@ -377,17 +435,17 @@ func (e *EventEmitter) emitEventByHandler(eventData eventdata.EventData) {
 	}
 
 	if eventData.HandlerKey == "" {
+		e.eventFilterCacheLock.RLock()
+		defer e.eventFilterCacheLock.RUnlock()
 		for key, handler := range e.eventHandlersCache {
-			e.eventFilterCacheLock.RLock()
-			defer e.eventFilterCacheLock.RUnlock()
-			// Filter Event
+			// Filter Event, the filter applies only to the handlers of its CloudEventSource
 			identifierKey := getPrefixIdentifierFromKey(key)
 
 			if e.eventFilterCache[identifierKey] != nil {
 				isFiltered := e.eventFilterCache[identifierKey].FilterEvent(eventData.CloudEventType)
 				if isFiltered {
 					e.log.V(1).Info("Event is filtered", "cloudeventType", eventData.CloudEventType, "event identifier", identifierKey)
-					return
+					continue
 				}
 			}
 			eventData.HandlerKey = key
@@ -169,3 +169,57 @@ func TestEventHandler_DirectCall(t *testing.T) {
 	eventEmitter.enqueueEventData(eventData)
 	wg.Wait()
 }
+
+func TestEventHandler_FilterPerCloudEventSource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+	mockClient := mock_client.NewMockClient(ctrl)
+
+	subscribedHandler := mock_eventemitter.NewMockEventDataHandler(ctrl)
+	filteredHandler := mock_eventemitter.NewMockEventDataHandler(ctrl)
+	subscribedSource := eventingv1alpha1.CloudEventSource{ObjectMeta: metav1.ObjectMeta{Name: "subscribed", Namespace: testNamespaceGlobal}}
+	filteredSource := eventingv1alpha1.CloudEventSource{ObjectMeta: metav1.ObjectMeta{Name: "filtered", Namespace: testNamespaceGlobal}}
+
+	caches := map[string]EventDataHandler{
+		newEventHandlerKey(subscribedSource.GenerateIdentifier(), cloudEventHandlerTypeHTTP): subscribedHandler,
+		newEventHandlerKey(filteredSource.GenerateIdentifier(), cloudEventHandlerTypeHTTP):   filteredHandler,
+	}
+	filtercaches := map[string]*EventFilter{
+		subscribedSource.GenerateIdentifier(): NewEventFilter([]eventingv1alpha1.CloudEventType{eventingv1alpha1.ScaledObjectTriggerActivatedType}, nil),
+		filteredSource.GenerateIdentifier():   NewEventFilter([]eventingv1alpha1.CloudEventType{eventingv1alpha1.ScaledObjectReadyType}, nil),
+	}
+
+	eventEmitter := EventEmitter{
+		client:                   mockClient,
+		recorder:                 recorder,
+		clusterName:              "cluster-name",
+		eventHandlersCache:       caches,
+		eventHandlersCacheLock:   &sync.RWMutex{},
+		eventFilterCache:         filtercaches,
+		eventFilterCacheLock:     &sync.RWMutex{},
+		eventLoopContexts:        &sync.Map{},
+		cloudEventProcessingChan: make(chan eventdata.EventData, 1),
+	}
+
+	eventData := eventdata.EventData{
+		Namespace:      testNamespaceGlobal,
+		ObjectName:     "so",
+		CloudEventType: eventingv1alpha1.ScaledObjectTriggerActivatedType,
+		Reason:         "KEDATriggerActivated",
+		Message:        "trigger 0 (cron) is active",
+		Time:           time.Now().UTC(),
+	}
+
+	// the event is filtered only for the CloudEventSource not subscribed to it
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	subscribedHandler.EXPECT().GetActiveStatus().Return(metav1.ConditionTrue).AnyTimes()
+	subscribedHandler.EXPECT().EmitEvent(gomock.Any(), gomock.Any()).Times(1).Do(func(arg0, arg1 interface{}) {
+		defer wg.Done()
+	})
+	filteredHandler.EXPECT().GetActiveStatus().Return(metav1.ConditionTrue).AnyTimes()
+	filteredHandler.EXPECT().EmitEvent(gomock.Any(), gomock.Any()).Times(0)
+
+	eventEmitter.emitEventByHandler(eventData)
+	wg.Wait()
+}
@@ -19,6 +19,9 @@ package eventemitter
 import (
 	"fmt"
 
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+
 	"github.com/kedacore/keda/v2/pkg/eventemitter/eventdata"
 	"github.com/kedacore/keda/v2/pkg/util"
 )
@@ -41,3 +44,18 @@ func generateCloudEventSubject(clusterName string, objectNamespace string, objec
 func generateCloudEventSubjectFromEventData(clusterName string, eventData eventdata.EventData) string {
 	return generateCloudEventSubject(clusterName, eventData.Namespace, eventData.ObjectType, eventData.ObjectName)
 }
+
+// newCloudEvent creates the CloudEvent of the eventData for the destinations without a CloudEvents client
+func newCloudEvent(clusterName string, eventData eventdata.EventData) (cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	event.SetID(uuid.NewString())
+	event.SetSource(generateCloudEventSource(clusterName))
+	event.SetSubject(generateCloudEventSubjectFromEventData(clusterName, eventData))
+	event.SetType(string(eventData.CloudEventType))
+	event.SetTime(eventData.Time)
+
+	if err := event.SetData(cloudevents.ApplicationJSON, EmitData{Reason: eventData.Reason, Message: eventData.Message}); err != nil {
+		return event, err
+	}
+	return event, nil
+}
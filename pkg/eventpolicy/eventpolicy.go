@@ -0,0 +1,268 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventpolicy deduplicates and rate limits the Kubernetes events emitted by KEDA,
+// the events failing on every polling interval would otherwise flood the API server
+package eventpolicy
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+var log = logf.Log.WithName("event_policy")
+
+const (
+	defaultDuplicateInterval = 5 * time.Minute
+	defaultBurst             = 5
+	defaultInterval          = 10 * time.Minute
+	// the recorder forgets the expired entries once it tracks this many
+	sweepSize = 1024
+)
+
+// Config is the configuration file of the event policies
+type Config struct {
+	Policies []Policy `json:"policies"`
+}
+
+// Policy limits the events of some reasons emitted for a single object
+type Policy struct {
+	// Reasons are the reasons of the events the policy applies to, a policy without reasons
+	// applies to the events of the reasons no other policy applies to
+	Reasons []string `json:"reasons,omitempty"`
+	// DuplicateInterval is how long an event with the same type, reason and message as an emitted one is suppressed
+	DuplicateInterval *metav1.Duration `json:"duplicateInterval,omitempty"`
+	// Burst is the number of events of a reason emitted for an object within the interval, 0 doesn't limit them
+	Burst int `json:"burst,omitempty"`
+	// Interval is the window the burst is counted in
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// DefaultConfig limits the events of the errors repeated on every polling interval
+func DefaultConfig() *Config {
+	return &Config{
+		Policies: []Policy{
+			{
+				Reasons: []string{
+					eventreason.KEDAScalerFailed,
+					eventreason.KEDAMetricSourceFailed,
+					eventreason.ScaledObjectCheckFailed,
+					eventreason.ScaledJobCheckFailed,
+				},
+				DuplicateInterval: &metav1.Duration{Duration: defaultDuplicateInterval},
+				Burst:             defaultBurst,
+				Interval:          &metav1.Duration{Duration: defaultInterval},
+			},
+		},
+	}
+}
+
+// LoadConfig reads and validates the configuration file of the event policies,
+// the default configuration is returned without a file
+func LoadConfig(path string) (*Config, error) {
+	if path == "" {
+		return DefaultConfig(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the event policy config: %w", err)
+	}
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("error parsing the event policy config: %w", err)
+	}
+
+	hasDefault := false
+	reasons := map[string]bool{}
+	for i, policy := range config.Policies {
+		if len(policy.Reasons) == 0 {
+			if hasDefault {
+				return nil, fmt.Errorf("only one event policy can apply to the other reasons")
+			}
+			hasDefault = true
+		}
+		for _, reason := range policy.Reasons {
+			if reasons[reason] {
+				return nil, fmt.Errorf("reason %s is in more than one event policy", reason)
+			}
+			reasons[reason] = true
+		}
+		if policy.DuplicateInterval != nil && policy.DuplicateInterval.Duration < 0 {
+			return nil, fmt.Errorf("the duplicateInterval of event policy %d can't be negative", i)
+		}
+		if policy.Burst < 0 {
+			return nil, fmt.Errorf("the burst of event policy %d can't be negative", i)
+		}
+		if policy.Burst > 0 && (policy.Interval == nil || policy.Interval.Duration <= 0) {
+			return nil, fmt.Errorf("event policy %d with a burst requires a positive interval", i)
+		}
+	}
+	return config, nil
+}
+
+// objectState is what has been emitted for a reason of an object
+type objectState struct {
+	// lastEmitted is when each message has been emitted last
+	lastEmitted map[string]time.Time
+	windowStart time.Time
+	emitted     int
+	suppressed  int
+}
+
+type recorder struct {
+	record.EventRecorder
+	policies map[string]*Policy
+	fallback *Policy
+	// retention is the longest interval of the policies
+	retention time.Duration
+	now       func() time.Time
+
+	lock    sync.Mutex
+	objects map[string]*objectState
+}
+
+// NewRecorder returns a recorder emitting the events through the recorder unless the policies suppress them,
+// the next emitted event of a reason for an object tells how many have been suppressed
+func NewRecorder(eventRecorder record.EventRecorder, config *Config) record.EventRecorder {
+	r := &recorder{
+		EventRecorder: eventRecorder,
+		policies:      map[string]*Policy{},
+		now:           time.Now,
+		objects:       map[string]*objectState{},
+	}
+	for i := range config.Policies {
+		policy := &config.Policies[i]
+		if len(policy.Reasons) == 0 {
+			r.fallback = policy
+		}
+		for _, reason := range policy.Reasons {
+			r.policies[reason] = policy
+		}
+		if policy.DuplicateInterval != nil && policy.DuplicateInterval.Duration > r.retention {
+			r.retention = policy.DuplicateInterval.Duration
+		}
+		if policy.Interval != nil && policy.Interval.Duration > r.retention {
+			r.retention = policy.Interval.Duration
+		}
+	}
+	return r
+}
+
+func (r *recorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if message, ok := r.allow(object, eventtype, reason, message); ok {
+		r.EventRecorder.Event(object, eventtype, reason, message)
+	}
+}
+
+func (r *recorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *recorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	if message, ok := r.allow(object, eventtype, reason, fmt.Sprintf(messageFmt, args...)); ok {
+		r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+	}
+}
+
+// allow returns whether the event is emitted and its message
+func (r *recorder) allow(object runtime.Object, eventtype, reason, message string) (string, bool) {
+	policy, found := r.policies[reason]
+	if !found {
+		policy = r.fallback
+	}
+	if policy == nil {
+		return message, true
+	}
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return message, true
+	}
+
+	now := r.now()
+	key := fmt.Sprintf("%T/%s/%s/%s", object, accessor.GetNamespace(), accessor.GetName(), reason)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	state, found := r.objects[key]
+	if !found {
+		if len(r.objects) >= sweepSize {
+			r.sweep(now)
+		}
+		state = &objectState{lastEmitted: map[string]time.Time{}, windowStart: now}
+		r.objects[key] = state
+	}
+
+	duplicate := eventtype + "/" + message
+	if policy.DuplicateInterval != nil {
+		for emitted, last := range state.lastEmitted {
+			if now.Sub(last) >= policy.DuplicateInterval.Duration {
+				delete(state.lastEmitted, emitted)
+			}
+		}
+		if last, found := state.lastEmitted[duplicate]; found && now.Sub(last) < policy.DuplicateInterval.Duration {
+			return r.suppress(state, key), false
+		}
+	}
+	if policy.Burst > 0 {
+		if now.Sub(state.windowStart) >= policy.Interval.Duration {
+			state.windowStart = now
+			state.emitted = 0
+		}
+		if state.emitted >= policy.Burst {
+			return r.suppress(state, key), false
+		}
+		state.emitted++
+	}
+
+	state.lastEmitted[duplicate] = now
+	if state.suppressed > 0 {
+		message = fmt.Sprintf("%s (%d similar events suppressed)", message, state.suppressed)
+		state.suppressed = 0
+	}
+	return message, true
+}
+
+func (r *recorder) suppress(state *objectState, key string) string {
+	state.suppressed++
+	log.V(1).Info("Suppressing event", "key", key, "suppressed", state.suppressed)
+	return ""
+}
+
+// sweep forgets the objects without an event emitted within the longest interval of the policies
+func (r *recorder) sweep(now time.Time) {
+	for key, state := range r.objects {
+		expired := now.Sub(state.windowStart) >= r.retention
+		for _, last := range state.lastEmitted {
+			if now.Sub(last) < r.retention {
+				expired = false
+				break
+			}
+		}
+		if expired {
+			delete(r.objects, key)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventpolicy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+func newTestRecorder(config *Config) (*recorder, *record.FakeRecorder, *time.Time) {
+	fake := record.NewFakeRecorder(100)
+	r := NewRecorder(fake, config).(*recorder)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+	return r, fake, &now
+}
+
+func events(fake *record.FakeRecorder) []string {
+	var result []string
+	for {
+		select {
+		case event := <-fake.Events:
+			result = append(result, event)
+		default:
+			return result
+		}
+	}
+}
+
+func TestDuplicateEvents(t *testing.T) {
+	r, fake, now := newTestRecorder(DefaultConfig())
+	so := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+	other := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"}}
+
+	r.Event(so, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "connection refused")
+	r.Event(so, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "connection refused")
+	r.Event(other, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "connection refused")
+	r.Eventf(so, corev1.EventTypeNormal, eventreason.KEDAScalersStarted, "Started scalers watch %d", 1)
+	r.Eventf(so, corev1.EventTypeNormal, eventreason.KEDAScalersStarted, "Started scalers watch %d", 1)
+	assert.Equal(t, []string{
+		"Warning KEDAScalerFailed connection refused",
+		"Warning KEDAScalerFailed connection refused",
+		"Normal KEDAScalersStarted Started scalers watch 1",
+		"Normal KEDAScalersStarted Started scalers watch 1",
+	}, events(fake))
+
+	*now = now.Add(defaultDuplicateInterval)
+	r.Event(so, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "connection refused")
+	assert.Equal(t, []string{"Warning KEDAScalerFailed connection refused (1 similar events suppressed)"}, events(fake))
+}
+
+func TestBurstEvents(t *testing.T) {
+	r, fake, now := newTestRecorder(&Config{Policies: []Policy{
+		{Burst: 2, Interval: &metav1.Duration{Duration: time.Minute}},
+	}})
+	so := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+
+	for _, message := range []string{"timeout 1", "timeout 2", "timeout 3", "timeout 4"} {
+		r.Event(so, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, message)
+	}
+	r.Event(so, corev1.EventTypeWarning, eventreason.KEDAMetricSourceFailed, "timeout")
+	assert.Equal(t, []string{
+		"Warning KEDAScalerFailed timeout 1",
+		"Warning KEDAScalerFailed timeout 2",
+		"Warning KEDAMetricSourceFailed timeout",
+	}, events(fake))
+
+	*now = now.Add(time.Minute)
+	r.Event(so, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "timeout 5")
+	assert.Equal(t, []string{"Warning KEDAScalerFailed timeout 5 (2 similar events suppressed)"}, events(fake))
+}
+
+func TestSweep(t *testing.T) {
+	r, _, now := newTestRecorder(DefaultConfig())
+	r.EventRecorder = &record.FakeRecorder{}
+	for i := 0; i < sweepSize; i++ {
+		so := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: string(rune('a' + i%26)), Namespace: string(rune('a' + i/26))}}
+		r.Event(so, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "error")
+	}
+	assert.Len(t, r.objects, sweepSize)
+
+	*now = now.Add(defaultInterval)
+	r.Event(&kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "new"}}, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "error")
+	assert.Len(t, r.objects, 1)
+}
+
+func TestLoadConfig(t *testing.T) {
+	config, err := LoadConfig("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultConfig(), config)
+
+	tests := []struct {
+		name    string
+		content string
+		isError bool
+	}{
+		{
+			name: "valid",
+			content: `
+policies:
+- reasons: [KEDAScalerFailed]
+  duplicateInterval: 10m
+- burst: 10
+  interval: 1m
+`,
+		},
+		{name: "disabled", content: "policies: []"},
+		{name: "unknown field", content: "policies:\n- reason: KEDAScalerFailed", isError: true},
+		{name: "duplicated reason", content: "policies:\n- reasons: [a]\n- reasons: [a]", isError: true},
+		{name: "two defaults", content: "policies:\n- burst: 1\n  interval: 1m\n- burst: 1\n  interval: 1m", isError: true},
+		{name: "burst without interval", content: "policies:\n- burst: 1", isError: true},
+		{name: "negative duplicate interval", content: "policies:\n- duplicateInterval: -1m", isError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			assert.NoError(t, os.WriteFile(path, []byte(test.content), 0600))
+			_, err := LoadConfig(path)
+			assert.Equal(t, test.isError, err != nil, "%v", err)
+		})
+	}
+}
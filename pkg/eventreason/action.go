@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventreason
+
+const (
+	// ActionReconcile is the action of the events emitted while reconciling a resource
+	ActionReconcile = "Reconcile"
+
+	// ActionGetMetrics is the action of the events emitted while building the scalers and querying their metrics
+	ActionGetMetrics = "GetMetrics"
+
+	// ActionScale is the action of the events emitted while scaling a scale target or creating jobs
+	ActionScale = "Scale"
+)
+
+const (
+	// TriggerIndexAnnotation is the index of the trigger an event is about
+	TriggerIndexAnnotation = "keda.sh/trigger-index"
+
+	// TriggerNameAnnotation is the name of the trigger an event is about, if it has one
+	TriggerNameAnnotation = "keda.sh/trigger-name"
+
+	// TriggerTypeAnnotation is the type of the trigger an event is about
+	TriggerTypeAnnotation = "keda.sh/trigger-type"
+
+	// MetricValuesAnnotation is a JSON object of the values of the metrics of the trigger by metric name
+	MetricValuesAnnotation = "keda.sh/metric-values"
+
+	// CurrentReplicasAnnotation is the number of replicas of the scale target before it has been scaled
+	CurrentReplicasAnnotation = "keda.sh/current-replicas"
+
+	// DesiredReplicasAnnotation is the number of replicas the scale target has been scaled to
+	DesiredReplicasAnnotation = "keda.sh/desired-replicas"
+)
+
+var actions = map[string]string{
+	KEDAScalersStarted:                ActionGetMetrics,
+	KEDAScalersStopped:                ActionGetMetrics,
+	KEDAScalerFailed:                  ActionGetMetrics,
+	KEDAMetricSourceFailed:            ActionGetMetrics,
+	KEDATriggerActivated:              ActionGetMetrics,
+	KEDATriggerDeactivated:            ActionGetMetrics,
+	KEDAFallbackEntered:               ActionGetMetrics,
+	KEDAFallbackExited:                ActionGetMetrics,
+	KEDAScaleTargetActivated:          ActionScale,
+	KEDAScaleTargetDeactivated:        ActionScale,
+	KEDAScaleTargetActivationFailed:   ActionScale,
+	KEDAScaleTargetDeactivationFailed: ActionScale,
+	KEDAJobsCreated:                   ActionScale,
+}
+
+// Action returns the action reported with the events of the reason by the events.k8s.io API,
+// the reasons not related to the scaling are reported while reconciling
+func Action(reason string) string {
+	if action, found := actions[reason]; found {
+		return action
+	}
+	return ActionReconcile
+}
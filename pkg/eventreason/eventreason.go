@@ -14,6 +14,8 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Package eventreason is the taxonomy of the reasons of the Kubernetes events emitted by KEDA,
+// the reasons are stable so the consumers of the events can match on them
 package eventreason
 
 const (
@@ -59,12 +61,30 @@ const (
 	// KEDAScaleTargetDeactivated is for event when the scale target for ScaledObject was deactivated
 	KEDAScaleTargetDeactivated = "KEDAScaleTargetDeactivated"
 
+	// KEDAScaleTargetScaled is for event when the scale target of ScaledObject scaled directly was scaled
+	KEDAScaleTargetScaled = "KEDAScaleTargetScaled"
+
+	// KEDATriggerActivated is for event when a trigger of a ScaledObject or a ScaledJob becomes active
+	KEDATriggerActivated = "KEDATriggerActivated"
+
+	// KEDATriggerDeactivated is for event when a trigger of a ScaledObject or a ScaledJob is no longer active
+	KEDATriggerDeactivated = "KEDATriggerDeactivated"
+
+	// KEDAFallbackEntered is for event when a ScaledObject starts using the fallback replicas
+	KEDAFallbackEntered = "KEDAFallbackEntered"
+
+	// KEDAFallbackExited is for event when a ScaledObject stops using the fallback replicas
+	KEDAFallbackExited = "KEDAFallbackExited"
+
 	// KEDAScaleTargetActivationFailed is for event when the activation the scale target for ScaledObject fails
 	KEDAScaleTargetActivationFailed = "KEDAScaleTargetActivationFailed"
 
 	// KEDAScaleTargetDeactivationFailed is for event when the deactivation of the scale target for ScaledObject fails
 	KEDAScaleTargetDeactivationFailed = "KEDAScaleTargetDeactivationFailed"
 
+	// KEDAScaleTargetScalingFailed is for event when the scaling of the scale target of ScaledObject scaled directly fails
+	KEDAScaleTargetScalingFailed = "KEDAScaleTargetScalingFailed"
+
 	// KEDAJobsCreated is for event when jobs for ScaledJob are created
 	KEDAJobsCreated = "KEDAJobsCreated"
 
@@ -78,7 +98,7 @@ const (
 	TriggerAuthenticationFailed = "TriggerAuthenticationFailed"
 
 	// TriggerAuthenticationUpdated is for event when a TriggerAuthentication is updated
-	TriggerAuthenticationUpdated = "ClusterTriggerAuthenticationUpdated"
+	TriggerAuthenticationUpdated = "TriggerAuthenticationUpdated"
 
 	// ClusterTriggerAuthenticationDeleted is for event when a ClusterTriggerAuthentication is deleted
 	ClusterTriggerAuthenticationDeleted = "ClusterTriggerAuthenticationDeleted"
@@ -90,5 +110,5 @@ const (
 	ClusterTriggerAuthenticationFailed = "ClusterTriggerAuthenticationFailed"
 
 	// ClusterTriggerAuthenticationUpdated is for event when a ClusterTriggerAuthentication is updated
-	ClusterTriggerAuthenticationUpdated = "ClusterTriggerAuthenticationUpdated"
+	ClusterTriggerAuthenticationUpdated = "TriggerAuthenticationUpdated"
 )
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventrecorder emits the Kubernetes events through the events.k8s.io API, the events
+// report the action KEDA was doing and carry the annotations describing them in a structured way.
+// They are still listed by the core events API, which serves the same events.
+package eventrecorder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	typedeventsv1 "k8s.io/client-go/kubernetes/typed/events/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/tools/reference"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+// +kubebuilder:rbac:groups="events.k8s.io",resources=events,verbs=create
+
+var log = logf.Log.WithName("event_recorder")
+
+const (
+	// the events are dropped once this many are waiting to be created
+	queueSize = 1024
+	// the events.k8s.io API rejects the longer notes
+	noteLengthLimit = 1024
+	createTimeout   = 10 * time.Second
+)
+
+// Recorder is a record.EventRecorder creating events.k8s.io events, the events are created
+// in the background once the recorder has been started, so emitting one never blocks
+type Recorder struct {
+	client     typedeventsv1.EventsGetter
+	scheme     *runtime.Scheme
+	controller string
+	instance   string
+	queue      chan *eventsv1.Event
+	now        func() time.Time
+}
+
+var _ record.EventRecorder = &Recorder{}
+
+// NewRecorder returns a recorder reporting the events as emitted by the controller, the objects
+// the events are about are referenced through their kind in the scheme
+func NewRecorder(client typedeventsv1.EventsGetter, scheme *runtime.Scheme, controller string) *Recorder {
+	instance := controller
+	if hostname, err := os.Hostname(); err == nil {
+		instance = fmt.Sprintf("%s-%s", controller, hostname)
+	}
+	return &Recorder{
+		client:     client,
+		scheme:     scheme,
+		controller: controller,
+		instance:   instance,
+		queue:      make(chan *eventsv1.Event, queueSize),
+		now:        time.Now,
+	}
+}
+
+func (r *Recorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.emit(object, nil, eventtype, reason, message)
+}
+
+func (r *Recorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.emit(object, nil, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *Recorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.emit(object, annotations, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *Recorder) emit(object runtime.Object, annotations map[string]string, eventtype, reason, message string) {
+	regarding, err := reference.GetReference(r.scheme, object)
+	if err != nil {
+		log.Error(err, "error referencing the object of the event, dropping it", "reason", reason, "message", message)
+		return
+	}
+	namespace := regarding.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	if len(message) > noteLengthLimit {
+		message = strings.ToValidUTF8(message[:noteLengthLimit], "")
+	}
+
+	now := r.now()
+	event := &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s.%x", regarding.Name, now.UnixNano()),
+			Namespace:   namespace,
+			Annotations: annotations,
+		},
+		EventTime:           metav1.NewMicroTime(now),
+		ReportingController: r.controller,
+		ReportingInstance:   r.instance,
+		Action:              eventreason.Action(reason),
+		Reason:              reason,
+		Regarding:           *regarding,
+		Note:                message,
+		Type:                eventtype,
+	}
+	select {
+	case r.queue <- event:
+	default:
+		log.V(1).Info("Dropping event, too many events are waiting to be created", "reason", reason, "object", regarding.Name)
+	}
+}
+
+// Start creates the emitted events until the context is done, it implements manager.Runnable
+func (r *Recorder) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-r.queue:
+			r.create(event)
+		}
+	}
+}
+
+// NeedLeaderElection returns false, the events of all the replicas are created
+func (r *Recorder) NeedLeaderElection() bool {
+	return false
+}
+
+func (r *Recorder) create(event *eventsv1.Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), createTimeout)
+	defer cancel()
+	if _, err := r.client.Events(event.Namespace).Create(ctx, event, metav1.CreateOptions{}); err != nil {
+		log.Error(err, "error creating event", "reason", event.Reason, "namespace", event.Namespace, "object", event.Regarding.Name)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventrecorder
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+func TestRecorder(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	kubeClient := fake.NewSimpleClientset()
+	r := NewRecorder(kubeClient.EventsV1(), scheme, "keda-operator")
+
+	so := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "1234"}}
+	r.AnnotatedEventf(so, map[string]string{eventreason.TriggerIndexAnnotation: "0"}, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "error %s", "connection refused")
+	r.Event(so, corev1.EventTypeNormal, eventreason.ScaledObjectReady, strings.Repeat("a", 2*noteLengthLimit))
+	// the objects missing from the scheme can't be referenced
+	r.Event(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"}}, corev1.EventTypeNormal, "Started", "started")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, r.Start(ctx))
+	}()
+	assert.Eventually(t, func() bool { return len(r.queue) == 0 }, time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	events, err := kubeClient.EventsV1().Events("default").List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, events.Items, 2)
+	for _, event := range events.Items {
+		assert.Equal(t, "ScaledObject", event.Regarding.Kind)
+		assert.Equal(t, "app", event.Regarding.Name)
+		assert.Equal(t, "keda-operator", event.ReportingController)
+		assert.True(t, strings.HasPrefix(event.ReportingInstance, "keda-operator"))
+		switch event.Reason {
+		case eventreason.KEDAScalerFailed:
+			assert.Equal(t, eventreason.ActionGetMetrics, event.Action)
+			assert.Equal(t, corev1.EventTypeWarning, event.Type)
+			assert.Equal(t, "error connection refused", event.Note)
+			assert.Equal(t, map[string]string{eventreason.TriggerIndexAnnotation: "0"}, event.Annotations)
+		case eventreason.ScaledObjectReady:
+			assert.Equal(t, eventreason.ActionReconcile, event.Action)
+			assert.Len(t, event.Note, noteLengthLimit)
+		default:
+			t.Errorf("unexpected event %s", event.Reason)
+		}
+	}
+}
@@ -29,6 +29,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricshistory"
 )
 
 var log = logf.Log.WithName("fallback")
@@ -104,6 +105,13 @@ func HasValidFallback(scaledObject *kedav1alpha1.ScaledObject) bool {
 }
 
 func doFallback(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpec, metricName string, suppressedError error) []external_metrics.ExternalMetricValue {
+	if scaledObject.Spec.Fallback.Behavior == kedav1alpha1.FallbackBehaviorLastKnownGood {
+		if lastKnownGoodMetrics, found := getLastKnownGoodMetrics(scaledObject, metricName, suppressedError); found {
+			return lastKnownGoodMetrics
+		}
+		log.Info("No last known good value of the metric, falling back to fallback.replicas", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "metricName", metricName, "metricsHistoryEnabled", metricshistory.Enabled())
+	}
+
 	replicas := int64(scaledObject.Spec.Fallback.Replicas)
 	var normalisationValue int64
 	if !scaledObject.IsUsingModifiers() {
@@ -125,6 +133,26 @@ func doFallback(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2.MetricSpe
 	return fallbackMetrics
 }
 
+// getLastKnownGoodMetrics returns the last value of the metric retained by the metrics history,
+// with scaling modifiers it is the last result of the formula
+func getLastKnownGoodMetrics(scaledObject *kedav1alpha1.ScaledObject, metricName string, suppressedError error) ([]external_metrics.ExternalMetricValue, bool) {
+	if scaledObject.IsUsingModifiers() {
+		metricName = kedav1alpha1.CompositeMetricName
+	}
+	sample, found := metricshistory.Last(scaledObject.Namespace, scaledObject.Name, metricName)
+	if !found {
+		return nil, false
+	}
+
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(int64(sample.Value*1000), resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+	log.Info("Suppressing error, falling back to the last known good value of the metric", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "suppressedError", suppressedError, "metricName", metricName, "value", sample.Value, "timestamp", sample.Timestamp)
+	return []external_metrics.ExternalMetricValue{metric}, true
+}
+
 func updateStatus(ctx context.Context, client runtimeclient.Client, scaledObject *kedav1alpha1.ScaledObject, status *kedav1alpha1.ScaledObjectStatus, metricSpec v2.MetricSpec) {
 	patch := runtimeclient.MergeFrom(scaledObject.DeepCopy())
 
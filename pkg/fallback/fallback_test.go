@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -32,6 +33,7 @@ import (
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricshistory"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
 	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
 )
@@ -212,6 +214,46 @@ var _ = Describe("fallback", func() {
 		Expect(so.Status.Health[metricName]).To(haveFailureAndStatus(4, kedav1alpha1.HealthStatusFailing))
 	})
 
+	It("should return the last known good metric when number of failures are beyond threshold", func() {
+		shutdownMetricsHistory, err := metricshistory.NewMetricsHistory(metricshistory.Options{Size: 3}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		defer func() { _ = shutdownMetricsHistory() }()
+
+		scaler.EXPECT().GetMetricsAndActivity(gomock.Any(), gomock.Eq(metricName)).Return(nil, false, errors.New("some error")).Times(2)
+		startingNumberOfFailures := int32(3)
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(3),
+				Replicas:         int32(10),
+				Behavior:         kedav1alpha1.FallbackBehaviorLastKnownGood,
+			},
+			&kedav1alpha1.ScaledObjectStatus{
+				Health: map[string]kedav1alpha1.HealthStatus{
+					metricName: {
+						NumberOfFailures: &startingNumberOfFailures,
+						Status:           kedav1alpha1.HealthStatusHappy,
+					},
+				},
+			},
+		)
+		metricSpec := createMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+
+		// without a retained value it scales to the fallback replicas
+		metrics, _, err := scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, _, err = GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metrics[0].Value.AsApproximateFloat64()).Should(Equal(float64(100)))
+
+		metricshistory.Record(so.Namespace, so.Name, metricName, 42, time.Now())
+		expectStatusPatch(ctrl, client)
+		metrics, _, err = scaler.GetMetricsAndActivity(context.Background(), metricName)
+		metrics, fallbackActive, err := GetMetricsWithFallback(context.Background(), client, metrics, err, metricName, so, metricSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fallbackActive).Should(BeTrue())
+		Expect(metrics[0].Value.AsApproximateFloat64()).Should(Equal(float64(42)))
+	})
+
 	It("should behave as if fallback is disabled when the metrics spec target type is not average value metric", func() {
 		so := buildScaledObject(
 			&kedav1alpha1.Fallback{
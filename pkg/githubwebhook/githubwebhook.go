@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package githubwebhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+var log = logf.Log.WithName("github_webhook")
+
+// Path is the path the webhook deliveries are received on
+const Path = "/github/webhook"
+
+const (
+	// maxPayloadSize is the largest payload of a delivery sent by GitHub
+	maxPayloadSize  = 25 << 20
+	signatureHeader = "X-Hub-Signature-256"
+	eventHeader     = "X-GitHub-Event"
+	deliveryHeader  = "X-GitHub-Delivery"
+	// forwardTimeout bounds the forwarding of a delivery to the other replicas
+	forwardTimeout = 10 * time.Second
+)
+
+// Options configures the webhook receiver
+type Options struct {
+	// BindAddress is the address the receiver listens on
+	BindAddress string
+	// Secret is the secret of the webhook the signatures of the deliveries are validated with
+	Secret string
+	// PeersService is the headless service resolving to the replicas of the operator, the deliveries are
+	// forwarded to the other replicas so the one evaluating a scaler has them. Empty disables the forwarding.
+	PeersService string
+}
+
+// workflowJobEvent is the part of a workflow_job delivery read by the receiver
+type workflowJobEvent struct {
+	Action      string `json:"action"`
+	WorkflowJob struct {
+		ID              int64    `json:"id"`
+		Status          string   `json:"status"`
+		Labels          []string `json:"labels"`
+		RunnerName      string   `json:"runner_name"`
+		RunnerGroupName string   `json:"runner_group_name"`
+	} `json:"workflow_job"`
+	Repository struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+	Enterprise struct {
+		Slug string `json:"slug"`
+	} `json:"enterprise"`
+}
+
+var (
+	lock  sync.RWMutex
+	store *jobStore
+)
+
+// NewReceiver starts receiving the workflow_job deliveries of GitHub webhooks, the queue of each
+// owner is maintained from them so the scalers don't have to poll the REST API. The queue is kept
+// in memory: it's rebuilt from the deliveries following a restart. A delivery received by a replica
+// is forwarded to the others of the PeersService, each replica keeps the whole queue whichever
+// evaluates the scalers. It returns a function stopping the receiver on shutdown.
+func NewReceiver(opts Options) (func() error, error) {
+	if opts.Secret == "" {
+		return nil, fmt.Errorf("a secret is required to validate the webhook deliveries")
+	}
+	listener, err := net.Listen("tcp", opts.BindAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error listening on %s: %w", opts.BindAddress, err)
+	}
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		return nil, err
+	}
+	return start(listener, opts.Secret, kedautil.NewPeerResolver(opts.PeersService, port)), nil
+}
+
+func start(listener net.Listener, secret string, peers kedautil.PeerResolver) func() error {
+	s := newJobStore()
+	lock.Lock()
+	store = s
+	lock.Unlock()
+
+	mux := http.NewServeMux()
+	mux.Handle(Path, &handler{secret: []byte(secret), store: s, peers: peers, client: &http.Client{Timeout: forwardTimeout}})
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error(err, "github webhook receiver stopped")
+		}
+	}()
+
+	return func() error {
+		lock.Lock()
+		store = nil
+		lock.Unlock()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(ctx)
+	}
+}
+
+// Enabled returns whether the webhook receiver has been set up for this component
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return store != nil
+}
+
+// Jobs returns the workflow jobs queued or in progress, as last delivered
+func Jobs() []WorkflowJob {
+	lock.RLock()
+	s := store
+	lock.RUnlock()
+	if s == nil {
+		return nil
+	}
+	return s.list(time.Now())
+}
+
+type handler struct {
+	secret []byte
+	store  *jobStore
+	// peers resolves the other replicas the deliveries are forwarded to, nil without forwarding
+	peers  kedautil.PeerResolver
+	client *http.Client
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize+1))
+	if err != nil {
+		http.Error(w, "error reading the payload", http.StatusBadRequest)
+		return
+	}
+	if len(payload) > maxPayloadSize {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	if !h.validSignature(r.Header.Get(signatureHeader), payload) {
+		log.V(1).Info("rejected github webhook delivery with an invalid signature", "delivery", r.Header.Get(deliveryHeader))
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Header.Get(eventHeader) {
+	case "ping":
+		w.WriteHeader(http.StatusOK)
+	case "workflow_job":
+		event := workflowJobEvent{}
+		if err := json.Unmarshal(payload, &event); err != nil || event.WorkflowJob.ID == 0 {
+			http.Error(w, "invalid workflow_job payload", http.StatusBadRequest)
+			return
+		}
+		h.store.record(WorkflowJob{
+			ID:              event.WorkflowJob.ID,
+			Owner:           event.Repository.Owner.Login,
+			Enterprise:      event.Enterprise.Slug,
+			Repository:      event.Repository.Name,
+			Status:          event.WorkflowJob.Status,
+			Labels:          event.WorkflowJob.Labels,
+			RunnerName:      event.WorkflowJob.RunnerName,
+			RunnerGroupName: event.WorkflowJob.RunnerGroupName,
+		}, time.Now())
+		log.V(1).Info("received workflow_job delivery", "delivery", r.Header.Get(deliveryHeader), "action", event.Action, "job", event.WorkflowJob.ID)
+		if h.peers != nil && r.Header.Get(kedautil.ForwardedHeader) == "" {
+			go h.forward(r.Header.Clone(), payload)
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		// the other events the webhook is subscribed to aren't used
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// forward sends the delivery to the other replicas, with its signature so they validate it as
+// sent by GitHub. The records of a job are idempotent, a replica receiving it twice is fine.
+func (h *handler) forward(header http.Header, payload []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), forwardTimeout)
+	defer cancel()
+	err := kedautil.ForwardToPeers(ctx, h.peers, func(ctx context.Context, peer string) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://%s%s", peer, Path), bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		for _, name := range []string{signatureHeader, eventHeader, deliveryHeader} {
+			req.Header.Set(name, header.Get(name))
+		}
+		req.Header.Set(kedautil.ForwardedHeader, "true")
+		resp, err := h.client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(err, "error forwarding the github webhook delivery", "delivery", header.Get(deliveryHeader))
+	}
+}
+
+// validSignature checks the HMAC-SHA256 signature of the payload sent by GitHub
+func (h *handler) validSignature(signature string, payload []byte) bool {
+	digest, found := strings.CutPrefix(signature, "sha256=")
+	if !found {
+		return false
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
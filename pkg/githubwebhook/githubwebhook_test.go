@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package githubwebhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const testSecret = "It's a Secret to Everybody"
+
+func sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliver(h http.Handler, event string, payload []byte, signature string) int {
+	req := httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(payload))
+	req.Header.Set(eventHeader, event)
+	req.Header.Set(signatureHeader, signature)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func post(t *testing.T, addr string, payload []byte) int {
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s%s", addr, Path), bytes.NewReader(payload))
+	assert.NoError(t, err)
+	req.Header.Set(eventHeader, "workflow_job")
+	req.Header.Set(signatureHeader, sign(payload))
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func workflowJobPayload(id int64, action string, status string) []byte {
+	return []byte(fmt.Sprintf(`{"action": %q, "workflow_job": {"id": %d, "status": %q, "labels": ["self-hosted", "gpu"], "runner_name": "gpu-1"},
+		"repository": {"name": "app", "owner": {"login": "kedacore"}}, "enterprise": {"slug": "keda"}}`, action, id, status))
+}
+
+func TestHandlerSignature(t *testing.T) {
+	h := &handler{secret: []byte(testSecret), store: newJobStore()}
+	payload := []byte(`{"zen": "Keep it logically awesome."}`)
+
+	assert.Equal(t, http.StatusOK, deliver(h, "ping", payload, sign(payload)))
+	assert.Equal(t, http.StatusUnauthorized, deliver(h, "ping", payload, ""))
+	assert.Equal(t, http.StatusUnauthorized, deliver(h, "ping", payload, "sha1=abcd"))
+	assert.Equal(t, http.StatusUnauthorized, deliver(h, "ping", append(payload, ' '), sign(payload)))
+
+	// the events other than workflow_job are ignored
+	assert.Equal(t, http.StatusNoContent, deliver(h, "push", payload, sign(payload)))
+	// and the payloads of workflow_job are validated
+	assert.Equal(t, http.StatusBadRequest, deliver(h, "workflow_job", payload, sign(payload)))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestHandlerWorkflowJob(t *testing.T) {
+	s := newJobStore()
+	h := &handler{secret: []byte(testSecret), store: s}
+
+	for _, payload := range [][]byte{workflowJobPayload(1, "queued", StatusQueued), workflowJobPayload(2, "queued", StatusQueued)} {
+		assert.Equal(t, http.StatusOK, deliver(h, "workflow_job", payload, sign(payload)))
+	}
+	jobs := s.list(time.Now())
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, WorkflowJob{ID: 1, Owner: "kedacore", Enterprise: "keda", Repository: "app", Status: StatusQueued, Labels: []string{"self-hosted", "gpu"}, RunnerName: "gpu-1"},
+		WorkflowJob{ID: jobs[0].ID, Owner: jobs[0].Owner, Enterprise: jobs[0].Enterprise, Repository: jobs[0].Repository, Status: jobs[0].Status, Labels: jobs[0].Labels, RunnerName: jobs[0].RunnerName})
+
+	payload := workflowJobPayload(1, "completed", StatusCompleted)
+	assert.Equal(t, http.StatusOK, deliver(h, "workflow_job", payload, sign(payload)))
+	jobs = s.list(time.Now())
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, int64(2), jobs[0].ID)
+}
+
+func TestJobStoreOutOfOrder(t *testing.T) {
+	s := newJobStore()
+	now := time.Now()
+
+	// the deliveries of the previous statuses don't bring a job back
+	s.record(WorkflowJob{ID: 1, Status: StatusInProgress}, now)
+	s.record(WorkflowJob{ID: 1, Status: StatusQueued}, now)
+	assert.Equal(t, StatusInProgress, s.list(now)[0].Status)
+
+	s.record(WorkflowJob{ID: 1, Status: StatusCompleted}, now)
+	s.record(WorkflowJob{ID: 1, Status: StatusQueued}, now)
+	assert.Empty(t, s.list(now))
+
+	// the unknown statuses are ignored
+	s.record(WorkflowJob{ID: 2, Status: "waiting"}, now)
+	assert.Empty(t, s.list(now))
+}
+
+func TestJobStoreExpiry(t *testing.T) {
+	s := newJobStore()
+	now := time.Now()
+
+	s.record(WorkflowJob{ID: 1, Status: StatusQueued}, now)
+	s.record(WorkflowJob{ID: 2, Status: StatusCompleted}, now)
+	assert.Len(t, s.list(now.Add(2*time.Hour)), 1)
+	assert.Len(t, s.jobs, 1)
+
+	// the jobs whose completion was missed are dropped eventually
+	assert.Empty(t, s.list(now.Add(25*time.Hour)))
+	assert.Empty(t, s.jobs)
+}
+
+func TestReceiver(t *testing.T) {
+	_, err := NewReceiver(Options{BindAddress: "127.0.0.1:0"})
+	assert.Error(t, err)
+	assert.False(t, Enabled())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	shutdown := start(listener, testSecret, nil)
+	assert.True(t, Enabled())
+
+	assert.Equal(t, http.StatusOK, post(t, listener.Addr().String(), workflowJobPayload(1, "queued", StatusQueued)))
+	assert.Len(t, Jobs(), 1)
+
+	assert.NoError(t, shutdown())
+	assert.False(t, Enabled())
+	assert.Empty(t, Jobs())
+}
+
+func TestHandlerForwarding(t *testing.T) {
+	// the replica evaluating the scalers, a forwarded delivery isn't forwarded again
+	peer := &handler{secret: []byte(testSecret), store: newJobStore(), peers: func(context.Context) ([]string, error) {
+		t.Error("forwarded delivery forwarded again")
+		return nil, nil
+	}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.Header.Get(kedautil.ForwardedHeader))
+		peer.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	// the replica GitHub delivered to
+	s := newJobStore()
+	h := &handler{secret: []byte(testSecret), store: s, client: server.Client(), peers: func(context.Context) ([]string, error) {
+		return []string{server.Listener.Addr().String()}, nil
+	}}
+	payload := workflowJobPayload(1, "queued", StatusQueued)
+	assert.Equal(t, http.StatusOK, deliver(h, "workflow_job", payload, sign(payload)))
+	assert.Len(t, s.list(time.Now()), 1)
+	assert.Eventually(t, func() bool { return len(peer.store.list(time.Now())) == 1 }, 5*time.Second, 10*time.Millisecond)
+}
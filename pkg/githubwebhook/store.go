@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package githubwebhook
+
+import (
+	"sync"
+	"time"
+)
+
+// Statuses of the workflow jobs
+const (
+	StatusQueued     = "queued"
+	StatusInProgress = "in_progress"
+	StatusCompleted  = "completed"
+)
+
+const (
+	// staleJobTTL is how long a job which isn't completed is kept since its last delivery, GitHub
+	// cancels the jobs queued for longer so the jobs whose completion was missed are dropped after it
+	staleJobTTL = 24 * time.Hour
+	// completedJobTTL is how long the completed jobs are remembered, so the deliveries of their
+	// previous statuses arriving late don't queue them again
+	completedJobTTL = time.Hour
+)
+
+// WorkflowJob is a workflow job as tracked from the workflow_job deliveries
+type WorkflowJob struct {
+	ID              int64
+	Owner           string
+	Enterprise      string
+	Repository      string
+	Status          string
+	Labels          []string
+	RunnerName      string
+	RunnerGroupName string
+
+	updatedAt time.Time
+}
+
+// jobStore keeps the last status of each workflow job delivered
+type jobStore struct {
+	lock sync.Mutex
+	jobs map[int64]*WorkflowJob
+}
+
+func newJobStore() *jobStore {
+	return &jobStore{jobs: map[int64]*WorkflowJob{}}
+}
+
+// statusRank orders the statuses a job goes through, the deliveries can arrive out of order
+// and a job never goes back to a previous status
+func statusRank(status string) int {
+	switch status {
+	case StatusQueued:
+		return 1
+	case StatusInProgress:
+		return 2
+	case StatusCompleted:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// record updates the job from a delivery, the deliveries of unknown statuses are ignored
+func (s *jobStore) record(job WorkflowJob, now time.Time) {
+	if statusRank(job.Status) == 0 {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.expire(now)
+	if current, ok := s.jobs[job.ID]; ok && statusRank(current.Status) > statusRank(job.Status) {
+		return
+	}
+	job.updatedAt = now
+	s.jobs[job.ID] = &job
+}
+
+// list returns the jobs which aren't completed yet
+func (s *jobStore) list(now time.Time) []WorkflowJob {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.expire(now)
+	jobs := make([]WorkflowJob, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		if job.Status != StatusCompleted {
+			jobs = append(jobs, *job)
+		}
+	}
+	return jobs
+}
+
+func (s *jobStore) expire(now time.Time) {
+	for id, job := range s.jobs {
+		ttl := staleJobTTL
+		if job.Status == StatusCompleted {
+			ttl = completedJobTTL
+		}
+		if now.Sub(job.updatedAt) > ttl {
+			delete(s.jobs, id)
+		}
+	}
+}
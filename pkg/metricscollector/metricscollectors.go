@@ -43,6 +43,20 @@ type MetricsCollector interface {
 	// RecordScalerLatency create a measurement of the latency to external metric
 	RecordScalerLatency(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, value time.Duration)
 
+	// RecordScalerCall create a measurement of the latency and the error, if any, of a single call to the scaler,
+	// a negative latency means that the scaler couldn't be called
+	RecordScalerCall(namespace string, scaledResource string, triggerType string, triggerIndex int, isScaledObject bool, value time.Duration, err error)
+
+	// RecordScalerCacheHit counts the lookups of the scaler metrics in the cache, hit or miss
+	RecordScalerCacheHit(namespace string, scaledResource string, triggerType string, triggerIndex int, isScaledObject bool, hit bool)
+
+	// RecordScalersCacheEviction counts the scalers of the scalable objects evicted from the scalers cache
+	// to keep it under its size limit
+	RecordScalersCacheEviction(isScaledObject bool)
+
+	// RecordScalersCacheRebuild counts the scalers of the scalable objects built again after their eviction
+	RecordScalersCacheRebuild(isScaledObject bool)
+
 	// RecordScalableObjectLatency create a measurement of the latency executing scalable object loop
 	RecordScalableObjectLatency(namespace string, name string, isScaledObject bool, value time.Duration)
 
@@ -52,6 +66,18 @@ type MetricsCollector interface {
 	// RecordScaledObjectPaused marks whether the current ScaledObject is paused.
 	RecordScaledObjectPaused(namespace string, scaledObject string, active bool)
 
+	// RecordScaledObjectReplicas create a measurement of the replicas desired by KEDA and by the HPA, the ready replicas
+	// of the scale target and the maxReplicaCount of the ScaledObject, a negative value means that it is unknown
+	RecordScaledObjectReplicas(namespace string, scaledObject string, desired int32, hpaDesired int32, ready int32, max int32)
+
+	// RecordScaledObjectActivationLatency create a measurement of the time from the activation of the ScaledObject
+	// scaled to zero until a replica of its scale target is ready
+	RecordScaledObjectActivationLatency(namespace string, scaledObject string, value time.Duration)
+
+	// RecordTriggerMetricValue create a measurement of the value and the target of a metric of a trigger
+	// of the ScaledObject, a negative target means that it is unknown
+	RecordTriggerMetricValue(namespace string, scaledObject string, triggerType string, triggerName string, triggerIndex int, metric string, value float64, target float64)
+
 	// RecordScalerError counts the number of errors occurred in trying to get an external metric used by the HPA
 	RecordScalerError(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, err error)
 
@@ -109,6 +135,34 @@ func RecordScalerLatency(namespace string, scaledObject string, scaler string, t
 	}
 }
 
+// RecordScalerCall create a measurement of the latency and the error, if any, of a single call to the scaler
+func RecordScalerCall(namespace string, scaledResource string, triggerType string, triggerIndex int, isScaledObject bool, value time.Duration, err error) {
+	for _, element := range collectors {
+		element.RecordScalerCall(namespace, scaledResource, triggerType, triggerIndex, isScaledObject, value, err)
+	}
+}
+
+// RecordScalerCacheHit counts the lookups of the scaler metrics in the cache, hit or miss
+func RecordScalerCacheHit(namespace string, scaledResource string, triggerType string, triggerIndex int, isScaledObject bool, hit bool) {
+	for _, element := range collectors {
+		element.RecordScalerCacheHit(namespace, scaledResource, triggerType, triggerIndex, isScaledObject, hit)
+	}
+}
+
+// RecordScalersCacheEviction counts the scalers of the scalable objects evicted from the scalers cache
+func RecordScalersCacheEviction(isScaledObject bool) {
+	for _, element := range collectors {
+		element.RecordScalersCacheEviction(isScaledObject)
+	}
+}
+
+// RecordScalersCacheRebuild counts the scalers of the scalable objects built again after their eviction
+func RecordScalersCacheRebuild(isScaledObject bool) {
+	for _, element := range collectors {
+		element.RecordScalersCacheRebuild(isScaledObject)
+	}
+}
+
 // RecordScalableObjectLatency create a measurement of the latency executing scalable object loop
 func RecordScalableObjectLatency(namespace string, name string, isScaledObject bool, value time.Duration) {
 	for _, element := range collectors {
@@ -130,6 +184,30 @@ func RecordScaledObjectPaused(namespace string, scaledObject string, active bool
 	}
 }
 
+// RecordScaledObjectReplicas create a measurement of the replicas desired by KEDA and by the HPA, the ready replicas
+// of the scale target and the maxReplicaCount of the ScaledObject, a negative value means that it is unknown
+func RecordScaledObjectReplicas(namespace string, scaledObject string, desired int32, hpaDesired int32, ready int32, max int32) {
+	for _, element := range collectors {
+		element.RecordScaledObjectReplicas(namespace, scaledObject, desired, hpaDesired, ready, max)
+	}
+}
+
+// RecordScaledObjectActivationLatency create a measurement of the time from the activation of the ScaledObject
+// scaled to zero until a replica of its scale target is ready
+func RecordScaledObjectActivationLatency(namespace string, scaledObject string, value time.Duration) {
+	for _, element := range collectors {
+		element.RecordScaledObjectActivationLatency(namespace, scaledObject, value)
+	}
+}
+
+// RecordTriggerMetricValue create a measurement of the value and the target of a metric of a trigger
+// of the ScaledObject, a negative target means that it is unknown
+func RecordTriggerMetricValue(namespace string, scaledObject string, triggerType string, triggerName string, triggerIndex int, metric string, value float64, target float64) {
+	for _, element := range collectors {
+		element.RecordTriggerMetricValue(namespace, scaledObject, triggerType, triggerName, triggerIndex, metric, value, target)
+	}
+}
+
 // RecordScalerError counts the number of errors occurred in trying to get an external metric used by the HPA
 func RecordScalerError(namespace string, scaledObject string, scaler string, triggerIndex int, metric string, isScaledObject bool, err error) {
 	for _, element := range collectors {
@@ -6,6 +6,7 @@ import (
 	"os"
 	"runtime"
 	"strconv"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -14,20 +15,30 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	api "go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/kedacore/keda/v2/pkg/tracing"
 	"github.com/kedacore/keda/v2/version"
 )
 
 var otLog = logf.Log.WithName("otel_collector")
 
 const meterName = "keda-open-telemetry-metrics"
+const otelServiceName = "keda-operator"
 const defaultNamespace = "default"
 
 var (
 	meterProvider                    *metric.MeterProvider
 	meter                            api.Meter
 	otScalerErrorsCounter            api.Int64Counter
+	otScalerCallDuration             api.Float64Histogram
+	otScaledObjectActivationLatency  api.Float64Histogram
+	otScalerCallErrorsCounter        api.Int64Counter
+	otScalerCacheLookupsCounter      api.Int64Counter
+	otScalersCacheEvictionsCounter   api.Int64Counter
+	otScalersCacheRebuildsCounter    api.Int64Counter
 	otScaledObjectErrorsCounter      api.Int64Counter
 	otScaledJobErrorsCounter         api.Int64Counter
 	otTriggerTotalsCounterDeprecated api.Int64UpDownCounter
@@ -47,6 +58,17 @@ var (
 
 	otelScalerActiveVals []OtelMetricFloat64Val
 	otelScalerPauseVals  []OtelMetricFloat64Val
+
+	otelScaledObjectDesiredReplicasVals    []OtelMetricFloat64Val
+	otelScaledObjectHPADesiredReplicasVals []OtelMetricFloat64Val
+	otelScaledObjectReadyReplicasVals      []OtelMetricFloat64Val
+	otelScaledObjectMaxReplicasVals        []OtelMetricFloat64Val
+
+	// the values of the trigger metrics are kept until they are polled again, so every
+	// export carries them even when the polling interval is longer than the export interval
+	otelTriggerMetricLock       sync.Mutex
+	otelTriggerMetricValueVals  = map[attribute.Distinct]OtelMetricFloat64Val{}
+	otelTriggerMetricTargetVals = map[attribute.Distinct]OtelMetricFloat64Val{}
 )
 
 type OtelMetrics struct {
@@ -83,6 +105,13 @@ func NewOtelMetrics(options ...metric.Option) *OtelMetrics {
 			return nil
 		}
 		options = []metric.Option{metric.WithReader(metric.NewPeriodicReader(exporter))}
+
+		res, err := newOtelResource()
+		if err != nil {
+			otLog.Error(err, "error creating the opentelemetry resource")
+		} else {
+			options = append(options, metric.WithResource(res))
+		}
 	}
 
 	meterProvider = metric.NewMeterProvider(options...)
@@ -105,6 +134,45 @@ func initMeters() {
 		otLog.Error(err, msg)
 	}
 
+	otScalerCallDuration, err = meter.Float64Histogram(
+		"keda.scaler.call.duration.seconds",
+		api.WithDescription("The distribution of the latency of each call to a scaler, per trigger"),
+		api.WithUnit("s"),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
+	otScaledObjectActivationLatency, err = meter.Float64Histogram(
+		"keda.scaledobject.activation.latency.seconds",
+		api.WithDescription("The distribution of the time from the activation of a ScaledObject scaled to zero until a replica of its scale target is ready"),
+		api.WithUnit("s"),
+		api.WithExplicitBucketBoundaries(1, 2.5, 5, 10, 15, 30, 45, 60, 120, 300, 600),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
+	otScalerCallErrorsCounter, err = meter.Int64Counter("keda.scaler.call.errors", api.WithDescription("Number of failed calls to a scaler, per trigger"))
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
+	otScalerCacheLookupsCounter, err = meter.Int64Counter("keda.scaler.cache.lookups", api.WithDescription("Number of lookups of the scaler metrics in the cache, per trigger. 'result': hit or miss"))
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
+	otScalersCacheEvictionsCounter, err = meter.Int64Counter("keda.internal.scalers.cache.evictions", api.WithDescription("Number of scalable objects whose scalers were evicted from the scalers cache to keep it under its size limit"))
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
+	otScalersCacheRebuildsCounter, err = meter.Int64Counter("keda.internal.scalers.cache.rebuilds", api.WithDescription("Number of scalable objects whose scalers were built again after their eviction from the scalers cache"))
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
 	otScaledObjectErrorsCounter, err = meter.Int64Counter("keda.scaledobject.errors", api.WithDescription("Number of scaled object errors"))
 	if err != nil {
 		otLog.Error(err, msg)
@@ -220,6 +288,77 @@ func initMeters() {
 	if err != nil {
 		otLog.Error(err, msg)
 	}
+
+	_, err = meter.Float64ObservableGauge(
+		"keda.scaled.object.desired.replicas",
+		api.WithDescription("The replicas desired by KEDA for each ScaledObject, computed from the metrics of its triggers like the HPA does"),
+		api.WithFloat64Callback(replicasCallback(&otelScaledObjectDesiredReplicasVals)),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
+	_, err = meter.Float64ObservableGauge(
+		"keda.scaled.object.hpa.desired.replicas",
+		api.WithDescription("The replicas desired by the HPA of each ScaledObject"),
+		api.WithFloat64Callback(replicasCallback(&otelScaledObjectHPADesiredReplicasVals)),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
+	_, err = meter.Float64ObservableGauge(
+		"keda.scaled.object.ready.replicas",
+		api.WithDescription("The ready replicas of the scale target of each ScaledObject"),
+		api.WithFloat64Callback(replicasCallback(&otelScaledObjectReadyReplicasVals)),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
+	_, err = meter.Float64ObservableGauge(
+		"keda.scaled.object.max.replicas",
+		api.WithDescription("The maxReplicaCount of each ScaledObject"),
+		api.WithFloat64Callback(replicasCallback(&otelScaledObjectMaxReplicasVals)),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+
+	_, err = meter.Float64ObservableGauge(
+		"keda.trigger.metric.value",
+		api.WithDescription("The last value of each metric of the triggers of the ScaledObjects"),
+		api.WithFloat64Callback(triggerMetricCallback(otelTriggerMetricValueVals)),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+	_, err = meter.Float64ObservableGauge(
+		"keda.trigger.metric.target",
+		api.WithDescription("The target of each metric of the triggers of the ScaledObjects"),
+		api.WithFloat64Callback(triggerMetricCallback(otelTriggerMetricTargetVals)),
+	)
+	if err != nil {
+		otLog.Error(err, msg)
+	}
+}
+
+// newOtelResource describes the operator exporting the metrics, the attributes
+// can be extended or overridden with OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME
+func newOtelResource() (*resource.Resource, error) {
+	attrs := []attribute.KeyValue{
+		semconv.ServiceName(otelServiceName),
+		semconv.ServiceVersion(version.Version),
+	}
+	if namespace, found := os.LookupEnv("POD_NAMESPACE"); found {
+		attrs = append(attrs, semconv.K8SNamespaceName(namespace))
+	}
+	return resource.New(context.Background(),
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(attrs...),
+		resource.WithFromEnv(),
+	)
 }
 
 func BuildInfoCallback(_ context.Context, obsrv api.Int64Observer) error {
@@ -371,6 +510,79 @@ func (o *OtelMetrics) RecordScaledObjectPaused(namespace string, scaledObject st
 	otelScalerPauseVals = append(otelScalerPauseVals, otelScalerPause)
 }
 
+func replicasCallback(vals *[]OtelMetricFloat64Val) api.Float64Callback {
+	return func(_ context.Context, obsrv api.Float64Observer) error {
+		for _, v := range *vals {
+			obsrv.Observe(v.val, v.measurementOption)
+		}
+		*vals = []OtelMetricFloat64Val{}
+		return nil
+	}
+}
+
+// RecordScaledObjectReplicas create a measurement of the replicas desired by KEDA and by the HPA, the ready replicas
+// of the scale target and the maxReplicaCount of the ScaledObject, a negative value means that it is unknown
+func (o *OtelMetrics) RecordScaledObjectReplicas(namespace string, scaledObject string, desired int32, hpaDesired int32, ready int32, max int32) {
+	opt := api.WithAttributes(
+		attribute.Key("namespace").String(namespace),
+		attribute.Key("scaledObject").String(scaledObject))
+
+	for _, replicas := range []struct {
+		vals  *[]OtelMetricFloat64Val
+		value int32
+	}{
+		{&otelScaledObjectDesiredReplicasVals, desired},
+		{&otelScaledObjectHPADesiredReplicasVals, hpaDesired},
+		{&otelScaledObjectReadyReplicasVals, ready},
+		{&otelScaledObjectMaxReplicasVals, max},
+	} {
+		if replicas.value >= 0 {
+			*replicas.vals = append(*replicas.vals, OtelMetricFloat64Val{val: float64(replicas.value), measurementOption: opt})
+		}
+	}
+}
+
+// RecordScaledObjectActivationLatency create a measurement of the time from the activation of the ScaledObject
+// scaled to zero until a replica of its scale target is ready
+func (o *OtelMetrics) RecordScaledObjectActivationLatency(namespace string, scaledObject string, value time.Duration) {
+	otScaledObjectActivationLatency.Record(context.Background(), value.Seconds(), api.WithAttributes(
+		attribute.Key("namespace").String(namespace),
+		attribute.Key("scaledObject").String(scaledObject)))
+}
+
+func triggerMetricCallback(vals map[attribute.Distinct]OtelMetricFloat64Val) api.Float64Callback {
+	return func(_ context.Context, obsrv api.Float64Observer) error {
+		otelTriggerMetricLock.Lock()
+		defer otelTriggerMetricLock.Unlock()
+		for _, v := range vals {
+			obsrv.Observe(v.val, v.measurementOption)
+		}
+		return nil
+	}
+}
+
+// RecordTriggerMetricValue create a measurement of the value and the target of a metric of a trigger
+// of the ScaledObject, a negative target means that it is unknown
+func (o *OtelMetrics) RecordTriggerMetricValue(namespace string, scaledObject string, triggerType string, triggerName string, triggerIndex int, metric string, value float64, target float64) {
+	attrs := attribute.NewSet(
+		tracing.ScalableObjectKindKey.String("ScaledObject"),
+		tracing.ScalableObjectNamespaceKey.String(namespace),
+		tracing.ScalableObjectNameKey.String(scaledObject),
+		tracing.TriggerTypeKey.String(triggerType),
+		tracing.TriggerNameKey.String(triggerName),
+		tracing.TriggerIndexKey.Int(triggerIndex),
+		tracing.MetricNameKey.String(metric),
+	)
+	opt := api.WithAttributeSet(attrs)
+
+	otelTriggerMetricLock.Lock()
+	defer otelTriggerMetricLock.Unlock()
+	otelTriggerMetricValueVals[attrs.Equivalent()] = OtelMetricFloat64Val{val: value, measurementOption: opt}
+	if target >= 0 {
+		otelTriggerMetricTargetVals[attrs.Equivalent()] = OtelMetricFloat64Val{val: target, measurementOption: opt}
+	}
+}
+
 // RecordScalerError counts the number of errors occurred in trying to get an external metric used by the HPA
 func (o *OtelMetrics) RecordScalerError(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, err error) {
 	if err != nil {
@@ -380,6 +592,34 @@ func (o *OtelMetrics) RecordScalerError(namespace string, scaledResource string,
 	}
 }
 
+// RecordScalerCall create a measurement of the latency and the error, if any, of a single call to the scaler
+func (o *OtelMetrics) RecordScalerCall(namespace string, scaledResource string, triggerType string, triggerIndex int, isScaledObject bool, value time.Duration, err error) {
+	attrs := getTriggerAttributes(namespace, scaledResource, triggerType, triggerIndex, isScaledObject)
+	if value >= 0 {
+		otScalerCallDuration.Record(context.Background(), value.Seconds(), api.WithAttributes(attrs...))
+	}
+	if err != nil {
+		otScalerCallErrorsCounter.Add(context.Background(), 1, api.WithAttributes(attrs...))
+	}
+}
+
+// RecordScalerCacheHit counts the lookups of the scaler metrics in the cache, hit or miss
+func (o *OtelMetrics) RecordScalerCacheHit(namespace string, scaledResource string, triggerType string, triggerIndex int, isScaledObject bool, hit bool) {
+	attrs := getTriggerAttributes(namespace, scaledResource, triggerType, triggerIndex, isScaledObject)
+	attrs = append(attrs, attribute.Key("result").String(getCacheResult(hit)))
+	otScalerCacheLookupsCounter.Add(context.Background(), 1, api.WithAttributes(attrs...))
+}
+
+// RecordScalersCacheEviction counts the scalers of the scalable objects evicted from the scalers cache
+func (o *OtelMetrics) RecordScalersCacheEviction(isScaledObject bool) {
+	otScalersCacheEvictionsCounter.Add(context.Background(), 1, api.WithAttributes(attribute.Key("type").String(getResourceType(isScaledObject))))
+}
+
+// RecordScalersCacheRebuild counts the scalers of the scalable objects built again after their eviction
+func (o *OtelMetrics) RecordScalersCacheRebuild(isScaledObject bool) {
+	otScalersCacheRebuildsCounter.Add(context.Background(), 1, api.WithAttributes(attribute.Key("type").String(getResourceType(isScaledObject))))
+}
+
 // RecordScaledObjectError counts the number of errors with the scaled object
 func (o *OtelMetrics) RecordScaledObjectError(namespace string, scaledObject string, err error) {
 	opt := api.WithAttributes(
@@ -461,6 +701,19 @@ func getScalerMeasurementOption(namespace string, scaledResource string, scaler
 	)
 }
 
+func getTriggerAttributes(namespace string, scaledResource string, triggerType string, triggerIndex int, isScaledObject bool) []attribute.KeyValue {
+	resourceKey := "scaledJob"
+	if isScaledObject {
+		resourceKey = "scaledObject"
+	}
+	return []attribute.KeyValue{
+		attribute.Key("namespace").String(namespace),
+		attribute.Key(resourceKey).String(scaledResource),
+		attribute.Key("triggerType").String(triggerType),
+		attribute.Key("triggerIndex").String(strconv.Itoa(triggerIndex)),
+	}
+}
+
 // RecordCloudEventEmitted counts the number of cloudevent that emitted to user's sink
 func (o *OtelMetrics) RecordCloudEventEmitted(namespace string, cloudeventsource string, eventsink string) {
 	opt := api.WithAttributes(
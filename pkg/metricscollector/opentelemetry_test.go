@@ -2,6 +2,7 @@ package metricscollector
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -155,3 +156,161 @@ func TestContinuousMetrics(t *testing.T) {
 	assert.Equal(t, attribute.AsString(), "testmetric")
 	assert.Equal(t, scaledJobMetric.Value, 0.0)
 }
+
+func TestScalerCallMetrics(t *testing.T) {
+	testOtel.RecordScalerCall("testnamespace", "testresource", "kafka", 1, true, 200*time.Millisecond, nil)
+	testOtel.RecordScalerCall("testnamespace", "testresource", "kafka", 1, true, 800*time.Millisecond, errors.New("timeout"))
+	testOtel.RecordScalerCall("testnamespace", "testresource", "kafka", 1, true, -1, errors.New("not found"))
+	testOtel.RecordScalerCacheHit("testnamespace", "testresource", "kafka", 1, true, true)
+	testOtel.RecordScalerCacheHit("testnamespace", "testresource", "kafka", 1, true, false)
+	testOtel.RecordScalerCacheHit("testnamespace", "testresource", "kafka", 1, true, true)
+	got := metricdata.ResourceMetrics{}
+	err := testReader.Collect(context.Background(), &got)
+
+	assert.Nil(t, err)
+	scopeMetrics := got.ScopeMetrics[0]
+
+	duration := retrieveMetric(scopeMetrics.Metrics, "keda.scaler.call.duration.seconds")
+	assert.NotNil(t, duration)
+	assert.Equal(t, duration.Unit, "s")
+	histogram := duration.Data.(metricdata.Histogram[float64]).DataPoints[0]
+	assert.Equal(t, histogram.Count, uint64(2))
+	assert.InDelta(t, histogram.Sum, 1.0, 0.0001)
+	attribute, _ := histogram.Attributes.Value("triggerType")
+	assert.Equal(t, attribute.AsString(), "kafka")
+	attribute, _ = histogram.Attributes.Value("triggerIndex")
+	assert.Equal(t, attribute.AsString(), "1")
+	attribute, _ = histogram.Attributes.Value("scaledObject")
+	assert.Equal(t, attribute.AsString(), "testresource")
+
+	callErrors := retrieveMetric(scopeMetrics.Metrics, "keda.scaler.call.errors")
+	assert.NotNil(t, callErrors)
+	assert.Equal(t, callErrors.Data.(metricdata.Sum[int64]).DataPoints[0].Value, int64(2))
+
+	cacheLookups := retrieveMetric(scopeMetrics.Metrics, "keda.scaler.cache.lookups")
+	assert.NotNil(t, cacheLookups)
+	results := map[string]int64{}
+	for _, v := range cacheLookups.Data.(metricdata.Sum[int64]).DataPoints {
+		attribute, _ := v.Attributes.Value("result")
+		results[attribute.AsString()] = v.Value
+	}
+	assert.Equal(t, results, map[string]int64{"hit": 2, "miss": 1})
+}
+
+func TestScalersCacheEvictions(t *testing.T) {
+	testOtel.RecordScalersCacheEviction(true)
+	testOtel.RecordScalersCacheEviction(true)
+	testOtel.RecordScalersCacheEviction(false)
+	testOtel.RecordScalersCacheRebuild(true)
+	got := metricdata.ResourceMetrics{}
+	err := testReader.Collect(context.Background(), &got)
+
+	assert.Nil(t, err)
+	scopeMetrics := got.ScopeMetrics[0]
+
+	evictions := retrieveMetric(scopeMetrics.Metrics, "keda.internal.scalers.cache.evictions")
+	assert.NotNil(t, evictions)
+	types := map[string]int64{}
+	for _, v := range evictions.Data.(metricdata.Sum[int64]).DataPoints {
+		attribute, _ := v.Attributes.Value("type")
+		types[attribute.AsString()] = v.Value
+	}
+	assert.Equal(t, types, map[string]int64{"scaledobject": 2, "scaledjob": 1})
+
+	rebuilds := retrieveMetric(scopeMetrics.Metrics, "keda.internal.scalers.cache.rebuilds")
+	assert.NotNil(t, rebuilds)
+	assert.Equal(t, rebuilds.Data.(metricdata.Sum[int64]).DataPoints[0].Value, int64(1))
+}
+
+func TestScaledObjectReplicas(t *testing.T) {
+	testOtel.RecordScaledObjectReplicas("testnamespace", "testresource", 8, -1, 3, 10)
+	got := metricdata.ResourceMetrics{}
+	err := testReader.Collect(context.Background(), &got)
+
+	assert.Nil(t, err)
+	scopeMetrics := got.ScopeMetrics[0]
+
+	desired := retrieveMetric(scopeMetrics.Metrics, "keda.scaled.object.desired.replicas")
+	assert.NotNil(t, desired)
+	data := desired.Data.(metricdata.Gauge[float64]).DataPoints[0]
+	assert.Equal(t, data.Value, 8.0)
+	attribute, _ := data.Attributes.Value("scaledObject")
+	assert.Equal(t, attribute.AsString(), "testresource")
+
+	ready := retrieveMetric(scopeMetrics.Metrics, "keda.scaled.object.ready.replicas")
+	assert.NotNil(t, ready)
+	assert.Equal(t, ready.Data.(metricdata.Gauge[float64]).DataPoints[0].Value, 3.0)
+
+	maxReplicas := retrieveMetric(scopeMetrics.Metrics, "keda.scaled.object.max.replicas")
+	assert.NotNil(t, maxReplicas)
+	assert.Equal(t, maxReplicas.Data.(metricdata.Gauge[float64]).DataPoints[0].Value, 10.0)
+
+	// an unknown value is not reported
+	assert.Nil(t, retrieveMetric(scopeMetrics.Metrics, "keda.scaled.object.hpa.desired.replicas"))
+}
+
+func TestTriggerMetricValue(t *testing.T) {
+	testOtel.RecordTriggerMetricValue("testnamespace", "testresource", "rabbitmq", "queue", 0, "s0-rabbitmq-orders", 12, 5)
+	testOtel.RecordTriggerMetricValue("testnamespace", "testresource", "rabbitmq", "queue", 0, "s0-rabbitmq-orders", 20, 5)
+	testOtel.RecordTriggerMetricValue("testnamespace", "testresource", "cron", "office-hours", 1, "s1-cron-UTC", 1, -1)
+
+	// the values are reported again on every collection until they are polled again
+	for i := 0; i < 2; i++ {
+		got := metricdata.ResourceMetrics{}
+		err := testReader.Collect(context.Background(), &got)
+
+		assert.Nil(t, err)
+		scopeMetrics := got.ScopeMetrics[0]
+
+		value := retrieveMetric(scopeMetrics.Metrics, "keda.trigger.metric.value")
+		assert.NotNil(t, value)
+		values := map[string]float64{}
+		for _, v := range value.Data.(metricdata.Gauge[float64]).DataPoints {
+			attribute, _ := v.Attributes.Value("keda.metric.name")
+			values[attribute.AsString()] = v.Value
+		}
+		assert.Equal(t, values, map[string]float64{"s0-rabbitmq-orders": 20, "s1-cron-UTC": 1})
+
+		target := retrieveMetric(scopeMetrics.Metrics, "keda.trigger.metric.target")
+		assert.NotNil(t, target)
+		data := target.Data.(metricdata.Gauge[float64]).DataPoints
+		assert.Len(t, data, 1)
+		assert.Equal(t, data[0].Value, 5.0)
+		attribute, _ := data[0].Attributes.Value("keda.trigger.type")
+		assert.Equal(t, attribute.AsString(), "rabbitmq")
+		attribute, _ = data[0].Attributes.Value("keda.scalableobject.name")
+		assert.Equal(t, attribute.AsString(), "testresource")
+	}
+}
+
+func TestOtelResource(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keda")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "k8s.cluster.name=test")
+
+	res, err := newOtelResource()
+	assert.Nil(t, err)
+	attributes := res.Set()
+	value, _ := attributes.Value("service.name")
+	assert.Equal(t, value.AsString(), "keda-operator")
+	value, _ = attributes.Value("k8s.namespace.name")
+	assert.Equal(t, value.AsString(), "keda")
+	value, _ = attributes.Value("k8s.cluster.name")
+	assert.Equal(t, value.AsString(), "test")
+}
+
+func TestScaledObjectActivationLatency(t *testing.T) {
+	testOtel.RecordScaledObjectActivationLatency("testnamespace", "testresource", 12*time.Second)
+	got := metricdata.ResourceMetrics{}
+	err := testReader.Collect(context.Background(), &got)
+
+	assert.Nil(t, err)
+	scopeMetrics := got.ScopeMetrics[0]
+
+	latency := retrieveMetric(scopeMetrics.Metrics, "keda.scaledobject.activation.latency.seconds")
+	assert.NotNil(t, latency)
+	data := latency.Data.(metricdata.Histogram[float64]).DataPoints[0]
+	assert.Equal(t, data.Count, uint64(1))
+	assert.Equal(t, data.Sum, 12.0)
+	attribute, _ := data.Attributes.Value("scaledObject")
+	assert.Equal(t, attribute.AsString(), "testresource")
+}
@@ -37,8 +37,9 @@ const bestPracticeDeprecatedMsg = "DEPRECATED - will be removed in 2.16:"
 var log = logf.Log.WithName("prometheus_server")
 
 var (
-	metricLabels = []string{"namespace", "metric", "scaledObject", "scaler", "triggerIndex", "type"}
-	buildInfo    = prometheus.NewGaugeVec(
+	metricLabels  = []string{"namespace", "metric", "scaledObject", "scaler", "triggerIndex", "type"}
+	triggerLabels = []string{"namespace", "scaledObject", "triggerType", "triggerIndex", "type"}
+	buildInfo     = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: DefaultPromMetricsNamespace,
 			Name:      "build_info",
@@ -73,6 +74,34 @@ var (
 		},
 		metricLabels,
 	)
+	scalerCallDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaler",
+			Name:      "call_duration_seconds",
+			Help:      "The distribution of the latency of each call to a scaler, per trigger, in seconds.",
+			Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		triggerLabels,
+	)
+	scalerCallErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaler",
+			Name:      "call_errors_total",
+			Help:      "The total number of failed calls to a scaler, per trigger.",
+		},
+		triggerLabels,
+	)
+	scalerCacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaler",
+			Name:      "cache_lookups_total",
+			Help:      "The total number of lookups of the scaler metrics in the cache, per trigger. 'result': hit or miss",
+		},
+		append(triggerLabels, "result"),
+	)
 	scalerActive = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: DefaultPromMetricsNamespace,
@@ -91,6 +120,52 @@ var (
 		},
 		[]string{"namespace", "scaledObject"},
 	)
+	scaledObjectDesiredReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaled_object",
+			Name:      "desired_replicas",
+			Help:      "The replicas desired by KEDA for each ScaledObject, computed from the metrics of its triggers like the HPA does.",
+		},
+		[]string{"namespace", "scaledObject"},
+	)
+	scaledObjectHPADesiredReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaled_object",
+			Name:      "hpa_desired_replicas",
+			Help:      "The replicas desired by the HPA of each ScaledObject.",
+		},
+		[]string{"namespace", "scaledObject"},
+	)
+	scaledObjectReadyReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaled_object",
+			Name:      "ready_replicas",
+			Help:      "The ready replicas of the scale target of each ScaledObject.",
+		},
+		[]string{"namespace", "scaledObject"},
+	)
+	scaledObjectMaxReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaled_object",
+			Name:      "max_replicas",
+			Help:      "The maxReplicaCount of each ScaledObject.",
+		},
+		[]string{"namespace", "scaledObject"},
+	)
+	scaledObjectActivationLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "scaled_object",
+			Name:      "activation_latency_seconds",
+			Help:      "The distribution of the time from the activation of a ScaledObject scaled to zero until a replica of its scale target is ready.",
+			Buckets:   []float64{1, 2.5, 5, 10, 15, 30, 45, 60, 120, 300, 600},
+		},
+		[]string{"namespace", "scaledObject"},
+	)
 	scalerErrorsDeprecated = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: DefaultPromMetricsNamespace,
@@ -200,6 +275,24 @@ var (
 		},
 		[]string{"namespace", "type", "resource"},
 	)
+	internalScalersCacheEvictions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "internal_scalers_cache",
+			Name:      "evictions_total",
+			Help:      "The total number of scalable objects whose scalers were evicted from the scalers cache to keep it under its size limit.",
+		},
+		[]string{"type"},
+	)
+	internalScalersCacheRebuilds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: DefaultPromMetricsNamespace,
+			Subsystem: "internal_scalers_cache",
+			Name:      "rebuilds_total",
+			Help:      "The total number of scalable objects whose scalers were built again after their eviction from the scalers cache.",
+		},
+		[]string{"type"},
+	)
 	internalLoopLatency = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: DefaultPromMetricsNamespace,
@@ -242,12 +335,22 @@ func NewPromMetrics() *PromMetrics {
 	metrics.Registry.MustRegister(scalerMetricsLatency)
 	metrics.Registry.MustRegister(internalLoopLatencyDeprecated)
 	metrics.Registry.MustRegister(internalLoopLatency)
+	metrics.Registry.MustRegister(internalScalersCacheEvictions)
+	metrics.Registry.MustRegister(internalScalersCacheRebuilds)
+	metrics.Registry.MustRegister(scalerCallDuration)
+	metrics.Registry.MustRegister(scalerCallErrors)
+	metrics.Registry.MustRegister(scalerCacheLookups)
 	metrics.Registry.MustRegister(scalerActive)
 	metrics.Registry.MustRegister(scalerErrorsDeprecated)
 	metrics.Registry.MustRegister(scalerErrors)
 	metrics.Registry.MustRegister(scaledObjectErrorsDeprecated)
 	metrics.Registry.MustRegister(scaledObjectErrors)
 	metrics.Registry.MustRegister(scaledObjectPaused)
+	metrics.Registry.MustRegister(scaledObjectActivationLatency)
+	metrics.Registry.MustRegister(scaledObjectDesiredReplicas)
+	metrics.Registry.MustRegister(scaledObjectHPADesiredReplicas)
+	metrics.Registry.MustRegister(scaledObjectReadyReplicas)
+	metrics.Registry.MustRegister(scaledObjectMaxReplicas)
 	metrics.Registry.MustRegister(triggerRegistered)
 	metrics.Registry.MustRegister(crdRegistered)
 	metrics.Registry.MustRegister(scaledJobErrorsDeprecated)
@@ -280,6 +383,39 @@ func (p *PromMetrics) RecordScalerLatency(namespace string, scaledResource strin
 	scalerMetricsLatencyDeprecated.With(getLabels(namespace, scaledResource, scaler, triggerIndex, metric, isScaledObject)).Set(float64(value.Milliseconds()))
 }
 
+// RecordScalerCall create a measurement of the latency and the error, if any, of a single call to the scaler
+func (p *PromMetrics) RecordScalerCall(namespace string, scaledResource string, triggerType string, triggerIndex int, isScaledObject bool, value time.Duration, err error) {
+	labels := getTriggerLabels(namespace, scaledResource, triggerType, triggerIndex, isScaledObject)
+	if value >= 0 {
+		scalerCallDuration.With(labels).Observe(value.Seconds())
+	}
+	if err != nil {
+		scalerCallErrors.With(labels).Inc()
+		return
+	}
+	// initialize metric with 0 if not already set
+	if _, errcall := scalerCallErrors.GetMetricWith(labels); errcall != nil {
+		log.Error(errcall, "Unable to record metrics: %v")
+	}
+}
+
+// RecordScalerCacheHit counts the lookups of the scaler metrics in the cache, hit or miss
+func (p *PromMetrics) RecordScalerCacheHit(namespace string, scaledResource string, triggerType string, triggerIndex int, isScaledObject bool, hit bool) {
+	labels := getTriggerLabels(namespace, scaledResource, triggerType, triggerIndex, isScaledObject)
+	labels["result"] = getCacheResult(hit)
+	scalerCacheLookups.With(labels).Inc()
+}
+
+// RecordScalersCacheEviction counts the scalers of the scalable objects evicted from the scalers cache
+func (p *PromMetrics) RecordScalersCacheEviction(isScaledObject bool) {
+	internalScalersCacheEvictions.WithLabelValues(getResourceType(isScaledObject)).Inc()
+}
+
+// RecordScalersCacheRebuild counts the scalers of the scalable objects built again after their eviction
+func (p *PromMetrics) RecordScalersCacheRebuild(isScaledObject bool) {
+	internalScalersCacheRebuilds.WithLabelValues(getResourceType(isScaledObject)).Inc()
+}
+
 // RecordScalableObjectLatency create a measurement of the latency executing scalable object loop
 func (p *PromMetrics) RecordScalableObjectLatency(namespace string, name string, isScaledObject bool, value time.Duration) {
 	internalLoopLatency.WithLabelValues(namespace, getResourceType(isScaledObject), name).Set(value.Seconds())
@@ -308,6 +444,36 @@ func (p *PromMetrics) RecordScaledObjectPaused(namespace string, scaledObject st
 	scaledObjectPaused.With(labels).Set(float64(activeVal))
 }
 
+// RecordScaledObjectReplicas create a measurement of the replicas desired by KEDA and by the HPA, the ready replicas
+// of the scale target and the maxReplicaCount of the ScaledObject, a negative value means that it is unknown
+func (p *PromMetrics) RecordScaledObjectReplicas(namespace string, scaledObject string, desired int32, hpaDesired int32, ready int32, max int32) {
+	labels := prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject}
+	for _, replicas := range []struct {
+		gauge *prometheus.GaugeVec
+		value int32
+	}{
+		{scaledObjectDesiredReplicas, desired},
+		{scaledObjectHPADesiredReplicas, hpaDesired},
+		{scaledObjectReadyReplicas, ready},
+		{scaledObjectMaxReplicas, max},
+	} {
+		if replicas.value >= 0 {
+			replicas.gauge.With(labels).Set(float64(replicas.value))
+		}
+	}
+}
+
+// RecordScaledObjectActivationLatency create a measurement of the time from the activation of the ScaledObject
+// scaled to zero until a replica of its scale target is ready
+func (p *PromMetrics) RecordScaledObjectActivationLatency(namespace string, scaledObject string, value time.Duration) {
+	scaledObjectActivationLatency.With(prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject}).Observe(value.Seconds())
+}
+
+// RecordTriggerMetricValue is a no-op, the values of the trigger metrics are already exposed by
+// keda_scaler_metrics_value and their targets are scraped from the HPAs
+func (p *PromMetrics) RecordTriggerMetricValue(string, string, string, string, int, string, float64, float64) {
+}
+
 // RecordScalerError counts the number of errors occurred in trying to get an external metric used by the HPA
 func (p *PromMetrics) RecordScalerError(namespace string, scaledResource string, scaler string, triggerIndex int, metric string, isScaledObject bool, err error) {
 	if err != nil {
@@ -369,6 +535,17 @@ func getLabels(namespace string, scaledObject string, scaler string, triggerInde
 	return prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject, "scaler": scaler, "triggerIndex": strconv.Itoa(triggerIndex), "metric": metric, "type": getResourceType(isScaledObject)}
 }
 
+func getTriggerLabels(namespace string, scaledObject string, triggerType string, triggerIndex int, isScaledObject bool) prometheus.Labels {
+	return prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject, "triggerType": triggerType, "triggerIndex": strconv.Itoa(triggerIndex), "type": getResourceType(isScaledObject)}
+}
+
+func getCacheResult(hit bool) string {
+	if hit {
+		return "hit"
+	}
+	return "miss"
+}
+
 func getResourceType(isScaledObject bool) string {
 	if isScaledObject {
 		return "scaledobject"
@@ -0,0 +1,299 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metricshistory retains the last samples of the metrics of the triggers of
+// the ScaledObjects, they are used by the last known good fallback and can be persisted
+// so they survive a restart of the operator
+package metricshistory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("metrics_history")
+
+const storeTimeout = 10 * time.Second
+
+// Sample is a value of a metric of a trigger
+type Sample struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+}
+
+// Options configure the metrics history
+type Options struct {
+	// Size is the number of samples retained for each metric
+	Size int
+	// ConfigMapName prefixes the ConfigMaps the history is persisted to, one for each ScaledObject. The
+	// history is kept in memory only if empty
+	ConfigMapName string
+	// Namespace is the namespace of the ConfigMap
+	Namespace string
+	// PersistInterval is how often the history is persisted when it changed
+	PersistInterval time.Duration
+}
+
+// Store persists the history, the samples are grouped by ScaledObject and then by metric
+type Store interface {
+	Load(ctx context.Context) (map[string]map[string][]Sample, error)
+	// Save persists the history of a ScaledObject, leaving the histories of the others as they are
+	Save(ctx context.Context, key string, metrics map[string][]Sample) error
+	// Delete drops the persisted history of a ScaledObject
+	Delete(ctx context.Context, key string) error
+}
+
+// ring is a fixed size buffer of the last samples of a metric
+type ring struct {
+	samples []Sample
+	next    int
+	full    bool
+}
+
+var (
+	lock      sync.RWMutex
+	size      int
+	histories map[string]map[string]*ring
+	// dirty are the keys of the ScaledObjects whose history changed since it has been persisted last
+	dirty map[string]bool
+)
+
+// NewMetricsHistory starts retaining the last samples of the metrics, the history is loaded from
+// the ConfigMap configured by the options and persisted to it in the background.
+// It returns a function persisting the history one last time on shutdown.
+func NewMetricsHistory(opts Options, kubeClient kubernetes.Interface) (func() error, error) {
+	if opts.Size <= 0 {
+		return nil, fmt.Errorf("the size of the metrics history must be positive, got %d", opts.Size)
+	}
+	if opts.ConfigMapName == "" {
+		start(opts.Size, nil)
+		return stop, nil
+	}
+	if opts.PersistInterval <= 0 {
+		return nil, fmt.Errorf("the persist interval of the metrics history must be positive, got %s", opts.PersistInterval)
+	}
+	store := newConfigMapStore(kubeClient, opts.Namespace, opts.ConfigMapName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	loaded, err := store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading the metrics history: %w", err)
+	}
+	start(opts.Size, loaded)
+	return persist(store, opts.PersistInterval), nil
+}
+
+func start(historySize int, loaded map[string]map[string][]Sample) {
+	lock.Lock()
+	defer lock.Unlock()
+	size = historySize
+	histories = make(map[string]map[string]*ring, len(loaded))
+	dirty = map[string]bool{}
+	for key, metrics := range loaded {
+		for metricName, samples := range metrics {
+			for _, sample := range samples {
+				record(key, metricName, sample)
+			}
+		}
+	}
+}
+
+func stop() error {
+	lock.Lock()
+	defer lock.Unlock()
+	histories = nil
+	return nil
+}
+
+func persist(store Store, interval time.Duration) func() error {
+	done := make(chan struct{})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := save(store); err != nil {
+					log.Error(err, "error persisting the metrics history")
+				}
+			}
+		}
+	}()
+
+	return func() error {
+		close(done)
+		wg.Wait()
+		err := save(store)
+		_ = stop()
+		return err
+	}
+}
+
+// save persists the histories of the ScaledObjects which changed since they have been persisted last,
+// the deleted ones are dropped
+func save(store Store) error {
+	lock.Lock()
+	if len(dirty) == 0 {
+		lock.Unlock()
+		return nil
+	}
+	snapshot := make(map[string]map[string][]Sample, len(dirty))
+	for key := range dirty {
+		metrics, found := histories[key]
+		if !found {
+			snapshot[key] = nil
+			continue
+		}
+		snapshot[key] = make(map[string][]Sample, len(metrics))
+		for metricName, r := range metrics {
+			snapshot[key][metricName] = r.list()
+		}
+	}
+	dirty = map[string]bool{}
+	lock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	var errs []error
+	for key, metrics := range snapshot {
+		var err error
+		if metrics == nil {
+			err = store.Delete(ctx, key)
+		} else {
+			err = store.Save(ctx, key, metrics)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error persisting the metrics history of %s: %w", key, err))
+			lock.Lock()
+			dirty[key] = true
+			lock.Unlock()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Enabled returns whether the metrics history has been set up for this component
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return histories != nil
+}
+
+// Record retains the value of the metric of the ScaledObject, the oldest sample is dropped
+// once the history of the metric is full
+func Record(namespace, scaledObject, metricName string, value float64, timestamp time.Time) {
+	lock.Lock()
+	defer lock.Unlock()
+	if histories == nil {
+		return
+	}
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	key := historyKey(namespace, scaledObject)
+	record(key, metricName, Sample{Timestamp: timestamp, Value: value})
+	dirty[key] = true
+}
+
+func record(key, metricName string, sample Sample) {
+	metrics, found := histories[key]
+	if !found {
+		metrics = map[string]*ring{}
+		histories[key] = metrics
+	}
+	r, found := metrics[metricName]
+	if !found {
+		r = &ring{samples: make([]Sample, size)}
+		metrics[metricName] = r
+	}
+	r.add(sample)
+}
+
+// Last returns the latest sample of the metric of the ScaledObject
+func Last(namespace, scaledObject, metricName string) (Sample, bool) {
+	lock.RLock()
+	defer lock.RUnlock()
+	r, found := histories[historyKey(namespace, scaledObject)][metricName]
+	if !found {
+		return Sample{}, false
+	}
+	return r.last()
+}
+
+// Get returns the samples of the metrics of the ScaledObject from the oldest to the latest
+func Get(namespace, scaledObject string) map[string][]Sample {
+	lock.RLock()
+	defer lock.RUnlock()
+	metrics, found := histories[historyKey(namespace, scaledObject)]
+	if !found {
+		return nil
+	}
+	result := make(map[string][]Sample, len(metrics))
+	for metricName, r := range metrics {
+		result[metricName] = r.list()
+	}
+	return result
+}
+
+// Delete drops the history of the ScaledObject
+func Delete(namespace, scaledObject string) {
+	lock.Lock()
+	defer lock.Unlock()
+	key := historyKey(namespace, scaledObject)
+	if _, found := histories[key]; found {
+		delete(histories, key)
+		dirty[key] = true
+	}
+}
+
+// historyKey identifies a ScaledObject, it is a valid key of a ConfigMap
+func historyKey(namespace, scaledObject string) string {
+	return namespace + "." + scaledObject
+}
+
+func (r *ring) add(sample Sample) {
+	r.samples[r.next] = sample
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *ring) last() (Sample, bool) {
+	if !r.full && r.next == 0 {
+		return Sample{}, false
+	}
+	return r.samples[(r.next-1+len(r.samples))%len(r.samples)], true
+}
+
+func (r *ring) list() []Sample {
+	if !r.full {
+		return append([]Sample(nil), r.samples[:r.next]...)
+	}
+	return append(append([]Sample(nil), r.samples[r.next:]...), r.samples[:r.next]...)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricshistory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRecord(t *testing.T) {
+	start(3, nil)
+	defer func() { _ = stop() }()
+
+	_, found := Last("default", "app", "s0-queue")
+	assert.False(t, found)
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		Record("default", "app", "s0-queue", float64(i), now.Add(time.Duration(i)*time.Second))
+	}
+	Record("default", "app", "s1-lag", 42, time.Time{})
+
+	last, found := Last("default", "app", "s0-queue")
+	assert.True(t, found)
+	assert.Equal(t, 4.0, last.Value)
+
+	history := Get("default", "app")
+	assert.Len(t, history, 2)
+	assert.Equal(t, []float64{2, 3, 4}, values(history["s0-queue"]))
+	assert.False(t, history["s1-lag"][0].Timestamp.IsZero())
+
+	Delete("default", "app")
+	assert.Nil(t, Get("default", "app"))
+}
+
+func TestRecordDisabled(t *testing.T) {
+	assert.False(t, Enabled())
+	Record("default", "app", "s0-queue", 1, time.Now())
+	_, found := Last("default", "app", "s0-queue")
+	assert.False(t, found)
+}
+
+func TestPersist(t *testing.T) {
+	store := &configMapStore{namespace: "keda", name: "keda-metrics-history"}
+	kubeClient := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      store.configMapName("default.app"),
+			Namespace: "keda",
+			Labels:    map[string]string{historyLabel: "keda-metrics-history"},
+		},
+		Data: map[string]string{
+			"default.app":    `{"s0-queue":[{"timestamp":"2024-01-01T00:00:00Z","value":7}]}`,
+			"default.broken": `{`,
+		},
+	}, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "keda"},
+		Data:       map[string]string{"default.other": `{"s0-queue":[{"timestamp":"2024-01-01T00:00:00Z","value":1}]}`},
+	})
+	shutdown, err := NewMetricsHistory(Options{Size: 2, ConfigMapName: "keda-metrics-history", Namespace: "keda", PersistInterval: time.Hour}, kubeClient)
+	assert.NoError(t, err)
+	assert.True(t, Enabled())
+
+	last, found := Last("default", "app", "s0-queue")
+	assert.True(t, found)
+	assert.Equal(t, 7.0, last.Value)
+	assert.Nil(t, Get("default", "broken"))
+	assert.Nil(t, Get("default", "other"))
+
+	Record("default", "app", "s0-queue", 8, time.Now())
+	Record("default", "worker", "s0-lag", 3, time.Now())
+	assert.NoError(t, shutdown())
+	assert.False(t, Enabled())
+
+	configMap, err := kubeClient.CoreV1().ConfigMaps("keda").Get(context.TODO(), store.configMapName("default.app"), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, configMap.Data["default.app"], `"value":8`)
+	configMap, err = kubeClient.CoreV1().ConfigMaps("keda").Get(context.TODO(), store.configMapName("default.worker"), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, configMap.Data["default.worker"], `"value":3`)
+
+	// the history loaded back is the one persisted, the deleted ScaledObjects are dropped
+	shutdown, err = NewMetricsHistory(Options{Size: 2, ConfigMapName: "keda-metrics-history", Namespace: "keda", PersistInterval: time.Hour}, kubeClient)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{7, 8}, values(Get("default", "app")["s0-queue"]))
+	Delete("default", "worker")
+	assert.NoError(t, shutdown())
+	_, err = kubeClient.CoreV1().ConfigMaps("keda").Get(context.TODO(), store.configMapName("default.worker"), metav1.GetOptions{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestConfigMapStoreMergesKeys(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	store := &configMapStore{client: kubeClient, namespace: "keda", name: "keda-metrics-history"}
+	name := store.configMapName("default.app")
+	samples := map[string][]Sample{"s0-queue": {{Timestamp: time.Now(), Value: 1}}}
+
+	// another replica persisted a history whose key hashes the same
+	_, err := kubeClient.CoreV1().ConfigMaps("keda").Create(context.TODO(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "keda", Labels: map[string]string{historyLabel: "keda-metrics-history"}},
+		Data:       map[string]string{"default.other": `{}`},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Save(context.TODO(), "default.app", samples))
+	configMap, err := kubeClient.CoreV1().ConfigMaps("keda").Get(context.TODO(), name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, configMap.Data, 2)
+
+	assert.NoError(t, store.Delete(context.TODO(), "default.app"))
+	configMap, err = kubeClient.CoreV1().ConfigMaps("keda").Get(context.TODO(), name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"default.other": `{}`}, configMap.Data)
+}
+
+func TestNewMetricsHistoryInvalid(t *testing.T) {
+	_, err := NewMetricsHistory(Options{Size: 0}, nil)
+	assert.Error(t, err)
+	_, err = NewMetricsHistory(Options{Size: 10, ConfigMapName: "keda-metrics-history"}, nil)
+	assert.Error(t, err)
+}
+
+func values(samples []Sample) []float64 {
+	result := make([]float64, 0, len(samples))
+	for _, sample := range samples {
+		result = append(result, sample.Value)
+	}
+	return result
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricshistory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// +kubebuilder:rbac:groups="",namespace=keda,resources=configmaps,verbs=get;create;update;delete
+
+// historyLabel labels the ConfigMaps of the history with its name
+const historyLabel = "keda.sh/metrics-history"
+
+// configMapStore persists the history to a ConfigMap for each ScaledObject, named after the
+// ScaledObject and holding the samples of its metrics as JSON under its key. The ConfigMaps are
+// updated key by key, so the replicas of the operator don't overwrite the histories of each other.
+type configMapStore struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+func newConfigMapStore(client kubernetes.Interface, namespace, name string) Store {
+	return &configMapStore{client: client, namespace: namespace, name: name}
+}
+
+// configMapName returns the ConfigMap the history of the ScaledObject is persisted to, the key is
+// hashed so the name is valid whatever the length of the namespace and of the ScaledObject name
+func (s *configMapStore) configMapName(key string) string {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(key))
+	return fmt.Sprintf("%s-%x", s.name, hash.Sum64())
+}
+
+func (s *configMapStore) Load(ctx context.Context) (map[string]map[string][]Sample, error) {
+	configMaps, err := s.client.CoreV1().ConfigMaps(s.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", historyLabel, s.name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	histories := map[string]map[string][]Sample{}
+	for _, configMap := range configMaps.Items {
+		for key, data := range configMap.Data {
+			metrics := map[string][]Sample{}
+			if err := json.Unmarshal([]byte(data), &metrics); err != nil {
+				// a corrupted entry only loses the history of its ScaledObject
+				log.Error(err, "error decoding the metrics history, dropping it", "configMap", configMap.Name, "key", key)
+				continue
+			}
+			histories[key] = metrics
+		}
+	}
+	return histories, nil
+}
+
+func (s *configMapStore) Save(ctx context.Context, key string, metrics map[string][]Sample) error {
+	encoded, err := json.Marshal(metrics)
+	if err != nil {
+		return fmt.Errorf("error encoding the metrics history of %s: %w", key, err)
+	}
+
+	configMaps := s.client.CoreV1().ConfigMaps(s.namespace)
+	name := s.configMapName(key)
+	return retry.OnError(retry.DefaultRetry, isConflict, func() error {
+		configMap, err := configMaps.Get(ctx, name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			_, err = configMaps.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: s.namespace,
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "keda-operator",
+						historyLabel:                   s.name,
+					},
+				},
+				Data: map[string]string{key: string(encoded)},
+			}, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[key] = string(encoded)
+		_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func (s *configMapStore) Delete(ctx context.Context, key string) error {
+	configMaps := s.client.CoreV1().ConfigMaps(s.namespace)
+	name := s.configMapName(key)
+	return retry.OnError(retry.DefaultRetry, isConflict, func() error {
+		configMap, err := configMaps.Get(ctx, name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, found := configMap.Data[key]; !found {
+			return nil
+		}
+		delete(configMap.Data, key)
+		if len(configMap.Data) > 0 {
+			// the ConfigMap is shared with the history of another ScaledObject whose key hashes the same
+			_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+			return err
+		}
+		err = configMaps.Delete(ctx, name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{ResourceVersion: &configMap.ResourceVersion},
+		})
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
+
+// isConflict returns whether the ConfigMap was written concurrently, by another replica of the operator
+func isConflict(err error) bool {
+	return errors.IsConflict(err) || errors.IsAlreadyExists(err)
+}
@@ -30,6 +30,7 @@ import (
 
 	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
 	"github.com/kedacore/keda/v2/pkg/metricsservice/utils"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 )
 
 type GrpcClient struct {
@@ -64,6 +65,7 @@ func NewGrpcClient(url, certDir, authority string, clientMetrics *grpcprom.Clien
 		grpc.WithChainUnaryInterceptor(clientMetrics.UnaryClientInterceptor()),
 		grpc.WithChainStreamInterceptor(clientMetrics.StreamClientInterceptor()),
 	)
+	opts = append(opts, tracing.GrpcDialOptions()...)
 
 	if authority != "" {
 		// If an Authority header override is specified, add it to the client so it is set on every request.
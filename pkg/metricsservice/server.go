@@ -29,6 +29,8 @@ import (
 	"github.com/kedacore/keda/v2/pkg/metricsservice/api"
 	"github.com/kedacore/keda/v2/pkg/metricsservice/utils"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/sharding"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 )
 
 var log = logf.Log.WithName("grpc_server")
@@ -39,13 +41,20 @@ type GrpcServer struct {
 	certDir       string
 	certsReady    chan struct{}
 	scalerHandler *scaling.ScaleHandler
+	// sharding is the coordinator of the shards of the replica, the metrics are served on the leader only without it
+	sharding *sharding.Coordinator
 	api.UnimplementedMetricsServiceServer
 }
 
 // GetMetrics returns metrics values in form of ExternalMetricValueList for specified ScaledObject reference
 func (s *GrpcServer) GetMetrics(ctx context.Context, in *api.ScaledObjectRef) (*v1beta1.ExternalMetricValueList, error) {
 	v1beta1ExtMetrics := &v1beta1.ExternalMetricValueList{}
-	extMetrics, err := (*s.scalerHandler).GetScaledObjectMetrics(ctx, in.Name, in.Namespace, in.MetricName)
+	getMetrics := (*s.scalerHandler).GetScaledObjectMetrics
+	// the scalers of the ScaledObjects of the other replicas aren't cached, their scale loops don't run here
+	if s.sharding != nil && !s.sharding.Owns(in.Namespace, in.Name) {
+		getMetrics = (*s.scalerHandler).GetUncachedScaledObjectMetrics
+	}
+	extMetrics, err := getMetrics(ctx, in.Name, in.Namespace, in.MetricName)
 	if err != nil {
 		return v1beta1ExtMetrics, fmt.Errorf("error when getting metric values %w", err)
 	}
@@ -60,13 +69,14 @@ func (s *GrpcServer) GetMetrics(ctx context.Context, in *api.ScaledObjectRef) (*
 	return v1beta1ExtMetrics, nil
 }
 
-// NewGrpcServer creates a new instance of GrpcServer
-func NewGrpcServer(scaleHandler *scaling.ScaleHandler, address, certDir string, certsReady chan struct{}) GrpcServer {
+// NewGrpcServer creates a new instance of GrpcServer, the sharding coordinator is nil when the ScaledObjects aren't sharded
+func NewGrpcServer(scaleHandler *scaling.ScaleHandler, address, certDir string, certsReady chan struct{}, shardCoordinator *sharding.Coordinator) GrpcServer {
 	return GrpcServer{
 		address:       address,
 		scalerHandler: scaleHandler,
 		certDir:       certDir,
 		certsReady:    certsReady,
+		sharding:      shardCoordinator,
 	}
 }
 
@@ -96,6 +106,7 @@ func (s *GrpcServer) Start(ctx context.Context) error {
 		grpcServerOpts := []grpc.ServerOption{
 			grpc.Creds(creds),
 		}
+		grpcServerOpts = append(grpcServerOpts, tracing.GrpcServerOptions()...)
 
 		if metricscollector.GetServerMetrics() != nil {
 			grpcServerOpts = append(
@@ -131,6 +142,7 @@ func (s *GrpcServer) Start(ctx context.Context) error {
 // NeedLeaderElection is needed to implement LeaderElectionRunnable interface
 // of controller-runtime. This assures that the component is started/stoped
 // when this particular instance is selected/deselected as a leader.
+// All the instances serve the metrics when the ScaledObjects are sharded across them.
 func (s *GrpcServer) NeedLeaderElection() bool {
-	return true
+	return s.sharding == nil
 }
@@ -69,6 +69,18 @@ func (mr *MockEventHandlerMockRecorder) Emit(object, namesapce, eventType, cloud
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Emit", reflect.TypeOf((*MockEventHandler)(nil).Emit), object, namesapce, eventType, cloudeventType, reason, message)
 }
 
+// EmitWithAnnotations mocks base method.
+func (m *MockEventHandler) EmitWithAnnotations(object runtime.Object, namespace, eventType string, cloudeventType v1alpha1.CloudEventType, reason, message string, annotations map[string]string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EmitWithAnnotations", object, namespace, eventType, cloudeventType, reason, message, annotations)
+}
+
+// EmitWithAnnotations indicates an expected call of EmitWithAnnotations.
+func (mr *MockEventHandlerMockRecorder) EmitWithAnnotations(object, namespace, eventType, cloudeventType, reason, message, annotations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmitWithAnnotations", reflect.TypeOf((*MockEventHandler)(nil).EmitWithAnnotations), object, namespace, eventType, cloudeventType, reason, message, annotations)
+}
+
 // HandleCloudEventSource mocks base method.
 func (m *MockEventHandler) HandleCloudEventSource(ctx context.Context, cloudEventSource v1alpha1.CloudEventSourceInterface) error {
 	m.ctrl.T.Helper()
@@ -41,16 +41,28 @@ func (m *MockScaleExecutor) EXPECT() *MockScaleExecutorMockRecorder {
 	return m.recorder
 }
 
+// DeleteScaledObject mocks base method.
+func (m *MockScaleExecutor) DeleteScaledObject(scaledObject *v1alpha1.ScaledObject) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteScaledObject", scaledObject)
+}
+
+// DeleteScaledObject indicates an expected call of DeleteScaledObject.
+func (mr *MockScaleExecutorMockRecorder) DeleteScaledObject(scaledObject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScaledObject", reflect.TypeOf((*MockScaleExecutor)(nil).DeleteScaledObject), scaledObject)
+}
+
 // RequestJobScale mocks base method.
-func (m *MockScaleExecutor) RequestJobScale(ctx context.Context, scaledJob *v1alpha1.ScaledJob, isActive, isError bool, scaleTo, maxScale int64) {
+func (m *MockScaleExecutor) RequestJobScale(ctx context.Context, scaledJob *v1alpha1.ScaledJob, isActive, isError bool, scaleTo, maxScale int64, options *executor.ScaleExecutorOptions) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RequestJobScale", ctx, scaledJob, isActive, isError, scaleTo, maxScale)
+	m.ctrl.Call(m, "RequestJobScale", ctx, scaledJob, isActive, isError, scaleTo, maxScale, options)
 }
 
 // RequestJobScale indicates an expected call of RequestJobScale.
-func (mr *MockScaleExecutorMockRecorder) RequestJobScale(ctx, scaledJob, isActive, isError, scaleTo, maxScale any) *gomock.Call {
+func (mr *MockScaleExecutorMockRecorder) RequestJobScale(ctx, scaledJob, isActive, isError, scaleTo, maxScale, options any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestJobScale", reflect.TypeOf((*MockScaleExecutor)(nil).RequestJobScale), ctx, scaledJob, isActive, isError, scaleTo, maxScale)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestJobScale", reflect.TypeOf((*MockScaleExecutor)(nil).RequestJobScale), ctx, scaledJob, isActive, isError, scaleTo, maxScale, options)
 }
 
 // RequestScale mocks base method.
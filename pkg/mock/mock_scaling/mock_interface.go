@@ -13,6 +13,8 @@ import (
 	context "context"
 	reflect "reflect"
 
+	v1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	scaling "github.com/kedacore/keda/v2/pkg/scaling"
 	cache "github.com/kedacore/keda/v2/pkg/scaling/cache"
 	gomock "go.uber.org/mock/gomock"
 	external_metrics "k8s.io/metrics/pkg/apis/external_metrics"
@@ -69,6 +71,20 @@ func (mr *MockScaleHandlerMockRecorder) DeleteScalableObject(ctx, scalableObject
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScalableObject", reflect.TypeOf((*MockScaleHandler)(nil).DeleteScalableObject), ctx, scalableObject)
 }
 
+// EvaluateTrigger mocks base method.
+func (m *MockScaleHandler) EvaluateTrigger(ctx context.Context, evaluation *v1alpha1.TriggerEvaluation) v1alpha1.TriggerEvaluationStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvaluateTrigger", ctx, evaluation)
+	ret0, _ := ret[0].(v1alpha1.TriggerEvaluationStatus)
+	return ret0
+}
+
+// EvaluateTrigger indicates an expected call of EvaluateTrigger.
+func (mr *MockScaleHandlerMockRecorder) EvaluateTrigger(ctx, evaluation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateTrigger", reflect.TypeOf((*MockScaleHandler)(nil).EvaluateTrigger), ctx, evaluation)
+}
+
 // GetScaledObjectMetrics mocks base method.
 func (m *MockScaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricName string) (*external_metrics.ExternalMetricValueList, error) {
 	m.ctrl.T.Helper()
@@ -99,6 +115,35 @@ func (mr *MockScaleHandlerMockRecorder) GetScalersCache(ctx, scalableObject any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScalersCache", reflect.TypeOf((*MockScaleHandler)(nil).GetScalersCache), ctx, scalableObject)
 }
 
+// GetScalersState mocks base method.
+func (m *MockScaleHandler) GetScalersState() []scaling.ScalableObjectScalers {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScalersState")
+	ret0, _ := ret[0].([]scaling.ScalableObjectScalers)
+	return ret0
+}
+
+// GetScalersState indicates an expected call of GetScalersState.
+func (mr *MockScaleHandlerMockRecorder) GetScalersState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScalersState", reflect.TypeOf((*MockScaleHandler)(nil).GetScalersState))
+}
+
+// GetUncachedScaledObjectMetrics mocks base method.
+func (m *MockScaleHandler) GetUncachedScaledObjectMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricName string) (*external_metrics.ExternalMetricValueList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUncachedScaledObjectMetrics", ctx, scaledObjectName, scaledObjectNamespace, metricName)
+	ret0, _ := ret[0].(*external_metrics.ExternalMetricValueList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUncachedScaledObjectMetrics indicates an expected call of GetUncachedScaledObjectMetrics.
+func (mr *MockScaleHandlerMockRecorder) GetUncachedScaledObjectMetrics(ctx, scaledObjectName, scaledObjectNamespace, metricName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUncachedScaledObjectMetrics", reflect.TypeOf((*MockScaleHandler)(nil).GetUncachedScaledObjectMetrics), ctx, scaledObjectName, scaledObjectNamespace, metricName)
+}
+
 // HandleScalableObject mocks base method.
 func (m *MockScaleHandler) HandleScalableObject(ctx context.Context, scalableObject any) error {
 	m.ctrl.T.Helper()
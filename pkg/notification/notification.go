@@ -0,0 +1,230 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+var log = logf.Log.WithName("notification")
+
+// Reasons a notification is sent for
+const (
+	ReasonTriggerError = "triggerError"
+	ReasonFallback     = "fallback"
+	ReasonMaxReplicas  = "maxReplicas"
+)
+
+const (
+	defaultQueueSize             = 256
+	defaultRateLimit             = 15 * time.Minute
+	defaultTriggerErrorThreshold = 5
+	// the rate limiter forgets the expired entries once it tracks this many
+	rateLimiterSweepSize = 1024
+)
+
+// Notification describes a scaling failure of a ScaledObject or a ScaledJob
+type Notification struct {
+	Timestamp time.Time `json:"timestamp"`
+	Reason    string    `json:"reason"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	// Trigger is set when the notification is about a single trigger
+	Trigger string `json:"trigger,omitempty"`
+	Message string `json:"message"`
+}
+
+func (n *Notification) key() string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", n.Kind, n.Namespace, n.Name, n.Reason, n.Trigger)
+}
+
+// Config is the configuration file of the notifications
+type Config struct {
+	// RateLimit is the minimal interval between two notifications of the same reason for a ScaledObject or a ScaledJob
+	RateLimit *metav1.Duration `json:"rateLimit,omitempty"`
+	// TriggerErrorThreshold is the number of consecutive errors of a trigger before it is notified
+	TriggerErrorThreshold int `json:"triggerErrorThreshold,omitempty"`
+
+	Receivers []ReceiverConfig `json:"receivers"`
+}
+
+// LoadConfig reads and validates the configuration file of the notifications
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the notification config: %w", err)
+	}
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("error parsing the notification config: %w", err)
+	}
+
+	if config.RateLimit == nil {
+		config.RateLimit = &metav1.Duration{Duration: defaultRateLimit}
+	}
+	if config.RateLimit.Duration < 0 {
+		return nil, fmt.Errorf("the notification rateLimit can't be negative")
+	}
+	if config.TriggerErrorThreshold == 0 {
+		config.TriggerErrorThreshold = defaultTriggerErrorThreshold
+	}
+	if config.TriggerErrorThreshold < 0 {
+		return nil, fmt.Errorf("the notification triggerErrorThreshold can't be negative")
+	}
+	if len(config.Receivers) == 0 {
+		return nil, fmt.Errorf("at least one notification receiver is required")
+	}
+	return config, nil
+}
+
+type notifier struct {
+	queue     chan *Notification
+	receivers []Receiver
+	rateLimit time.Duration
+	threshold int
+
+	lock     sync.Mutex
+	lastSent map[string]time.Time
+}
+
+var (
+	lock    sync.RWMutex
+	current *notifier
+	stopWg  sync.WaitGroup
+)
+
+// NewNotifier starts sending the notifications to the receivers of the configuration file,
+// the notifications are queued and sent in the background not to slow the scale loop down.
+// It returns a function flushing the queue on shutdown.
+func NewNotifier(configPath string) (func() error, error) {
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	receivers := make([]Receiver, 0, len(config.Receivers))
+	for i, receiverConfig := range config.Receivers {
+		receiver, err := NewReceiver(receiverConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid notification receiver %d: %w", i, err)
+		}
+		receivers = append(receivers, receiver)
+	}
+	return start(receivers, config.RateLimit.Duration, config.TriggerErrorThreshold, defaultQueueSize), nil
+}
+
+func start(receivers []Receiver, rateLimit time.Duration, threshold, queueSize int) func() error {
+	lock.Lock()
+	defer lock.Unlock()
+	n := &notifier{
+		queue:     make(chan *Notification, queueSize),
+		receivers: receivers,
+		rateLimit: rateLimit,
+		threshold: threshold,
+		lastSent:  map[string]time.Time{},
+	}
+	current = n
+
+	stopWg.Add(1)
+	go func() {
+		defer stopWg.Done()
+		for notification := range n.queue {
+			for _, receiver := range n.receivers {
+				if !receiver.Accepts(notification.Reason) {
+					continue
+				}
+				if err := receiver.Send(notification); err != nil {
+					log.Error(err, "error sending notification", "receiver", receiver.Name(), "reason", notification.Reason, "namespace", notification.Namespace, "name", notification.Name)
+				}
+			}
+		}
+	}()
+
+	return func() error {
+		lock.Lock()
+		current = nil
+		lock.Unlock()
+		close(n.queue)
+		stopWg.Wait()
+		return nil
+	}
+}
+
+// Enabled returns whether the notifications have been set up for this component
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return current != nil
+}
+
+// TriggerErrorThreshold returns the number of consecutive errors of a trigger before it is notified
+func TriggerErrorThreshold() int {
+	lock.RLock()
+	defer lock.RUnlock()
+	if current == nil {
+		return defaultTriggerErrorThreshold
+	}
+	return current.threshold
+}
+
+// Notify queues the notification, it is dropped if the same one has been sent within
+// the rate limit or if the queue is full
+func Notify(notification *Notification) {
+	lock.RLock()
+	defer lock.RUnlock()
+	if current == nil {
+		return
+	}
+	if notification.Timestamp.IsZero() {
+		notification.Timestamp = time.Now().UTC()
+	}
+	if !current.allow(notification) {
+		log.V(1).Info("notification is rate limited", "reason", notification.Reason, "namespace", notification.Namespace, "name", notification.Name)
+		return
+	}
+	select {
+	case current.queue <- notification:
+	default:
+		log.Error(fmt.Errorf("notification queue is full"), "dropping notification", "reason", notification.Reason, "namespace", notification.Namespace, "name", notification.Name)
+	}
+}
+
+func (n *notifier) allow(notification *Notification) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	key := notification.key()
+	if last, found := n.lastSent[key]; found && notification.Timestamp.Sub(last) < n.rateLimit {
+		return false
+	}
+	if len(n.lastSent) >= rateLimiterSweepSize {
+		for k, last := range n.lastSent {
+			if notification.Timestamp.Sub(last) >= n.rateLimit {
+				delete(n.lastSent, k)
+			}
+		}
+	}
+	n.lastSent[key] = notification.Timestamp
+	return true
+}
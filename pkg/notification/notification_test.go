@@ -0,0 +1,167 @@
+package notification
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryReceiver struct {
+	lock          sync.Mutex
+	reasons       []string
+	notifications []*Notification
+}
+
+func (r *memoryReceiver) Name() string { return "memory" }
+
+func (r *memoryReceiver) Accepts(reason string) bool {
+	return len(r.reasons) == 0 || reason == r.reasons[0]
+}
+
+func (r *memoryReceiver) Send(notification *Notification) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.notifications = append(r.notifications, notification)
+	return nil
+}
+
+func TestNotify(t *testing.T) {
+	// nothing is sent until the notifications are set up
+	Notify(&Notification{Name: "ignored"})
+	assert.False(t, Enabled())
+	assert.Equal(t, defaultTriggerErrorThreshold, TriggerErrorThreshold())
+
+	all := &memoryReceiver{}
+	fallbackOnly := &memoryReceiver{reasons: []string{ReasonFallback}}
+	stop := start([]Receiver{all, fallbackOnly}, time.Minute, 3, 10)
+	assert.True(t, Enabled())
+	assert.Equal(t, 3, TriggerErrorThreshold())
+
+	now := time.Now()
+	Notify(&Notification{Timestamp: now, Reason: ReasonTriggerError, Kind: "ScaledObject", Namespace: "default", Name: "so", Trigger: "0"})
+	// rate limited
+	Notify(&Notification{Timestamp: now.Add(30 * time.Second), Reason: ReasonTriggerError, Kind: "ScaledObject", Namespace: "default", Name: "so", Trigger: "0"})
+	// another trigger and another reason are not
+	Notify(&Notification{Timestamp: now.Add(30 * time.Second), Reason: ReasonTriggerError, Kind: "ScaledObject", Namespace: "default", Name: "so", Trigger: "1"})
+	Notify(&Notification{Timestamp: now.Add(30 * time.Second), Reason: ReasonFallback, Kind: "ScaledObject", Namespace: "default", Name: "so"})
+	// sent again after the rate limit
+	Notify(&Notification{Timestamp: now.Add(2 * time.Minute), Reason: ReasonTriggerError, Kind: "ScaledObject", Namespace: "default", Name: "so", Trigger: "0"})
+
+	assert.NoError(t, stop())
+	assert.False(t, Enabled())
+
+	assert.Len(t, all.notifications, 4)
+	assert.Len(t, fallbackOnly.notifications, 1)
+	assert.Equal(t, ReasonFallback, fallbackOnly.notifications[0].Reason)
+}
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  string
+		isError bool
+	}{
+		{"valid", "rateLimit: 10m\ntriggerErrorThreshold: 3\nreceivers:\n- type: slack\n  url: https://hooks.slack.com/services/T/B/X\n", false},
+		{"defaults", "receivers:\n- type: webhook\n  url: http://alerts\n", false},
+		{"no receivers", "rateLimit: 10m\n", true},
+		{"negative rate limit", "rateLimit: -1m\nreceivers:\n- type: webhook\n  url: http://alerts\n", true},
+		{"unknown field", "receiver:\n- type: webhook\n", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "notifications.yaml")
+			assert.NoError(t, os.WriteFile(path, []byte(tc.config), 0600))
+			config, err := LoadConfig(path)
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotZero(t, config.RateLimit.Duration)
+			assert.NotZero(t, config.TriggerErrorThreshold)
+		})
+	}
+}
+
+func TestNewReceiver(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  ReceiverConfig
+		isError bool
+	}{
+		{"slack", ReceiverConfig{Type: ReceiverSlack, URL: "https://hooks.slack.com/services/T/B/X"}, false},
+		{"pagerduty with default url", ReceiverConfig{Type: ReceiverPagerDuty, RoutingKey: "key"}, false},
+		{"pagerduty without routing key", ReceiverConfig{Type: ReceiverPagerDuty}, true},
+		{"webhook without url", ReceiverConfig{Type: ReceiverWebhook}, true},
+		{"invalid template", ReceiverConfig{Type: ReceiverSlack, URL: "http://slack", Template: "{{.Name"}, true},
+		{"unknown reason", ReceiverConfig{Type: ReceiverSlack, URL: "http://slack", Reasons: []string{"scaledUp"}}, true},
+		{"unknown type", ReceiverConfig{Type: "email"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewReceiver(tc.config)
+			if tc.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReceiverSend(t *testing.T) {
+	var bodies []map[string]interface{}
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		decoded := map[string]interface{}{}
+		if json.Unmarshal(body, &decoded) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		bodies = append(bodies, decoded)
+		headers = append(headers, r.Header)
+	}))
+	defer server.Close()
+
+	notification := &Notification{Timestamp: time.Now(), Reason: ReasonTriggerError, Kind: "ScaledObject", Namespace: "default", Name: "so", Trigger: "0", Message: "trigger 0 (kafka) failed 5 times in a row"}
+
+	slack, err := NewReceiver(ReceiverConfig{Type: ReceiverSlack, URL: server.URL})
+	assert.NoError(t, err)
+	assert.NoError(t, slack.Send(notification))
+
+	pagerDuty, err := NewReceiver(ReceiverConfig{Type: ReceiverPagerDuty, URL: server.URL, RoutingKey: "key", Template: "{{.Reason}} on {{.Namespace}}/{{.Name}}"})
+	assert.NoError(t, err)
+	assert.NoError(t, pagerDuty.Send(notification))
+
+	webhook, err := NewReceiver(ReceiverConfig{Type: ReceiverWebhook, URL: server.URL, Headers: map[string]string{"Authorization": "Bearer token"}})
+	assert.NoError(t, err)
+	assert.NoError(t, webhook.Send(notification))
+
+	// with a template the generic webhook sends the rendered body
+	invalid, err := NewReceiver(ReceiverConfig{Type: ReceiverWebhook, URL: server.URL, Template: "not json"})
+	assert.NoError(t, err)
+	assert.Error(t, invalid.Send(notification))
+
+	assert.Len(t, bodies, 3)
+	assert.Equal(t, "[KEDA] ScaledObject default/so: trigger 0 (kafka) failed 5 times in a row", bodies[0]["text"])
+
+	assert.Equal(t, "key", bodies[1]["routing_key"])
+	assert.Equal(t, "trigger", bodies[1]["event_action"])
+	payload := bodies[1]["payload"].(map[string]interface{})
+	assert.Equal(t, "triggerError on default/so", payload["summary"])
+	assert.Equal(t, "error", payload["severity"])
+
+	assert.Equal(t, "so", bodies[2]["name"])
+	assert.Equal(t, "Bearer token", headers[2].Get("Authorization"))
+}
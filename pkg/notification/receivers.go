@@ -0,0 +1,234 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"golang.org/x/exp/slices"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// Supported notification receivers
+const (
+	ReceiverSlack     = "slack"
+	ReceiverPagerDuty = "pagerduty"
+	ReceiverWebhook   = "webhook"
+)
+
+const (
+	defaultPagerDutyURL    = "https://events.pagerduty.com/v2/enqueue"
+	defaultReceiverTimeout = 5 * time.Second
+	defaultTemplate        = `[KEDA] {{.Kind}} {{.Namespace}}/{{.Name}}: {{.Message}}`
+)
+
+// ReceiverConfig configures a destination of the notifications
+type ReceiverConfig struct {
+	Name string `json:"name,omitempty"`
+	// Type is one of slack, pagerduty or webhook
+	Type string `json:"type"`
+	// URL is the incoming webhook of Slack, the endpoint of the generic webhook
+	// or an alternative PagerDuty Events API v2 endpoint
+	URL string `json:"url,omitempty"`
+	// RoutingKey is the integration key of the PagerDuty service
+	RoutingKey string `json:"routingKey,omitempty"`
+	// Headers are added to the requests of the generic webhook
+	Headers map[string]string `json:"headers,omitempty"`
+	// Template is a Go template of the message rendered with the notification,
+	// with the generic webhook it renders the whole body which defaults to the notification as JSON
+	Template string `json:"template,omitempty"`
+	// Reasons restricts the notifications sent to the receiver, all reasons are sent by default
+	Reasons []string         `json:"reasons,omitempty"`
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// Receiver is a destination of the notifications
+type Receiver interface {
+	Name() string
+	Accepts(reason string) bool
+	Send(notification *Notification) error
+}
+
+// NewReceiver creates the notification receiver of the config
+func NewReceiver(config ReceiverConfig) (Receiver, error) {
+	for _, reason := range config.Reasons {
+		if reason != ReasonTriggerError && reason != ReasonFallback && reason != ReasonMaxReplicas {
+			return nil, fmt.Errorf("unsupported notification reason %q, must be one of %s, %s or %s", reason, ReasonTriggerError, ReasonFallback, ReasonMaxReplicas)
+		}
+	}
+
+	timeout := defaultReceiverTimeout
+	if config.Timeout != nil {
+		timeout = config.Timeout.Duration
+	}
+	receiver := &httpReceiver{
+		name:    config.Name,
+		url:     config.URL,
+		headers: config.Headers,
+		reasons: config.Reasons,
+		client:  kedautil.CreateHTTPClient(timeout, false),
+	}
+	if receiver.name == "" {
+		receiver.name = config.Type
+	}
+
+	var tmpl *template.Template
+	if config.Template != "" || config.Type != ReceiverWebhook {
+		text := config.Template
+		if text == "" {
+			text = defaultTemplate
+		}
+		var err error
+		if tmpl, err = template.New(receiver.name).Option("missingkey=error").Parse(text); err != nil {
+			return nil, fmt.Errorf("invalid notification template: %w", err)
+		}
+	}
+
+	switch config.Type {
+	case ReceiverSlack:
+		receiver.body = slackBody(tmpl)
+	case ReceiverPagerDuty:
+		if config.RoutingKey == "" {
+			return nil, fmt.Errorf("routingKey is required with the %s receiver", ReceiverPagerDuty)
+		}
+		if receiver.url == "" {
+			receiver.url = defaultPagerDutyURL
+		}
+		receiver.body = pagerDutyBody(tmpl, config.RoutingKey)
+	case ReceiverWebhook:
+		receiver.body = webhookBody(tmpl)
+	default:
+		return nil, fmt.Errorf("unsupported notification receiver %q, must be one of %s, %s or %s", config.Type, ReceiverSlack, ReceiverPagerDuty, ReceiverWebhook)
+	}
+
+	if _, err := url.ParseRequestURI(receiver.url); err != nil {
+		return nil, fmt.Errorf("invalid notification receiver url: %w", err)
+	}
+	return receiver, nil
+}
+
+// httpReceiver posts the body built from the notification to the url
+type httpReceiver struct {
+	name    string
+	url     string
+	headers map[string]string
+	reasons []string
+	client  *http.Client
+	body    func(notification *Notification) ([]byte, error)
+}
+
+func (r *httpReceiver) Name() string {
+	return r.name
+}
+
+func (r *httpReceiver) Accepts(reason string) bool {
+	return len(r.reasons) == 0 || slices.Contains(r.reasons, reason)
+}
+
+func (r *httpReceiver) Send(notification *Notification) error {
+	body, err := r.body(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range r.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification receiver returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func render(tmpl *template.Template, notification *Notification) (string, error) {
+	var text strings.Builder
+	if err := tmpl.Execute(&text, notification); err != nil {
+		return "", fmt.Errorf("error rendering the notification template: %w", err)
+	}
+	return text.String(), nil
+}
+
+func slackBody(tmpl *template.Template) func(*Notification) ([]byte, error) {
+	return func(notification *Notification) ([]byte, error) {
+		text, err := render(tmpl, notification)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(map[string]string{"text": text})
+	}
+}
+
+// pagerDutyBody builds the events of the PagerDuty Events API v2, the notifications
+// of an object and reason are deduplicated into the same incident
+func pagerDutyBody(tmpl *template.Template, routingKey string) func(*Notification) ([]byte, error) {
+	return func(notification *Notification) ([]byte, error) {
+		summary, err := render(tmpl, notification)
+		if err != nil {
+			return nil, err
+		}
+		severity := "warning"
+		if notification.Reason == ReasonTriggerError {
+			severity = "error"
+		}
+		return json.Marshal(map[string]interface{}{
+			"routing_key":  routingKey,
+			"event_action": "trigger",
+			"dedup_key":    notification.key(),
+			"payload": map[string]interface{}{
+				"summary":        summary,
+				"source":         "keda",
+				"severity":       severity,
+				"timestamp":      notification.Timestamp.Format(time.RFC3339),
+				"component":      fmt.Sprintf("%s/%s", notification.Namespace, notification.Name),
+				"group":          notification.Kind,
+				"class":          notification.Reason,
+				"custom_details": notification,
+			},
+		})
+	}
+}
+
+func webhookBody(tmpl *template.Template) func(*Notification) ([]byte, error) {
+	return func(notification *Notification) ([]byte, error) {
+		if tmpl == nil {
+			return json.Marshal(notification)
+		}
+		body, err := render(tmpl, notification)
+		return []byte(body), err
+	}
+}
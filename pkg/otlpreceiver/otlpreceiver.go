@@ -0,0 +1,390 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otlpreceiver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"k8s.io/client-go/kubernetes"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+var log = logf.Log.WithName("otlp_receiver")
+
+// forwardTimeout bounds the forwarding of an export to the other replicas
+const forwardTimeout = 10 * time.Second
+
+// NamespaceMetadata is the metadata of the namespace whose series an export pushes, the export is
+// authenticated with the token of the namespace
+const NamespaceMetadata = "x-keda-namespace"
+
+// forwardedMetadata marks the exports forwarded by another replica
+var forwardedMetadata = strings.ToLower(kedautil.ForwardedHeader)
+
+// Options configures the OTLP receiver
+type Options struct {
+	// BindAddress is the address the receiver listens on
+	BindAddress string
+	// TokenSecret is the name of the Secret of each namespace whose token key holds the bearer token
+	// the exports to the namespace are authenticated with, the namespaces without it can't be exported to
+	TokenSecret string
+	// KubeClient watches the Secrets of the tokens
+	KubeClient kubernetes.Interface
+	// CertFile and KeyFile are the certificate and the key the receiver serves TLS with, they're read
+	// again as they're rotated
+	CertFile string
+	KeyFile  string
+	// SeriesTTL is how long a series is kept since its last data point was pushed
+	SeriesTTL time.Duration
+	// MaxSeries is the number of series kept, the data points of the new series past it are rejected
+	MaxSeries int
+	// PeersService is the headless service resolving to the replicas of the operator, the exports are
+	// forwarded to the other replicas so the one evaluating a scaler has them. Empty disables the forwarding.
+	PeersService string
+}
+
+var (
+	lock  sync.RWMutex
+	store *seriesStore
+)
+
+// NewReceiver starts receiving the metrics exported over OTLP/gRPC with TLS, the latest data point of
+// each series of the gauges and sums is kept so the scalers can read the metrics the workloads push
+// without a Prometheus. The series are kept per namespace, the scalers only read the series of their
+// namespace. The series are kept in memory: they're pushed again following a restart.
+// An export received by a replica is forwarded to the others of the PeersService, each replica
+// keeps all the series whichever evaluates the scalers. It returns a function stopping the
+// receiver on shutdown.
+func NewReceiver(opts Options) (func() error, error) {
+	if opts.TokenSecret == "" || opts.KubeClient == nil {
+		return nil, fmt.Errorf("the secret of the tokens is required to authenticate the exports")
+	}
+	if opts.CertFile == "" || opts.KeyFile == "" {
+		return nil, fmt.Errorf("a certificate is required to serve the exports over tls")
+	}
+	if opts.SeriesTTL <= 0 {
+		return nil, fmt.Errorf("the series ttl must be greater than 0")
+	}
+	listener, err := net.Listen("tcp", opts.BindAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error listening on %s: %w", opts.BindAddress, err)
+	}
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		return nil, err
+	}
+	return start(listener, opts, kedautil.NewPeerResolver(opts.PeersService, port)), nil
+}
+
+func start(listener net.Listener, opts Options, peers kedautil.PeerResolver) func() error {
+	s := newSeriesStore(opts.SeriesTTL, opts.MaxSeries)
+	lock.Lock()
+	store = s
+	lock.Unlock()
+
+	cert := kedautil.NewServingCertificate(opts.CertFile, opts.KeyFile)
+	tokens, informers := kedautil.NewNamespaceTokens(opts.KubeClient, opts.TokenSecret)
+	stopInformers := make(chan struct{})
+	informers.Start(stopInformers)
+	var f *forwarder
+	if peers != nil {
+		f = &forwarder{peers: peers, credentials: credentials.NewTLS(cert.PeerConfig()), conns: map[string]*grpc.ClientConn{}}
+	}
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(cert.ServerConfig())), grpc.UnaryInterceptor(tokenInterceptor(tokens)))
+	colmetricspb.RegisterMetricsServiceServer(server, &metricsService{store: s, forwarder: f})
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			log.Error(err, "otlp receiver stopped")
+		}
+	}()
+
+	return func() error {
+		lock.Lock()
+		store = nil
+		lock.Unlock()
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			server.Stop()
+		}
+		if f != nil {
+			f.close()
+		}
+		close(stopInformers)
+		informers.Shutdown()
+		return nil
+	}
+}
+
+// Enabled returns whether the OTLP receiver has been set up for this component
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return store != nil
+}
+
+// DataPoints returns the latest data points of the series of the metric of the namespace, as last pushed
+func DataPoints(namespace, metric string) []DataPoint {
+	lock.RLock()
+	s := store
+	lock.RUnlock()
+	if s == nil {
+		return nil
+	}
+	return s.list(namespace, metric, time.Now())
+}
+
+// tokenInterceptor rejects the exports without a namespace metadata or whose authorization metadata
+// isn't the bearer token of the namespace
+func tokenInterceptor(tokens *kedautil.NamespaceTokens) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		namespace := namespaceOf(md)
+		if namespace == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "the %s metadata must be the namespace of the series", NamespaceMetadata)
+		}
+		token, err := tokens.Get(namespace)
+		if err != nil {
+			log.V(1).Info("error reading the token of the namespace", "namespace", namespace, "error", err.Error())
+			return nil, status.Error(codes.Unavailable, "error reading the token of the namespace")
+		}
+		authorization := md.Get("authorization")
+		if token == nil || len(authorization) != 1 || subtle.ConstantTimeCompare([]byte(authorization[0]), append([]byte("Bearer "), token...)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// namespaceOf returns the namespace of the export, empty unless its metadata has a single one
+func namespaceOf(md metadata.MD) string {
+	namespace := md.Get(NamespaceMetadata)
+	if len(namespace) != 1 {
+		return ""
+	}
+	return namespace[0]
+}
+
+type metricsService struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+	store *seriesStore
+	// forwarder forwards the exports to the other replicas, nil without forwarding
+	forwarder *forwarder
+}
+
+// Export records the data points of the gauges and sums, the data points of the other metric types
+// are rejected in the partial success of the response
+func (m *metricsService) Export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	namespace := namespaceOf(md)
+	if m.forwarder != nil && len(md.Get(forwardedMetadata)) == 0 {
+		go m.forwarder.forward(namespace, md.Get("authorization"), req)
+	}
+
+	var points, ended []DataPoint
+	var unsupported int64
+	for _, resourceMetrics := range req.GetResourceMetrics() {
+		resourceAttributes := attributesOf(resourceMetrics.GetResource().GetAttributes(), nil)
+		for _, scopeMetrics := range resourceMetrics.GetScopeMetrics() {
+			for _, metric := range scopeMetrics.GetMetrics() {
+				var dataPoints []*metricspb.NumberDataPoint
+				switch data := metric.GetData().(type) {
+				case *metricspb.Metric_Gauge:
+					dataPoints = data.Gauge.GetDataPoints()
+				case *metricspb.Metric_Sum:
+					dataPoints = data.Sum.GetDataPoints()
+				case *metricspb.Metric_Histogram:
+					unsupported += int64(len(data.Histogram.GetDataPoints()))
+				case *metricspb.Metric_ExponentialHistogram:
+					unsupported += int64(len(data.ExponentialHistogram.GetDataPoints()))
+				case *metricspb.Metric_Summary:
+					unsupported += int64(len(data.Summary.GetDataPoints()))
+				}
+
+				for _, dataPoint := range dataPoints {
+					point := DataPoint{
+						namespace:  namespace,
+						Metric:     metric.GetName(),
+						Attributes: attributesOf(dataPoint.GetAttributes(), resourceAttributes),
+						Timestamp:  time.Unix(0, int64(dataPoint.GetTimeUnixNano())),
+					}
+					if dataPoint.GetFlags()&uint32(metricspb.DataPointFlags_DATA_POINT_FLAGS_NO_RECORDED_VALUE_MASK) != 0 {
+						ended = append(ended, point)
+						continue
+					}
+					switch value := dataPoint.GetValue().(type) {
+					case *metricspb.NumberDataPoint_AsDouble:
+						point.Value = value.AsDouble
+					case *metricspb.NumberDataPoint_AsInt:
+						point.Value = float64(value.AsInt)
+					default:
+						unsupported++
+						continue
+					}
+					points = append(points, point)
+				}
+			}
+		}
+	}
+
+	m.store.remove(ended)
+	rejected := int64(m.store.record(points, time.Now()))
+	log.V(1).Info("received otlp metrics export", "namespace", namespace, "dataPoints", len(points), "rejected", rejected+unsupported)
+
+	response := &colmetricspb.ExportMetricsServiceResponse{}
+	if rejected+unsupported > 0 {
+		var messages []string
+		if unsupported > 0 {
+			messages = append(messages, fmt.Sprintf("%d data points aren't of a gauge or a sum", unsupported))
+		}
+		if rejected > 0 {
+			messages = append(messages, fmt.Sprintf("%d data points are of new series past the maximum number of series", rejected))
+		}
+		response.PartialSuccess = &colmetricspb.ExportMetricsPartialSuccess{
+			RejectedDataPoints: rejected + unsupported,
+			ErrorMessage:       strings.Join(messages, ", "),
+		}
+	}
+	return response, nil
+}
+
+// forwarder forwards the exports to the other replicas, over a TLS connection kept to each of them
+type forwarder struct {
+	peers       kedautil.PeerResolver
+	credentials credentials.TransportCredentials
+	lock        sync.Mutex
+	conns       map[string]*grpc.ClientConn
+}
+
+// forward sends the export to the other replicas, with its namespace and its authorization so they
+// authenticate it as exported by the workload. The series are upserted, a replica receiving it twice is fine.
+func (f *forwarder) forward(namespace string, authorization []string, req *colmetricspb.ExportMetricsServiceRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), forwardTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, forwardedMetadata, "true", NamespaceMetadata, namespace)
+	for _, value := range authorization {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", value)
+	}
+	err := kedautil.ForwardToPeers(ctx, f.resolve, func(ctx context.Context, peer string) error {
+		conn, err := f.conn(peer)
+		if err != nil {
+			return err
+		}
+		_, err = colmetricspb.NewMetricsServiceClient(conn).Export(ctx, req)
+		return err
+	})
+	if err != nil {
+		log.Error(err, "error forwarding the otlp metrics export")
+	}
+}
+
+// resolve returns the other replicas, the connections to the replicas gone are closed
+func (f *forwarder) resolve(ctx context.Context) ([]string, error) {
+	peers, err := f.peers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for peer, conn := range f.conns {
+		if !kedautil.Contains(peers, peer) {
+			conn.Close()
+			delete(f.conns, peer)
+		}
+	}
+	return peers, nil
+}
+
+func (f *forwarder) conn(peer string) (*grpc.ClientConn, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.conns == nil {
+		return nil, fmt.Errorf("the receiver is stopped")
+	}
+	if conn, ok := f.conns[peer]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.NewClient(peer, grpc.WithTransportCredentials(f.credentials))
+	if err != nil {
+		return nil, err
+	}
+	f.conns[peer] = conn
+	return conn, nil
+}
+
+func (f *forwarder) close() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, conn := range f.conns {
+		conn.Close()
+	}
+	f.conns = nil
+}
+
+// attributesOf returns the attributes as strings, over the inherited attributes of the resource
+func attributesOf(keyValues []*commonpb.KeyValue, inherited map[string]string) map[string]string {
+	attributes := make(map[string]string, len(keyValues)+len(inherited))
+	for name, value := range inherited {
+		attributes[name] = value
+	}
+	for _, keyValue := range keyValues {
+		attributes[keyValue.GetKey()] = anyValueString(keyValue.GetValue())
+	}
+	return attributes
+}
+
+// anyValueString formats the scalar attribute values, the arrays, maps and bytes are formatted
+// by their protobuf text representation
+func anyValueString(value *commonpb.AnyValue) string {
+	switch v := value.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return v.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return strconv.FormatBool(v.BoolValue)
+	case *commonpb.AnyValue_IntValue:
+		return strconv.FormatInt(v.IntValue, 10)
+	case *commonpb.AnyValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'g', -1, 64)
+	case nil:
+		return ""
+	default:
+		return value.String()
+	}
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otlpreceiver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	testToken       = "It's a Secret to Everybody"
+	testTokenSecret = "keda-otlp-receiver"
+)
+
+// testSecrets returns the client of the tokens of the namespaces default and other
+func testSecrets() kubernetes.Interface {
+	return fake.NewSimpleClientset(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: testTokenSecret, Namespace: "default"}, Data: map[string][]byte{kedautil.ReceiverTokenKey: []byte(testToken)}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: testTokenSecret, Namespace: "other"}, Data: map[string][]byte{kedautil.ReceiverTokenKey: []byte("other")}},
+	)
+}
+
+// testTokens returns the tokens of the namespaces default and other, once they're listed
+func testTokens(t *testing.T) *kedautil.NamespaceTokens {
+	tokens, informers := kedautil.NewNamespaceTokens(testSecrets(), testTokenSecret)
+	stop := make(chan struct{})
+	t.Cleanup(func() {
+		close(stop)
+		informers.Shutdown()
+	})
+	informers.Start(stop)
+	informers.WaitForCacheSync(stop)
+	return tokens
+}
+
+// testCertificate writes a self-signed certificate of 127.0.0.1, it returns its files and the pool trusting it
+func testCertificate(t *testing.T) (string, string, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "keda-operator"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return certFile, keyFile, pool
+}
+
+// exportContext returns the context of an export of the namespace with the token
+func exportContext(namespace, token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), NamespaceMetadata, namespace, "authorization", "Bearer "+token)
+}
+
+func stringAttribute(name, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: name, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+func numberDataPoint(value float64, timestamp time.Time, attributes ...*commonpb.KeyValue) *metricspb.NumberDataPoint {
+	return &metricspb.NumberDataPoint{
+		Attributes:   attributes,
+		TimeUnixNano: uint64(timestamp.UnixNano()),
+		Value:        &metricspb.NumberDataPoint_AsDouble{AsDouble: value},
+	}
+}
+
+func exportRequest(pod string, metrics ...*metricspb.Metric) *colmetricspb.ExportMetricsServiceRequest {
+	return &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource:     &resourcepb.Resource{Attributes: []*commonpb.KeyValue{stringAttribute("k8s.pod.name", pod)}},
+			ScopeMetrics: []*metricspb.ScopeMetrics{{Metrics: metrics}},
+		}},
+	}
+}
+
+func TestMetricsServiceExport(t *testing.T) {
+	s := newSeriesStore(time.Minute, 0)
+	service := &metricsService{store: s}
+	now := time.Now()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(NamespaceMetadata, "default"))
+
+	resp, err := service.Export(ctx, exportRequest("worker-1",
+		&metricspb.Metric{Name: "queue.depth", Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: []*metricspb.NumberDataPoint{
+			numberDataPoint(5, now, stringAttribute("queue", "orders")),
+			numberDataPoint(2, now, stringAttribute("queue", "payments")),
+		}}}},
+		&metricspb.Metric{Name: "jobs.processed", Data: &metricspb.Metric_Sum{Sum: &metricspb.Sum{DataPoints: []*metricspb.NumberDataPoint{
+			{TimeUnixNano: uint64(now.UnixNano()), Value: &metricspb.NumberDataPoint_AsInt{AsInt: 42}},
+		}}}},
+		&metricspb.Metric{Name: "job.duration", Data: &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{DataPoints: []*metricspb.HistogramDataPoint{{Count: 3}}}}},
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.GetPartialSuccess().GetRejectedDataPoints())
+
+	points := s.list("default", "queue.depth", now)
+	assert.Len(t, points, 2)
+	for _, point := range points {
+		assert.Equal(t, "worker-1", point.Attributes["k8s.pod.name"])
+	}
+	assert.Equal(t, float64(42), s.list("default", "jobs.processed", now)[0].Value)
+	assert.Empty(t, s.list("default", "job.duration", now))
+
+	// the older data points of a series are ignored
+	_, err = service.Export(ctx, exportRequest("worker-1",
+		&metricspb.Metric{Name: "queue.depth", Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: []*metricspb.NumberDataPoint{
+			numberDataPoint(9, now.Add(-time.Second), stringAttribute("queue", "orders")),
+		}}}},
+	))
+	assert.NoError(t, err)
+	for _, point := range s.list("default", "queue.depth", now) {
+		assert.NotEqual(t, float64(9), point.Value)
+	}
+
+	// and the series ended with no recorded value are dropped
+	ended := numberDataPoint(0, now.Add(time.Second), stringAttribute("queue", "payments"))
+	ended.Flags = uint32(metricspb.DataPointFlags_DATA_POINT_FLAGS_NO_RECORDED_VALUE_MASK)
+	_, err = service.Export(ctx, exportRequest("worker-1",
+		&metricspb.Metric{Name: "queue.depth", Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: []*metricspb.NumberDataPoint{ended}}}},
+	))
+	assert.NoError(t, err)
+	points = s.list("default", "queue.depth", now)
+	assert.Len(t, points, 1)
+	assert.Equal(t, "orders", points[0].Attributes["queue"])
+
+	// the series are kept per namespace
+	assert.Empty(t, s.list("other", "queue.depth", now))
+}
+
+func TestSeriesStoreLimits(t *testing.T) {
+	s := newSeriesStore(time.Minute, 2)
+	now := time.Now()
+	point := func(pod string) DataPoint {
+		return DataPoint{namespace: "default", Metric: "queue.depth", Attributes: map[string]string{"k8s.pod.name": pod}, Value: 1, Timestamp: now}
+	}
+
+	assert.Equal(t, 1, s.record([]DataPoint{point("worker-1"), point("worker-2"), point("worker-3")}, now))
+	assert.Len(t, s.list("default", "queue.depth", now), 2)
+	// the series already kept are still updated past maxSeries
+	assert.Equal(t, 0, s.record([]DataPoint{point("worker-1")}, now.Add(30*time.Second)))
+
+	// the series which aren't pushed anymore are dropped eventually
+	points := s.list("default", "queue.depth", now.Add(80*time.Second))
+	assert.Len(t, points, 1)
+	assert.Equal(t, "worker-1", points[0].Attributes["k8s.pod.name"])
+	assert.Empty(t, s.list("default", "queue.depth", now.Add(2*time.Minute)))
+}
+
+func TestReceiver(t *testing.T) {
+	certFile, keyFile, pool := testCertificate(t)
+	opts := Options{BindAddress: "127.0.0.1:0", TokenSecret: testTokenSecret, KubeClient: testSecrets(), CertFile: certFile, KeyFile: keyFile, SeriesTTL: time.Minute}
+	withoutTTL := opts
+	withoutTTL.SeriesTTL = 0
+	_, err := NewReceiver(withoutTTL)
+	assert.Error(t, err)
+	// the exports must be authenticated and served over tls
+	withoutTokens := opts
+	withoutTokens.KubeClient = nil
+	_, err = NewReceiver(withoutTokens)
+	assert.Error(t, err)
+	withoutCertificate := opts
+	withoutCertificate.CertFile = ""
+	_, err = NewReceiver(withoutCertificate)
+	assert.Error(t, err)
+	assert.False(t, Enabled())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	shutdown := start(listener, opts, nil)
+	assert.True(t, Enabled())
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12})))
+	assert.NoError(t, err)
+	defer conn.Close()
+	client := colmetricspb.NewMetricsServiceClient(conn)
+	req := exportRequest("worker-1", &metricspb.Metric{Name: "queue.depth", Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: []*metricspb.NumberDataPoint{
+		numberDataPoint(5, time.Now()),
+	}}}})
+
+	// the exports are authenticated once the tokens are listed
+	assert.Eventually(t, func() bool {
+		_, err := client.Export(exportContext("default", testToken), req)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, DataPoints("default", "queue.depth"), 1)
+	assert.Empty(t, DataPoints("other", "queue.depth"))
+
+	_, err = client.Export(metadata.AppendToOutgoingContext(context.Background(), NamespaceMetadata, "default"), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.Export(metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+testToken), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	// a token only allows exporting to its namespace
+	_, err = client.Export(exportContext("other", testToken), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.Export(exportContext("none", testToken), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	assert.NoError(t, shutdown())
+	assert.False(t, Enabled())
+	assert.Empty(t, DataPoints("default", "queue.depth"))
+}
+
+func TestMetricsServiceForwarding(t *testing.T) {
+	certFile, keyFile, _ := testCertificate(t)
+	cert := kedautil.NewServingCertificate(certFile, keyFile)
+
+	// the replica evaluating the scalers, an export forwarded isn't forwarded again
+	peer := &metricsService{store: newSeriesStore(time.Minute, 0), forwarder: &forwarder{peers: func(context.Context) ([]string, error) {
+		t.Error("forwarded export forwarded again")
+		return nil, nil
+	}}}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(cert.ServerConfig())), grpc.UnaryInterceptor(tokenInterceptor(testTokens(t))))
+	colmetricspb.RegisterMetricsServiceServer(server, peer)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	// the replica the workload exported to
+	f := &forwarder{credentials: credentials.NewTLS(cert.PeerConfig()), conns: map[string]*grpc.ClientConn{}, peers: func(context.Context) ([]string, error) {
+		return []string{listener.Addr().String()}, nil
+	}}
+	defer f.close()
+	service := &metricsService{store: newSeriesStore(time.Minute, 0), forwarder: f}
+	req := exportRequest("worker-1", &metricspb.Metric{Name: "queue.depth", Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: []*metricspb.NumberDataPoint{
+		numberDataPoint(5, time.Now()),
+	}}}})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(NamespaceMetadata, "default", "authorization", "Bearer "+testToken))
+	_, err = service.Export(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, service.store.list("default", "queue.depth", time.Now()), 1)
+	assert.Eventually(t, func() bool { return len(peer.store.list("default", "queue.depth", time.Now())) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// the peers authenticate the forwarded exports
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(NamespaceMetadata, "other", "authorization", "Bearer "+testToken))
+	_, err = service.Export(ctx, exportRequest("worker-2", &metricspb.Metric{Name: "queue.length", Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: []*metricspb.NumberDataPoint{
+		numberDataPoint(5, time.Now()),
+	}}}}))
+	assert.NoError(t, err)
+	assert.Never(t, func() bool { return len(peer.store.list("other", "queue.length", time.Now())) > 0 }, 200*time.Millisecond, 10*time.Millisecond)
+
+	// the exports aren't forwarded to the servers which don't serve the certificate of the receiver
+	otherCertFile, otherKeyFile, _ := testCertificate(t)
+	var received atomic.Bool
+	other := &metricsService{store: newSeriesStore(time.Minute, 0)}
+	otherListener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	otherServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(kedautil.NewServingCertificate(otherCertFile, otherKeyFile).ServerConfig())), grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		received.Store(true)
+		return handler(ctx, req)
+	}))
+	colmetricspb.RegisterMetricsServiceServer(otherServer, other)
+	go func() { _ = otherServer.Serve(otherListener) }()
+	defer otherServer.Stop()
+	f = &forwarder{credentials: f.credentials, conns: map[string]*grpc.ClientConn{}, peers: func(context.Context) ([]string, error) {
+		return []string{otherListener.Addr().String()}, nil
+	}}
+	defer f.close()
+	service = &metricsService{store: newSeriesStore(time.Minute, 0), forwarder: f}
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(NamespaceMetadata, "default", "authorization", "Bearer "+testToken))
+	_, err = service.Export(ctx, req)
+	assert.NoError(t, err)
+	assert.Never(t, received.Load, 200*time.Millisecond, 10*time.Millisecond)
+}
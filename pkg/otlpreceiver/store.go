@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package otlpreceiver
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DataPoint is the latest data point of a series, a metric of a namespace along with its attributes
+type DataPoint struct {
+	namespace  string
+	Metric     string
+	Attributes map[string]string
+	Value      float64
+	Timestamp  time.Time
+
+	receivedAt time.Time
+}
+
+// seriesStore keeps the latest data point pushed of each series
+type seriesStore struct {
+	lock      sync.Mutex
+	ttl       time.Duration
+	maxSeries int
+	series    map[string]*DataPoint
+}
+
+func newSeriesStore(ttl time.Duration, maxSeries int) *seriesStore {
+	return &seriesStore{ttl: ttl, maxSeries: maxSeries, series: map[string]*DataPoint{}}
+}
+
+// seriesKey identifies the series of the data point by its namespace, its metric and its sorted attributes
+func seriesKey(point DataPoint) string {
+	names := make([]string, 0, len(point.Attributes))
+	for name := range point.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var key strings.Builder
+	key.WriteString(point.namespace)
+	key.WriteString("\x00")
+	key.WriteString(point.Metric)
+	for _, name := range names {
+		key.WriteString("\x00")
+		key.WriteString(name)
+		key.WriteString("=")
+		key.WriteString(point.Attributes[name])
+	}
+	return key.String()
+}
+
+// record updates the series from the data points of an export, the points older than the latest
+// of their series are ignored and the new series past maxSeries are rejected, their count is returned
+func (s *seriesStore) record(points []DataPoint, now time.Time) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.expire(now)
+
+	rejected := 0
+	for _, point := range points {
+		key := seriesKey(point)
+		current, ok := s.series[key]
+		if ok && point.Timestamp.Before(current.Timestamp) {
+			continue
+		}
+		if !ok && s.maxSeries > 0 && len(s.series) >= s.maxSeries {
+			rejected++
+			continue
+		}
+		point.receivedAt = now
+		s.series[key] = &point
+	}
+	return rejected
+}
+
+// remove drops the series of the data points, sent with no recorded value once they ended
+func (s *seriesStore) remove(points []DataPoint) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, point := range points {
+		key := seriesKey(point)
+		if current, ok := s.series[key]; ok && !point.Timestamp.Before(current.Timestamp) {
+			delete(s.series, key)
+		}
+	}
+}
+
+// list returns the latest data points of the series of the metric of the namespace
+func (s *seriesStore) list(namespace, metric string, now time.Time) []DataPoint {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.expire(now)
+	var points []DataPoint
+	for _, point := range s.series {
+		if point.namespace == namespace && point.Metric == metric {
+			points = append(points, *point)
+		}
+	}
+	return points
+}
+
+// expire drops the series which haven't been pushed for longer than the ttl
+func (s *seriesStore) expire(now time.Time) {
+	for key, point := range s.series {
+		if now.Sub(point.receivedAt) > s.ttl {
+			delete(s.series, key)
+		}
+	}
+}
@@ -29,6 +29,7 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/metricsservice"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 )
 
 // KedaProvider implements External Metrics Provider
@@ -104,7 +105,10 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 		return &external_metrics.ExternalMetricValueList{}, err
 	}
 
+	attrs := append(tracing.ScalableObjectAttributes("ScaledObject", namespace, scaledObjectName), tracing.MetricNameKey.String(info.Metric))
+	ctx, span := tracing.StartSpan(ctx, "MetricsAdapter.GetExternalMetric", attrs...)
 	metrics, err := p.grpcClient.GetMetrics(ctx, scaledObjectName, namespace, info.Metric)
+	tracing.EndSpan(span, err)
 	logger.V(1).WithValues("scaledObjectName", scaledObjectName, "scaledObjectNamespace", namespace, "metrics", metrics).Info("Receiving metrics")
 
 	return metrics, err
@@ -388,6 +388,11 @@ func (s *apacheKafkaScaler) getLagForPartition(topic string, partitionID int, co
 	return producerOffset - consumerOffset, producerOffset - consumerOffset, nil
 }
 
+// IsStateful returns whether the persistent lag is detected from the offsets of the previous evaluation
+func (s *apacheKafkaScaler) IsStateful() bool {
+	return s.metadata.ExcludePersistentLag
+}
+
 // Close closes the kafka client
 func (s *apacheKafkaScaler) Close(context.Context) error {
 	if s.client == nil {
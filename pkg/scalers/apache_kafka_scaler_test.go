@@ -378,7 +378,9 @@ func TestApacheKafkaGetMetricSpecForScaling(t *testing.T) {
 func parseExpectedLagThreshold(metadata map[string]string) (int64, error) {
 	val, ok := metadata["lagThreshold"]
 	if !ok {
-		return 0, nil
+		if val, ok = metadata["lagThresholdSeconds"]; !ok {
+			return 0, nil
+		}
 	}
 	return strconv.ParseInt(val, 10, 64)
 }
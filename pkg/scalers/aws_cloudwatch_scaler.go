@@ -2,8 +2,10 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -17,6 +19,12 @@ import (
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
 
+// cloudwatchExpressionQueryID is the id of the query built from the expression metadata
+const cloudwatchExpressionQueryID = "q1"
+
+// cloudwatchQueryIDRegex is the format CloudWatch requires for MetricDataQuery ids
+var cloudwatchQueryIDRegex = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+
 type awsCloudwatchScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *awsCloudwatchMetadata
@@ -34,6 +42,11 @@ type awsCloudwatchMetadata struct {
 	DimensionValue []string `keda:"name=dimensionValue, order=triggerMetadata, optional"`
 	Expression     string   `keda:"name=expression, order=triggerMetadata, optional"`
 
+	// MetricDataQueries is a JSON list of metric stats and/or math expressions, which
+	// can be referenced by id from Expression, e.g. `m1 / m2`
+	MetricDataQueries string `keda:"name=metricDataQueries, order=triggerMetadata, optional"`
+	metricDataQueries []cloudwatchMetricDataQuery
+
 	TargetMetricValue           float64 `keda:"name=targetMetricValue, order=triggerMetadata"`
 	ActivationTargetMetricValue float64 `keda:"name=activationTargetMetricValue, order=triggerMetadata, optional"`
 	MinMetricValue              float64 `keda:"name=minMetricValue, order=triggerMetadata"`
@@ -49,9 +62,96 @@ type awsCloudwatchMetadata struct {
 	AwsEndpoint string `keda:"name=awsEndpoint, order=triggerMetadata, optional"`
 }
 
+// cloudwatchMetricDataQuery is a single query of the metricDataQueries metadata
+type cloudwatchMetricDataQuery struct {
+	ID         string                      `json:"id"`
+	Expression string                      `json:"expression,omitempty"`
+	Namespace  string                      `json:"namespace,omitempty"`
+	MetricName string                      `json:"metricName,omitempty"`
+	Dimensions []cloudwatchMetricDimension `json:"dimensions,omitempty"`
+	Stat       string                      `json:"stat,omitempty"`
+	Unit       string                      `json:"unit,omitempty"`
+	Period     int64                       `json:"period,omitempty"`
+	ReturnData bool                        `json:"returnData,omitempty"`
+}
+
+type cloudwatchMetricDimension struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (q *cloudwatchMetricDataQuery) validate() error {
+	if !cloudwatchQueryIDRegex.MatchString(q.ID) {
+		return fmt.Errorf("query id '%s' must start with a lowercase letter and contain only letters, numbers and underscores", q.ID)
+	}
+	if q.Expression != "" {
+		if q.Namespace != "" || q.MetricName != "" || len(q.Dimensions) > 0 || q.Stat != "" || q.Unit != "" {
+			return fmt.Errorf("query '%s' can't define both expression and metric stat", q.ID)
+		}
+		return nil
+	}
+	if q.Namespace == "" || q.MetricName == "" {
+		return fmt.Errorf("query '%s' must define either expression or namespace and metricName", q.ID)
+	}
+	for _, d := range q.Dimensions {
+		if d.Name == "" || d.Value == "" {
+			return fmt.Errorf("query '%s' has a dimension without name or value", q.ID)
+		}
+	}
+	if q.Stat != "" {
+		if err := checkMetricStat(q.Stat); err != nil {
+			return fmt.Errorf("query '%s': %w", q.ID, err)
+		}
+	}
+	if q.Period != 0 {
+		if err := checkMetricStatPeriod(q.Period); err != nil {
+			return fmt.Errorf("query '%s': %w", q.ID, err)
+		}
+	}
+	return checkMetricUnit(q.Unit)
+}
+
+func (a *awsCloudwatchMetadata) validateMetricDataQueries() error {
+	if err := json.Unmarshal([]byte(a.MetricDataQueries), &a.metricDataQueries); err != nil {
+		return fmt.Errorf("error parsing metricDataQueries: %w", err)
+	}
+	if len(a.metricDataQueries) == 0 {
+		return errors.New("metricDataQueries must contain at least one query")
+	}
+
+	ids := map[string]bool{}
+	returning := 0
+	for i := range a.metricDataQueries {
+		q := &a.metricDataQueries[i]
+		if err := q.validate(); err != nil {
+			return err
+		}
+		if ids[q.ID] || (a.Expression != "" && q.ID == cloudwatchExpressionQueryID) {
+			return fmt.Errorf("query id '%s' is used more than once", q.ID)
+		}
+		ids[q.ID] = true
+		if q.ReturnData {
+			returning++
+		}
+	}
+
+	// the scaler can only consume a single timeseries, so there must be no doubt about which one
+	if a.Expression != "" && returning > 0 {
+		return errors.New("returnData can't be set on metricDataQueries when expression is given")
+	}
+	if a.Expression == "" && returning != 1 {
+		return errors.New("exactly one of metricDataQueries must set returnData when expression isn't given")
+	}
+	return nil
+}
+
 func (a *awsCloudwatchMetadata) Validate() error {
 	var err error
-	if a.Expression == "" {
+	if a.MetricDataQueries != "" {
+		if err = a.validateMetricDataQueries(); err != nil {
+			return err
+		}
+	} else if a.Expression == "" {
 		if a.Namespace == "" {
 			return errors.New("namespace not given")
 		}
@@ -220,10 +320,20 @@ func (s *awsCloudwatchScaler) Close(context.Context) error {
 
 func (s *awsCloudwatchScaler) GetCloudwatchMetrics(ctx context.Context) (float64, error) {
 	var input cloudwatch.GetMetricDataInput
+	var resultID string
 
 	startTime, endTime := computeQueryWindow(time.Now(), s.metadata.MetricStatPeriod, s.metadata.MetricEndTimeOffset, s.metadata.MetricCollectionTime)
 
-	if s.metadata.Expression != "" {
+	switch {
+	case s.metadata.MetricDataQueries != "":
+		input = cloudwatch.GetMetricDataInput{
+			StartTime:         aws.Time(startTime),
+			EndTime:           aws.Time(endTime),
+			ScanBy:            types.ScanByTimestampDescending,
+			MetricDataQueries: s.buildMetricDataQueries(),
+		}
+		resultID = s.metricDataResultID()
+	case s.metadata.Expression != "":
 		input = cloudwatch.GetMetricDataInput{
 			StartTime: aws.Time(startTime),
 			EndTime:   aws.Time(endTime),
@@ -231,12 +341,13 @@ func (s *awsCloudwatchScaler) GetCloudwatchMetrics(ctx context.Context) (float64
 			MetricDataQueries: []types.MetricDataQuery{
 				{
 					Expression: aws.String(s.metadata.Expression),
-					Id:         aws.String("q1"),
+					Id:         aws.String(cloudwatchExpressionQueryID),
 					Period:     aws.Int32(int32(s.metadata.MetricStatPeriod)),
 				},
 			},
 		}
-	} else {
+		resultID = cloudwatchExpressionQueryID
+	default:
 		var dimensions []types.Dimension
 		for i := range s.metadata.DimensionName {
 			dimensions = append(dimensions, types.Dimension{
@@ -271,6 +382,7 @@ func (s *awsCloudwatchScaler) GetCloudwatchMetrics(ctx context.Context) (float64
 				},
 			},
 		}
+		resultID = "c1"
 	}
 
 	output, err := s.cwClient.GetMetricData(ctx, &input)
@@ -283,7 +395,8 @@ func (s *awsCloudwatchScaler) GetCloudwatchMetrics(ctx context.Context) (float64
 
 	// If no metric data results or the first result has no values, and ignoreNullValues is false,
 	// the scaler should return an error to prevent any further scaling actions.
-	if len(output.MetricDataResults) > 0 && len(output.MetricDataResults[0].Values) == 0 && !s.metadata.IgnoreNullValues {
+	result := selectMetricDataResult(output.MetricDataResults, resultID)
+	if result != nil && len(result.Values) == 0 && !s.metadata.IgnoreNullValues {
 		emptyMetricsErrMsg := "empty metric data received, ignoreNullValues is false, returning error"
 		s.logger.Error(nil, emptyMetricsErrMsg)
 		return -1, fmt.Errorf(emptyMetricsErrMsg)
@@ -291,11 +404,92 @@ func (s *awsCloudwatchScaler) GetCloudwatchMetrics(ctx context.Context) (float64
 
 	var metricValue float64
 
-	if len(output.MetricDataResults) > 0 && len(output.MetricDataResults[0].Values) > 0 {
-		metricValue = output.MetricDataResults[0].Values[0]
+	if result != nil && len(result.Values) > 0 {
+		metricValue = result.Values[0]
 	} else {
 		s.logger.Info("empty metric data received, returning minMetricValue")
 		metricValue = s.metadata.MinMetricValue
 	}
 	return metricValue, nil
 }
+
+// buildMetricDataQueries converts the metricDataQueries metadata (plus the expression consuming
+// them, if any) into the queries of a single GetMetricData request
+func (s *awsCloudwatchScaler) buildMetricDataQueries() []types.MetricDataQuery {
+	queries := make([]types.MetricDataQuery, 0, len(s.metadata.metricDataQueries)+1)
+	for _, q := range s.metadata.metricDataQueries {
+		period := q.Period
+		if period == 0 {
+			period = s.metadata.MetricStatPeriod
+		}
+
+		query := types.MetricDataQuery{
+			Id:         aws.String(q.ID),
+			ReturnData: aws.Bool(q.ReturnData),
+		}
+		if q.Expression != "" {
+			query.Expression = aws.String(q.Expression)
+			query.Period = aws.Int32(int32(period))
+		} else {
+			stat := q.Stat
+			if stat == "" {
+				stat = s.metadata.MetricStat
+			}
+			dimensions := make([]types.Dimension, 0, len(q.Dimensions))
+			for _, d := range q.Dimensions {
+				dimensions = append(dimensions, types.Dimension{
+					Name:  aws.String(d.Name),
+					Value: aws.String(d.Value),
+				})
+			}
+			query.MetricStat = &types.MetricStat{
+				Metric: &types.Metric{
+					Namespace:  aws.String(q.Namespace),
+					MetricName: aws.String(q.MetricName),
+					Dimensions: dimensions,
+				},
+				Period: aws.Int32(int32(period)),
+				Stat:   aws.String(stat),
+				Unit:   types.StandardUnit(q.Unit),
+			}
+		}
+		queries = append(queries, query)
+	}
+
+	if s.metadata.Expression != "" {
+		queries = append(queries, types.MetricDataQuery{
+			Expression: aws.String(s.metadata.Expression),
+			Id:         aws.String(cloudwatchExpressionQueryID),
+			Period:     aws.Int32(int32(s.metadata.MetricStatPeriod)),
+			ReturnData: aws.Bool(true),
+		})
+	}
+	return queries
+}
+
+// metricDataResultID returns the id of the query whose timeseries feeds the metric
+func (s *awsCloudwatchScaler) metricDataResultID() string {
+	if s.metadata.Expression != "" {
+		return cloudwatchExpressionQueryID
+	}
+	for _, q := range s.metadata.metricDataQueries {
+		if q.ReturnData {
+			return q.ID
+		}
+	}
+	return ""
+}
+
+// selectMetricDataResult returns the result for the given query id, falling back
+// to the first result when the response doesn't carry ids
+func selectMetricDataResult(results []types.MetricDataResult, id string) *types.MetricDataResult {
+	if len(results) == 0 {
+		return nil
+	}
+	for i := range results {
+		if results[i].Id != nil && *results[i].Id == id {
+			return &results[i]
+		}
+	}
+	return &results[0]
+}
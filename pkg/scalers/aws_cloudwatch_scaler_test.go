@@ -478,6 +478,101 @@ var testAWSCloudwatchMetadata = []parseAWSCloudwatchMetadataTestData{
 		testAWSAuthentication, true,
 		"unsupported value for ignoreNullValues",
 	},
+	// metric math over multiple metric stats
+	{
+		map[string]string{
+			"metricDataQueries": `[{"id": "m1", "namespace": "AWS/SQS", "metricName": "ApproximateNumberOfMessagesVisible", "dimensions": [{"name": "QueueName", "value": "keda"}], "stat": "Sum"},
+				{"id": "m2", "namespace": "AWS/AutoScaling", "metricName": "GroupInServiceInstances", "dimensions": [{"name": "AutoScalingGroupName", "value": "workers"}, {"name": "Env", "value": "prod"}]}]`,
+			"expression":        "m1 / m2",
+			"targetMetricValue": "2",
+			"minMetricValue":    "0",
+			"awsRegion":         "eu-west-1",
+		},
+		testAWSAuthentication, false,
+		"metric math over multiple metric stats",
+	},
+	// metricDataQueries returning data without expression
+	{
+		map[string]string{
+			"metricDataQueries": `[{"id": "m1", "namespace": "AWS/SQS", "metricName": "ApproximateNumberOfMessagesVisible"}, {"id": "e1", "expression": "RATE(m1)", "returnData": true}]`,
+			"targetMetricValue": "2",
+			"minMetricValue":    "0",
+			"awsRegion":         "eu-west-1",
+		},
+		testAWSAuthentication, false,
+		"metricDataQueries returning data without expression",
+	},
+	// metricDataQueries without any returned data
+	{
+		map[string]string{
+			"metricDataQueries": `[{"id": "m1", "namespace": "AWS/SQS", "metricName": "ApproximateNumberOfMessagesVisible"}]`,
+			"targetMetricValue": "2",
+			"minMetricValue":    "0",
+			"awsRegion":         "eu-west-1",
+		},
+		testAWSAuthentication, true,
+		"metricDataQueries without any returned data",
+	},
+	// metricDataQueries returning data along with expression
+	{
+		map[string]string{
+			"metricDataQueries": `[{"id": "m1", "namespace": "AWS/SQS", "metricName": "ApproximateNumberOfMessagesVisible", "returnData": true}]`,
+			"expression":        "m1 * 2",
+			"targetMetricValue": "2",
+			"minMetricValue":    "0",
+			"awsRegion":         "eu-west-1",
+		},
+		testAWSAuthentication, true,
+		"metricDataQueries returning data along with expression",
+	},
+	// metricDataQueries with duplicated ids
+	{
+		map[string]string{
+			"metricDataQueries": `[{"id": "m1", "namespace": "AWS/SQS", "metricName": "A"}, {"id": "m1", "namespace": "AWS/SQS", "metricName": "B"}]`,
+			"expression":        "m1 * 2",
+			"targetMetricValue": "2",
+			"minMetricValue":    "0",
+			"awsRegion":         "eu-west-1",
+		},
+		testAWSAuthentication, true,
+		"metricDataQueries with duplicated ids",
+	},
+	// metricDataQueries with invalid id
+	{
+		map[string]string{
+			"metricDataQueries": `[{"id": "M1", "namespace": "AWS/SQS", "metricName": "A"}]`,
+			"expression":        "M1 * 2",
+			"targetMetricValue": "2",
+			"minMetricValue":    "0",
+			"awsRegion":         "eu-west-1",
+		},
+		testAWSAuthentication, true,
+		"metricDataQueries with invalid id",
+	},
+	// metricDataQueries with invalid stat
+	{
+		map[string]string{
+			"metricDataQueries": `[{"id": "m1", "namespace": "AWS/SQS", "metricName": "A", "stat": "Median"}]`,
+			"expression":        "m1 * 2",
+			"targetMetricValue": "2",
+			"minMetricValue":    "0",
+			"awsRegion":         "eu-west-1",
+		},
+		testAWSAuthentication, true,
+		"metricDataQueries with invalid stat",
+	},
+	// metricDataQueries is not valid JSON
+	{
+		map[string]string{
+			"metricDataQueries": `[{"id": "m1"`,
+			"expression":        "m1 * 2",
+			"targetMetricValue": "2",
+			"minMetricValue":    "0",
+			"awsRegion":         "eu-west-1",
+		},
+		testAWSAuthentication, true,
+		"metricDataQueries is not valid JSON",
+	},
 }
 
 var awsCloudwatchMetricIdentifiers = []awsCloudwatchMetricIdentifier{
@@ -589,6 +684,18 @@ type mockCloudwatch struct {
 }
 
 func (m *mockCloudwatch) GetMetricData(_ context.Context, input *cloudwatch.GetMetricDataInput, _ ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error) {
+	if len(input.MetricDataQueries) > 1 {
+		// metric math: every query gets its own result, only the returned one has the expected value
+		var results []types.MetricDataResult
+		for _, q := range input.MetricDataQueries {
+			value := float64(1)
+			if q.ReturnData != nil && *q.ReturnData {
+				value = 10
+			}
+			results = append(results, types.MetricDataResult{Id: q.Id, Values: []float64{value}})
+		}
+		return &cloudwatch.GetMetricDataOutput{MetricDataResults: results}, nil
+	}
 	if input.MetricDataQueries[0].MetricStat != nil {
 		switch *input.MetricDataQueries[0].MetricStat.Metric.MetricName {
 		case testAWSCloudwatchErrorMetric:
@@ -617,6 +724,29 @@ func (m *mockCloudwatch) GetMetricData(_ context.Context, input *cloudwatch.GetM
 	}, nil
 }
 
+func TestAWSCloudwatchScalerGetMetricsFromMetricDataQueries(t *testing.T) {
+	for _, testData := range []*parseAWSCloudwatchMetadataTestData{&testAWSCloudwatchMetadata[len(testAWSCloudwatchMetadata)-8], &testAWSCloudwatchMetadata[len(testAWSCloudwatchMetadata)-7]} {
+		meta, err := parseAwsCloudwatchMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, ResolvedEnv: testAWSCloudwatchResolvedEnv, AuthParams: testData.authParams})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockAWSCloudwatchScaler := awsCloudwatchScaler{"", meta, &mockCloudwatch{}, logr.Discard()}
+
+		queries := mockAWSCloudwatchScaler.buildMetricDataQueries()
+		returned := 0
+		for _, q := range queries {
+			if *q.ReturnData {
+				returned++
+			}
+		}
+		assert.Equal(t, 1, returned, testData.comment)
+
+		value, _, err := mockAWSCloudwatchScaler.GetMetricsAndActivity(context.Background(), "metric")
+		assert.NoError(t, err, testData.comment)
+		assert.EqualValues(t, int64(10.0), value[0].Value.Value(), testData.comment)
+	}
+}
+
 func TestCloudwatchParseMetadata(t *testing.T) {
 	for _, testData := range testAWSCloudwatchMetadata {
 		_, err := parseAwsCloudwatchMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, ResolvedEnv: testAWSCloudwatchResolvedEnv, AuthParams: testData.authParams})
@@ -3,6 +3,7 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/url"
 	"strconv"
 	"strings"
@@ -24,6 +25,14 @@ const (
 	activationTargetQueueLengthDefault = 0
 	defaultScaleOnInFlight             = true
 	defaultScaleOnDelayed              = false
+	defaultScaleOnMessageGroups        = false
+	defaultMessageGroupsSampleSize     = 100
+
+	// sqsMaxReceiveMessages is the most messages returned by a ReceiveMessage call
+	sqsMaxReceiveMessages = 10
+	// sqsMaxMessageGroupsSampleSize bounds the ReceiveMessage calls of each poll
+	sqsMaxMessageGroupsSampleSize = 1000
+	sqsFifoQueueSuffix            = ".fifo"
 )
 
 type awsSqsQueueScaler struct {
@@ -44,6 +53,8 @@ type awsSqsQueueMetadata struct {
 	triggerIndex                int
 	scaleOnInFlight             bool
 	scaleOnDelayed              bool
+	scaleOnMessageGroups        bool
+	messageGroupsSampleSize     int
 	awsSqsQueueMetricNames      []types.QueueAttributeName
 }
 
@@ -76,6 +87,7 @@ func NewAwsSqsQueueScaler(ctx context.Context, config *scalersconfig.ScalerConfi
 
 type SqsWrapperClient interface {
 	GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
+	ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
 }
 
 type sqsWrapperClient struct {
@@ -86,11 +98,17 @@ func (w sqsWrapperClient) GetQueueAttributes(ctx context.Context, params *sqs.Ge
 	return w.sqsClient.GetQueueAttributes(ctx, params, optFns...)
 }
 
+func (w sqsWrapperClient) ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	return w.sqsClient.ReceiveMessage(ctx, params, optFns...)
+}
+
 func parseAwsSqsQueueMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (*awsSqsQueueMetadata, error) {
 	meta := awsSqsQueueMetadata{}
 	meta.targetQueueLength = defaultTargetQueueLength
 	meta.scaleOnInFlight = defaultScaleOnInFlight
 	meta.scaleOnDelayed = defaultScaleOnDelayed
+	meta.scaleOnMessageGroups = defaultScaleOnMessageGroups
+	meta.messageGroupsSampleSize = defaultMessageGroupsSampleSize
 
 	if val, ok := config.TriggerMetadata["queueLength"]; ok && val != "" {
 		queueLength, err := strconv.ParseInt(val, 10, 64)
@@ -132,6 +150,25 @@ func parseAwsSqsQueueMetadata(config *scalersconfig.ScalerConfig, logger logr.Lo
 		}
 	}
 
+	if val, ok := config.TriggerMetadata["scaleOnMessageGroups"]; ok && val != "" {
+		scaleOnMessageGroups, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing scaleOnMessageGroups: %w", err)
+		}
+		meta.scaleOnMessageGroups = scaleOnMessageGroups
+	}
+
+	if val, ok := config.TriggerMetadata["messageGroupsSampleSize"]; ok && val != "" {
+		messageGroupsSampleSize, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing messageGroupsSampleSize: %w", err)
+		}
+		if messageGroupsSampleSize < sqsMaxReceiveMessages || messageGroupsSampleSize > sqsMaxMessageGroupsSampleSize {
+			return nil, fmt.Errorf("messageGroupsSampleSize must be between %d and %d", sqsMaxReceiveMessages, sqsMaxMessageGroupsSampleSize)
+		}
+		meta.messageGroupsSampleSize = messageGroupsSampleSize
+	}
+
 	meta.awsSqsQueueMetricNames = []types.QueueAttributeName{}
 	meta.awsSqsQueueMetricNames = append(meta.awsSqsQueueMetricNames, types.QueueAttributeNameApproximateNumberOfMessages)
 	if meta.scaleOnInFlight {
@@ -167,6 +204,10 @@ func parseAwsSqsQueueMetadata(config *scalersconfig.ScalerConfig, logger logr.Lo
 		meta.queueName = queueURLPathParts[2]
 	}
 
+	if meta.scaleOnMessageGroups && !strings.HasSuffix(meta.queueName, sqsFifoQueueSuffix) {
+		return nil, fmt.Errorf("scaleOnMessageGroups is only supported with FIFO queues")
+	}
+
 	if val, ok := config.TriggerMetadata["awsRegion"]; ok && val != "" {
 		meta.awsRegion = val
 	} else {
@@ -249,8 +290,61 @@ func (s *awsSqsQueueScaler) getAwsSqsQueueLength(ctx context.Context) (int64, er
 		if err != nil {
 			return -1, err
 		}
+		// the visible messages of a FIFO queue are processed one message group at a time
+		if s.metadata.scaleOnMessageGroups && awsSqsQueueMetric == types.QueueAttributeNameApproximateNumberOfMessages {
+			metricValue, err = s.getAwsSqsMessageGroups(ctx, metricValue)
+			if err != nil {
+				return -1, err
+			}
+		}
 		approximateNumberOfMessages += metricValue
 	}
 
 	return approximateNumberOfMessages, nil
 }
+
+// getAwsSqsMessageGroups estimates the number of message groups with visible messages, SQS doesn't
+// report it so the visible messages are sampled. The sampled messages are received with a visibility
+// timeout of 0 so they can be received again right away, their receive count is increased though,
+// which counts towards the maxReceiveCount of the redrive policy of the queue.
+func (s *awsSqsQueueScaler) getAwsSqsMessageGroups(ctx context.Context, visibleMessages int64) (int64, error) {
+	if visibleMessages == 0 {
+		return 0, nil
+	}
+
+	messages := map[string]struct{}{}
+	groups := map[string]struct{}{}
+	for sampled := 0; sampled < s.metadata.messageGroupsSampleSize && int64(len(messages)) < visibleMessages; sampled += sqsMaxReceiveMessages {
+		output, err := s.sqsWrapperClient.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:                    aws.String(s.metadata.queueURL),
+			MaxNumberOfMessages:         sqsMaxReceiveMessages,
+			VisibilityTimeout:           0,
+			WaitTimeSeconds:             0,
+			MessageSystemAttributeNames: []types.MessageSystemAttributeName{types.MessageSystemAttributeNameMessageGroupId},
+		})
+		if err != nil {
+			return -1, err
+		}
+		if len(output.Messages) == 0 {
+			break
+		}
+		for _, message := range output.Messages {
+			messages[aws.ToString(message.MessageId)] = struct{}{}
+			groups[message.Attributes[string(types.MessageSystemAttributeNameMessageGroupId)]] = struct{}{}
+		}
+	}
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	// the groups of the messages not sampled are extrapolated from the ones of the sampled messages,
+	// there are at least as many groups as sampled and at most as many as the visible messages
+	estimate := int64(math.Ceil(float64(len(groups)) * float64(visibleMessages) / float64(len(messages))))
+	if estimate > visibleMessages {
+		estimate = visibleMessages
+	}
+	if estimate < int64(len(groups)) {
+		estimate = int64(len(groups))
+	}
+	return estimate, nil
+}
@@ -6,7 +6,9 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 
@@ -26,10 +28,13 @@ const (
 
 	testAWSSQSErrorQueueURL   = "https://sqs.eu-west-1.amazonaws.com/account_id/Error"
 	testAWSSQSBadDataQueueURL = "https://sqs.eu-west-1.amazonaws.com/account_id/BadData"
+	testAWSSQSFifoQueueURL    = "https://sqs.eu-west-1.amazonaws.com/account_id/Jobs.fifo"
+	testAWSSQSEmptyQueueURL   = "https://sqs.eu-west-1.amazonaws.com/account_id/Empty.fifo"
 
 	testAWSSQSApproximateNumberOfMessagesVisible    = 200
 	testAWSSQSApproximateNumberOfMessagesNotVisible = 100
 	testAWSSQSApproximateNumberOfMessagesDelayed    = 50
+	testAWSSQSMessageGroups                         = 5
 )
 
 var testAWSSQSEmptyResolvedEnv = map[string]string{}
@@ -58,6 +63,7 @@ type awsSQSMetricIdentifier struct {
 }
 
 type mockSqs struct {
+	receiveCalls int
 }
 
 func (m *mockSqs) GetQueueAttributes(_ context.Context, input *sqs.GetQueueAttributesInput, _ ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
@@ -73,6 +79,15 @@ func (m *mockSqs) GetQueueAttributes(_ context.Context, input *sqs.GetQueueAttri
 		}, nil
 	}
 
+	if *input.QueueUrl == testAWSSQSEmptyQueueURL {
+		return &sqs.GetQueueAttributesOutput{
+			Attributes: map[string]string{
+				"ApproximateNumberOfMessages":           "0",
+				"ApproximateNumberOfMessagesNotVisible": "0",
+			},
+		}, nil
+	}
+
 	return &sqs.GetQueueAttributesOutput{
 		Attributes: map[string]string{
 			"ApproximateNumberOfMessages":           strconv.Itoa(testAWSSQSApproximateNumberOfMessagesVisible),
@@ -82,6 +97,26 @@ func (m *mockSqs) GetQueueAttributes(_ context.Context, input *sqs.GetQueueAttri
 	}, nil
 }
 
+// ReceiveMessage returns the next messages of the queue, they're spread over testAWSSQSMessageGroups groups
+func (m *mockSqs) ReceiveMessage(_ context.Context, input *sqs.ReceiveMessageInput, _ ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	if *input.QueueUrl == testAWSSQSErrorQueueURL {
+		return nil, errors.New("some error")
+	}
+	output := &sqs.ReceiveMessageOutput{}
+	for i := 0; i < int(input.MaxNumberOfMessages); i++ {
+		id := m.receiveCalls*int(input.MaxNumberOfMessages) + i
+		if id >= testAWSSQSApproximateNumberOfMessagesVisible {
+			break
+		}
+		output.Messages = append(output.Messages, types.Message{
+			MessageId:  aws.String(strconv.Itoa(id)),
+			Attributes: map[string]string{"MessageGroupId": strconv.Itoa(id % testAWSSQSMessageGroups)},
+		})
+	}
+	m.receiveCalls++
+	return output, nil
+}
+
 var testAWSSQSMetadata = []parseAWSSQSMetadataTestData{
 	{map[string]string{},
 		testAWSSQSAuthentication,
@@ -305,6 +340,39 @@ var testAWSSQSMetadata = []parseAWSSQSMetadataTestData{
 		},
 		true,
 		"empty QUEUE_URL env value"},
+	{map[string]string{
+		"queueURL":             testAWSSQSFifoQueueURL,
+		"awsRegion":            "eu-west-1",
+		"scaleOnMessageGroups": "true"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		false,
+		"properly formed FIFO queue with scaleOnMessageGroups"},
+	{map[string]string{
+		"queueURL":             testAWSSQSProperQueueURL,
+		"awsRegion":            "eu-west-1",
+		"scaleOnMessageGroups": "true"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"scaleOnMessageGroups with a standard queue"},
+	{map[string]string{
+		"queueURL":             testAWSSQSFifoQueueURL,
+		"awsRegion":            "eu-west-1",
+		"scaleOnMessageGroups": "yes"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"invalid scaleOnMessageGroups"},
+	{map[string]string{
+		"queueURL":                testAWSSQSFifoQueueURL,
+		"awsRegion":               "eu-west-1",
+		"scaleOnMessageGroups":    "true",
+		"messageGroupsSampleSize": "5000"},
+		testAWSSQSAuthentication,
+		testAWSSQSEmptyResolvedEnv,
+		true,
+		"messageGroupsSampleSize out of range"},
 }
 
 var awsSQSMetricIdentifiers = []awsSQSMetricIdentifier{
@@ -444,3 +512,40 @@ func TestAWSSQSScalerGetMetrics(t *testing.T) {
 		}
 	}
 }
+
+func TestAWSSQSScalerGetMessageGroups(t *testing.T) {
+	tests := []struct {
+		comment    string
+		queueURL   string
+		sampleSize string
+		inFlight   string
+		expected   int64
+	}{
+		// the groups of the sampled messages are extrapolated to the visible messages
+		{"half of the messages sampled", testAWSSQSFifoQueueURL, "100", "false", 2 * testAWSSQSMessageGroups},
+		{"all the messages sampled", testAWSSQSFifoQueueURL, "1000", "false", testAWSSQSMessageGroups},
+		{"in flight messages", testAWSSQSFifoQueueURL, "1000", "true", testAWSSQSMessageGroups + testAWSSQSApproximateNumberOfMessagesNotVisible},
+		{"empty queue", testAWSSQSEmptyQueueURL, "100", "false", 0},
+	}
+	for _, test := range tests {
+		meta, err := parseAwsSqsQueueMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+			"queueURL":                test.queueURL,
+			"awsRegion":               "eu-west-1",
+			"scaleOnMessageGroups":    "true",
+			"messageGroupsSampleSize": test.sampleSize,
+			"scaleOnInFlight":         test.inFlight,
+		}, AuthParams: testAWSSQSAuthentication}, logr.Discard())
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mock := &mockSqs{}
+		scaler := awsSqsQueueScaler{"", meta, mock, logr.Discard()}
+
+		value, _, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
+		assert.NoError(t, err, test.comment)
+		assert.EqualValues(t, test.expected, value[0].Value.Value(), test.comment)
+		if test.queueURL == testAWSSQSEmptyQueueURL {
+			assert.Zero(t, mock.receiveCalls, "the empty queues aren't sampled")
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package scalers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	stepFunctionsSigningName  = "states"
+	stepFunctionsContentType  = "application/x-amz-json-1.0"
+	stepFunctionsTargetPrefix = "AWSStepFunctions."
+	// stepFunctionsPageSize is the largest page of executions returned by ListExecutions
+	stepFunctionsPageSize = 1000
+)
+
+type awsStepFunctionsScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *awsStepFunctionsMetadata
+	sfnClient  stepFunctionsWrapperClient
+	logger     logr.Logger
+}
+
+type awsStepFunctionsMetadata struct {
+	awsAuthorization awsutils.AuthorizationMetadata
+
+	triggerIndex                   int
+	StateMachineArn                string   `keda:"name=stateMachineArn, order=triggerMetadata, optional"`
+	MapRunArn                      string   `keda:"name=mapRunArn, order=triggerMetadata, optional"`
+	ExecutionStatuses              []string `keda:"name=executionStatuses, order=triggerMetadata, enum=RUNNING;PENDING_REDRIVE, default=RUNNING"`
+	TargetExecutionCount           int64    `keda:"name=targetExecutionCount, order=triggerMetadata, default=5"`
+	ActivationTargetExecutionCount int64    `keda:"name=activationTargetExecutionCount, order=triggerMetadata, default=0"`
+
+	AwsRegion   string `keda:"name=awsRegion, order=triggerMetadata"`
+	AwsEndpoint string `keda:"name=awsEndpoint, order=triggerMetadata, optional"`
+}
+
+// stepFunctionsWrapperClient is the part of the Step Functions API used by the scaler
+type stepFunctionsWrapperClient interface {
+	ListExecutions(ctx context.Context, input *stepFunctionsListExecutionsInput) (*stepFunctionsListExecutionsOutput, error)
+	DescribeMapRun(ctx context.Context, input *stepFunctionsDescribeMapRunInput) (*stepFunctionsDescribeMapRunOutput, error)
+}
+
+type stepFunctionsListExecutionsInput struct {
+	StateMachineArn string `json:"stateMachineArn,omitempty"`
+	MapRunArn       string `json:"mapRunArn,omitempty"`
+	StatusFilter    string `json:"statusFilter,omitempty"`
+	MaxResults      int    `json:"maxResults,omitempty"`
+	NextToken       string `json:"nextToken,omitempty"`
+}
+
+type stepFunctionsListExecutionsOutput struct {
+	Executions []struct {
+		ExecutionArn string `json:"executionArn"`
+		Status       string `json:"status"`
+	} `json:"executions"`
+	NextToken string `json:"nextToken"`
+}
+
+type stepFunctionsDescribeMapRunInput struct {
+	MapRunArn string `json:"mapRunArn"`
+}
+
+type stepFunctionsDescribeMapRunOutput struct {
+	Status     string `json:"status"`
+	ItemCounts struct {
+		Pending int64 `json:"pending"`
+		Running int64 `json:"running"`
+	} `json:"itemCounts"`
+}
+
+// stepFunctionsError is the body of the errors returned by the Step Functions API
+type stepFunctionsError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+func (a *awsStepFunctionsMetadata) Validate() error {
+	if (a.StateMachineArn == "") == (a.MapRunArn == "") {
+		return errors.New("exactly one of stateMachineArn or mapRunArn must be provided")
+	}
+	if a.TargetExecutionCount <= 0 {
+		return errors.New("targetExecutionCount must be greater than 0")
+	}
+	return nil
+}
+
+// NewAwsStepFunctionsScaler creates a new scaler for the executions of a Step Functions state
+// machine, or the items of a Map Run, which are pending or running
+func NewAwsStepFunctionsScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseAwsStepFunctionsMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing step functions metadata: %w", err)
+	}
+
+	sfnClient, err := createStepFunctionsClient(ctx, meta, config.GlobalHTTPTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("error creating step functions client: %w", err)
+	}
+
+	return &awsStepFunctionsScaler{
+		metricType: metricType,
+		metadata:   meta,
+		sfnClient:  sfnClient,
+		logger:     InitializeLogger(config, "aws_step_functions_scaler"),
+	}, nil
+}
+
+func parseAwsStepFunctionsMetadata(config *scalersconfig.ScalerConfig) (*awsStepFunctionsMetadata, error) {
+	meta := &awsStepFunctionsMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing step functions metadata: %w", err)
+	}
+
+	awsAuthorization, err := awsutils.GetAwsAuthorization(config.TriggerUniqueKey, config.PodIdentity, config.TriggerMetadata, config.AuthParams, config.ResolvedEnv)
+	if err != nil {
+		return nil, err
+	}
+	meta.awsAuthorization = awsAuthorization
+
+	meta.triggerIndex = config.TriggerIndex
+
+	return meta, nil
+}
+
+// stepFunctionsClient calls the JSON protocol of the Step Functions API, signing the requests
+// with the credentials of the shared aws.Config
+type stepFunctionsClient struct {
+	httpClient  *http.Client
+	endpoint    string
+	region      string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+}
+
+func createStepFunctionsClient(ctx context.Context, metadata *awsStepFunctionsMetadata, timeout time.Duration) (*stepFunctionsClient, error) {
+	cfg, err := awsutils.GetAwsConfig(ctx, metadata.AwsRegion, metadata.awsAuthorization)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := metadata.AwsEndpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://states.%s.amazonaws.com", metadata.AwsRegion)
+		if strings.HasPrefix(metadata.AwsRegion, "cn-") {
+			endpoint += ".cn"
+		}
+	}
+
+	return &stepFunctionsClient{
+		httpClient:  kedautil.CreateHTTPClient(timeout, false),
+		endpoint:    strings.TrimSuffix(endpoint, "/") + "/",
+		region:      metadata.AwsRegion,
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+	}, nil
+}
+
+func (c *stepFunctionsClient) ListExecutions(ctx context.Context, input *stepFunctionsListExecutionsInput) (*stepFunctionsListExecutionsOutput, error) {
+	output := &stepFunctionsListExecutionsOutput{}
+	if err := c.call(ctx, "ListExecutions", input, output); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (c *stepFunctionsClient) DescribeMapRun(ctx context.Context, input *stepFunctionsDescribeMapRunInput) (*stepFunctionsDescribeMapRunOutput, error) {
+	output := &stepFunctionsDescribeMapRunOutput{}
+	if err := c.call(ctx, "DescribeMapRun", input, output); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// call sends a signed request of the operation and decodes its response into the output
+func (c *stepFunctionsClient) call(ctx context.Context, operation string, input, output any) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", stepFunctionsContentType)
+	req.Header.Set("X-Amz-Target", stepFunctionsTargetPrefix+operation)
+
+	cred, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("error retrieving aws credentials: %w", err)
+	}
+	payloadHash := sha256.Sum256(body)
+	if err := c.signer.SignHTTP(ctx, cred, req, hex.EncodeToString(payloadHash[:]), stepFunctionsSigningName, c.region, time.Now()); err != nil {
+		return fmt.Errorf("error signing the request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := stepFunctionsError{}
+		if err := json.Unmarshal(respBody, &apiErr); err != nil || apiErr.Type == "" {
+			return fmt.Errorf("step functions %s returned status %d: %s", operation, resp.StatusCode, strings.TrimSpace(string(respBody)))
+		}
+		// the type may be prefixed by the namespace of the error, e.g. `com.amazonaws.states#ExecutionDoesNotExist`
+		if i := strings.LastIndex(apiErr.Type, "#"); i >= 0 {
+			apiErr.Type = apiErr.Type[i+1:]
+		}
+		return fmt.Errorf("step functions %s failed: %s: %s", operation, apiErr.Type, apiErr.Message)
+	}
+	return json.Unmarshal(respBody, output)
+}
+
+func (s *awsStepFunctionsScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	resource := s.metadata.StateMachineArn
+	if resource == "" {
+		resource = s.metadata.MapRunArn
+	}
+	// the name of the resource follows the `stateMachine:` or `mapRun:` part of its arn
+	parts := strings.SplitN(resource, ":", 7)
+	resource = parts[len(parts)-1]
+
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("aws-step-functions-%s", resource))),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetExecutionCount),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *awsStepFunctionsScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	count, err := s.getPendingExecutions(ctx)
+	if err != nil {
+		s.logger.Error(err, "Error getting step functions executions")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(count))
+	return []external_metrics.ExternalMetricValue{metric}, count > s.metadata.ActivationTargetExecutionCount, nil
+}
+
+// getPendingExecutions returns the items of the Map Run which are pending or running, or else the
+// executions of the state machine with one of the statuses
+func (s *awsStepFunctionsScaler) getPendingExecutions(ctx context.Context) (int64, error) {
+	if s.metadata.MapRunArn != "" {
+		mapRun, err := s.sfnClient.DescribeMapRun(ctx, &stepFunctionsDescribeMapRunInput{MapRunArn: s.metadata.MapRunArn})
+		if err != nil {
+			return -1, err
+		}
+		return mapRun.ItemCounts.Pending + mapRun.ItemCounts.Running, nil
+	}
+
+	var count int64
+	for _, status := range s.metadata.ExecutionStatuses {
+		input := &stepFunctionsListExecutionsInput{
+			StateMachineArn: s.metadata.StateMachineArn,
+			StatusFilter:    status,
+			MaxResults:      stepFunctionsPageSize,
+		}
+		for {
+			page, err := s.sfnClient.ListExecutions(ctx, input)
+			if err != nil {
+				return -1, err
+			}
+			count += int64(len(page.Executions))
+			if page.NextToken == "" {
+				break
+			}
+			input.NextToken = page.NextToken
+		}
+	}
+	return count, nil
+}
+
+func (s *awsStepFunctionsScaler) Close(context.Context) error {
+	awsutils.ClearAwsConfig(s.metadata.awsAuthorization)
+	if c, ok := s.sfnClient.(*stepFunctionsClient); ok {
+		c.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
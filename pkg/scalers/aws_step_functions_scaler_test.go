@@ -0,0 +1,221 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+const (
+	testAWSStepFunctionsStateMachineArn = "arn:aws:states:eu-west-1:123456789012:stateMachine:orders"
+	testAWSStepFunctionsMapRunArn       = "arn:aws:states:eu-west-1:123456789012:mapRun:orders/items:0a1b2c3d"
+	testAWSStepFunctionsErrorArn        = "arn:aws:states:eu-west-1:123456789012:stateMachine:error"
+)
+
+var testAWSStepFunctionsAuthentication = map[string]string{
+	"awsAccessKeyId":     "none",
+	"awsSecretAccessKey": "none",
+}
+
+type parseAWSStepFunctionsMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type awsStepFunctionsMetricIdentifier struct {
+	metadataTestData *parseAWSStepFunctionsMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testAWSStepFunctionsMetadata = []parseAWSStepFunctionsMetadataTestData{
+	// state machine
+	{map[string]string{"stateMachineArn": testAWSStepFunctionsStateMachineArn, "awsRegion": "eu-west-1"}, testAWSStepFunctionsAuthentication, false},
+	// map run
+	{map[string]string{"mapRunArn": testAWSStepFunctionsMapRunArn, "awsRegion": "eu-west-1", "targetExecutionCount": "10"}, testAWSStepFunctionsAuthentication, false},
+	// pending redrive executions
+	{map[string]string{"stateMachineArn": testAWSStepFunctionsStateMachineArn, "awsRegion": "eu-west-1", "executionStatuses": "RUNNING,PENDING_REDRIVE"}, testAWSStepFunctionsAuthentication, false},
+	// unknown execution status
+	{map[string]string{"stateMachineArn": testAWSStepFunctionsStateMachineArn, "awsRegion": "eu-west-1", "executionStatuses": "SUCCEEDED"}, testAWSStepFunctionsAuthentication, true},
+	// neither stateMachineArn nor mapRunArn
+	{map[string]string{"awsRegion": "eu-west-1"}, testAWSStepFunctionsAuthentication, true},
+	// both stateMachineArn and mapRunArn
+	{map[string]string{"stateMachineArn": testAWSStepFunctionsStateMachineArn, "mapRunArn": testAWSStepFunctionsMapRunArn, "awsRegion": "eu-west-1"}, testAWSStepFunctionsAuthentication, true},
+	// missing awsRegion
+	{map[string]string{"stateMachineArn": testAWSStepFunctionsStateMachineArn}, testAWSStepFunctionsAuthentication, true},
+	// invalid targetExecutionCount
+	{map[string]string{"stateMachineArn": testAWSStepFunctionsStateMachineArn, "awsRegion": "eu-west-1", "targetExecutionCount": "0"}, testAWSStepFunctionsAuthentication, true},
+	// missing credentials
+	{map[string]string{"stateMachineArn": testAWSStepFunctionsStateMachineArn, "awsRegion": "eu-west-1"}, map[string]string{}, true},
+}
+
+var awsStepFunctionsMetricIdentifiers = []awsStepFunctionsMetricIdentifier{
+	{&testAWSStepFunctionsMetadata[0], 0, "s0-aws-step-functions-orders"},
+	{&testAWSStepFunctionsMetadata[1], 1, "s1-aws-step-functions-orders-items-0a1b2c3d"},
+}
+
+type mockStepFunctions struct {
+	executions map[string][]int
+}
+
+func (m *mockStepFunctions) ListExecutions(_ context.Context, input *stepFunctionsListExecutionsInput) (*stepFunctionsListExecutionsOutput, error) {
+	if input.StateMachineArn == testAWSStepFunctionsErrorArn {
+		return nil, errors.New("some error")
+	}
+	pages := m.executions[input.StatusFilter]
+	page := 0
+	if input.NextToken != "" {
+		fmt.Sscan(input.NextToken, &page)
+	}
+	output := &stepFunctionsListExecutionsOutput{}
+	if page >= len(pages) {
+		return output, nil
+	}
+	for i := 0; i < pages[page]; i++ {
+		output.Executions = append(output.Executions, struct {
+			ExecutionArn string `json:"executionArn"`
+			Status       string `json:"status"`
+		}{ExecutionArn: fmt.Sprintf("%s:%d", input.StateMachineArn, i), Status: input.StatusFilter})
+	}
+	if page+1 < len(pages) {
+		output.NextToken = fmt.Sprint(page + 1)
+	}
+	return output, nil
+}
+
+func (m *mockStepFunctions) DescribeMapRun(_ context.Context, _ *stepFunctionsDescribeMapRunInput) (*stepFunctionsDescribeMapRunOutput, error) {
+	output := &stepFunctionsDescribeMapRunOutput{Status: "RUNNING"}
+	output.ItemCounts.Pending = 7
+	output.ItemCounts.Running = 3
+	return output, nil
+}
+
+func TestAWSStepFunctionsParseMetadata(t *testing.T) {
+	for i, testData := range testAWSStepFunctionsMetadata {
+		_, err := parseAwsStepFunctionsMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("test case %d: expected success but got error %v", i, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("test case %d: expected error but got success", i)
+		}
+	}
+}
+
+func TestAWSStepFunctionsGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range awsStepFunctionsMetricIdentifiers {
+		meta, err := parseAwsStepFunctionsMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := awsStepFunctionsScaler{metadata: meta, sfnClient: &mockStepFunctions{}, logger: logr.Discard()}
+
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func TestAWSStepFunctionsGetMetricsAndActivity(t *testing.T) {
+	mock := &mockStepFunctions{executions: map[string][]int{
+		"RUNNING":         {1000, 1000, 3},
+		"PENDING_REDRIVE": {2},
+	}}
+
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		expectedValue  int64
+		expectedActive bool
+		isError        bool
+	}{
+		{"running executions", map[string]string{"stateMachineArn": testAWSStepFunctionsStateMachineArn}, 2003, true, false},
+		{"running and pending redrive executions", map[string]string{"stateMachineArn": testAWSStepFunctionsStateMachineArn, "executionStatuses": "RUNNING,PENDING_REDRIVE"}, 2005, true, false},
+		{"only pending redrive executions", map[string]string{"stateMachineArn": testAWSStepFunctionsStateMachineArn, "executionStatuses": "PENDING_REDRIVE"}, 2, false, false},
+		{"map run items", map[string]string{"mapRunArn": testAWSStepFunctionsMapRunArn}, 10, true, false},
+		{"error", map[string]string{"stateMachineArn": testAWSStepFunctionsErrorArn}, 0, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["awsRegion"] = "eu-west-1"
+			tc.metadata["activationTargetExecutionCount"] = "2"
+			meta, err := parseAwsStepFunctionsMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: testAWSStepFunctionsAuthentication})
+			assert.NoError(t, err)
+			scaler := awsStepFunctionsScaler{metadata: meta, sfnClient: mock, logger: logr.Discard()}
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.expectedActive, isActive)
+		})
+	}
+}
+
+func TestAWSStepFunctionsClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, stepFunctionsContentType, r.Header.Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=none/"), "request isn't signed")
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/states/aws4_request")
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "AWSStepFunctions.ListExecutions":
+			input := stepFunctionsListExecutionsInput{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+			assert.Equal(t, testAWSStepFunctionsStateMachineArn, input.StateMachineArn)
+			assert.Equal(t, "RUNNING", input.StatusFilter)
+			assert.Equal(t, stepFunctionsPageSize, input.MaxResults)
+			if input.NextToken == "" {
+				fmt.Fprint(w, `{"executions":[{"executionArn":"a","status":"RUNNING"},{"executionArn":"b","status":"RUNNING"}],"nextToken":"page2"}`)
+				return
+			}
+			assert.Equal(t, "page2", input.NextToken)
+			fmt.Fprint(w, `{"executions":[{"executionArn":"c","status":"RUNNING"}]}`)
+		case "AWSStepFunctions.DescribeMapRun":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"__type":"com.amazonaws.states#ResourceNotFound","message":"Map Run does not exist"}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	for _, arn := range []string{testAWSStepFunctionsStateMachineArn, testAWSStepFunctionsMapRunArn} {
+		arnParam := "stateMachineArn"
+		if arn == testAWSStepFunctionsMapRunArn {
+			arnParam = "mapRunArn"
+		}
+		meta, err := parseAwsStepFunctionsMetadata(&scalersconfig.ScalerConfig{
+			TriggerMetadata:  map[string]string{arnParam: arn, "awsRegion": "eu-west-1", "awsEndpoint": server.URL},
+			AuthParams:       testAWSStepFunctionsAuthentication,
+			TriggerUniqueKey: arn,
+		})
+		assert.NoError(t, err)
+		client, err := createStepFunctionsClient(context.Background(), meta, 0)
+		assert.NoError(t, err)
+		scaler := awsStepFunctionsScaler{metadata: meta, sfnClient: client, logger: logr.Discard()}
+
+		count, err := scaler.getPendingExecutions(context.Background())
+		if arnParam == "mapRunArn" {
+			assert.EqualError(t, err, "step functions DescribeMapRun failed: ResourceNotFound: Map Run does not exist")
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, int64(3), count)
+		}
+		assert.NoError(t, scaler.Close(context.Background()))
+	}
+}
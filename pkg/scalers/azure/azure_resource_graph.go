@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+const resourceGraphAPIVersion = "2021-03-01"
+
+// ResourceGraphClient runs KQL queries against Azure Resource Graph
+type ResourceGraphClient struct {
+	pipeline runtime.Pipeline
+	endpoint string
+}
+
+type resourceGraphRequest struct {
+	Subscriptions []string                    `json:"subscriptions,omitempty"`
+	Query         string                      `json:"query"`
+	Options       resourceGraphRequestOptions `json:"options"`
+}
+
+type resourceGraphRequestOptions struct {
+	ResultFormat string `json:"resultFormat"`
+}
+
+type resourceGraphResponse struct {
+	Data []map[string]interface{} `json:"data"`
+}
+
+// NewResourceGraphClient creates a new Resource Graph client for the given cloud
+func NewResourceGraphClient(credential azcore.TokenCredential, azCloud cloud.Configuration, transport policy.Transporter) (*ResourceGraphClient, error) {
+	armConfig, ok := azCloud.Services[cloud.ResourceManager]
+	if !ok || armConfig.Endpoint == "" {
+		return nil, fmt.Errorf("cloud doesn't define a resource manager endpoint")
+	}
+
+	audience := armConfig.Audience
+	if audience == "" {
+		audience = armConfig.Endpoint
+	}
+	scope := strings.TrimSuffix(audience, "/") + "/.default"
+
+	pipeline := runtime.NewPipeline("keda", "v2", runtime.PipelineOptions{
+		PerRetry: []policy.Policy{runtime.NewBearerTokenPolicy(credential, []string{scope}, nil)},
+	}, &policy.ClientOptions{Transport: transport, Cloud: azCloud})
+
+	return &ResourceGraphClient{
+		pipeline: pipeline,
+		endpoint: strings.TrimSuffix(armConfig.Endpoint, "/"),
+	}, nil
+}
+
+// QueryValue runs the query (optionally scoped to the given subscriptions) and returns
+// the numeric value of the first row. When column is empty, the row must have a single column.
+func (c *ResourceGraphClient) QueryValue(ctx context.Context, query string, subscriptions []string, column string) (float64, error) {
+	url := fmt.Sprintf("%s/providers/Microsoft.ResourceGraph/resources?api-version=%s", c.endpoint, resourceGraphAPIVersion)
+	req, err := runtime.NewRequest(ctx, http.MethodPost, url)
+	if err != nil {
+		return -1, err
+	}
+
+	body := resourceGraphRequest{
+		Subscriptions: subscriptions,
+		Query:         query,
+		Options:       resourceGraphRequestOptions{ResultFormat: "objectArray"},
+	}
+	if err := runtime.MarshalAsJSON(req, body); err != nil {
+		return -1, err
+	}
+
+	resp, err := c.pipeline.Do(req)
+	if err != nil {
+		return -1, err
+	}
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return -1, runtime.NewResponseError(resp)
+	}
+
+	var result resourceGraphResponse
+	if err := runtime.UnmarshalAsJSON(resp, &result); err != nil {
+		return -1, err
+	}
+	return getResourceGraphValue(result, column)
+}
+
+func getResourceGraphValue(result resourceGraphResponse, column string) (float64, error) {
+	if len(result.Data) == 0 {
+		return -1, fmt.Errorf("resource graph query returned no rows")
+	}
+
+	row := result.Data[0]
+	var value interface{}
+	switch {
+	case column != "":
+		v, ok := row[column]
+		if !ok {
+			return -1, fmt.Errorf("column %s not found in resource graph query result", column)
+		}
+		value = v
+	case len(row) == 1:
+		for _, v := range row {
+			value = v
+		}
+	default:
+		return -1, fmt.Errorf("resource graph query returned %d columns, set the result column to pick one", len(row))
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return -1, fmt.Errorf("resource graph query result %q is not a number", v)
+		}
+		return parsed, nil
+	default:
+		return -1, fmt.Errorf("resource graph query result of type %T is not a number", value)
+	}
+}
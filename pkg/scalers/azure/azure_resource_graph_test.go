@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"testing"
+)
+
+func TestGetResourceGraphValue(t *testing.T) {
+	testData := []struct {
+		name     string
+		data     []map[string]interface{}
+		column   string
+		expected float64
+		isError  bool
+	}{
+		{name: "single column", data: []map[string]interface{}{{"count_": float64(12)}}, expected: 12},
+		{name: "selected column", data: []map[string]interface{}{{"name": "vm", "count_": float64(3)}}, column: "count_", expected: 3},
+		{name: "string number", data: []map[string]interface{}{{"count_": "4.5"}}, expected: 4.5},
+		{name: "no rows", data: []map[string]interface{}{}, isError: true},
+		{name: "several columns without selection", data: []map[string]interface{}{{"name": "vm", "count_": float64(3)}}, isError: true},
+		{name: "missing column", data: []map[string]interface{}{{"count_": float64(3)}}, column: "total", isError: true},
+		{name: "not a number", data: []map[string]interface{}{{"name": "vm"}}, isError: true},
+	}
+
+	for _, tc := range testData {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := getResourceGraphValue(resourceGraphResponse{Data: tc.data}, tc.column)
+			if tc.isError && err == nil {
+				t.Error("expected error but got success")
+			}
+			if !tc.isError && err != nil {
+				t.Errorf("expected success but got error: %v", err)
+			}
+			if !tc.isError && value != tc.expected {
+				t.Errorf("expected %v but got %v", tc.expected, value)
+			}
+		})
+	}
+}
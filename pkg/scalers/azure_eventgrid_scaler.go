@@ -0,0 +1,243 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	azcloud "github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/monitor/azquery"
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/azure"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	eventGridDeadLettered     = "deadLettered"
+	eventGridUndelivered      = "undelivered"
+	eventGridDeliveryFailures = "deliveryFailures"
+
+	// eventGridSubscriptionDimension is the dimension of the delivery metrics with the event subscription
+	eventGridSubscriptionDimension = "EventSubscriptionName"
+)
+
+// eventGridMetrics are the delivery metrics of the topic read for each metric of the trigger, the
+// undelivered events are the matched events less the delivered, dead-lettered and dropped ones
+var eventGridMetrics = map[string][]string{
+	eventGridDeadLettered:     {"DeadLetteredCount"},
+	eventGridUndelivered:      {"MatchedEventCount", "DeliverySuccessCount", "DeadLetteredCount", "DroppedEventCount"},
+	eventGridDeliveryFailures: {"DeliveryAttemptFailCount"},
+}
+
+type azureEventGridScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *azureEventGridMetadata
+	client     *azquery.MetricsClient
+	logger     logr.Logger
+}
+
+type azureEventGridMetadata struct {
+	// TopicType is the type of the Microsoft.EventGrid resource the event subscription belongs to
+	TopicType             string `keda:"name=topicType,             order=triggerMetadata, enum=topics;systemTopics;domains, default=topics"`
+	TopicName             string `keda:"name=topicName,             order=triggerMetadata"`
+	EventSubscriptionName string `keda:"name=eventSubscriptionName, order=triggerMetadata"`
+	ResourceGroupName     string `keda:"name=resourceGroupName,     order=triggerMetadata"`
+	SubscriptionID        string `keda:"name=subscriptionId,        order=triggerMetadata"`
+	TenantID              string `keda:"name=tenantId,              order=triggerMetadata"`
+	Metric                string `keda:"name=metric,                order=triggerMetadata, enum=deadLettered;undelivered;deliveryFailures, default=deadLettered"`
+	// MetricAggregationInterval is the window the events are counted over, hh:mm:ss, 5 minutes by default
+	MetricAggregationInterval string `keda:"name=metricAggregationInterval, order=triggerMetadata, optional"`
+
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	clientID       string
+	clientPassword string
+	cloud          azcloud.Configuration
+	triggerIndex   int
+}
+
+func (m *azureEventGridMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return fmt.Errorf("targetValue must be greater than 0")
+	}
+	if m.MetricAggregationInterval != "" {
+		// formatTimeSpan expects the three parts
+		if len(strings.Split(m.MetricAggregationInterval, ":")) != 3 {
+			return fmt.Errorf("metricAggregationInterval not in the correct format. Should be hh:mm:ss")
+		}
+		if _, err := formatTimeSpan(m.MetricAggregationInterval); err != nil {
+			return fmt.Errorf("metricAggregationInterval not in the correct format. Should be hh:mm:ss")
+		}
+	}
+	return nil
+}
+
+// resourceURI returns the resource of the topic the delivery metrics are queried from
+func (m *azureEventGridMetadata) resourceURI() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.EventGrid/%s/%s",
+		m.SubscriptionID, m.ResourceGroupName, m.TopicType, m.TopicName)
+}
+
+// NewAzureEventGridScaler creates a new scaler for the dead-lettered or undelivered events of an
+// Event Grid subscription, read from the delivery metrics of its topic in Azure Monitor
+func NewAzureEventGridScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	logger := InitializeLogger(config, "azure_eventgrid_scaler")
+
+	meta, err := parseAzureEventGridMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing azure event grid metadata: %w", err)
+	}
+
+	var creds azcore.TokenCredential
+	switch config.PodIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		creds, err = azidentity.NewClientSecretCredential(meta.TenantID, meta.clientID, meta.clientPassword, nil)
+	case kedav1alpha1.PodIdentityProviderAzureWorkload:
+		creds, err = azure.NewChainedCredential(logger, config.PodIdentity)
+	}
+	if err != nil {
+		return nil, err
+	}
+	client, err := azquery.NewMetricsClient(creds, &azquery.MetricsClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Transport: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+			Cloud:     meta.cloud,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &azureEventGridScaler{
+		metricType: metricType,
+		metadata:   meta,
+		client:     client,
+		logger:     logger,
+	}, nil
+}
+
+func parseAzureEventGridMetadata(config *scalersconfig.ScalerConfig) (*azureEventGridMetadata, error) {
+	meta := &azureEventGridMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing azure event grid metadata: %w", err)
+	}
+
+	clientID, clientPassword, err := parseAzurePodIdentityParams(config)
+	if err != nil {
+		return nil, err
+	}
+	meta.clientID = clientID
+	meta.clientPassword = clientPassword
+
+	cloud, err := parseCloud(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.cloud = cloud
+
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// GetMetricValue returns the events of the subscription over the aggregation interval
+func (s *azureEventGridScaler) GetMetricValue(ctx context.Context) (float64, error) {
+	timespan, err := formatTimeSpan(s.metadata.MetricAggregationInterval)
+	if err != nil {
+		return -1, err
+	}
+	names := strings.Join(eventGridMetrics[s.metadata.Metric], ",")
+	filter := fmt.Sprintf("%s eq '%s'", eventGridSubscriptionDimension, s.metadata.EventSubscriptionName)
+	aggregation := azquery.AggregationTypeTotal
+	response, err := s.client.QueryResource(ctx, s.metadata.resourceURI(), &azquery.MetricsClientQueryResourceOptions{
+		MetricNames: &names,
+		Filter:      &filter,
+		Timespan:    timespan,
+		Aggregation: []*azquery.AggregationType{&aggregation},
+	})
+	if err != nil {
+		return -1, fmt.Errorf("error querying the event grid delivery metrics: %w", err)
+	}
+	return getEventGridValue(s.metadata.Metric, response.Value)
+}
+
+// getEventGridValue returns the value of the metric of the trigger from the totals of the delivery metrics
+func getEventGridValue(metric string, metrics []*azquery.Metric) (float64, error) {
+	totals := map[string]float64{}
+	for _, m := range metrics {
+		if m == nil || m.Name == nil || m.Name.Value == nil {
+			continue
+		}
+		if m.ErrorCode != nil && *m.ErrorCode != "Success" {
+			message := ""
+			if m.ErrorMessage != nil {
+				message = *m.ErrorMessage
+			}
+			return -1, fmt.Errorf("error querying %s: %s %s", *m.Name.Value, *m.ErrorCode, message)
+		}
+		total := 0.0
+		for _, series := range m.TimeSeries {
+			for _, data := range series.Data {
+				if data != nil && data.Total != nil {
+					total += *data.Total
+				}
+			}
+		}
+		totals[*m.Name.Value] = total
+	}
+
+	names := eventGridMetrics[metric]
+	for _, name := range names {
+		if _, ok := totals[name]; !ok {
+			return -1, fmt.Errorf("no %s in the event grid delivery metrics", name)
+		}
+	}
+	if metric != eventGridUndelivered {
+		return totals[names[0]], nil
+	}
+	value := totals[names[0]]
+	for _, name := range names[1:] {
+		value -= totals[name]
+	}
+	// the events delivered during the interval may have been matched before it
+	return max(value, 0), nil
+}
+
+func (s *azureEventGridScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.GetMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting azure event grid metric value")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *azureEventGridScaler) GetMetricSpecForScaling(_ context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("azure-eventgrid-%s-%s", s.metadata.EventSubscriptionName, s.metadata.Metric))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *azureEventGridScaler) Close(context.Context) error {
+	return nil
+}
@@ -0,0 +1,130 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/monitor/azquery"
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseAzureEventGridMetadataTestData struct {
+	metadata    map[string]string
+	authParams  map[string]string
+	podIdentity kedav1alpha1.PodIdentityProvider
+	isError     bool
+}
+
+type azureEventGridMetricIdentifier struct {
+	metadataTestData *parseAzureEventGridMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testAzureEventGridAuthParams = map[string]string{"activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPassword": "CLIENT_PASSWORD"}
+
+var testAzureEventGridMetadata = []parseAzureEventGridMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, "", true},
+	// properly formed metadata
+	{map[string]string{"topicName": "orders", "eventSubscriptionName": "orders-webhook", "resourceGroupName": "rg", "subscriptionId": "123", "tenantId": "456", "targetValue": "10"}, testAzureEventGridAuthParams, "", false},
+	// undelivered events of a system topic with workload identity
+	{map[string]string{"topicType": "systemTopics", "topicName": "storage-events", "eventSubscriptionName": "blob-created", "resourceGroupName": "rg", "subscriptionId": "123", "tenantId": "456", "metric": "undelivered", "metricAggregationInterval": "0:15:0", "targetValue": "100"}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload, false},
+	// invalid topicType
+	{map[string]string{"topicType": "namespaces", "topicName": "orders", "eventSubscriptionName": "orders-webhook", "resourceGroupName": "rg", "subscriptionId": "123", "tenantId": "456", "targetValue": "10"}, testAzureEventGridAuthParams, "", true},
+	// invalid metric
+	{map[string]string{"topicName": "orders", "eventSubscriptionName": "orders-webhook", "resourceGroupName": "rg", "subscriptionId": "123", "tenantId": "456", "metric": "matched", "targetValue": "10"}, testAzureEventGridAuthParams, "", true},
+	// missing eventSubscriptionName
+	{map[string]string{"topicName": "orders", "resourceGroupName": "rg", "subscriptionId": "123", "tenantId": "456", "targetValue": "10"}, testAzureEventGridAuthParams, "", true},
+	// invalid metricAggregationInterval
+	{map[string]string{"topicName": "orders", "eventSubscriptionName": "orders-webhook", "resourceGroupName": "rg", "subscriptionId": "123", "tenantId": "456", "metricAggregationInterval": "15m", "targetValue": "10"}, testAzureEventGridAuthParams, "", true},
+	// missing activeDirectoryClientPassword
+	{map[string]string{"topicName": "orders", "eventSubscriptionName": "orders-webhook", "resourceGroupName": "rg", "subscriptionId": "123", "tenantId": "456", "targetValue": "10"}, map[string]string{"activeDirectoryClientId": "CLIENT_ID"}, "", true},
+	// unknown cloud
+	{map[string]string{"topicName": "orders", "eventSubscriptionName": "orders-webhook", "resourceGroupName": "rg", "subscriptionId": "123", "tenantId": "456", "cloud": "Mars", "targetValue": "10"}, testAzureEventGridAuthParams, "", true},
+}
+
+var azureEventGridMetricIdentifiers = []azureEventGridMetricIdentifier{
+	{&testAzureEventGridMetadata[1], 0, "s0-azure-eventgrid-orders-webhook-deadLettered"},
+	{&testAzureEventGridMetadata[2], 1, "s1-azure-eventgrid-blob-created-undelivered"},
+}
+
+func TestAzureEventGridParseMetadata(t *testing.T) {
+	for testCaseNum, testData := range testAzureEventGridMetadata {
+		_, err := parseAzureEventGridMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.podIdentity}})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", testCaseNum, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", testCaseNum)
+		}
+	}
+}
+
+func TestAzureEventGridResourceURI(t *testing.T) {
+	meta, err := parseAzureEventGridMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testAzureEventGridMetadata[2].metadata, PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload}})
+	assert.NoError(t, err)
+	assert.Equal(t, "/subscriptions/123/resourceGroups/rg/providers/Microsoft.EventGrid/systemTopics/storage-events", meta.resourceURI())
+}
+
+func TestAzureEventGridGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range azureEventGridMetricIdentifiers {
+		meta, err := parseAzureEventGridMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.metadataTestData.podIdentity}, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockAzureEventGridScaler := azureEventGridScaler{metadata: meta}
+
+		metricSpec := mockAzureEventGridScaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func testEventGridMetric(name string, totals ...float64) *azquery.Metric {
+	data := make([]*azquery.MetricValue, len(totals))
+	for i := range totals {
+		data[i] = &azquery.MetricValue{Total: &totals[i]}
+	}
+	return &azquery.Metric{
+		Name:       &azquery.LocalizableString{Value: &name},
+		TimeSeries: []*azquery.TimeSeriesElement{{Data: data}},
+	}
+}
+
+func TestGetEventGridValue(t *testing.T) {
+	value, err := getEventGridValue(eventGridDeadLettered, []*azquery.Metric{testEventGridMetric("DeadLetteredCount", 2, 0, 3)})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(5), value)
+
+	metrics := []*azquery.Metric{
+		testEventGridMetric("MatchedEventCount", 40, 60),
+		testEventGridMetric("DeliverySuccessCount", 30, 40),
+		testEventGridMetric("DeadLetteredCount", 5),
+		testEventGridMetric("DroppedEventCount"),
+	}
+	value, err = getEventGridValue(eventGridUndelivered, metrics)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(25), value)
+
+	// more events are delivered than matched when they were matched before the interval
+	value, err = getEventGridValue(eventGridUndelivered, []*azquery.Metric{
+		testEventGridMetric("MatchedEventCount", 10),
+		testEventGridMetric("DeliverySuccessCount", 30),
+		testEventGridMetric("DeadLetteredCount"),
+		testEventGridMetric("DroppedEventCount"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), value)
+
+	_, err = getEventGridValue(eventGridUndelivered, metrics[:2])
+	assert.EqualError(t, err, "no DeadLetteredCount in the event grid delivery metrics")
+
+	errorCode, errorMessage := "BadRequest", "invalid filter"
+	failed := testEventGridMetric("DeliveryAttemptFailCount")
+	failed.ErrorCode, failed.ErrorMessage = &errorCode, &errorMessage
+	_, err = getEventGridValue(eventGridDeliveryFailures, []*azquery.Metric{failed})
+	assert.EqualError(t, err, "error querying DeliveryAttemptFailCount: BadRequest invalid filter")
+}
@@ -43,6 +43,10 @@ const (
 	azureMonitorMetricName    = "metricName"
 	targetValueName           = "targetValue"
 	activationTargetValueName = "activationTargetValue"
+
+	azureMonitorQueryTypeMetrics       = "metrics"
+	azureMonitorQueryTypeLogAnalytics  = "logAnalytics"
+	azureMonitorQueryTypeResourceGraph = "resourceGraph"
 )
 
 // monitorInfo to create metric request
@@ -59,6 +63,10 @@ type monitorInfo struct {
 	ClientID            string
 	ClientPassword      string
 	Cloud               azcloud.Configuration
+	QueryType           string
+	Query               string
+	WorkspaceID         string
+	ResultColumn        string
 }
 
 func (m monitorInfo) MetricResourceURI() string {
@@ -79,6 +87,9 @@ type azureMonitorScaler struct {
 	metadata   *azureMonitorMetadata
 	logger     logr.Logger
 	client     *azquery.MetricsClient
+	// logsClient and resourceGraphClient are only set for the KQL query types
+	logsClient          *azquery.LogsClient
+	resourceGraphClient *azure.ResourceGraphClient
 }
 
 type azureMonitorMetadata struct {
@@ -102,29 +113,39 @@ func NewAzureMonitorScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing azure monitor metadata: %w", err)
 	}
 
-	client, err := CreateAzureMetricsClient(config, meta, logger)
-	if err != nil {
-		return nil, err
-	}
-	return &azureMonitorScaler{
+	scaler := &azureMonitorScaler{
 		metricType: metricType,
 		metadata:   meta,
 		logger:     logger,
-		client:     client,
-	}, nil
+	}
+
+	switch meta.azureMonitorInfo.QueryType {
+	case azureMonitorQueryTypeLogAnalytics:
+		scaler.logsClient, err = createAzureLogsClient(config, meta, logger)
+	case azureMonitorQueryTypeResourceGraph:
+		scaler.resourceGraphClient, err = createAzureResourceGraphClient(config, meta, logger)
+	default:
+		scaler.client, err = CreateAzureMetricsClient(config, meta, logger)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scaler, nil
 }
 
-func CreateAzureMetricsClient(config *scalersconfig.ScalerConfig, meta *azureMonitorMetadata, logger logr.Logger) (*azquery.MetricsClient, error) {
-	var creds azcore.TokenCredential
-	var err error
+func getAzureMonitorCredential(config *scalersconfig.ScalerConfig, meta *azureMonitorMetadata, logger logr.Logger) (azcore.TokenCredential, error) {
 	switch config.PodIdentity.Provider {
 	case "", kedav1alpha1.PodIdentityProviderNone:
-		creds, err = azidentity.NewClientSecretCredential(meta.azureMonitorInfo.TenantID, meta.azureMonitorInfo.ClientID, meta.azureMonitorInfo.ClientPassword, nil)
+		return azidentity.NewClientSecretCredential(meta.azureMonitorInfo.TenantID, meta.azureMonitorInfo.ClientID, meta.azureMonitorInfo.ClientPassword, nil)
 	case kedav1alpha1.PodIdentityProviderAzureWorkload:
-		creds, err = azure.NewChainedCredential(logger, config.PodIdentity)
+		return azure.NewChainedCredential(logger, config.PodIdentity)
 	default:
 		return nil, fmt.Errorf("azure monitor does not support pod identity provider - %s", config.PodIdentity.Provider)
 	}
+}
+
+func CreateAzureMetricsClient(config *scalersconfig.ScalerConfig, meta *azureMonitorMetadata, logger logr.Logger) (*azquery.MetricsClient, error) {
+	creds, err := getAzureMonitorCredential(config, meta, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +161,27 @@ func CreateAzureMetricsClient(config *scalersconfig.ScalerConfig, meta *azureMon
 	return client, nil
 }
 
+func createAzureLogsClient(config *scalersconfig.ScalerConfig, meta *azureMonitorMetadata, logger logr.Logger) (*azquery.LogsClient, error) {
+	creds, err := getAzureMonitorCredential(config, meta, logger)
+	if err != nil {
+		return nil, err
+	}
+	return azquery.NewLogsClient(creds, &azquery.LogsClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Transport: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+			Cloud:     meta.azureMonitorInfo.Cloud,
+		},
+	})
+}
+
+func createAzureResourceGraphClient(config *scalersconfig.ScalerConfig, meta *azureMonitorMetadata, logger logr.Logger) (*azure.ResourceGraphClient, error) {
+	creds, err := getAzureMonitorCredential(config, meta, logger)
+	if err != nil {
+		return nil, err
+	}
+	return azure.NewResourceGraphClient(creds, meta.azureMonitorInfo.Cloud, kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false))
+}
+
 func parseAzureMonitorMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (*azureMonitorMetadata, error) {
 	meta := azureMonitorMetadata{
 		azureMonitorInfo: monitorInfo{},
@@ -171,37 +213,90 @@ func parseAzureMonitorMetadata(config *scalersconfig.ScalerConfig, logger logr.L
 		meta.activationTargetValue = 0
 	}
 
+	meta.azureMonitorInfo.QueryType = azureMonitorQueryTypeMetrics
+	if val, ok := config.TriggerMetadata["queryType"]; ok && val != "" {
+		switch val {
+		case azureMonitorQueryTypeMetrics, azureMonitorQueryTypeLogAnalytics, azureMonitorQueryTypeResourceGraph:
+			meta.azureMonitorInfo.QueryType = val
+		default:
+			return nil, fmt.Errorf("invalid queryType given, must be one of %s, %s or %s", azureMonitorQueryTypeMetrics, azureMonitorQueryTypeLogAnalytics, azureMonitorQueryTypeResourceGraph)
+		}
+	}
+
+	if meta.azureMonitorInfo.QueryType == azureMonitorQueryTypeMetrics {
+		if err := parseAzureMonitorMetricsMetadata(config, &meta); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := parseAzureMonitorQueryMetadata(config, &meta); err != nil {
+			return nil, err
+		}
+	}
+
+	// Required authentication parameters below
+
+	if val, ok := config.TriggerMetadata["subscriptionId"]; ok && val != "" {
+		meta.azureMonitorInfo.SubscriptionID = val
+	} else if meta.azureMonitorInfo.QueryType == azureMonitorQueryTypeMetrics {
+		return nil, fmt.Errorf("no subscriptionId given")
+	}
+
+	if val, ok := config.TriggerMetadata["tenantId"]; ok && val != "" {
+		meta.azureMonitorInfo.TenantID = val
+	} else {
+		return nil, fmt.Errorf("no tenantId given")
+	}
+
+	clientID, clientPassword, err := parseAzurePodIdentityParams(config)
+	if err != nil {
+		return nil, err
+	}
+	meta.azureMonitorInfo.ClientID = clientID
+	meta.azureMonitorInfo.ClientPassword = clientPassword
+
+	cloud, err := parseCloud(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.azureMonitorInfo.Cloud = cloud
+
+	meta.triggerIndex = config.TriggerIndex
+	return &meta, nil
+}
+
+// parseAzureMonitorMetricsMetadata parses the metadata of the default Metrics API query type
+func parseAzureMonitorMetricsMetadata(config *scalersconfig.ScalerConfig, meta *azureMonitorMetadata) error {
 	if val, ok := config.TriggerMetadata["resourceURI"]; ok && val != "" {
 		resourceURI := strings.Split(val, "/")
 		if len(resourceURI) != 3 {
-			return nil, fmt.Errorf("resourceURI not in the correct format. Should be namespace/resource_type/resource_name")
+			return fmt.Errorf("resourceURI not in the correct format. Should be namespace/resource_type/resource_name")
 		}
 		meta.azureMonitorInfo.ResourceURI = val
 	} else {
-		return nil, fmt.Errorf("no resourceURI given")
+		return fmt.Errorf("no resourceURI given")
 	}
 
 	if val, ok := config.TriggerMetadata["resourceGroupName"]; ok && val != "" {
 		meta.azureMonitorInfo.ResourceGroupName = val
 	} else {
-		return nil, fmt.Errorf("no resourceGroupName given")
+		return fmt.Errorf("no resourceGroupName given")
 	}
 
 	if val, ok := config.TriggerMetadata[azureMonitorMetricName]; ok && val != "" {
 		meta.azureMonitorInfo.Name = &val
 	} else {
-		return nil, fmt.Errorf("no metricName given")
+		return fmt.Errorf("no metricName given")
 	}
 
 	if val, ok := config.TriggerMetadata["metricAggregationType"]; ok && val != "" {
 		aggregationType := azquery.AggregationType(val)
 		allowedTypes := azquery.PossibleAggregationTypeValues()
 		if !slices.Contains(allowedTypes, aggregationType) {
-			return nil, fmt.Errorf("invalid metricAggregationType given")
+			return fmt.Errorf("invalid metricAggregationType given")
 		}
 		meta.azureMonitorInfo.AggregationType = &aggregationType
 	} else {
-		return nil, fmt.Errorf("no metricAggregationType given")
+		return fmt.Errorf("no metricAggregationType given")
 	}
 
 	if val, ok := config.TriggerMetadata["metricFilter"]; ok && val != "" {
@@ -211,44 +306,46 @@ func parseAzureMonitorMetadata(config *scalersconfig.ScalerConfig, logger logr.L
 	if val, ok := config.TriggerMetadata["metricAggregationInterval"]; ok && val != "" {
 		aggregationInterval := strings.Split(val, ":")
 		if len(aggregationInterval) != 3 {
-			return nil, fmt.Errorf("metricAggregationInterval not in the correct format. Should be hh:mm:ss")
+			return fmt.Errorf("metricAggregationInterval not in the correct format. Should be hh:mm:ss")
 		}
 		meta.azureMonitorInfo.AggregationInterval = val
 	}
 
-	// Required authentication parameters below
-
-	if val, ok := config.TriggerMetadata["subscriptionId"]; ok && val != "" {
-		meta.azureMonitorInfo.SubscriptionID = val
-	} else {
-		return nil, fmt.Errorf("no subscriptionId given")
+	if val, ok := config.TriggerMetadata["metricNamespace"]; ok {
+		meta.azureMonitorInfo.Namespace = &val
 	}
+	return nil
+}
 
-	if val, ok := config.TriggerMetadata["tenantId"]; ok && val != "" {
-		meta.azureMonitorInfo.TenantID = val
+// parseAzureMonitorQueryMetadata parses the metadata of the Log Analytics and Resource Graph query types
+func parseAzureMonitorQueryMetadata(config *scalersconfig.ScalerConfig, meta *azureMonitorMetadata) error {
+	if val, ok := config.TriggerMetadata["query"]; ok && val != "" {
+		meta.azureMonitorInfo.Query = val
 	} else {
-		return nil, fmt.Errorf("no tenantId given")
+		return fmt.Errorf("no query given")
 	}
 
-	if val, ok := config.TriggerMetadata["metricNamespace"]; ok {
-		meta.azureMonitorInfo.Namespace = &val
+	if val, ok := config.TriggerMetadata["workspaceId"]; ok && val != "" {
+		if meta.azureMonitorInfo.QueryType != azureMonitorQueryTypeLogAnalytics {
+			return fmt.Errorf("workspaceId can only be used with queryType %s", azureMonitorQueryTypeLogAnalytics)
+		}
+		meta.azureMonitorInfo.WorkspaceID = val
+	} else if meta.azureMonitorInfo.QueryType == azureMonitorQueryTypeLogAnalytics {
+		return fmt.Errorf("no workspaceId given")
 	}
 
-	clientID, clientPassword, err := parseAzurePodIdentityParams(config)
-	if err != nil {
-		return nil, err
+	if val, ok := config.TriggerMetadata["resultColumn"]; ok && val != "" {
+		meta.azureMonitorInfo.ResultColumn = val
 	}
-	meta.azureMonitorInfo.ClientID = clientID
-	meta.azureMonitorInfo.ClientPassword = clientPassword
 
-	cloud, err := parseCloud(config.TriggerMetadata)
-	if err != nil {
-		return nil, err
+	// metricName is optional here and only used to build the metric name
+	if val, ok := config.TriggerMetadata[azureMonitorMetricName]; ok && val != "" {
+		meta.azureMonitorInfo.Name = &val
+	} else {
+		name := meta.azureMonitorInfo.QueryType
+		meta.azureMonitorInfo.Name = &name
 	}
-	meta.azureMonitorInfo.Cloud = cloud
-
-	meta.triggerIndex = config.TriggerIndex
-	return &meta, nil
+	return nil
 }
 
 func parseCloud(metadata map[string]string) (azcloud.Configuration, error) {
@@ -316,7 +413,16 @@ func (s *azureMonitorScaler) GetMetricSpecForScaling(context.Context) []v2.Metri
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *azureMonitorScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	val, err := s.requestMetric(ctx)
+	var val float64
+	var err error
+	switch s.metadata.azureMonitorInfo.QueryType {
+	case azureMonitorQueryTypeLogAnalytics:
+		val, err = s.requestLogAnalytics(ctx)
+	case azureMonitorQueryTypeResourceGraph:
+		val, err = s.requestResourceGraph(ctx)
+	default:
+		val, err = s.requestMetric(ctx)
+	}
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
@@ -372,6 +478,60 @@ func (s *azureMonitorScaler) requestMetric(ctx context.Context) (float64, error)
 	return val, nil
 }
 
+func (s *azureMonitorScaler) requestLogAnalytics(ctx context.Context) (float64, error) {
+	response, err := s.logsClient.QueryWorkspace(ctx, s.metadata.azureMonitorInfo.WorkspaceID, azquery.Body{
+		Query: &s.metadata.azureMonitorInfo.Query,
+	}, nil)
+	if err != nil {
+		s.logger.Error(err, "error running azure monitor log analytics query")
+		return -1, err
+	}
+	return getLogAnalyticsQueryValue(response.Results, s.metadata.azureMonitorInfo.ResultColumn)
+}
+
+// getLogAnalyticsQueryValue returns the value of the result column (or the first column
+// if none is set) of a query result which must contain a single table with a single row
+func getLogAnalyticsQueryValue(results azquery.Results, column string) (float64, error) {
+	switch {
+	case len(results.Tables) == 0 || len(results.Tables[0].Columns) == 0 || len(results.Tables[0].Rows) == 0:
+		return -1, fmt.Errorf("log analytics query returned no results")
+	case len(results.Tables) > 1:
+		return -1, fmt.Errorf("too many tables in log analytics query result: %d, expected: 1", len(results.Tables))
+	case len(results.Tables[0].Rows) > 1:
+		return -1, fmt.Errorf("too many rows in log analytics query result: %d, expected: 1", len(results.Tables[0].Rows))
+	}
+
+	table := results.Tables[0]
+	index := 0
+	if column != "" {
+		index = slices.IndexFunc(table.Columns, func(c *azquery.Column) bool {
+			return c != nil && c.Name != nil && *c.Name == column
+		})
+		if index < 0 {
+			return -1, fmt.Errorf("column %s not found in log analytics query result", column)
+		}
+	}
+
+	row := table.Rows[0]
+	if index >= len(row) || table.Columns[index] == nil || table.Columns[index].Type == nil {
+		return -1, fmt.Errorf("log analytics query result has no value for column %d", index)
+	}
+	return parseTableValueToFloat64(row[index], *table.Columns[index].Type)
+}
+
+func (s *azureMonitorScaler) requestResourceGraph(ctx context.Context) (float64, error) {
+	var subscriptions []string
+	if s.metadata.azureMonitorInfo.SubscriptionID != "" {
+		subscriptions = []string{s.metadata.azureMonitorInfo.SubscriptionID}
+	}
+	val, err := s.resourceGraphClient.QueryValue(ctx, s.metadata.azureMonitorInfo.Query, subscriptions, s.metadata.azureMonitorInfo.ResultColumn)
+	if err != nil {
+		s.logger.Error(err, "error running azure monitor resource graph query")
+		return -1, err
+	}
+	return val, nil
+}
+
 // formatTimeSpan defaults to a 5 minute timespan if the user does not provide one
 func formatTimeSpan(timeSpan string) (*azquery.TimeInterval, error) {
 	endtime := time.Now().UTC()
@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/monitor/azquery"
 	"github.com/go-logr/logr"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
@@ -95,11 +96,31 @@ var testParseAzMonitorMetadata = []parseAzMonitorMetadataTestData{
 	// private cloud
 	{map[string]string{"resourceURI": "test/resource/uri", "tenantId": "123", "subscriptionId": "456", "resourceGroupName": "test", "metricName": "metric", "metricAggregationInterval": "0:15:0", "metricAggregationType": "Average", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD", "targetValue": "5", "metricNamespace": "namespace", "cloud": "private",
 		"azureResourceManagerEndpoint": testAzureResourceManagerEndpoint}, false, testAzMonitorResolvedEnv, map[string]string{}, ""},
+	// invalid queryType
+	{map[string]string{"queryType": "other", "query": "Perf | count", "tenantId": "123", "targetValue": "5"}, true, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload},
+	// log analytics query
+	{map[string]string{"queryType": "logAnalytics", "query": "Perf | count", "workspaceId": "789", "tenantId": "123", "targetValue": "5"}, false, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload},
+	// log analytics query with result column and secret
+	{map[string]string{"queryType": "logAnalytics", "query": "Perf | summarize total = count()", "workspaceId": "789", "resultColumn": "total", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD", "targetValue": "5"}, false, testAzMonitorResolvedEnv, map[string]string{}, ""},
+	// log analytics query without workspaceId
+	{map[string]string{"queryType": "logAnalytics", "query": "Perf | count", "tenantId": "123", "targetValue": "5"}, true, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload},
+	// log analytics without query
+	{map[string]string{"queryType": "logAnalytics", "workspaceId": "789", "tenantId": "123", "targetValue": "5"}, true, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload},
+	// resource graph query without subscriptionId
+	{map[string]string{"queryType": "resourceGraph", "query": "Resources | count", "tenantId": "123", "targetValue": "5"}, false, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload},
+	// resource graph query scoped to a subscription
+	{map[string]string{"queryType": "resourceGraph", "query": "Resources | count", "subscriptionId": "456", "metricName": "vms", "tenantId": "123", "targetValue": "5"}, false, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload},
+	// resource graph query with workspaceId
+	{map[string]string{"queryType": "resourceGraph", "query": "Resources | count", "workspaceId": "789", "tenantId": "123", "targetValue": "5"}, true, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload},
+	// query without tenantId
+	{map[string]string{"queryType": "resourceGraph", "query": "Resources | count", "targetValue": "5"}, true, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload},
 }
 
 var azMonitorMetricIdentifiers = []azMonitorMetricIdentifier{
 	{&testParseAzMonitorMetadata[1], 0, "s0-azure-monitor-metric"},
 	{&testParseAzMonitorMetadata[1], 1, "s1-azure-monitor-metric"},
+	{&testParseAzMonitorMetadata[23], 0, "s0-azure-monitor-logAnalytics"},
+	{&testParseAzMonitorMetadata[28], 2, "s2-azure-monitor-vms"},
 }
 
 func TestAzMonitorParseMetadata(t *testing.T) {
@@ -123,7 +144,7 @@ func TestAzMonitorGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockAzMonitorScaler := azureMonitorScaler{"", meta, logr.Discard(), nil}
+		mockAzMonitorScaler := azureMonitorScaler{"", meta, logr.Discard(), nil, nil, nil}
 
 		metricSpec := mockAzMonitorScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -132,3 +153,40 @@ func TestAzMonitorGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestAzMonitorGetLogAnalyticsQueryValue(t *testing.T) {
+	longType := azquery.LogsColumnTypeLong
+	stringType := azquery.LogsColumnTypeString
+	name, total := "name", "total"
+	columns := []*azquery.Column{{Name: &name, Type: &stringType}, {Name: &total, Type: &longType}}
+
+	testCases := []struct {
+		name     string
+		results  azquery.Results
+		column   string
+		expected float64
+		isError  bool
+	}{
+		{"first column", azquery.Results{Tables: []*azquery.Table{{Columns: columns[1:], Rows: []azquery.Row{{float64(7)}}}}}, "", 7, false},
+		{"result column", azquery.Results{Tables: []*azquery.Table{{Columns: columns, Rows: []azquery.Row{{"vm", float64(3)}}}}}, "total", 3, false},
+		{"missing column", azquery.Results{Tables: []*azquery.Table{{Columns: columns, Rows: []azquery.Row{{"vm", float64(3)}}}}}, "other", 0, true},
+		{"non numeric column", azquery.Results{Tables: []*azquery.Table{{Columns: columns, Rows: []azquery.Row{{"vm", float64(3)}}}}}, "name", 0, true},
+		{"no rows", azquery.Results{Tables: []*azquery.Table{{Columns: columns}}}, "", 0, true},
+		{"too many rows", azquery.Results{Tables: []*azquery.Table{{Columns: columns[1:], Rows: []azquery.Row{{float64(1)}, {float64(2)}}}}}, "", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			val, err := getLogAnalyticsQueryValue(tc.results, tc.column)
+			if tc.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+			if !tc.isError && err != nil {
+				t.Error("Expected success but got error", err)
+			}
+			if !tc.isError && val != tc.expected {
+				t.Errorf("Expected %v but got %v", tc.expected, val)
+			}
+		})
+	}
+}
@@ -130,6 +130,15 @@ type azurePipelinesPoolIDResponse struct {
 	ID int `json:"id"`
 }
 
+type azurePipelinesResourceUsage struct {
+	ResourceLimit struct {
+		ParallelismTag string `json:"parallelismTag"`
+		IsHosted       bool   `json:"isHosted"`
+		TotalCount     int64  `json:"totalCount"`
+	} `json:"resourceLimit"`
+	UsedCount int64 `json:"usedCount"`
+}
+
 type azurePipelinesScaler struct {
 	metricType  v2.MetricTargetType
 	metadata    *azurePipelinesMetadata
@@ -150,6 +159,8 @@ type azurePipelinesMetadata struct {
 	jobsToFetch                          int64
 	triggerIndex                         int
 	requireAllDemands                    bool
+	capabilities                         map[string]string
+	limitToParallelJobs                  bool
 }
 
 type authContext struct {
@@ -267,6 +278,13 @@ func parseAzurePipelinesMetadata(ctx context.Context, logger logr.Logger, config
 		meta.demands = ""
 	}
 
+	if val, ok := config.TriggerMetadata["capabilities"]; ok && val != "" {
+		if meta.demands != "" {
+			return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("demands and capabilities can't be used together")
+		}
+		meta.capabilities = parseAzurePipelinesCapabilities(val)
+	}
+
 	meta.jobsToFetch = 250
 	if val, ok := config.TriggerMetadata["jobsToFetch"]; ok && val != "" {
 		jobsToFetch, err := strconv.ParseInt(val, 10, 64)
@@ -285,6 +303,15 @@ func parseAzurePipelinesMetadata(ctx context.Context, logger logr.Logger, config
 		meta.requireAllDemands = requireAllDemands
 	}
 
+	meta.limitToParallelJobs = false
+	if val, ok := config.TriggerMetadata["limitToParallelJobs"]; ok && val != "" {
+		limitToParallelJobs, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, kedav1alpha1.AuthPodIdentity{}, fmt.Errorf("error parsing limitToParallelJobs: %w", err)
+		}
+		meta.limitToParallelJobs = limitToParallelJobs
+	}
+
 	if val, ok := config.TriggerMetadata["poolName"]; ok && val != "" {
 		var err error
 		poolID, err := getPoolIDFromName(ctx, logger, val, &meta, podIdentity, httpClient)
@@ -310,6 +337,21 @@ func parseAzurePipelinesMetadata(ctx context.Context, logger logr.Logger, config
 	return &meta, podIdentity, nil
 }
 
+// parseAzurePipelinesCapabilities parses the agent capabilities given as `name=value` or `name` pairs,
+// capability names are case insensitive in Azure Pipelines
+func parseAzurePipelinesCapabilities(val string) map[string]string {
+	capabilities := map[string]string{}
+	for _, capability := range strings.Split(val, ",") {
+		name, value, _ := strings.Cut(capability, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		capabilities[strings.ToLower(name)] = strings.TrimSpace(value)
+	}
+	return capabilities
+}
+
 func getPoolIDFromName(ctx context.Context, logger logr.Logger, poolName string, metadata *azurePipelinesMetadata, podIdentity kedav1alpha1.AuthPodIdentity, httpClient *http.Client) (int, error) {
 	urlString := fmt.Sprintf("%s/_apis/distributedtask/pools?poolName=%s", metadata.organizationURL, url.QueryEscape(poolName))
 	body, err := getAzurePipelineRequest(ctx, logger, urlString, metadata, podIdentity, httpClient)
@@ -435,30 +477,66 @@ func (s *azurePipelinesScaler) GetAzurePipelinesQueueLength(ctx context.Context)
 		return -1, err
 	}
 
-	// for each job check if its parent fulfilled, then demand fulfilled, then finally pool fulfilled
+	// for each job check if its parent fulfilled, then capabilities or demand fulfilled, then finally pool fulfilled
 	var count int64
+	parallelismTag := ""
 	for _, job := range stripDeadJobs(jrs.Value) {
-		if s.metadata.parent == "" && s.metadata.demands == "" {
+		var matches bool
+		switch {
+		case s.metadata.parent != "":
+			// does use parent
+			matches = getCanAgentParentFulfilJob(job, s.metadata)
+		case s.metadata.capabilities != nil:
+			// uses the agent capabilities, evaluate the demands of the job against them
+			matches = getCanAgentCapabilitiesFulfilJob(job, s.metadata)
+		case s.metadata.demands != "":
+			// doesn't use parent, switch to demand
+			matches = getCanAgentDemandFulfilJob(job, s.metadata)
+		default:
 			// no plan defined, just add a count
+			matches = true
+		}
+		if matches {
 			count++
-		} else {
-			if s.metadata.parent == "" {
-				// doesn't use parent, switch to demand
-				if getCanAgentDemandFulfilJob(job, s.metadata) {
-					count++
-				}
-			} else {
-				// does use parent
-				if getCanAgentParentFulfilJob(job, s.metadata) {
-					count++
-				}
-			}
+			parallelismTag = job.Data.ParallelismTag
+		}
+	}
+
+	if s.metadata.limitToParallelJobs && count > 0 {
+		parallelJobs, err := s.getParallelJobsLimit(ctx, parallelismTag)
+		if err != nil {
+			return -1, err
+		}
+		// jobs above the purchased parallel jobs stay queued whatever the number of agents
+		if parallelJobs > 0 && count > parallelJobs {
+			count = parallelJobs
 		}
 	}
 
 	return count, err
 }
 
+// getParallelJobsLimit returns the number of parallel jobs of the organization for self-hosted agents
+func (s *azurePipelinesScaler) getParallelJobsLimit(ctx context.Context, parallelismTag string) (int64, error) {
+	if parallelismTag == "" {
+		parallelismTag = "Private"
+	}
+	urlString := fmt.Sprintf("%s/_apis/distributedtask/resourceusage?parallelismTag=%s&poolIsHosted=false&includeRunningRequests=false", s.metadata.organizationURL, url.QueryEscape(parallelismTag))
+	body, err := getAzurePipelineRequest(ctx, s.logger, urlString, s.metadata, s.podIdentity, s.httpClient)
+	if err != nil {
+		return -1, err
+	}
+
+	var usage azurePipelinesResourceUsage
+	err = json.Unmarshal(body, &usage)
+	if err != nil {
+		s.logger.Error(err, "Cannot unmarshal ADO ResourceUsage API response")
+		return -1, err
+	}
+
+	return usage.ResourceLimit.TotalCount, nil
+}
+
 func stripDeadJobs(jobs []JobRequest) []JobRequest {
 	var filtered []JobRequest
 	for _, job := range jobs {
@@ -500,6 +578,69 @@ func getCanAgentDemandFulfilJob(jr JobRequest, metadata *azurePipelinesMetadata)
 	return countDemands == len(demandsInJob)
 }
 
+// Determine if the agent capabilities satisfy all the demands of the job
+func getCanAgentCapabilitiesFulfilJob(jr JobRequest, metadata *azurePipelinesMetadata) bool {
+	for _, demand := range jr.Demands {
+		name, operator, value := parseAzurePipelinesDemand(demand)
+		capability, ok := metadata.capabilities[strings.ToLower(name)]
+
+		// the agent version is only checked when given, like in demands matching
+		if strings.EqualFold(name, "Agent.Version") && !ok {
+			continue
+		}
+
+		switch strings.ToLower(operator) {
+		case "", "exists":
+			if !ok {
+				return false
+			}
+		case "equals":
+			if !ok || !strings.EqualFold(capability, value) {
+				return false
+			}
+		case "gtversion":
+			if !ok || compareAzurePipelinesVersions(capability, value) <= 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// parseAzurePipelinesDemand splits demands like `Agent.OS -equals Linux` or `java`
+func parseAzurePipelinesDemand(demand string) (string, string, string) {
+	name, expression, found := strings.Cut(strings.TrimSpace(demand), " -")
+	if !found {
+		return name, "", ""
+	}
+	operator, value, _ := strings.Cut(expression, " ")
+	return strings.TrimSpace(name), operator, strings.TrimSpace(value)
+}
+
+// compareAzurePipelinesVersions compares dotted versions, returning -1, 0 or 1
+func compareAzurePipelinesVersions(a string, b string) int {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numA, numB int
+		if i < len(partsA) {
+			numA, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			numB, _ = strconv.Atoi(partsB[i])
+		}
+		if numA != numB {
+			if numA < numB {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
 // Determine if the Job and Parent Agent Template have matching capabilities
 func getCanAgentParentFulfilJob(jr JobRequest, metadata *azurePipelinesMetadata) bool {
 	matchedAgents := jr.MatchedAgents
@@ -46,6 +46,12 @@ var testAzurePipelinesMetadata = []parseAzurePipelinesMetadataTestData{
 	{"all properly formed", map[string]string{"organizationURLFromEnv": "AZP_URL", "personalAccessTokenFromEnv": "AZP_TOKEN", "poolID": "1", "targetPipelinesQueueLength": "1", "activationTargetPipelinesQueueLength": "A"}, true, testAzurePipelinesResolvedEnv, map[string]string{}},
 	// jobsToFetch malformed
 	{"jobsToFetch malformed", map[string]string{"organizationURLFromEnv": "AZP_URL", "personalAccessTokenFromEnv": "AZP_TOKEN", "poolID": "1", "targetPipelinesQueueLength": "1", "jobsToFetch": "test"}, true, testAzurePipelinesResolvedEnv, map[string]string{}},
+	// capabilities and parallel jobs limit
+	{"capabilities and limitToParallelJobs", map[string]string{"organizationURLFromEnv": "AZP_URL", "personalAccessTokenFromEnv": "AZP_TOKEN", "poolID": "1", "capabilities": "java,Agent.OS=Linux", "limitToParallelJobs": "true"}, false, testAzurePipelinesResolvedEnv, map[string]string{}},
+	// capabilities with demands
+	{"capabilities with demands", map[string]string{"organizationURLFromEnv": "AZP_URL", "personalAccessTokenFromEnv": "AZP_TOKEN", "poolID": "1", "capabilities": "java", "demands": "java"}, true, testAzurePipelinesResolvedEnv, map[string]string{}},
+	// limitToParallelJobs malformed
+	{"limitToParallelJobs malformed", map[string]string{"organizationURLFromEnv": "AZP_URL", "personalAccessTokenFromEnv": "AZP_TOKEN", "poolID": "1", "limitToParallelJobs": "test"}, true, testAzurePipelinesResolvedEnv, map[string]string{}},
 }
 
 var testJobRequestResponse = `{"count":2,"value":[{"requestId":890659,"queueTime":"2022-09-28T11:19:49.89Z","assignTime":"2022-09-28T11:20:29.5033333Z","receiveTime":"2022-09-28T11:20:32.0530499Z","lockedUntil":"2022-09-28T11:30:32.07Z","serviceOwner":"xxx","hostId":"xxx","scopeId":"xxx","planType":"Build","planId":"xxx","jobId":"xxx","demands":["kubectl","Agent.Version -gtVersion 2.182.1"],"reservedAgent":{"_links":{"self":{"href":"https://dev.azure.com/FOO/_apis/distributedtask/pools/44/agents/11735"},"web":{"href":"https://dev.azure.com/FOO/_settings/agentpools?view=jobs&poolId=44&agentId=11735"}},"id":11735,"name":"kube-scaledjob-5nlph-kzpgf","version":"2.210.1","osDescription":"Linux 5.4.0-1089-azure #94~18.04.1-Ubuntu SMP Fri Aug 5 12:34:50 UTC 2022","enabled":true,"status":"online","provisioningState":"Provisioned","accessPoint":"CodexAccessMapping"},"definition":{"_links":{"web":{"href":"https://dev.azure.com/FOO/1858395a-257e-4efd-bbc5-eb618128452b/_build/definition?definitionId=4869"},"self":{"href":"https://dev.azure.com/FOO/1858395a-257e-4efd-bbc5-eb618128452b/_apis/build/Definitions/4869"}},"id":4869,"name":"base - main"},"owner":{"_links":{"web":{"href":"https://dev.azure.com/FOO/1858395a-257e-4efd-bbc5-eb618128452b/_build/results?buildId=673584"},"self":{"href":"https://dev.azure.com/FOO/1858395a-257e-4efd-bbc5-eb618128452b/_apis/build/Builds/673584"}},"id":673584,"name":"20220928.2"},"data":{"ParallelismTag":"Private","IsScheduledKey":"False"},"poolId":44,"orchestrationId":"5c5c8ec9-786f-4e97-99d4-a29279befba3.build.__default","priority":0},{"requestId":890663,"queueTime":"2022-09-28T11:20:22.4633333Z","serviceOwner":"00025394-6065-48ca-87d9-7f5672854ef7","hostId":"41a18c7d-df5e-4032-a4df-d533b56bd2de","scopeId":"02696e26-a35b-424c-86b8-1f54e1b0b4b7","planType":"Build","planId":"b718cfed-493c-46be-a650-88fe762f75aa","jobId":"15b95994-59ec-5502-695d-0b93722883bd","demands":["dotnet60","java","Agent.Version -gtVersion 2.182.1"],"matchedAgents":[{"_links":{"self":{"href":"https://dev.azure.com/FOO/_apis/distributedtask/pools/44/agents/1755"},"web":{"href":"https://dev.azure.com/FOO/_settings/agentpools?view=jobs&poolId=44&agentId=1755"}},"id":1755,"name":"dotnet60-keda-template","version":"2.210.1","enabled":true,"status":"offline","provisioningState":"Provisioned"},{"_links":{"self":{"href":"https://dev.azure.com/FOO/_apis/distributedtask/pools/44/agents/11732"},"web":{"href":"https://dev.azure.com/FOO/_settings/agentpools?view=jobs&poolId=44&agentId=11732"}},"id":11732,"name":"dotnet60-scaledjob-5dsgc-pkqvm","version":"2.210.1","enabled":true,"status":"online","provisioningState":"Provisioned"},{"_links":{"self":{"href":"https://dev.azure.com/FOO/_apis/distributedtask/pools/44/agents/11733"},"web":{"href":"https://dev.azure.com/FOO/_settings/agentpools?view=jobs&poolId=44&agentId=11733"}},"id":11733,"name":"dotnet60-scaledjob-zgqnp-8h4z4","version":"2.210.1","enabled":true,"status":"online","provisioningState":"Provisioned"},{"_links":{"self":{"href":"https://dev.azure.com/FOO/_apis/distributedtask/pools/44/agents/11734"},"web":{"href":"https://dev.azure.com/FOO/_settings/agentpools?view=jobs&poolId=44&agentId=11734"}},"id":11734,"name":"dotnet60-scaledjob-wr65c-ff2cv","version":"2.210.1","enabled":true,"status":"online","provisioningState":"Provisioned"}],"definition":{"_links":{"web":{"href":"https://FOO.visualstudio.com/02696e26-a35b-424c-86b8-1f54e1b0b4b7/_build/definition?definitionId=3129"},"self":{"href":"https://FOO.visualstudio.com/02696e26-a35b-424c-86b8-1f54e1b0b4b7/_apis/build/Definitions/3129"}},"id":3129,"name":"Other Build CI"},"owner":{"_links":{"web":{"href":"https://FOO.visualstudio.com/02696e26-a35b-424c-86b8-1f54e1b0b4b7/_build/results?buildId=673585"},"self":{"href":"https://FOO.visualstudio.com/02696e26-a35b-424c-86b8-1f54e1b0b4b7/_apis/build/Builds/673585"}},"id":673585,"name":"20220928.11"},"data":{"ParallelismTag":"Private","IsScheduledKey":"False"},"poolId":44,"orchestrationId":"b718cfed-493c-46be-a650-88fe762f75aa.buildtest.build_and_test.__default","priority":0}]}`
@@ -337,6 +343,80 @@ func TestAzurePipelinesNotMatchedPartialRequiredTriggerDemands(t *testing.T) {
 	}
 }
 
+func TestAzurePipelinesCapabilitiesFulfilJob(t *testing.T) {
+	tests := []struct {
+		name         string
+		demands      []string
+		capabilities string
+		expected     bool
+	}{
+		{"exists demands", []string{"dotnet60", "java", "Agent.Version -gtVersion 2.182.1"}, "dotnet60,java,kubectl", true},
+		{"missing capability", []string{"dotnet60", "java"}, "java", false},
+		{"exists operator", []string{"java -exists"}, "JAVA", true},
+		{"equals operator", []string{"Agent.OS -equals Linux"}, "Agent.OS=linux", true},
+		{"equals operator with other value", []string{"Agent.OS -equals Windows_NT"}, "Agent.OS=Linux", false},
+		{"agent version too old", []string{"Agent.Version -gtVersion 2.182.1"}, "Agent.Version=2.181.10", false},
+		{"agent version", []string{"Agent.Version -gtVersion 2.182.1"}, "Agent.Version=2.210.1", true},
+		{"unknown operator", []string{"java -matches 1.*"}, "java=1.8", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := &azurePipelinesMetadata{capabilities: parseAzurePipelinesCapabilities(tt.capabilities)}
+			if got := getCanAgentCapabilitiesFulfilJob(JobRequest{Demands: tt.demands}, meta); got != tt.expected {
+				t.Errorf("expected %v but got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAzurePipelinesLimitToParallelJobs(t *testing.T) {
+	tests := []struct {
+		name         string
+		parallelJobs string
+		expected     int64
+	}{
+		{"less parallel jobs than queued jobs", "1", 1},
+		{"more parallel jobs than queued jobs", "5", 2},
+		{"no parallel jobs limit reported", "0", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var apiStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/resourceusage") {
+					if r.URL.Query().Get("parallelismTag") != "Private" {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					_, _ = w.Write([]byte(`{"resourceLimit":{"parallelismTag":"Private","isHosted":false,"totalCount":` + tt.parallelJobs + `},"usedCount":1}`))
+					return
+				}
+				_, _ = w.Write(buildLoadJSON())
+			}))
+			defer apiStub.Close()
+
+			meta := getMatchedAgentMetaData(apiStub.URL)
+			meta.parent = ""
+			meta.limitToParallelJobs = true
+
+			mockAzurePipelinesScaler := azurePipelinesScaler{
+				metadata:   meta,
+				httpClient: http.DefaultClient,
+				logger:     logr.Discard(),
+			}
+
+			queueLen, err := mockAzurePipelinesScaler.GetAzurePipelinesQueueLength(context.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if queueLen != tt.expected {
+				t.Errorf("expected queue length %d but got %d", tt.expected, queueLen)
+			}
+		})
+	}
+}
+
 func buildLoadJSON() []byte {
 	output := testJobRequestResponse[0 : len(testJobRequestResponse)-2]
 	for i := 1; i < loadCount; i++ {
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
 	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus/admin"
 	az "github.com/Azure/go-autorest/autorest/azure"
 	"github.com/go-logr/logr"
@@ -45,6 +46,11 @@ const (
 	messageCountMetricName                      = "messageCount"
 	activationMessageCountMetricName            = "activationMessageCount"
 	defaultTargetMessageCount                   = 5
+	// serviceBusPeekBatchSize is the number of messages peeked at once looking for the scheduled messages
+	serviceBusPeekBatchSize = 250
+	// maxServiceBusPeekedMessages bounds the messages peeked on each poll, so an entity with a long
+	// backlog of active messages before its scheduled ones isn't read in full
+	maxServiceBusPeekedMessages = 5000
 )
 
 type azureServiceBusScaler struct {
@@ -53,7 +59,9 @@ type azureServiceBusScaler struct {
 	metadata    *azureServiceBusMetadata
 	podIdentity kedav1alpha1.AuthPodIdentity
 	client      *admin.Client
-	logger      logr.Logger
+	// messagingClient peeks the scheduled messages, the admin client only counts them
+	messagingClient *azservicebus.Client
+	logger          logr.Logger
 }
 
 type azureServiceBusMetadata struct {
@@ -70,6 +78,13 @@ type azureServiceBusMetadata struct {
 	operation               string
 	triggerIndex            int
 	timeout                 time.Duration
+
+	includeActiveMessages             bool
+	includeDeadLetterMessages         bool
+	includeTransferDeadLetterMessages bool
+	// scheduledMessageWindow adds the messages scheduled to be enqueued within the window to the count,
+	// so the workload is scaled out before they are delivered
+	scheduledMessageWindow time.Duration
 }
 
 // NewAzureServiceBusScaler creates a new AzureServiceBusScaler
@@ -131,6 +146,43 @@ func parseAzureServiceBusMetadata(config *scalersconfig.ScalerConfig, logger log
 		meta.useRegex = useRegex
 	}
 
+	meta.includeActiveMessages = true
+	if val, ok := config.TriggerMetadata["includeActiveMessages"]; ok {
+		includeActiveMessages, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("includeActiveMessages has invalid value")
+		}
+		meta.includeActiveMessages = includeActiveMessages
+	}
+
+	if val, ok := config.TriggerMetadata["includeDeadLetterMessages"]; ok {
+		includeDeadLetterMessages, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("includeDeadLetterMessages has invalid value")
+		}
+		meta.includeDeadLetterMessages = includeDeadLetterMessages
+	}
+
+	if val, ok := config.TriggerMetadata["includeTransferDeadLetterMessages"]; ok {
+		includeTransferDeadLetterMessages, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("includeTransferDeadLetterMessages has invalid value")
+		}
+		meta.includeTransferDeadLetterMessages = includeTransferDeadLetterMessages
+	}
+
+	if val, ok := config.TriggerMetadata["scheduledMessageWindow"]; ok && val != "" {
+		scheduledMessageWindow, err := time.ParseDuration(val)
+		if err != nil || scheduledMessageWindow <= 0 {
+			return nil, fmt.Errorf("scheduledMessageWindow must be a positive duration")
+		}
+		meta.scheduledMessageWindow = scheduledMessageWindow
+	}
+
+	if !meta.includeActiveMessages && !meta.includeDeadLetterMessages && !meta.includeTransferDeadLetterMessages && meta.scheduledMessageWindow == 0 {
+		return nil, fmt.Errorf("at least one of the active, dead-letter, transfer dead-letter or scheduled messages must be counted")
+	}
+
 	meta.operation = sumOperation
 	if meta.useRegex {
 		if val, ok := config.TriggerMetadata["operation"]; ok {
@@ -191,6 +243,12 @@ func parseAzureServiceBusMetadata(config *scalersconfig.ScalerConfig, logger log
 		return nil, fmt.Errorf("no service bus entity type set")
 	}
 
+	// the scheduled messages of a topic are held by the topic until they are enqueued, they can only
+	// be peeked from a queue
+	if meta.scheduledMessageWindow > 0 && (meta.entityType != queue || meta.useRegex) {
+		return nil, fmt.Errorf("scheduledMessageWindow is only supported with a queueName without useRegex")
+	}
+
 	switch config.PodIdentity.Provider {
 	case "", kedav1alpha1.PodIdentityProviderNone:
 		// get servicebus connection string
@@ -227,8 +285,13 @@ func parseAzureServiceBusMetadata(config *scalersconfig.ScalerConfig, logger log
 	return &meta, nil
 }
 
-// Close - nothing to close for SB
-func (s *azureServiceBusScaler) Close(context.Context) error {
+// Close closes the client peeking the scheduled messages, the admin client has nothing to close
+func (s *azureServiceBusScaler) Close(ctx context.Context) error {
+	if s.messagingClient != nil {
+		err := s.messagingClient.Close(ctx)
+		s.messagingClient = nil
+		return err
+	}
 	return nil
 }
 
@@ -283,6 +346,9 @@ func (s *azureServiceBusScaler) getAzureServiceBusLength(ctx context.Context) (i
 	// switch case for queue vs topic here
 	switch s.metadata.entityType {
 	case queue:
+		if s.metadata.scheduledMessageWindow > 0 {
+			return s.getQueueLengthWithScheduledMessages(ctx, adminClient)
+		}
 		return getQueueLength(ctx, adminClient, s.metadata)
 	case subscription:
 		return getSubscriptionLength(ctx, adminClient, s.metadata)
@@ -321,6 +387,108 @@ func (s *azureServiceBusScaler) getServiceBusAdminClient() (*admin.Client, error
 	return client, err
 }
 
+// getServiceBusMessagingClient returns the client peeking the messages of the entities
+func (s *azureServiceBusScaler) getServiceBusMessagingClient() (*azservicebus.Client, error) {
+	if s.messagingClient != nil {
+		return s.messagingClient, nil
+	}
+	var err error
+	var client *azservicebus.Client
+
+	switch s.podIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		client, err = azservicebus.NewClientFromConnectionString(s.metadata.connection, nil)
+	case kedav1alpha1.PodIdentityProviderAzureWorkload:
+		creds, chainedErr := azure.NewChainedCredential(s.logger, s.podIdentity)
+		if chainedErr != nil {
+			return nil, chainedErr
+		}
+		client, err = azservicebus.NewClient(s.metadata.fullyQualifiedNamespace, creds, nil)
+	default:
+		err = fmt.Errorf("incorrect podIdentity type")
+	}
+
+	s.messagingClient = client
+	return client, err
+}
+
+// getQueueLengthWithScheduledMessages returns the length of the queue along with its messages scheduled
+// to be enqueued within the window
+func (s *azureServiceBusScaler) getQueueLengthWithScheduledMessages(ctx context.Context, adminClient *admin.Client) (int64, error) {
+	queueEntity, err := adminClient.GetQueueRuntimeProperties(ctx, s.metadata.queueName, &admin.GetQueueRuntimePropertiesOptions{})
+	if err != nil {
+		return -1, err
+	}
+	if queueEntity == nil {
+		return -1, fmt.Errorf("queue %s doesn't exist", s.metadata.queueName)
+	}
+	length := s.metadata.countMessages(queueEntity.ActiveMessageCount, queueEntity.DeadLetterMessageCount, queueEntity.TransferDeadLetterMessageCount)
+	if queueEntity.ScheduledMessageCount == 0 {
+		return length, nil
+	}
+
+	client, err := s.getServiceBusMessagingClient()
+	if err != nil {
+		return -1, err
+	}
+	receiver, err := client.NewReceiverForQueue(s.metadata.queueName, nil)
+	if err != nil {
+		return -1, err
+	}
+	defer receiver.Close(ctx)
+
+	// the scheduled messages are peeked in the order of their sequence numbers, until all of them are found
+	deadline := time.Now().Add(s.metadata.scheduledMessageWindow)
+	var found, due int64
+	for peeked := 0; found < int64(queueEntity.ScheduledMessageCount) && peeked < maxServiceBusPeekedMessages; {
+		messages, err := receiver.PeekMessages(ctx, serviceBusPeekBatchSize, nil)
+		if err != nil {
+			return -1, err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		peeked += len(messages)
+		scheduled, scheduledDue := countDueScheduledMessages(messages, deadline)
+		found += scheduled
+		due += scheduledDue
+	}
+	if found < int64(queueEntity.ScheduledMessageCount) {
+		s.logger.V(1).Info("not all the scheduled messages have been peeked", "queueName", s.metadata.queueName, "scheduled", queueEntity.ScheduledMessageCount, "found", found)
+	}
+
+	return length + due, nil
+}
+
+// countDueScheduledMessages returns the number of scheduled messages and of the ones enqueued by the deadline
+func countDueScheduledMessages(messages []*azservicebus.ReceivedMessage, deadline time.Time) (scheduled, due int64) {
+	for _, message := range messages {
+		if message.State != azservicebus.MessageStateScheduled {
+			continue
+		}
+		scheduled++
+		if message.ScheduledEnqueueTime != nil && !message.ScheduledEnqueueTime.After(deadline) {
+			due++
+		}
+	}
+	return scheduled, due
+}
+
+// countMessages returns the number of messages of an entity, summing the counts which are included
+func (meta *azureServiceBusMetadata) countMessages(active, deadLetter, transferDeadLetter int32) int64 {
+	var count int64
+	if meta.includeActiveMessages {
+		count += int64(active)
+	}
+	if meta.includeDeadLetterMessages {
+		count += int64(deadLetter)
+	}
+	if meta.includeTransferDeadLetterMessages {
+		count += int64(transferDeadLetter)
+	}
+	return count
+}
+
 func getQueueLength(ctx context.Context, adminClient *admin.Client, meta *azureServiceBusMetadata) (int64, error) {
 	if !meta.useRegex {
 		queueEntity, err := adminClient.GetQueueRuntimeProperties(ctx, meta.queueName, &admin.GetQueueRuntimePropertiesOptions{})
@@ -331,7 +499,7 @@ func getQueueLength(ctx context.Context, adminClient *admin.Client, meta *azureS
 			return -1, fmt.Errorf("queue %s doesn't exist", meta.queueName)
 		}
 
-		return int64(queueEntity.ActiveMessageCount), nil
+		return meta.countMessages(queueEntity.ActiveMessageCount, queueEntity.DeadLetterMessageCount, queueEntity.TransferDeadLetterMessageCount), nil
 	}
 
 	messageCounts := make([]int64, 0)
@@ -345,7 +513,7 @@ func getQueueLength(ctx context.Context, adminClient *admin.Client, meta *azureS
 
 		for _, queue := range page.QueueRuntimeProperties {
 			if meta.entityNameRegex.FindString(queue.QueueName) == queue.QueueName {
-				messageCounts = append(messageCounts, int64(queue.ActiveMessageCount))
+				messageCounts = append(messageCounts, meta.countMessages(queue.ActiveMessageCount, queue.DeadLetterMessageCount, queue.TransferDeadLetterMessageCount))
 			}
 		}
 	}
@@ -364,7 +532,7 @@ func getSubscriptionLength(ctx context.Context, adminClient *admin.Client, meta
 			return -1, fmt.Errorf("subscription %s doesn't exist in topic %s", meta.subscriptionName, meta.topicName)
 		}
 
-		return int64(subscriptionEntity.ActiveMessageCount), nil
+		return meta.countMessages(subscriptionEntity.ActiveMessageCount, subscriptionEntity.DeadLetterMessageCount, subscriptionEntity.TransferDeadLetterMessageCount), nil
 	}
 
 	messageCounts := make([]int64, 0)
@@ -378,7 +546,7 @@ func getSubscriptionLength(ctx context.Context, adminClient *admin.Client, meta
 
 		for _, subscription := range page.SubscriptionRuntimeProperties {
 			if meta.entityNameRegex.FindString(subscription.SubscriptionName) == subscription.SubscriptionName {
-				messageCounts = append(messageCounts, int64(subscription.ActiveMessageCount))
+				messageCounts = append(messageCounts, meta.countMessages(subscription.ActiveMessageCount, subscription.DeadLetterMessageCount, subscription.TransferDeadLetterMessageCount))
 			}
 		}
 	}
@@ -21,7 +21,9 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/messaging/azservicebus"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 
@@ -123,6 +125,27 @@ var parseServiceBusMetadataDataset = []parseServiceBusMetadataTestData{
 	{map[string]string{"topicName": topicName, "subscriptionName": subscriptionName, "connectionFromEnv": connectionSetting, "useRegex": "true", "operation": "random"}, true, subscription, defaultSuffix, map[string]string{}, ""},
 	// subscription with invalid regex string
 	{map[string]string{"topicName": topicName, "subscriptionName": "*", "connectionFromEnv": connectionSetting, "useRegex": "true", "operation": "avg"}, true, subscription, defaultSuffix, map[string]string{}, ""},
+
+	// subscription with dead-letter messages
+	{map[string]string{"topicName": topicName, "subscriptionName": subscriptionName, "connectionFromEnv": connectionSetting, "includeDeadLetterMessages": "true", "includeTransferDeadLetterMessages": "true"}, false, subscription, defaultSuffix, map[string]string{}, ""},
+	// subscription with only dead-letter messages
+	{map[string]string{"topicName": topicName, "subscriptionName": subscriptionName, "connectionFromEnv": connectionSetting, "includeActiveMessages": "false", "includeDeadLetterMessages": "true"}, false, subscription, defaultSuffix, map[string]string{}, ""},
+	// subscription without any messages counted
+	{map[string]string{"topicName": topicName, "subscriptionName": subscriptionName, "connectionFromEnv": connectionSetting, "includeActiveMessages": "false"}, true, subscription, defaultSuffix, map[string]string{}, ""},
+	// incorrect includeDeadLetterMessages value
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "includeDeadLetterMessages": "ababa"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// incorrect includeTransferDeadLetterMessages value
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "includeTransferDeadLetterMessages": "ababa"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// queue with scheduled messages
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "scheduledMessageWindow": "5m"}, false, queue, defaultSuffix, map[string]string{}, ""},
+	// queue with only scheduled messages
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "includeActiveMessages": "false", "scheduledMessageWindow": "5m"}, false, queue, defaultSuffix, map[string]string{}, ""},
+	// invalid scheduledMessageWindow
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "scheduledMessageWindow": "-5m"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// scheduledMessageWindow with a subscription
+	{map[string]string{"topicName": topicName, "subscriptionName": subscriptionName, "connectionFromEnv": connectionSetting, "scheduledMessageWindow": "5m"}, true, subscription, defaultSuffix, map[string]string{}, ""},
+	// scheduledMessageWindow with useRegex
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "useRegex": "true", "scheduledMessageWindow": "5m"}, true, queue, defaultSuffix, map[string]string{}, ""},
 }
 
 var azServiceBusMetricIdentifiers = []azServiceBusMetricIdentifier{
@@ -133,22 +156,25 @@ var azServiceBusMetricIdentifiers = []azServiceBusMetricIdentifier{
 var getServiceBusLengthTestScalers = []azureServiceBusScaler{
 	{
 		metadata: &azureServiceBusMetadata{
-			entityType: queue,
-			queueName:  queueName,
+			entityType:            queue,
+			queueName:             queueName,
+			includeActiveMessages: true,
 		},
 	},
 	{
 		metadata: &azureServiceBusMetadata{
-			entityType:       subscription,
-			topicName:        topicName,
-			subscriptionName: subscriptionName,
+			entityType:            subscription,
+			topicName:             topicName,
+			subscriptionName:      subscriptionName,
+			includeActiveMessages: true,
 		},
 	},
 	{
 		metadata: &azureServiceBusMetadata{
-			entityType:       subscription,
-			topicName:        topicName,
-			subscriptionName: subscriptionName,
+			entityType:            subscription,
+			topicName:             topicName,
+			subscriptionName:      subscriptionName,
+			includeActiveMessages: true,
 		},
 		podIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAzureWorkload},
 	},
@@ -250,3 +276,46 @@ func TestAzServiceBusGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestAzServiceBusCountMessages(t *testing.T) {
+	testCases := []struct {
+		name     string
+		metadata map[string]string
+		expected int64
+	}{
+		{"active messages", map[string]string{}, 10},
+		{"active and dead-letter messages", map[string]string{"includeDeadLetterMessages": "true"}, 13},
+		{"all the messages", map[string]string{"includeDeadLetterMessages": "true", "includeTransferDeadLetterMessages": "true"}, 15},
+		{"dead-letter messages", map[string]string{"includeActiveMessages": "false", "includeDeadLetterMessages": "true"}, 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["queueName"] = queueName
+			meta, err := parseAzureServiceBusMetadata(&scalersconfig.ScalerConfig{ResolvedEnv: sampleResolvedEnv,
+				TriggerMetadata: tc.metadata, AuthParams: map[string]string{"connection": connectionSetting}},
+				logr.Discard())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, meta.countMessages(10, 3, 2))
+		})
+	}
+}
+
+func TestAzServiceBusCountDueScheduledMessages(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	messages := []*azservicebus.ReceivedMessage{
+		{State: azservicebus.MessageStateActive},
+		{State: azservicebus.MessageStateScheduled, ScheduledEnqueueTime: at(time.Minute)},
+		{State: azservicebus.MessageStateScheduled, ScheduledEnqueueTime: at(5 * time.Minute)},
+		{State: azservicebus.MessageStateScheduled, ScheduledEnqueueTime: at(time.Hour)},
+		{State: azservicebus.MessageStateDeferred},
+	}
+
+	scheduled, due := countDueScheduledMessages(messages, now.Add(5*time.Minute))
+	assert.Equal(t, int64(3), scheduled)
+	assert.Equal(t, int64(2), due)
+}
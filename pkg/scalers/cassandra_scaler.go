@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -37,9 +38,14 @@ type CassandraMetadata struct {
 	clusterIPAddress           string
 	port                       int
 	consistency                gocql.Consistency
+	localDC                    string
+	pageSize                   int
 	protocolVersion            int
 	keyspace                   string
 	query                      string
+	queryParameters            []string
+	tokenAwareRouting          bool
+	rejectUnboundedQuery       bool
 	targetQueryValue           int64
 	activationTargetQueryValue int64
 	triggerIndex               int
@@ -50,6 +56,14 @@ const (
 	tlsDisable = "disable"
 )
 
+var (
+	cassandraCountRegex = regexp.MustCompile(`(?i)\bcount\s*\(`)
+	cassandraWhereRegex = regexp.MustCompile(`(?is)\bwhere\b(.*)`)
+	// a restriction of the partition key is an equality or an IN, a range restricts the token of the partition key
+	cassandraPartitionRegex = regexp.MustCompile(`(?i)(^|[^<>!])=|\bin\s*\(|\btoken\s*\(`)
+	cassandraFilteringRegex = regexp.MustCompile(`(?i)\ballow\s+filtering\b`)
+)
+
 // NewCassandraScaler creates a new Cassandra scaler.
 func NewCassandraScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
@@ -64,6 +78,10 @@ func NewCassandraScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing cassandra metadata: %w", err)
 	}
 
+	if err := validateCassandraQuery(meta.query); err != nil {
+		logger.Info("the query may scan the whole table on every polling interval, set rejectUnboundedQuery to reject it", "reason", err.Error())
+	}
+
 	session, err := newCassandraSession(meta, logger)
 	if err != nil {
 		return nil, fmt.Errorf("error establishing cassandra session: %w", err)
@@ -88,6 +106,16 @@ func parseCassandraMetadata(config *scalersconfig.ScalerConfig) (*CassandraMetad
 		return nil, fmt.Errorf("no query given")
 	}
 
+	// the bind parameters can come from a secret or a config map through the TriggerAuthentication
+	if val, ok := config.TriggerMetadata["queryParameters"]; ok {
+		meta.queryParameters = splitCassandraQueryParameters(val)
+	} else if val, ok := config.AuthParams["queryParameters"]; ok {
+		meta.queryParameters = splitCassandraQueryParameters(val)
+	}
+	if markers := countCassandraBindMarkers(meta.query); markers != len(meta.queryParameters) {
+		return nil, fmt.Errorf("query has %d bind markers but %d queryParameters given", markers, len(meta.queryParameters))
+	}
+
 	if val, ok := config.TriggerMetadata["targetQueryValue"]; ok {
 		targetQueryValue, err := strconv.ParseInt(val, 10, 64)
 		if err != nil {
@@ -153,10 +181,57 @@ func parseCassandraMetadata(config *scalersconfig.ScalerConfig) (*CassandraMetad
 	}
 
 	if val, ok := config.TriggerMetadata["consistency"]; ok {
-		meta.consistency = gocql.ParseConsistency(val)
+		consistency, err := gocql.ParseConsistencyWrapper(val)
+		if err != nil {
+			return nil, fmt.Errorf("consistency parsing error %w", err)
+		}
+		meta.consistency = consistency
 	} else {
 		meta.consistency = gocql.One
 	}
+	if meta.consistency == gocql.EachQuorum {
+		return nil, fmt.Errorf("consistency %s is only supported for writes", meta.consistency)
+	}
+
+	if val, ok := config.TriggerMetadata["localDC"]; ok {
+		meta.localDC = strings.TrimSpace(val)
+	}
+	if (meta.consistency == gocql.LocalOne || meta.consistency == gocql.LocalQuorum) && meta.localDC == "" {
+		return nil, fmt.Errorf("localDC must be given with consistency %s", meta.consistency)
+	}
+
+	meta.tokenAwareRouting = true
+	if val, ok := config.TriggerMetadata["tokenAwareRouting"]; ok {
+		tokenAwareRouting, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("tokenAwareRouting parsing error %w", err)
+		}
+		meta.tokenAwareRouting = tokenAwareRouting
+	}
+
+	if val, ok := config.TriggerMetadata["pageSize"]; ok {
+		pageSize, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("pageSize parsing error %w", err)
+		}
+		if pageSize <= 0 {
+			return nil, fmt.Errorf("pageSize must be greater than 0")
+		}
+		meta.pageSize = pageSize
+	}
+
+	if val, ok := config.TriggerMetadata["rejectUnboundedQuery"]; ok {
+		rejectUnboundedQuery, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("rejectUnboundedQuery parsing error %w", err)
+		}
+		meta.rejectUnboundedQuery = rejectUnboundedQuery
+	}
+	if meta.rejectUnboundedQuery {
+		if err := validateCassandraQuery(meta.query); err != nil {
+			return nil, err
+		}
+	}
 
 	if val, ok := config.TriggerMetadata["keyspace"]; ok {
 		meta.keyspace = val
@@ -178,6 +253,46 @@ func parseCassandraMetadata(config *scalersconfig.ScalerConfig) (*CassandraMetad
 	return meta, nil
 }
 
+// validateCassandraQuery rejects the COUNT queries which aren't restricted to partitions or to a token
+// range, as they scan the whole table on every polling interval. A LIMIT doesn't bound a COUNT, it
+// applies to the single row of the aggregate. The restrictions needing ALLOW FILTERING don't restrict
+// the partition key, they scan the table too.
+func validateCassandraQuery(query string) error {
+	if !cassandraCountRegex.MatchString(query) {
+		return nil
+	}
+	where := cassandraWhereRegex.FindStringSubmatch(query)
+	if where != nil && cassandraPartitionRegex.MatchString(where[1]) && !cassandraFilteringRegex.MatchString(where[1]) {
+		return nil
+	}
+	return fmt.Errorf("unbounded COUNT query given, restrict the partition key or its token() range in the query")
+}
+
+// splitCassandraQueryParameters splits the comma separated bind parameters of the query
+func splitCassandraQueryParameters(val string) []string {
+	var parameters []string
+	for _, parameter := range strings.Split(val, ",") {
+		parameters = append(parameters, strings.TrimSpace(parameter))
+	}
+	return parameters
+}
+
+// countCassandraBindMarkers counts the positional bind markers of the query, skipping the
+// question marks of the string literals
+func countCassandraBindMarkers(query string) int {
+	markers := 0
+	inLiteral := false
+	for _, c := range query {
+		switch {
+		case c == '\'':
+			inLiteral = !inLiteral
+		case c == '?' && !inLiteral:
+			markers++
+		}
+	}
+	return markers
+}
+
 func createTempFile(prefix string, content string) (string, error) {
 	tempCassandraDir := fmt.Sprintf("%s%c%s", os.TempDir(), os.PathSeparator, "cassandra")
 	err := os.MkdirAll(tempCassandraDir, 0700)
@@ -256,6 +371,19 @@ func newCassandraSession(meta *CassandraMetadata, logger logr.Logger) (*gocql.Se
 	cluster := gocql.NewCluster(meta.clusterIPAddress)
 	cluster.ProtoVersion = meta.protocolVersion
 	cluster.Consistency = meta.consistency
+	hostPolicy := gocql.RoundRobinHostPolicy()
+	if meta.localDC != "" {
+		// route the queries to the replicas of the local datacenter
+		hostPolicy = gocql.DCAwareRoundRobinPolicy(meta.localDC)
+	}
+	if meta.tokenAwareRouting {
+		// send the queries to a replica of the partition read instead of a coordinator forwarding them
+		hostPolicy = gocql.TokenAwareHostPolicy(hostPolicy)
+	}
+	cluster.PoolConfig.HostSelectionPolicy = hostPolicy
+	if meta.pageSize > 0 {
+		cluster.PageSize = meta.pageSize
+	}
 	cluster.Authenticator = gocql.PasswordAuthenticator{
 		Username: meta.username,
 		Password: meta.password,
@@ -305,10 +433,15 @@ func (s *cassandraScaler) GetMetricsAndActivity(ctx context.Context, metricName
 	return []external_metrics.ExternalMetricValue{metric}, num > s.metadata.activationTargetQueryValue, nil
 }
 
-// GetQueryResult returns the result of the scaler query.
+// GetQueryResult returns the result of the scaler query, the query is prepared by the driver and
+// the parameters are bound to its markers.
 func (s *cassandraScaler) GetQueryResult(ctx context.Context) (int64, error) {
+	values := make([]interface{}, len(s.metadata.queryParameters))
+	for i, parameter := range s.metadata.queryParameters {
+		values[i] = parameter
+	}
 	var value int64
-	if err := s.session.Query(s.metadata.query).WithContext(ctx).Scan(&value); err != nil {
+	if err := s.session.Query(s.metadata.query, values...).WithContext(ctx).Scan(&value); err != nil {
 		if err != gocql.ErrNotFound {
 			s.logger.Error(err, "query failed")
 			return 0, err
@@ -53,6 +53,36 @@ var testCassandraMetadata = []parseCassandraMetadataTestData{
 	{map[string]string{"query": "SELECT COUNT(*) FROM test_keyspace.test_table;", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace", "TriggerIndex": "0"}, true, map[string]string{}},
 	// fix issue[4110] passed
 	{map[string]string{"query": "SELECT COUNT(*) FROM test_keyspace.test_table;", "targetQueryValue": "1", "username": "cassandra", "port": "9042", "clusterIPAddress": "https://cassandra.test", "keyspace": "test_keyspace", "TriggerIndex": "0"}, false, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// unbounded COUNT query rejected
+	{map[string]string{"query": "SELECT COUNT(*) FROM test_keyspace.test_table;", "rejectUnboundedQuery": "true", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, true, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// a LIMIT doesn't bound a COUNT query
+	{map[string]string{"query": "SELECT COUNT(*) FROM test_keyspace.test_table LIMIT 1000;", "rejectUnboundedQuery": "true", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, true, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// nor a restriction needing filtering
+	{map[string]string{"query": "SELECT COUNT(*) FROM test_keyspace.test_table WHERE status = 'pending' ALLOW FILTERING;", "rejectUnboundedQuery": "true", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, true, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// COUNT query restricted to a partition
+	{map[string]string{"query": "SELECT COUNT(*) FROM test_keyspace.test_table WHERE queue = 'jobs';", "rejectUnboundedQuery": "true", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, false, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// COUNT query bounded by a token range, DC aware with local consistency
+	{map[string]string{"query": "SELECT count(*) FROM test_keyspace.test_table WHERE token(id) > -9223372036854775808 AND token(id) <= 0;", "rejectUnboundedQuery": "true", "consistency": "LOCAL_QUORUM", "localDC": "dc1", "pageSize": "500", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, false, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// local consistency without localDC
+	{map[string]string{"query": "SELECT COUNT(*) FROM test_keyspace.test_table;", "consistency": "LOCAL_ONE", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, true, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// invalid consistency
+	{map[string]string{"query": "SELECT COUNT(*) FROM test_keyspace.test_table;", "consistency": "MOST", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, true, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// invalid pageSize
+	{map[string]string{"query": "SELECT COUNT(*) FROM test_keyspace.test_table;", "pageSize": "0", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, true, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// non COUNT query doesn't need bounds
+	{map[string]string{"query": "SELECT value FROM test_keyspace.queue_size WHERE name = 'jobs';", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, false, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// EACH_QUORUM consistency isn't supported for reads
+	{map[string]string{"query": "SELECT COUNT(*) FROM test_keyspace.test_table;", "consistency": "EACH_QUORUM", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, true, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// bind parameters in metadata, token aware routing disabled
+	{map[string]string{"query": "SELECT value FROM test_keyspace.queue_size WHERE tenant = ? AND name = ?;", "queryParameters": "acme, jobs", "tokenAwareRouting": "false", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, false, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// bind parameters in auth params
+	{map[string]string{"query": "SELECT value FROM test_keyspace.queue_size WHERE tenant = ? AND name = 'jobs?';", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, false, map[string]string{"password": "Y2Fzc2FuZHJhCg==", "queryParameters": "acme"}},
+	// fewer bind parameters than markers
+	{map[string]string{"query": "SELECT value FROM test_keyspace.queue_size WHERE tenant = ? AND name = ?;", "queryParameters": "acme", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, true, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// bind markers without parameters
+	{map[string]string{"query": "SELECT value FROM test_keyspace.queue_size WHERE tenant = ?;", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, true, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
+	// invalid tokenAwareRouting
+	{map[string]string{"query": "SELECT COUNT(*) FROM test_keyspace.test_table;", "tokenAwareRouting": "sometimes", "targetQueryValue": "1", "username": "cassandra", "clusterIPAddress": "cassandra.test:9042", "keyspace": "test_keyspace"}, true, map[string]string{"password": "Y2Fzc2FuZHJhCg=="}},
 }
 
 var tlsAuthParamsTestData = []parseCassandraTLSTestData{
@@ -155,3 +185,27 @@ func TestParseCassandraTLS(t *testing.T) {
 		}
 	}
 }
+
+func TestCassandraParseQueryParameters(t *testing.T) {
+	meta, err := parseCassandraMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testCassandraMetadata[21].metadata, AuthParams: testCassandraMetadata[21].authParams})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if len(meta.queryParameters) != 2 || meta.queryParameters[0] != "acme" || meta.queryParameters[1] != "jobs" {
+		t.Errorf("Expected queryParameters [acme jobs] but got %v", meta.queryParameters)
+	}
+	if meta.tokenAwareRouting {
+		t.Error("Expected tokenAwareRouting to be disabled")
+	}
+
+	meta, err = parseCassandraMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testCassandraMetadata[22].metadata, AuthParams: testCassandraMetadata[22].authParams})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if len(meta.queryParameters) != 1 || meta.queryParameters[0] != "acme" {
+		t.Errorf("Expected queryParameters [acme] but got %v", meta.queryParameters)
+	}
+	if !meta.tokenAwareRouting {
+		t.Error("Expected tokenAwareRouting to be enabled by default")
+	}
+}
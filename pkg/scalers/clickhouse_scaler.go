@@ -0,0 +1,200 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	url_pkg "net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	clickHouseDefaultHTTPPort  = "8123"
+	clickHouseDefaultHTTPSPort = "8443"
+)
+
+type clickHouseScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *clickHouseMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type clickHouseMetadata struct {
+	Host                 string  `keda:"name=host,                 order=triggerMetadata;authParams;resolvedEnv"`
+	Port                 string  `keda:"name=port,                 order=triggerMetadata;authParams, optional"`
+	Database             string  `keda:"name=database,             order=triggerMetadata;authParams, default=default"`
+	Username             string  `keda:"name=username,             order=triggerMetadata;authParams;resolvedEnv, default=default"`
+	Password             string  `keda:"name=password,             order=authParams;resolvedEnv, optional"`
+	Query                string  `keda:"name=query,                order=triggerMetadata"`
+	QueryValue           float64 `keda:"name=queryValue,           order=triggerMetadata"`
+	ActivationQueryValue float64 `keda:"name=activationQueryValue, order=triggerMetadata, default=0"`
+	MetricName           string  `keda:"name=metricName,           order=triggerMetadata, optional"`
+
+	// TLS
+	TLS         string `keda:"name=tls,         order=triggerMetadata;authParams, enum=enable;disable, default=disable"`
+	CA          string `keda:"name=ca,          order=authParams, optional"`
+	Cert        string `keda:"name=cert,        order=authParams, optional"`
+	Key         string `keda:"name=key,         order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+	UnsafeSsl   bool   `keda:"name=unsafeSsl,   order=triggerMetadata, default=false"`
+}
+
+func (m *clickHouseMetadata) Validate() error {
+	if m.TLS == stringEnable && (m.Cert == "") != (m.Key == "") {
+		return fmt.Errorf("both cert and key must be provided when using TLS")
+	}
+	if m.TLS != stringEnable && (m.Cert != "" || m.Key != "" || m.CA != "") {
+		return fmt.Errorf("tls must be enabled when ca, cert or key are provided")
+	}
+	if m.Port == "" {
+		m.Port = clickHouseDefaultHTTPPort
+		if m.TLS == stringEnable {
+			m.Port = clickHouseDefaultHTTPSPort
+		}
+	}
+	return nil
+}
+
+// NewClickHouseScaler creates a new ClickHouse scaler, the query is run over the HTTP interface of the server
+func NewClickHouseScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseClickHouseMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ClickHouse metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	if meta.TLS == stringEnable {
+		tlsConfig, err := kedautil.NewTLSConfigWithPassword(meta.Cert, meta.Key, meta.KeyPassword, meta.CA, meta.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+	}
+
+	return &clickHouseScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "clickhouse_scaler"),
+	}, nil
+}
+
+func parseClickHouseMetadata(config *scalersconfig.ScalerConfig) (*clickHouseMetadata, error) {
+	meta := &clickHouseMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing clickhouse metadata: %w", err)
+	}
+
+	if !config.AsMetricSource && meta.QueryValue == 0 {
+		return nil, fmt.Errorf("no queryValue given")
+	}
+
+	if meta.MetricName == "" {
+		meta.MetricName = fmt.Sprintf("clickhouse-%s", meta.Database)
+	}
+	meta.MetricName = GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(meta.MetricName))
+	return meta, nil
+}
+
+// getQueryResult runs the query and returns the number in the first column of its first row. The query is sent
+// with a GET request, which ClickHouse runs in the readonly mode so the scaler can't modify the data.
+func (s *clickHouseScaler) getQueryResult(ctx context.Context) (float64, error) {
+	scheme := "http"
+	if s.metadata.TLS == stringEnable {
+		scheme = "https"
+	}
+	params := url_pkg.Values{}
+	params.Set("query", s.metadata.Query)
+	params.Set("database", s.metadata.Database)
+	params.Set("default_format", "TabSeparated")
+	url := fmt.Sprintf("%s://%s/?%s", scheme, net.JoinHostPort(s.metadata.Host, s.metadata.Port), params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return -1, err
+	}
+	req.Header.Set("X-ClickHouse-User", s.metadata.Username)
+	if s.metadata.Password != "" {
+		req.Header.Set("X-ClickHouse-Key", s.metadata.Password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return -1, fmt.Errorf("error sending request to clickhouse: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return -1, fmt.Errorf("clickhouse query returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	// only the first row is read, the rest of the result is discarded with the body
+	reader := bufio.NewReader(resp.Body)
+	row, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return -1, fmt.Errorf("error reading clickhouse query result: %w", err)
+	}
+	row = strings.TrimRight(row, "\r\n")
+	if row == "" {
+		return -1, fmt.Errorf("clickhouse query %s returned no rows", s.metadata.Query)
+	}
+
+	column, _, _ := strings.Cut(row, "\t")
+	if column == `\N` {
+		return -1, fmt.Errorf("clickhouse query %s returned NULL", s.metadata.Query)
+	}
+	value, err := strconv.ParseFloat(column, 64)
+	if err != nil {
+		return -1, fmt.Errorf("error converting clickhouse query result %q: %w", column, err)
+	}
+	return value, nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *clickHouseScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: s.metadata.MetricName,
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.QueryValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
+func (s *clickHouseScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	num, err := s.getQueryResult(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting clickhouse: %w", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, num)
+	return []external_metrics.ExternalMetricValue{metric}, num > s.metadata.ActivationQueryValue, nil
+}
+
+// Close closes the idle connections of the http client
+func (s *clickHouseScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package scalers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseClickHouseMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type clickHouseMetricIdentifier struct {
+	metadataTestData *parseClickHouseMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testClickHouseMetadata = []parseClickHouseMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed metadata
+	{map[string]string{"host": "clickhouse", "query": "SELECT count() FROM jobs WHERE status = 'pending'", "queryValue": "10"}, map[string]string{}, false},
+	// database and metricName
+	{map[string]string{"host": "clickhouse", "database": "events", "metricName": "pending", "query": "SELECT 1", "queryValue": "10"}, map[string]string{"password": "secret"}, false},
+	// no host
+	{map[string]string{"query": "SELECT 1", "queryValue": "10"}, map[string]string{}, true},
+	// no query
+	{map[string]string{"host": "clickhouse", "queryValue": "10"}, map[string]string{}, true},
+	// no queryValue
+	{map[string]string{"host": "clickhouse", "query": "SELECT 1"}, map[string]string{}, true},
+	// TLS with client certificate
+	{map[string]string{"host": "clickhouse", "query": "SELECT 1", "queryValue": "10", "tls": "enable"}, map[string]string{"cert": "ceert", "key": "keey"}, false},
+	// cert without key
+	{map[string]string{"host": "clickhouse", "query": "SELECT 1", "queryValue": "10", "tls": "enable"}, map[string]string{"cert": "ceert"}, true},
+	// ca without TLS
+	{map[string]string{"host": "clickhouse", "query": "SELECT 1", "queryValue": "10"}, map[string]string{"ca": "caaa"}, true},
+}
+
+var clickHouseMetricIdentifiers = []clickHouseMetricIdentifier{
+	{&testClickHouseMetadata[1], 0, "s0-clickhouse-default"},
+	{&testClickHouseMetadata[2], 1, "s1-pending"},
+}
+
+func TestClickHouseParseMetadata(t *testing.T) {
+	for testCaseNum, testData := range testClickHouseMetadata {
+		_, err := parseClickHouseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", testCaseNum, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", testCaseNum)
+		}
+	}
+
+	// the port defaults to the one of the HTTP interface
+	meta, err := parseClickHouseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testClickHouseMetadata[1].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, "8123", meta.Port)
+	meta, err = parseClickHouseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testClickHouseMetadata[6].metadata, AuthParams: testClickHouseMetadata[6].authParams})
+	assert.NoError(t, err)
+	assert.Equal(t, "8443", meta.Port)
+}
+
+func TestClickHouseGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range clickHouseMetricIdentifiers {
+		meta, err := parseClickHouseMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockClickHouseScaler := clickHouseScaler{metadata: meta}
+
+		metricSpec := mockClickHouseScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Errorf("Wrong External metric source name: %s, expected: %s", metricName, testData.name)
+		}
+	}
+}
+
+func TestClickHouseGetMetricsAndActivity(t *testing.T) {
+	var response string
+	var status int
+	var r *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r = req
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.NoError(t, err)
+	meta, err := parseClickHouseMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"host": host, "port": port, "database": "events", "query": "SELECT count() FROM jobs", "queryValue": "10", "activationQueryValue": "5"},
+		AuthParams:      map[string]string{"username": "keda", "password": "secret"},
+	})
+	assert.NoError(t, err)
+	s := &clickHouseScaler{metadata: meta, httpClient: http.DefaultClient}
+
+	// the first column of the first row is the metric
+	response, status = "12.5\tpending\n3\tdone\n", http.StatusOK
+	metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "s0-clickhouse-events")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12500), metrics[0].Value.MilliValue())
+	assert.True(t, isActive)
+	assert.Equal(t, http.MethodGet, r.Method)
+	assert.Equal(t, "SELECT count() FROM jobs", r.URL.Query().Get("query"))
+	assert.Equal(t, "events", r.URL.Query().Get("database"))
+	assert.Equal(t, "keda", r.Header.Get("X-ClickHouse-User"))
+	assert.Equal(t, "secret", r.Header.Get("X-ClickHouse-Key"))
+
+	response = "4"
+	_, isActive, err = s.GetMetricsAndActivity(context.Background(), "s0-clickhouse-events")
+	assert.NoError(t, err)
+	assert.False(t, isActive)
+
+	// no rows, NULL, values which aren't numbers and the errors of the server are errors
+	for _, response = range []string{"", `\N`, "pending"} {
+		_, _, err = s.GetMetricsAndActivity(context.Background(), "s0-clickhouse-events")
+		assert.Error(t, err, response)
+	}
+	response, status = "Code: 60. DB::Exception: Table events.jobs does not exist.", http.StatusNotFound
+	_, _, err = s.GetMetricsAndActivity(context.Background(), "s0-clickhouse-events")
+	assert.ErrorContains(t, err, "Table events.jobs does not exist")
+}
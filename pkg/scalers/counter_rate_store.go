@@ -0,0 +1,91 @@
+package scalers
+
+import (
+	"sync"
+	"time"
+)
+
+// counterRateTTL is how long a counter is kept past its rate window once it isn't sampled anymore
+const counterRateTTL = 10 * time.Minute
+
+// counterRateKey identifies a cumulative counter, sampled for its rate window
+type counterRateKey interface {
+	comparable
+	window() time.Duration
+}
+
+// counterSample is the value of a counter at a time
+type counterSample struct {
+	value float64
+	time  time.Time
+}
+
+// counterRateStore keeps the samples of cumulative counters over their rate window. The stores are kept
+// out of the scalers so the rates go on being computed over the window across the rebuilds of the scalers.
+type counterRateStore[K counterRateKey] struct {
+	lock    sync.Mutex
+	samples map[K][]counterSample
+	// startedAt is the start of the counters first sampled within the rate window of their start, they
+	// started from 0 then
+	startedAt map[K]time.Time
+}
+
+func newCounterRateStore[K counterRateKey]() *counterRateStore[K] {
+	return &counterRateStore[K]{samples: map[K][]counterSample{}, startedAt: map[K]time.Time{}}
+}
+
+// rate records the value of the counter and returns its rate since the newest sample at least the
+// rate window old. Until the window has elapsed since the counter was first sampled, the rate of a
+// counter started within the window is its rate since it started, it's unknown for the other
+// counters and once the counter was reset.
+func (c *counterRateStore[K]) rate(key K, value float64, now time.Time, startedAt time.Time) (float64, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	samples := c.samples[key]
+	if len(samples) > 0 && !now.After(samples[len(samples)-1].time) {
+		// the counters shared by several scalers may be sampled out of order, the newest sample is used
+		last := samples[len(samples)-1]
+		value, now = last.value, last.time
+		samples = samples[:len(samples)-1]
+	}
+	if len(samples) == 0 && !startedAt.IsZero() && now.Sub(startedAt) < key.window() {
+		c.startedAt[key] = startedAt
+	}
+	if len(samples) > 0 && value < samples[len(samples)-1].value {
+		// the counter was reset when its process restarted
+		samples = nil
+		delete(c.startedAt, key)
+	}
+	samples = append(samples, counterSample{value: value, time: now})
+
+	baseline := -1
+	for i := range samples {
+		if now.Sub(samples[i].time) < key.window() {
+			break
+		}
+		baseline = i
+	}
+	if baseline < 0 {
+		c.samples[key] = samples
+		if startedAt, ok := c.startedAt[key]; ok && now.After(startedAt) {
+			return value / now.Sub(startedAt).Seconds(), true
+		}
+		return 0, false
+	}
+	delete(c.startedAt, key)
+	// the samples older than the baseline aren't needed anymore
+	c.samples[key] = samples[baseline:]
+	return (value - samples[baseline].value) / now.Sub(samples[baseline].time).Seconds(), true
+}
+
+// expire drops the counters which aren't sampled anymore
+func (c *counterRateStore[K]) expire(now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for key, samples := range c.samples {
+		if now.Sub(samples[len(samples)-1].time) > key.window()+counterRateTTL {
+			delete(c.samples, key)
+			delete(c.startedAt, key)
+		}
+	}
+}
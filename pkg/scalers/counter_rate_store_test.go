@@ -0,0 +1,73 @@
+package scalers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCounterRateStore(t *testing.T) {
+	store := newCounterRateStore[envoyCounterKey]()
+	key := envoyCounterKey{pod: types.UID("api-1"), cluster: "backend", rateWindow: time.Minute}
+	now := time.Now()
+
+	// the rate is unknown until the counter has been sampled for the window
+	_, ok := store.rate(key, 100, now, time.Time{})
+	assert.False(t, ok)
+	_, ok = store.rate(key, 130, now.Add(30*time.Second), time.Time{})
+	assert.False(t, ok)
+	rate, ok := store.rate(key, 160, now.Add(time.Minute), time.Time{})
+	assert.True(t, ok)
+	assert.Equal(t, float64(1), rate)
+
+	// the rate is computed over the window whatever the interval between the evaluations
+	rate, ok = store.rate(key, 190, now.Add(70*time.Second), time.Time{})
+	assert.True(t, ok)
+	assert.InDelta(t, 90.0/70, rate, 0.001)
+	rate, ok = store.rate(key, 220, now.Add(90*time.Second), time.Time{})
+	assert.True(t, ok)
+	assert.Equal(t, float64(1.5), rate)
+	assert.Len(t, store.samples[key], 4)
+
+	// the window starts over once the counter is reset
+	_, ok = store.rate(key, 10, now.Add(100*time.Second), time.Time{})
+	assert.False(t, ok)
+	assert.Len(t, store.samples[key], 1)
+
+	// the counters which aren't sampled anymore are dropped
+	store.expire(now.Add(100*time.Second + time.Minute + counterRateTTL))
+	assert.Len(t, store.samples, 1)
+	store.expire(now.Add(101*time.Second + time.Minute + counterRateTTL))
+	assert.Empty(t, store.samples)
+}
+
+func TestCounterRateStoreStartedAt(t *testing.T) {
+	store := newCounterRateStore[envoyCounterKey]()
+	key := envoyCounterKey{pod: types.UID("api-2"), cluster: "backend", rateWindow: time.Minute}
+	now := time.Now()
+
+	// a counter started within the window counts from its start, it started from 0
+	rate, ok := store.rate(key, 20, now, now.Add(-10*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, float64(2), rate)
+	rate, ok = store.rate(key, 80, now.Add(50*time.Second), now.Add(-10*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, float64(4.0/3), rate)
+	// and over the window once it has been scraped for it
+	rate, ok = store.rate(key, 140, now.Add(time.Minute), now.Add(-10*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, float64(2), rate)
+
+	// the counters started before the window wait for it
+	other := envoyCounterKey{pod: types.UID("api-3"), cluster: "backend", rateWindow: time.Minute}
+	_, ok = store.rate(other, 1000, now, now.Add(-time.Hour))
+	assert.False(t, ok)
+
+	// the samples taken out of order by the scalers sharing the counter count as the newest one
+	rate, ok = store.rate(key, 70, now.Add(50*time.Second), now.Add(-10*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, float64(2), rate)
+	assert.Len(t, store.samples[key], 3)
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	url_pkg "net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+const (
+	// metricsBackendMetricsAPI reads the usage of the pods from the metrics API, served by metrics-server
+	metricsBackendMetricsAPI = "metrics-api"
+	// metricsBackendPrometheus reads it from the cAdvisor metrics scraped by Prometheus
+	metricsBackendPrometheus = "prometheus"
+
+	// prometheusRateWindow is the window of the rates of the cAdvisor counters
+	prometheusRateWindow = "2m"
+)
+
+// prometheusResourceUsageQueries are the queries of the usage of the containers by resource, their %s are the label
+// matchers of the containers of the pods. The cpu is in cores, the memory and the ephemeral storage in bytes.
+var prometheusResourceUsageQueries = map[corev1.ResourceName]string{
+	corev1.ResourceCPU:              `sum by (pod, container) (rate(container_cpu_usage_seconds_total{%s}[` + prometheusRateWindow + `]))`,
+	corev1.ResourceMemory:           `sum by (pod, container) (container_memory_working_set_bytes{%s})`,
+	corev1.ResourceEphemeralStorage: `sum by (pod, container) (container_fs_usage_bytes{%s})`,
+}
+
+// prometheusThrottlingQuery is the query of the ratio of the CFS periods the containers of the pods were throttled
+// in, by pod, its %s are the label matchers of the containers
+const prometheusThrottlingQuery = `sum by (pod) (rate(container_cpu_cfs_throttled_periods_total{%[1]s}[` + prometheusRateWindow + `]))` +
+	` / sum by (pod) (rate(container_cpu_cfs_periods_total{%[1]s}[` + prometheusRateWindow + `]))`
+
+// prometheusVectorResult is the result of an instant query of Prometheus returning a vector
+type prometheusVectorResult struct {
+	Status string `json:"status"`
+
+	Data struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// prometheusSample is a sample of a vector returned by Prometheus
+type prometheusSample struct {
+	labels map[string]string
+	value  float64
+}
+
+// getPrometheusPodMetricsList returns the usage of the containers of the pods read from Prometheus, as the PodMetrics
+// the metrics API would return
+func (s *cpuMemoryScaler) getPrometheusPodMetricsList(ctx context.Context, podList *corev1.PodList) (*v1beta1.PodMetricsList, error) {
+	query, ok := prometheusResourceUsageQueries[s.resourceName]
+	if !ok {
+		return nil, fmt.Errorf("the usage of %s can't be read from prometheus", s.resourceName)
+	}
+	podMetricsList := &v1beta1.PodMetricsList{}
+	if len(podList.Items) == 0 {
+		return podMetricsList, nil
+	}
+
+	samples, err := queryPrometheusVector(ctx, s.httpClient, s.metadata.PrometheusURL, fmt.Sprintf(query, getPrometheusContainersMatchers(s.metadata.Namespace, podList)))
+	if err != nil {
+		return nil, err
+	}
+
+	podsMetrics := map[string]*v1beta1.PodMetrics{}
+	for _, sample := range samples {
+		podName, containerName := sample.labels["pod"], sample.labels["container"]
+		podMetrics, found := podsMetrics[podName]
+		if !found {
+			podMetrics = &v1beta1.PodMetrics{ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: s.metadata.Namespace}}
+			podsMetrics[podName] = podMetrics
+		}
+		var quantity *resource.Quantity
+		if s.resourceName == corev1.ResourceCPU {
+			quantity = resource.NewMilliQuantity(int64(sample.value*1000), resource.DecimalSI)
+		} else {
+			quantity = resource.NewQuantity(int64(sample.value), resource.BinarySI)
+		}
+		podMetrics.Containers = append(podMetrics.Containers, v1beta1.ContainerMetrics{
+			Name:  containerName,
+			Usage: corev1.ResourceList{s.resourceName: *quantity},
+		})
+	}
+	for _, pod := range podList.Items {
+		if podMetrics, found := podsMetrics[pod.Name]; found {
+			podMetricsList.Items = append(podMetricsList.Items, *podMetrics)
+		}
+	}
+	return podMetricsList, nil
+}
+
+// getThrottlingRatio returns the percentage of the CFS periods the containers of the pods were throttled in,
+// aggregated over the pods, and whether a pod was found
+func (s *cpuMemoryScaler) getThrottlingRatio(ctx context.Context) (int64, bool, error) {
+	podList, _, err := s.getPodList(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	countedPods := &corev1.PodList{}
+	for _, pod := range podList.Items {
+		if s.isPodCounted(&pod) {
+			countedPods.Items = append(countedPods.Items, pod)
+		}
+	}
+	if len(countedPods.Items) == 0 {
+		return 0, false, nil
+	}
+
+	matchers := getPrometheusContainersMatchers(s.metadata.Namespace, countedPods)
+	if s.metadata.containerPattern != nil {
+		matchers += fmt.Sprintf(`,container=~"%s"`, strings.ReplaceAll(s.metadata.containerPattern.String(), `\`, `\\`))
+	}
+	samples, err := queryPrometheusVector(ctx, s.httpClient, s.metadata.PrometheusURL, fmt.Sprintf(prometheusThrottlingQuery, matchers))
+	if err != nil {
+		return 0, false, err
+	}
+
+	aggregator := &podValuesAggregator{aggregation: s.metadata.Aggregation}
+	for _, sample := range samples {
+		aggregator.add(int64(sample.value * 100))
+	}
+	return aggregator.result(), aggregator.count > 0, nil
+}
+
+// getPrometheusContainersMatchers returns the label matchers of the containers of the pods, without the pause
+// containers and the cgroups of the whole pods
+func getPrometheusContainersMatchers(namespace string, podList *corev1.PodList) string {
+	podNames := make([]string, 0, len(podList.Items))
+	for _, pod := range podList.Items {
+		// the backslashes are escaped in the string literals of PromQL
+		podNames = append(podNames, strings.ReplaceAll(regexp.QuoteMeta(pod.Name), `\`, `\\`))
+	}
+	return fmt.Sprintf(`namespace=%q,pod=~"%s",container!="",container!="POD"`, namespace, strings.Join(podNames, "|"))
+}
+
+// queryPrometheusVector executes the instant query on Prometheus and returns the samples of the vector it returns
+func queryPrometheusVector(ctx context.Context, httpClient *http.Client, serverURL, query string) ([]prometheusSample, error) {
+	url := fmt.Sprintf("%s/api/v1/query?query=%s", strings.TrimSuffix(serverURL, "/"), url_pkg.QueryEscape(query))
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if !(r.StatusCode >= 200 && r.StatusCode <= 299) {
+		return nil, fmt.Errorf("prometheus query api returned error. status: %d response: %s", r.StatusCode, string(b))
+	}
+
+	var result prometheusVectorResult
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil, err
+	}
+	if result.Data.ResultType != "vector" {
+		return nil, fmt.Errorf("prometheus query %s returned a %s instead of a vector", query, result.Data.ResultType)
+	}
+
+	samples := make([]prometheusSample, 0, len(result.Data.Result))
+	for _, item := range result.Data.Result {
+		if len(item.Value) < 2 {
+			return nil, fmt.Errorf("prometheus query %s didn't return enough values", query)
+		}
+		str, ok := item.Value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("prometheus query %s returned an invalid value %v", query, item.Value[1])
+		}
+		value, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error converting prometheus value %s: %w", str, err)
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		samples = append(samples, prometheusSample{labels: item.Metric, value: value})
+	}
+	return samples, nil
+}
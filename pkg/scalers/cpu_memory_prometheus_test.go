@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const prometheusContainersUsageResponse = `{"status":"success","data":{"resultType":"vector","result":[
+	{"metric":{"pod":"web-1","container":"app"},"value":[1700000000,"0.25"]},
+	{"metric":{"pod":"web-1","container":"istio-proxy"},"value":[1700000000,"0.05"]},
+	{"metric":{"pod":"web-2","container":"app"},"value":[1700000000,"NaN"]},
+	{"metric":{"pod":"other","container":"app"},"value":[1700000000,"1"]}
+]}}`
+
+func TestGetPrometheusPodMetricsList(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/query", r.URL.Path)
+		query = r.URL.Query().Get("query")
+		_, _ = w.Write([]byte(prometheusContainersUsageResponse))
+	}))
+	defer server.Close()
+
+	s := &cpuMemoryScaler{
+		metadata:     &cpuMemoryMetadata{Namespace: "test-namespace", MetricsBackend: metricsBackendPrometheus, PrometheusURL: server.URL + "/"},
+		resourceName: v1.ResourceCPU,
+		httpClient:   http.DefaultClient,
+	}
+	podList := &v1.PodList{Items: []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web.3"}},
+	}}
+	podMetricsList, err := s.getPrometheusPodMetricsList(context.Background(), podList)
+	assert.NoError(t, err)
+	assert.Equal(t, `sum by (pod, container) (rate(container_cpu_usage_seconds_total{namespace="test-namespace",pod=~"web-1|web-2|web\\.3",container!="",container!="POD"}[2m]))`, query)
+
+	// only the pods listed are returned, without the values which aren't numbers
+	assert.Len(t, podMetricsList.Items, 1)
+	assert.Equal(t, "web-1", podMetricsList.Items[0].Name)
+	assert.Equal(t, int64(300), getPodResourceValueInMillis(&podMetricsList.Items[0], "cpu"))
+
+	// the usage of the memory is its working set
+	s.resourceName = v1.ResourceMemory
+	_, err = s.getPrometheusPodMetricsList(context.Background(), podList)
+	assert.NoError(t, err)
+	assert.Contains(t, query, "container_memory_working_set_bytes")
+
+	// no pods aren't queried
+	query = ""
+	podMetricsList, err = s.getPrometheusPodMetricsList(context.Background(), &v1.PodList{})
+	assert.NoError(t, err)
+	assert.Empty(t, podMetricsList.Items)
+	assert.Equal(t, "", query)
+}
+
+func TestGetPodMetricsListFallback(t *testing.T) {
+	defer func(podMetricsCache *podMetricsCache) {
+		sharedPodMetricsCache = podMetricsCache
+	}(sharedPodMetricsCache)
+	sharedPodMetricsCache = newPodMetricsCache(time.Hour, func(context.Context, string, labels.Selector) (*metricsv1beta1.PodMetricsList, error) {
+		return nil, errors.New("the server could not find the requested resource")
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(prometheusContainersUsageResponse))
+	}))
+	defer server.Close()
+
+	podList := &v1.PodList{Items: []v1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "web-1"}}}}
+	selector := labels.SelectorFromSet(selectLabels)
+	s := &cpuMemoryScaler{
+		metadata:     &cpuMemoryMetadata{Namespace: "test-namespace", MetricsBackend: metricsBackendMetricsAPI},
+		resourceName: v1.ResourceCPU,
+		httpClient:   http.DefaultClient,
+	}
+
+	// the errors of the metrics API are returned without prometheusURL
+	_, err := s.getPodMetricsList(context.Background(), podList, selector, 0)
+	assert.Error(t, err)
+
+	// otherwise the usage is read from prometheus
+	s.metadata.PrometheusURL = server.URL
+	podMetricsList, err := s.getPodMetricsList(context.Background(), podList, selector, 0)
+	assert.NoError(t, err)
+	assert.Len(t, podMetricsList.Items, 1)
+}
+
+func TestQueryPrometheusVectorErrors(t *testing.T) {
+	for response, status := range map[string]int{
+		`{"status":"error","error":"bad query"}`:                                                    http.StatusBadRequest,
+		`{"status":"success","data":{"resultType":"scalar","result":[1700000000,"1"]}}`:             http.StatusOK,
+		`{"status":"success","data":{"resultType":"vector","result":[{"value":[1700000000]}]}}`:     http.StatusOK,
+		`{"status":"success","data":{"resultType":"vector","result":[{"value":[1700000000,"x"]}]}}`: http.StatusOK,
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(response))
+		}))
+		_, err := queryPrometheusVector(context.Background(), http.DefaultClient, server.URL, "up")
+		assert.Error(t, err, response)
+		server.Close()
+	}
+}
+
+func TestGetThrottlingRatio(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("query")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"pod":"web-1"},"value":[1700000000,"0.1"]},
+			{"metric":{"pod":"web-2"},"value":[1700000000,"0.6"]}
+		]}}`))
+	}))
+	defer server.Close()
+
+	deployment := createDeployment()
+	running := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "test-namespace", Labels: selectLabels},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+	pending := running.DeepCopy()
+	pending.Name = "web-2"
+	pending.Status.Phase = v1.PodPending
+	s := &cpuMemoryScaler{
+		metadata: &cpuMemoryMetadata{
+			Namespace:        "test-namespace",
+			ScaleTargetName:  deployment.Name,
+			ScaleTargetKind:  "Deployment",
+			PrometheusURL:    server.URL,
+			Mode:             modeThrottling,
+			Aggregation:      aggregationMax,
+			containerPattern: regexp.MustCompile(`^(?:app\.v1)$`),
+		},
+		resourceName: v1.ResourceCPU,
+		kubeClient:   fake.NewClientBuilder().WithObjects(deployment, running, pending).Build(),
+		httpClient:   http.DefaultClient,
+	}
+
+	// only the pods running are queried
+	throttlingRatio, found, err := s.getThrottlingRatio(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, int64(60), throttlingRatio)
+	assert.Equal(t, `sum by (pod) (rate(container_cpu_cfs_throttled_periods_total{namespace="test-namespace",pod=~"web-1",container!="",container!="POD",container=~"^(?:app\\.v1)$"}[2m]))`+
+		` / sum by (pod) (rate(container_cpu_cfs_periods_total{namespace="test-namespace",pod=~"web-1",container!="",container!="POD",container=~"^(?:app\\.v1)$"}[2m]))`, query)
+
+	// without pods running there's no throttling
+	s.kubeClient = fake.NewClientBuilder().WithObjects(deployment, pending).Build()
+	query = ""
+	_, found, err = s.getThrottlingRatio(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, found)
+	assert.Equal(t, "", query)
+}
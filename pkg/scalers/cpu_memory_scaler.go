@@ -3,48 +3,141 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	v2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/rest"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
-	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
+const (
+	// resourceDivisorRequests computes the utilization against the requests of the containers, like the HPA
+	resourceDivisorRequests = "requests"
+	// resourceDivisorLimits computes the utilization against the limits of the containers instead, the HPA
+	// still scales the ScaledObjects against their requests
+	resourceDivisorLimits = "limits"
+
+	// aggregationAvg averages the values of the pods, like the HPA
+	aggregationAvg = "avg"
+	aggregationMax = "max"
+	aggregationMin = "min"
+
+	// defaultSampleInterval is the resolution of metrics-server, the samples listed more often are the same
+	defaultSampleInterval = 15 * time.Second
+
+	// modeUsage activates on the usage of the resource
+	modeUsage = "usage"
+	// modeThrottling activates on the percentage of the CFS periods the containers were throttled in
+	modeThrottling = "throttling"
+)
+
+// aggregationPercentiles are the percentiles the values of the pods can be aggregated to
+var aggregationPercentiles = map[string]float64{"p90": 90, "p95": 95, "p99": 99}
+
+// containerNameRegexp matches the names of the containers, the containerNames not matching it are regular expressions
+var containerNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
 type cpuMemoryScaler struct {
 	metadata     *cpuMemoryMetadata
 	resourceName v1.ResourceName
 	logger       logr.Logger
 	kubeClient   client.Client
+	// httpClient queries Prometheus, it's nil without prometheusURL
+	httpClient *http.Client
+	// sampler lists the samples of the PodMetrics in the background, it's nil with a single sample
+	sampler *podMetricsSampler
+}
+
+// podMetricsSampler keeps the samples of the PodMetrics of the pods listed in the background every sampleInterval,
+// from the first poll of the scaler until it's closed
+type podMetricsSampler struct {
+	lock    sync.Mutex
+	samples []podMetricsSample
+	cancel  context.CancelFunc
+	closed  bool
+}
+
+type podMetricsSample struct {
+	listed         time.Time
+	podMetricsList *v1beta1.PodMetricsList
 }
 
 type cpuMemoryMetadata struct {
-	Type                         v2.MetricTargetType
-	AverageValue                 *resource.Quantity
-	AverageUtilization           *int32
-	ContainerName                string
+	Type               v2.MetricTargetType
+	AverageValue       *resource.Quantity
+	AverageUtilization *int32
+	// ContainerName is the name, the regular expression or the comma separated list of them of the containers
+	ContainerName   string
+	ResourceDivisor string
+	// Aggregation is how the values of the pods are aggregated for the activation and the metric value,
+	// the HPA still scales the ScaledObjects on their average
+	Aggregation string
+	// ExcludeTerminatingPods excludes the pods being deleted, RequireReady the ones which aren't ready
+	ExcludeTerminatingPods bool
+	RequireReady           bool
+	// IncludeSidecarContainers counts the sidecar containers, the restartable init containers, with the containers
+	IncludeSidecarContainers bool
+	// ActivationTrendWindow is the number of consecutive polls the resource has to be above the activation value
+	// to activate the scale target
+	ActivationTrendWindow int
+	// SkipWhenNodePressure suppresses the activation while a node of the pods is under memory or disk pressure
+	SkipWhenNodePressure bool
+	// SampleCount is the number of samples of the PodMetrics averaged on each poll, SampleInterval the interval they're
+	// listed at in the background
+	SampleCount    int
+	SampleInterval time.Duration
+	// MetricsBackend is where the usage of the pods is read from, the metrics API falls back to Prometheus on its
+	// errors if the PrometheusURL is given
+	MetricsBackend string
+	PrometheusURL  string
+	// Mode is what the activation is based on, the usage of the resource, or the throttling of the cpu read from
+	// Prometheus, which can be heavy while the utilization is low. The HPA still scales on the usage.
+	Mode                         string
+	ActivationThrottlingRatio    int64
 	ActivationAverageValue       *resource.Quantity
 	ActivationAverageUtilization *int32
 	ScalableObjectType           string
+	ScalableObjectName           string
 	Namespace                    string
 	ScaleTargetName              string
 	ScaleTargetKind              string
+	ScaleTargetAPIVersion        string
+
+	// containerNames are the names of the containers if the containerName only lists names, the HPA scales on them
+	containerNames []string
+	// containerPattern matches the containers of the containerName
+	containerPattern *regexp.Regexp
+	triggerIndex     int
+	// asMetricSource is whether the values are consumed by the scaling modifiers of the ScaledObject
+	asMetricSource bool
 }
 
-// NewCPUMemoryScaler creates a new cpuMemoryScaler
+// NewCPUMemoryScaler creates a new cpuMemoryScaler, it's the scaler of the cpu, the memory and the ephemeral
+// storage, whose usage is read from the PodMetrics reporting it
 func NewCPUMemoryScaler(resourceName v1.ResourceName, config *scalersconfig.ScalerConfig, kubeClient client.Client) (Scaler, error) {
 	logger := InitializeLogger(config, "cpu_memory_scaler")
 
@@ -53,15 +146,27 @@ func NewCPUMemoryScaler(resourceName v1.ResourceName, config *scalersconfig.Scal
 		return nil, fmt.Errorf("error parsing %s metadata: %w", resourceName, parseErr)
 	}
 
+	var httpClient *http.Client
+	if meta.PrometheusURL != "" {
+		httpClient = kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	}
+
+	var sampler *podMetricsSampler
+	if meta.SampleCount > 1 {
+		sampler = &podMetricsSampler{}
+	}
+
 	return &cpuMemoryScaler{
 		metadata:     meta,
 		resourceName: resourceName,
 		logger:       logger,
 		kubeClient:   kubeClient,
+		httpClient:   httpClient,
+		sampler:      sampler,
 	}, nil
 }
 
-func getScaleTarget(scalableObjectName, scalableObjectNamespace string, kubeClient client.Client) (string, string, error) {
+func getScaleTarget(scalableObjectName, scalableObjectNamespace string, kubeClient client.Client) (*kedav1alpha1.ScaleTarget, error) {
 	scaledObject := &kedav1alpha1.ScaledObject{}
 	err := kubeClient.Get(context.Background(), types.NamespacedName{
 		Name:      scalableObjectName,
@@ -69,14 +174,14 @@ func getScaleTarget(scalableObjectName, scalableObjectNamespace string, kubeClie
 	}, scaledObject)
 
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
 	if scaledObject.Spec.ScaleTargetRef == nil {
-		return "", "", fmt.Errorf("scaled object %s has no scale target ref", scalableObjectName)
+		return nil, fmt.Errorf("scaled object %s has no scale target ref", scalableObjectName)
 	}
 
-	return scaledObject.Spec.ScaleTargetRef.Name, scaledObject.Spec.ScaleTargetRef.Kind, nil
+	return scaledObject.Spec.ScaleTargetRef, nil
 }
 
 func parseResourceMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger, kubeClient client.Client) (*cpuMemoryMetadata, error) {
@@ -130,57 +235,261 @@ func parseResourceMetadata(config *scalersconfig.ScalerConfig, logger logr.Logge
 
 	if value, ok = config.TriggerMetadata["containerName"]; ok && value != "" {
 		meta.ContainerName = value
+		names, pattern, err := parseContainerName(value)
+		if err != nil {
+			return nil, err
+		}
+		meta.containerNames = names
+		meta.containerPattern = pattern
+	}
+
+	meta.ResourceDivisor = resourceDivisorRequests
+	if value, ok = config.TriggerMetadata["resourceDivisor"]; ok && value != "" {
+		switch value {
+		case resourceDivisorRequests, resourceDivisorLimits:
+			meta.ResourceDivisor = value
+		default:
+			return nil, fmt.Errorf("unsupported resourceDivisor %q, allowed values are '%s' or '%s'", value, resourceDivisorRequests, resourceDivisorLimits)
+		}
+	}
+
+	meta.Aggregation = aggregationAvg
+	if value, ok = config.TriggerMetadata["aggregation"]; ok && value != "" {
+		if _, isPercentile := aggregationPercentiles[value]; !isPercentile && value != aggregationAvg && value != aggregationMax && value != aggregationMin {
+			return nil, fmt.Errorf("unsupported aggregation %q, allowed values are 'avg', 'max', 'min', 'p90', 'p95' or 'p99'", value)
+		}
+		meta.Aggregation = value
+	}
+
+	if value, ok = config.TriggerMetadata["excludeTerminatingPods"]; ok && value != "" {
+		excludeTerminatingPods, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid excludeTerminatingPods: %w", err)
+		}
+		meta.ExcludeTerminatingPods = excludeTerminatingPods
+	}
+
+	if value, ok = config.TriggerMetadata["requireReady"]; ok && value != "" {
+		requireReady, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid requireReady: %w", err)
+		}
+		meta.RequireReady = requireReady
+	}
+
+	meta.IncludeSidecarContainers = true
+	if value, ok = config.TriggerMetadata["includeSidecarContainers"]; ok && value != "" {
+		includeSidecarContainers, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid includeSidecarContainers: %w", err)
+		}
+		meta.IncludeSidecarContainers = includeSidecarContainers
+	}
+
+	if value, ok = config.TriggerMetadata["activationTrendWindow"]; ok && value != "" {
+		activationTrendWindow, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid activationTrendWindow: %w", err)
+		}
+		if activationTrendWindow < 0 {
+			return nil, fmt.Errorf("activationTrendWindow must not be negative, got %d", activationTrendWindow)
+		}
+		meta.ActivationTrendWindow = activationTrendWindow
+	}
+
+	if value, ok = config.TriggerMetadata["skipWhenNodePressure"]; ok && value != "" {
+		skipWhenNodePressure, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid skipWhenNodePressure: %w", err)
+		}
+		meta.SkipWhenNodePressure = skipWhenNodePressure
+	}
+
+	meta.SampleCount = 1
+	if value, ok = config.TriggerMetadata["sampleCount"]; ok && value != "" {
+		sampleCount, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sampleCount: %w", err)
+		}
+		if sampleCount < 1 {
+			return nil, fmt.Errorf("sampleCount must be at least 1, got %d", sampleCount)
+		}
+		meta.SampleCount = sampleCount
+	}
+
+	meta.SampleInterval = defaultSampleInterval
+	if value, ok = config.TriggerMetadata["sampleInterval"]; ok && value != "" {
+		sampleInterval, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sampleInterval: %w", err)
+		}
+		if sampleInterval <= 0 {
+			return nil, fmt.Errorf("sampleInterval must be positive, got %v", sampleInterval)
+		}
+		meta.SampleInterval = sampleInterval
+	}
+
+	meta.MetricsBackend = metricsBackendMetricsAPI
+	if value, ok = config.TriggerMetadata["metricsBackend"]; ok && value != "" {
+		switch value {
+		case metricsBackendMetricsAPI, metricsBackendPrometheus:
+			meta.MetricsBackend = value
+		default:
+			return nil, fmt.Errorf("unsupported metricsBackend %q, allowed values are '%s' or '%s'", value, metricsBackendMetricsAPI, metricsBackendPrometheus)
+		}
+	}
+	meta.PrometheusURL = config.TriggerMetadata["prometheusURL"]
+	if meta.MetricsBackend == metricsBackendPrometheus && meta.PrometheusURL == "" {
+		return nil, fmt.Errorf("no prometheusURL given for the prometheus metricsBackend")
+	}
+
+	meta.Mode = modeUsage
+	if value, ok = config.TriggerMetadata["mode"]; ok && value != "" {
+		switch value {
+		case modeUsage:
+		case modeThrottling:
+			if config.TriggerType != "cpu" {
+				return nil, fmt.Errorf("the %s mode is only supported by the cpu trigger", modeThrottling)
+			}
+			if meta.PrometheusURL == "" {
+				return nil, fmt.Errorf("no prometheusURL given for the %s mode", modeThrottling)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported mode %q, allowed values are '%s' or '%s'", value, modeUsage, modeThrottling)
+		}
+		meta.Mode = value
+	}
+	if value, ok = config.TriggerMetadata["activationThrottlingRatio"]; ok && value != "" {
+		if meta.Mode != modeThrottling {
+			return nil, fmt.Errorf("activationThrottlingRatio is only supported by the %s mode", modeThrottling)
+		}
+		activationThrottlingRatio, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid activationThrottlingRatio: %w", err)
+		}
+		if activationThrottlingRatio < 0 || activationThrottlingRatio > 100 {
+			return nil, fmt.Errorf("activationThrottlingRatio must be a percentage, got %d", activationThrottlingRatio)
+		}
+		meta.ActivationThrottlingRatio = activationThrottlingRatio
 	}
 
 	if config.ScalableObjectType == "ScaledObject" {
-		scaleTargetName, scaleTargetKind, err := getScaleTarget(config.ScalableObjectName, config.ScalableObjectNamespace, kubeClient)
+		scaleTarget, err := getScaleTarget(config.ScalableObjectName, config.ScalableObjectNamespace, kubeClient)
 		if err != nil {
 			return nil, err
 		}
 
-		meta.ScaleTargetName = scaleTargetName
-		meta.ScaleTargetKind = scaleTargetKind
+		meta.ScaleTargetName = scaleTarget.Name
+		meta.ScaleTargetKind = scaleTarget.Kind
+		meta.ScaleTargetAPIVersion = scaleTarget.APIVersion
 	}
 
 	meta.ScalableObjectType = config.ScalableObjectType
+	meta.ScalableObjectName = config.ScalableObjectName
 	meta.Namespace = config.ScalableObjectNamespace
+	meta.triggerIndex = config.TriggerIndex
+	meta.asMetricSource = config.AsMetricSource
 
 	return meta, nil
 }
 
-// Close no need for cpuMemory scaler
+// parseContainerName returns the names of the containers listed, or nil if some are regular expressions, and the
+// pattern matching all the containers. Each item of the list matches the whole name of the containers.
+func parseContainerName(containerName string) ([]string, *regexp.Regexp, error) {
+	var names, patterns []string
+	isList := true
+	for _, item := range strings.Split(containerName, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if containerNameRegexp.MatchString(item) {
+			names = append(names, item)
+			patterns = append(patterns, regexp.QuoteMeta(item))
+			continue
+		}
+		if _, err := regexp.Compile(item); err != nil {
+			return nil, nil, fmt.Errorf("invalid containerName %q: %w", item, err)
+		}
+		isList = false
+		patterns = append(patterns, "(?:"+item+")")
+	}
+	if len(patterns) == 0 {
+		return nil, nil, fmt.Errorf("no containerName given")
+	}
+	if !isList {
+		names = nil
+	}
+	return names, regexp.MustCompile("^(?:" + strings.Join(patterns, "|") + ")$"), nil
+}
+
+// Close stops the sampling of the PodMetrics
 func (s *cpuMemoryScaler) Close(context.Context) error {
+	if s.sampler != nil {
+		s.sampler.stop()
+	}
 	return nil
 }
 
-// GetMetricSpecForScaling returns the metric spec for the HPA
+// GetMetricSpecForScaling returns the metric spec for the HPA, the HPA scales on every container listed by the
+// containerName, or on the whole pods if it has regular expressions. The ScaledJobs, which have no HPA, scale on
+// the external metric of the average value or utilization instead, so does the ephemeral storage, which the
+// resource metrics of the HPA don't serve. The ScaledObjects with scaling modifiers read it too, their HPA
+// scales on the composite metric only.
 func (s *cpuMemoryScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
-	var metricSpec v2.MetricSpec
+	if s.metadata.ScalableObjectType == "ScaledJob" || s.resourceName == corev1.ResourceEphemeralStorage || s.metadata.asMetricSource {
+		return []v2.MetricSpec{s.getExternalMetricSpec()}
+	}
 
-	if s.metadata.ContainerName != "" {
-		containerCPUMemoryMetric := &v2.ContainerResourceMetricSource{
-			Name: s.resourceName,
-			Target: v2.MetricTarget{
-				Type:               s.metadata.Type,
-				AverageUtilization: s.metadata.AverageUtilization,
-				AverageValue:       s.metadata.AverageValue,
-			},
-			Container: s.metadata.ContainerName,
-		}
-		metricSpec = v2.MetricSpec{ContainerResource: containerCPUMemoryMetric, Type: v2.ContainerResourceMetricSourceType}
-	} else {
-		cpuMemoryMetric := &v2.ResourceMetricSource{
-			Name: s.resourceName,
-			Target: v2.MetricTarget{
-				Type:               s.metadata.Type,
-				AverageUtilization: s.metadata.AverageUtilization,
-				AverageValue:       s.metadata.AverageValue,
-			},
+	target := v2.MetricTarget{
+		Type:               s.metadata.Type,
+		AverageUtilization: s.metadata.AverageUtilization,
+		AverageValue:       s.metadata.AverageValue,
+	}
+
+	if len(s.metadata.containerNames) > 0 {
+		var metricSpecs []v2.MetricSpec
+		for _, containerName := range s.metadata.containerNames {
+			containerCPUMemoryMetric := &v2.ContainerResourceMetricSource{
+				Name:      s.resourceName,
+				Target:    target,
+				Container: containerName,
+			}
+			metricSpecs = append(metricSpecs, v2.MetricSpec{ContainerResource: containerCPUMemoryMetric, Type: v2.ContainerResourceMetricSourceType})
 		}
-		metricSpec = v2.MetricSpec{Resource: cpuMemoryMetric, Type: v2.ResourceMetricSourceType}
+		return metricSpecs
 	}
 
-	return []v2.MetricSpec{metricSpec}
+	cpuMemoryMetric := &v2.ResourceMetricSource{
+		Name:   s.resourceName,
+		Target: target,
+	}
+	return []v2.MetricSpec{{Resource: cpuMemoryMetric, Type: v2.ResourceMetricSourceType}}
+}
+
+// getExternalMetricSpec returns the spec of the external metric of the average value or utilization. The metric
+// is already the value of a pod, the HPA of a ScaledObject scales on the ephemeral storage with a Value target, it
+// then multiplies the ratio of the metric to the target by the replicas as it does for the resource metrics,
+// where an AverageValue target would divide the value of a pod by the replicas.
+func (s *cpuMemoryScaler) getExternalMetricSpec() v2.MetricSpec {
+	var targetValue float64
+	switch s.metadata.Type {
+	case v2.AverageValueMetricType:
+		targetValue = s.metadata.AverageValue.AsApproximateFloat64()
+	case v2.UtilizationMetricType:
+		targetValue = float64(*s.metadata.AverageUtilization)
+	}
+	targetType := v2.AverageValueMetricType
+	if s.metadata.ScalableObjectType != "ScaledJob" && !s.metadata.asMetricSource {
+		targetType = v2.ValueMetricType
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, string(s.resourceName)),
+		},
+		Target: GetMetricTargetMili(targetType, targetValue),
+	}
+	return v2.MetricSpec{External: externalMetric, Type: externalMetricType}
 }
 
 func calculateAverage(total *resource.Quantity, count int64) *resource.Quantity {
@@ -198,22 +507,83 @@ func calculateAverage(total *resource.Quantity, count int64) *resource.Quantity
 	return resource.NewScaledQuantity(averageNanoValue, resource.Nano)
 }
 
+// podValuesAggregator aggregates the values of the pods as they're read, only the percentiles keep them all
+type podValuesAggregator struct {
+	aggregation string
+	count       int
+	sum         int64
+	min         int64
+	max         int64
+	values      []int64
+}
+
+func (a *podValuesAggregator) add(value int64) {
+	if a.count == 0 || value < a.min {
+		a.min = value
+	}
+	if a.count == 0 || value > a.max {
+		a.max = value
+	}
+	a.sum += value
+	a.count++
+	if _, isPercentile := aggregationPercentiles[a.aggregation]; isPercentile {
+		a.values = append(a.values, value)
+	}
+}
+
+// result returns the aggregation of the values added, the percentiles are the nearest ranks
+func (a *podValuesAggregator) result() int64 {
+	if a.count == 0 {
+		return 0
+	}
+	switch a.aggregation {
+	case aggregationMax:
+		return a.max
+	case aggregationMin:
+		return a.min
+	}
+	if percentile, isPercentile := aggregationPercentiles[a.aggregation]; isPercentile {
+		sort.Slice(a.values, func(i, j int) bool { return a.values[i] < a.values[j] })
+		rank := int(math.Ceil(percentile / 100 * float64(len(a.values))))
+		return a.values[rank-1]
+	}
+	return a.sum / int64(a.count)
+}
+
+// getAverageValue returns the value of the resource of the pods averaged over the samples
 func (s *cpuMemoryScaler) getAverageValue(ctx context.Context, metricName string) (*resource.Quantity, error) {
 	podList, labelSelector, err := s.getPodList(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	podMetricsList, err := s.getPodMetricsList(ctx, labelSelector)
+	podMetricsSamples, err := s.getPodMetricsSamples(ctx, podList, labelSelector)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(podMetricsSamples) == 1 {
+		return s.getSampleValue(podList, podMetricsSamples[0], metricName)
+	}
+	totalValue := &resource.Quantity{}
+	for _, podMetricsList := range podMetricsSamples {
+		value, err := s.getSampleValue(podList, podMetricsList, metricName)
+		if err != nil {
+			return nil, err
+		}
+		totalValue.Add(*value)
+	}
+	return calculateAverage(totalValue, int64(len(podMetricsSamples))), nil
+}
+
+// getSampleValue returns the value of the resource of the pods aggregated from a sample of their PodMetrics
+func (s *cpuMemoryScaler) getSampleValue(podList *corev1.PodList, podMetricsList *v1beta1.PodMetricsList, metricName string) (*resource.Quantity, error) {
 	totalValue := &resource.Quantity{}
 	podCount := 0
+	aggregator := &podValuesAggregator{aggregation: s.metadata.Aggregation}
 
 	for _, pod := range podList.Items {
-		if pod.Status.Phase != corev1.PodRunning {
+		if !s.isPodCounted(&pod) {
 			continue
 		}
 
@@ -221,14 +591,17 @@ func (s *cpuMemoryScaler) getAverageValue(ctx context.Context, metricName string
 		if podMetrics == nil {
 			continue
 		}
+		if !s.metadata.IncludeSidecarContainers {
+			podMetrics = withoutSidecarContainers(podMetrics, &pod)
+		}
 
 		var metricValue *resource.Quantity
-		if s.metadata.ContainerName != "" {
-			containerMetrics := getContainerMetrics(podMetrics, s.metadata.ContainerName)
-			if containerMetrics == nil {
+		if s.metadata.containerPattern != nil {
+			containersMetrics := getContainersMetrics(podMetrics, s.metadata.containerPattern)
+			if len(containersMetrics) == 0 {
 				continue
 			}
-			metricValue = getResourceValue(containerMetrics, metricName)
+			metricValue = getContainersResourceValue(containersMetrics, metricName)
 		} else {
 			metricValue = getPodResourceValue(podMetrics, metricName)
 		}
@@ -238,6 +611,7 @@ func (s *cpuMemoryScaler) getAverageValue(ctx context.Context, metricName string
 		}
 
 		totalValue.Add(*metricValue)
+		aggregator.add(metricValue.MilliValue())
 		podCount++
 	}
 
@@ -245,26 +619,43 @@ func (s *cpuMemoryScaler) getAverageValue(ctx context.Context, metricName string
 		return nil, fmt.Errorf("no running pods found")
 	}
 
+	if s.metadata.Aggregation != aggregationAvg {
+		return resource.NewMilliQuantity(aggregator.result(), totalValue.Format), nil
+	}
 	averageValue := calculateAverage(totalValue, int64(podCount))
 	return averageValue, nil
 }
 
+// getAverageUtilization returns the utilization of the resource of the pods averaged over the samples
 func (s *cpuMemoryScaler) getAverageUtilization(ctx context.Context, metricName string) (*int32, error) {
 	podList, labelSelector, err := s.getPodList(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	podMetricsList, err := s.getPodMetricsList(ctx, labelSelector)
+	podMetricsSamples, err := s.getPodMetricsSamples(ctx, podList, labelSelector)
 	if err != nil {
 		return nil, err
 	}
 
 	var totalUtilization int64
-	podCount := 0
+	for _, podMetricsList := range podMetricsSamples {
+		utilization, err := s.getSampleUtilization(podList, podMetricsList, metricName)
+		if err != nil {
+			return nil, err
+		}
+		totalUtilization += int64(*utilization)
+	}
+	averageUtilization := int32(totalUtilization / int64(len(podMetricsSamples)))
+	return &averageUtilization, nil
+}
+
+// getSampleUtilization returns the utilization of the resource of the pods aggregated from a sample of their PodMetrics
+func (s *cpuMemoryScaler) getSampleUtilization(podList *corev1.PodList, podMetricsList *v1beta1.PodMetricsList, metricName string) (*int32, error) {
+	aggregator := &podValuesAggregator{aggregation: s.metadata.Aggregation}
 
 	for _, pod := range podList.Items {
-		if pod.Status.Phase != corev1.PodRunning {
+		if !s.isPodCounted(&pod) {
 			continue
 		}
 
@@ -272,18 +663,23 @@ func (s *cpuMemoryScaler) getAverageUtilization(ctx context.Context, metricName
 		if podMetrics == nil {
 			continue
 		}
+		if !s.metadata.IncludeSidecarContainers {
+			podMetrics = withoutSidecarContainers(podMetrics, &pod)
+		}
 
 		var metricValue, capacity int64
-		if s.metadata.ContainerName != "" {
-			containerMetrics := getContainerMetrics(podMetrics, s.metadata.ContainerName)
-			if containerMetrics == nil {
+		if s.metadata.containerPattern != nil {
+			containersMetrics := getContainersMetrics(podMetrics, s.metadata.containerPattern)
+			if len(containersMetrics) == 0 {
 				continue
 			}
-			metricValue = getResourceValueInMillis(containerMetrics, metricName)
-			capacity = getContainerResourceCapacity(&pod, s.metadata.ContainerName, getResourceName(metricName))
+			for _, containerMetrics := range containersMetrics {
+				metricValue += getResourceValueInMillis(&containerMetrics, metricName)
+			}
+			capacity = getContainerResourceCapacity(&pod, s.metadata.containerPattern, getResourceName(metricName), s.metadata.ResourceDivisor, s.metadata.IncludeSidecarContainers)
 		} else {
 			metricValue = getPodResourceValueInMillis(podMetrics, metricName)
-			capacity = getPodResourceCapacity(&pod, getResourceName(metricName))
+			capacity = getPodResourceCapacity(&pod, getResourceName(metricName), s.metadata.ResourceDivisor, s.metadata.IncludeSidecarContainers)
 		}
 
 		if capacity == 0 {
@@ -291,16 +687,39 @@ func (s *cpuMemoryScaler) getAverageUtilization(ctx context.Context, metricName
 		}
 
 		utilization := (metricValue * 100) / capacity
-		totalUtilization += utilization
-		podCount++
+		aggregator.add(utilization)
 	}
 
-	if podCount == 0 {
+	if aggregator.count == 0 {
 		return nil, fmt.Errorf("no running pods found with non-zero capacity")
 	}
 
-	averageUtilization := int32(totalUtilization / int64(podCount))
-	return &averageUtilization, nil
+	utilization := int32(aggregator.result())
+	return &utilization, nil
+}
+
+// isPodCounted returns whether the values of the pod are aggregated, the pods must be running, and depending on
+// the metadata neither terminating nor unready
+func (s *cpuMemoryScaler) isPodCounted(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	if s.metadata.ExcludeTerminatingPods && pod.DeletionTimestamp != nil {
+		return false
+	}
+	if s.metadata.RequireReady && !isPodReady(pod) {
+		return false
+	}
+	return true
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
 }
 
 // Helper functions
@@ -310,17 +729,25 @@ func getResourceValue(containerMetrics *v1beta1.ContainerMetrics, metricName str
 		return containerMetrics.Usage.Cpu()
 	case "memory":
 		return containerMetrics.Usage.Memory()
+	case "ephemeral-storage":
+		return containerMetrics.Usage.StorageEphemeral()
 	default:
 		return nil
 	}
 }
 
 func getPodResourceValue(podMetrics *v1beta1.PodMetrics, metricName string) *resource.Quantity {
+	return getContainersResourceValue(podMetrics.Containers, metricName)
+}
+
+func getContainersResourceValue(containersMetrics []v1beta1.ContainerMetrics, metricName string) *resource.Quantity {
 	var total resource.Quantity
-	for _, container := range podMetrics.Containers {
-		if value := getResourceValue(&container, metricName); value != nil {
-			total.Add(*value)
+	for _, container := range containersMetrics {
+		value := getResourceValue(&container, metricName)
+		if value == nil {
+			return nil
 		}
+		total.Add(*value)
 	}
 	return &total
 }
@@ -331,6 +758,8 @@ func getResourceValueInMillis(containerMetrics *v1beta1.ContainerMetrics, metric
 		return containerMetrics.Usage.Cpu().MilliValue()
 	case "memory":
 		return containerMetrics.Usage.Memory().Value()
+	case "ephemeral-storage":
+		return containerMetrics.Usage.StorageEphemeral().MilliValue()
 	default:
 		return 0
 	}
@@ -350,45 +779,163 @@ func getResourceName(metricName string) corev1.ResourceName {
 		return corev1.ResourceCPU
 	case "memory":
 		return corev1.ResourceMemory
+	case "ephemeral-storage":
+		return corev1.ResourceEphemeralStorage
 	default:
 		return ""
 	}
 }
 
-func getPodResourceCapacity(pod *corev1.Pod, resourceName corev1.ResourceName) int64 {
+// getContainerResources returns the requests or the limits of the container, depending on the resource divisor
+func getContainerResources(container *corev1.Container, resourceDivisor string) corev1.ResourceList {
+	if resourceDivisor == resourceDivisorLimits {
+		return container.Resources.Limits
+	}
+	return container.Resources.Requests
+}
+
+// getPodContainers returns the containers of the pod, with its sidecar containers if they're included
+func getPodContainers(pod *corev1.Pod, includeSidecarContainers bool) []corev1.Container {
+	if !includeSidecarContainers {
+		return pod.Spec.Containers
+	}
+	containers := append([]corev1.Container{}, pod.Spec.Containers...)
+	for _, container := range pod.Spec.InitContainers {
+		if isSidecarContainer(&container) {
+			containers = append(containers, container)
+		}
+	}
+	return containers
+}
+
+// isSidecarContainer returns whether the init container is a sidecar container, which keeps running with the pod
+func isSidecarContainer(container *corev1.Container) bool {
+	return container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways
+}
+
+// withoutSidecarContainers returns the metrics of the pod without the ones of its sidecar containers
+func withoutSidecarContainers(podMetrics *v1beta1.PodMetrics, pod *corev1.Pod) *v1beta1.PodMetrics {
+	sidecarContainers := map[string]bool{}
+	for _, container := range pod.Spec.InitContainers {
+		if isSidecarContainer(&container) {
+			sidecarContainers[container.Name] = true
+		}
+	}
+	if len(sidecarContainers) == 0 {
+		return podMetrics
+	}
+	filtered := podMetrics.DeepCopy()
+	filtered.Containers = nil
+	for _, containerMetrics := range podMetrics.Containers {
+		if !sidecarContainers[containerMetrics.Name] {
+			filtered.Containers = append(filtered.Containers, containerMetrics)
+		}
+	}
+	return filtered
+}
+
+func getPodResourceCapacity(pod *corev1.Pod, resourceName corev1.ResourceName, resourceDivisor string, includeSidecarContainers bool) int64 {
 	var total int64
-	for _, container := range pod.Spec.Containers {
-		if quantity, ok := container.Resources.Requests[resourceName]; ok {
+	for _, container := range getPodContainers(pod, includeSidecarContainers) {
+		if quantity, ok := getContainerResources(&container, resourceDivisor)[resourceName]; ok {
 			total += quantity.MilliValue()
 		}
 	}
 	return total
 }
 
-func (s *cpuMemoryScaler) getPodList(ctx context.Context) (*corev1.PodList, labels.Selector, error) {
-	var labelSelector labels.Selector
+// GetWatchedPods returns the pods of the scale target the scaler averages the resource of
+func (s *cpuMemoryScaler) GetWatchedPods(ctx context.Context) (string, labels.Selector, error) {
+	labelSelector, err := s.getPodSelector(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	return s.metadata.Namespace, labelSelector, nil
+}
 
+func (s *cpuMemoryScaler) getPodSelector(ctx context.Context) (labels.Selector, error) {
+	key := types.NamespacedName{Namespace: s.metadata.Namespace, Name: s.metadata.ScaleTargetName}
+	var selector *metav1.LabelSelector
 	switch s.metadata.ScaleTargetKind {
+	case "":
+		// the pods of the jobs of a ScaledJob are labelled with its name
+		if s.metadata.ScalableObjectType == "ScaledJob" {
+			return labels.SelectorFromSet(labels.Set{"scaledjob.keda.sh/name": s.metadata.ScalableObjectName}), nil
+		}
+		return nil, fmt.Errorf("unsupported scalable object type: %s", s.metadata.ScalableObjectType)
 	case "Deployment":
 		deployment := &appsv1.Deployment{}
-		err := s.kubeClient.Get(ctx, types.NamespacedName{Namespace: s.metadata.Namespace, Name: s.metadata.ScaleTargetName}, deployment)
+		err := s.kubeClient.Get(ctx, key, deployment)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get deployment: %v", err)
+			return nil, fmt.Errorf("failed to get deployment: %v", err)
 		}
-		labelSelector = labels.SelectorFromSet(deployment.Spec.Selector.MatchLabels)
+		selector = deployment.Spec.Selector
 	case "StatefulSet":
 		statefulSet := &appsv1.StatefulSet{}
-		err := s.kubeClient.Get(ctx, types.NamespacedName{Namespace: s.metadata.Namespace, Name: s.metadata.ScaleTargetName}, statefulSet)
+		err := s.kubeClient.Get(ctx, key, statefulSet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statefulset: %v", err)
+		}
+		selector = statefulSet.Spec.Selector
+	case "ReplicaSet":
+		replicaSet := &appsv1.ReplicaSet{}
+		err := s.kubeClient.Get(ctx, key, replicaSet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get replicaset: %v", err)
+		}
+		selector = replicaSet.Spec.Selector
+	case "DaemonSet":
+		daemonSet := &appsv1.DaemonSet{}
+		err := s.kubeClient.Get(ctx, key, daemonSet)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get statefulset: %v", err)
+			return nil, fmt.Errorf("failed to get daemonset: %v", err)
 		}
-		labelSelector = labels.SelectorFromSet(statefulSet.Spec.Selector.MatchLabels)
+		selector = daemonSet.Spec.Selector
 	default:
-		return nil, nil, fmt.Errorf("unsupported scalable object type: %s", s.metadata.ScalableObjectType)
+		return s.getScaleSubresourceSelector(ctx, key)
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of the %s %s: %w", s.metadata.ScaleTargetKind, s.metadata.ScaleTargetName, err)
+	}
+	return labelSelector, nil
+}
+
+// getScaleSubresourceSelector returns the selector of the pods of the scale target from its /scale subresource,
+// for the custom resources like the Argo Rollouts
+func (s *cpuMemoryScaler) getScaleSubresourceSelector(ctx context.Context, key types.NamespacedName) (labels.Selector, error) {
+	gv, err := schema.ParseGroupVersion(s.metadata.ScaleTargetAPIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apiVersion of the scale target %s: %w", s.metadata.ScaleTargetName, err)
+	}
+	target := &unstructured.Unstructured{}
+	target.SetGroupVersionKind(gv.WithKind(s.metadata.ScaleTargetKind))
+	target.SetNamespace(key.Namespace)
+	target.SetName(key.Name)
+
+	scale := &autoscalingv1.Scale{}
+	if err := s.kubeClient.SubResource("scale").Get(ctx, target, scale); err != nil {
+		return nil, fmt.Errorf("failed to get the scale of the %s %s: %v", s.metadata.ScaleTargetKind, s.metadata.ScaleTargetName, err)
+	}
+	if scale.Status.Selector == "" {
+		return nil, fmt.Errorf("the scale of the %s %s has no selector", s.metadata.ScaleTargetKind, s.metadata.ScaleTargetName)
+	}
+	labelSelector, err := labels.Parse(scale.Status.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of the scale of the %s %s: %w", s.metadata.ScaleTargetKind, s.metadata.ScaleTargetName, err)
+	}
+	return labelSelector, nil
+}
+
+func (s *cpuMemoryScaler) getPodList(ctx context.Context) (*corev1.PodList, labels.Selector, error) {
+	labelSelector, err := s.getPodSelector(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	podList := &corev1.PodList{}
-	err := s.kubeClient.List(ctx, podList, &client.ListOptions{
+	err = s.kubeClient.List(ctx, podList, &client.ListOptions{
 		Namespace:     s.metadata.Namespace,
 		LabelSelector: labelSelector,
 	})
@@ -399,28 +946,110 @@ func (s *cpuMemoryScaler) getPodList(ctx context.Context) (*corev1.PodList, labe
 	return podList, labelSelector, nil
 }
 
-func (s *cpuMemoryScaler) getPodMetricsList(ctx context.Context, labelSelector labels.Selector) (*v1beta1.PodMetricsList, error) {
-	//podMetricsList := &v1beta1.PodMetricsList{}
-	//err := s.kubeClient.List(ctx, podMetricsList, &client.ListOptions{Namespace: s.metadata.Namespace})
-	//if err != nil {
-	//	return nil, fmt.Errorf("failed to get pod metrics: %v", err)
-	//}
-	//return podMetricsList, nil
-	config, err := rest.InClusterConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get in-cluster config: %v", err)
+// getPodMetricsSamples returns the samples of the PodMetrics of the pods listed in the background over the last
+// sampleCount sampleIntervals, the polls don't wait for them. The first poll lists a single sample while the
+// sampling starts, so do the polls once the sampling fails over the whole window.
+func (s *cpuMemoryScaler) getPodMetricsSamples(ctx context.Context, podList *corev1.PodList, labelSelector labels.Selector) ([]*v1beta1.PodMetricsList, error) {
+	if s.sampler != nil {
+		s.sampler.start(s.samplePodMetrics)
+		if samples := s.sampler.get(time.Duration(s.metadata.SampleCount) * s.metadata.SampleInterval); len(samples) > 0 {
+			return samples, nil
+		}
 	}
 
-	metricsClient, err := metrics.NewForConfig(config)
+	podMetricsList, err := s.getPodMetricsList(ctx, podList, labelSelector, 0)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create metrics client: %v", err)
+		return nil, err
 	}
+	return []*v1beta1.PodMetricsList{podMetricsList}, nil
+}
 
-	podsMetricsList, err := metricsClient.MetricsV1beta1().PodMetricses(s.metadata.Namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: labelSelector.String(),
-	})
+// samplePodMetrics lists the PodMetrics of the pods every sampleInterval until the context is done, the samples
+// aren't older than half of the sampleInterval, so they aren't the same list
+func (s *cpuMemoryScaler) samplePodMetrics(ctx context.Context) {
+	ticker := time.NewTicker(s.metadata.SampleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		podList, labelSelector, err := s.getPodList(ctx)
+		if err == nil {
+			var podMetricsList *v1beta1.PodMetricsList
+			podMetricsList, err = s.getPodMetricsList(ctx, podList, labelSelector, s.metadata.SampleInterval/2)
+			if err == nil {
+				s.sampler.add(podMetricsList, s.metadata.SampleCount)
+			}
+		}
+		if err != nil && ctx.Err() == nil {
+			s.logger.V(1).Info("Error sampling the PodMetrics of the pods", "error", err.Error())
+		}
+	}
+}
+
+// start starts the sampling once, unless the sampler is stopped
+func (p *podMetricsSampler) start(sample func(ctx context.Context)) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.cancel != nil || p.closed {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	go sample(ctx)
+}
+
+func (p *podMetricsSampler) stop() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.closed = true
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+// add keeps the last sampleCount samples
+func (p *podMetricsSampler) add(podMetricsList *v1beta1.PodMetricsList, sampleCount int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.samples = append(p.samples, podMetricsSample{listed: time.Now(), podMetricsList: podMetricsList})
+	if len(p.samples) > sampleCount {
+		p.samples = p.samples[len(p.samples)-sampleCount:]
+	}
+}
+
+// get returns the samples listed within the window
+func (p *podMetricsSampler) get(window time.Duration) []*v1beta1.PodMetricsList {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var samples []*v1beta1.PodMetricsList
+	for _, sample := range p.samples {
+		if time.Since(sample.listed) < window {
+			samples = append(samples, sample.podMetricsList)
+		}
+	}
+	return samples
+}
+
+// getPodMetricsList returns the PodMetrics of the pods read from the metrics backend, metrics-server doesn't report
+// the ephemeral storage, the metrics API backend reads it from the kubelets instead
+func (s *cpuMemoryScaler) getPodMetricsList(ctx context.Context, podList *corev1.PodList, labelSelector labels.Selector, maxAge time.Duration) (*v1beta1.PodMetricsList, error) {
+	if s.metadata.MetricsBackend == metricsBackendPrometheus {
+		return s.getPrometheusPodMetricsList(ctx, podList)
+	}
+	if s.resourceName == corev1.ResourceEphemeralStorage {
+		return listKubeletPodMetrics(ctx, podList)
+	}
 
-	return podsMetricsList, err
+	podMetricsList, err := sharedPodMetricsCache.get(ctx, s.metadata.Namespace, labelSelector, maxAge)
+	if err != nil && s.metadata.PrometheusURL != "" {
+		s.logger.V(1).Info("Reading the usage of the pods from prometheus, the metrics API failed", "error", err.Error())
+		return s.getPrometheusPodMetricsList(ctx, podList)
+	}
+	return podMetricsList, err
 }
 
 func getPodMetrics(podMetricsList *v1beta1.PodMetricsList, podName string) *v1beta1.PodMetrics {
@@ -432,44 +1061,110 @@ func getPodMetrics(podMetricsList *v1beta1.PodMetricsList, podName string) *v1be
 	return nil
 }
 
-func getContainerMetrics(podMetrics *v1beta1.PodMetrics, containerName string) *v1beta1.ContainerMetrics {
+// getContainersMetrics returns the metrics of the containers of the pod matching the pattern
+func getContainersMetrics(podMetrics *v1beta1.PodMetrics, containerPattern *regexp.Regexp) []v1beta1.ContainerMetrics {
+	var containersMetrics []v1beta1.ContainerMetrics
 	for _, containerMetrics := range podMetrics.Containers {
-		if containerMetrics.Name == containerName {
-			return &containerMetrics
+		if containerPattern.MatchString(containerMetrics.Name) {
+			containersMetrics = append(containersMetrics, containerMetrics)
 		}
 	}
-	return nil
+	return containersMetrics
 }
 
-func getContainerResourceCapacity(pod *corev1.Pod, containerName string, resourceName corev1.ResourceName) int64 {
-	for _, container := range pod.Spec.Containers {
-		if container.Name == containerName {
-			if quantity, ok := container.Resources.Requests[resourceName]; ok {
-				return quantity.MilliValue()
+// getContainerResourceCapacity returns the total capacity of the containers of the pod matching the pattern
+func getContainerResourceCapacity(pod *corev1.Pod, containerPattern *regexp.Regexp, resourceName corev1.ResourceName, resourceDivisor string, includeSidecarContainers bool) int64 {
+	var total int64
+	for _, container := range getPodContainers(pod, includeSidecarContainers) {
+		if containerPattern.MatchString(container.Name) {
+			if quantity, ok := getContainerResources(&container, resourceDivisor)[resourceName]; ok {
+				total += quantity.MilliValue()
 			}
 		}
 	}
-	return 0
+	return total
 }
 
-// GetMetricsAndActivity only returns the activity of the cpu/memory scaler
+// GetMetricsAndActivity returns the average value or utilization of the resource as the value of the metric
 func (s *cpuMemoryScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	var metric external_metrics.ExternalMetricValue
+	var isActive bool
 	switch s.metadata.Type {
 	case v2.AverageValueMetricType:
-		averageValue, err := s.getAverageValue(ctx, metricName)
+		averageValue, err := s.getAverageValue(ctx, string(s.resourceName))
 		if err != nil {
 			return nil, false, err
 		}
 
-		return nil, averageValue.Cmp(*s.metadata.ActivationAverageValue) == 1, nil
+		metric = GenerateMetricInMili(metricName, averageValue.AsApproximateFloat64())
+		isActive = averageValue.Cmp(*s.metadata.ActivationAverageValue) == 1
 	case v2.UtilizationMetricType:
-		averageUtilization, err := s.getAverageUtilization(ctx, metricName)
+		averageUtilization, err := s.getAverageUtilization(ctx, string(s.resourceName))
 		if err != nil {
 			return nil, false, err
 		}
 
-		return nil, *averageUtilization > *s.metadata.ActivationAverageUtilization, nil
+		metric = GenerateMetricInMili(metricName, float64(*averageUtilization))
+		isActive = *averageUtilization > *s.metadata.ActivationAverageUtilization
+	default:
+		return nil, false, fmt.Errorf("no matching resource metric found for %s", s.resourceName)
+	}
+
+	if s.metadata.Mode == modeThrottling {
+		throttlingRatio, found, err := s.getThrottlingRatio(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		isActive = found && throttlingRatio > s.metadata.ActivationThrottlingRatio
+	}
+
+	if isActive && s.metadata.SkipWhenNodePressure {
+		pressuredNode, err := s.getPressuredNode(ctx)
+		switch {
+		case err != nil:
+			s.logger.Error(err, "error getting the conditions of the nodes of the pods, not suppressing the activation")
+		case pressuredNode != "":
+			s.logger.V(1).Info("Suppressing the activation, a node of the pods is under pressure", "node", pressuredNode)
+			isActive = false
+		}
+	}
+
+	return []external_metrics.ExternalMetricValue{metric}, isActive, nil
+}
+
+// GetActivationTrendWindow returns the number of consecutive evaluations of the scale loop the resource has to be
+// above the activation value on to activate the scale target
+func (s *cpuMemoryScaler) GetActivationTrendWindow() int {
+	return s.metadata.ActivationTrendWindow
+}
+
+// getPressuredNode returns the name of a node hosting the pods under memory or disk pressure, the pods scaled out
+// would likely land on it, or empty if there's none
+func (s *cpuMemoryScaler) getPressuredNode(ctx context.Context) (string, error) {
+	podList, _, err := s.getPodList(ctx)
+	if err != nil {
+		return "", err
 	}
 
-	return nil, false, fmt.Errorf("no matching resource metric found for %s", s.resourceName)
+	nodeNames := map[string]bool{}
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName == "" || nodeNames[pod.Spec.NodeName] || !s.isPodCounted(&pod) {
+			continue
+		}
+		nodeNames[pod.Spec.NodeName] = true
+
+		node := &corev1.Node{}
+		if err := s.kubeClient.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed to get node %s: %w", pod.Spec.NodeName, err)
+		}
+		for _, condition := range node.Status.Conditions {
+			if (condition.Type == corev1.NodeMemoryPressure || condition.Type == corev1.NodeDiskPressure) && condition.Status == corev1.ConditionTrue {
+				return node.Name, nil
+			}
+		}
+	}
+	return "", nil
 }
@@ -3,18 +3,25 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	v2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/scheme"
 	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -51,6 +58,38 @@ var testCPUMemoryMetadata = []parseCPUMemoryMetadataTestData{
 	{v2.ValueMetricType, map[string]string{"value": "50"}, true},
 	{"", map[string]string{"type": "AverageValue"}, true},
 	{"", map[string]string{"type": "xxx", "value": "50"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "resourceDivisor": "limits"}, false},
+	{"", map[string]string{"type": "Utilization", "value": "50", "resourceDivisor": "requests"}, false},
+	{"", map[string]string{"type": "Utilization", "value": "50", "resourceDivisor": "xxx"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "containerName": "app,istio-proxy"}, false},
+	{"", map[string]string{"type": "Utilization", "value": "50", "containerName": "app-.*"}, false},
+	{"", map[string]string{"type": "Utilization", "value": "50", "containerName": "app-(.*"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "containerName": " , "}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "aggregation": "max"}, false},
+	{"", map[string]string{"type": "AverageValue", "value": "50", "aggregation": "p95"}, false},
+	{"", map[string]string{"type": "Utilization", "value": "50", "aggregation": "p50"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "excludeTerminatingPods": "true", "requireReady": "true"}, false},
+	{"", map[string]string{"type": "Utilization", "value": "50", "excludeTerminatingPods": "yes"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "requireReady": "yes"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "includeSidecarContainers": "false"}, false},
+	{"", map[string]string{"type": "Utilization", "value": "50", "includeSidecarContainers": "no"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "activationTrendWindow": "3"}, false},
+	{"", map[string]string{"type": "Utilization", "value": "50", "activationTrendWindow": "-1"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "activationTrendWindow": "x"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "skipWhenNodePressure": "true"}, false},
+	{"", map[string]string{"type": "Utilization", "value": "50", "skipWhenNodePressure": "x"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "sampleCount": "3", "sampleInterval": "5s"}, false},
+	{"", map[string]string{"type": "Utilization", "value": "50", "sampleCount": "0"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "sampleInterval": "0s"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "sampleInterval": "x"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "metricsBackend": "prometheus", "prometheusURL": "http://prometheus:9090"}, false},
+	{"", map[string]string{"type": "Utilization", "value": "50", "metricsBackend": "prometheus"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "metricsBackend": "xxx"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "mode": "throttling", "prometheusURL": "http://prometheus:9090", "activationThrottlingRatio": "20"}, false},
+	{"", map[string]string{"type": "Utilization", "value": "50", "mode": "throttling"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "mode": "throttling", "prometheusURL": "http://prometheus:9090", "activationThrottlingRatio": "120"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "activationThrottlingRatio": "20"}, true},
+	{"", map[string]string{"type": "Utilization", "value": "50", "mode": "xxx"}, true},
 }
 
 var selectLabels = map[string]string{
@@ -62,6 +101,7 @@ func TestCPUMemoryParseMetadata(t *testing.T) {
 		config := &scalersconfig.ScalerConfig{
 			TriggerMetadata: testData.metadata,
 			MetricType:      testData.metricType,
+			TriggerType:     "cpu",
 		}
 		_, err := parseResourceMetadata(config, logr.Discard(), fake.NewFakeClient())
 		if err != nil && !testData.isError {
@@ -73,6 +113,319 @@ func TestCPUMemoryParseMetadata(t *testing.T) {
 	}
 }
 
+func TestCPUMemoryParseThrottlingMode(t *testing.T) {
+	// the throttling of the memory isn't a thing
+	config := &scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"type": "Utilization", "value": "50", "mode": "throttling", "prometheusURL": "http://prometheus:9090"},
+		TriggerType:     "memory",
+	}
+	_, err := parseResourceMetadata(config, logr.Discard(), fake.NewFakeClient())
+	assert.Error(t, err)
+}
+
+func TestCPUMemoryParseResourceDivisor(t *testing.T) {
+	config := &scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"type": "Utilization", "value": "50"},
+	}
+	meta, err := parseResourceMetadata(config, logr.Discard(), fake.NewFakeClient())
+	assert.NoError(t, err)
+	assert.Equal(t, resourceDivisorRequests, meta.ResourceDivisor)
+
+	config.TriggerMetadata["resourceDivisor"] = "limits"
+	meta, err = parseResourceMetadata(config, logr.Discard(), fake.NewFakeClient())
+	assert.NoError(t, err)
+	assert.Equal(t, resourceDivisorLimits, meta.ResourceDivisor)
+}
+
+func TestGetResourceCapacity(t *testing.T) {
+	pod := createPod("400m")
+
+	assert.Equal(t, int64(400), getPodResourceCapacity(pod, v1.ResourceCPU, resourceDivisorRequests, true))
+	assert.Equal(t, int64(600), getPodResourceCapacity(pod, v1.ResourceCPU, resourceDivisorLimits, true))
+	assert.Equal(t, int64(400), getContainerResourceCapacity(pod, regexp.MustCompile("^test-container$"), v1.ResourceCPU, resourceDivisorRequests, true))
+	assert.Equal(t, int64(600), getContainerResourceCapacity(pod, regexp.MustCompile("^test-container$"), v1.ResourceCPU, resourceDivisorLimits, true))
+
+	// the containers without limits don't count
+	assert.Equal(t, int64(0), getPodResourceCapacity(pod, v1.ResourceMemory, resourceDivisorLimits, true))
+	assert.Equal(t, int64(0), getContainerResourceCapacity(pod, regexp.MustCompile("^other-container$"), v1.ResourceCPU, resourceDivisorLimits, true))
+}
+
+func TestPodValuesAggregator(t *testing.T) {
+	values := []int64{40, 10, 30, 100, 20, 90, 60, 50, 80, 70}
+	for aggregation, expected := range map[string]int64{
+		aggregationAvg: 55,
+		aggregationMax: 100,
+		aggregationMin: 10,
+		"p90":          90,
+		"p95":          100,
+		"p99":          100,
+	} {
+		aggregator := &podValuesAggregator{aggregation: aggregation}
+		for _, value := range values {
+			aggregator.add(value)
+		}
+		assert.Equal(t, expected, aggregator.result(), aggregation)
+	}
+
+	// the hottest pod isn't hidden by the average
+	aggregator := &podValuesAggregator{aggregation: "p90"}
+	for _, value := range []int64{10, 10, 10, 10, 10, 10, 10, 10, 10, 100, 100} {
+		aggregator.add(value)
+	}
+	assert.Equal(t, int64(100), aggregator.result())
+	assert.Equal(t, int64(0), (&podValuesAggregator{aggregation: aggregationMax}).result())
+}
+
+func TestIsPodCounted(t *testing.T) {
+	running := createPod("400m")
+	running.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}
+	unready := running.DeepCopy()
+	unready.Status.Conditions[0].Status = v1.ConditionFalse
+	terminating := running.DeepCopy()
+	terminating.DeletionTimestamp = &metav1.Time{}
+	pending := running.DeepCopy()
+	pending.Status.Phase = v1.PodPending
+
+	s := &cpuMemoryScaler{metadata: &cpuMemoryMetadata{}}
+	assert.True(t, s.isPodCounted(running))
+	assert.True(t, s.isPodCounted(unready))
+	assert.True(t, s.isPodCounted(terminating))
+	assert.False(t, s.isPodCounted(pending))
+
+	s.metadata.ExcludeTerminatingPods = true
+	assert.True(t, s.isPodCounted(unready))
+	assert.False(t, s.isPodCounted(terminating))
+
+	s.metadata.RequireReady = true
+	assert.True(t, s.isPodCounted(running))
+	assert.False(t, s.isPodCounted(unready))
+	assert.False(t, s.isPodCounted(createPod("400m")))
+}
+
+func TestGetSidecarContainersResource(t *testing.T) {
+	restartPolicyAlways := v1.ContainerRestartPolicyAlways
+	pod := createPod("400m")
+	pod.Spec.InitContainers = []v1.Container{
+		{
+			Name:          "istio-proxy",
+			RestartPolicy: &restartPolicyAlways,
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+			},
+		},
+		{
+			Name: "migrations",
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			},
+		},
+	}
+	podMetrics := createPodMetrics("300m")
+	podMetrics.Containers = append(podMetrics.Containers, metricsv1beta1.ContainerMetrics{
+		Name:  "istio-proxy",
+		Usage: v1.ResourceList{v1.ResourceCPU: resource.MustParse("50m")},
+	})
+
+	// the sidecar containers are counted, not the other init containers
+	assert.Equal(t, int64(500), getPodResourceCapacity(pod, v1.ResourceCPU, resourceDivisorRequests, true))
+	assert.Equal(t, int64(100), getContainerResourceCapacity(pod, regexp.MustCompile("^istio-proxy$"), v1.ResourceCPU, resourceDivisorRequests, true))
+	assert.Equal(t, int64(350), getPodResourceValueInMillis(podMetrics, "cpu"))
+
+	// unless they're opted out
+	assert.Equal(t, int64(400), getPodResourceCapacity(pod, v1.ResourceCPU, resourceDivisorRequests, false))
+	assert.Equal(t, int64(0), getContainerResourceCapacity(pod, regexp.MustCompile("^istio-proxy$"), v1.ResourceCPU, resourceDivisorRequests, false))
+	assert.Equal(t, int64(300), getPodResourceValueInMillis(withoutSidecarContainers(podMetrics, pod), "cpu"))
+	assert.Len(t, podMetrics.Containers, 2)
+}
+
+func TestGetPressuredNode(t *testing.T) {
+	newPod := func(name, nodeName string, phase v1.PodPhase) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace", Labels: selectLabels},
+			Spec:       v1.PodSpec{NodeName: nodeName},
+			Status:     v1.PodStatus{Phase: phase},
+		}
+	}
+	newNode := func(name string, conditionType v1.NodeConditionType, status v1.ConditionStatus) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     v1.NodeStatus{Conditions: []v1.NodeCondition{{Type: conditionType, Status: status}}},
+		}
+	}
+	deployment := createDeployment()
+	s := &cpuMemoryScaler{
+		metadata: &cpuMemoryMetadata{
+			Namespace:       "test-namespace",
+			ScaleTargetName: deployment.Name,
+			ScaleTargetKind: "Deployment",
+		},
+	}
+
+	s.kubeClient = fake.NewClientBuilder().WithObjects(
+		deployment,
+		newPod("pod-1", "node-1", v1.PodRunning),
+		newPod("pod-2", "node-2", v1.PodRunning),
+		newPod("pod-3", "node-missing", v1.PodRunning),
+		newNode("node-1", v1.NodeMemoryPressure, v1.ConditionFalse),
+		newNode("node-2", v1.NodeReady, v1.ConditionTrue),
+	).Build()
+	pressuredNode, err := s.getPressuredNode(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "", pressuredNode)
+
+	// the nodes under memory or disk pressure are, unless their pods aren't running
+	s.kubeClient = fake.NewClientBuilder().WithObjects(
+		deployment,
+		newPod("pod-1", "node-1", v1.PodRunning),
+		newPod("pod-2", "node-2", v1.PodRunning),
+		newPod("pod-3", "node-3", v1.PodPending),
+		newNode("node-1", v1.NodeMemoryPressure, v1.ConditionFalse),
+		newNode("node-2", v1.NodeDiskPressure, v1.ConditionTrue),
+		newNode("node-3", v1.NodeMemoryPressure, v1.ConditionTrue),
+	).Build()
+	pressuredNode, err = s.getPressuredNode(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "node-2", pressuredNode)
+}
+
+func TestGetPodMetricsSamples(t *testing.T) {
+	defer func(podMetricsCache *podMetricsCache) {
+		sharedPodMetricsCache = podMetricsCache
+	}(sharedPodMetricsCache)
+	var lock sync.Mutex
+	listed := 0
+	sharedPodMetricsCache = newPodMetricsCache(time.Hour, func(context.Context, string, labels.Selector) (*metricsv1beta1.PodMetricsList, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		listed++
+		return &metricsv1beta1.PodMetricsList{Items: []metricsv1beta1.PodMetrics{*createPodMetrics(fmt.Sprintf("%dm", listed*100))}}, nil
+	})
+	getListed := func() int {
+		lock.Lock()
+		defer lock.Unlock()
+		return listed
+	}
+	deployment := createDeployment()
+	pod := createPod("400m")
+	podList := &v1.PodList{Items: []v1.Pod{*pod}}
+	selector := labels.SelectorFromSet(selectLabels)
+
+	s := &cpuMemoryScaler{
+		metadata: &cpuMemoryMetadata{
+			SampleCount:     3,
+			SampleInterval:  20 * time.Millisecond,
+			Aggregation:     aggregationAvg,
+			Namespace:       "test-namespace",
+			ScaleTargetName: deployment.Name,
+			ScaleTargetKind: "Deployment",
+		},
+		kubeClient: fake.NewClientBuilder().WithObjects(deployment, pod).Build(),
+		logger:     logr.Discard(),
+		sampler:    &podMetricsSampler{},
+	}
+	defer s.Close(context.Background())
+
+	// the first poll doesn't wait for the samples, it lists one while they're listed in the background
+	samples, err := s.getPodMetricsSamples(context.Background(), podList, selector)
+	assert.NoError(t, err)
+	assert.Len(t, samples, 1)
+
+	// the samples are listed again every sampleInterval, even if they're cached, the polls get the last ones
+	assert.Eventually(t, func() bool { return getListed() >= 4 }, time.Second, 5*time.Millisecond)
+	samples, err = s.getPodMetricsSamples(context.Background(), podList, selector)
+	assert.NoError(t, err)
+	assert.Len(t, samples, 3)
+
+	var utilizations []int32
+	for _, sample := range samples {
+		utilization, err := s.getSampleUtilization(podList, sample, "cpu")
+		assert.NoError(t, err)
+		utilizations = append(utilizations, *utilization)
+	}
+	assert.Equal(t, utilizations[0]+25, utilizations[1])
+	assert.Equal(t, utilizations[1]+25, utilizations[2])
+
+	// the sampling stops once the scaler is closed
+	assert.NoError(t, s.Close(context.Background()))
+	time.Sleep(50 * time.Millisecond)
+	stopped := getListed()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, stopped, getListed())
+
+	// a single sample is served from the cache
+	s.metadata.SampleCount = 1
+	s.sampler = nil
+	samples, err = s.getPodMetricsSamples(context.Background(), podList, selector)
+	assert.NoError(t, err)
+	assert.Len(t, samples, 1)
+	assert.Equal(t, stopped, getListed())
+}
+
+func TestParseContainerName(t *testing.T) {
+	names, pattern, err := parseContainerName("app, istio-proxy")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app", "istio-proxy"}, names)
+	assert.True(t, pattern.MatchString("istio-proxy"))
+	assert.False(t, pattern.MatchString("app-sidecar"))
+
+	names, pattern, err = parseContainerName("app,sidecar-[0-9a-f]+")
+	assert.NoError(t, err)
+	assert.Nil(t, names)
+	assert.True(t, pattern.MatchString("app"))
+	assert.True(t, pattern.MatchString("sidecar-1f2e"))
+	assert.False(t, pattern.MatchString("my-sidecar-1f2e"))
+}
+
+func TestGetContainersResourceAggregation(t *testing.T) {
+	pod := createPod("400m")
+	pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{
+		Name: "sidecar-1f2e",
+		Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+		},
+	})
+	podMetrics := createPodMetrics("300m")
+	podMetrics.Containers = append(podMetrics.Containers, metricsv1beta1.ContainerMetrics{
+		Name:  "sidecar-1f2e",
+		Usage: v1.ResourceList{v1.ResourceCPU: resource.MustParse("50m")},
+	})
+	_, pattern, err := parseContainerName("test-container,sidecar-.*")
+	assert.NoError(t, err)
+
+	containersMetrics := getContainersMetrics(podMetrics, pattern)
+	assert.Len(t, containersMetrics, 2)
+	assert.Equal(t, int64(350), getContainersResourceValue(containersMetrics, "cpu").MilliValue())
+	assert.Equal(t, int64(500), getContainerResourceCapacity(pod, pattern, v1.ResourceCPU, resourceDivisorRequests, true))
+
+	_, pattern, err = parseContainerName("sidecar-.*")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), getContainerResourceCapacity(pod, pattern, v1.ResourceCPU, resourceDivisorRequests, true))
+}
+
+func TestGetContainersMetricSpecForScaling(t *testing.T) {
+	kubeClient := fake.NewFakeClient()
+
+	// the HPA scales on each container listed
+	config := &scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"value": "50", "containerName": "app,istio-proxy"},
+		MetricType:      v2.UtilizationMetricType,
+	}
+	scaler, err := NewCPUMemoryScaler(v1.ResourceCPU, config, kubeClient)
+	assert.NoError(t, err)
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+	assert.Len(t, metricSpec, 2)
+	assert.Equal(t, metricSpec[0].ContainerResource.Container, "app")
+	assert.Equal(t, metricSpec[1].ContainerResource.Container, "istio-proxy")
+
+	// and on the whole pods if the containers are matched by regular expressions
+	config.TriggerMetadata = map[string]string{"value": "50", "containerName": "app-.*"}
+	scaler, err = NewCPUMemoryScaler(v1.ResourceCPU, config, kubeClient)
+	assert.NoError(t, err)
+	metricSpec = scaler.GetMetricSpecForScaling(context.Background())
+	assert.Len(t, metricSpec, 1)
+	assert.Equal(t, metricSpec[0].Type, v2.ResourceMetricSourceType)
+}
+
 func TestGetMetricSpecForScaling(t *testing.T) {
 	// Using trigger.metadata.type field for type
 	config := &scalersconfig.ScalerConfig{
@@ -99,6 +452,137 @@ func TestGetMetricSpecForScaling(t *testing.T) {
 	assert.Equal(t, metricSpec[0].Resource.Target.Type, v2.UtilizationMetricType)
 }
 
+func TestGetScaledJobMetricSpecForScaling(t *testing.T) {
+	config := &scalersconfig.ScalerConfig{
+		TriggerMetadata:    validCPUMemoryMetadata,
+		ScalableObjectType: "ScaledJob",
+		ScalableObjectName: "test-job",
+		TriggerIndex:       1,
+	}
+	kubeClient := fake.NewFakeClient()
+	scaler, _ := NewCPUMemoryScaler(v1.ResourceCPU, config, kubeClient)
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+
+	assert.Len(t, metricSpec, 1)
+	assert.Equal(t, v2.ExternalMetricSourceType, metricSpec[0].Type)
+	assert.Equal(t, "s1-cpu", metricSpec[0].External.Metric.Name)
+	assert.Equal(t, v2.AverageValueMetricType, metricSpec[0].External.Target.Type)
+	assert.Equal(t, int64(50000), metricSpec[0].External.Target.AverageValue.MilliValue())
+
+	config = &scalersconfig.ScalerConfig{
+		TriggerMetadata:    map[string]string{"type": "AverageValue", "value": "200Mi"},
+		ScalableObjectType: "ScaledJob",
+		ScalableObjectName: "test-job",
+	}
+	scaler, _ = NewCPUMemoryScaler(v1.ResourceMemory, config, kubeClient)
+	metricSpec = scaler.GetMetricSpecForScaling(context.Background())
+
+	assert.Equal(t, "s0-memory", metricSpec[0].External.Metric.Name)
+	assert.Equal(t, int64(200*1024*1024), metricSpec[0].External.Target.AverageValue.Value())
+}
+
+func TestGetScalingModifiersMetricSpecForScaling(t *testing.T) {
+	kubeClient := fake.NewFakeClient()
+	for triggerIndex := 0; triggerIndex < 2; triggerIndex++ {
+		config := &scalersconfig.ScalerConfig{
+			TriggerMetadata: validCPUMemoryMetadata,
+			TriggerIndex:    triggerIndex,
+			AsMetricSource:  true,
+		}
+		scaler, err := NewCPUMemoryScaler(v1.ResourceCPU, config, kubeClient)
+		assert.NoError(t, err)
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+
+		// the HPA scales on the composite metric only, the triggers of the same resource are told apart by their index
+		assert.Len(t, metricSpec, 1)
+		assert.Nil(t, metricSpec[0].Resource)
+		assert.Equal(t, fmt.Sprintf("s%d-cpu", triggerIndex), metricSpec[0].External.Metric.Name)
+	}
+}
+
+func TestGetEphemeralStorageMetricSpecForScaling(t *testing.T) {
+	config := &scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"type": "AverageValue", "value": "1Gi"},
+		TriggerIndex:    2,
+	}
+	scaler, err := NewCPUMemoryScaler(v1.ResourceEphemeralStorage, config, fake.NewFakeClient())
+	assert.NoError(t, err)
+	metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+
+	// the resource metrics of the HPA don't serve the ephemeral storage, its metric is the value of a pod
+	assert.Len(t, metricSpec, 1)
+	assert.Equal(t, v2.ExternalMetricSourceType, metricSpec[0].Type)
+	assert.Equal(t, "s2-ephemeral-storage", metricSpec[0].External.Metric.Name)
+	assert.Equal(t, v2.ValueMetricType, metricSpec[0].External.Target.Type)
+	assert.Equal(t, int64(1024*1024*1024), metricSpec[0].External.Target.Value.Value())
+
+	// the ScaledJobs compare it to an AverageValue target
+	config.ScalableObjectType = "ScaledJob"
+	scaler, err = NewCPUMemoryScaler(v1.ResourceEphemeralStorage, config, fake.NewFakeClient())
+	assert.NoError(t, err)
+	metricSpec = scaler.GetMetricSpecForScaling(context.Background())
+	assert.Equal(t, v2.AverageValueMetricType, metricSpec[0].External.Target.Type)
+	assert.Equal(t, int64(1024*1024*1024), metricSpec[0].External.Target.AverageValue.Value())
+}
+
+func TestListKubeletPodMetrics(t *testing.T) {
+	summaries := map[string]string{
+		"node-1": `{"pods": [
+			{"podRef": {"name": "app-1", "namespace": "default"}, "containers": [
+				{"name": "app", "rootfs": {"usedBytes": 104857600}, "logs": {"usedBytes": 1048576}},
+				{"name": "sidecar", "rootfs": {"usedBytes": 2097152}}
+			]},
+			{"podRef": {"name": "other", "namespace": "default"}, "containers": [{"name": "other", "rootfs": {"usedBytes": 1}}]}
+		]}`,
+		"node-2": `{"pods": [{"podRef": {"name": "app-2", "namespace": "default"}, "containers": [{"name": "app", "rootfs": {}, "logs": {"usedBytes": 1024}}]}]}`,
+	}
+	defer func(get func(ctx context.Context, nodeName string) ([]byte, error)) { getKubeletSummary = get }(getKubeletSummary)
+	getKubeletSummary = func(_ context.Context, nodeName string) ([]byte, error) {
+		summary, ok := summaries[nodeName]
+		if !ok {
+			return nil, fmt.Errorf("node %s not found", nodeName)
+		}
+		return []byte(summary), nil
+	}
+
+	pod := func(name, nodeName string) v1.Pod {
+		return v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Spec: v1.PodSpec{NodeName: nodeName}}
+	}
+	podList := &v1.PodList{Items: []v1.Pod{pod("app-1", "node-1"), pod("app-2", "node-2"), pod("app-3", "")}}
+	podMetricsList, err := listKubeletPodMetrics(context.Background(), podList)
+	assert.NoError(t, err)
+	// only the pods listed are kept, the storage of a container is its writable layer and its logs
+	assert.Len(t, podMetricsList.Items, 2)
+	assert.Equal(t, int64(100*1024*1024+1024*1024+2*1024*1024), getPodResourceValue(getPodMetrics(podMetricsList, "app-1"), "ephemeral-storage").Value())
+	assert.Equal(t, int64(1024), getPodResourceValue(getPodMetrics(podMetricsList, "app-2"), "ephemeral-storage").Value())
+
+	podList.Items = append(podList.Items, pod("app-4", "node-3"))
+	_, err = listKubeletPodMetrics(context.Background(), podList)
+	assert.EqualError(t, err, "error getting the stats summary of the node node-3: node node-3 not found")
+}
+
+func TestGetEphemeralStorageResourceValue(t *testing.T) {
+	podMetrics := &metricsv1beta1.PodMetrics{
+		Containers: []metricsv1beta1.ContainerMetrics{
+			{Name: "app", Usage: v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse("300Mi")}},
+			{Name: "sidecar", Usage: v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse("100Mi")}},
+		},
+	}
+	pod := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{
+		Name: "app",
+		Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceEphemeralStorage: resource.MustParse("800Mi")},
+		},
+	}}}}
+
+	assert.Equal(t, int64(400*1024*1024), getPodResourceValue(podMetrics, "ephemeral-storage").Value())
+	assert.Equal(t, v1.ResourceEphemeralStorage, getResourceName("ephemeral-storage"))
+	// the utilization is computed against the requests
+	usage := getPodResourceValueInMillis(podMetrics, "ephemeral-storage")
+	capacity := getPodResourceCapacity(pod, v1.ResourceEphemeralStorage, resourceDivisorRequests, true)
+	assert.Equal(t, int64(50), usage*100/capacity)
+}
+
 func TestGetContainerMetricSpecForScaling(t *testing.T) {
 	// Using trigger.metadata.type field for type
 	config := &scalersconfig.ScalerConfig{
@@ -349,3 +833,63 @@ func TestGetMetricsAndActivity_IsNotActive(t *testing.T) {
 	_, isActive, _ := scaler.GetMetricsAndActivity(context.Background(), "cpu")
 	assert.Equal(t, isActive, false)
 }
+
+func TestGetPodSelector(t *testing.T) {
+	selector := &metav1.LabelSelector{
+		MatchLabels:      selectLabels,
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "track", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"canary"}}},
+	}
+	objectMeta := metav1.ObjectMeta{Name: "test-target", Namespace: "test-namespace"}
+	kubeClient := fake.NewClientBuilder().
+		WithObjects(
+			&appsv1.ReplicaSet{ObjectMeta: objectMeta, Spec: appsv1.ReplicaSetSpec{Selector: selector}},
+			&appsv1.DaemonSet{ObjectMeta: objectMeta, Spec: appsv1.DaemonSetSpec{Selector: selector}},
+		).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceGet: func(_ context.Context, _ client.Client, subResourceName string, obj client.Object, subResource client.Object, _ ...client.SubResourceGetOption) error {
+				assert.Equal(t, "scale", subResourceName)
+				assert.Equal(t, "argoproj.io/v1alpha1, Kind=Rollout", obj.GetObjectKind().GroupVersionKind().String())
+				subResource.(*autoscalingv1.Scale).Status.Selector = "app=test-deployment,track notin (canary)"
+				return nil
+			},
+		}).
+		Build()
+
+	for _, kind := range []string{"ReplicaSet", "DaemonSet", "Rollout"} {
+		apiVersion := "apps/v1"
+		if kind == "Rollout" {
+			apiVersion = "argoproj.io/v1alpha1"
+		}
+		s := &cpuMemoryScaler{
+			metadata: &cpuMemoryMetadata{
+				Namespace:             "test-namespace",
+				ScaleTargetName:       "test-target",
+				ScaleTargetKind:       kind,
+				ScaleTargetAPIVersion: apiVersion,
+			},
+			kubeClient: kubeClient,
+		}
+		labelSelector, err := s.getPodSelector(context.Background())
+		assert.NoError(t, err, kind)
+		assert.True(t, labelSelector.Matches(labels.Set{"app": "test-deployment"}), kind)
+		assert.False(t, labelSelector.Matches(labels.Set{"app": "test-deployment", "track": "canary"}), kind)
+	}
+
+	// the pods of the jobs of the ScaledJobs are selected from their label
+	s := &cpuMemoryScaler{
+		metadata:   &cpuMemoryMetadata{ScalableObjectType: "ScaledJob", ScalableObjectName: "test-job", Namespace: "test-namespace"},
+		kubeClient: kubeClient,
+	}
+	labelSelector, err := s.getPodSelector(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, labelSelector.Matches(labels.Set{"scaledjob.keda.sh/name": "test-job"}))
+	assert.False(t, labelSelector.Matches(labels.Set{"scaledjob.keda.sh/name": "other-job"}))
+
+	// the scale targets without pods selected from their scale aren't supported
+	s = &cpuMemoryScaler{
+		metadata:   &cpuMemoryMetadata{},
+		kubeClient: kubeClient,
+	}
+	_, err = s.getPodSelector(context.Background())
+	assert.Error(t, err)
+}
@@ -1,12 +1,16 @@
 package scalers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -49,13 +53,24 @@ type datadogMetadata struct {
 	datadogMetricNamespace  string
 	activationTargetValue   float64
 
+	// TriggerMetadata Cluster Agent Proxy, the metric is read from the metrics the Cluster Agent
+	// aggregates for its external metrics provider, without a DatadogMetric
+	clusterAgentMetric     string
+	clusterAgentMetricTags map[string]string
+
 	// AuthParams Datadog API
 	apiKey      string
 	appKey      string
 	datadogSite string
 
 	// TriggerMetadata Datadog API
+	queryType                string
 	query                    string
+	formula                  string
+	formulaQueries           []datadogFormulaQuery
+	sloID                    string
+	sloTarget                float64
+	datadogAPIURL            string
 	queryAggegrator          string
 	activationQueryValue     float64
 	age                      int
@@ -70,9 +85,23 @@ type datadogMetadata struct {
 	vType         v2.MetricTargetType
 }
 
+// datadogFormulaQuery is a named query referenced from a formula
+type datadogFormulaQuery struct {
+	name  string
+	query string
+}
+
 const maxString = "max"
 const avgString = "average"
 
+const (
+	datadogQueryTypeMetrics     = "metrics"
+	datadogQueryTypeFormula     = "formula"
+	datadogQueryTypeSLOBurnRate = "sloBurnRate"
+)
+
+var formulaQueryName = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+
 var filter *regexp.Regexp
 
 func init() {
@@ -142,9 +171,64 @@ func buildMetricURL(datadogClusterAgentURL, datadogMetricNamespace, datadogMetri
 	return fmt.Sprintf("%s/namespaces/%s/%s", datadogClusterAgentURL, datadogMetricNamespace, datadogMetricName)
 }
 
+// buildClusterAgentMetricURL builds the URL for a metric of the Cluster Agent, its tags are sent as the label
+// selector the Cluster Agent builds the query of the metric from, the names of the external metrics are lowercase
+func buildClusterAgentMetricURL(datadogClusterAgentURL, namespace, metric string, tags map[string]string) string {
+	metricURL := buildMetricURL(datadogClusterAgentURL, namespace, url.PathEscape(strings.ToLower(metric)))
+	if len(tags) == 0 {
+		return metricURL
+	}
+	selector := make([]string, 0, len(tags))
+	for tag, value := range tags {
+		selector = append(selector, fmt.Sprintf("%s=%s", tag, value))
+	}
+	sort.Strings(selector)
+	return fmt.Sprintf("%s?%s", metricURL, url.Values{"labelSelector": {strings.Join(selector, ",")}}.Encode())
+}
+
+// parseDatadogFormulaQueries parses the named queries of a formula, given as name:query pairs separated by ';'
+func parseDatadogFormulaQueries(val string) ([]datadogFormulaQuery, error) {
+	var queries []datadogFormulaQuery
+	names := map[string]bool{}
+	for _, q := range strings.Split(val, ";") {
+		q = strings.TrimSpace(q)
+		if q == "" {
+			continue
+		}
+		name, query, found := strings.Cut(q, ":")
+		name = strings.TrimSpace(name)
+		query = strings.TrimSpace(query)
+		if !found || !formulaQueryName.MatchString(name) {
+			return nil, fmt.Errorf("malformed named query %q, expected name:query", q)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("duplicated query name %s", name)
+		}
+		if _, err := parseDatadogQuery(query); err != nil {
+			return nil, fmt.Errorf("error in query %s: %w", name, err)
+		}
+		names[name] = true
+		queries = append(queries, datadogFormulaQuery{name: name, query: query})
+	}
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("no queries given")
+	}
+	return queries, nil
+}
+
 func parseDatadogAPIMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger) (*datadogMetadata, error) {
 	meta := datadogMetadata{}
 
+	meta.queryType = datadogQueryTypeMetrics
+	if val, ok := config.TriggerMetadata["queryType"]; ok && val != "" {
+		switch val {
+		case datadogQueryTypeMetrics, datadogQueryTypeFormula, datadogQueryTypeSLOBurnRate:
+			meta.queryType = val
+		default:
+			return nil, fmt.Errorf("queryType value %s has to be one of '%s, %s, %s'", val, datadogQueryTypeMetrics, datadogQueryTypeFormula, datadogQueryTypeSLOBurnRate)
+		}
+	}
+
 	if val, ok := config.TriggerMetadata["age"]; ok {
 		age, err := strconv.Atoi(val)
 		if err != nil {
@@ -158,6 +242,8 @@ func parseDatadogAPIMetadata(config *scalersconfig.ScalerConfig, logger logr.Log
 		if age < 60 {
 			logger.Info("selecting a window smaller than 60 seconds can cause Datadog not finding a metric value for the query")
 		}
+	} else if meta.queryType == datadogQueryTypeSLOBurnRate {
+		meta.age = 3600 // Default burn rate window 1 hour
 	} else {
 		meta.age = 90 // Default window 90 seconds
 	}
@@ -189,15 +275,49 @@ func parseDatadogAPIMetadata(config *scalersconfig.ScalerConfig, logger logr.Log
 		meta.lastAvailablePointOffset = 0 // Default use the last point
 	}
 
-	if val, ok := config.TriggerMetadata["query"]; ok {
-		_, err := parseDatadogQuery(val)
+	switch meta.queryType {
+	case datadogQueryTypeFormula:
+		if val, ok := config.TriggerMetadata["formula"]; ok && val != "" {
+			meta.formula = val
+		} else {
+			return nil, fmt.Errorf("no formula given")
+		}
+		if val, ok := config.TriggerMetadata["queries"]; ok && val != "" {
+			queries, err := parseDatadogFormulaQueries(val)
+			if err != nil {
+				return nil, fmt.Errorf("error in queries: %w", err)
+			}
+			meta.formulaQueries = queries
+		} else {
+			return nil, fmt.Errorf("no queries given")
+		}
+	case datadogQueryTypeSLOBurnRate:
+		if val, ok := config.TriggerMetadata["sloID"]; ok && val != "" {
+			meta.sloID = val
+		} else {
+			return nil, fmt.Errorf("no sloID given")
+		}
+		if val, ok := config.TriggerMetadata["sloTarget"]; ok && val != "" {
+			sloTarget, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil, fmt.Errorf("sloTarget parsing error %w", err)
+			}
+			if sloTarget <= 0 || sloTarget >= 100 {
+				return nil, fmt.Errorf("sloTarget should be between 0 and 100")
+			}
+			meta.sloTarget = sloTarget
+		}
+	default:
+		if val, ok := config.TriggerMetadata["query"]; ok {
+			_, err := parseDatadogQuery(val)
 
-		if err != nil {
-			return nil, fmt.Errorf("error in query: %w", err)
+			if err != nil {
+				return nil, fmt.Errorf("error in query: %w", err)
+			}
+			meta.query = val
+		} else {
+			return nil, fmt.Errorf("no query given")
 		}
-		meta.query = val
-	} else {
-		return nil, fmt.Errorf("no query given")
 	}
 
 	if val, ok := config.TriggerMetadata["targetValue"]; ok {
@@ -291,8 +411,18 @@ func parseDatadogAPIMetadata(config *scalersconfig.ScalerConfig, logger logr.Log
 	}
 
 	meta.datadogSite = siteVal
-
-	hpaMetricName := meta.query[0:strings.Index(meta.query, "{")]
+	meta.datadogAPIURL = fmt.Sprintf("https://api.%s", siteVal)
+
+	var hpaMetricName string
+	switch meta.queryType {
+	case datadogQueryTypeFormula:
+		firstQuery := meta.formulaQueries[0].query
+		hpaMetricName = "formula-" + firstQuery[0:strings.Index(firstQuery, "{")]
+	case datadogQueryTypeSLOBurnRate:
+		hpaMetricName = "slo-" + meta.sloID
+	default:
+		hpaMetricName = meta.query[0:strings.Index(meta.query, "{")]
+	}
 	meta.hpaMetricName = GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("datadog-%s", hpaMetricName)))
 
 	return &meta, nil
@@ -334,19 +464,45 @@ func parseDatadogClusterAgentMetadata(config *scalersconfig.ScalerConfig, logger
 		meta.unsafeSsl = unsafeSsl
 	}
 
-	if val, ok := config.TriggerMetadata["datadogMetricName"]; ok {
-		meta.datadogMetricName = val
-	} else {
-		return nil, fmt.Errorf("no datadogMetricName key given")
-	}
+	if val, ok := config.TriggerMetadata["clusterAgentMetric"]; ok && val != "" {
+		if _, ok := config.TriggerMetadata["datadogMetricName"]; ok {
+			return nil, fmt.Errorf("clusterAgentMetric can't be used with datadogMetricName")
+		}
+		meta.clusterAgentMetric = val
 
-	if val, ok := config.TriggerMetadata["datadogMetricNamespace"]; ok {
-		meta.datadogMetricNamespace = val
+		if val, ok := config.TriggerMetadata["clusterAgentMetricTags"]; ok {
+			tags, err := kedautil.ParseStringList(val)
+			if err != nil {
+				return nil, fmt.Errorf("clusterAgentMetricTags parsing error %w", err)
+			}
+			meta.clusterAgentMetricTags = tags
+		}
+
+		// the Cluster Agent doesn't scope the metrics without a DatadogMetric by their namespace
+		meta.datadogMetricNamespace = config.ScalableObjectNamespace
+		if val, ok := config.TriggerMetadata["datadogMetricNamespace"]; ok {
+			meta.datadogMetricNamespace = val
+		}
+		if meta.datadogMetricNamespace == "" {
+			meta.datadogMetricNamespace = "default"
+		}
+
+		meta.hpaMetricName = GenerateMetricNameWithIndex(config.TriggerIndex, kedautil.NormalizeString(fmt.Sprintf("datadog-%s", meta.clusterAgentMetric)))
 	} else {
-		return nil, fmt.Errorf("no datadogMetricNamespace key given")
-	}
+		if val, ok := config.TriggerMetadata["datadogMetricName"]; ok {
+			meta.datadogMetricName = val
+		} else {
+			return nil, fmt.Errorf("no datadogMetricName key given")
+		}
+
+		if val, ok := config.TriggerMetadata["datadogMetricNamespace"]; ok {
+			meta.datadogMetricNamespace = val
+		} else {
+			return nil, fmt.Errorf("no datadogMetricNamespace key given")
+		}
 
-	meta.hpaMetricName = "datadogmetric@" + meta.datadogMetricNamespace + ":" + meta.datadogMetricName
+		meta.hpaMetricName = "datadogmetric@" + meta.datadogMetricNamespace + ":" + meta.datadogMetricName
+	}
 
 	if val, ok := config.TriggerMetadata["targetValue"]; ok {
 		targetValue, err := strconv.ParseFloat(val, 64)
@@ -488,27 +644,17 @@ func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
 
 	timeWindowTo := time.Now().Unix() - int64(s.metadata.timeWindowOffset)
 	timeWindowFrom := timeWindowTo - int64(s.metadata.age)
-	resp, r, err := s.apiClient.MetricsApi.QueryMetrics(ctx, timeWindowFrom, timeWindowTo, s.metadata.query) //nolint:bodyclose
 
-	if r != nil {
-		if r.StatusCode == 429 {
-			rateLimit := r.Header.Get("X-Ratelimit-Limit")
-			rateLimitReset := r.Header.Get("X-Ratelimit-Reset")
-			rateLimitPeriod := r.Header.Get("X-Ratelimit-Period")
-
-			return -1, fmt.Errorf("your Datadog account reached the %s queries per %s seconds rate limit, next limit reset will happen in %s seconds", rateLimit, rateLimitPeriod, rateLimitReset)
-		}
-
-		if r.StatusCode != 200 {
-			if err != nil {
-				return -1, fmt.Errorf("error when retrieving Datadog metrics: %w", err)
-			}
-			return -1, fmt.Errorf("error when retrieving Datadog metrics")
-		}
+	switch s.metadata.queryType {
+	case datadogQueryTypeFormula:
+		return s.getFormulaQueryResult(ctx, timeWindowFrom, timeWindowTo)
+	case datadogQueryTypeSLOBurnRate:
+		return s.getSLOBurnRate(ctx, timeWindowFrom, timeWindowTo)
 	}
 
-	if err != nil {
-		return -1, fmt.Errorf("error when retrieving Datadog metrics: %w", err)
+	resp, r, err := s.apiClient.MetricsApi.QueryMetrics(ctx, timeWindowFrom, timeWindowTo, s.metadata.query) //nolint:bodyclose
+	if err := checkDatadogResponse(r, err); err != nil {
+		return -1, err
 	}
 
 	if resp.GetStatus() == "error" {
@@ -559,15 +705,198 @@ func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
 		results[i] = *points[index][1]
 	}
 
+	return s.aggregateResults(results), nil
+}
+
+// aggregateResults aggregates the latest values of several series with the queryAggregator
+func (s *datadogScaler) aggregateResults(results []float64) float64 {
 	switch s.metadata.queryAggegrator {
 	case avgString:
-		return AvgFloatFromSlice(results), nil
+		return AvgFloatFromSlice(results)
 	default:
 		// Aggregate Results - default Max value:
-		return MaxFloatFromSlice(results), nil
+		return MaxFloatFromSlice(results)
 	}
 }
 
+// checkDatadogResponse returns an error for rate limited or failed Datadog API calls
+func checkDatadogResponse(r *http.Response, err error) error {
+	if r != nil {
+		if r.StatusCode == http.StatusTooManyRequests {
+			rateLimit := r.Header.Get("X-Ratelimit-Limit")
+			rateLimitReset := r.Header.Get("X-Ratelimit-Reset")
+			rateLimitPeriod := r.Header.Get("X-Ratelimit-Period")
+
+			return fmt.Errorf("your Datadog account reached the %s queries per %s seconds rate limit, next limit reset will happen in %s seconds", rateLimit, rateLimitPeriod, rateLimitReset)
+		}
+
+		if r.StatusCode != http.StatusOK {
+			if err != nil {
+				return fmt.Errorf("error when retrieving Datadog metrics: %w", err)
+			}
+			return fmt.Errorf("error when retrieving Datadog metrics")
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("error when retrieving Datadog metrics: %w", err)
+	}
+	return nil
+}
+
+type datadogTimeseriesResponse struct {
+	Data struct {
+		Attributes struct {
+			Times  []int64      `json:"times"`
+			Values [][]*float64 `json:"values"`
+		} `json:"attributes"`
+	} `json:"data"`
+	Errors string `json:"errors"`
+}
+
+// buildFormulaRequest builds the body of a formulas and functions timeseries query
+func buildFormulaRequest(meta *datadogMetadata, from, to int64) ([]byte, error) {
+	queries := make([]map[string]string, 0, len(meta.formulaQueries))
+	for _, q := range meta.formulaQueries {
+		queries = append(queries, map[string]string{
+			"data_source": "metrics",
+			"name":        q.name,
+			"query":       q.query,
+		})
+	}
+	return json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "timeseries_request",
+			"attributes": map[string]interface{}{
+				"formulas": []map[string]string{{"formula": meta.formula}},
+				"queries":  queries,
+				"from":     from * 1000,
+				"to":       to * 1000,
+			},
+		},
+	})
+}
+
+// getFormulaQueryResult evaluates the formula through the timeseries query API, which the v1 client doesn't cover
+func (s *datadogScaler) getFormulaQueryResult(ctx context.Context, from, to int64) (float64, error) {
+	body, err := buildFormulaRequest(s.metadata, from, to)
+	if err != nil {
+		return -1, fmt.Errorf("error building Datadog formula request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.metadata.datadogAPIURL+"/api/v2/query/timeseries", bytes.NewReader(body))
+	if err != nil {
+		return -1, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("DD-API-KEY", s.metadata.apiKey)
+	req.Header.Set("DD-APPLICATION-KEY", s.metadata.appKey)
+
+	r, err := s.apiClient.GetConfig().HTTPClient.Do(req)
+	if err != nil {
+		return -1, fmt.Errorf("error when retrieving Datadog metrics: %w", err)
+	}
+	defer r.Body.Close()
+	respBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		return -1, fmt.Errorf("error when retrieving Datadog metrics: %w", err)
+	}
+	if r.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%s", respBody)
+	}
+	if err := checkDatadogResponse(r, err); err != nil {
+		return -1, err
+	}
+
+	var resp datadogTimeseriesResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return -1, fmt.Errorf("error decoding Datadog formula response: %w", err)
+	}
+	if resp.Errors != "" {
+		return -1, fmt.Errorf("error when retrieving Datadog metrics: %s", resp.Errors)
+	}
+
+	return s.getFormulaSeriesValue(resp.Data.Attributes.Values)
+}
+
+// getFormulaSeriesValue picks the last available point of each formula series
+func (s *datadogScaler) getFormulaSeriesValue(series [][]*float64) (float64, error) {
+	if len(series) == 0 {
+		if !s.metadata.useFiller {
+			return 0, fmt.Errorf("no Datadog metrics returned for the given time window")
+		}
+		return s.metadata.fillValue, nil
+	}
+
+	if len(series) > 1 && s.metadata.queryAggegrator == "" {
+		return 0, fmt.Errorf("formula returned more than 1 series; modify the queries to return only 1 series or add a queryAggregator")
+	}
+
+	results := make([]float64, len(series))
+	for i, points := range series {
+		index := -1
+		for j := len(points) - 1; j >= 0; j-- {
+			if points[j] != nil {
+				index = j
+				break
+			}
+		}
+		index -= s.metadata.lastAvailablePointOffset
+		if index < 0 || points[index] == nil {
+			if !s.metadata.useFiller {
+				return 0, fmt.Errorf("no Datadog metrics returned for the given time window")
+			}
+			return s.metadata.fillValue, nil
+		}
+		results[i] = *points[index]
+	}
+
+	return s.aggregateResults(results), nil
+}
+
+// getSLOBurnRate returns how fast the SLO error budget is consumed over the time window,
+// a burn rate of 1 consumes the whole budget exactly by the end of the SLO timeframe
+func (s *datadogScaler) getSLOBurnRate(ctx context.Context, from, to int64) (float64, error) {
+	resp, r, err := s.apiClient.ServiceLevelObjectivesApi.GetSLOHistory(ctx, s.metadata.sloID, from, to) //nolint:bodyclose
+	if err := checkDatadogResponse(r, err); err != nil {
+		return -1, err
+	}
+
+	data := resp.GetData()
+	overall := data.GetOverall()
+	sli, ok := overall.GetSliValueOk()
+	if !ok || sli == nil {
+		if !s.metadata.useFiller {
+			return 0, fmt.Errorf("no Datadog SLO history returned for the given time window")
+		}
+		return s.metadata.fillValue, nil
+	}
+
+	target := s.metadata.sloTarget
+	if target == 0 {
+		thresholds := data.GetThresholds()
+		if len(thresholds) != 1 {
+			return -1, fmt.Errorf("SLO %s has %d thresholds, set sloTarget to select one", s.metadata.sloID, len(thresholds))
+		}
+		for _, threshold := range thresholds {
+			target = threshold.Target
+		}
+	}
+
+	return calculateSLOBurnRate(*sli, target)
+}
+
+func calculateSLOBurnRate(sli, target float64) (float64, error) {
+	if target <= 0 || target >= 100 {
+		return -1, fmt.Errorf("SLO target %v should be between 0 and 100", target)
+	}
+	burnRate := (100 - sli) / (100 - target)
+	if burnRate < 0 {
+		return 0, nil
+	}
+	return burnRate, nil
+}
+
 func (s *datadogScaler) getDatadogMetricValue(req *http.Request) (float64, error) {
 	resp, err := s.httpClient.Do(req)
 
@@ -650,6 +979,9 @@ func (s *datadogScaler) GetMetricsAndActivity(ctx context.Context, metricName st
 
 	if s.useClusterAgentProxy {
 		url := buildMetricURL(s.metadata.datadogMetricServiceURL, s.metadata.datadogMetricNamespace, s.metadata.hpaMetricName)
+		if s.metadata.clusterAgentMetric != "" {
+			url = buildClusterAgentMetricURL(s.metadata.datadogMetricServiceURL, s.metadata.datadogMetricNamespace, s.metadata.clusterAgentMetric, s.metadata.clusterAgentMetricTags)
+		}
 
 		req, err := s.getDatadogClusterAgentHTTPRequest(ctx, url)
 		if (err != nil) || (req == nil) {
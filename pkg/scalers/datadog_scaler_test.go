@@ -2,8 +2,12 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	datadog "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 
@@ -118,6 +122,12 @@ var testDatadogClusterAgentMetadata = []datadogAuthMetadataTestData{
 	{"", map[string]string{"useClusterAgentProxy": "true", "datadogMetricName": "nginx-hits", "datadogMetricNamespace": "default", "targetValue": "notanint", "type": "global"}, map[string]string{"token": "token", "datadogNamespace": "datadog", "datadogMetricsService": "datadog-cluster-agent-metrics-api", "datadogMetricsServicePort": "8080", "unsafeSsl": "true", "authMode": "bearer"}, true},
 	// wrong type
 	{"", map[string]string{"useClusterAgentProxy": "true", "datadogMetricName": "nginx-hits", "datadogMetricNamespace": "default", "targetValue": "2", "type": "notatype"}, map[string]string{"token": "token", "datadogNamespace": "datadog", "datadogMetricsService": "datadog-cluster-agent-metrics-api", "datadogMetricsServicePort": "8080", "unsafeSsl": "true", "authMode": "bearer"}, true},
+	// metric of the Cluster Agent with tags
+	{"", map[string]string{"useClusterAgentProxy": "true", "clusterAgentMetric": "nginx.net.request_per_s", "clusterAgentMetricTags": "kube_deployment=nginx,env=prod", "targetValue": "2"}, map[string]string{"token": "token", "datadogNamespace": "datadog", "datadogMetricsService": "datadog-cluster-agent-metrics-api", "authMode": "bearer"}, false},
+	// metric of the Cluster Agent with a DatadogMetric name
+	{"", map[string]string{"useClusterAgentProxy": "true", "clusterAgentMetric": "nginx.net.request_per_s", "datadogMetricName": "nginx-hits", "targetValue": "2"}, map[string]string{"datadogNamespace": "datadog", "datadogMetricsService": "datadog-cluster-agent-metrics-api"}, true},
+	// metric of the Cluster Agent with malformed tags
+	{"", map[string]string{"useClusterAgentProxy": "true", "clusterAgentMetric": "nginx.net.request_per_s", "clusterAgentMetricTags": "kube_deployment", "targetValue": "2"}, map[string]string{"datadogNamespace": "datadog", "datadogMetricsService": "datadog-cluster-agent-metrics-api"}, true},
 }
 
 var testDatadogAPIMetadata = []datadogAuthMetadataTestData{
@@ -163,6 +173,28 @@ var testDatadogAPIMetadata = []datadogAuthMetadataTestData{
 	{"", map[string]string{"query": "sum:trace.redis.command.hits{env:none,service:redis}.as_count()", "queryValue": "7"}, map[string]string{"apiKey": "apiKey"}, true},
 	// invalid query missing {
 	{"", map[string]string{"query": "sum:trace.redis.command.hits.as_count()", "queryValue": "7"}, map[string]string{}, true},
+	// invalid queryType
+	{"", map[string]string{"queryType": "logs", "query": "sum:trace.redis.command.hits{*}", "queryValue": "7"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// formula properly formed
+	{"", map[string]string{"queryType": "formula", "formula": "100 * a / b", "queries": "a:sum:trace.http.request.errors{service:web}.as_count();b:sum:trace.http.request.hits{service:web}.as_count()", "queryValue": "7"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, false},
+	// formula missing formula
+	{"", map[string]string{"queryType": "formula", "queries": "a:sum:trace.http.request.errors{service:web}", "queryValue": "7"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// formula missing queries
+	{"", map[string]string{"queryType": "formula", "formula": "a", "queryValue": "7"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// formula with malformed query name
+	{"", map[string]string{"queryType": "formula", "formula": "a", "queries": "A-1:sum:trace.http.request.errors{service:web}", "queryValue": "7"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// formula with duplicated query names
+	{"", map[string]string{"queryType": "formula", "formula": "a", "queries": "a:sum:trace.http.request.errors{*};a:sum:trace.http.request.hits{*}", "queryValue": "7"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// formula with query missing scope
+	{"", map[string]string{"queryType": "formula", "formula": "a", "queries": "a:sum:trace.http.request.errors", "queryValue": "7"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// SLO burn rate properly formed
+	{"", map[string]string{"queryType": "sloBurnRate", "sloID": "abc123", "sloTarget": "99.9", "queryValue": "2"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, false},
+	// SLO burn rate without target
+	{"", map[string]string{"queryType": "sloBurnRate", "sloID": "abc123", "queryValue": "2", "age": "300"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, false},
+	// SLO burn rate missing sloID
+	{"", map[string]string{"queryType": "sloBurnRate", "queryValue": "2"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// SLO burn rate with out of range target
+	{"", map[string]string{"queryType": "sloBurnRate", "sloID": "abc123", "sloTarget": "100", "queryValue": "2"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
 }
 
 func TestDatadogScalerAPIAuthParams(t *testing.T) {
@@ -195,6 +227,9 @@ var datadogMetricIdentifiers = []datadogMetricIdentifier{
 	{&testDatadogAPIMetadata[1], apiType, 0, "s0-datadog-sum-trace-redis-command-hits"},
 	{&testDatadogAPIMetadata[1], apiType, 1, "s1-datadog-sum-trace-redis-command-hits"},
 	{&testDatadogClusterAgentMetadata[1], clusterAgentType, 0, "datadogmetric@default:nginx-hits"},
+	{&testDatadogClusterAgentMetadata[9], clusterAgentType, 1, "s1-datadog-nginx-net-request_per_s"},
+	{&testDatadogAPIMetadata[22], apiType, 0, "s0-datadog-formula-sum-trace-http-request-errors"},
+	{&testDatadogAPIMetadata[28], apiType, 1, "s1-datadog-slo-abc123"},
 }
 
 func TestDatadogGetMetricSpecForScaling(t *testing.T) {
@@ -240,3 +275,130 @@ func TestBuildMetricURL(t *testing.T) {
 		t.Error("Expected https://localhost:8080/apis/datadoghq.com/v1alpha1/namespaces/datadogMetricNamespace/datadogMetricName, got ", url)
 	}
 }
+
+func TestBuildClusterAgentMetricURL(t *testing.T) {
+	url := buildClusterAgentMetricURL("https://localhost:8443/apis/external.metrics.k8s.io/v1beta1", "default", "Nginx.net.request_per_s", nil)
+	if url != "https://localhost:8443/apis/external.metrics.k8s.io/v1beta1/namespaces/default/nginx.net.request_per_s" {
+		t.Error("Expected https://localhost:8443/apis/external.metrics.k8s.io/v1beta1/namespaces/default/nginx.net.request_per_s, got ", url)
+	}
+
+	url = buildClusterAgentMetricURL("https://localhost:8443/apis/external.metrics.k8s.io/v1beta1", "default", "nginx.net.request_per_s", map[string]string{"kube_deployment": "nginx", "env": "prod"})
+	if url != "https://localhost:8443/apis/external.metrics.k8s.io/v1beta1/namespaces/default/nginx.net.request_per_s?labelSelector=env%3Dprod%2Ckube_deployment%3Dnginx" {
+		t.Error("Expected the tags as the sorted label selector, got ", url)
+	}
+}
+
+func TestDatadogClusterAgentMetric(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/namespaces/shop/nginx.net.request_per_s" || r.URL.Query().Get("labelSelector") != "env=prod,kube_deployment=nginx" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message": "unexpected request"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"kind": "ExternalMetricValueList", "items": [{"metricName": "nginx.net.request_per_s", "value": "2500m"}]}`))
+	}))
+	defer server.Close()
+
+	meta, err := parseDatadogClusterAgentMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata:         testDatadogClusterAgentMetadata[9].metadata,
+		AuthParams:              testDatadogClusterAgentMetadata[9].authParams,
+		ScalableObjectNamespace: "shop",
+	}, logr.Discard())
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	meta.datadogMetricServiceURL = server.URL
+	s := datadogScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard(), useClusterAgentProxy: true}
+
+	metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "MetricName")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if val := metrics[0].Value.AsApproximateFloat64(); val != 2.5 {
+		t.Errorf("Expected 2.5, got %v", val)
+	}
+	if !isActive {
+		t.Error("Expected the scaler to be active")
+	}
+}
+
+func TestDatadogGetFormulaQueryResult(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statusCode int
+		response   string
+		aggregator string
+		expected   float64
+		isError    bool
+	}{
+		{"last available point", http.StatusOK, `{"data":{"attributes":{"times":[1,2,3],"values":[[1.5,2.5,null]]}}}`, "", 2.5, false},
+		{"several series with aggregator", http.StatusOK, `{"data":{"attributes":{"times":[1,2],"values":[[1,2],[3,4]]}}}`, avgString, 3, false},
+		{"several series without aggregator", http.StatusOK, `{"data":{"attributes":{"times":[1,2],"values":[[1,2],[3,4]]}}}`, "", 0, true},
+		{"no series", http.StatusOK, `{"data":{"attributes":{"times":[],"values":[]}}}`, "", 0, true},
+		{"query errors", http.StatusOK, `{"data":{"attributes":{}},"errors":"unknown metric"}`, "", 0, true},
+		{"rate limited", http.StatusTooManyRequests, `{"errors":["rate limited"]}`, "", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v2/query/timeseries" || r.Header.Get("DD-API-KEY") != "apiKey" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				var body map[string]interface{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			configuration := datadog.NewConfiguration()
+			configuration.HTTPClient = server.Client()
+			s := datadogScaler{
+				metadata: &datadogMetadata{
+					queryType:       datadogQueryTypeFormula,
+					formula:         "a / b",
+					formulaQueries:  []datadogFormulaQuery{{"a", "sum:errors{*}"}, {"b", "sum:hits{*}"}},
+					queryAggegrator: tc.aggregator,
+					apiKey:          "apiKey",
+					appKey:          "appKey",
+					datadogAPIURL:   server.URL,
+				},
+				apiClient: datadog.NewAPIClient(configuration),
+				logger:    logr.Discard(),
+			}
+
+			val, err := s.getFormulaQueryResult(context.Background(), 0, 60)
+			if tc.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+			if !tc.isError && err != nil {
+				t.Error("Expected success but got error", err)
+			}
+			if !tc.isError && val != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, val)
+			}
+		})
+	}
+}
+
+func TestCalculateSLOBurnRate(t *testing.T) {
+	burnRate, err := calculateSLOBurnRate(99.8, 99.9)
+	if err != nil {
+		t.Error("Expected success but got error", err)
+	}
+	if burnRate < 1.999 || burnRate > 2.001 {
+		t.Errorf("Expected burn rate 2, got %v", burnRate)
+	}
+
+	burnRate, _ = calculateSLOBurnRate(100, 99.9)
+	assertEqual(t, burnRate, float64(0))
+
+	if _, err := calculateSLOBurnRate(99, 100); err == nil {
+		t.Error("Expected error but got success")
+	}
+}
@@ -1,6 +1,7 @@
 package scalers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,8 +10,12 @@ import (
 	"net/http"
 	neturl "net/url"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/tidwall/gjson"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
@@ -20,8 +25,16 @@ import (
 
 const (
 	dynatraceMetricDataPointsAPI = "api/v2/metrics/query"
+	dynatraceQueryExecuteAPI     = "platform/storage/query/v1/query:execute"
+	dynatraceQueryPollAPI        = "platform/storage/query/v1/query:poll"
+
+	// dynatraceQueryRequestTimeout is how long the query is awaited by a request before it's polled
+	dynatraceQueryRequestTimeout = 10 * time.Second
 )
 
+// dynatraceDefaultScopes are the scopes of the OAuth clients reading the metrics of Grail
+var dynatraceDefaultScopes = []string{"storage:metrics:read", "storage:buckets:read"}
+
 type dynatraceScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *dynatraceMetadata
@@ -31,12 +44,46 @@ type dynatraceScaler struct {
 
 type dynatraceMetadata struct {
 	Host                string  `keda:"name=host, order=triggerMetadata;authParams"`
-	Token               string  `keda:"name=token, order=authParams"`
-	MetricSelector      string  `keda:"name=metricSelector, order=triggerMetadata"`
+	Token               string  `keda:"name=token, order=authParams, optional"`
+	MetricSelector      string  `keda:"name=metricSelector, order=triggerMetadata, optional"`
 	FromTimestamp       string  `keda:"name=from, order=triggerMetadata, default=now-2h, optional"`
 	Threshold           float64 `keda:"name=threshold, order=triggerMetadata"`
 	ActivationThreshold float64 `keda:"name=activationThreshold, order=triggerMetadata, optional"`
 	TriggerIndex        int
+
+	// Query is a DQL query of Grail, the value is the valueField of its first record
+	Query      string `keda:"name=query, order=triggerMetadata, optional"`
+	ValueField string `keda:"name=valueField, order=triggerMetadata, optional"`
+
+	// OAuth client of the platform, the client credentials grant is used instead of the token
+	ClientID      string   `keda:"name=clientId, order=authParams;resolvedEnv, optional"`
+	ClientSecret  string   `keda:"name=clientSecret, order=authParams;resolvedEnv, optional"`
+	OauthTokenURI string   `keda:"name=oauthTokenURI, order=triggerMetadata;authParams, default=https://sso.dynatrace.com/sso/oauth2/token"`
+	Scopes        []string `keda:"name=scope, order=triggerMetadata;authParams, optional"`
+	AccountURN    string   `keda:"name=accountUrn, order=triggerMetadata;authParams, optional"`
+}
+
+func (m *dynatraceMetadata) Validate() error {
+	if (m.MetricSelector == "") == (m.Query == "") {
+		return errors.New("exactly one of metricSelector or query must be provided")
+	}
+	if m.ValueField != "" && m.Query == "" {
+		return errors.New("valueField can only be used with a query")
+	}
+	if m.ClientID != "" {
+		if m.Query == "" {
+			return errors.New("the oauth client can only be used with a query")
+		}
+		if m.ClientSecret == "" {
+			return errors.New("clientSecret is required with clientId")
+		}
+		if m.Token != "" {
+			return errors.New("token can't be used with clientId")
+		}
+	} else if m.Token == "" {
+		return errors.New("either token or clientId and clientSecret must be provided")
+	}
+	return nil
 }
 
 // Model of relevant part of Dynatrace's Metric Data Points API Response
@@ -49,6 +96,16 @@ type dynatraceResponse struct {
 	} `json:"result"`
 }
 
+// Model of relevant part of the responses of Grail's Query API, the query is either done
+// or the requestToken is polled until it is
+type dynatraceQueryResponse struct {
+	State        string `json:"state"`
+	RequestToken string `json:"requestToken"`
+	Result       struct {
+		Records []json.RawMessage `json:"records"`
+	} `json:"result"`
+}
+
 func NewDynatraceScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
@@ -63,6 +120,23 @@ func NewDynatraceScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	}
 
 	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	if meta.ClientID != "" {
+		// the token is requested with the client credentials grant and refreshed by the client when it expires
+		scopes := meta.Scopes
+		if len(scopes) == 0 {
+			scopes = dynatraceDefaultScopes
+		}
+		oauthConfig := clientcredentials.Config{
+			ClientID:     meta.ClientID,
+			ClientSecret: meta.ClientSecret,
+			TokenURL:     meta.OauthTokenURI,
+			Scopes:       scopes,
+		}
+		if meta.AccountURN != "" {
+			oauthConfig.EndpointParams = neturl.Values{"resource": {meta.AccountURN}}
+		}
+		httpClient = oauthConfig.Client(context.WithValue(context.Background(), oauth2.HTTPClient, httpClient))
+	}
 
 	logMsg := fmt.Sprintf("Initializing Dynatrace Scaler (Host: %s)", meta.Host)
 
@@ -108,6 +182,10 @@ func validateDynatraceResponse(response *dynatraceResponse) error {
 }
 
 func (s *dynatraceScaler) GetMetricValue(ctx context.Context) (float64, error) {
+	if s.metadata.Query != "" {
+		return s.getQueryValue(ctx)
+	}
+
 	/*
 	 * Build request
 	 */
@@ -168,6 +246,99 @@ func (s *dynatraceScaler) GetMetricValue(ctx context.Context) (float64, error) {
 	return dynatraceResponse.Result[0].Data[0].Values[0], nil
 }
 
+// getQueryValue executes the DQL query on Grail, polling it while it's running, and reads the value of its first record
+func (s *dynatraceScaler) getQueryValue(ctx context.Context) (float64, error) {
+	host := strings.TrimRight(s.metadata.Host, "/")
+	body, err := json.Marshal(map[string]any{
+		"query":                      s.metadata.Query,
+		"requestTimeoutMilliseconds": dynatraceQueryRequestTimeout.Milliseconds(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	response, err := s.doQueryRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%s", host, dynatraceQueryExecuteAPI), body)
+	if err != nil {
+		return 0, err
+	}
+
+	for response.State == "RUNNING" || response.State == "NOT_STARTED" {
+		if response.RequestToken == "" {
+			return 0, errors.New("dynatrace query is running without a request token")
+		}
+		params := neturl.Values{}
+		params.Set("request-token", response.RequestToken)
+		params.Set("request-timeout-milliseconds", fmt.Sprint(dynatraceQueryRequestTimeout.Milliseconds()))
+		response, err = s.doQueryRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s?%s", host, dynatraceQueryPollAPI, params.Encode()), nil)
+		if err != nil {
+			return 0, err
+		}
+	}
+	if response.State != "SUCCEEDED" {
+		return 0, fmt.Errorf("dynatrace query ended with state %s", response.State)
+	}
+
+	if len(response.Result.Records) == 0 {
+		return 0, errors.New("dynatrace query did not return any records")
+	}
+	record := response.Result.Records[0]
+	valueField := s.metadata.ValueField
+	if valueField == "" {
+		// a record of a single field holds the value
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(record, &fields); err != nil {
+			return 0, fmt.Errorf("unable to parse Dynatrace query record: %w", err)
+		}
+		if len(fields) != 1 {
+			return 0, fmt.Errorf("dynatrace query record has %d fields, valueField must be provided", len(fields))
+		}
+		for field := range fields {
+			valueField = field
+		}
+	}
+	if !gjson.GetBytes(record, gjson.Escape(valueField)).Exists() {
+		return 0, fmt.Errorf("field %s not found in the dynatrace query record", valueField)
+	}
+	return getValueFromSearch(record, gjson.Escape(valueField))
+}
+
+func (s *dynatraceScaler) doQueryRequest(ctx context.Context, method, url string, body []byte) (*dynatraceQueryResponse, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// the platform tokens are bearer tokens, the oauth client authenticates its own requests
+	if s.metadata.Token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.metadata.Token))
+	}
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if r.StatusCode != http.StatusOK && r.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: api returned %d: %s", r.Request.URL.Path, r.StatusCode, strings.TrimSpace(string(b)))
+	}
+
+	response := &dynatraceQueryResponse{}
+	if err := json.Unmarshal(b, response); err != nil {
+		return nil, fmt.Errorf("unable to parse Dynatrace Query API response: %w", err)
+	}
+	return response, nil
+}
+
 func (s *dynatraceScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
 	val, err := s.GetMetricValue(ctx)
 
@@ -2,7 +2,10 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -34,6 +37,20 @@ var testDynatraceMetadata = []dynatraceMetadataTestData{
 	{map[string]string{"threshold": "100"}, map[string]string{"host": "http://dummy:1234", "token": "dummy"}, true},
 	// missing token (must come from auth params)
 	{map[string]string{"token": "foo", "threshold": "100", "from": "now-3d", "metricSelector": "MyCustomEvent:filter(eq(\"someProperty\",\"someValue\")):count:splitBy(\"dt.entity.process_group\"):fold"}, map[string]string{"host": "http://dummy:1234"}, true},
+	// DQL query with a platform token
+	{map[string]string{"threshold": "100", "query": "timeseries avg(dt.host.cpu.usage)", "valueField": "value"}, map[string]string{"host": "https://dummy.apps.dynatrace.com", "token": "dummy"}, false},
+	// DQL query with an oauth client
+	{map[string]string{"threshold": "100", "query": "timeseries avg(dt.host.cpu.usage)"}, map[string]string{"host": "https://dummy.apps.dynatrace.com", "clientId": "dummy", "clientSecret": "dummy", "accountUrn": "urn:dtaccount:dummy"}, false},
+	// both metricSelector and query
+	{map[string]string{"threshold": "100", "query": "timeseries avg(dt.host.cpu.usage)", "metricSelector": "builtin:host.cpu.usage"}, map[string]string{"host": "https://dummy.apps.dynatrace.com", "token": "dummy"}, true},
+	// valueField without query
+	{map[string]string{"threshold": "100", "metricSelector": "builtin:host.cpu.usage", "valueField": "value"}, map[string]string{"host": "http://dummy:1234", "token": "dummy"}, true},
+	// oauth client with metricSelector
+	{map[string]string{"threshold": "100", "metricSelector": "builtin:host.cpu.usage"}, map[string]string{"host": "http://dummy:1234", "clientId": "dummy", "clientSecret": "dummy"}, true},
+	// oauth client without clientSecret
+	{map[string]string{"threshold": "100", "query": "timeseries avg(dt.host.cpu.usage)"}, map[string]string{"host": "https://dummy.apps.dynatrace.com", "clientId": "dummy"}, true},
+	// both token and oauth client
+	{map[string]string{"threshold": "100", "query": "timeseries avg(dt.host.cpu.usage)"}, map[string]string{"host": "https://dummy.apps.dynatrace.com", "token": "dummy", "clientId": "dummy", "clientSecret": "dummy"}, true},
 }
 
 var dynatraceMetricIdentifiers = []dynatraceMetricIdentifier{
@@ -72,3 +89,86 @@ func TestDynatraceGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestDynatraceQuery(t *testing.T) {
+	testCases := []struct {
+		name          string
+		valueField    string
+		records       string
+		expectedValue float64
+		expectedError bool
+	}{
+		{"valueField", "value", `[{"value": 42.5, "host": "a"}]`, 42.5, false},
+		{"single field", "", `[{"value": "12"}]`, 12, false},
+		{"several fields without valueField", "", `[{"value": 42.5, "host": "a"}]`, 0, true},
+		{"missing valueField", "count", `[{"value": 42.5}]`, 0, true},
+		{"no records", "value", `[]`, 0, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var tokenRequests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/sso/oauth2/token":
+					tokenRequests++
+					if err := r.ParseForm(); err != nil {
+						t.Fatal(err)
+					}
+					if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("resource") != "urn:dtaccount:dummy" {
+						t.Errorf("unexpected token request: %v", r.Form)
+					}
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"access_token": "oauth-token", "token_type": "Bearer", "expires_in": 300}`))
+				case "/" + dynatraceQueryExecuteAPI:
+					if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer oauth-token" {
+						t.Errorf("unexpected execute request: %s %s", r.Method, r.Header.Get("Authorization"))
+					}
+					body := map[string]any{}
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Fatal(err)
+					}
+					if body["query"] != "timeseries avg(dt.host.cpu.usage)" {
+						t.Errorf("unexpected query: %v", body["query"])
+					}
+					w.WriteHeader(http.StatusAccepted)
+					_, _ = w.Write([]byte(`{"state": "RUNNING", "requestToken": "request-1"}`))
+				case "/" + dynatraceQueryPollAPI:
+					if r.URL.Query().Get("request-token") != "request-1" {
+						t.Errorf("unexpected request token: %s", r.URL.Query().Get("request-token"))
+					}
+					_, _ = fmt.Fprintf(w, `{"state": "SUCCEEDED", "result": {"records": %s}}`, testCase.records)
+				default:
+					t.Errorf("unexpected request: %s", r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			config := &scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{"threshold": "100", "query": "timeseries avg(dt.host.cpu.usage)", "valueField": testCase.valueField},
+				AuthParams:      map[string]string{"host": server.URL, "clientId": "dummy", "clientSecret": "dummy", "oauthTokenURI": server.URL + "/sso/oauth2/token", "accountUrn": "urn:dtaccount:dummy"},
+			}
+			scaler, err := NewDynatraceScaler(config)
+			if err != nil {
+				t.Fatal("Could not create scaler:", err)
+			}
+
+			value, err := scaler.(*dynatraceScaler).GetMetricValue(context.Background())
+			if testCase.expectedError {
+				if err == nil {
+					t.Error("Expected error but got success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if value != testCase.expectedValue {
+				t.Errorf("Expected value %v but got %v", testCase.expectedValue, value)
+			}
+			if tokenRequests != 1 {
+				t.Errorf("Expected a single token request but got %d", tokenRequests)
+			}
+		})
+	}
+}
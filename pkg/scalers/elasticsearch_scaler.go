@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/go-logr/logr"
 	"github.com/tidwall/gjson"
 	v2 "k8s.io/api/autoscaling/v2"
@@ -19,6 +22,31 @@ import (
 	"github.com/kedacore/keda/v2/pkg/util"
 )
 
+const (
+	elasticsearchModeSearch     = "search"
+	elasticsearchModeNodesStats = "nodesStats"
+
+	elasticsearchThreadPoolQueue    = "queue"
+	elasticsearchThreadPoolRejected = "rejected"
+
+	elasticsearchDefaultRateWindow = 60
+)
+
+// elasticsearchRejections are the rejection counters of the thread pools of the nodes, they're kept out of
+// the scalers so the rejection rates go on being computed over the window across the rebuilds of the scalers
+var elasticsearchRejections = newCounterRateStore[elasticsearchRejectionsKey]()
+
+// elasticsearchRejectionsKey identifies the rejection counter of a thread pool of a node, sampled for a rate window
+type elasticsearchRejectionsKey struct {
+	node       string
+	threadPool string
+	rateWindow time.Duration
+}
+
+func (k elasticsearchRejectionsKey) window() time.Duration {
+	return k.rateWindow
+}
+
 type elasticsearchScaler struct {
 	metricType v2.MetricTargetType
 	metadata   elasticsearchMetadata
@@ -33,10 +61,17 @@ type elasticsearchMetadata struct {
 	Password              string   `keda:"name=password,              order=authParams;resolvedEnv;triggerMetadata, optional"`
 	CloudID               string   `keda:"name=cloudID,               order=authParams;triggerMetadata, optional"`
 	APIKey                string   `keda:"name=apiKey,                order=authParams;triggerMetadata, optional"`
-	Index                 []string `keda:"name=index,                 order=authParams;triggerMetadata, separator=;"`
-	SearchTemplateName    string   `keda:"name=searchTemplateName,    order=authParams;triggerMetadata"`
+	Mode                  string   `keda:"name=mode,                  order=triggerMetadata, enum=search;nodesStats, optional"`
+	Index                 []string `keda:"name=index,                 order=authParams;triggerMetadata, optional, separator=;"`
+	SearchTemplateName    string   `keda:"name=searchTemplateName,    order=authParams;triggerMetadata, optional"`
 	Parameters            []string `keda:"name=parameters,            order=triggerMetadata, optional, separator=;"`
-	ValueLocation         string   `keda:"name=valueLocation,         order=authParams;triggerMetadata"`
+	Query                 string   `keda:"name=query,                 order=authParams;triggerMetadata, optional"`
+	RuntimeMappings       string   `keda:"name=runtimeMappings,       order=authParams;triggerMetadata, optional"`
+	ValueLocation         string   `keda:"name=valueLocation,         order=authParams;triggerMetadata, optional"`
+	ThreadPools           []string `keda:"name=threadPools,           order=triggerMetadata, optional"`
+	ThreadPoolMetric      string   `keda:"name=threadPoolMetric,      order=triggerMetadata, enum=queue;rejected, optional"`
+	Nodes                 []string `keda:"name=nodes,                 order=triggerMetadata, optional"`
+	RateWindow            int      `keda:"name=rateWindow,            order=triggerMetadata, optional"`
 	TargetValue           float64  `keda:"name=targetValue,           order=authParams;triggerMetadata"`
 	ActivationTargetValue float64  `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
 	MetricName            string   `keda:"name=metricName,            order=triggerMetadata, optional"`
@@ -45,6 +80,48 @@ type elasticsearchMetadata struct {
 }
 
 func (m *elasticsearchMetadata) Validate() error {
+	// the parameters required by the mode are reported along with the connection errors
+	var errs []error
+	if m.Mode == elasticsearchModeNodesStats {
+		if m.SearchTemplateName != "" || m.Query != "" {
+			errs = append(errs, fmt.Errorf("searchTemplateName and query can't be used with mode nodesStats"))
+		}
+		if len(m.ThreadPools) == 0 {
+			m.ThreadPools = []string{"search"}
+		}
+		if m.ThreadPoolMetric == "" {
+			m.ThreadPoolMetric = elasticsearchThreadPoolQueue
+		}
+		if m.ThreadPoolMetric == elasticsearchThreadPoolRejected && m.RateWindow == 0 {
+			m.RateWindow = elasticsearchDefaultRateWindow
+		}
+	} else {
+		if len(m.ThreadPools) > 0 || m.ThreadPoolMetric != "" || len(m.Nodes) > 0 {
+			errs = append(errs, fmt.Errorf("threadPools, threadPoolMetric and nodes can only be used with mode nodesStats"))
+		}
+		if len(m.Index) == 0 {
+			errs = append(errs, fmt.Errorf("missing required parameter \"index\" with mode search"))
+		}
+		if m.ValueLocation == "" {
+			errs = append(errs, fmt.Errorf("missing required parameter \"valueLocation\" with mode search"))
+		}
+	}
+	if m.RateWindow != 0 && m.ThreadPoolMetric != elasticsearchThreadPoolRejected {
+		errs = append(errs, fmt.Errorf("rateWindow can only be used with threadPoolMetric rejected"))
+	} else if m.RateWindow < 0 {
+		errs = append(errs, fmt.Errorf("rateWindow must be greater than 0"))
+	}
+	if err := m.validateConnection(); err != nil {
+		errs = append(errs, err)
+	} else if m.Mode != elasticsearchModeNodesStats {
+		if err := m.validateSearch(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *elasticsearchMetadata) validateConnection() error {
 	if (m.CloudID != "" || m.APIKey != "") && (len(m.Addresses) > 0 || m.Username != "" || m.Password != "") {
 		return fmt.Errorf("can't provide both cloud config and endpoint addresses")
 	}
@@ -60,6 +137,37 @@ func (m *elasticsearchMetadata) Validate() error {
 	return nil
 }
 
+func (m *elasticsearchMetadata) validateSearch() error {
+	if m.SearchTemplateName == "" && m.Query == "" {
+		return fmt.Errorf("either searchTemplateName or query must be provided")
+	}
+	if m.SearchTemplateName != "" && m.Query != "" {
+		return fmt.Errorf("searchTemplateName and query can't be provided at the same time")
+	}
+	if m.Query != "" {
+		if len(m.Parameters) > 0 {
+			return fmt.Errorf("parameters can only be used with searchTemplateName")
+		}
+		if err := validateJSONObject(m.Query); err != nil {
+			return fmt.Errorf("query must be a valid JSON object: %w", err)
+		}
+	}
+	if m.RuntimeMappings != "" {
+		if m.Query == "" {
+			return fmt.Errorf("runtimeMappings can only be used with query")
+		}
+		if err := validateJSONObject(m.RuntimeMappings); err != nil {
+			return fmt.Errorf("runtimeMappings must be a valid JSON object: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateJSONObject(value string) error {
+	var obj map[string]interface{}
+	return json.Unmarshal([]byte(value), &obj)
+}
+
 func NewElasticsearchScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
@@ -93,7 +201,14 @@ func parseElasticsearchMetadata(config *scalersconfig.ScalerConfig) (elasticsear
 		return meta, err
 	}
 
-	meta.MetricName = GenerateMetricNameWithIndex(config.TriggerIndex, util.NormalizeString(fmt.Sprintf("elasticsearch-%s", meta.SearchTemplateName)))
+	metricName := meta.SearchTemplateName
+	switch {
+	case meta.Mode == elasticsearchModeNodesStats:
+		metricName = fmt.Sprintf("nodes-stats-%s-%s", strings.Join(meta.ThreadPools, "-"), meta.ThreadPoolMetric)
+	case meta.Query != "":
+		metricName = "query"
+	}
+	meta.MetricName = GenerateMetricNameWithIndex(config.TriggerIndex, util.NormalizeString(fmt.Sprintf("elasticsearch-%s", metricName)))
 	meta.TriggerIndex = config.TriggerIndex
 
 	return meta, nil
@@ -130,24 +245,45 @@ func newElasticsearchClient(meta elasticsearchMetadata, logger logr.Logger) (*el
 	return esClient, nil
 }
 
+// IsStateful returns whether the rejection rate is computed from the counters of the previous evaluations
+func (s *elasticsearchScaler) IsStateful() bool {
+	return s.metadata.ThreadPoolMetric == elasticsearchThreadPoolRejected
+}
+
 func (s *elasticsearchScaler) Close(_ context.Context) error {
 	return nil
 }
 
 // getQueryResult returns result of the scaler query
 func (s *elasticsearchScaler) getQueryResult(ctx context.Context) (float64, error) {
-	// Build the request body.
-	var body bytes.Buffer
-	if err := json.NewEncoder(&body).Encode(buildQuery(&s.metadata)); err != nil {
-		s.logger.Error(err, "Error encoding query: %s", err)
-	}
-
-	// Run the templated search
-	res, err := s.esClient.SearchTemplate(
-		&body,
-		s.esClient.SearchTemplate.WithIndex(s.metadata.Index...),
-		s.esClient.SearchTemplate.WithContext(ctx),
-	)
+	var res *esapi.Response
+	var err error
+
+	if s.metadata.Query != "" {
+		// Run the inline search
+		body, buildErr := buildSearchBody(&s.metadata)
+		if buildErr != nil {
+			return 0, buildErr
+		}
+		res, err = s.esClient.Search(
+			s.esClient.Search.WithBody(body),
+			s.esClient.Search.WithIndex(s.metadata.Index...),
+			s.esClient.Search.WithContext(ctx),
+		)
+	} else {
+		// Build the request body.
+		var body bytes.Buffer
+		if err := json.NewEncoder(&body).Encode(buildQuery(&s.metadata)); err != nil {
+			s.logger.Error(err, "Error encoding query: %s", err)
+		}
+
+		// Run the templated search
+		res, err = s.esClient.SearchTemplate(
+			&body,
+			s.esClient.SearchTemplate.WithIndex(s.metadata.Index...),
+			s.esClient.SearchTemplate.WithContext(ctx),
+		)
+	}
 	if err != nil {
 		s.logger.Error(err, fmt.Sprintf("Could not query elasticsearch: %s", err))
 		return 0, err
@@ -158,6 +294,9 @@ func (s *elasticsearchScaler) getQueryResult(ctx context.Context) (float64, erro
 	if err != nil {
 		return 0, err
 	}
+	if res.IsError() {
+		return 0, fmt.Errorf("elasticsearch search failed with status %s: %s", res.Status(), string(b))
+	}
 	v, err := getValueFromSearch(b, s.metadata.ValueLocation)
 	if err != nil {
 		return 0, err
@@ -165,13 +304,87 @@ func (s *elasticsearchScaler) getQueryResult(ctx context.Context) (float64, erro
 	return v, nil
 }
 
+// elasticsearchNodesStats is the part of the nodes stats read by the scaler
+type elasticsearchNodesStats struct {
+	Nodes map[string]struct {
+		Name       string `json:"name"`
+		ThreadPool map[string]struct {
+			Queue    int64 `json:"queue"`
+			Rejected int64 `json:"rejected"`
+		} `json:"thread_pool"`
+	} `json:"nodes"`
+}
+
+// getNodesStatsResult returns the tasks queued by the thread pools of the nodes, or the rate of the
+// tasks they rejected
+func (s *elasticsearchScaler) getNodesStatsResult(ctx context.Context) (float64, error) {
+	options := []func(*esapi.NodesStatsRequest){
+		s.esClient.Nodes.Stats.WithMetric("thread_pool"),
+		s.esClient.Nodes.Stats.WithFilterPath("nodes.*.name", "nodes.*.thread_pool"),
+		s.esClient.Nodes.Stats.WithContext(ctx),
+	}
+	if len(s.metadata.Nodes) > 0 {
+		options = append(options, s.esClient.Nodes.Stats.WithNodeID(s.metadata.Nodes...))
+	}
+	res, err := s.esClient.Nodes.Stats(options...)
+	if err != nil {
+		s.logger.Error(err, fmt.Sprintf("Could not get elasticsearch nodes stats: %s", err))
+		return 0, err
+	}
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, err
+	}
+	if res.IsError() {
+		return 0, fmt.Errorf("elasticsearch nodes stats failed with status %s: %s", res.Status(), string(b))
+	}
+	stats := elasticsearchNodesStats{}
+	if err := json.Unmarshal(b, &stats); err != nil {
+		return 0, fmt.Errorf("error decoding nodes stats: %w", err)
+	}
+	return s.sumThreadPools(stats, time.Now())
+}
+
+// sumThreadPools sums the metric of the thread pools over the nodes. The rejections are cumulative
+// counters, their rates per second over the rate window are summed: a node counts once its counter
+// has been polled for the window, since it was first polled or restarted.
+func (s *elasticsearchScaler) sumThreadPools(stats elasticsearchNodesStats, now time.Time) (float64, error) {
+	var sum float64
+	for id, node := range stats.Nodes {
+		for _, name := range s.metadata.ThreadPools {
+			pool, ok := node.ThreadPool[name]
+			if !ok {
+				return 0, fmt.Errorf("thread pool %q not found on node %s", name, node.Name)
+			}
+			if s.metadata.ThreadPoolMetric == elasticsearchThreadPoolQueue {
+				sum += float64(pool.Queue)
+				continue
+			}
+
+			key := elasticsearchRejectionsKey{node: id, threadPool: name, rateWindow: time.Duration(s.metadata.RateWindow) * time.Second}
+			if rate, ok := elasticsearchRejections.rate(key, float64(pool.Rejected), now, time.Time{}); ok {
+				sum += rate
+			}
+		}
+	}
+	if s.metadata.ThreadPoolMetric == elasticsearchThreadPoolRejected {
+		elasticsearchRejections.expire(now)
+	}
+	return sum, nil
+}
+
 func buildQuery(metadata *elasticsearchMetadata) map[string]interface{} {
 	parameters := map[string]interface{}{}
 	for _, p := range metadata.Parameters {
 		if p != "" {
-			kv := strings.Split(p, ":")
+			kv := strings.SplitN(p, ":", 2)
 			key := strings.TrimSpace(kv[0])
-			value := strings.TrimSpace(kv[1])
+			value := ""
+			if len(kv) > 1 {
+				value = strings.TrimSpace(kv[1])
+			}
 			parameters[key] = value
 		}
 	}
@@ -184,6 +397,28 @@ func buildQuery(metadata *elasticsearchMetadata) map[string]interface{} {
 	return query
 }
 
+// buildSearchBody returns the inline search body, with the runtime mappings
+// (if any) injected so the query can reference fields computed at search time
+func buildSearchBody(metadata *elasticsearchMetadata) (io.Reader, error) {
+	body := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(metadata.Query), &body); err != nil {
+		return nil, fmt.Errorf("error decoding query: %w", err)
+	}
+	if metadata.RuntimeMappings != "" {
+		runtimeMappings := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(metadata.RuntimeMappings), &runtimeMappings); err != nil {
+			return nil, fmt.Errorf("error decoding runtimeMappings: %w", err)
+		}
+		body["runtime_mappings"] = runtimeMappings
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return nil, fmt.Errorf("error encoding query: %w", err)
+	}
+	return &buf, nil
+}
+
 func getValueFromSearch(body []byte, valueLocation string) (float64, error) {
 	r := gjson.GetBytes(body, valueLocation)
 	errorMsg := "valueLocation must point to value of type number but got: '%s'"
@@ -216,7 +451,13 @@ func (s *elasticsearchScaler) GetMetricSpecForScaling(context.Context) []v2.Metr
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *elasticsearchScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	num, err := s.getQueryResult(ctx)
+	var num float64
+	var err error
+	if s.metadata.Mode == elasticsearchModeNodesStats {
+		num, err = s.getNodesStatsResult(ctx)
+	} else {
+		num, err = s.getQueryResult(ctx)
+	}
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error inspecting elasticsearch: %w", err)
 	}
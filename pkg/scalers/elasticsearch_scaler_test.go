@@ -3,8 +3,14 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -73,13 +79,15 @@ var testCases = []parseElasticsearchMetadataTestData{
 		expectedError: fmt.Errorf("missing required parameter \"index\""),
 	},
 	{
-		name: "no searchTemplateName given",
+		name: "no searchTemplateName or query given",
 		metadata: map[string]string{
-			"addresses": "http://localhost:9200",
-			"index":     "index1",
+			"addresses":     "http://localhost:9200",
+			"index":         "index1",
+			"valueLocation": "hits.total.value",
+			"targetValue":   "12",
 		},
-		authParams:    map[string]string{"username": "admin"},
-		expectedError: fmt.Errorf("missing required parameter \"searchTemplateName\""),
+		authParams:    map[string]string{"username": "admin", "password": "password"},
+		expectedError: fmt.Errorf("either searchTemplateName or query must be provided"),
 	},
 	{
 		name: "no valueLocation given",
@@ -306,6 +314,181 @@ var testCases = []parseElasticsearchMetadataTestData{
 		},
 		expectedError: nil,
 	},
+	{
+		name: "inline query with runtime mappings",
+		metadata: map[string]string{
+			"addresses":       "http://localhost:9200",
+			"index":           "index1",
+			"query":           `{"size": 0, "query": {"range": {"lag": {"gte": 10}}}}`,
+			"runtimeMappings": `{"lag": {"type": "long", "script": "emit(doc['processed'].value - doc['created'].value)"}}`,
+			"valueLocation":   "hits.total.value",
+			"targetValue":     "12",
+		},
+		authParams: map[string]string{
+			"username": "admin",
+			"password": "password",
+		},
+		expectedMetadata: &elasticsearchMetadata{
+			Addresses:       []string{"http://localhost:9200"},
+			Index:           []string{"index1"},
+			Username:        "admin",
+			Password:        "password",
+			Query:           `{"size": 0, "query": {"range": {"lag": {"gte": 10}}}}`,
+			RuntimeMappings: `{"lag": {"type": "long", "script": "emit(doc['processed'].value - doc['created'].value)"}}`,
+			ValueLocation:   "hits.total.value",
+			TargetValue:     12,
+			MetricName:      "s0-elasticsearch-query",
+		},
+		expectedError: nil,
+	},
+	{
+		name: "searchTemplateName and query given",
+		metadata: map[string]string{
+			"addresses":          "http://localhost:9200",
+			"index":              "index1",
+			"searchTemplateName": "myAwesomeSearch",
+			"query":              `{"size": 0}`,
+			"valueLocation":      "hits.total.value",
+			"targetValue":        "12",
+		},
+		authParams:    map[string]string{"username": "admin", "password": "password"},
+		expectedError: fmt.Errorf("searchTemplateName and query can't be provided at the same time"),
+	},
+	{
+		name: "invalid query",
+		metadata: map[string]string{
+			"addresses":     "http://localhost:9200",
+			"index":         "index1",
+			"query":         `{"size": 0`,
+			"valueLocation": "hits.total.value",
+			"targetValue":   "12",
+		},
+		authParams:    map[string]string{"username": "admin", "password": "password"},
+		expectedError: fmt.Errorf("query must be a valid JSON object"),
+	},
+	{
+		name: "parameters with query",
+		metadata: map[string]string{
+			"addresses":     "http://localhost:9200",
+			"index":         "index1",
+			"query":         `{"size": 0}`,
+			"parameters":    "param1:value1",
+			"valueLocation": "hits.total.value",
+			"targetValue":   "12",
+		},
+		authParams:    map[string]string{"username": "admin", "password": "password"},
+		expectedError: fmt.Errorf("parameters can only be used with searchTemplateName"),
+	},
+	{
+		name: "runtimeMappings with searchTemplateName",
+		metadata: map[string]string{
+			"addresses":          "http://localhost:9200",
+			"index":              "index1",
+			"searchTemplateName": "myAwesomeSearch",
+			"runtimeMappings":    `{"lag": {"type": "long"}}`,
+			"valueLocation":      "hits.total.value",
+			"targetValue":        "12",
+		},
+		authParams:    map[string]string{"username": "admin", "password": "password"},
+		expectedError: fmt.Errorf("runtimeMappings can only be used with query"),
+	},
+	{
+		name: "nodes stats with the default thread pool",
+		metadata: map[string]string{
+			"addresses":   "http://localhost:9200",
+			"mode":        "nodesStats",
+			"targetValue": "100",
+		},
+		authParams: map[string]string{
+			"username": "admin",
+			"password": "password",
+		},
+		expectedMetadata: &elasticsearchMetadata{
+			Addresses:        []string{"http://localhost:9200"},
+			Username:         "admin",
+			Password:         "password",
+			Mode:             "nodesStats",
+			ThreadPools:      []string{"search"},
+			ThreadPoolMetric: "queue",
+			TargetValue:      100,
+			MetricName:       "s0-elasticsearch-nodes-stats-search-queue",
+		},
+		expectedError: nil,
+	},
+	{
+		name: "nodes stats rejections of the data nodes",
+		metadata: map[string]string{
+			"addresses":        "http://localhost:9200",
+			"mode":             "nodesStats",
+			"threadPools":      "search, write",
+			"threadPoolMetric": "rejected",
+			"nodes":            "data:true",
+			"targetValue":      "1",
+		},
+		authParams: map[string]string{
+			"username": "admin",
+			"password": "password",
+		},
+		expectedMetadata: &elasticsearchMetadata{
+			Addresses:        []string{"http://localhost:9200"},
+			Username:         "admin",
+			Password:         "password",
+			Mode:             "nodesStats",
+			ThreadPools:      []string{"search", "write"},
+			ThreadPoolMetric: "rejected",
+			Nodes:            []string{"data:true"},
+			RateWindow:       60,
+			TargetValue:      1,
+			MetricName:       "s0-elasticsearch-nodes-stats-search-write-rejected",
+		},
+		expectedError: nil,
+	},
+	{
+		name: "rate window of the queued tasks",
+		metadata: map[string]string{
+			"addresses":   "http://localhost:9200",
+			"mode":        "nodesStats",
+			"rateWindow":  "30",
+			"targetValue": "100",
+		},
+		authParams:    map[string]string{"username": "admin", "password": "password"},
+		expectedError: fmt.Errorf("rateWindow can only be used with threadPoolMetric rejected"),
+	},
+	{
+		name: "nodes stats with a search template",
+		metadata: map[string]string{
+			"addresses":          "http://localhost:9200",
+			"mode":               "nodesStats",
+			"searchTemplateName": "myAwesomeSearch",
+			"targetValue":        "100",
+		},
+		authParams:    map[string]string{"username": "admin", "password": "password"},
+		expectedError: fmt.Errorf("searchTemplateName and query can't be used with mode nodesStats"),
+	},
+	{
+		name: "thread pools with mode search",
+		metadata: map[string]string{
+			"addresses":          "http://localhost:9200",
+			"index":              "index1",
+			"searchTemplateName": "myAwesomeSearch",
+			"threadPools":        "write",
+			"valueLocation":      "hits.total.value",
+			"targetValue":        "12",
+		},
+		authParams:    map[string]string{"username": "admin", "password": "password"},
+		expectedError: fmt.Errorf("threadPools, threadPoolMetric and nodes can only be used with mode nodesStats"),
+	},
+	{
+		name: "invalid threadPoolMetric",
+		metadata: map[string]string{
+			"addresses":        "http://localhost:9200",
+			"mode":             "nodesStats",
+			"threadPoolMetric": "completed",
+			"targetValue":      "100",
+		},
+		authParams:    map[string]string{"username": "admin", "password": "password"},
+		expectedError: fmt.Errorf("parameter \"threadPoolMetric\" value \"completed\" must be one of [queue rejected]"),
+	},
 }
 
 func TestParseElasticsearchMetadata(t *testing.T) {
@@ -428,6 +611,27 @@ func TestBuildQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "param values can contain colons",
+			metadata: map[string]string{
+				"addresses":          "http://localhost:9200",
+				"index":              "index1",
+				"searchTemplateName": "myAwesomeSearch",
+				"parameters":         "since:2024-01-01T00:00:00Z",
+				"valueLocation":      "hits.hits[0]._source.value",
+				"targetValue":        "12",
+			},
+			authParams: map[string]string{
+				"username": "admin",
+				"password": "password",
+			},
+			expectedQuery: map[string]interface{}{
+				"id": "myAwesomeSearch",
+				"params": map[string]interface{}{
+					"since": "2024-01-01T00:00:00Z",
+				},
+			},
+		},
 		{
 			name: "params are trimmed",
 			metadata: map[string]string{
@@ -463,6 +667,28 @@ func TestBuildQuery(t *testing.T) {
 	}
 }
 
+func TestBuildSearchBody(t *testing.T) {
+	metadata, err := parseElasticsearchMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"addresses":       "http://localhost:9200",
+			"index":           "index1",
+			"query":           `{"size": 0, "query": {"range": {"lag": {"gte": 10}}}}`,
+			"runtimeMappings": `{"lag": {"type": "long", "script": "emit(1)"}}`,
+			"valueLocation":   "hits.total.value",
+			"targetValue":     "12",
+		},
+		AuthParams: map[string]string{"username": "admin", "password": "password"},
+	})
+	assert.NoError(t, err)
+
+	body, err := buildSearchBody(&metadata)
+	assert.NoError(t, err)
+
+	b, err := io.ReadAll(body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"size": 0, "query": {"range": {"lag": {"gte": 10}}}, "runtime_mappings": {"lag": {"type": "long", "script": "emit(1)"}}}`, string(b))
+}
+
 func TestElasticsearchGetMetricSpecForScaling(t *testing.T) {
 	var elasticsearchMetricIdentifiers = []elasticsearchMetricIdentifier{
 		{&testCases[7], 0, "s0-elasticsearch-myAwesomeSearch"},
@@ -489,3 +715,67 @@ func TestElasticsearchGetMetricSpecForScaling(t *testing.T) {
 		assert.Equal(t, metricSpec[0].External.Metric.Name, testData.name)
 	}
 }
+
+func TestElasticsearchGetNodesStatsResult(t *testing.T) {
+	nodesStats := `{"nodes": {
+		"node-1": {"name": "es-0", "thread_pool": {"search": {"queue": 4, "rejected": 10}, "write": {"queue": 1, "rejected": 3}}},
+		"node-2": {"name": "es-1", "thread_pool": {"search": {"queue": 2, "rejected": 5}, "write": {"queue": 0, "rejected": 0}}}
+	}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `{"version": {"number": "7.17.0"}, "tagline": "You Know, for Search"}`)
+		case "/_nodes/data:true/stats/thread_pool":
+			fmt.Fprint(w, nodesStats)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	newScaler := func(metadata map[string]string) *elasticsearchScaler {
+		metadata["addresses"] = server.URL
+		metadata["mode"] = "nodesStats"
+		metadata["nodes"] = "data:true"
+		metadata["targetValue"] = "1"
+		meta, err := parseElasticsearchMetadata(&scalersconfig.ScalerConfig{
+			TriggerMetadata: metadata,
+			AuthParams:      map[string]string{"username": "admin", "password": "password"},
+		})
+		assert.NoError(t, err)
+		esClient, err := newElasticsearchClient(meta, logr.Discard())
+		assert.NoError(t, err)
+		return &elasticsearchScaler{metadata: meta, esClient: esClient, logger: logr.Discard()}
+	}
+
+	scaler := newScaler(map[string]string{"threadPools": "search,write"})
+	value, err := scaler.getNodesStatsResult(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(7), value)
+
+	scaler = newScaler(map[string]string{"threadPools": "search,write", "threadPoolMetric": "rejected"})
+	value, err = scaler.getNodesStatsResult(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), value, "the rate is unknown until the counters have been polled for the window")
+
+	elasticsearchRejections.lock.Lock()
+	for key, samples := range elasticsearchRejections.samples {
+		for i := range samples {
+			samples[i].time = samples[i].time.Add(-100 * time.Second)
+		}
+		elasticsearchRejections.samples[key] = samples
+	}
+	elasticsearchRejections.lock.Unlock()
+	nodesStats = strings.NewReplacer(`"rejected": 10`, `"rejected": 16`, `"rejected": 5`, `"rejected": 1`).Replace(nodesStats)
+	// the counters are kept across the rebuilds of the scaler, the counter of es-1 was reset
+	scaler = newScaler(map[string]string{"threadPools": "search,write", "threadPoolMetric": "rejected"})
+	value, err = scaler.getNodesStatsResult(context.Background())
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.06, value, 0.001)
+
+	scaler = newScaler(map[string]string{"threadPools": "get"})
+	_, err = scaler.getNodesStatsResult(context.Background())
+	assert.ErrorContains(t, err, `thread pool "get" not found on node`)
+}
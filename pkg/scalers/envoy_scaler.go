@@ -0,0 +1,331 @@
+package scalers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// envoySourceAdmin reads the stats of the admin interface of Envoy
+	envoySourceAdmin = "admin"
+	// envoySourceIstio reads the stats merged by the Istio agent in the Prometheus format, the admin
+	// interface of the sidecars only listens on localhost
+	envoySourceIstio = "istio"
+
+	envoyDefaultAdminPort = 9901
+	envoyDefaultIstioPort = 15090
+
+	envoyActiveRequests = "activeRequests"
+	envoyRequestRate    = "requestRate"
+)
+
+// envoyRequestTotals are the request counters scraped of the pods, they're kept out of the scalers
+// so the request rates go on being computed over the window across the rebuilds of the scalers
+var envoyRequestTotals = newCounterRateStore[envoyCounterKey]()
+
+type envoyScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *envoyMetadata
+	kubeClient client.Client
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+// envoyCounterKey identifies the request counter of a cluster of a pod, sampled for a rate window
+type envoyCounterKey struct {
+	pod        types.UID
+	source     string
+	cluster    string
+	rateWindow time.Duration
+}
+
+func (k envoyCounterKey) window() time.Duration {
+	return k.rateWindow
+}
+
+type envoyMetadata struct {
+	// PodSelector selects the pods of the namespace of the ScaledObject or ScaledJob whose Envoy is scraped
+	PodSelector string `keda:"name=podSelector, order=triggerMetadata"`
+	// Cluster is the Envoy cluster of the requests, e.g. inbound|8080|| for the requests received by an Istio sidecar
+	Cluster string `keda:"name=cluster,     order=triggerMetadata"`
+	Source  string `keda:"name=source,      order=triggerMetadata, enum=admin;istio, default=admin"`
+	Port    int    `keda:"name=port,        order=triggerMetadata, optional"`
+	Metric  string `keda:"name=metric,      order=triggerMetadata, enum=activeRequests;requestRate, default=activeRequests"`
+	// RateWindow is the minimum number of seconds the request rate is computed over, whatever the
+	// interval between the evaluations
+	RateWindow int `keda:"name=rateWindow, order=triggerMetadata, default=60"`
+
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	namespace    string
+	podSelector  labels.Selector
+	triggerIndex int
+}
+
+func (m *envoyMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return fmt.Errorf("targetValue must be greater than 0")
+	}
+	if m.Port < 0 || m.Port > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535")
+	}
+	if m.RateWindow <= 0 {
+		return fmt.Errorf("rateWindow must be greater than 0")
+	}
+	return nil
+}
+
+// NewEnvoyScaler creates a new scaler for the requests of an Envoy cluster, read from the stats of
+// the Envoy proxies of the pods without a Prometheus
+func NewEnvoyScaler(kubeClient client.Client, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseEnvoyMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing envoy metadata: %w", err)
+	}
+
+	return &envoyScaler{
+		metricType: metricType,
+		metadata:   meta,
+		kubeClient: kubeClient,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		logger:     InitializeLogger(config, "envoy_scaler"),
+	}, nil
+}
+
+func parseEnvoyMetadata(config *scalersconfig.ScalerConfig) (*envoyMetadata, error) {
+	meta := &envoyMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing envoy metadata: %w", err)
+	}
+
+	podSelector, err := labels.Parse(meta.PodSelector)
+	if err != nil || podSelector.Empty() {
+		return nil, fmt.Errorf("invalid pod selector %q", meta.PodSelector)
+	}
+	meta.podSelector = podSelector
+
+	if meta.Port == 0 {
+		switch meta.Source {
+		case envoySourceAdmin:
+			meta.Port = envoyDefaultAdminPort
+		case envoySourceIstio:
+			meta.Port = envoyDefaultIstioPort
+		}
+	}
+	meta.namespace = config.ScalableObjectNamespace
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// statsURL returns the url of the stats of the cluster of the Envoy of the pod
+func (s *envoyScaler) statsURL(pod *corev1.Pod) string {
+	host := net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(s.metadata.Port))
+	if s.metadata.Source == envoySourceIstio {
+		return fmt.Sprintf("http://%s/stats/prometheus", host)
+	}
+	filter := "^cluster\\." + regexp.QuoteMeta(s.metadata.Cluster) + "\\.upstream_rq_(active|total)$"
+	return fmt.Sprintf("http://%s/stats?filter=%s", host, neturl.QueryEscape(filter))
+}
+
+// scrape returns the active requests and the total of the requests of the cluster in the Envoy of the pod
+func (s *envoyScaler) scrape(ctx context.Context, pod *corev1.Pod) (active float64, total float64, err error) {
+	body, err := getPodEndpoint(ctx, s.httpClient, pod, s.statsURL(pod))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if s.metadata.Source == envoySourceIstio {
+		return getEnvoyPrometheusStats(body, s.metadata.Cluster)
+	}
+	return getEnvoyAdminStats(body, s.metadata.Cluster)
+}
+
+// getEnvoyAdminStats reads the stats of the cluster in the text format of the admin interface, name: value
+func getEnvoyAdminStats(body []byte, cluster string) (active float64, total float64, err error) {
+	prefix := "cluster." + cluster + "."
+	found := false
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), ": ")
+		if !ok {
+			continue
+		}
+		stat, ok := strings.CutPrefix(name, prefix)
+		if !ok || (stat != "upstream_rq_active" && stat != "upstream_rq_total") {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("error parsing %s: %w", name, err)
+		}
+		if stat == "upstream_rq_active" {
+			active = v
+		} else {
+			total = v
+		}
+		found = true
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, err
+	}
+	if !found {
+		return 0, 0, fmt.Errorf("no stat of the cluster %s", cluster)
+	}
+	return active, total, nil
+}
+
+// getEnvoyPrometheusStats reads the stats of the cluster in the Prometheus format of the Istio agent
+func getEnvoyPrometheusStats(body []byte, cluster string) (active float64, total float64, err error) {
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return 0, 0, fmt.Errorf("error parsing the stats: %w", err)
+	}
+
+	found := false
+	for _, metric := range families["envoy_cluster_upstream_rq_active"].GetMetric() {
+		if envoyMetricCluster(metric.GetLabel()) == cluster {
+			active += metric.GetGauge().GetValue()
+			found = true
+		}
+	}
+	for _, metric := range families["envoy_cluster_upstream_rq_total"].GetMetric() {
+		if envoyMetricCluster(metric.GetLabel()) == cluster {
+			total += metric.GetCounter().GetValue()
+			found = true
+		}
+	}
+	if !found {
+		return 0, 0, fmt.Errorf("no stat of the cluster %s", cluster)
+	}
+	return active, total, nil
+}
+
+// envoyMetricCluster returns the cluster of a stat in the Prometheus format
+func envoyMetricCluster(labels []*dto.LabelPair) string {
+	for _, label := range labels {
+		if label.GetName() == "cluster_name" {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+// GetMetricValue returns the active requests or the request rate of the cluster, summed over the pods
+func (s *envoyScaler) GetMetricValue(ctx context.Context) (float64, error) {
+	pods, err := listScrapedPods(ctx, s.kubeClient, s.metadata.namespace, s.metadata.podSelector)
+	if err != nil {
+		return -1, err
+	}
+
+	type podStats struct {
+		active float64
+		total  float64
+		err    error
+	}
+	stats := make([]podStats, len(pods))
+	scrapePods(pods, func(i int, pod *corev1.Pod) {
+		stats[i].active, stats[i].total, stats[i].err = s.scrape(ctx, pod)
+	})
+
+	now := time.Now()
+	value := 0.0
+	var failed []string
+	var lastErr error
+	for i, pod := range pods {
+		if stats[i].err != nil {
+			failed = append(failed, pod.Name)
+			lastErr = stats[i].err
+			continue
+		}
+		if s.metadata.Metric == envoyActiveRequests {
+			value += stats[i].active
+			continue
+		}
+		key := envoyCounterKey{pod: pod.UID, source: s.metadata.Source, cluster: s.metadata.Cluster, rateWindow: time.Duration(s.metadata.RateWindow) * time.Second}
+		var startedAt time.Time
+		if pod.Status.StartTime != nil {
+			startedAt = pod.Status.StartTime.Time
+		}
+		// the new pods count from their start, the others once their counter has been scraped for the window
+		if rate, ok := envoyRequestTotals.rate(key, stats[i].total, now, startedAt); ok {
+			value += rate
+		}
+	}
+	envoyRequestTotals.expire(now)
+
+	if len(failed) > 0 {
+		if len(failed) == len(pods) {
+			return -1, fmt.Errorf("error scraping the envoy of all the %d pods: %w", len(pods), lastErr)
+		}
+		s.logger.Error(lastErr, "error scraping the envoy of some pods, they aren't counted", "pods", failed)
+	}
+	return value, nil
+}
+
+// GetWatchedPods returns the pods whose Envoy is scraped
+func (s *envoyScaler) GetWatchedPods(context.Context) (string, labels.Selector, error) {
+	return s.metadata.namespace, s.metadata.podSelector, nil
+}
+
+func (s *envoyScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.GetMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting envoy metric value")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *envoyScaler) GetMetricSpecForScaling(_ context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("envoy-%s-%s", strings.ReplaceAll(s.metadata.Cluster, "|", "-"), s.metadata.Metric))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// IsStateful returns whether the request rate is computed from the totals of the previous evaluations
+func (s *envoyScaler) IsStateful() bool {
+	return s.metadata.Metric == envoyRequestRate
+}
+
+func (s *envoyScaler) Close(_ context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package scalers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseEnvoyMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type envoyMetricIdentifier struct {
+	metadataTestData *parseEnvoyMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testEnvoyMetadata = []parseEnvoyMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true},
+	// properly formed metadata
+	{map[string]string{"podSelector": "app=api", "cluster": "backend", "targetValue": "10"}, false},
+	// istio sidecars and request rate
+	{map[string]string{"podSelector": "app=api", "cluster": "inbound|8080||", "source": "istio", "metric": "requestRate", "rateWindow": "30", "targetValue": "100"}, false},
+	// missing podSelector
+	{map[string]string{"cluster": "backend", "targetValue": "10"}, true},
+	// invalid podSelector
+	{map[string]string{"podSelector": "app in", "cluster": "backend", "targetValue": "10"}, true},
+	// missing cluster
+	{map[string]string{"podSelector": "app=api", "targetValue": "10"}, true},
+	// invalid source
+	{map[string]string{"podSelector": "app=api", "cluster": "backend", "source": "xds", "targetValue": "10"}, true},
+	// invalid port
+	{map[string]string{"podSelector": "app=api", "cluster": "backend", "port": "70000", "targetValue": "10"}, true},
+	// invalid targetValue
+	{map[string]string{"podSelector": "app=api", "cluster": "backend", "targetValue": "0"}, true},
+	// invalid rateWindow
+	{map[string]string{"podSelector": "app=api", "cluster": "backend", "metric": "requestRate", "rateWindow": "0", "targetValue": "10"}, true},
+}
+
+var envoyMetricIdentifiers = []envoyMetricIdentifier{
+	{&testEnvoyMetadata[1], 0, "s0-envoy-backend-activeRequests"},
+	{&testEnvoyMetadata[2], 1, "s1-envoy-inbound-8080---requestRate"},
+}
+
+func TestEnvoyParseMetadata(t *testing.T) {
+	for testCaseNum, testData := range testEnvoyMetadata {
+		_, err := parseEnvoyMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", testCaseNum, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", testCaseNum)
+		}
+	}
+}
+
+func TestEnvoyParseMetadataDefaultPort(t *testing.T) {
+	meta, err := parseEnvoyMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testEnvoyMetadata[1].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, envoyDefaultAdminPort, meta.Port)
+
+	meta, err = parseEnvoyMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testEnvoyMetadata[2].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, envoyDefaultIstioPort, meta.Port)
+}
+
+func TestEnvoyGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range envoyMetricIdentifiers {
+		meta, err := parseEnvoyMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockEnvoyScaler := envoyScaler{metadata: meta}
+
+		metricSpec := mockEnvoyScaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func TestGetEnvoyStats(t *testing.T) {
+	admin := []byte("cluster.backend.upstream_rq_active: 4\ncluster.backend.upstream_rq_total: 120\ncluster.other.upstream_rq_active: 9\n")
+	active, total, err := getEnvoyAdminStats(admin, "backend")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(4), active)
+	assert.Equal(t, float64(120), total)
+	_, _, err = getEnvoyAdminStats(admin, "missing")
+	assert.Error(t, err)
+
+	istio := []byte(`# TYPE envoy_cluster_upstream_rq_active gauge
+envoy_cluster_upstream_rq_active{cluster_name="inbound|8080||"} 3
+envoy_cluster_upstream_rq_active{cluster_name="outbound|80||db.default.svc.cluster.local"} 7
+# TYPE envoy_cluster_upstream_rq_total counter
+envoy_cluster_upstream_rq_total{cluster_name="inbound|8080||"} 250
+`)
+	active, total, err = getEnvoyPrometheusStats(istio, "inbound|8080||")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(3), active)
+	assert.Equal(t, float64(250), total)
+	_, _, err = getEnvoyPrometheusStats(istio, "inbound|9090||")
+	assert.Error(t, err)
+}
+
+func TestEnvoyGetMetricValue(t *testing.T) {
+	var total atomic.Int64
+	total.Store(100)
+	var failing atomic.Bool
+	var apiStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "/stats", r.URL.Path)
+		assert.Equal(t, `^cluster\.backend\.upstream_rq_(active|total)$`, r.URL.Query().Get("filter"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("cluster.backend.upstream_rq_active: 4\ncluster.backend.upstream_rq_total: " + strconv.FormatInt(total.Load(), 10) + "\n"))
+	}))
+	defer apiStub.Close()
+	host, port, _ := net.SplitHostPort(apiStub.Listener.Addr().String())
+
+	pod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name), Labels: map[string]string{"app": "api"}},
+			Status:     corev1.PodStatus{Phase: phase, PodIP: host},
+		}
+	}
+	kubeClient := fake.NewClientBuilder().WithObjects(pod("api-1", corev1.PodRunning), pod("api-2", corev1.PodRunning), pod("api-3", corev1.PodPending)).Build()
+
+	newScaler := func(metric string) *envoyScaler {
+		meta, err := parseEnvoyMetadata(&scalersconfig.ScalerConfig{
+			TriggerMetadata:         map[string]string{"podSelector": "app=api", "cluster": "backend", "port": port, "metric": metric, "targetValue": "10"},
+			ScalableObjectNamespace: "default",
+		})
+		assert.NoError(t, err)
+		return &envoyScaler{metadata: meta, kubeClient: kubeClient, httpClient: http.DefaultClient, logger: logr.Discard()}
+	}
+
+	// the active requests are summed over the running pods
+	value, err := newScaler("activeRequests").GetMetricValue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(8), value)
+
+	// the request rate is computed once the counters have been scraped for the window
+	value, err = newScaler("requestRate").GetMetricValue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), value)
+
+	envoyRequestTotals.lock.Lock()
+	for key, samples := range envoyRequestTotals.samples {
+		for i := range samples {
+			samples[i].time = samples[i].time.Add(-100 * time.Second)
+		}
+		envoyRequestTotals.samples[key] = samples
+	}
+	envoyRequestTotals.lock.Unlock()
+	total.Store(150)
+	// the counters are kept across the rebuilds of the scaler
+	value, err = newScaler("requestRate").GetMetricValue(context.Background())
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, value, 0.01)
+
+	// the pods failing to be scraped are an error once they all fail
+	failing.Store(true)
+	_, err = newScaler("activeRequests").GetMetricValue(context.Background())
+	assert.ErrorContains(t, err, "error scraping the envoy of all the 2 pods")
+}
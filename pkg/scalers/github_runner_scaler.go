@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	gha "github.com/bradleyfalzon/ghinstallation/v2"
@@ -34,6 +35,15 @@ type githubRunnerScaler struct {
 	metadata   *githubRunnerMetadata
 	httpClient *http.Client
 	logger     logr.Logger
+
+	// responses of the previous requests, for conditional requests when enableEtags is set
+	etagsLock sync.Mutex
+	etags     map[string]githubCachedResponse
+}
+
+type githubCachedResponse struct {
+	etag string
+	body []byte
 }
 
 type githubRunnerMetadata struct {
@@ -48,6 +58,10 @@ type githubRunnerMetadata struct {
 	applicationID             *int64
 	installationID            *int64
 	applicationKey            *string
+	runnerGroup               string
+	runnerNamePrefix          string
+	exactLabelMatch           bool
+	enableEtags               bool
 }
 
 type WorkflowRuns struct {
@@ -289,6 +303,24 @@ type Repo struct {
 	Watchers   int `json:"watchers"`
 }
 
+type RunnerGroups struct {
+	TotalCount   int           `json:"total_count"`
+	RunnerGroups []RunnerGroup `json:"runner_groups"`
+}
+
+type RunnerGroup struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Visibility string `json:"visibility"`
+	Default    bool   `json:"default"`
+	Inherited  bool   `json:"inherited"`
+}
+
+type RunnerGroupRepos struct {
+	TotalCount   int    `json:"total_count"`
+	Repositories []Repo `json:"repositories"`
+}
+
 type Jobs struct {
 	TotalCount int   `json:"total_count"`
 	Jobs       []Job `json:"jobs"`
@@ -417,6 +449,33 @@ func parseGitHubRunnerMetadata(config *scalersconfig.ScalerConfig) (*githubRunne
 		meta.githubAPIURL = defaultGithubAPIURL
 	}
 
+	if val, err := getValueFromMetaOrEnv("runnerGroup", config.TriggerMetadata, config.ResolvedEnv); err == nil && val != "" {
+		if meta.runnerScope == REPO {
+			return nil, fmt.Errorf("runnerGroup is only supported with runnerScope %s or %s", ORG, ENT)
+		}
+		meta.runnerGroup = val
+	}
+
+	if val, err := getValueFromMetaOrEnv("runnerNamePrefix", config.TriggerMetadata, config.ResolvedEnv); err == nil && val != "" {
+		meta.runnerNamePrefix = val
+	}
+
+	if val, err := getValueFromMetaOrEnv("exactLabelMatch", config.TriggerMetadata, config.ResolvedEnv); err == nil && val != "" {
+		exactLabelMatch, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing exactLabelMatch: %w", err)
+		}
+		meta.exactLabelMatch = exactLabelMatch
+	}
+
+	if val, err := getValueFromMetaOrEnv("enableEtags", config.TriggerMetadata, config.ResolvedEnv); err == nil && val != "" {
+		enableEtags, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing enableEtags: %w", err)
+		}
+		meta.enableEtags = enableEtags
+	}
+
 	if val, ok := config.AuthParams["personalAccessToken"]; ok && val != "" {
 		// Found the pat token in a parameter from TriggerAuthentication
 		meta.personalAccessToken = &val
@@ -486,7 +545,7 @@ func (s *githubRunnerScaler) getRepositories(ctx context.Context) ([]string, err
 			return nil, fmt.Errorf("runnerScope %s not supported", s.metadata.runnerScope)
 		}
 
-		body, _, err := getGithubRequest(ctx, url, s.metadata, s.httpClient)
+		body, _, err := s.getGithubRequest(ctx, url)
 		if err != nil {
 			return nil, err
 		}
@@ -513,7 +572,68 @@ func (s *githubRunnerScaler) getRepositories(ctx context.Context) ([]string, err
 	return repoList, nil
 }
 
-func getGithubRequest(ctx context.Context, url string, metadata *githubRunnerMetadata, httpClient *http.Client) ([]byte, int, error) {
+// getRunnerGroup returns the runner group the runners are registered in
+func (s *githubRunnerScaler) getRunnerGroup(ctx context.Context) (*RunnerGroup, error) {
+	page := 1
+	for {
+		url := fmt.Sprintf("%s/orgs/%s/actions/runner-groups?per_page=100&page=%d", s.metadata.githubAPIURL, s.metadata.owner, page)
+		body, _, err := s.getGithubRequest(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+
+		var groups RunnerGroups
+		if err := json.Unmarshal(body, &groups); err != nil {
+			return nil, err
+		}
+
+		for _, group := range groups.RunnerGroups {
+			if strings.EqualFold(group.Name, s.metadata.runnerGroup) {
+				return &group, nil
+			}
+		}
+
+		if len(groups.RunnerGroups) < 100 {
+			return nil, fmt.Errorf("runner group %s not found for %s", s.metadata.runnerGroup, s.metadata.owner)
+		}
+		page++
+	}
+}
+
+// getRunnerGroupRepositories returns the repositories allowed to use a runner group,
+// or nil if the runner group is not restricted to selected repositories
+func (s *githubRunnerScaler) getRunnerGroupRepositories(ctx context.Context, group *RunnerGroup) ([]string, error) {
+	// groups inherited from the enterprise are shared with all the repositories of the organization
+	if group.Visibility != "selected" || group.Inherited {
+		return nil, nil
+	}
+
+	page := 1
+	repoList := []string{}
+	for {
+		url := fmt.Sprintf("%s/orgs/%s/actions/runner-groups/%d/repositories?per_page=100&page=%d", s.metadata.githubAPIURL, s.metadata.owner, group.ID, page)
+		body, _, err := s.getGithubRequest(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+
+		var repos RunnerGroupRepos
+		if err := json.Unmarshal(body, &repos); err != nil {
+			return nil, err
+		}
+
+		for _, repo := range repos.Repositories {
+			repoList = append(repoList, repo.Name)
+		}
+
+		if len(repos.Repositories) < 100 {
+			return repoList, nil
+		}
+		page++
+	}
+}
+
+func (s *githubRunnerScaler) getGithubRequest(ctx context.Context, url string) ([]byte, int, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return []byte{}, -1, err
@@ -522,11 +642,17 @@ func getGithubRequest(ctx context.Context, url string, metadata *githubRunnerMet
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
-	if metadata.applicationID == nil && metadata.personalAccessToken != nil {
-		req.Header.Set("Authorization", "Bearer "+*metadata.personalAccessToken)
+	if s.metadata.applicationID == nil && s.metadata.personalAccessToken != nil {
+		req.Header.Set("Authorization", "Bearer "+*s.metadata.personalAccessToken)
 	}
 
-	r, err := httpClient.Do(req)
+	// conditional requests answered with 304 Not Modified don't count against the rate limit
+	cached, hasCached := s.getCachedResponse(url)
+	if hasCached {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	r, err := s.httpClient.Do(req)
 	if err != nil {
 		return []byte{}, -1, err
 	}
@@ -537,6 +663,10 @@ func getGithubRequest(ctx context.Context, url string, metadata *githubRunnerMet
 	}
 	_ = r.Body.Close()
 
+	if r.StatusCode == http.StatusNotModified && hasCached {
+		return cached.body, http.StatusOK, nil
+	}
+
 	if r.StatusCode != 200 {
 		if r.Header.Get("X-RateLimit-Remaining") != "" {
 			githubAPIRemaining, _ := strconv.Atoi(r.Header.Get("X-RateLimit-Remaining"))
@@ -550,9 +680,33 @@ func getGithubRequest(ctx context.Context, url string, metadata *githubRunnerMet
 		return []byte{}, r.StatusCode, fmt.Errorf("the GitHub REST API returned error. url: %s status: %d response: %s", url, r.StatusCode, string(b))
 	}
 
+	s.setCachedResponse(url, r.Header.Get("ETag"), b)
+
 	return b, r.StatusCode, nil
 }
 
+func (s *githubRunnerScaler) getCachedResponse(url string) (githubCachedResponse, bool) {
+	if !s.metadata.enableEtags {
+		return githubCachedResponse{}, false
+	}
+	s.etagsLock.Lock()
+	defer s.etagsLock.Unlock()
+	cached, ok := s.etags[url]
+	return cached, ok
+}
+
+func (s *githubRunnerScaler) setCachedResponse(url string, etag string, body []byte) {
+	if !s.metadata.enableEtags || etag == "" {
+		return
+	}
+	s.etagsLock.Lock()
+	defer s.etagsLock.Unlock()
+	if s.etags == nil {
+		s.etags = map[string]githubCachedResponse{}
+	}
+	s.etags[url] = githubCachedResponse{etag: etag, body: body}
+}
+
 func stripDeadRuns(allWfrs []WorkflowRuns) []WorkflowRun {
 	var filtered []WorkflowRun
 	for _, wfrs := range allWfrs {
@@ -568,7 +722,7 @@ func stripDeadRuns(allWfrs []WorkflowRuns) []WorkflowRun {
 // getWorkflowRunJobs returns a list of jobs for a given workflow run
 func (s *githubRunnerScaler) getWorkflowRunJobs(ctx context.Context, workflowRunID int64, repoName string) ([]Job, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs/%d/jobs", s.metadata.githubAPIURL, s.metadata.owner, repoName, workflowRunID)
-	body, _, err := getGithubRequest(ctx, url, s.metadata, s.httpClient)
+	body, _, err := s.getGithubRequest(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -585,7 +739,7 @@ func (s *githubRunnerScaler) getWorkflowRunJobs(ctx context.Context, workflowRun
 // getWorkflowRuns returns a list of workflow runs for a given repository
 func (s *githubRunnerScaler) getWorkflowRuns(ctx context.Context, repoName string) (*WorkflowRuns, error) {
 	url := fmt.Sprintf("%s/repos/%s/%s/actions/runs", s.metadata.githubAPIURL, s.metadata.owner, repoName)
-	body, statusCode, err := getGithubRequest(ctx, url, s.metadata, s.httpClient)
+	body, statusCode, err := s.getGithubRequest(ctx, url)
 	if err != nil && statusCode == 404 {
 		return nil, nil
 	} else if err != nil {
@@ -620,12 +774,61 @@ func canRunnerMatchLabels(jobLabels []string, runnerLabels []string) bool {
 	return true
 }
 
+// canRunnerMatchLabelsExactly check Agent Label array is the same set as the runner label array
+func canRunnerMatchLabelsExactly(jobLabels []string, runnerLabels []string) bool {
+	if !canRunnerMatchLabels(jobLabels, runnerLabels) {
+		return false
+	}
+	for _, runnerLabel := range runnerLabels {
+		if !contains(jobLabels, runnerLabel) {
+			return false
+		}
+	}
+	return true
+}
+
+// canRunnerPickJob check the job is waiting for or being run by a runner of the scaled pool
+func (s *githubRunnerScaler) canRunnerPickJob(job Job) bool {
+	return canRunnerPoolPickJob(job, s.metadata.labels, s.metadata.exactLabelMatch, s.metadata.runnerGroup, s.metadata.runnerNamePrefix)
+}
+
+// canRunnerPoolPickJob check the job is waiting for or being run by a runner of the pool with the labels,
+// the runner group and the runner name prefix
+func canRunnerPoolPickJob(job Job, labels []string, exactLabelMatch bool, runnerGroup string, runnerNamePrefix string) bool {
+	if job.Status != "queued" && job.Status != "in_progress" {
+		return false
+	}
+
+	if exactLabelMatch {
+		if !canRunnerMatchLabelsExactly(job.Labels, labels) {
+			return false
+		}
+	} else if !canRunnerMatchLabels(job.Labels, labels) {
+		return false
+	}
+
+	// jobs already assigned to a runner of another group or pool don't need a new runner
+	if job.Status == "in_progress" {
+		if runnerGroup != "" && job.RunnerGroupName != "" && !strings.EqualFold(job.RunnerGroupName, runnerGroup) {
+			return false
+		}
+		if runnerNamePrefix != "" && job.RunnerName != "" && !strings.HasPrefix(job.RunnerName, runnerNamePrefix) {
+			return false
+		}
+	}
+	return true
+}
+
 // GetWorkflowQueueLength returns the number of workflow jobs in the queue
 func (s *githubRunnerScaler) GetWorkflowQueueLength(ctx context.Context) (int64, error) {
 	var repos []string
 	var err error
 
-	repos, err = s.getRepositories(ctx)
+	if s.metadata.runnerGroup != "" {
+		repos, err = s.getRunnerGroupScopedRepositories(ctx)
+	} else {
+		repos, err = s.getRepositories(ctx)
+	}
 	if err != nil {
 		return -1, err
 	}
@@ -651,7 +854,7 @@ func (s *githubRunnerScaler) GetWorkflowQueueLength(ctx context.Context) (int64,
 			return -1, err
 		}
 		for _, job := range jobs {
-			if (job.Status == "queued" || job.Status == "in_progress") && canRunnerMatchLabels(job.Labels, s.metadata.labels) {
+			if s.canRunnerPickJob(job) {
 				queueCount++
 			}
 		}
@@ -660,6 +863,34 @@ func (s *githubRunnerScaler) GetWorkflowQueueLength(ctx context.Context) (int64,
 	return queueCount, nil
 }
 
+// getRunnerGroupScopedRepositories returns the repositories whose jobs can run on the runner group
+func (s *githubRunnerScaler) getRunnerGroupScopedRepositories(ctx context.Context) ([]string, error) {
+	group, err := s.getRunnerGroup(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groupRepos, err := s.getRunnerGroupRepositories(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	if groupRepos == nil {
+		return s.getRepositories(ctx)
+	}
+	if s.metadata.repos == nil {
+		return groupRepos, nil
+	}
+
+	var repos []string
+	for _, repo := range s.metadata.repos {
+		if contains(groupRepos, repo) {
+			repos = append(repos, repo)
+		}
+	}
+	return repos, nil
+}
+
 func (s *githubRunnerScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
 	queueLen, err := s.GetWorkflowQueueLength(ctx)
 
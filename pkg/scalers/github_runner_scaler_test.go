@@ -110,6 +110,14 @@ var testGitHubRunnerMetadata = []parseGitHubRunnerMetadataTestData{
 	{"missing applicationID", map[string]string{"githubApiURL": "https://api.github.com", "runnerScope": ORG, "owner": "ownername", "repos": "reponame,otherrepo", "labels": "golang", "targetWorkflowQueueLength": "1", "installationID": "1"}, true, true, "applicationID, installationID and applicationKey must be given"},
 	// all good
 	{"missing applicationKey", map[string]string{"githubApiURL": "https://api.github.com", "runnerScope": ORG, "owner": "ownername", "repos": "reponame,otherrepo", "labels": "golang", "targetWorkflowQueueLength": "1", "applicationID": "1", "installationID": "1"}, true, true, "applicationID, installationID and applicationKey must be given"},
+	// runnerGroup with repo scope
+	{"runnerGroup with repo scope", map[string]string{"githubApiURL": "https://api.github.com", "runnerScope": REPO, "owner": "ownername", "runnerGroup": "pool", "targetWorkflowQueueLength": "1"}, false, true, "runnerGroup is only supported with runnerScope org or ent"},
+	// runner group, runner name prefix, exact label match and etags
+	{"runner group scoping", map[string]string{"githubApiURL": "https://api.github.com", "runnerScope": ENT, "owner": "ownername", "runnerGroup": "pool", "runnerNamePrefix": "keda-", "labels": "golang", "exactLabelMatch": "true", "enableEtags": "true", "targetWorkflowQueueLength": "1"}, false, false, ""},
+	// invalid exactLabelMatch
+	{"invalid exactLabelMatch", map[string]string{"githubApiURL": "https://api.github.com", "runnerScope": ORG, "owner": "ownername", "exactLabelMatch": "maybe", "targetWorkflowQueueLength": "1"}, false, true, "error parsing exactLabelMatch: strconv.ParseBool: parsing \"maybe\": invalid syntax"},
+	// invalid enableEtags
+	{"invalid enableEtags", map[string]string{"githubApiURL": "https://api.github.com", "runnerScope": ORG, "owner": "ownername", "enableEtags": "maybe", "targetWorkflowQueueLength": "1"}, false, true, "error parsing enableEtags: strconv.ParseBool: parsing \"maybe\": invalid syntax"},
 }
 
 func TestGitHubRunnerParseMetadata(t *testing.T) {
@@ -612,6 +620,193 @@ func TestNewGitHubRunnerScaler_QueueLength_MultiRepo_PulledRepos_NoRate(t *testi
 	}
 }
 
+func TestNewGitHubRunnerScaler_QueueLength_SingleRepo_ExactLabelMatch(t *testing.T) {
+	var apiStub = apiStubHandler(true, false)
+
+	meta := getGitHubTestMetaData(apiStub.URL)
+	meta.repos = []string{"test"}
+	meta.exactLabelMatch = true
+
+	mockGitHubRunnerScaler := githubRunnerScaler{
+		metadata:   meta,
+		httpClient: http.DefaultClient,
+	}
+
+	mockGitHubRunnerScaler.metadata.labels = []string{"foo", "bar", "other"}
+	queueLen, err := mockGitHubRunnerScaler.GetWorkflowQueueLength(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queueLen != 0 {
+		t.Fatalf("expected queue length 0 but got %d", queueLen)
+	}
+
+	mockGitHubRunnerScaler.metadata.labels = []string{"foo", "bar"}
+	queueLen, err = mockGitHubRunnerScaler.GetWorkflowQueueLength(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queueLen != 1 {
+		t.Fatalf("expected queue length 1 but got %d", queueLen)
+	}
+}
+
+func apiStubHandlerRunnerGroup(visibility string, jobResponse string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/actions/runner-groups"):
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"total_count":2,"runner_groups":[{"id":1,"name":"Default","visibility":"all","default":true},{"id":2,"name":"my runner group","visibility":"%s"}]}`, visibility)))
+		case strings.HasSuffix(r.URL.Path, "/actions/runner-groups/2/repositories"):
+			_, _ = w.Write([]byte(`{"total_count":1,"repositories":[{"name":"allowed"}]}`))
+		case strings.HasSuffix(r.URL.Path, "jobs"):
+			_, _ = w.Write([]byte(jobResponse))
+		case strings.HasSuffix(r.URL.Path, "runs"):
+			_, _ = w.Write(buildQueueJSON())
+		case strings.Contains(r.URL.Path, "/repos"):
+			_, _ = w.Write([]byte(testGhUserReposResponse))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestNewGitHubRunnerScaler_QueueLength_RunnerGroup(t *testing.T) {
+	tests := []struct {
+		name       string
+		visibility string
+		repos      []string
+		group      string
+		expected   int64
+		isError    bool
+	}{
+		{"selected repositories", "selected", nil, "my runner group", 1, false},
+		{"selected repositories intersected with repos", "selected", []string{"allowed", "other"}, "my runner group", 1, false},
+		{"no allowed repository in repos", "selected", []string{"other"}, "my runner group", 0, false},
+		{"all repositories", "all", []string{"allowed", "other"}, "my runner group", 2, false},
+		{"unknown runner group", "selected", nil, "missing", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiStub := apiStubHandlerRunnerGroup(tt.visibility, testGhWFJobResponse)
+			defer apiStub.Close()
+
+			meta := getGitHubTestMetaData(apiStub.URL)
+			meta.runnerScope = ORG
+			meta.repos = tt.repos
+			meta.labels = []string{"foo", "bar"}
+			meta.runnerGroup = tt.group
+
+			mockGitHubRunnerScaler := githubRunnerScaler{
+				metadata:   meta,
+				httpClient: http.DefaultClient,
+			}
+
+			queueLen, err := mockGitHubRunnerScaler.GetWorkflowQueueLength(context.TODO())
+			if tt.isError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if queueLen != tt.expected {
+				t.Fatalf("expected queue length %d but got %d", tt.expected, queueLen)
+			}
+		})
+	}
+}
+
+func TestNewGitHubRunnerScaler_QueueLength_InProgressJobAssignment(t *testing.T) {
+	inProgressJobResponse := strings.Replace(testGhWFJobResponse, `"status":"queued"`, `"status":"in_progress"`, 1)
+
+	tests := []struct {
+		name             string
+		runnerGroup      string
+		runnerNamePrefix string
+		expected         int64
+	}{
+		{"no assignment filter", "", "", 1},
+		{"runner of the group", "my runner group", "", 1},
+		{"runner of another group", "Default", "", 0},
+		{"runner of the pool", "", "my ", 1},
+		{"runner of another pool", "", "keda-", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiStub := apiStubHandlerRunnerGroup("all", inProgressJobResponse)
+			defer apiStub.Close()
+
+			meta := getGitHubTestMetaData(apiStub.URL)
+			meta.runnerScope = ORG
+			meta.repos = []string{"test"}
+			meta.labels = []string{"foo", "bar"}
+			meta.runnerGroup = tt.runnerGroup
+			meta.runnerNamePrefix = tt.runnerNamePrefix
+
+			mockGitHubRunnerScaler := githubRunnerScaler{
+				metadata:   meta,
+				httpClient: http.DefaultClient,
+			}
+
+			queueLen, err := mockGitHubRunnerScaler.GetWorkflowQueueLength(context.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if queueLen != tt.expected {
+				t.Fatalf("expected queue length %d but got %d", tt.expected, queueLen)
+			}
+		})
+	}
+}
+
+func TestNewGitHubRunnerScaler_QueueLength_Etags(t *testing.T) {
+	var notModified int
+	apiStub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := fmt.Sprintf(`"%x"`, len(r.URL.Path))
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		if strings.HasSuffix(r.URL.Path, "jobs") {
+			_, _ = w.Write([]byte(testGhWFJobResponse))
+			return
+		}
+		_, _ = w.Write(buildQueueJSON())
+	}))
+	defer apiStub.Close()
+
+	meta := getGitHubTestMetaData(apiStub.URL)
+	meta.repos = []string{"test"}
+	meta.labels = []string{"foo", "bar"}
+	meta.enableEtags = true
+
+	mockGitHubRunnerScaler := githubRunnerScaler{
+		metadata:   meta,
+		httpClient: http.DefaultClient,
+	}
+
+	for i := 0; i < 2; i++ {
+		queueLen, err := mockGitHubRunnerScaler.GetWorkflowQueueLength(context.TODO())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if queueLen != 1 {
+			t.Fatalf("expected queue length 1 but got %d", queueLen)
+		}
+	}
+
+	// the runs and the jobs of the second evaluation are served from the cache
+	if notModified != 2 {
+		t.Fatalf("expected 2 not modified responses but got %d", notModified)
+	}
+}
+
 type githubRunnerMetricIdentifier struct {
 	metadataTestData *map[string]string
 	triggerIndex     int
@@ -0,0 +1,135 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/githubwebhook"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type githubWebhookScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *githubWebhookMetadata
+	jobs       func() []githubwebhook.WorkflowJob
+	logger     logr.Logger
+}
+
+type githubWebhookMetadata struct {
+	Owner                               string   `keda:"name=owner,                               order=triggerMetadata;resolvedEnv"`
+	RunnerScope                         string   `keda:"name=runnerScope,                         order=triggerMetadata;resolvedEnv, enum=org;ent;repo, default=org"`
+	Repos                               []string `keda:"name=repos,                               order=triggerMetadata;resolvedEnv, optional"`
+	Labels                              []string `keda:"name=labels,                              order=triggerMetadata;resolvedEnv, optional"`
+	ExactLabelMatch                     bool     `keda:"name=exactLabelMatch,                     order=triggerMetadata;resolvedEnv, default=false"`
+	RunnerGroup                         string   `keda:"name=runnerGroup,                         order=triggerMetadata;resolvedEnv, optional"`
+	RunnerNamePrefix                    string   `keda:"name=runnerNamePrefix,                    order=triggerMetadata;resolvedEnv, optional"`
+	TargetWorkflowQueueLength           int64    `keda:"name=targetWorkflowQueueLength,           order=triggerMetadata;resolvedEnv, default=1"`
+	ActivationTargetWorkflowQueueLength int64    `keda:"name=activationTargetWorkflowQueueLength, order=triggerMetadata;resolvedEnv, default=0"`
+
+	triggerIndex int
+}
+
+func (m *githubWebhookMetadata) Validate() error {
+	if m.TargetWorkflowQueueLength <= 0 {
+		return fmt.Errorf("targetWorkflowQueueLength must be greater than 0")
+	}
+	if m.RunnerScope == REPO && len(m.Repos) == 0 {
+		return fmt.Errorf("repos must be provided with runnerScope %s", REPO)
+	}
+	if m.RunnerGroup != "" && m.RunnerScope == REPO {
+		return fmt.Errorf("runnerGroup is only supported with runnerScope %s or %s", ORG, ENT)
+	}
+	return nil
+}
+
+// NewGitHubWebhookScaler creates a new scaler for the GitHub Actions jobs queued, the queue is
+// maintained by the webhook receiver of the operator from the workflow_job deliveries
+func NewGitHubWebhookScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseGitHubWebhookMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing GitHub webhook metadata: %w", err)
+	}
+
+	if !githubwebhook.Enabled() {
+		return nil, fmt.Errorf("the github webhook receiver isn't enabled, set --github-webhook-bind-address on the operator")
+	}
+
+	return &githubWebhookScaler{
+		metricType: metricType,
+		metadata:   meta,
+		jobs:       githubwebhook.Jobs,
+		logger:     InitializeLogger(config, "github_webhook_scaler"),
+	}, nil
+}
+
+func parseGitHubWebhookMetadata(config *scalersconfig.ScalerConfig) (*githubWebhookMetadata, error) {
+	meta := &githubWebhookMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing github webhook metadata: %w", err)
+	}
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// isJobInScope checks the job has been queued in the owner of the scaler, and in one of its repos when set
+func (s *githubWebhookScaler) isJobInScope(job githubwebhook.WorkflowJob) bool {
+	owner := job.Owner
+	if s.metadata.RunnerScope == ENT {
+		owner = job.Enterprise
+	}
+	if !strings.EqualFold(owner, s.metadata.Owner) {
+		return false
+	}
+	return len(s.metadata.Repos) == 0 || contains(s.metadata.Repos, job.Repository)
+}
+
+// GetWorkflowQueueLength returns the number of workflow jobs in the queue
+func (s *githubWebhookScaler) GetWorkflowQueueLength() int64 {
+	var queueCount int64
+	for _, job := range s.jobs() {
+		if !s.isJobInScope(job) {
+			continue
+		}
+		if canRunnerPoolPickJob(Job{
+			Status:          job.Status,
+			Labels:          job.Labels,
+			RunnerName:      job.RunnerName,
+			RunnerGroupName: job.RunnerGroupName,
+		}, s.metadata.Labels, s.metadata.ExactLabelMatch, s.metadata.RunnerGroup, s.metadata.RunnerNamePrefix) {
+			queueCount++
+		}
+	}
+	return queueCount
+}
+
+func (s *githubWebhookScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	queueLen := s.GetWorkflowQueueLength()
+	metric := GenerateMetricInMili(metricName, float64(queueLen))
+	return []external_metrics.ExternalMetricValue{metric}, queueLen > s.metadata.ActivationTargetWorkflowQueueLength, nil
+}
+
+func (s *githubWebhookScaler) GetMetricSpecForScaling(_ context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("github-webhook-%s", s.metadata.Owner))),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetWorkflowQueueLength),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *githubWebhookScaler) Close(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,114 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/githubwebhook"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseGitHubWebhookMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type githubWebhookMetricIdentifier struct {
+	metadataTestData *parseGitHubWebhookMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testGitHubWebhookMetadata = []parseGitHubWebhookMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true},
+	// properly formed metadata
+	{map[string]string{"owner": "kedacore", "labels": "gpu"}, false},
+	// enterprise scope with repos
+	{map[string]string{"owner": "keda", "runnerScope": "ent", "repos": "app,site", "targetWorkflowQueueLength": "2"}, false},
+	// invalid runnerScope
+	{map[string]string{"owner": "kedacore", "runnerScope": "user"}, true},
+	// repo scope without repos
+	{map[string]string{"owner": "kedacore", "runnerScope": "repo"}, true},
+	// runnerGroup with repo scope
+	{map[string]string{"owner": "kedacore", "runnerScope": "repo", "repos": "app", "runnerGroup": "gpu"}, true},
+	// invalid targetWorkflowQueueLength
+	{map[string]string{"owner": "kedacore", "targetWorkflowQueueLength": "0"}, true},
+}
+
+var githubWebhookMetricIdentifiers = []githubWebhookMetricIdentifier{
+	{&testGitHubWebhookMetadata[1], 0, "s0-github-webhook-kedacore"},
+	{&testGitHubWebhookMetadata[2], 1, "s1-github-webhook-keda"},
+}
+
+func TestGitHubWebhookParseMetadata(t *testing.T) {
+	for testCaseNum, testData := range testGitHubWebhookMetadata {
+		_, err := parseGitHubWebhookMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", testCaseNum, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", testCaseNum)
+		}
+	}
+}
+
+func TestGitHubWebhookGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range githubWebhookMetricIdentifiers {
+		meta, err := parseGitHubWebhookMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockGitHubWebhookScaler := githubWebhookScaler{metadata: meta}
+
+		metricSpec := mockGitHubWebhookScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Errorf("Wrong External metric source name: %s, expected: %s", metricName, testData.name)
+		}
+	}
+}
+
+func TestNewGitHubWebhookScalerWithoutReceiver(t *testing.T) {
+	_, err := NewGitHubWebhookScaler(&scalersconfig.ScalerConfig{TriggerMetadata: testGitHubWebhookMetadata[1].metadata})
+	assert.ErrorContains(t, err, "--github-webhook-bind-address")
+}
+
+var testGitHubWebhookJobs = []githubwebhook.WorkflowJob{
+	{ID: 1, Owner: "kedacore", Enterprise: "keda", Repository: "app", Status: githubwebhook.StatusQueued, Labels: []string{"self-hosted", "gpu"}},
+	{ID: 2, Owner: "kedacore", Enterprise: "keda", Repository: "site", Status: githubwebhook.StatusInProgress, Labels: []string{"gpu"}, RunnerName: "gpu-runner-1"},
+	{ID: 3, Owner: "kedacore", Enterprise: "keda", Repository: "app", Status: githubwebhook.StatusInProgress, Labels: []string{"gpu"}, RunnerName: "other-pool-1"},
+	{ID: 4, Owner: "kedacore", Enterprise: "keda", Repository: "app", Status: githubwebhook.StatusQueued, Labels: []string{"arm64"}},
+	{ID: 5, Owner: "other", Enterprise: "keda", Repository: "app", Status: githubwebhook.StatusQueued, Labels: []string{"gpu"}},
+}
+
+func TestGitHubWebhookGetMetricsAndActivity(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		value    int64
+		isActive bool
+	}{
+		{"jobs of the owner matching the labels", map[string]string{"owner": "kedacore", "labels": "gpu"}, 3, true},
+		{"jobs of the repos", map[string]string{"owner": "kedacore", "labels": "gpu", "repos": "site"}, 1, false},
+		{"jobs of the runner pool", map[string]string{"owner": "kedacore", "labels": "gpu", "runnerNamePrefix": "gpu-runner-"}, 2, true},
+		{"jobs of the enterprise", map[string]string{"owner": "keda", "runnerScope": "ent", "labels": "gpu,arm64"}, 5, true},
+		{"jobs with the exact labels", map[string]string{"owner": "kedacore", "labels": "arm64", "exactLabelMatch": "true"}, 1, false},
+		{"no jobs", map[string]string{"owner": "kedacore", "labels": "windows", "exactLabelMatch": "true"}, 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.metadata["activationTargetWorkflowQueueLength"] = "1"
+			meta, err := parseGitHubWebhookMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: test.metadata})
+			assert.NoError(t, err)
+			s := &githubWebhookScaler{metadata: meta, jobs: func() []githubwebhook.WorkflowJob { return testGitHubWebhookJobs }}
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "s0-github-webhook")
+			assert.NoError(t, err)
+			assert.Equal(t, test.value, metrics[0].Value.Value())
+			assert.Equal(t, test.isActive, isActive)
+		})
+	}
+}
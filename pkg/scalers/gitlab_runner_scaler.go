@@ -0,0 +1,245 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	url_pkg "net/url"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	gitlabPageSize       = 100
+	gitlabNextPageHeader = "X-Next-Page"
+)
+
+type gitlabRunnerScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *gitlabRunnerMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type gitlabRunnerMetadata struct {
+	GitLabAPIURL                        string   `keda:"name=gitlabApiURL,                        order=triggerMetadata;resolvedEnv, default=https://gitlab.com"`
+	PersonalAccessToken                 string   `keda:"name=personalAccessToken,                 order=authParams;resolvedEnv"`
+	Projects                            []string `keda:"name=projects,                            order=triggerMetadata;resolvedEnv, optional"`
+	GroupID                             string   `keda:"name=groupID,                             order=triggerMetadata;resolvedEnv, optional"`
+	Tags                                []string `keda:"name=tags,                                order=triggerMetadata;resolvedEnv, optional"`
+	RunUntagged                         bool     `keda:"name=runUntagged,                         order=triggerMetadata;resolvedEnv, default=false"`
+	TargetPipelineQueueLength           int64    `keda:"name=targetPipelineQueueLength,           order=triggerMetadata;resolvedEnv, default=1"`
+	ActivationTargetPipelineQueueLength int64    `keda:"name=activationTargetPipelineQueueLength, order=triggerMetadata;resolvedEnv, default=0"`
+	UnsafeSsl                           bool     `keda:"name=unsafeSsl,                           order=triggerMetadata, default=false"`
+
+	triggerIndex int
+}
+
+type gitlabProject struct {
+	ID                int64  `json:"id"`
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+type gitlabJob struct {
+	ID      int64    `json:"id"`
+	Status  string   `json:"status"`
+	TagList []string `json:"tag_list"`
+}
+
+func (m *gitlabRunnerMetadata) Validate() error {
+	if len(m.Projects) == 0 && m.GroupID == "" {
+		return fmt.Errorf("projects or groupID must be provided")
+	}
+	if m.TargetPipelineQueueLength <= 0 {
+		return fmt.Errorf("targetPipelineQueueLength must be greater than 0")
+	}
+	// a runner without tags only picks the untagged jobs
+	if len(m.Tags) == 0 {
+		m.RunUntagged = true
+	}
+	return nil
+}
+
+// NewGitLabRunnerScaler creates a new scaler for the GitLab CI jobs pending for the runners with the tags
+func NewGitLabRunnerScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseGitLabRunnerMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing GitLab Runner metadata: %w", err)
+	}
+
+	return &gitlabRunnerScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "gitlab_runner_scaler"),
+	}, nil
+}
+
+func parseGitLabRunnerMetadata(config *scalersconfig.ScalerConfig) (*gitlabRunnerMetadata, error) {
+	meta := &gitlabRunnerMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing gitlab runner metadata: %w", err)
+	}
+	meta.GitLabAPIURL = strings.TrimSuffix(meta.GitLabAPIURL, "/")
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// getGitLabRequest gets all the pages of a list of the GitLab API, the items of each page are passed to the function
+func (s *gitlabRunnerScaler) getGitLabRequest(ctx context.Context, path string, params url_pkg.Values, handlePage func(body []byte) error) error {
+	params.Set("per_page", fmt.Sprint(gitlabPageSize))
+	page := "1"
+	for page != "" {
+		params.Set("page", page)
+		url := fmt.Sprintf("%s/api/v4/%s?%s", s.metadata.GitLabAPIURL, path, params.Encode())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("PRIVATE-TOKEN", s.metadata.PersonalAccessToken)
+
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("error requesting the gitlab api: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("error reading the gitlab api response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("gitlab api %s returned status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+		if err := handlePage(body); err != nil {
+			return fmt.Errorf("error parsing the gitlab api response: %w", err)
+		}
+		page = resp.Header.Get(gitlabNextPageHeader)
+	}
+	return nil
+}
+
+// getProjects returns the projects whose jobs are counted, the projects of the group and of its subgroups
+// are listed when a group is set
+func (s *gitlabRunnerScaler) getProjects(ctx context.Context) ([]string, error) {
+	if s.metadata.GroupID == "" {
+		return s.metadata.Projects, nil
+	}
+
+	var projects []gitlabProject
+	params := url_pkg.Values{}
+	params.Set("include_subgroups", "true")
+	params.Set("archived", "false")
+	params.Set("simple", "true")
+	err := s.getGitLabRequest(ctx, fmt.Sprintf("groups/%s/projects", url_pkg.PathEscape(s.metadata.GroupID)), params, func(body []byte) error {
+		var page []gitlabProject
+		if err := json.Unmarshal(body, &page); err != nil {
+			return err
+		}
+		projects = append(projects, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the projects are restricted to the ones listed, by their ids or paths, when both are set
+	var ids []string
+	for _, project := range projects {
+		id := fmt.Sprint(project.ID)
+		if len(s.metadata.Projects) == 0 || contains(s.metadata.Projects, id) || contains(s.metadata.Projects, project.PathWithNamespace) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// canRunnerPickGitLabJob checks the runners with the tags can pick the job, all the tags of a job
+// have to be tags of the runner
+func (s *gitlabRunnerScaler) canRunnerPickGitLabJob(job gitlabJob) bool {
+	if len(job.TagList) == 0 {
+		return s.metadata.RunUntagged
+	}
+	for _, tag := range job.TagList {
+		if !contains(s.metadata.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetPipelineQueueLength returns the number of pending jobs the runners can pick
+func (s *gitlabRunnerScaler) GetPipelineQueueLength(ctx context.Context) (int64, error) {
+	projects, err := s.getProjects(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	var queueCount int64
+	params := url_pkg.Values{}
+	params.Set("scope[]", "pending")
+	for _, project := range projects {
+		err := s.getGitLabRequest(ctx, fmt.Sprintf("projects/%s/jobs", url_pkg.PathEscape(project)), params, func(body []byte) error {
+			var page []gitlabJob
+			if err := json.Unmarshal(body, &page); err != nil {
+				return err
+			}
+			for _, job := range page {
+				if s.canRunnerPickGitLabJob(job) {
+					queueCount++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return -1, err
+		}
+	}
+	return queueCount, nil
+}
+
+func (s *gitlabRunnerScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	queueLen, err := s.GetPipelineQueueLength(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting pipeline queue length")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(queueLen))
+	return []external_metrics.ExternalMetricValue{metric}, queueLen > s.metadata.ActivationTargetPipelineQueueLength, nil
+}
+
+func (s *gitlabRunnerScaler) GetMetricSpecForScaling(_ context.Context) []v2.MetricSpec {
+	scope := s.metadata.GroupID
+	if scope == "" {
+		scope = strings.Join(s.metadata.Projects, "-")
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("gitlab-runner-%s", scope))),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetPipelineQueueLength),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *gitlabRunnerScaler) Close(_ context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
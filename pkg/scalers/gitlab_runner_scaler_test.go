@@ -0,0 +1,142 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseGitLabRunnerMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type gitlabRunnerMetricIdentifier struct {
+	metadataTestData *parseGitLabRunnerMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testGitLabRunnerMetadata = []parseGitLabRunnerMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed metadata
+	{map[string]string{"projects": "kedacore/keda", "tags": "docker,linux"}, map[string]string{"personalAccessToken": "glpat"}, false},
+	// group with a self-managed instance
+	{map[string]string{"gitlabApiURL": "https://gitlab.example.com/", "groupID": "42", "targetPipelineQueueLength": "2"}, map[string]string{"personalAccessToken": "glpat"}, false},
+	// no personalAccessToken
+	{map[string]string{"projects": "kedacore/keda"}, map[string]string{}, true},
+	// no projects or groupID
+	{map[string]string{"tags": "docker"}, map[string]string{"personalAccessToken": "glpat"}, true},
+	// invalid targetPipelineQueueLength
+	{map[string]string{"projects": "kedacore/keda", "targetPipelineQueueLength": "0"}, map[string]string{"personalAccessToken": "glpat"}, true},
+}
+
+var gitlabRunnerMetricIdentifiers = []gitlabRunnerMetricIdentifier{
+	{&testGitLabRunnerMetadata[1], 0, "s0-gitlab-runner-kedacore-keda"},
+	{&testGitLabRunnerMetadata[2], 1, "s1-gitlab-runner-42"},
+}
+
+func TestGitLabRunnerParseMetadata(t *testing.T) {
+	for testCaseNum, testData := range testGitLabRunnerMetadata {
+		_, err := parseGitLabRunnerMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", testCaseNum, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", testCaseNum)
+		}
+	}
+
+	meta, err := parseGitLabRunnerMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testGitLabRunnerMetadata[2].metadata, AuthParams: testGitLabRunnerMetadata[2].authParams})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gitlab.example.com", meta.GitLabAPIURL)
+	// the runners without tags pick the untagged jobs
+	assert.True(t, meta.RunUntagged)
+}
+
+func TestGitLabRunnerGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range gitlabRunnerMetricIdentifiers {
+		meta, err := parseGitLabRunnerMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockGitLabRunnerScaler := gitlabRunnerScaler{metadata: meta}
+
+		metricSpec := mockGitLabRunnerScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Errorf("Wrong External metric source name: %s, expected: %s", metricName, testData.name)
+		}
+	}
+}
+
+func TestGitLabRunnerGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "glpat" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"401 Unauthorized"}`))
+			return
+		}
+		switch r.URL.EscapedPath() {
+		case "/api/v4/groups/42/projects":
+			assert.Equal(t, "true", r.URL.Query().Get("include_subgroups"))
+			_, _ = w.Write([]byte(`[{"id": 1, "path_with_namespace": "kedacore/keda"}, {"id": 2, "path_with_namespace": "kedacore/charts"}]`))
+		case "/api/v4/projects/kedacore%2Fkeda/jobs", "/api/v4/projects/1/jobs":
+			assert.Equal(t, "pending", r.URL.Query().Get("scope[]"))
+			// the jobs are paginated
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set(gitlabNextPageHeader, "2")
+				_, _ = w.Write([]byte(`[{"id": 10, "status": "pending", "tag_list": ["docker"]}, {"id": 11, "status": "pending", "tag_list": []}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"id": 12, "status": "pending", "tag_list": ["docker", "linux"]}, {"id": 13, "status": "pending", "tag_list": ["gpu"]}]`))
+		case "/api/v4/projects/2/jobs":
+			_, _ = w.Write([]byte(`[{"id": 20, "status": "pending", "tag_list": []}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"404 Project Not Found"}`))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		token    string
+		value    int64
+		isActive bool
+		isError  bool
+	}{
+		{"pending jobs with the tags of the runners", map[string]string{"projects": "kedacore/keda", "tags": "docker,linux"}, "glpat", 2, true, false},
+		{"untagged pending jobs too", map[string]string{"projects": "kedacore/keda", "tags": "docker,linux", "runUntagged": "true"}, "glpat", 3, true, false},
+		{"pending jobs of the projects of the group", map[string]string{"groupID": "42"}, "glpat", 2, true, false},
+		{"pending jobs of the projects of the group listed", map[string]string{"groupID": "42", "projects": "kedacore/charts"}, "glpat", 1, false, false},
+		{"unknown project", map[string]string{"projects": "kedacore/http-add-on"}, "glpat", 0, false, true},
+		{"invalid token", map[string]string{"projects": "kedacore/keda"}, "invalid", 0, false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.metadata["gitlabApiURL"] = server.URL
+			test.metadata["activationTargetPipelineQueueLength"] = "1"
+			meta, err := parseGitLabRunnerMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: test.metadata, AuthParams: map[string]string{"personalAccessToken": test.token}})
+			assert.NoError(t, err)
+			s := &gitlabRunnerScaler{metadata: meta, httpClient: http.DefaultClient}
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "s0-gitlab-runner")
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.value, metrics[0].Value.Value())
+			assert.Equal(t, test.isActive, isActive)
+		})
+	}
+}
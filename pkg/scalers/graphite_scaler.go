@@ -8,11 +8,13 @@ import (
 	"net/http"
 	url_pkg "net/url"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
@@ -23,10 +25,38 @@ const (
 	graphiteThreshold                  = "threshold"
 	graphiteActivationThreshold        = "activationThreshold"
 	graphiteQueryTime                  = "queryTime"
+	graphiteReducer                    = "reducer"
+	graphiteNullHandling               = "nullHandling"
 	defaultGraphiteThreshold           = 100
 	defaultGraphiteActivationThreshold = 0
+
+	// graphiteNullSkip uses the most recent non-null datapoint of a series
+	graphiteNullSkip = "skip"
+	// graphiteNullZero treats a null most recent datapoint as 0
+	graphiteNullZero = "zero"
 )
 
+var graphiteReducers = map[string]func([]float64) float64{
+	"sum": func(values []float64) float64 {
+		total := 0.0
+		for _, v := range values {
+			total += v
+		}
+		return total
+	},
+	"avg": AvgFloatFromSlice,
+	"max": MaxFloatFromSlice,
+	"min": func(values []float64) float64 {
+		minValue := values[0]
+		for _, v := range values {
+			if v < minValue {
+				minValue = v
+			}
+		}
+		return minValue
+	},
+}
+
 type graphiteScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *graphiteMetadata
@@ -37,6 +67,9 @@ type graphiteScaler struct {
 type graphiteMetadata struct {
 	serverAddress       string
 	query               string
+	targets             []string
+	reducer             string
+	nullHandling        string
 	threshold           float64
 	activationThreshold float64
 	from                string
@@ -45,7 +78,11 @@ type graphiteMetadata struct {
 	enableBasicAuth bool
 	username        string
 	password        string // +optional
-	triggerIndex    int
+
+	// bearer auth
+	enableBearerAuth bool
+	bearerToken      string
+	triggerIndex     int
 }
 
 type grapQueryResult []struct {
@@ -91,6 +128,31 @@ func parseGraphiteMetadata(config *scalersconfig.ScalerConfig) (*graphiteMetadat
 		return nil, fmt.Errorf("no %s given", graphiteQuery)
 	}
 
+	// several targets can be given separated by ';', as ',' is used by graphite functions
+	for _, target := range strings.Split(meta.query, ";") {
+		if target = strings.TrimSpace(target); target != "" {
+			meta.targets = append(meta.targets, target)
+		}
+	}
+	if len(meta.targets) == 0 {
+		return nil, fmt.Errorf("no %s given", graphiteQuery)
+	}
+
+	if val, ok := config.TriggerMetadata[graphiteReducer]; ok && val != "" {
+		if _, ok := graphiteReducers[val]; !ok {
+			return nil, fmt.Errorf("%s must be one of sum, avg, max or min", graphiteReducer)
+		}
+		meta.reducer = val
+	}
+
+	meta.nullHandling = graphiteNullSkip
+	if val, ok := config.TriggerMetadata[graphiteNullHandling]; ok && val != "" {
+		if val != graphiteNullSkip && val != graphiteNullZero {
+			return nil, fmt.Errorf("%s must be %s or %s", graphiteNullHandling, graphiteNullSkip, graphiteNullZero)
+		}
+		meta.nullHandling = val
+	}
+
 	if val, ok := config.TriggerMetadata[graphiteQueryTime]; ok && val != "" {
 		meta.from = val
 	} else {
@@ -124,8 +186,17 @@ func parseGraphiteMetadata(config *scalersconfig.ScalerConfig) (*graphiteMetadat
 	if !ok {
 		return &meta, nil
 	}
-	if val != "basic" {
-		return nil, fmt.Errorf("authMode must be 'basic'")
+	switch authentication.Type(strings.TrimSpace(val)) {
+	case authentication.BasicAuthType:
+	case authentication.BearerAuthType:
+		if len(config.AuthParams["token"]) == 0 {
+			return nil, fmt.Errorf("no token given")
+		}
+		meta.bearerToken = config.AuthParams["token"]
+		meta.enableBearerAuth = true
+		return &meta, nil
+	default:
+		return nil, fmt.Errorf("authMode must be 'basic' or 'bearer'")
 	}
 
 	if len(config.AuthParams["username"]) == 0 {
@@ -162,14 +233,26 @@ func (s *graphiteScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpe
 }
 
 func (s *graphiteScaler) executeGrapQuery(ctx context.Context) (float64, error) {
-	queryEscaped := url_pkg.QueryEscape(s.metadata.query)
-	url := fmt.Sprintf("%s/render?from=%s&target=%s&format=json", s.metadata.serverAddress, s.metadata.from, queryEscaped)
+	params := url_pkg.Values{}
+	params.Set("from", s.metadata.from)
+	params.Set("format", "json")
+	targets := s.metadata.targets
+	if len(targets) == 0 {
+		targets = []string{s.metadata.query}
+	}
+	for _, target := range targets {
+		params.Add("target", target)
+	}
+	url := fmt.Sprintf("%s/render?%s", s.metadata.serverAddress, params.Encode())
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return -1, err
 	}
-	if s.metadata.enableBasicAuth {
+	switch {
+	case s.metadata.enableBasicAuth:
 		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	case s.metadata.enableBearerAuth:
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.metadata.bearerToken))
 	}
 	r, err := s.httpClient.Do(req)
 	if err != nil {
@@ -182,6 +265,10 @@ func (s *graphiteScaler) executeGrapQuery(ctx context.Context) (float64, error)
 	}
 	r.Body.Close()
 
+	if r.StatusCode != http.StatusOK {
+		return -1, fmt.Errorf("graphite query %s failed with status %d: %s", s.metadata.query, r.StatusCode, string(b))
+	}
+
 	var result grapQueryResult
 	err = json.Unmarshal(b, &result)
 	if err != nil {
@@ -190,23 +277,52 @@ func (s *graphiteScaler) executeGrapQuery(ctx context.Context) (float64, error)
 
 	if len(result) == 0 {
 		return 0, nil
-	} else if len(result) > 1 {
-		return -1, fmt.Errorf("graphite query %s returned multiple series", s.metadata.query)
+	} else if len(result) > 1 && s.metadata.reducer == "" {
+		return -1, fmt.Errorf("graphite query %s returned multiple series, set a reducer to combine them", s.metadata.query)
 	}
 
-	// https://graphite-api.readthedocs.io/en/latest/api.html#json
-	if len(result[0].Datapoints) == 0 {
+	values := make([]float64, 0, len(result))
+	emptySeries := 0
+	for _, series := range result {
+		// https://graphite-api.readthedocs.io/en/latest/api.html#json
+		if len(series.Datapoints) == 0 {
+			emptySeries++
+			continue
+		}
+		if value, ok := s.latestDatapoint(series.Datapoints); ok {
+			values = append(values, value)
+		}
+	}
+
+	if emptySeries == len(result) {
 		return 0, nil
 	}
+	if len(values) == 0 {
+		return -1, fmt.Errorf("no valid non-null response in query %s, try increasing your queryTime or check your query", s.metadata.query)
+	}
+	if s.metadata.reducer == "" {
+		return values[0], nil
+	}
+	return graphiteReducers[s.metadata.reducer](values), nil
+}
+
+// latestDatapoint returns the most recent datapoint of the series according to the null handling
+func (s *graphiteScaler) latestDatapoint(datapoints [][]*float64) (float64, bool) {
+	if s.metadata.nullHandling == graphiteNullZero {
+		last := datapoints[len(datapoints)-1]
+		if len(last) == 0 || last[0] == nil {
+			return 0, true
+		}
+		return *last[0], true
+	}
 
 	// Return the most recent non-null datapoint
-	for i := len(result[0].Datapoints) - 1; i >= 0; i-- {
-		if datapoint := result[0].Datapoints[i][0]; datapoint != nil {
-			return *datapoint, nil
+	for i := len(datapoints) - 1; i >= 0; i-- {
+		if len(datapoints[i]) > 0 && datapoints[i][0] != nil {
+			return *datapoints[i][0], true
 		}
 	}
-
-	return -1, fmt.Errorf("no valid non-null response in query %s, try increasing your queryTime or check your query", s.metadata.query)
+	return 0, false
 }
 
 func (s *graphiteScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
@@ -37,6 +37,14 @@ var testGrapMetadata = []parseGraphiteMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:81", "metricName": "request-count", "threshold": "100", "query": "", "queryTime": "-30Seconds", "disableScaleToZero": "true"}, true},
 	// missing queryTime
 	{map[string]string{"serverAddress": "http://localhost:81", "metricName": "request-count", "threshold": "100", "query": "stats.counters.http.hello-world.request.count.count", "queryTime": ""}, true},
+	// multiple targets with reducer and null handling
+	{map[string]string{"serverAddress": "http://localhost:81", "threshold": "100", "query": "sumSeries(stats.a.*);movingAverage(stats.b, 5)", "queryTime": "-30Seconds", "reducer": "sum", "nullHandling": "zero"}, false},
+	// invalid reducer
+	{map[string]string{"serverAddress": "http://localhost:81", "threshold": "100", "query": "stats.a;stats.b", "queryTime": "-30Seconds", "reducer": "median"}, true},
+	// invalid nullHandling
+	{map[string]string{"serverAddress": "http://localhost:81", "threshold": "100", "query": "stats.a", "queryTime": "-30Seconds", "nullHandling": "ignore"}, true},
+	// only separators in query
+	{map[string]string{"serverAddress": "http://localhost:81", "threshold": "100", "query": " ; ", "queryTime": "-30Seconds"}, true},
 }
 
 var graphiteMetricIdentifiers = []graphiteMetricIdentifier{
@@ -57,6 +65,10 @@ var testGraphiteAuthMetadata = []graphiteAuthMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:81", "metricName": "request-count", "threshold": "100", "query": "stats.counters.http.hello-world.request.count.count", "queryTime": "-30Seconds", "authMode": "basic"}, map[string]string{}, true},
 	// fail if using non-basicAuth authMode
 	{map[string]string{"serverAddress": "http://localhost:81", "metricName": "request-count", "threshold": "100", "query": "stats.counters.http.hello-world.request.count.count", "queryTime": "-30Seconds", "authMode": "tls"}, map[string]string{"username": "user"}, true},
+	// success bearerAuth
+	{map[string]string{"serverAddress": "http://localhost:81", "metricName": "request-count", "threshold": "100", "query": "stats.counters.http.hello-world.request.count.count", "queryTime": "-30Seconds", "authMode": "bearer"}, map[string]string{"token": "jwt"}, false},
+	// fail bearerAuth with no token
+	{map[string]string{"serverAddress": "http://localhost:81", "metricName": "request-count", "threshold": "100", "query": "stats.counters.http.hello-world.request.count.count", "queryTime": "-30Seconds", "authMode": "bearer"}, map[string]string{}, true},
 }
 
 type grapQueryResultTestData struct {
@@ -65,6 +77,8 @@ type grapQueryResultTestData struct {
 	responseStatus int
 	expectedValue  float64
 	isError        bool
+	reducer        string
+	nullHandling   string
 }
 
 var testGrapQueryResults = []grapQueryResultTestData{
@@ -110,6 +124,39 @@ var testGrapQueryResults = []grapQueryResultTestData{
 		expectedValue:  -1,
 		isError:        true,
 	},
+	{
+		name:           "multiple results with reducer",
+		bodyStr:        `[{"target":"metric1","datapoints":[[1,1000000],[2,1000010]]}, {"target":"metric2","datapoints":[[3,1000000],[null,1000010]]}]`,
+		responseStatus: http.StatusOK,
+		expectedValue:  5,
+		isError:        false,
+		reducer:        "sum",
+	},
+	{
+		name:           "multiple results with reducer and zero nulls",
+		bodyStr:        `[{"target":"metric1","datapoints":[[1,1000000],[2,1000010]]}, {"target":"metric2","datapoints":[[3,1000000],[null,1000010]]}]`,
+		responseStatus: http.StatusOK,
+		expectedValue:  2,
+		isError:        false,
+		reducer:        "max",
+		nullHandling:   "zero",
+	},
+	{
+		name:           "multiple results with reducer skips empty series",
+		bodyStr:        `[{"target":"metric1","datapoints":[[null,1000000]]}, {"target":"metric2","datapoints":[[4,1000000]]}]`,
+		responseStatus: http.StatusOK,
+		expectedValue:  4,
+		isError:        false,
+		reducer:        "min",
+	},
+	{
+		name:           "all datapoints are null with zero nulls",
+		bodyStr:        `[{"target":"sumSeries(metric)","datapoints":[[null,10000000],[null,10000010]]}]`,
+		responseStatus: http.StatusOK,
+		expectedValue:  0,
+		isError:        false,
+		nullHandling:   "zero",
+	},
 }
 
 func TestGraphiteParseMetadata(t *testing.T) {
@@ -176,6 +223,8 @@ func TestGrapScalerExecuteGrapQuery(t *testing.T) {
 			scaler := graphiteScaler{
 				metadata: &graphiteMetadata{
 					serverAddress: server.URL,
+					reducer:       testData.reducer,
+					nullHandling:  testData.nullHandling,
 				},
 				httpClient: http.DefaultClient,
 			}
@@ -192,3 +241,26 @@ func TestGrapScalerExecuteGrapQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestGrapScalerExecuteGrapQueryTargetsAndAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		targets := request.URL.Query()["target"]
+		if request.Header.Get("Authorization") != "Bearer jwt" || len(targets) != 2 || targets[1] != "movingAverage(stats.b, 5)" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = writer.Write([]byte(`[{"target":"a","datapoints":[[1,1000000]]}, {"target":"b","datapoints":[[2,1000000]]}]`))
+	}))
+	defer server.Close()
+
+	meta, err := parseGraphiteMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"serverAddress": server.URL, "query": "stats.a; movingAverage(stats.b, 5)", "queryTime": "-30Seconds", "reducer": "avg", "authMode": "bearer"},
+		AuthParams:      map[string]string{"token": "jwt"},
+	})
+	assert.NoError(t, err)
+
+	scaler := graphiteScaler{metadata: meta, httpClient: http.DefaultClient}
+	value, err := scaler.executeGrapQuery(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, value)
+}
@@ -0,0 +1,339 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	grpcmetadata "google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type grpcProbeScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *grpcProbeMetadata
+	conn       *grpc.ClientConn
+	// method is resolved with the server reflection on the first call
+	methodLock sync.Mutex
+	method     protoreflect.MethodDescriptor
+	logger     logr.Logger
+}
+
+type grpcProbeMetadata struct {
+	Address string `keda:"name=address, order=triggerMetadata"`
+
+	// Method is the unary method called with the Request, as package.Service/Method, the standard health
+	// service is called when it's empty
+	Method        string `keda:"name=method,        order=triggerMetadata, optional"`
+	Request       string `keda:"name=request,       order=triggerMetadata, default={}"`
+	HealthService string `keda:"name=healthService, order=triggerMetadata, optional"`
+
+	// ValueField is the path of a numeric field of the response in its JSON form, the metric is the
+	// latency of the call in milliseconds without it
+	ValueField string `keda:"name=valueField, order=triggerMetadata, optional"`
+
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	BearerToken string `keda:"name=bearerToken, order=authParams;resolvedEnv, optional"`
+
+	// TLS
+	EnableTLS bool   `keda:"name=tls,       order=triggerMetadata, default=false"`
+	UnsafeSsl bool   `keda:"name=unsafeSsl, order=triggerMetadata, default=false"`
+	CA        string `keda:"name=ca,        order=authParams, optional"`
+	Cert      string `keda:"name=cert,      order=authParams, optional"`
+	Key       string `keda:"name=key,       order=authParams, optional"`
+
+	triggerIndex int
+}
+
+func (m *grpcProbeMetadata) Validate() error {
+	m.Method = strings.TrimPrefix(m.Method, "/")
+	if m.Method != "" {
+		if service, method, ok := strings.Cut(m.Method, "/"); !ok || service == "" || method == "" || strings.Contains(method, "/") {
+			return fmt.Errorf("method must be package.Service/Method but is %s", m.Method)
+		}
+		if m.HealthService != "" {
+			return errors.New("healthService can't be used with method")
+		}
+	} else if m.ValueField != "" {
+		return errors.New("valueField requires method")
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("both cert and key must be provided when using TLS")
+	}
+	if !m.EnableTLS && (m.CA != "" || m.Cert != "" || m.UnsafeSsl) {
+		return errors.New("ca, cert, key and unsafeSsl require tls")
+	}
+	return nil
+}
+
+// NewGrpcProbeScaler creates a new scaler calling a gRPC method
+func NewGrpcProbeScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseGrpcProbeMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing grpc-probe metadata: %w", err)
+	}
+
+	transportCredentials := insecure.NewCredentials()
+	if meta.EnableTLS {
+		tlsConfig, err := kedautil.NewTLSConfig(meta.Cert, meta.Key, meta.CA, meta.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		transportCredentials = credentials.NewTLS(tlsConfig)
+	}
+
+	// nosemgrep: go.grpc.ssrf.grpc-tainted-url-host.grpc-tainted-url-host
+	conn, err := grpc.NewClient(meta.Address, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return nil, fmt.Errorf("error creating the grpc client: %w", err)
+	}
+
+	return &grpcProbeScaler{
+		metricType: metricType,
+		metadata:   meta,
+		conn:       conn,
+		logger:     InitializeLogger(config, "grpc_probe_scaler"),
+	}, nil
+}
+
+func parseGrpcProbeMetadata(config *scalersconfig.ScalerConfig) (*grpcProbeMetadata, error) {
+	meta := &grpcProbeMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing grpc-probe metadata: %w", err)
+	}
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// getValue calls the method, or the health service, and returns the field of the response or the latency
+func (s *grpcProbeScaler) getValue(ctx context.Context) (float64, error) {
+	if s.metadata.BearerToken != "" {
+		ctx = grpcmetadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+s.metadata.BearerToken)
+	}
+
+	if s.metadata.Method == "" {
+		start := time.Now()
+		response, err := grpc_health_v1.NewHealthClient(s.conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: s.metadata.HealthService})
+		if err != nil {
+			return 0, fmt.Errorf("error checking the health: %w", err)
+		}
+		if response.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+			return 0, fmt.Errorf("the health status is %s", response.GetStatus())
+		}
+		return float64(time.Since(start).Microseconds()) / 1000, nil
+	}
+
+	method, err := s.getMethod(ctx)
+	if err != nil {
+		return 0, err
+	}
+	request := dynamicpb.NewMessage(method.Input())
+	if err := protojson.Unmarshal([]byte(s.metadata.Request), request); err != nil {
+		return 0, fmt.Errorf("error parsing the request for %s: %w", method.Input().FullName(), err)
+	}
+	response := dynamicpb.NewMessage(method.Output())
+
+	start := time.Now()
+	if err := s.conn.Invoke(ctx, "/"+s.metadata.Method, request, response); err != nil {
+		return 0, fmt.Errorf("error calling %s: %w", s.metadata.Method, err)
+	}
+	latency := float64(time.Since(start).Microseconds()) / 1000
+
+	if s.metadata.ValueField == "" {
+		return latency, nil
+	}
+	body, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(response)
+	if err != nil {
+		return 0, fmt.Errorf("error converting the response of %s: %w", s.metadata.Method, err)
+	}
+	return getValueFromSearch(body, s.metadata.ValueField)
+}
+
+// getMethod returns the descriptor of the method, resolved with the server reflection
+func (s *grpcProbeScaler) getMethod(ctx context.Context) (protoreflect.MethodDescriptor, error) {
+	s.methodLock.Lock()
+	defer s.methodLock.Unlock()
+	if s.method != nil {
+		return s.method, nil
+	}
+
+	serviceName, methodName, _ := strings.Cut(s.metadata.Method, "/")
+	files, err := resolveGrpcServiceFiles(ctx, reflectionpb.NewServerReflectionClient(s.conn), serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving %s with the server reflection: %w", serviceName, err)
+	}
+	descriptor, err := files.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, fmt.Errorf("error resolving %s with the server reflection: %w", serviceName, err)
+	}
+	service, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s isn't a service", serviceName)
+	}
+	method := service.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil, fmt.Errorf("the service %s has no method %s", serviceName, methodName)
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, fmt.Errorf("the method %s isn't unary", s.metadata.Method)
+	}
+	s.method = method
+	return method, nil
+}
+
+// resolveGrpcServiceFiles returns the file defining the service and its dependencies, the files
+// linked in the binary such as the well-known types are used when the server doesn't return them
+func resolveGrpcServiceFiles(ctx context.Context, client reflectionpb.ServerReflectionClient, serviceName string) (*protoregistry.Files, error) {
+	stream, err := client.ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = stream.CloseSend() }()
+
+	fileProtos := map[string]*descriptorpb.FileDescriptorProto{}
+	request := &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: serviceName},
+	}
+	var rootFile string
+	for request != nil {
+		if err := stream.Send(request); err != nil {
+			return nil, err
+		}
+		response, err := stream.Recv()
+		if err != nil {
+			if status.Code(err) == codes.Unimplemented {
+				return nil, errors.New("the server doesn't implement the grpc.reflection.v1 service")
+			}
+			return nil, err
+		}
+		if errorResponse := response.GetErrorResponse(); errorResponse != nil {
+			return nil, fmt.Errorf("server reflection error %d: %s", errorResponse.GetErrorCode(), errorResponse.GetErrorMessage())
+		}
+		for _, file := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fileProto := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(file, fileProto); err != nil {
+				return nil, err
+			}
+			fileProtos[fileProto.GetName()] = fileProto
+			// the first file defines the service
+			if rootFile == "" {
+				rootFile = fileProto.GetName()
+			}
+		}
+
+		// ask for the dependencies which are neither returned nor linked in the binary
+		request = nil
+		for _, fileProto := range fileProtos {
+			for _, dependency := range fileProto.GetDependency() {
+				if _, ok := fileProtos[dependency]; ok {
+					continue
+				}
+				if _, err := protoregistry.GlobalFiles.FindFileByPath(dependency); err == nil {
+					continue
+				}
+				request = &reflectionpb.ServerReflectionRequest{
+					MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dependency},
+				}
+				break
+			}
+			if request != nil {
+				break
+			}
+		}
+	}
+	if rootFile == "" {
+		return nil, errors.New("the server returned no file")
+	}
+
+	files := &protoregistry.Files{}
+	var register func(name string) error
+	register = func(name string) error {
+		if _, err := files.FindFileByPath(name); err == nil {
+			return nil
+		}
+		fileProto, ok := fileProtos[name]
+		if !ok {
+			file, err := protoregistry.GlobalFiles.FindFileByPath(name)
+			if err != nil {
+				return err
+			}
+			return files.RegisterFile(file)
+		}
+		for _, dependency := range fileProto.GetDependency() {
+			if err := register(dependency); err != nil {
+				return err
+			}
+		}
+		file, err := protodesc.NewFile(fileProto, files)
+		if err != nil {
+			return err
+		}
+		return files.RegisterFile(file)
+	}
+	if err := register(rootFile); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (s *grpcProbeScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "Error probing", "address", s.metadata.Address)
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *grpcProbeScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	method := s.metadata.Method
+	if method == "" {
+		method = "health"
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("grpc-probe-%s", method))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *grpcProbeScaler) Close(context.Context) error {
+	if s.conn != nil {
+		return s.conn.Close()
+	}
+	return nil
+}
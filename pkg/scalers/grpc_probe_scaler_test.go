@@ -0,0 +1,149 @@
+package scalers
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	grpcmetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+
+	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseGrpcProbeMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type grpcProbeMetricIdentifier struct {
+	metadataTestData *parseGrpcProbeMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testGrpcProbeMetadata = []parseGrpcProbeMetadataTestData{
+	// health check
+	{map[string]string{"address": "app:9090", "targetValue": "100"}, map[string]string{}, false},
+	// method with a value field
+	{map[string]string{"address": "app:9090", "method": "app.v1.Load/Get", "request": `{"queue": "orders"}`, "valueField": "pending", "targetValue": "10"}, map[string]string{}, false},
+	// method with a leading slash and tls
+	{map[string]string{"address": "app:9090", "method": "/app.v1.Load/Get", "tls": "true", "targetValue": "10"}, map[string]string{"ca": "caaa"}, false},
+	// missing address
+	{map[string]string{"targetValue": "100"}, map[string]string{}, true},
+	// missing targetValue
+	{map[string]string{"address": "app:9090"}, map[string]string{}, true},
+	// invalid method
+	{map[string]string{"address": "app:9090", "method": "app.v1.Load", "targetValue": "10"}, map[string]string{}, true},
+	// valueField without method
+	{map[string]string{"address": "app:9090", "valueField": "pending", "targetValue": "10"}, map[string]string{}, true},
+	// healthService with method
+	{map[string]string{"address": "app:9090", "method": "app.v1.Load/Get", "healthService": "app", "targetValue": "10"}, map[string]string{}, true},
+	// ca without tls
+	{map[string]string{"address": "app:9090", "targetValue": "10"}, map[string]string{"ca": "caaa"}, true},
+	// cert without key
+	{map[string]string{"address": "app:9090", "tls": "true", "targetValue": "10"}, map[string]string{"cert": "ceert"}, true},
+}
+
+var grpcProbeMetricIdentifiers = []grpcProbeMetricIdentifier{
+	{&testGrpcProbeMetadata[0], 0, "s0-grpc-probe-health"},
+	{&testGrpcProbeMetadata[1], 1, "s1-grpc-probe-app-v1-Load-Get"},
+}
+
+func TestGrpcProbeParseMetadata(t *testing.T) {
+	for i, testData := range testGrpcProbeMetadata {
+		_, err := parseGrpcProbeMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", i, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", i)
+		}
+	}
+}
+
+func TestGrpcProbeGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range grpcProbeMetricIdentifiers {
+		meta, err := parseGrpcProbeMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockGrpcProbeScaler := grpcProbeScaler{metadata: meta}
+
+		metricSpec := mockGrpcProbeScaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+type testGrpcProbeExternalScaler struct {
+	pb.UnimplementedExternalScalerServer
+}
+
+func (testGrpcProbeExternalScaler) GetMetrics(ctx context.Context, request *pb.GetMetricsRequest) (*pb.GetMetricsResponse, error) {
+	md, _ := grpcmetadata.FromIncomingContext(ctx)
+	value := int64(len(request.GetMetricName()))
+	if len(md.Get("authorization")) > 0 && md.Get("authorization")[0] == "Bearer token" {
+		value *= 10
+	}
+	return &pb.GetMetricsResponse{MetricValues: []*pb.MetricValue{{MetricName: request.GetMetricName(), MetricValue: value}}}, nil
+}
+
+func TestGrpcProbeGetMetricsAndActivity(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("draining", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	pb.RegisterExternalScalerServer(server, testGrpcProbeExternalScaler{})
+	reflection.Register(server)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		authParams     map[string]string
+		expectedValue  float64
+		expectedActive bool
+		expectedError  string
+	}{
+		{"health latency", map[string]string{}, map[string]string{}, 0, true, ""},
+		{"health not serving", map[string]string{"healthService": "draining"}, map[string]string{}, 0, false, "the health status is NOT_SERVING"},
+		{"response field", map[string]string{"method": "externalscaler.ExternalScaler/GetMetrics", "request": `{"metricName": "orders"}`, "valueField": "metricValues.0.metricValue"}, map[string]string{}, 6, true, ""},
+		{"response field with a bearer token", map[string]string{"method": "externalscaler.ExternalScaler/GetMetrics", "request": `{"metricName": "orders"}`, "valueField": "metricValues.0.metricValue"}, map[string]string{"bearerToken": "token"}, 60, true, ""},
+		{"method latency", map[string]string{"method": "externalscaler.ExternalScaler/GetMetrics"}, map[string]string{}, 0, true, ""},
+		{"unknown method", map[string]string{"method": "externalscaler.ExternalScaler/GetLoad"}, map[string]string{}, 0, false, "the service externalscaler.ExternalScaler has no method GetLoad"},
+		{"invalid request", map[string]string{"method": "externalscaler.ExternalScaler/GetMetrics", "request": `{"queue": "orders"}`}, map[string]string{}, 0, false, "error parsing the request for externalscaler.GetMetricsRequest"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["address"] = listener.Addr().String()
+			tc.metadata["targetValue"] = "10"
+			tc.metadata["activationTargetValue"] = "-1"
+			s, err := NewGrpcProbeScaler(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: tc.authParams})
+			assert.NoError(t, err)
+			defer s.Close(context.Background())
+			s.(*grpcProbeScaler).logger = logr.Discard()
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedActive, isActive)
+			if tc.expectedValue != 0 {
+				assert.Equal(t, tc.expectedValue, metrics[0].Value.AsApproximateFloat64())
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	url_pkg "net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/tidwall/gjson"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	hashicorpKVStoreConsul = "consul"
+	hashicorpKVStoreVault  = "vault"
+)
+
+type hashicorpKVScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *hashicorpKVMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type hashicorpKVMetadata struct {
+	Store     string `keda:"name=store,     order=triggerMetadata, enum=consul;vault"`
+	Address   string `keda:"name=address,   order=triggerMetadata;resolvedEnv"`
+	Key       string `keda:"name=key,       order=triggerMetadata"`
+	Token     string `keda:"name=token,     order=authParams;resolvedEnv, optional"`
+	Namespace string `keda:"name=namespace, order=triggerMetadata, optional"`
+	UnsafeSsl bool   `keda:"name=unsafeSsl, order=triggerMetadata, default=false"`
+
+	// ValueLocation is the GJSON path of the value in the JSON of the consul key, or in the data of the vault secret
+	ValueLocation string `keda:"name=valueLocation, order=triggerMetadata, optional"`
+
+	// consul
+	Datacenter string `keda:"name=datacenter, order=triggerMetadata, optional"`
+
+	// vault
+	Mount     string `keda:"name=mount,     order=triggerMetadata, default=secret"`
+	KVVersion int    `keda:"name=kvVersion, order=triggerMetadata, enum=1;2, default=2"`
+
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	triggerIndex int
+}
+
+func (m *hashicorpKVMetadata) Validate() error {
+	switch m.Store {
+	case hashicorpKVStoreConsul:
+		if m.KVVersion != 2 || m.Mount != "secret" {
+			return errors.New("mount and kvVersion can only be used with store vault")
+		}
+	case hashicorpKVStoreVault:
+		if m.Datacenter != "" {
+			return errors.New("datacenter can only be used with store consul")
+		}
+		if m.ValueLocation == "" {
+			return errors.New("valueLocation is required with store vault")
+		}
+		if m.Token == "" {
+			return errors.New("token is required with store vault")
+		}
+	}
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	return nil
+}
+
+// NewHashicorpKVScaler creates a new scaler for a numeric value published in a key of Consul KV,
+// or in a secret of the Vault KV secrets engine
+func NewHashicorpKVScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseHashicorpKVMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing hashicorp kv metadata: %w", err)
+	}
+
+	return &hashicorpKVScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "hashicorp_kv_scaler"),
+	}, nil
+}
+
+func parseHashicorpKVMetadata(config *scalersconfig.ScalerConfig) (*hashicorpKVMetadata, error) {
+	meta := &hashicorpKVMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing hashicorp kv metadata: %w", err)
+	}
+	meta.Address = strings.TrimSuffix(meta.Address, "/")
+	meta.Key = strings.Trim(meta.Key, "/")
+	meta.Mount = strings.Trim(meta.Mount, "/")
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// getValue reads the value of the key from the store
+func (s *hashicorpKVScaler) getValue(ctx context.Context) (float64, error) {
+	if s.metadata.Store == hashicorpKVStoreVault {
+		return s.getVaultValue(ctx)
+	}
+	return s.getConsulValue(ctx)
+}
+
+// getConsulValue reads the raw value of the consul key, a number or the JSON holding it
+func (s *hashicorpKVScaler) getConsulValue(ctx context.Context) (float64, error) {
+	params := url_pkg.Values{}
+	params.Set("raw", "true")
+	if s.metadata.Datacenter != "" {
+		params.Set("dc", s.metadata.Datacenter)
+	}
+	url := fmt.Sprintf("%s/v1/kv/%s?%s", s.metadata.Address, escapeHashicorpKVPath(s.metadata.Key), params.Encode())
+	body, err := s.get(ctx, url, "X-Consul-Token", "X-Consul-Namespace")
+	if err != nil {
+		return 0, err
+	}
+
+	if s.metadata.ValueLocation != "" {
+		return getValueFromSearch(body, s.metadata.ValueLocation)
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(body)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("value of consul key %s isn't a number: %q", s.metadata.Key, body)
+	}
+	return value, nil
+}
+
+// getVaultValue reads the field of the vault secret at valueLocation, the data of the version 2
+// of the KV secrets engine is nested under the data of the response
+func (s *hashicorpKVScaler) getVaultValue(ctx context.Context) (float64, error) {
+	path := fmt.Sprintf("%s/%s", escapeHashicorpKVPath(s.metadata.Mount), escapeHashicorpKVPath(s.metadata.Key))
+	location := "data." + s.metadata.ValueLocation
+	if s.metadata.KVVersion == 2 {
+		path = fmt.Sprintf("%s/data/%s", escapeHashicorpKVPath(s.metadata.Mount), escapeHashicorpKVPath(s.metadata.Key))
+		location = "data.data." + s.metadata.ValueLocation
+	}
+	body, err := s.get(ctx, fmt.Sprintf("%s/v1/%s", s.metadata.Address, path), "X-Vault-Token", "X-Vault-Namespace")
+	if err != nil {
+		return 0, err
+	}
+	if !gjson.GetBytes(body, location).Exists() {
+		return 0, fmt.Errorf("valueLocation %s not found in vault secret %s", s.metadata.ValueLocation, s.metadata.Key)
+	}
+	return getValueFromSearch(body, location)
+}
+
+func (s *hashicorpKVScaler) get(ctx context.Context, url, tokenHeader, namespaceHeader string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.metadata.Token != "" {
+		req.Header.Set(tokenHeader, s.metadata.Token)
+	}
+	if s.metadata.Namespace != "" {
+		req.Header.Set(namespaceHeader, s.metadata.Namespace)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting %s: %w", s.metadata.Store, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the %s response: %w", s.metadata.Store, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s key %s not found", s.metadata.Store, s.metadata.Key)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d: %s", s.metadata.Store, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// escapeHashicorpKVPath escapes the segments of the path, keeping its slashes
+func escapeHashicorpKVPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url_pkg.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func (s *hashicorpKVScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting hashicorp kv value")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *hashicorpKVScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("%s-kv-%s", s.metadata.Store, s.metadata.Key))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *hashicorpKVScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
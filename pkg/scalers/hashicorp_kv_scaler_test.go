@@ -0,0 +1,143 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseHashicorpKVMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type hashicorpKVMetricIdentifier struct {
+	metadataTestData *parseHashicorpKVMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testHashicorpKVMetadata = []parseHashicorpKVMetadataTestData{
+	// consul key
+	{map[string]string{"store": "consul", "address": "http://consul:8500", "key": "demand/orders", "targetValue": "10"}, map[string]string{"token": "secret"}, false},
+	// vault secret
+	{map[string]string{"store": "vault", "address": "https://vault:8200", "key": "demand/orders", "valueLocation": "workers", "targetValue": "2.5"}, map[string]string{"token": "s.token"}, false},
+	// vault secret of a kv version 1 mount
+	{map[string]string{"store": "vault", "address": "https://vault:8200", "mount": "kv", "kvVersion": "1", "key": "demand/orders", "valueLocation": "workers", "targetValue": "5"}, map[string]string{"token": "s.token"}, false},
+	// unknown store
+	{map[string]string{"store": "etcd", "address": "http://etcd:2379", "key": "demand/orders", "targetValue": "10"}, map[string]string{}, true},
+	// missing key
+	{map[string]string{"store": "consul", "address": "http://consul:8500", "targetValue": "10"}, map[string]string{}, true},
+	// missing targetValue
+	{map[string]string{"store": "consul", "address": "http://consul:8500", "key": "demand/orders"}, map[string]string{}, true},
+	// invalid kvVersion
+	{map[string]string{"store": "vault", "address": "https://vault:8200", "kvVersion": "3", "key": "demand/orders", "valueLocation": "workers", "targetValue": "5"}, map[string]string{"token": "s.token"}, true},
+	// vault without valueLocation
+	{map[string]string{"store": "vault", "address": "https://vault:8200", "key": "demand/orders", "targetValue": "5"}, map[string]string{"token": "s.token"}, true},
+	// vault without token
+	{map[string]string{"store": "vault", "address": "https://vault:8200", "key": "demand/orders", "valueLocation": "workers", "targetValue": "5"}, map[string]string{}, true},
+	// mount with consul
+	{map[string]string{"store": "consul", "address": "http://consul:8500", "mount": "kv", "key": "demand/orders", "targetValue": "10"}, map[string]string{}, true},
+	// datacenter with vault
+	{map[string]string{"store": "vault", "address": "https://vault:8200", "datacenter": "dc1", "key": "demand/orders", "valueLocation": "workers", "targetValue": "5"}, map[string]string{"token": "s.token"}, true},
+}
+
+var hashicorpKVMetricIdentifiers = []hashicorpKVMetricIdentifier{
+	{&testHashicorpKVMetadata[0], 0, "s0-consul-kv-demand-orders"},
+	{&testHashicorpKVMetadata[1], 1, "s1-vault-kv-demand-orders"},
+}
+
+func TestHashicorpKVParseMetadata(t *testing.T) {
+	for i, testData := range testHashicorpKVMetadata {
+		_, err := parseHashicorpKVMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("test case %d: expected success but got error %v", i, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("test case %d: expected error but got success", i)
+		}
+	}
+}
+
+func TestHashicorpKVGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range hashicorpKVMetricIdentifiers {
+		meta, err := parseHashicorpKVMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := hashicorpKVScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func TestHashicorpKVGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/kv/demand/orders":
+			assert.Equal(t, "consul-token", r.Header.Get("X-Consul-Token"))
+			assert.Equal(t, "true", r.URL.Query().Get("raw"))
+			assert.Equal(t, "dc1", r.URL.Query().Get("dc"))
+			fmt.Fprint(w, "42\n")
+		case "/v1/kv/demand/payments":
+			fmt.Fprint(w, `{"backlog": {"pending": 7}}`)
+		case "/v1/kv/demand/invalid":
+			fmt.Fprint(w, "many")
+		case "/v1/secret/data/demand/orders":
+			assert.Equal(t, "vault-token", r.Header.Get("X-Vault-Token"))
+			assert.Equal(t, "team-a", r.Header.Get("X-Vault-Namespace"))
+			fmt.Fprint(w, `{"data": {"data": {"workers": "12.5"}, "metadata": {"version": 3}}}`)
+		case "/v1/kv/demand/orders/v1":
+			fmt.Fprint(w, `{"data": {"workers": 3}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		authParams     map[string]string
+		expectedValue  float64
+		expectedActive bool
+		expectedError  string
+	}{
+		{"consul raw value", map[string]string{"store": "consul", "key": "demand/orders", "datacenter": "dc1"}, map[string]string{"token": "consul-token"}, 42, true, ""},
+		{"consul json value", map[string]string{"store": "consul", "key": "demand/payments", "valueLocation": "backlog.pending"}, map[string]string{}, 7, true, ""},
+		{"consul value isn't a number", map[string]string{"store": "consul", "key": "demand/invalid"}, map[string]string{}, 0, false, `value of consul key demand/invalid isn't a number: "many"`},
+		{"consul missing key", map[string]string{"store": "consul", "key": "demand/shipping"}, map[string]string{}, 0, false, "consul key demand/shipping not found"},
+		{"vault kv version 2", map[string]string{"store": "vault", "key": "demand/orders", "valueLocation": "workers", "namespace": "team-a"}, map[string]string{"token": "vault-token"}, 12.5, true, ""},
+		{"vault kv version 1", map[string]string{"store": "vault", "mount": "kv", "kvVersion": "1", "key": "demand/orders/v1", "valueLocation": "workers"}, map[string]string{"token": "vault-token"}, 3, false, ""},
+		{"vault missing field", map[string]string{"store": "vault", "mount": "kv", "kvVersion": "1", "key": "demand/orders/v1", "valueLocation": "replicas"}, map[string]string{"token": "vault-token"}, 0, false, "valueLocation replicas not found in vault secret demand/orders/v1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["address"] = server.URL
+			tc.metadata["targetValue"] = "10"
+			tc.metadata["activationTargetValue"] = "5"
+			meta, err := parseHashicorpKVMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: tc.authParams})
+			assert.NoError(t, err)
+			scaler := hashicorpKVScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.expectedActive, isActive)
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	hpcSchedulerSlurm    = "slurm"
+	hpcSchedulerHTCondor = "htcondor"
+
+	// htcondorIdleJobs is the constraint of the jobs waiting to be matched, JobStatus 1 is Idle
+	htcondorIdleJobs = "JobStatus == 1"
+)
+
+type hpcSchedulerScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *hpcSchedulerMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type hpcSchedulerMetadata struct {
+	Auth *authentication.Config `keda:"optional"`
+
+	Scheduler string `keda:"name=scheduler, order=triggerMetadata, enum=slurm;htcondor"`
+	// URL is the slurmrestd endpoint or the HTCondor REST daemon, e.g. http://slurmrestd.hpc:6820
+	URL string `keda:"name=url, order=triggerMetadata;resolvedEnv"`
+
+	// Partition restricts the pending Slurm jobs to the ones which can run in the partition
+	Partition  string `keda:"name=partition,  order=triggerMetadata, optional"`
+	APIVersion string `keda:"name=apiVersion, order=triggerMetadata, default=v0.0.40"`
+	// SlurmUser and SlurmToken are the user and the JWT sent to slurmrestd
+	SlurmUser  string `keda:"name=slurmUser,  order=authParams;triggerMetadata, optional"`
+	SlurmToken string `keda:"name=slurmToken, order=authParams, optional"`
+
+	// Constraint is a ClassAd expression the idle HTCondor jobs must match, e.g. RequestGPUs > 0
+	Constraint string `keda:"name=constraint, order=triggerMetadata, optional"`
+	Schedd     string `keda:"name=schedd,     order=triggerMetadata, optional"`
+
+	UnsafeSsl bool `keda:"name=unsafeSsl, order=triggerMetadata, default=false"`
+
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	triggerIndex int
+}
+
+func (m *hpcSchedulerMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return fmt.Errorf("targetValue must be greater than 0")
+	}
+	if !m.Auth.Disabled() && m.Auth.EnabledOAuth() {
+		return fmt.Errorf("authMode oauth isn't supported")
+	}
+	switch m.Scheduler {
+	case hpcSchedulerSlurm:
+		if m.Constraint != "" || m.Schedd != "" {
+			return fmt.Errorf("constraint and schedd aren't supported by slurm, use partition")
+		}
+		if m.SlurmToken != "" && m.SlurmUser == "" {
+			return fmt.Errorf("slurmUser is required with slurmToken")
+		}
+	case hpcSchedulerHTCondor:
+		if m.Partition != "" {
+			return fmt.Errorf("partition isn't supported by htcondor, use constraint")
+		}
+		if m.SlurmUser != "" || m.SlurmToken != "" {
+			return fmt.Errorf("slurmUser and slurmToken aren't supported by htcondor")
+		}
+	}
+	return nil
+}
+
+// NewHPCSchedulerScaler creates a new scaler for the pending jobs of a Slurm partition or the idle jobs
+// of an HTCondor schedd, to burst the workers of the cluster into Kubernetes
+func NewHPCSchedulerScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseHPCSchedulerMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing hpc scheduler metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	if !meta.Auth.Disabled() && (meta.Auth.CA != "" || meta.Auth.EnabledTLS()) {
+		transport, err := authentication.CreateHTTPRoundTripper(authentication.NetHTTP, meta.Auth.ToAuthMeta())
+		if err != nil {
+			return nil, fmt.Errorf("error creating the hpc scheduler http transport: %w", err)
+		}
+		httpClient.Transport = transport
+	}
+
+	return &hpcSchedulerScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "hpc_scheduler_scaler"),
+	}, nil
+}
+
+func parseHPCSchedulerMetadata(config *scalersconfig.ScalerConfig) (*hpcSchedulerMetadata, error) {
+	meta := &hpcSchedulerMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing hpc scheduler metadata: %w", err)
+	}
+	meta.URL = strings.TrimSuffix(meta.URL, "/")
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// get sends the request to the scheduler and decodes its json response into v
+func (s *hpcSchedulerScaler) get(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.metadata.SlurmUser != "" {
+		req.Header.Set("X-SLURM-USER-NAME", s.metadata.SlurmUser)
+	}
+	if s.metadata.SlurmToken != "" {
+		req.Header.Set("X-SLURM-USER-TOKEN", s.metadata.SlurmToken)
+	}
+	switch {
+	case s.metadata.Auth.Disabled():
+	case s.metadata.Auth.EnabledBearerAuth():
+		req.Header.Set("Authorization", s.metadata.Auth.GetBearerToken())
+	case s.metadata.Auth.EnabledBasicAuth():
+		req.SetBasicAuth(s.metadata.Auth.Username, s.metadata.Auth.Password)
+	case s.metadata.Auth.EnabledCustomAuth():
+		req.Header.Set(s.metadata.Auth.CustomAuthHeader, s.metadata.Auth.CustomAuthValue)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s api returned %d", resp.Request.URL.Path, s.metadata.Scheduler, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("error decoding the %s response: %w", s.metadata.Scheduler, err)
+	}
+	return nil
+}
+
+// slurmJobState is the state of a job, a string up to v0.0.38 of the API and a list of flags since
+type slurmJobState []string
+
+func (s *slurmJobState) UnmarshalJSON(data []byte) error {
+	var state string
+	if err := json.Unmarshal(data, &state); err == nil {
+		*s = []string{state}
+		return nil
+	}
+	var states []string
+	if err := json.Unmarshal(data, &states); err != nil {
+		return err
+	}
+	*s = states
+	return nil
+}
+
+type slurmJobsResponse struct {
+	Jobs []struct {
+		JobState slurmJobState `json:"job_state"`
+		// Partition lists the partitions a pending job can run in, separated by commas
+		Partition   string `json:"partition"`
+		StateReason string `json:"state_reason"`
+	} `json:"jobs"`
+	Errors []struct {
+		Error       string `json:"error"`
+		Description string `json:"description"`
+	} `json:"errors"`
+}
+
+// getSlurmPendingJobs returns the pending jobs of the partition, the jobs held by a user or an
+// administrator aren't counted as they won't start on new workers
+func (s *hpcSchedulerScaler) getSlurmPendingJobs(ctx context.Context) (float64, error) {
+	url := fmt.Sprintf("%s/slurm/%s/jobs", s.metadata.URL, s.metadata.APIVersion)
+	var response slurmJobsResponse
+	if err := s.get(ctx, url, &response); err != nil {
+		return -1, err
+	}
+	if len(response.Errors) > 0 {
+		return -1, fmt.Errorf("slurm error: %s %s", response.Errors[0].Error, response.Errors[0].Description)
+	}
+
+	pending := 0
+	for _, job := range response.Jobs {
+		if !slices.Contains(job.JobState, "PENDING") || strings.HasPrefix(job.StateReason, "JobHeld") {
+			continue
+		}
+		if s.metadata.Partition != "" && !slices.Contains(strings.Split(job.Partition, ","), s.metadata.Partition) {
+			continue
+		}
+		pending++
+	}
+	return float64(pending), nil
+}
+
+// getHTCondorIdleJobs returns the idle jobs of the schedd matching the constraint
+func (s *hpcSchedulerScaler) getHTCondorIdleJobs(ctx context.Context) (float64, error) {
+	constraint := htcondorIdleJobs
+	if s.metadata.Constraint != "" {
+		constraint = fmt.Sprintf("(%s) && (%s)", htcondorIdleJobs, s.metadata.Constraint)
+	}
+	query := neturl.Values{}
+	query.Set("constraint", constraint)
+	query.Set("projection", "clusterid")
+	if s.metadata.Schedd != "" {
+		query.Set("schedd", s.metadata.Schedd)
+	}
+	url := fmt.Sprintf("%s/v1/jobs?%s", s.metadata.URL, query.Encode())
+
+	var jobs []json.RawMessage
+	if err := s.get(ctx, url, &jobs); err != nil {
+		return -1, err
+	}
+	return float64(len(jobs)), nil
+}
+
+// GetMetricValue returns the jobs waiting in the scheduler
+func (s *hpcSchedulerScaler) GetMetricValue(ctx context.Context) (float64, error) {
+	switch s.metadata.Scheduler {
+	case hpcSchedulerSlurm:
+		return s.getSlurmPendingJobs(ctx)
+	case hpcSchedulerHTCondor:
+		return s.getHTCondorIdleJobs(ctx)
+	}
+	return -1, fmt.Errorf("unknown scheduler %s", s.metadata.Scheduler)
+}
+
+func (s *hpcSchedulerScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.GetMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting hpc scheduler metric value")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *hpcSchedulerScaler) GetMetricSpecForScaling(_ context.Context) []v2.MetricSpec {
+	name := s.metadata.Scheduler
+	switch {
+	case s.metadata.Partition != "":
+		name += "-" + s.metadata.Partition
+	case s.metadata.Schedd != "":
+		name += "-" + s.metadata.Schedd
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("hpc-scheduler-%s", name))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *hpcSchedulerScaler) Close(_ context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseHPCSchedulerMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type hpcSchedulerMetricIdentifier struct {
+	metadataTestData *parseHPCSchedulerMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testHPCSchedulerMetadata = []parseHPCSchedulerMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// slurm partition
+	{map[string]string{"scheduler": "slurm", "url": "http://slurmrestd:6820", "partition": "burst", "targetValue": "4"}, map[string]string{"slurmUser": "keda", "slurmToken": "jwt"}, false},
+	// htcondor constraint
+	{map[string]string{"scheduler": "htcondor", "url": "http://condor-restd:9680", "constraint": "RequestGPUs > 0", "schedd": "submit-1", "targetValue": "4"}, map[string]string{}, false},
+	// unknown scheduler
+	{map[string]string{"scheduler": "pbs", "url": "http://pbs:8080", "targetValue": "4"}, map[string]string{}, true},
+	// missing url
+	{map[string]string{"scheduler": "slurm", "targetValue": "4"}, map[string]string{}, true},
+	// slurmToken without slurmUser
+	{map[string]string{"scheduler": "slurm", "url": "http://slurmrestd:6820", "targetValue": "4"}, map[string]string{"slurmToken": "jwt"}, true},
+	// constraint isn't supported by slurm
+	{map[string]string{"scheduler": "slurm", "url": "http://slurmrestd:6820", "constraint": "RequestGPUs > 0", "targetValue": "4"}, map[string]string{}, true},
+	// partition isn't supported by htcondor
+	{map[string]string{"scheduler": "htcondor", "url": "http://condor-restd:9680", "partition": "burst", "targetValue": "4"}, map[string]string{}, true},
+	// invalid targetValue
+	{map[string]string{"scheduler": "slurm", "url": "http://slurmrestd:6820", "targetValue": "0"}, map[string]string{}, true},
+}
+
+var hpcSchedulerMetricIdentifiers = []hpcSchedulerMetricIdentifier{
+	{&testHPCSchedulerMetadata[1], 0, "s0-hpc-scheduler-slurm-burst"},
+	{&testHPCSchedulerMetadata[2], 1, "s1-hpc-scheduler-htcondor-submit-1"},
+}
+
+func TestHPCSchedulerParseMetadata(t *testing.T) {
+	for testCaseNum, testData := range testHPCSchedulerMetadata {
+		_, err := parseHPCSchedulerMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", testCaseNum, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", testCaseNum)
+		}
+	}
+}
+
+func TestHPCSchedulerGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range hpcSchedulerMetricIdentifiers {
+		meta, err := parseHPCSchedulerMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockHPCSchedulerScaler := hpcSchedulerScaler{metadata: meta}
+
+		metricSpec := mockHPCSchedulerScaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+const testSlurmJobs = `{
+  "jobs": [
+    {"job_id": 1, "job_state": ["PENDING"], "partition": "burst", "state_reason": "Resources"},
+    {"job_id": 2, "job_state": ["PENDING"], "partition": "normal,burst", "state_reason": "Priority"},
+    {"job_id": 3, "job_state": ["PENDING"], "partition": "burst", "state_reason": "JobHeldUser"},
+    {"job_id": 4, "job_state": ["RUNNING"], "partition": "burst", "state_reason": "None"},
+    {"job_id": 5, "job_state": ["PENDING"], "partition": "normal", "state_reason": "Resources"}
+  ],
+  "errors": []
+}`
+
+const testSlurmJobsV38 = `{
+  "jobs": [
+    {"job_id": 1, "job_state": "PENDING", "partition": "burst", "state_reason": "Resources"},
+    {"job_id": 2, "job_state": "COMPLETED", "partition": "burst", "state_reason": "None"}
+  ]
+}`
+
+func TestHPCSchedulerGetMetricsAndActivity(t *testing.T) {
+	var apiStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slurm/v0.0.40/jobs":
+			assert.Equal(t, "keda", r.Header.Get("X-SLURM-USER-NAME"))
+			assert.Equal(t, "jwt", r.Header.Get("X-SLURM-USER-TOKEN"))
+			_, _ = w.Write([]byte(testSlurmJobs))
+		case "/slurm/v0.0.38/jobs":
+			_, _ = w.Write([]byte(testSlurmJobsV38))
+		case "/v1/jobs":
+			assert.Equal(t, "(JobStatus == 1) && (RequestGPUs > 0)", r.URL.Query().Get("constraint"))
+			assert.Equal(t, "submit-1", r.URL.Query().Get("schedd"))
+			_, _ = w.Write([]byte(`[{"classad": {"clusterid": 10}, "jobid": "10.0"}, {"classad": {"clusterid": 10}, "jobid": "10.1"}, {"classad": {"clusterid": 11}, "jobid": "11.0"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer apiStub.Close()
+
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		authParams     map[string]string
+		expectedValue  float64
+		expectedActive bool
+		expectedError  string
+	}{
+		{"slurm partition", map[string]string{"scheduler": "slurm", "partition": "burst"}, map[string]string{"slurmUser": "keda", "slurmToken": "jwt"}, 2, false, ""},
+		{"slurm all partitions", map[string]string{"scheduler": "slurm"}, map[string]string{"slurmUser": "keda", "slurmToken": "jwt"}, 3, true, ""},
+		{"slurm v0.0.38", map[string]string{"scheduler": "slurm", "apiVersion": "v0.0.38"}, map[string]string{}, 1, false, ""},
+		{"slurm unknown version", map[string]string{"scheduler": "slurm", "apiVersion": "v0.0.36"}, map[string]string{}, 0, false, "/slurm/v0.0.36/jobs: slurm api returned 404"},
+		{"htcondor", map[string]string{"scheduler": "htcondor", "constraint": "RequestGPUs > 0", "schedd": "submit-1"}, map[string]string{}, 3, true, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["url"] = apiStub.URL
+			tc.metadata["targetValue"] = "4"
+			tc.metadata["activationTargetValue"] = "2"
+			s, err := NewHPCSchedulerScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata:   tc.metadata,
+				AuthParams:        tc.authParams,
+				GlobalHTTPTimeout: 3000 * time.Millisecond,
+			})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.expectedActive, isActive)
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	url_pkg "net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	icebergBacklogSnapshots   = "snapshots"
+	icebergBacklogDataFiles   = "dataFiles"
+	icebergBacklogDeleteFiles = "deleteFiles"
+
+	// icebergReplaceOperation is the operation of the snapshots committed by a compaction, which rewrites
+	// the data files of the table without changing its data
+	icebergReplaceOperation = "replace"
+	// icebergNamespaceSeparator separates the levels of a namespace in the path of the REST catalog
+	icebergNamespaceSeparator = "\x1f"
+)
+
+type icebergScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *icebergMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type icebergMetadata struct {
+	CatalogURI            string  `keda:"name=catalogUri,            order=triggerMetadata;resolvedEnv"`
+	Prefix                string  `keda:"name=prefix,                order=triggerMetadata, optional"`
+	Namespace             string  `keda:"name=namespace,             order=triggerMetadata"`
+	Table                 string  `keda:"name=table,                 order=triggerMetadata"`
+	Branch                string  `keda:"name=branch,                order=triggerMetadata, default=main"`
+	BacklogType           string  `keda:"name=backlogType,           order=triggerMetadata, enum=snapshots;dataFiles;deleteFiles, default=snapshots"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata, default=10"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	// Authentication
+	Token         string   `keda:"name=token,         order=authParams;resolvedEnv, optional"`
+	ClientID      string   `keda:"name=clientId,      order=authParams;resolvedEnv, optional"`
+	ClientSecret  string   `keda:"name=clientSecret,  order=authParams;resolvedEnv, optional"`
+	OauthTokenURI string   `keda:"name=oauthTokenURI, order=triggerMetadata;authParams, optional"`
+	Scopes        []string `keda:"name=scope,         order=triggerMetadata;authParams, optional"`
+
+	// TLS
+	CA          string `keda:"name=ca,          order=authParams, optional"`
+	Cert        string `keda:"name=cert,        order=authParams, optional"`
+	Key         string `keda:"name=key,         order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+	UnsafeSsl   bool   `keda:"name=unsafeSsl,   order=triggerMetadata, default=false"`
+
+	triggerIndex int
+}
+
+// icebergLoadTableResult is the part of the LoadTableResult of the REST catalog read by the scaler
+type icebergLoadTableResult struct {
+	Metadata struct {
+		CurrentSnapshotID *int64            `json:"current-snapshot-id"`
+		Snapshots         []icebergSnapshot `json:"snapshots"`
+		Refs              map[string]struct {
+			SnapshotID int64 `json:"snapshot-id"`
+		} `json:"refs"`
+	} `json:"metadata"`
+}
+
+type icebergSnapshot struct {
+	SnapshotID       int64             `json:"snapshot-id"`
+	ParentSnapshotID *int64            `json:"parent-snapshot-id"`
+	Summary          map[string]string `json:"summary"`
+}
+
+func (m *icebergMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return fmt.Errorf("targetValue must be greater than 0")
+	}
+	if (m.ClientID == "") != (m.ClientSecret == "") {
+		return fmt.Errorf("both clientId and clientSecret must be provided for OAuth")
+	}
+	if m.Token != "" && m.ClientID != "" {
+		return fmt.Errorf("token and clientId can't be used together")
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return fmt.Errorf("both cert and key must be provided when using TLS")
+	}
+	if m.OauthTokenURI == "" {
+		m.OauthTokenURI = fmt.Sprintf("%s/v1/oauth/tokens", strings.TrimSuffix(m.CatalogURI, "/"))
+	}
+	return nil
+}
+
+// NewIcebergScaler creates a new scaler for the compaction backlog of an Iceberg table in a REST catalog
+func NewIcebergScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseIcebergMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Iceberg metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	if meta.CA != "" || meta.Cert != "" {
+		tlsConfig, err := kedautil.NewTLSConfigWithPassword(meta.Cert, meta.Key, meta.KeyPassword, meta.CA, meta.UnsafeSsl)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = kedautil.CreateHTTPTransportWithTLSConfig(tlsConfig)
+	}
+	if meta.ClientID != "" {
+		// the token is requested with the client credentials grant and refreshed by the client when it expires
+		oauthConfig := clientcredentials.Config{
+			ClientID:     meta.ClientID,
+			ClientSecret: meta.ClientSecret,
+			TokenURL:     meta.OauthTokenURI,
+			Scopes:       meta.Scopes,
+		}
+		httpClient = oauthConfig.Client(context.WithValue(context.Background(), oauth2.HTTPClient, httpClient))
+	}
+
+	return &icebergScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "iceberg_scaler"),
+	}, nil
+}
+
+func parseIcebergMetadata(config *scalersconfig.ScalerConfig) (*icebergMetadata, error) {
+	meta := &icebergMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing iceberg metadata: %w", err)
+	}
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// getTableURL returns the url of the table in the REST catalog, the levels of the namespace are separated
+// with dots in the metadata and with the unit separator in the path
+func (s *icebergScaler) getTableURL() string {
+	levels := strings.Split(s.metadata.Namespace, ".")
+	for i, level := range levels {
+		levels[i] = url_pkg.PathEscape(level)
+	}
+	path := []string{strings.TrimSuffix(s.metadata.CatalogURI, "/"), "v1"}
+	if s.metadata.Prefix != "" {
+		path = append(path, strings.Trim(s.metadata.Prefix, "/"))
+	}
+	path = append(path, "namespaces", strings.Join(levels, url_pkg.PathEscape(icebergNamespaceSeparator)), "tables", url_pkg.PathEscape(s.metadata.Table))
+	return strings.Join(path, "/")
+}
+
+func (s *icebergScaler) loadTable(ctx context.Context) (*icebergLoadTableResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.getTableURL(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.metadata.Token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.metadata.Token))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error loading iceberg table: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading iceberg catalog response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("iceberg catalog returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	result := &icebergLoadTableResult{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, fmt.Errorf("error parsing iceberg catalog response: %w", err)
+	}
+	return result, nil
+}
+
+// getBacklog returns the backlog of the table since its last compaction. The snapshots are walked from the
+// head of the branch through their parents until the snapshot of a compaction or the first snapshot still
+// retained in the table.
+func (s *icebergScaler) getBacklog(table *icebergLoadTableResult) (float64, error) {
+	var head *int64
+	if ref, ok := table.Metadata.Refs[s.metadata.Branch]; ok {
+		head = &ref.SnapshotID
+	} else if s.metadata.Branch == "main" {
+		head = table.Metadata.CurrentSnapshotID
+	} else {
+		return -1, fmt.Errorf("branch %s not found in iceberg table %s", s.metadata.Branch, s.metadata.Table)
+	}
+	// the table doesn't have any snapshot yet
+	if head == nil || *head == -1 {
+		return 0, nil
+	}
+
+	snapshots := make(map[int64]*icebergSnapshot, len(table.Metadata.Snapshots))
+	for i := range table.Metadata.Snapshots {
+		snapshots[table.Metadata.Snapshots[i].SnapshotID] = &table.Metadata.Snapshots[i]
+	}
+
+	if s.metadata.BacklogType == icebergBacklogDeleteFiles {
+		snapshot, ok := snapshots[*head]
+		if !ok {
+			return -1, fmt.Errorf("snapshot %d not found in iceberg table %s", *head, s.metadata.Table)
+		}
+		return parseIcebergSummaryValue(snapshot, "total-delete-files")
+	}
+
+	var backlog float64
+	for id := head; id != nil; {
+		snapshot, ok := snapshots[*id]
+		if !ok || snapshot.Summary["operation"] == icebergReplaceOperation {
+			break
+		}
+		switch s.metadata.BacklogType {
+		case icebergBacklogSnapshots:
+			backlog++
+		case icebergBacklogDataFiles:
+			dataFiles, err := parseIcebergSummaryValue(snapshot, "added-data-files")
+			if err != nil {
+				return -1, err
+			}
+			backlog += dataFiles
+		}
+		id = snapshot.ParentSnapshotID
+	}
+	return backlog, nil
+}
+
+// parseIcebergSummaryValue returns a counter of the summary of a snapshot, the counters are optional and
+// missing ones are zero
+func parseIcebergSummaryValue(snapshot *icebergSnapshot, name string) (float64, error) {
+	value, ok := snapshot.Summary[name]
+	if !ok {
+		return 0, nil
+	}
+	num, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return -1, fmt.Errorf("error parsing %s of iceberg snapshot %d: %w", name, snapshot.SnapshotID, err)
+	}
+	return num, nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *icebergScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	metricName := kedautil.NormalizeString(fmt.Sprintf("iceberg-%s-%s-%s", s.metadata.Namespace, s.metadata.Table, s.metadata.BacklogType))
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
+func (s *icebergScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	table, err := s.loadTable(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+	backlog, err := s.getBacklog(table)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, backlog)
+	return []external_metrics.ExternalMetricValue{metric}, backlog > s.metadata.ActivationTargetValue, nil
+}
+
+// Close closes the idle connections of the http client
+func (s *icebergScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
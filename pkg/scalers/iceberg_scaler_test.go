@@ -0,0 +1,163 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseIcebergMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type icebergMetricIdentifier struct {
+	metadataTestData *parseIcebergMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testIcebergMetadata = []parseIcebergMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed metadata
+	{map[string]string{"catalogUri": "http://iceberg-rest:8181", "namespace": "analytics", "table": "events"}, map[string]string{}, false},
+	// nested namespace with dataFiles
+	{map[string]string{"catalogUri": "http://iceberg-rest:8181", "namespace": "analytics.raw", "table": "events", "backlogType": "dataFiles", "targetValue": "100"}, map[string]string{"token": "secret"}, false},
+	// no catalogUri
+	{map[string]string{"namespace": "analytics", "table": "events"}, map[string]string{}, true},
+	// no table
+	{map[string]string{"catalogUri": "http://iceberg-rest:8181", "namespace": "analytics"}, map[string]string{}, true},
+	// invalid backlogType
+	{map[string]string{"catalogUri": "http://iceberg-rest:8181", "namespace": "analytics", "table": "events", "backlogType": "manifests"}, map[string]string{}, true},
+	// invalid targetValue
+	{map[string]string{"catalogUri": "http://iceberg-rest:8181", "namespace": "analytics", "table": "events", "targetValue": "0"}, map[string]string{}, true},
+	// OAuth
+	{map[string]string{"catalogUri": "http://iceberg-rest:8181", "namespace": "analytics", "table": "events", "scope": "catalog"}, map[string]string{"clientId": "keda", "clientSecret": "secret"}, false},
+	// clientId without clientSecret
+	{map[string]string{"catalogUri": "http://iceberg-rest:8181", "namespace": "analytics", "table": "events"}, map[string]string{"clientId": "keda"}, true},
+	// token and clientId
+	{map[string]string{"catalogUri": "http://iceberg-rest:8181", "namespace": "analytics", "table": "events"}, map[string]string{"token": "secret", "clientId": "keda", "clientSecret": "secret"}, true},
+}
+
+var icebergMetricIdentifiers = []icebergMetricIdentifier{
+	{&testIcebergMetadata[1], 0, "s0-iceberg-analytics-events-snapshots"},
+	{&testIcebergMetadata[2], 1, "s1-iceberg-analytics-raw-events-dataFiles"},
+}
+
+func TestIcebergParseMetadata(t *testing.T) {
+	for testCaseNum, testData := range testIcebergMetadata {
+		_, err := parseIcebergMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", testCaseNum, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", testCaseNum)
+		}
+	}
+
+	meta, err := parseIcebergMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testIcebergMetadata[7].metadata, AuthParams: testIcebergMetadata[7].authParams})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://iceberg-rest:8181/v1/oauth/tokens", meta.OauthTokenURI)
+	assert.Equal(t, "main", meta.Branch)
+}
+
+func TestIcebergGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range icebergMetricIdentifiers {
+		meta, err := parseIcebergMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockIcebergScaler := icebergScaler{metadata: meta}
+
+		metricSpec := mockIcebergScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Errorf("Wrong External metric source name: %s, expected: %s", metricName, testData.name)
+		}
+	}
+}
+
+// testIcebergTable has two appends on main after a compaction and an audit branch
+const testIcebergTable = `{
+	"metadata-location": "s3://warehouse/analytics/events/metadata/00005.metadata.json",
+	"metadata": {
+		"format-version": 2,
+		"current-snapshot-id": 5,
+		"refs": {"main": {"snapshot-id": 5, "type": "branch"}, "audit": {"snapshot-id": 6, "type": "branch"}},
+		"snapshots": [
+			{"snapshot-id": 2, "parent-snapshot-id": 1, "summary": {"operation": "append", "added-data-files": "40"}},
+			{"snapshot-id": 3, "parent-snapshot-id": 2, "summary": {"operation": "replace", "added-data-files": "2", "deleted-data-files": "40"}},
+			{"snapshot-id": 4, "parent-snapshot-id": 3, "summary": {"operation": "append", "added-data-files": "7"}},
+			{"snapshot-id": 5, "parent-snapshot-id": 4, "summary": {"operation": "overwrite", "added-data-files": "5", "total-delete-files": "3"}},
+			{"snapshot-id": 6, "parent-snapshot-id": 2, "summary": {"operation": "append", "added-data-files": "1"}}
+		]
+	}
+}`
+
+func TestIcebergGetMetricsAndActivity(t *testing.T) {
+	var r *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r = req
+		if req.URL.EscapedPath() != "/v1/warehouse/namespaces/analytics%1Fraw/tables/events" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"message": "Table does not exist", "type": "NoSuchTableException", "code": 404}}`))
+			return
+		}
+		_, _ = w.Write([]byte(testIcebergTable))
+	}))
+	defer server.Close()
+
+	meta, err := parseIcebergMetadata(&scalersconfig.ScalerConfig{
+		TriggerMetadata: map[string]string{"catalogUri": server.URL, "prefix": "warehouse", "namespace": "analytics.raw", "table": "events", "activationTargetValue": "2"},
+		AuthParams:      map[string]string{"token": "secret"},
+	})
+	assert.NoError(t, err)
+	s := &icebergScaler{metadata: meta, httpClient: http.DefaultClient}
+
+	tests := []struct {
+		backlogType string
+		branch      string
+		value       int64
+		isActive    bool
+	}{
+		// the snapshots since the compaction
+		{icebergBacklogSnapshots, "main", 2, false},
+		// the data files added since the compaction
+		{icebergBacklogDataFiles, "main", 12, true},
+		// the delete files of the head of the branch
+		{icebergBacklogDeleteFiles, "main", 3, true},
+		// the snapshots retained without a compaction
+		{icebergBacklogSnapshots, "audit", 2, false},
+		{icebergBacklogDataFiles, "audit", 41, true},
+	}
+	for _, test := range tests {
+		s.metadata.BacklogType, s.metadata.Branch = test.backlogType, test.branch
+		metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "s0-iceberg-analytics-raw-events")
+		assert.NoError(t, err)
+		assert.Equal(t, test.value, metrics[0].Value.Value(), "%s on %s", test.backlogType, test.branch)
+		assert.Equal(t, test.isActive, isActive, "%s on %s", test.backlogType, test.branch)
+	}
+	assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+	s.metadata.Branch = "staging"
+	_, _, err = s.GetMetricsAndActivity(context.Background(), "s0-iceberg-analytics-raw-events")
+	assert.ErrorContains(t, err, "branch staging not found")
+
+	s.metadata.Branch, s.metadata.Table = "main", "sessions"
+	_, _, err = s.GetMetricsAndActivity(context.Background(), "s0-iceberg-analytics-raw-sessions")
+	assert.ErrorContains(t, err, "NoSuchTableException")
+}
+
+func TestIcebergGetBacklogWithoutSnapshots(t *testing.T) {
+	s := &icebergScaler{metadata: &icebergMetadata{Table: "events", Branch: "main", BacklogType: icebergBacklogSnapshots}}
+	backlog, err := s.getBacklog(&icebergLoadTableResult{})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), backlog)
+}
@@ -1,9 +1,14 @@
 package scalers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
@@ -15,16 +20,33 @@ import (
 	"github.com/kedacore/keda/v2/pkg/util"
 )
 
+const (
+	influxDBQueryLanguageFlux     = "flux"
+	influxDBQueryLanguageSQL      = "sql"
+	influxDBQueryLanguageInfluxQL = "influxql"
+)
+
+// influxDBReservedColumns are returned by InfluxDB 3 next to the queried values
+var influxDBReservedColumns = map[string]bool{
+	"time":             true,
+	"iox::measurement": true,
+}
+
 type influxDBScaler struct {
 	client     influxdb2.Client
 	metricType v2.MetricTargetType
 	metadata   *influxDBMetadata
 	logger     logr.Logger
+	// httpClient queries InfluxDB 3 through its HTTP query API
+	httpClient *http.Client
 }
 
 type influxDBMetadata struct {
 	authToken                string
 	organizationName         string
+	bucket                   string
+	queryLanguage            string
+	valueColumn              string
 	query                    string
 	serverURL                string
 	unsafeSsl                bool
@@ -47,6 +69,15 @@ func NewInfluxDBScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing influxdb metadata: %w", err)
 	}
 
+	if meta.queryLanguage != influxDBQueryLanguageFlux {
+		return &influxDBScaler{
+			metricType: metricType,
+			metadata:   meta,
+			logger:     logger,
+			httpClient: util.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl),
+		}, nil
+	}
+
 	logger.Info("starting up influxdb client")
 	client := influxdb2.NewClientWithOptions(
 		meta.serverURL,
@@ -61,10 +92,27 @@ func NewInfluxDBScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	}, nil
 }
 
+// getInfluxDBParameter reads a parameter from the trigger metadata, the resolved environment or the auth params
+func getInfluxDBParameter(config *scalersconfig.ScalerConfig, name, description string) (string, bool, error) {
+	val, ok := config.TriggerMetadata[name]
+	switch {
+	case ok && val != "":
+		return val, true, nil
+	case config.TriggerMetadata[name+"FromEnv"] != "":
+		if val, ok := config.ResolvedEnv[config.TriggerMetadata[name+"FromEnv"]]; ok {
+			return val, true, nil
+		}
+		return "", false, fmt.Errorf("no %s given", description)
+	case config.AuthParams[name] != "":
+		return config.AuthParams[name], true, nil
+	default:
+		return "", false, nil
+	}
+}
+
 // parseInfluxDBMetadata parses the metadata passed in from the ScaledObject config
 func parseInfluxDBMetadata(config *scalersconfig.ScalerConfig) (*influxDBMetadata, error) {
 	var authToken string
-	var organizationName string
 	var query string
 	var serverURL string
 	var unsafeSsl bool
@@ -87,22 +135,33 @@ func parseInfluxDBMetadata(config *scalersconfig.ScalerConfig) (*influxDBMetadat
 		return nil, fmt.Errorf("no auth token given")
 	}
 
-	val, ok = config.TriggerMetadata["organizationName"]
-	switch {
-	case ok && val != "":
-		organizationName = val
-	case config.TriggerMetadata["organizationNameFromEnv"] != "":
-		if val, ok := config.ResolvedEnv[config.TriggerMetadata["organizationNameFromEnv"]]; ok {
-			organizationName = val
-		} else {
-			return nil, fmt.Errorf("no organization name given")
+	queryLanguage := influxDBQueryLanguageFlux
+	if val, ok := config.TriggerMetadata["queryLanguage"]; ok && val != "" {
+		queryLanguage = strings.ToLower(val)
+		switch queryLanguage {
+		case influxDBQueryLanguageFlux, influxDBQueryLanguageSQL, influxDBQueryLanguageInfluxQL:
+		default:
+			return nil, fmt.Errorf("queryLanguage must be one of %s, %s or %s", influxDBQueryLanguageFlux, influxDBQueryLanguageSQL, influxDBQueryLanguageInfluxQL)
 		}
-	case config.AuthParams["organizationName"] != "":
-		organizationName = config.AuthParams["organizationName"]
-	default:
+	}
+
+	organizationName, found, err := getInfluxDBParameter(config, "organizationName", "organization name")
+	if err != nil {
+		return nil, err
+	}
+	// Flux queries are scoped to an organization, SQL and InfluxQL to a bucket (database)
+	if !found && queryLanguage == influxDBQueryLanguageFlux {
 		return nil, fmt.Errorf("no organization name given")
 	}
 
+	bucket, found, err := getInfluxDBParameter(config, "bucket", "bucket")
+	if err != nil {
+		return nil, err
+	}
+	if !found && queryLanguage != influxDBQueryLanguageFlux {
+		return nil, fmt.Errorf("no bucket given")
+	}
+
 	if val, ok := config.TriggerMetadata["query"]; ok {
 		query = val
 	} else {
@@ -150,6 +209,9 @@ func parseInfluxDBMetadata(config *scalersconfig.ScalerConfig) (*influxDBMetadat
 	return &influxDBMetadata{
 		authToken:                authToken,
 		organizationName:         organizationName,
+		bucket:                   bucket,
+		queryLanguage:            queryLanguage,
+		valueColumn:              config.TriggerMetadata["valueColumn"],
 		query:                    query,
 		serverURL:                serverURL,
 		thresholdValue:           thresholdValue,
@@ -161,7 +223,12 @@ func parseInfluxDBMetadata(config *scalersconfig.ScalerConfig) (*influxDBMetadat
 
 // Close closes the connection of the client to the server
 func (s *influxDBScaler) Close(context.Context) error {
-	s.client.Close()
+	if s.client != nil {
+		s.client.Close()
+	}
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
 	return nil
 }
 
@@ -189,12 +256,98 @@ func queryInfluxDB(ctx context.Context, queryAPI api.QueryAPI, query string) (fl
 	}
 }
 
+// queryInfluxDBV3 runs a SQL or InfluxQL query through the InfluxDB 3 HTTP query API
+// and returns the value column of the first row
+func (s *influxDBScaler) queryInfluxDBV3(ctx context.Context) (float64, error) {
+	endpoint := "query_sql"
+	if s.metadata.queryLanguage == influxDBQueryLanguageInfluxQL {
+		endpoint = "query_influxql"
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"db":     s.metadata.bucket,
+		"q":      s.metadata.query,
+		"format": "json",
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	url := fmt.Sprintf("%s/api/v3/%s", strings.TrimSuffix(s.metadata.serverURL, "/"), endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.metadata.authToken))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("influxdb query failed with status %d: %s", resp.StatusCode, string(b))
+	}
+
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(b, &rows); err != nil {
+		return 0, fmt.Errorf("error decoding influxdb query result: %w", err)
+	}
+	return getInfluxDBRowValue(rows, s.metadata.valueColumn)
+}
+
+// getInfluxDBRowValue returns the value column of the first row, when no column
+// is set the row must hold a single column besides time and measurement
+func getInfluxDBRowValue(rows []map[string]interface{}, column string) (float64, error) {
+	if len(rows) == 0 {
+		return 0, fmt.Errorf("no results found from query")
+	}
+
+	var value interface{}
+	if column != "" {
+		v, ok := rows[0][column]
+		if !ok {
+			return 0, fmt.Errorf("column %s not found in query result", column)
+		}
+		value = v
+	} else {
+		found := 0
+		for k, v := range rows[0] {
+			if influxDBReservedColumns[k] {
+				continue
+			}
+			value = v
+			found++
+		}
+		if found != 1 {
+			return 0, fmt.Errorf("query returned %d value columns, set valueColumn to select one", found)
+		}
+	}
+
+	switch valRaw := value.(type) {
+	case float64:
+		return valRaw, nil
+	default:
+		return 0, fmt.Errorf("value of type %T could not be converted into a float", valRaw)
+	}
+}
+
 // GetMetricsAndActivity connects to influxdb via the client and returns a value based on the query
 func (s *influxDBScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	// Grab QueryAPI to make queries to influxdb instance
-	queryAPI := s.client.QueryAPI(s.metadata.organizationName)
-
-	value, err := queryInfluxDB(ctx, queryAPI, s.metadata.query)
+	var value float64
+	var err error
+	if s.metadata.queryLanguage == influxDBQueryLanguageFlux {
+		// Grab QueryAPI to make queries to influxdb instance
+		queryAPI := s.client.QueryAPI(s.metadata.organizationName)
+		value, err = queryInfluxDB(ctx, queryAPI, s.metadata.query)
+	} else {
+		value, err = s.queryInfluxDBV3(ctx)
+	}
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
@@ -208,7 +361,7 @@ func (s *influxDBScaler) GetMetricsAndActivity(ctx context.Context, metricName s
 func (s *influxDBScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, util.NormalizeString(fmt.Sprintf("influxdb-%s", s.metadata.organizationName))),
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, util.NormalizeString(fmt.Sprintf("influxdb-%s", s.influxDBMetricScope()))),
 		},
 		Target: GetMetricTargetMili(s.metricType, s.metadata.thresholdValue),
 	}
@@ -217,3 +370,11 @@ func (s *influxDBScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpe
 	}
 	return []v2.MetricSpec{metricSpec}
 }
+
+// influxDBMetricScope returns the organization for Flux queries and the bucket otherwise
+func (s *influxDBScaler) influxDBMetricScope() string {
+	if s.metadata.queryLanguage == influxDBQueryLanguageFlux || s.metadata.queryLanguage == "" {
+		return s.metadata.organizationName
+	}
+	return s.metadata.bucket
+}
@@ -2,6 +2,9 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -50,11 +53,22 @@ var testInfluxDBMetadata = []parseInfluxDBMetadataTestData{
 	{map[string]string{"serverURL": "https://influxdata.com", "metricName": "influx_metric", "organizationName": "influx_org", "query": "from(bucket: hello)", "thresholdValue": "10", "authToken": "myToken"}, false, map[string]string{}},
 	// 11 wrong activationThreshold valuequeryInfluxDB
 	{map[string]string{"serverURL": "https://influxdata.com", "metricName": "influx_metric", "organizationName": "influx_org", "query": "from(bucket: hello)", "thresholdValue": "10", "activationThresholdValue": "aa", "authToken": "myToken", "unsafeSsl": "false"}, true, map[string]string{}},
+	// 12 SQL query with bucket and no organization
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "sql", "bucket": "hello", "query": "SELECT count(*) FROM jobs", "thresholdValue": "10", "authToken": "myToken"}, false, map[string]string{}},
+	// 13 InfluxQL query with bucket in authParams
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "InfluxQL", "query": "SELECT COUNT(value) FROM jobs", "thresholdValue": "10"}, false, map[string]string{"bucket": "hello", "authToken": "myToken"}},
+	// 14 SQL query without bucket
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "sql", "organizationName": "influx_org", "query": "SELECT count(*) FROM jobs", "thresholdValue": "10", "authToken": "myToken"}, true, map[string]string{}},
+	// 15 invalid query language
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "promql", "bucket": "hello", "query": "up", "thresholdValue": "10", "authToken": "myToken"}, true, map[string]string{}},
+	// 16 bucket from missing env
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "sql", "bucketFromEnv": "MISSING", "query": "SELECT count(*) FROM jobs", "thresholdValue": "10", "authToken": "myToken"}, true, map[string]string{}},
 }
 
 var influxDBMetricIdentifiers = []influxDBMetricIdentifier{
 	{&testInfluxDBMetadata[1], 0, "s0-influxdb-influx_org"},
 	{&testInfluxDBMetadata[2], 1, "s1-influxdb-influx_org"},
+	{&testInfluxDBMetadata[11], 2, "s2-influxdb-hello"},
 }
 
 func TestInfluxDBParseMetadata(t *testing.T) {
@@ -77,7 +91,7 @@ func TestInfluxDBGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockInfluxDBScaler := influxDBScaler{influxdb2.NewClient("https://influxdata.com", "myToken"), "", meta, logr.Discard()}
+		mockInfluxDBScaler := influxDBScaler{influxdb2.NewClient("https://influxdata.com", "myToken"), "", meta, logr.Discard(), nil}
 
 		metricSpec := mockInfluxDBScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -86,3 +100,63 @@ func TestInfluxDBGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestInfluxDBQueryV3(t *testing.T) {
+	testCases := []struct {
+		name          string
+		queryLanguage string
+		valueColumn   string
+		response      string
+		statusCode    int
+		expected      float64
+		isError       bool
+	}{
+		{"sql single column", influxDBQueryLanguageSQL, "", `[{"count(*)": 12}]`, http.StatusOK, 12, false},
+		{"influxql with time and measurement", influxDBQueryLanguageInfluxQL, "", `[{"iox::measurement": "jobs", "time": "1970-01-01T00:00:00", "count": 4}]`, http.StatusOK, 4, false},
+		{"selected value column", influxDBQueryLanguageSQL, "pending", `[{"running": 1, "pending": 7}]`, http.StatusOK, 7, false},
+		{"several columns without selection", influxDBQueryLanguageSQL, "", `[{"running": 1, "pending": 7}]`, http.StatusOK, 0, true},
+		{"no rows", influxDBQueryLanguageSQL, "", `[]`, http.StatusOK, 0, true},
+		{"non numeric value", influxDBQueryLanguageSQL, "", `[{"host": "a"}]`, http.StatusOK, 0, true},
+		{"error status", influxDBQueryLanguageSQL, "", `{"error": "database not found"}`, http.StatusNotFound, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]string
+				expectedPath := "/api/v3/query_" + tc.queryLanguage
+				if r.URL.Path != expectedPath || r.Header.Get("Authorization") != "Bearer myToken" || json.NewDecoder(r.Body).Decode(&body) != nil || body["db"] != "hello" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			s := influxDBScaler{
+				metadata: &influxDBMetadata{
+					serverURL:     server.URL,
+					authToken:     "myToken",
+					bucket:        "hello",
+					queryLanguage: tc.queryLanguage,
+					valueColumn:   tc.valueColumn,
+					query:         "SELECT 1",
+				},
+				logger:     logr.Discard(),
+				httpClient: server.Client(),
+			}
+
+			value, err := s.queryInfluxDBV3(context.Background())
+			if tc.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+			if !tc.isError && err != nil {
+				t.Errorf("Expected success but got error: %v", err)
+			}
+			if !tc.isError && value != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, value)
+			}
+		})
+	}
+}
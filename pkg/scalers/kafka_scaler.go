@@ -24,9 +24,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/go-logr/logr"
@@ -46,8 +48,21 @@ type kafkaScaler struct {
 	admin           sarama.ClusterAdmin
 	logger          logr.Logger
 	previousOffsets map[string]map[int32]int64
+	// sharedClients is the reference on the client and the admin shared with the scalers of the same brokers
+	sharedClients *sharedClientRef
 }
 
+// kafkaClients are a client and its admin, shared by the scalers connecting to the same brokers
+type kafkaClients struct {
+	client sarama.Client
+	admin  sarama.ClusterAdmin
+}
+
+var sharedKafkaClients = newSharedClients(func(clients kafkaClients) error {
+	// underlying client will also be closed on admin's Close() call
+	return clients.admin.Close()
+})
+
 const (
 	stringEnable     = "enable"
 	stringDisable    = "disable"
@@ -66,6 +81,10 @@ type kafkaMetadata struct {
 	excludePersistentLag   bool
 	version                sarama.KafkaVersion
 
+	// Whether the lag is measured as the time since the next message to consume was produced, the lag
+	// thresholds are then in seconds, so scaling tracks the latency whatever the size of the messages
+	lagInSeconds bool
+
 	// If an invalid offset is found, whether to scale to 1 (false - the default) so consumption can
 	// occur or scale to 0 (true). See discussion in https://github.com/kedacore/keda/issues/2612
 	scaleToZeroOnInvalidOffset bool
@@ -133,11 +152,16 @@ const (
 const (
 	lagThresholdMetricName             = "lagThreshold"
 	activationLagThresholdMetricName   = "activationLagThreshold"
+	lagThresholdSecondsMetricName      = "lagThresholdSeconds"
+	activationLagThresholdSecondsName  = "activationLagThresholdSeconds"
 	kafkaMetricType                    = "External"
 	defaultKafkaLagThreshold           = 10
 	defaultKafkaActivationLagThreshold = 0
 	defaultOffsetResetPolicy           = latest
 	invalidOffset                      = -1
+	// kafkaTimeLagFetchMaxBytes is the size fetched to read the timestamp of the next message of a
+	// partition, the brokers still return the first batch when it's larger
+	kafkaTimeLagFetchMaxBytes = 1024 * 1024
 )
 
 // NewKafkaScaler creates a new kafkaScaler
@@ -154,7 +178,10 @@ func NewKafkaScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Sc
 		return nil, fmt.Errorf("error parsing kafka metadata: %w", err)
 	}
 
-	client, admin, err := getKafkaClients(ctx, kafkaMetadata)
+	clients, sharedClients, err := sharedKafkaClients.acquire(getKafkaClientsKey(kafkaMetadata), func() (kafkaClients, error) {
+		client, admin, err := getKafkaClients(ctx, kafkaMetadata)
+		return kafkaClients{client: client, admin: admin}, err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -162,12 +189,13 @@ func NewKafkaScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Sc
 	previousOffsets := make(map[string]map[int32]int64)
 
 	return &kafkaScaler{
-		client:          client,
-		admin:           admin,
+		client:          clients.client,
+		admin:           clients.admin,
 		metricType:      metricType,
 		metadata:        kafkaMetadata,
 		logger:          logger,
 		previousOffsets: previousOffsets,
+		sharedClients:   sharedClients,
 	}, nil
 }
 
@@ -538,6 +566,38 @@ func parseKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger)
 		meta.activationLagThreshold = t
 	}
 
+	if val, ok := config.TriggerMetadata[lagThresholdSecondsMetricName]; ok {
+		if _, ok := config.TriggerMetadata[lagThresholdMetricName]; ok {
+			return meta, fmt.Errorf("%q and %q cannot be set simultaneously", lagThresholdMetricName, lagThresholdSecondsMetricName)
+		}
+		if _, ok := config.TriggerMetadata[activationLagThresholdMetricName]; ok {
+			return meta, fmt.Errorf("%q cannot be set with %q, use %q", activationLagThresholdMetricName, lagThresholdSecondsMetricName, activationLagThresholdSecondsName)
+		}
+		t, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing %q: %w", lagThresholdSecondsMetricName, err)
+		}
+		if t <= 0 {
+			return meta, fmt.Errorf("%q must be positive number", lagThresholdSecondsMetricName)
+		}
+		meta.lagThreshold = t
+		meta.lagInSeconds = true
+	}
+
+	if val, ok := config.TriggerMetadata[activationLagThresholdSecondsName]; ok {
+		if !meta.lagInSeconds {
+			return meta, fmt.Errorf("%q can only be set with %q", activationLagThresholdSecondsName, lagThresholdSecondsMetricName)
+		}
+		t, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing %q: %w", activationLagThresholdSecondsName, err)
+		}
+		if t < 0 {
+			return meta, fmt.Errorf("%q must be positive number", activationLagThresholdSecondsName)
+		}
+		meta.activationLagThreshold = t
+	}
+
 	if err := parseKafkaAuthParams(config, &meta); err != nil {
 		return meta, err
 	}
@@ -594,6 +654,10 @@ func parseKafkaMetadata(config *scalersconfig.ScalerConfig, logger logr.Logger)
 		}
 		meta.version = version
 	}
+	// the messages have a timestamp since the message format v1 of Kafka 0.10.0
+	if meta.lagInSeconds && !meta.version.IsAtLeast(sarama.V0_10_0_0) {
+		return meta, fmt.Errorf("%q requires kafka version 0.10.0 or later", lagThresholdSecondsMetricName)
+	}
 	meta.triggerIndex = config.TriggerIndex
 	return meta, nil
 }
@@ -620,6 +684,38 @@ func getKafkaClients(ctx context.Context, metadata kafkaMetadata) (sarama.Client
 	return client, admin, nil
 }
 
+// getKafkaClientsKey returns the key of the clients shared, all the settings of the clients are part of it
+func getKafkaClientsKey(metadata kafkaMetadata) string {
+	oauthExtensions := make([]string, 0, len(metadata.oauthExtensions))
+	for key, value := range metadata.oauthExtensions {
+		oauthExtensions = append(oauthExtensions, key+"="+value)
+	}
+	sort.Strings(oauthExtensions)
+	return sharedClientKey(
+		strings.Join(metadata.bootstrapServers, ","),
+		metadata.version.String(),
+		string(metadata.saslType),
+		metadata.username,
+		metadata.password,
+		metadata.keytabPath,
+		metadata.realm,
+		metadata.kerberosConfigPath,
+		metadata.kerberosServiceName,
+		string(metadata.tokenProvider),
+		strings.Join(metadata.scopes, ","),
+		metadata.oauthTokenEndpointURI,
+		strings.Join(oauthExtensions, ","),
+		metadata.awsRegion,
+		fmt.Sprintf("%+v", metadata.awsAuthorization),
+		strconv.FormatBool(metadata.enableTLS),
+		metadata.cert,
+		metadata.key,
+		metadata.keyPassword,
+		metadata.ca,
+		strconv.FormatBool(metadata.unsafeSsl),
+	)
+}
+
 func getKafkaClientConfig(ctx context.Context, metadata kafkaMetadata) (*sarama.Config, error) {
 	config := sarama.NewConfig()
 	config.Version = metadata.version
@@ -836,25 +932,24 @@ func (s *kafkaScaler) getLagForPartition(topic string, partitionID int32, offset
 	return latestOffset - consumerOffset, latestOffset - consumerOffset, nil
 }
 
+// IsStateful returns whether the persistent lag is detected from the offsets of the previous evaluation
+func (s *kafkaScaler) IsStateful() bool {
+	return s.metadata.excludePersistentLag
+}
+
 // Close closes the kafka admin and client
 func (s *kafkaScaler) Close(context.Context) error {
+	// the clients are released before their temporary files are removed
+	err := s.sharedClients.release()
+
 	// clean up any temporary files
 	if strings.TrimSpace(s.metadata.kerberosConfigPath) != "" {
-		if err := os.Remove(s.metadata.kerberosConfigPath); err != nil {
-			return err
-		}
+		err = errors.Join(err, os.Remove(s.metadata.kerberosConfigPath))
 	}
 	if strings.TrimSpace(s.metadata.keytabPath) != "" {
-		if err := os.Remove(s.metadata.keytabPath); err != nil {
-			return err
-		}
+		err = errors.Join(err, os.Remove(s.metadata.keytabPath))
 	}
-	// underlying client will also be closed on admin's Close() call
-	if s.admin == nil {
-		return nil
-	}
-
-	return s.admin.Close()
+	return err
 }
 
 func (s *kafkaScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
@@ -915,6 +1010,7 @@ func (s *kafkaScaler) getConsumerAndProducerOffsets(topicPartitions map[string][
 func (s *kafkaScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
 	totalLag, totalLagWithPersistent, err := s.getTotalLag()
 	if err != nil {
+		s.sharedClients.invalidate()
 		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 	metric := GenerateMetricInMili(metricName, float64(totalLag))
@@ -940,6 +1036,7 @@ func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
 	totalLagWithPersistent := int64(0)
 	totalTopicPartitions := int64(0)
 	partitionsWithLag := int64(0)
+	var laggingPartitions []kafkaLaggingPartition
 
 	for topic, partitionsOffsets := range producerOffsets {
 		for partition := range partitionsOffsets {
@@ -953,9 +1050,24 @@ func (s *kafkaScaler) getTotalLag() (int64, int64, error) {
 			if lag > 0 {
 				partitionsWithLag++
 			}
+			if s.metadata.lagInSeconds && lagWithPersistent > 0 {
+				laggingPartitions = append(laggingPartitions, kafkaLaggingPartition{
+					topic:      topic,
+					partition:  partition,
+					offset:     consumerOffsets.GetBlock(topic, partition).Offset,
+					persistent: lag == 0,
+				})
+			}
 		}
 		totalTopicPartitions += (int64)(len(partitionsOffsets))
 	}
+
+	if s.metadata.lagInSeconds {
+		totalLag, totalLagWithPersistent, err = s.getTotalTimeLag(laggingPartitions, time.Now())
+		if err != nil {
+			return 0, 0, err
+		}
+	}
 	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, topicPartitions %v, threshold %v", totalLag, len(topicPartitions), s.metadata.lagThreshold))
 
 	if !s.metadata.allowIdleConsumers || s.metadata.limitToPartitionsWithLag {
@@ -1037,3 +1149,137 @@ func (s *kafkaScaler) getProducerOffsets(topicPartitions map[string][]int32) (ma
 
 	return topicPartitionsOffsets, nil
 }
+
+// kafkaLaggingPartition is a partition with messages left to consume, from the offset committed by the group
+type kafkaLaggingPartition struct {
+	topic      string
+	partition  int32
+	offset     int64
+	persistent bool
+}
+
+type brokerFetchResult struct {
+	fetchResp *sarama.FetchResponse
+	err       error
+}
+
+// getTotalTimeLag returns the sums of the time lags of the partitions, in seconds, without and with the
+// partitions deemed as having persistent lag. The time lag of a partition is the time since the next
+// message to consume was produced, the partitions whose next message can't be read, like the ones
+// without a committed offset, lag by the threshold so they still require a consumer.
+func (s *kafkaScaler) getTotalTimeLag(partitions []kafkaLaggingPartition, now time.Time) (int64, int64, error) {
+	version := kafkaFetchRequestVersion(s.client.Config().Version)
+
+	// Step 1: build one FetchRequest instance per broker, for the partitions with a committed offset
+	requests := make(map[*sarama.Broker]*sarama.FetchRequest)
+	for _, p := range partitions {
+		if p.offset == invalidOffset {
+			continue
+		}
+		broker, err := s.client.Leader(p.topic, p.partition)
+		if err != nil {
+			return 0, 0, err
+		}
+		request, ok := requests[broker]
+		if !ok {
+			request = &sarama.FetchRequest{Version: version, MinBytes: 1, MaxBytes: sarama.MaxResponseSize}
+			requests[broker] = request
+		}
+		request.AddBlock(p.topic, p.partition, p.offset, kafkaTimeLagFetchMaxBytes, -1)
+	}
+
+	// Step 2: send requests, one per broker, and collect the responses
+	resultCh := make(chan brokerFetchResult, len(requests))
+	var wg sync.WaitGroup
+	wg.Add(len(requests))
+	for broker, request := range requests {
+		go func(brCopy *sarama.Broker, reqCopy *sarama.FetchRequest) {
+			defer wg.Done()
+			response, err := brCopy.Fetch(reqCopy)
+			resultCh <- brokerFetchResult{response, err}
+		}(broker, request)
+	}
+
+	wg.Wait()
+	close(resultCh)
+
+	var responses []*sarama.FetchResponse
+	for brokerFetchRes := range resultCh {
+		if brokerFetchRes.err != nil {
+			return 0, 0, brokerFetchRes.err
+		}
+		responses = append(responses, brokerFetchRes.fetchResp)
+	}
+
+	// Step 3: sum the time lags of the partitions from the timestamps of their next messages
+	totalLag := int64(0)
+	totalLagWithPersistent := int64(0)
+	for _, p := range partitions {
+		timeLag := s.metadata.lagThreshold
+		if p.offset != invalidOffset {
+			if timestamp, found := getFetchedMessageTimestamp(responses, p.topic, p.partition, p.offset); found {
+				timeLag = max(int64(now.Sub(timestamp)/time.Second), 0)
+			} else {
+				s.logger.V(1).Info(fmt.Sprintf("no message found at offset %d of topic %s and partition %d, returning with a time lag of %d seconds",
+					p.offset, p.topic, p.partition, timeLag))
+			}
+		}
+		totalLagWithPersistent += timeLag
+		if !p.persistent {
+			totalLag += timeLag
+		}
+	}
+	return totalLag, totalLagWithPersistent, nil
+}
+
+// kafkaFetchRequestVersion returns the version of the fetch requests supported by the kafka version,
+// the fetch sessions added in version 7 aren't needed to read a single message
+func kafkaFetchRequestVersion(version sarama.KafkaVersion) int16 {
+	switch {
+	case version.IsAtLeast(sarama.V1_0_0_0):
+		return 6
+	case version.IsAtLeast(sarama.V0_11_0_0):
+		return 5
+	case version.IsAtLeast(sarama.V0_10_1_0):
+		return 3
+	default:
+		return 2
+	}
+}
+
+// getFetchedMessageTimestamp returns the timestamp of the first message from the offset fetched for the partition
+func getFetchedMessageTimestamp(responses []*sarama.FetchResponse, topic string, partition int32, offset int64) (time.Time, bool) {
+	for _, response := range responses {
+		block := response.GetBlock(topic, partition)
+		if block == nil || block.Err != sarama.ErrNoError {
+			continue
+		}
+		for _, records := range block.RecordsSet {
+			if batch := records.RecordBatch; batch != nil {
+				if batch.Control {
+					continue
+				}
+				for _, record := range batch.Records {
+					if batch.FirstOffset+record.OffsetDelta < offset {
+						continue
+					}
+					if batch.LogAppendTime {
+						return batch.MaxTimestamp, true
+					}
+					return batch.FirstTimestamp.Add(record.TimestampDelta), true
+				}
+			}
+			if records.MsgSet != nil {
+				for _, message := range records.MsgSet.Messages {
+					// a compressed message wraps the messages of its set
+					for _, msg := range message.Messages() {
+						if msg.Offset >= offset {
+							return msg.Msg.Timestamp, true
+						}
+					}
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -8,9 +8,11 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
 
 	kafka_oauth "github.com/kedacore/keda/v2/pkg/scalers/kafka"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -138,6 +140,18 @@ var parseKafkaMetadataTestDataset = []parseKafkaMetadataTestData{
 	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "allowIdleConsumers": "true", "limitToPartitionsWithLag": "false"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), true, false, false},
 	// failure, topic must be specified when limitToPartitionsWithLag is true
 	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "limitToPartitionsWithLag": "true"}, true, 1, []string{"foobar:9092"}, "my-group", "", nil, offsetResetPolicy("latest"), false, false, true},
+	// success, lagThresholdSeconds
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagThresholdSeconds": "30", "activationLagThresholdSeconds": "5"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, lagThresholdSeconds is 0
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagThresholdSeconds": "0"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, lagThreshold and lagThresholdSeconds cannot be set simultaneously
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagThreshold": "10", "lagThresholdSeconds": "30"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, activationLagThreshold cannot be set with lagThresholdSeconds
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagThresholdSeconds": "30", "activationLagThreshold": "5"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, activationLagThresholdSeconds without lagThresholdSeconds
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "activationLagThresholdSeconds": "5"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
+	// failure, lagThresholdSeconds with a version without timestamps
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "lagThresholdSeconds": "30", "version": "0.9.0.0"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", nil, offsetResetPolicy("latest"), false, false, false},
 }
 
 var parseKafkaAuthParamsTestDataset = []parseKafkaAuthParamsTestData{
@@ -603,7 +617,7 @@ func TestKafkaGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockKafkaScaler := kafkaScaler{"", meta, nil, nil, logr.Discard(), make(map[string]map[int32]int64)}
+		mockKafkaScaler := kafkaScaler{"", meta, nil, nil, logr.Discard(), make(map[string]map[int32]int64), nil}
 
 		metricSpec := mockKafkaScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -631,7 +645,7 @@ func TestGetTopicPartitions(t *testing.T) {
 			if err != nil {
 				t.Fatal("Could not parse metadata:", err)
 			}
-			mockKafkaScaler := kafkaScaler{"", meta, nil, &MockClusterAdmin{partitionIds: tt.partitionIds}, logr.Discard(), make(map[string]map[int32]int64)}
+			mockKafkaScaler := kafkaScaler{"", meta, nil, &MockClusterAdmin{partitionIds: tt.partitionIds}, logr.Discard(), make(map[string]map[int32]int64), nil}
 
 			partitions, err := mockKafkaScaler.getTopicPartitions()
 
@@ -778,3 +792,33 @@ func (m *MockClusterAdmin) RemoveMemberFromConsumerGroup(_ string, _ []string) (
 func (m *MockClusterAdmin) Close() error {
 	return nil
 }
+
+func TestKafkaGetFetchedMessageTimestamp(t *testing.T) {
+	produced := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	response := &sarama.FetchResponse{}
+	response.AddControlRecordWithTimestamp("batch-topic", 0, 8, 1, sarama.ControlRecordCommit, produced.Add(-time.Hour))
+	for offset := int64(9); offset < 12; offset++ {
+		response.AddRecordBatchWithTimestamp("batch-topic", 0, nil, nil, offset, 1, false, produced.Add(time.Duration(offset)*time.Second))
+	}
+	response.AddMessageWithTimestamp("message-topic", 1, nil, nil, 41, produced, 1)
+	response.AddMessageWithTimestamp("message-topic", 1, nil, nil, 42, produced.Add(time.Minute), 1)
+	responses := []*sarama.FetchResponse{response}
+
+	timestamp, found := getFetchedMessageTimestamp(responses, "batch-topic", 0, 8)
+	assert.True(t, found)
+	assert.Equal(t, produced.Add(9*time.Second), timestamp)
+
+	timestamp, found = getFetchedMessageTimestamp(responses, "batch-topic", 0, 10)
+	assert.True(t, found)
+	assert.Equal(t, produced.Add(10*time.Second), timestamp)
+
+	timestamp, found = getFetchedMessageTimestamp(responses, "message-topic", 1, 42)
+	assert.True(t, found)
+	assert.Equal(t, produced.Add(time.Minute), timestamp)
+
+	_, found = getFetchedMessageTimestamp(responses, "batch-topic", 0, 12)
+	assert.False(t, found)
+
+	_, found = getFetchedMessageTimestamp(responses, "other-topic", 0, 0)
+	assert.False(t, found)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// kubeletSummary is the part of the stats summary of a kubelet with the filesystem usage of the containers
+type kubeletSummary struct {
+	Pods []struct {
+		PodRef struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"podRef"`
+		Containers []struct {
+			Name   string          `json:"name"`
+			Rootfs *kubeletFsStats `json:"rootfs"`
+			Logs   *kubeletFsStats `json:"logs"`
+		} `json:"containers"`
+	} `json:"pods"`
+}
+
+type kubeletFsStats struct {
+	UsedBytes *uint64 `json:"usedBytes"`
+}
+
+// getKubeClient returns the client of the API server the stats summaries of the kubelets are proxied through,
+// it's created once
+var getKubeClient = sync.OnceValues(func() (kubernetes.Interface, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get in-cluster config: %v", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %v", err)
+	}
+	return kubeClient, nil
+})
+
+// getKubeletSummary returns the stats summary of the kubelet of the node, through the proxy of the API server.
+// The nodes/proxy subresource isn't granted to the operator by default, see config/kubelet-stats.
+var getKubeletSummary = func(ctx context.Context, nodeName string) ([]byte, error) {
+	kubeClient, err := getKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	return kubeClient.CoreV1().RESTClient().Get().
+		Resource("nodes").Name(nodeName).SubResource("proxy").Suffix("stats/summary").
+		DoRaw(ctx)
+}
+
+// listKubeletPodMetrics returns the ephemeral storage used by the containers of the pods read from the stats
+// summaries of the kubelets of their nodes, as the PodMetrics the metrics API would return if metrics-server
+// reported it. The storage of a container is its writable layer and its logs, as counted by the kubelet evictions.
+func listKubeletPodMetrics(ctx context.Context, podList *corev1.PodList) (*v1beta1.PodMetricsList, error) {
+	podsByNode := map[string]map[string]bool{}
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		if podsByNode[pod.Spec.NodeName] == nil {
+			podsByNode[pod.Spec.NodeName] = map[string]bool{}
+		}
+		podsByNode[pod.Spec.NodeName][pod.Namespace+"/"+pod.Name] = true
+	}
+
+	podMetricsList := &v1beta1.PodMetricsList{}
+	for nodeName, pods := range podsByNode {
+		data, err := getKubeletSummary(ctx, nodeName)
+		if errors.IsForbidden(err) {
+			return nil, fmt.Errorf("error getting the stats summary of the node %s, the operator must be granted get on nodes/proxy: %w", nodeName, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting the stats summary of the node %s: %w", nodeName, err)
+		}
+		summary := &kubeletSummary{}
+		if err := json.Unmarshal(data, summary); err != nil {
+			return nil, fmt.Errorf("error parsing the stats summary of the node %s: %w", nodeName, err)
+		}
+
+		for _, podStats := range summary.Pods {
+			if !pods[podStats.PodRef.Namespace+"/"+podStats.PodRef.Name] {
+				continue
+			}
+			podMetrics := v1beta1.PodMetrics{
+				ObjectMeta: metav1.ObjectMeta{Name: podStats.PodRef.Name, Namespace: podStats.PodRef.Namespace},
+			}
+			for _, containerStats := range podStats.Containers {
+				var usedBytes uint64
+				for _, fsStats := range []*kubeletFsStats{containerStats.Rootfs, containerStats.Logs} {
+					if fsStats != nil && fsStats.UsedBytes != nil {
+						usedBytes += *fsStats.UsedBytes
+					}
+				}
+				podMetrics.Containers = append(podMetrics.Containers, v1beta1.ContainerMetrics{
+					Name:  containerStats.Name,
+					Usage: corev1.ResourceList{corev1.ResourceEphemeralStorage: *resource.NewQuantity(int64(usedBytes), resource.BinarySI)},
+				})
+			}
+			podMetricsList.Items = append(podMetricsList.Items, podMetrics)
+		}
+	}
+	return podMetricsList, nil
+}
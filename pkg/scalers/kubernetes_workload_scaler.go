@@ -124,6 +124,11 @@ func (s *kubernetesWorkloadScaler) GetMetricsAndActivity(ctx context.Context, me
 	return []external_metrics.ExternalMetricValue{metric}, float64(pods) > s.metadata.activationValue, nil
 }
 
+// GetWatchedPods returns the pods counted by the scaler
+func (s *kubernetesWorkloadScaler) GetWatchedPods(context.Context) (string, labels.Selector, error) {
+	return s.metadata.namespace, s.metadata.podSelector, nil
+}
+
 func (s *kubernetesWorkloadScaler) getMetricValue(ctx context.Context) (int64, error) {
 	podList := &corev1.PodList{}
 	listOptions := client.ListOptions{}
@@ -0,0 +1,291 @@
+package scalers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	llmEngineVLLM   = "vllm"
+	llmEngineTGI    = "tgi"
+	llmEngineTriton = "triton"
+
+	llmRequestsWaiting    = "requestsWaiting"
+	llmRequestsRunning    = "requestsRunning"
+	llmKVCacheUtilization = "kvCacheUtilization"
+)
+
+// llmEngine describes the metrics endpoint of an inference server
+type llmEngine struct {
+	// port is the default port of the metrics endpoint
+	port int
+	// modelLabel is the label of the metrics with the model served, empty when the server serves a single model
+	modelLabel string
+	// metrics are the metric families read for each metric of the trigger
+	metrics map[string]string
+}
+
+var llmEngines = map[string]llmEngine{
+	llmEngineVLLM: {
+		port:       8000,
+		modelLabel: "model_name",
+		metrics: map[string]string{
+			llmRequestsWaiting:    "vllm:num_requests_waiting",
+			llmRequestsRunning:    "vllm:num_requests_running",
+			llmKVCacheUtilization: "vllm:gpu_cache_usage_perc",
+		},
+	},
+	llmEngineTGI: {
+		port: 80,
+		metrics: map[string]string{
+			llmRequestsWaiting: "tgi_queue_size",
+			llmRequestsRunning: "tgi_batch_current_size",
+		},
+	},
+	llmEngineTriton: {
+		port:       8002,
+		modelLabel: "model",
+		metrics: map[string]string{
+			llmRequestsWaiting: "nv_inference_pending_request_count",
+		},
+	},
+}
+
+type llmInferenceScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *llmInferenceMetadata
+	kubeClient client.Client
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type llmInferenceMetadata struct {
+	// PodSelector selects the pods of the inference server in the namespace of the ScaledObject or ScaledJob
+	PodSelector string `keda:"name=podSelector, order=triggerMetadata"`
+	Engine      string `keda:"name=engine,      order=triggerMetadata, enum=vllm;tgi;triton"`
+	Metric      string `keda:"name=metric,      order=triggerMetadata, enum=requestsWaiting;requestsRunning;kvCacheUtilization, default=requestsWaiting"`
+	// Model restricts the metrics to a model of the servers serving several of them
+	Model       string `keda:"name=model,       order=triggerMetadata, optional"`
+	Port        int    `keda:"name=port,        order=triggerMetadata, optional"`
+	MetricsPath string `keda:"name=metricsPath, order=triggerMetadata, default=/metrics"`
+	// Aggregation of the values of the pods, the requests are summed and the kv-cache utilization is averaged by default
+	Aggregation string `keda:"name=aggregation, order=triggerMetadata, enum=sum;avg;max, optional"`
+
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	namespace    string
+	podSelector  labels.Selector
+	triggerIndex int
+}
+
+func (m *llmInferenceMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return fmt.Errorf("targetValue must be greater than 0")
+	}
+	if m.Port < 0 || m.Port > 65535 {
+		return fmt.Errorf("port must be between 1 and 65535")
+	}
+	engine := llmEngines[m.Engine]
+	if _, ok := engine.metrics[m.Metric]; !ok {
+		return fmt.Errorf("metric %s isn't exposed by %s", m.Metric, m.Engine)
+	}
+	if m.Model != "" && engine.modelLabel == "" {
+		return fmt.Errorf("model isn't supported by %s, it serves a single model", m.Engine)
+	}
+	return nil
+}
+
+// NewLLMInferenceScaler creates a new scaler for the requests waiting or the kv-cache utilization of
+// the vLLM, TGI or Triton inference servers, read from the metrics endpoints of their pods
+func NewLLMInferenceScaler(kubeClient client.Client, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseLLMInferenceMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing llm inference metadata: %w", err)
+	}
+
+	return &llmInferenceScaler{
+		metricType: metricType,
+		metadata:   meta,
+		kubeClient: kubeClient,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		logger:     InitializeLogger(config, "llm_inference_scaler"),
+	}, nil
+}
+
+func parseLLMInferenceMetadata(config *scalersconfig.ScalerConfig) (*llmInferenceMetadata, error) {
+	meta := &llmInferenceMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing llm inference metadata: %w", err)
+	}
+
+	podSelector, err := labels.Parse(meta.PodSelector)
+	if err != nil || podSelector.Empty() {
+		return nil, fmt.Errorf("invalid pod selector %q", meta.PodSelector)
+	}
+	meta.podSelector = podSelector
+
+	if meta.Port == 0 {
+		meta.Port = llmEngines[meta.Engine].port
+	}
+	if meta.Aggregation == "" {
+		meta.Aggregation = "sum"
+		if meta.Metric == llmKVCacheUtilization {
+			meta.Aggregation = "avg"
+		}
+	}
+	meta.namespace = config.ScalableObjectNamespace
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// scrape returns the value of the metric in the metrics of the inference server of the pod
+func (s *llmInferenceScaler) scrape(ctx context.Context, pod *corev1.Pod) (float64, error) {
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(s.metadata.Port)), s.metadata.MetricsPath)
+	body, err := getPodEndpoint(ctx, s.httpClient, pod, url)
+	if err != nil {
+		return 0, err
+	}
+	engine := llmEngines[s.metadata.Engine]
+	return getLLMInferenceValue(body, engine.metrics[s.metadata.Metric], engine.modelLabel, s.metadata.Model)
+}
+
+// getLLMInferenceValue sums the samples of the metric family of the model, or of all the models when it isn't set
+func getLLMInferenceValue(body []byte, family string, modelLabel string, model string) (float64, error) {
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("error parsing the metrics: %w", err)
+	}
+	metricFamily, ok := families[family]
+	if !ok {
+		return 0, fmt.Errorf("%s isn't exposed", family)
+	}
+
+	value := 0.0
+	for _, metric := range metricFamily.GetMetric() {
+		if model != "" && !hasMetricLabel(metric.GetLabel(), modelLabel, model) {
+			continue
+		}
+		switch {
+		case metric.GetGauge() != nil:
+			value += metric.GetGauge().GetValue()
+		case metric.GetUntyped() != nil:
+			value += metric.GetUntyped().GetValue()
+		default:
+			return 0, fmt.Errorf("%s isn't a gauge", family)
+		}
+	}
+	return value, nil
+}
+
+// hasMetricLabel checks the metric has the label with the value
+func hasMetricLabel(labelPairs []*dto.LabelPair, name string, value string) bool {
+	for _, label := range labelPairs {
+		if label.GetName() == name && label.GetValue() == value {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMetricValue returns the value of the metric, aggregated over the pods
+func (s *llmInferenceScaler) GetMetricValue(ctx context.Context) (float64, error) {
+	pods, err := listScrapedPods(ctx, s.kubeClient, s.metadata.namespace, s.metadata.podSelector)
+	if err != nil {
+		return -1, err
+	}
+
+	values := make([]float64, len(pods))
+	errs := make([]error, len(pods))
+	scrapePods(pods, func(i int, pod *corev1.Pod) {
+		values[i], errs[i] = s.scrape(ctx, pod)
+	})
+
+	var scraped []float64
+	var lastErr error
+	for i, pod := range pods {
+		if errs[i] != nil {
+			s.logger.V(1).Info("error scraping the inference server of the pod", "pod", pod.Name, "error", errs[i])
+			lastErr = errs[i]
+			continue
+		}
+		scraped = append(scraped, values[i])
+	}
+	if len(scraped) == 0 {
+		if lastErr != nil {
+			return -1, fmt.Errorf("error scraping the inference servers of the pods: %w", lastErr)
+		}
+		return 0, nil
+	}
+
+	value := 0.0
+	for _, v := range scraped {
+		switch s.metadata.Aggregation {
+		case "max":
+			value = math.Max(value, v)
+		default:
+			value += v
+		}
+	}
+	if s.metadata.Aggregation == "avg" {
+		value /= float64(len(scraped))
+	}
+	return value, nil
+}
+
+// GetWatchedPods returns the pods of the inference server
+func (s *llmInferenceScaler) GetWatchedPods(context.Context) (string, labels.Selector, error) {
+	return s.metadata.namespace, s.metadata.podSelector, nil
+}
+
+func (s *llmInferenceScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.GetMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting llm inference metric value")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *llmInferenceScaler) GetMetricSpecForScaling(_ context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("llm-inference-%s-%s", s.metadata.Engine, s.metadata.Metric))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *llmInferenceScaler) Close(_ context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package scalers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseLLMInferenceMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type llmInferenceMetricIdentifier struct {
+	metadataTestData *parseLLMInferenceMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testLLMInferenceMetadata = []parseLLMInferenceMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true},
+	// properly formed metadata
+	{map[string]string{"podSelector": "app=llama", "engine": "vllm", "targetValue": "5"}, false},
+	// kv-cache utilization of a model
+	{map[string]string{"podSelector": "app=llama", "engine": "vllm", "metric": "kvCacheUtilization", "model": "meta-llama/Llama-3.1-8B-Instruct", "targetValue": "0.8"}, false},
+	// tgi queue
+	{map[string]string{"podSelector": "app=mistral", "engine": "tgi", "targetValue": "5"}, false},
+	// triton pending requests of a model
+	{map[string]string{"podSelector": "app=triton", "engine": "triton", "model": "resnet", "targetValue": "5"}, false},
+	// unknown engine
+	{map[string]string{"podSelector": "app=llama", "engine": "ollama", "targetValue": "5"}, true},
+	// kv-cache utilization isn't exposed by tgi
+	{map[string]string{"podSelector": "app=mistral", "engine": "tgi", "metric": "kvCacheUtilization", "targetValue": "0.8"}, true},
+	// tgi serves a single model
+	{map[string]string{"podSelector": "app=mistral", "engine": "tgi", "model": "mistral", "targetValue": "5"}, true},
+	// missing podSelector
+	{map[string]string{"engine": "vllm", "targetValue": "5"}, true},
+	// invalid aggregation
+	{map[string]string{"podSelector": "app=llama", "engine": "vllm", "aggregation": "min", "targetValue": "5"}, true},
+	// invalid targetValue
+	{map[string]string{"podSelector": "app=llama", "engine": "vllm", "targetValue": "0"}, true},
+}
+
+var llmInferenceMetricIdentifiers = []llmInferenceMetricIdentifier{
+	{&testLLMInferenceMetadata[1], 0, "s0-llm-inference-vllm-requestsWaiting"},
+	{&testLLMInferenceMetadata[2], 1, "s1-llm-inference-vllm-kvCacheUtilization"},
+}
+
+func TestLLMInferenceParseMetadata(t *testing.T) {
+	for testCaseNum, testData := range testLLMInferenceMetadata {
+		_, err := parseLLMInferenceMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", testCaseNum, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", testCaseNum)
+		}
+	}
+}
+
+func TestLLMInferenceParseMetadataDefaults(t *testing.T) {
+	meta, err := parseLLMInferenceMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testLLMInferenceMetadata[1].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, 8000, meta.Port)
+	assert.Equal(t, "sum", meta.Aggregation)
+
+	meta, err = parseLLMInferenceMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testLLMInferenceMetadata[2].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, "avg", meta.Aggregation)
+
+	meta, err = parseLLMInferenceMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testLLMInferenceMetadata[4].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, 8002, meta.Port)
+}
+
+func TestLLMInferenceGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range llmInferenceMetricIdentifiers {
+		meta, err := parseLLMInferenceMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockLLMInferenceScaler := llmInferenceScaler{metadata: meta}
+
+		metricSpec := mockLLMInferenceScaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func TestGetLLMInferenceValue(t *testing.T) {
+	vllm := []byte(`# HELP vllm:num_requests_waiting Number of requests waiting to be processed.
+# TYPE vllm:num_requests_waiting gauge
+vllm:num_requests_waiting{model_name="llama"} 7.0
+vllm:num_requests_waiting{model_name="mistral"} 2.0
+# HELP vllm:gpu_cache_usage_perc GPU KV-cache usage. 1 means 100 percent usage.
+# TYPE vllm:gpu_cache_usage_perc gauge
+vllm:gpu_cache_usage_perc{model_name="llama"} 0.65
+`)
+	value, err := getLLMInferenceValue(vllm, "vllm:num_requests_waiting", "model_name", "")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(9), value)
+	value, err = getLLMInferenceValue(vllm, "vllm:num_requests_waiting", "model_name", "llama")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(7), value)
+	value, err = getLLMInferenceValue(vllm, "vllm:gpu_cache_usage_perc", "model_name", "llama")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.65, value)
+	_, err = getLLMInferenceValue(vllm, "vllm:num_requests_running", "model_name", "")
+	assert.Error(t, err)
+
+	triton := []byte(`# HELP nv_inference_pending_request_count Instantaneous number of pending requests awaiting execution per-model.
+# TYPE nv_inference_pending_request_count gauge
+nv_inference_pending_request_count{model="resnet",version="1"} 3
+nv_inference_pending_request_count{model="resnet",version="2"} 1
+nv_inference_pending_request_count{model="bert",version="1"} 12
+`)
+	value, err = getLLMInferenceValue(triton, "nv_inference_pending_request_count", "model", "resnet")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(4), value)
+}
+
+func TestLLMInferenceGetMetricValue(t *testing.T) {
+	var apiStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/metrics", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("# TYPE tgi_queue_size gauge\ntgi_queue_size 6\n"))
+	}))
+	defer apiStub.Close()
+	host, port, _ := net.SplitHostPort(apiStub.Listener.Addr().String())
+
+	pod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "mistral"}},
+			Status:     corev1.PodStatus{Phase: phase, PodIP: host},
+		}
+	}
+	kubeClient := fake.NewClientBuilder().WithObjects(pod("mistral-1", corev1.PodRunning), pod("mistral-2", corev1.PodRunning), pod("mistral-3", corev1.PodPending)).Build()
+
+	testCases := []struct {
+		aggregation   string
+		expectedValue float64
+	}{
+		{"sum", 12},
+		{"avg", 6},
+		{"max", 6},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.aggregation, func(t *testing.T) {
+			meta, err := parseLLMInferenceMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata:         map[string]string{"podSelector": "app=mistral", "engine": "tgi", "port": port, "aggregation": tc.aggregation, "targetValue": "5"},
+				ScalableObjectNamespace: "default",
+			})
+			assert.NoError(t, err)
+			s := &llmInferenceScaler{metadata: meta, kubeClient: kubeClient, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+			value, err := s.GetMetricValue(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, value)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"strings"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+// typedMetadata are the scalers parsing their metadata with a typed config, by trigger type,
+// along with the metadata they parse besides it
+var typedMetadata = map[string]struct {
+	config      func() any
+	knownParams []string
+}{
+	"activemq":               {config: func() any { return &activeMQMetadata{} }},
+	"apache-kafka":           {config: func() any { return &apacheKafkaMetadata{} }, knownParams: awsAuthorizationParams},
+	"arangodb":               {config: func() any { return &arangoDBMetadata{} }, knownParams: []string{"dbName"}},
+	"artemis-queue":          {config: func() any { return &artemisMetadata{} }},
+	"aws-cloudwatch":         {config: func() any { return &awsCloudwatchMetadata{} }, knownParams: awsAuthorizationParams},
+	"aws-dynamodb":           {config: func() any { return &awsDynamoDBMetadata{} }, knownParams: append([]string{"expressionAttributeNames", "expressionAttributeValues"}, awsAuthorizationParams...)},
+	"aws-step-functions":     {config: func() any { return &awsStepFunctionsMetadata{} }, knownParams: awsAuthorizationParams},
+	"azure-eventgrid":        {config: func() any { return &azureEventGridMetadata{} }, knownParams: azureAuthorizationParams},
+	"clickhouse":             {config: func() any { return &clickHouseMetadata{} }},
+	"cron":                   {config: func() any { return &cronMetadata{} }},
+	"dynatrace":              {config: func() any { return &dynatraceMetadata{} }},
+	"elasticsearch":          {config: func() any { return &elasticsearchMetadata{} }},
+	"envoy":                  {config: func() any { return &envoyMetadata{} }},
+	"github-webhook":         {config: func() any { return &githubWebhookMetadata{} }},
+	"gitlab-runner":          {config: func() any { return &gitlabRunnerMetadata{} }},
+	"grpc-probe":             {config: func() any { return &grpcProbeMetadata{} }},
+	"hashicorp-kv":           {config: func() any { return &hashicorpKVMetadata{} }},
+	"hpc-scheduler":          {config: func() any { return &hpcSchedulerMetadata{} }},
+	"ibmmq":                  {config: func() any { return &ibmmqMetadata{} }},
+	"iceberg":                {config: func() any { return &icebergMetadata{} }},
+	"llm-inference":          {config: func() any { return &llmInferenceMetadata{} }},
+	"mqtt":                   {config: func() any { return &mqttMetadata{} }},
+	"mysql":                  {config: func() any { return &mySQLMetadata{} }},
+	"object-storage":         {config: func() any { return &objectStorageMetadata{} }, knownParams: append([]string{"cloud", "endpointSuffix", "credentialsFromEnv", "credentialsFromEnvFile"}, awsAuthorizationParams...)},
+	"opensearch":             {config: func() any { return &opensearchMetadata{} }, knownParams: awsAuthorizationParams},
+	"otel":                   {config: func() any { return &otelMetadata{} }},
+	"prometheus":             {config: func() any { return &prometheusMetadata{} }, knownParams: append([]string{"awsRegion", "cloud", "azureManagedPrometheusResourceURL", "credentialsFromEnv", "credentialsFromEnvFile"}, awsAuthorizationParams...)},
+	"redis":                  {config: func() any { return &redisMetadata{} }},
+	"redis-cluster":          {config: func() any { return &redisMetadata{} }},
+	"redis-sentinel":         {config: func() any { return &redisMetadata{} }},
+	"redis-streams":          {config: func() any { return &redisStreamsMetadata{} }},
+	"redis-cluster-streams":  {config: func() any { return &redisStreamsMetadata{} }},
+	"redis-sentinel-streams": {config: func() any { return &redisStreamsMetadata{} }},
+	"selenium-grid":          {config: func() any { return &seleniumGridScalerMetadata{} }},
+	"sftp":                   {config: func() any { return &sftpMetadata{} }},
+	"solace-event-queue":     {config: func() any { return &SolaceMetadata{} }},
+	"solr":                   {config: func() any { return &solrMetadata{} }},
+	"splunk":                 {config: func() any { return &SplunkMetadata{} }},
+	"splunk-observability":   {config: func() any { return &splunkObservabilityMetadata{} }},
+	"temporal":               {config: func() any { return &temporalMetadata{} }},
+	"webhook-push":           {config: func() any { return &webhookPushMetadata{} }},
+	"workflow-orchestrator":  {config: func() any { return &workflowOrchestratorMetadata{} }},
+	"workqueue":              {config: func() any { return &workqueueMetadata{} }},
+}
+
+var (
+	// awsAuthorizationParams are the metadata parsed by awsutils.GetAwsAuthorization
+	awsAuthorizationParams = []string{"identityOwner", "awsAccessKeyID", "awsAccessKeyIDFromEnv", "awsSecretAccessKeyFromEnv"}
+	// azureAuthorizationParams are the metadata parsed by parseAzurePodIdentityParams and parseCloud
+	azureAuthorizationParams = []string{"activeDirectoryClientId", "activeDirectoryClientIdFromEnv", "activeDirectoryClientPasswordFromEnv", "cloud", "azureResourceManagerEndpoint"}
+	// ignoredParams are the metadata of the earlier versions which are still accepted though they're ignored
+	ignoredParams = []string{"metricName"}
+)
+
+// ValidateTriggerMetadata validates the metadata of the trigger against the typed config of its scaler,
+// without resolving its environment or authentication nor contacting the scaled backend, so the errors
+// like unknown or malformed parameters are reported before the scaler is built.
+// The triggers of the scalers without a typed config aren't validated.
+func ValidateTriggerMetadata(trigger kedav1alpha1.ScaleTriggers) error {
+	typed, ok := typedMetadata[trigger.Type]
+	if !ok {
+		return nil
+	}
+
+	config := &scalersconfig.ScalerConfig{
+		TriggerType:     trigger.Type,
+		TriggerName:     trigger.Name,
+		TriggerMetadata: trigger.Metadata,
+		MetricType:      trigger.MetricType,
+	}
+	// the environment is only resolved for the parameters referencing it
+	if !hasParamFromEnv(trigger.Metadata) {
+		config.ResolvedEnv = map[string]string{}
+	}
+	// the trigger is only authenticated through its authentication reference
+	if trigger.AuthenticationRef == nil {
+		config.AuthParams = map[string]string{}
+	}
+	knownParams := append(append([]string{}, typed.knownParams...), ignoredParams...)
+	return config.ValidateTypedConfig(typed.config(), knownParams...)
+}
+
+func hasParamFromEnv(metadata map[string]string) bool {
+	for key := range metadata {
+		if strings.HasSuffix(key, "FromEnv") {
+			return true
+		}
+	}
+	return false
+}
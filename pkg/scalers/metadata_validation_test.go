@@ -0,0 +1,81 @@
+package scalers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestValidateTriggerMetadata(t *testing.T) {
+	cases := []struct {
+		name    string
+		trigger kedav1alpha1.ScaleTriggers
+		wantErr []string
+	}{
+		{
+			name: "valid cron trigger",
+			trigger: kedav1alpha1.ScaleTriggers{Type: "cron", Metadata: map[string]string{
+				"timezone": "Etc/UTC", "start": "0 8 * * *", "end": "0 18 * * *", "desiredReplicas": "3",
+			}},
+		},
+		{
+			name: "unknown and malformed parameters",
+			trigger: kedav1alpha1.ScaleTriggers{Type: "cron", Metadata: map[string]string{
+				"timezone": "Etc/UTC", "start": "0 8 * * *", "end": "0 18 * * *", "desiredReplica": "3",
+			}},
+			wantErr: []string{`missing required parameter "desiredReplicas"`, `unknown parameter "desiredReplica"`},
+		},
+		{
+			name: "bad quantity",
+			trigger: kedav1alpha1.ScaleTriggers{Type: "prometheus", Metadata: map[string]string{
+				"serverAddress": "http://prometheus:9090", "query": "sum(rate(http_requests_total[2m]))", "threshold": "ten",
+			}},
+			wantErr: []string{`unable to set param "threshold" value "ten"`},
+		},
+		{
+			name: "parameters resolved from the authentication",
+			trigger: kedav1alpha1.ScaleTriggers{
+				Type:              "redis",
+				Metadata:          map[string]string{"listName": "jobs", "listLength": "10"},
+				AuthenticationRef: &kedav1alpha1.AuthenticationRef{Name: "redis-auth"},
+			},
+		},
+		{
+			name:    "parameters missing without authentication",
+			trigger: kedav1alpha1.ScaleTriggers{Type: "redis", Metadata: map[string]string{"listName": "jobs"}},
+			wantErr: []string{"no addresses or hosts given"},
+		},
+		{
+			name: "parameters resolved from the environment",
+			trigger: kedav1alpha1.ScaleTriggers{Type: "redis", Metadata: map[string]string{
+				"listName": "jobs", "addressFromEnv": "REDIS_ADDRESS",
+			}},
+		},
+		{
+			name: "parameters parsed besides the typed config",
+			trigger: kedav1alpha1.ScaleTriggers{Type: "aws-cloudwatch", Metadata: map[string]string{
+				"namespace": "AWS/SQS", "metricName": "ApproximateNumberOfMessagesVisible", "dimensionName": "QueueName", "dimensionValue": "keda",
+				"targetMetricValue": "2", "minMetricValue": "0", "awsRegion": "eu-west-1", "identityOwner": "operator",
+			}},
+		},
+		{
+			name:    "scaler without typed config",
+			trigger: kedav1alpha1.ScaleTriggers{Type: "rabbitmq", Metadata: map[string]string{"unknown": "value"}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateTriggerMetadata(c.trigger)
+			if len(c.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			for _, wantErr := range c.wantErr {
+				assert.ErrorContains(t, err, wantErr)
+			}
+		})
+	}
+}
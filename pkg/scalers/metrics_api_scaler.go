@@ -1,7 +1,9 @@
 package scalers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -10,8 +12,11 @@ import (
 	neturl "net/url"
 	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/go-logr/logr"
+	"github.com/google/cel-go/cel"
+	"github.com/jmespath/go-jmespath"
 	"github.com/prometheus/common/expfmt"
 	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/tidwall/gjson"
@@ -40,6 +45,22 @@ type metricsAPIScalerMetadata struct {
 	valueLocation         string
 	unsafeSsl             bool
 
+	// valueLocationType is the language of valueLocation for the json format,
+	// either "gjson" (default), "jmespath" or "cel"
+	valueLocationType string
+	valueQuery        *jmespath.JMESPath
+	valueProgram      cel.Program
+
+	// request
+	requestMethod      string
+	requestBody        *template.Template
+	requestBodyParams  map[string]string
+	requestContentType string
+
+	// pagination
+	paginationNextLocation string
+	paginationMaxPages     int
+
 	// apiKeyAuth
 	enableAPIKeyAuth bool
 	method           string // way of providing auth key, either "header" (default) or "query"
@@ -69,8 +90,26 @@ type metricsAPIScalerMetadata struct {
 const (
 	methodValueQuery           = "query"
 	valueLocationWrongErrorMsg = "valueLocation must point to value of type number or a string representing a Quantity got: '%s'"
+
+	gjsonValueLocationType    = "gjson"
+	jmespathValueLocationType = "jmespath"
+	celValueLocationType      = "cel"
+
+	defaultPaginationMaxPages = 10
+	// valueProgramCostLimit bounds the cost of the evaluation of a cel valueLocation on a response
+	valueProgramCostLimit = 1000000
 )
 
+// metricsAPIRequestBodyData is the data the requestBody template is rendered with
+type metricsAPIRequestBodyData struct {
+	// Params are the requestBodyParams
+	Params map[string]string
+	// Page is the number of the requested page, starting at 1
+	Page int
+	// Next is the value at paginationNextLocation in the previous page, empty on the first one
+	Next string
+}
+
 type APIFormat string
 
 // Options for APIFormat:
@@ -178,6 +217,18 @@ func parseMetricsAPIMetadata(config *scalersconfig.ScalerConfig) (*metricsAPISca
 		return nil, fmt.Errorf("no valueLocation given in metadata")
 	}
 
+	if err := parseMetricsAPIValueLocationType(config, &meta); err != nil {
+		return nil, err
+	}
+
+	if err := parseMetricsAPIRequest(config, &meta); err != nil {
+		return nil, err
+	}
+
+	if err := parseMetricsAPIPagination(config, &meta); err != nil {
+		return nil, err
+	}
+
 	authMode, ok := config.TriggerMetadata["authMode"]
 	// no authMode specified
 	if !ok {
@@ -246,6 +297,102 @@ func parseMetricsAPIMetadata(config *scalersconfig.ScalerConfig) (*metricsAPISca
 	return &meta, nil
 }
 
+func parseMetricsAPIValueLocationType(config *scalersconfig.ScalerConfig, meta *metricsAPIScalerMetadata) error {
+	meta.valueLocationType = gjsonValueLocationType
+	if val, ok := config.TriggerMetadata["valueLocationType"]; ok && val != "" {
+		meta.valueLocationType = strings.TrimSpace(val)
+	}
+	if meta.valueLocationType != gjsonValueLocationType && meta.format != JSONFormat {
+		return fmt.Errorf("valueLocationType %s is only supported with the %s format", meta.valueLocationType, JSONFormat)
+	}
+
+	switch meta.valueLocationType {
+	case gjsonValueLocationType:
+	case jmespathValueLocationType:
+		query, err := jmespath.Compile(meta.valueLocation)
+		if err != nil {
+			return fmt.Errorf("error compiling the jmespath valueLocation: %w", err)
+		}
+		meta.valueQuery = query
+	case celValueLocationType:
+		env, err := cel.NewEnv(cel.Variable("response", cel.DynType))
+		if err != nil {
+			return err
+		}
+		ast, issues := env.Compile(meta.valueLocation)
+		if issues != nil && issues.Err() != nil {
+			return fmt.Errorf("error compiling the cel valueLocation: %w", issues.Err())
+		}
+		program, err := env.Program(ast, cel.CostLimit(valueProgramCostLimit))
+		if err != nil {
+			return fmt.Errorf("error compiling the cel valueLocation: %w", err)
+		}
+		meta.valueProgram = program
+	default:
+		return fmt.Errorf("valueLocationType %s not supported", meta.valueLocationType)
+	}
+	return nil
+}
+
+func parseMetricsAPIRequest(config *scalersconfig.ScalerConfig, meta *metricsAPIScalerMetadata) error {
+	meta.requestMethod = http.MethodGet
+	if val, ok := config.TriggerMetadata["requestMethod"]; ok && val != "" {
+		meta.requestMethod = strings.ToUpper(strings.TrimSpace(val))
+	}
+	if meta.requestMethod != http.MethodGet && meta.requestMethod != http.MethodPost {
+		return fmt.Errorf("requestMethod %s not supported", meta.requestMethod)
+	}
+
+	val, ok := config.TriggerMetadata["requestBody"]
+	if !ok || val == "" {
+		return nil
+	}
+	if meta.requestMethod != http.MethodPost {
+		return fmt.Errorf("requestBody is only supported with the %s requestMethod", http.MethodPost)
+	}
+	body, err := template.New("requestBody").Option("missingkey=error").Parse(val)
+	if err != nil {
+		return fmt.Errorf("error parsing requestBody: %w", err)
+	}
+	meta.requestBody = body
+
+	params, err := kedautil.ParseStringList(config.TriggerMetadata["requestBodyParams"])
+	if err != nil {
+		return fmt.Errorf("error parsing requestBodyParams: %w", err)
+	}
+	meta.requestBodyParams = params
+
+	meta.requestContentType = "application/json"
+	if val, ok := config.TriggerMetadata["requestContentType"]; ok && val != "" {
+		meta.requestContentType = val
+	}
+	return nil
+}
+
+func parseMetricsAPIPagination(config *scalersconfig.ScalerConfig, meta *metricsAPIScalerMetadata) error {
+	val, ok := config.TriggerMetadata["paginationNextLocation"]
+	if !ok || val == "" {
+		return nil
+	}
+	if meta.format != JSONFormat {
+		return fmt.Errorf("paginationNextLocation is only supported with the %s format", JSONFormat)
+	}
+	meta.paginationNextLocation = val
+
+	meta.paginationMaxPages = defaultPaginationMaxPages
+	if val, ok := config.TriggerMetadata["paginationMaxPages"]; ok {
+		maxPages, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("error parsing paginationMaxPages: %w", err)
+		}
+		if maxPages < 1 {
+			return fmt.Errorf("paginationMaxPages must be at least 1, got %d", maxPages)
+		}
+		meta.paginationMaxPages = maxPages
+	}
+	return nil
+}
+
 // GetValueFromResponse uses provided valueLocation to access the numeric value in provided body using the format specified.
 func GetValueFromResponse(body []byte, valueLocation string, format APIFormat) (float64, error) {
 	switch format {
@@ -358,22 +505,7 @@ func getValueFromXMLResponse(body []byte, valueLocation string) (float64, error)
 		return 0, err
 	}
 
-	switch v := path.(type) {
-	case int:
-		return float64(v), nil
-	case int64:
-		return float64(v), nil
-	case float64:
-		return v, nil
-	case string:
-		r, err := resource.ParseQuantity(v)
-		if err != nil {
-			return 0, fmt.Errorf(valueLocationWrongErrorMsg, v)
-		}
-		return r.AsApproximateFloat64(), nil
-	default:
-		return 0, fmt.Errorf(valueLocationWrongErrorMsg, v)
-	}
+	return getValueFromInterface(path)
 }
 
 // getValueFromYAMLResponse uses provided valueLocation to access the numeric value in provided body
@@ -390,11 +522,46 @@ func getValueFromYAMLResponse(body []byte, valueLocation string) (float64, error
 		return 0, err
 	}
 
-	switch v := path.(type) {
+	return getValueFromInterface(path)
+}
+
+// getValueFromJMESPathResponse uses the provided query to access the numeric value in provided JSON body
+func getValueFromJMESPathResponse(body []byte, query *jmespath.JMESPath) (float64, error) {
+	var response interface{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return 0, err
+	}
+
+	v, err := query.Search(response)
+	if err != nil {
+		return 0, err
+	}
+	return getValueFromInterface(v)
+}
+
+// getValueFromCELResponse evaluates the provided program on the provided JSON body, available as `response`
+func getValueFromCELResponse(body []byte, program cel.Program) (float64, error) {
+	var response interface{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return 0, err
+	}
+
+	v, _, err := program.Eval(map[string]interface{}{"response": response})
+	if err != nil {
+		return 0, err
+	}
+	return getValueFromInterface(v.Value())
+}
+
+// getValueFromInterface converts the value found at valueLocation to a float64
+func getValueFromInterface(value interface{}) (float64, error) {
+	switch v := value.(type) {
 	case int:
 		return float64(v), nil
 	case int64:
 		return float64(v), nil
+	case uint64:
+		return float64(v), nil
 	case float64:
 		return v, nil
 	case string:
@@ -408,32 +575,93 @@ func getValueFromYAMLResponse(body []byte, valueLocation string) (float64, error
 	}
 }
 
+// getValueFromResponse accesses the numeric value in provided body using the valueLocation of the trigger
+func (m *metricsAPIScalerMetadata) getValueFromResponse(body []byte) (float64, error) {
+	switch m.valueLocationType {
+	case jmespathValueLocationType:
+		return getValueFromJMESPathResponse(body, m.valueQuery)
+	case celValueLocationType:
+		return getValueFromCELResponse(body, m.valueProgram)
+	}
+	return GetValueFromResponse(body, m.valueLocation, m.format)
+}
+
+// getMetricValue returns the value of the response, or the sum of the values of its pages when paginationNextLocation is set
 func (s *metricsAPIScaler) getMetricValue(ctx context.Context) (float64, error) {
-	request, err := getMetricAPIServerRequest(ctx, s.metadata)
+	url, next := s.metadata.url, ""
+	value := 0.0
+	for page := 1; ; page++ {
+		b, err := s.getResponse(ctx, url, page, next)
+		if err != nil {
+			return 0, err
+		}
+		v, err := s.metadata.getValueFromResponse(b)
+		if err != nil {
+			return 0, err
+		}
+		value += v
+
+		if s.metadata.paginationNextLocation == "" {
+			return value, nil
+		}
+		next = gjson.GetBytes(b, s.metadata.paginationNextLocation).String()
+		if next == "" {
+			return value, nil
+		}
+		if page >= s.metadata.paginationMaxPages {
+			return 0, fmt.Errorf("the response has more than %d pages", s.metadata.paginationMaxPages)
+		}
+		// the cursor of a templated body is given to the next request as .Next,
+		// otherwise it's the link to the next page
+		if s.metadata.requestBody == nil {
+			if url, err = resolveNextPageURL(url, next); err != nil {
+				return 0, err
+			}
+		}
+	}
+}
+
+// resolveNextPageURL resolves the link to the next page, which can be relative, against the current one
+func resolveNextPageURL(current, next string) (string, error) {
+	base, err := neturl.Parse(current)
 	if err != nil {
-		return 0, err
+		return "", err
+	}
+	ref, err := neturl.Parse(next)
+	if err != nil {
+		return "", fmt.Errorf("error parsing the link to the next page: %w", err)
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+func (s *metricsAPIScaler) getResponse(ctx context.Context, url string, page int, next string) ([]byte, error) {
+	var body io.Reader
+	if s.metadata.requestBody != nil {
+		buf := &bytes.Buffer{}
+		data := metricsAPIRequestBodyData{Params: s.metadata.requestBodyParams, Page: page, Next: next}
+		if err := s.metadata.requestBody.Execute(buf, data); err != nil {
+			return nil, fmt.Errorf("error rendering requestBody: %w", err)
+		}
+		body = buf
+	}
+
+	request, err := getMetricAPIServerRequest(ctx, s.metadata, url, body)
+	if err != nil {
+		return nil, err
 	}
 
 	r, err := s.httpClient.Do(request)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
 		msg := fmt.Sprintf("%s: api returned %d", r.Request.URL.Path, r.StatusCode)
-		return 0, errors.New(msg)
+		return nil, errors.New(msg)
 	}
 
-	b, err := io.ReadAll(r.Body)
-	if err != nil {
-		return 0, err
-	}
-	v, err := GetValueFromResponse(b, s.metadata.valueLocation, s.metadata.format)
-	if err != nil {
-		return 0, err
-	}
-	return v, nil
+	return io.ReadAll(r.Body)
 }
 
 // Close does nothing in case of metricsAPIScaler
@@ -470,57 +698,40 @@ func (s *metricsAPIScaler) GetMetricsAndActivity(ctx context.Context, metricName
 	return []external_metrics.ExternalMetricValue{metric}, val > s.metadata.activationTargetValue, nil
 }
 
-func getMetricAPIServerRequest(ctx context.Context, meta *metricsAPIScalerMetadata) (*http.Request, error) {
-	var req *http.Request
-	var err error
+func getMetricAPIServerRequest(ctx context.Context, meta *metricsAPIScalerMetadata, url string, body io.Reader) (*http.Request, error) {
+	method := meta.requestMethod
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", meta.requestContentType)
+	}
 
 	switch {
 	case meta.enableAPIKeyAuth:
+		keyParamName := meta.keyParamName
 		if meta.method == methodValueQuery {
-			url, _ := neturl.Parse(meta.url)
-			queryString := url.Query()
-			if len(meta.keyParamName) == 0 {
-				queryString.Set("api_key", meta.apiKey)
-			} else {
-				queryString.Set(meta.keyParamName, meta.apiKey)
-			}
-
-			url.RawQuery = queryString.Encode()
-			req, err = http.NewRequestWithContext(ctx, "GET", url.String(), nil)
-			if err != nil {
-				return nil, err
+			if len(keyParamName) == 0 {
+				keyParamName = "api_key"
 			}
+			queryString := req.URL.Query()
+			queryString.Set(keyParamName, meta.apiKey)
+			req.URL.RawQuery = queryString.Encode()
 		} else {
 			// default behaviour is to use header method
-			req, err = http.NewRequestWithContext(ctx, "GET", meta.url, nil)
-			if err != nil {
-				return nil, err
-			}
-
-			if len(meta.keyParamName) == 0 {
-				req.Header.Add("X-API-KEY", meta.apiKey)
-			} else {
-				req.Header.Add(meta.keyParamName, meta.apiKey)
+			if len(keyParamName) == 0 {
+				keyParamName = "X-API-KEY"
 			}
+			req.Header.Add(keyParamName, meta.apiKey)
 		}
 	case meta.enableBaseAuth:
-		req, err = http.NewRequestWithContext(ctx, "GET", meta.url, nil)
-		if err != nil {
-			return nil, err
-		}
-
 		req.SetBasicAuth(meta.username, meta.password)
 	case meta.enableBearerAuth:
-		req, err = http.NewRequestWithContext(ctx, "GET", meta.url, nil)
-		if err != nil {
-			return nil, err
-		}
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", meta.bearerToken))
-	default:
-		req, err = http.NewRequestWithContext(ctx, "GET", meta.url, nil)
-		if err != nil {
-			return nil, err
-		}
 	}
 
 	return req, nil
@@ -3,6 +3,7 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -34,6 +35,32 @@ var testMetricsAPIMetadata = []metricsAPIMetadataTestData{
 	{metadata: map[string]string{"valueLocation": "metric", "targetValue": "aa"}, raisesError: true},
 	// Missing targetValue
 	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric"}, raisesError: true},
+	// jmespath valueLocation
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "length(items[?state=='pending'])", "valueLocationType": "jmespath", "targetValue": "42"}, raisesError: false},
+	// invalid jmespath valueLocation
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "items[?", "valueLocationType": "jmespath", "targetValue": "42"}, raisesError: true},
+	// cel valueLocation
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "response.items.filter(i, i.state == 'pending').size()", "valueLocationType": "cel", "targetValue": "42"}, raisesError: false},
+	// invalid cel valueLocation
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "response.items.(", "valueLocationType": "cel", "targetValue": "42"}, raisesError: true},
+	// unknown valueLocationType
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "valueLocationType": "xpath", "targetValue": "42"}, raisesError: true},
+	// valueLocationType with a non json format
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "valueLocationType": "cel", "format": "yaml", "targetValue": "42"}, raisesError: true},
+	// POST with a templated body
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "requestMethod": "POST", "requestBody": `{"queue":"{{ .Params.queue }}"}`, "requestBodyParams": "queue=orders"}, raisesError: false},
+	// requestBody with GET
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "requestBody": `{}`}, raisesError: true},
+	// invalid requestBody template
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "requestMethod": "POST", "requestBody": `{{ .Params`}, raisesError: true},
+	// unsupported requestMethod
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "requestMethod": "DELETE"}, raisesError: true},
+	// pagination
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "paginationNextLocation": "next", "paginationMaxPages": "5"}, raisesError: false},
+	// invalid paginationMaxPages
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "paginationNextLocation": "next", "paginationMaxPages": "0"}, raisesError: true},
+	// pagination with a non json format
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "paginationNextLocation": "next", "format": "xml"}, raisesError: true},
 }
 
 type metricAPIAuthMetadataTestData struct {
@@ -174,6 +201,130 @@ func TestGetValueFromResponse(t *testing.T) {
 	}
 }
 
+func TestMetricsAPIValueLocationType(t *testing.T) {
+	input := []byte(`{"items":[{"state":"pending","size":3},{"state":"done","size":5},{"state":"pending","size":"2k"}]}`)
+
+	testCases := []struct {
+		name              string
+		valueLocation     string
+		valueLocationType string
+		expectVal         float64
+		expectErr         bool
+	}{
+		{name: "jmespath length", valueLocation: "length(items[?state=='pending'])", valueLocationType: "jmespath", expectVal: 2},
+		{name: "jmespath quantity", valueLocation: "items[2].size", valueLocationType: "jmespath", expectVal: 2000},
+		{name: "jmespath not a number", valueLocation: "items[0]", valueLocationType: "jmespath", expectErr: true},
+		{name: "cel size", valueLocation: "response.items.filter(i, i.state == 'pending').size()", valueLocationType: "cel", expectVal: 2},
+		{name: "cel double", valueLocation: "response.items[0].size + response.items[1].size", valueLocationType: "cel", expectVal: 8},
+		{name: "cel not a number", valueLocation: "response.items[0].state == 'pending'", valueLocationType: "cel", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meta, err := parseMetricsAPIMetadata(&scalersconfig.ScalerConfig{
+				TriggerMetadata: map[string]string{"url": "http://dummy:1230/api/v1/", "targetValue": "1", "valueLocation": tc.valueLocation, "valueLocationType": tc.valueLocationType},
+				AuthParams:      map[string]string{},
+			})
+			assert.NoError(t, err)
+
+			v, err := meta.getValueFromResponse(input)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tc.expectVal, v)
+		})
+	}
+}
+
+func TestMetricsAPIPagination(t *testing.T) {
+	var apiStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Query().Get("page") {
+		case "":
+			_, _ = w.Write([]byte(`{"count":3,"next":"?page=2"}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"count":4,"next":"?page=3"}`))
+		default:
+			_, _ = w.Write([]byte(`{"count":5}`))
+		}
+	}))
+	defer apiStub.Close()
+
+	testCases := []struct {
+		name      string
+		maxPages  string
+		expectVal float64
+		expectErr bool
+	}{
+		{name: "all pages", maxPages: "3", expectVal: 12},
+		{name: "too many pages", maxPages: "2", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewMetricsAPIScaler(
+				&scalersconfig.ScalerConfig{
+					TriggerMetadata:   map[string]string{"url": apiStub.URL, "valueLocation": "count", "targetValue": "1", "paginationNextLocation": "next", "paginationMaxPages": tc.maxPages},
+					AuthParams:        map[string]string{},
+					GlobalHTTPTimeout: 3000 * time.Millisecond,
+				},
+			)
+			assert.NoError(t, err)
+
+			v, err := s.(*metricsAPIScaler).getMetricValue(context.Background())
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tc.expectVal, v)
+		})
+	}
+}
+
+func TestMetricsAPIRequestBody(t *testing.T) {
+	var apiStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+
+		w.WriteHeader(http.StatusOK)
+		switch string(body) {
+		case `{"queue":"orders","page":1,"cursor":""}`:
+			_, _ = w.Write([]byte(`{"count":3,"cursor":"abc"}`))
+		case `{"queue":"orders","page":2,"cursor":"abc"}`:
+			_, _ = w.Write([]byte(`{"count":4}`))
+		default:
+			t.Errorf("unexpected request body %s", body)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer apiStub.Close()
+
+	s, err := NewMetricsAPIScaler(
+		&scalersconfig.ScalerConfig{
+			TriggerMetadata: map[string]string{
+				"url":                    apiStub.URL,
+				"valueLocation":          "count",
+				"targetValue":            "1",
+				"requestMethod":          "POST",
+				"requestBody":            `{"queue":"{{ .Params.queue }}","page":{{ .Page }},"cursor":"{{ .Next }}"}`,
+				"requestBodyParams":      "queue=orders",
+				"paginationNextLocation": "cursor",
+			},
+			AuthParams:        map[string]string{},
+			GlobalHTTPTimeout: 3000 * time.Millisecond,
+		},
+	)
+	assert.NoError(t, err)
+
+	v, err := s.(*metricsAPIScaler).getMetricValue(context.Background())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 7, v)
+}
+
 func TestMetricAPIScalerAuthParams(t *testing.T) {
 	for _, testData := range testMetricsAPIAuthMetadata {
 		meta, err := parseMetricsAPIMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
@@ -2,6 +2,8 @@ package scalers
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
@@ -12,6 +14,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -60,6 +63,19 @@ type mongoDBMetadata struct {
 	// A mongoDB filter doc,used by specify DB.
 	// +required
 	query string
+	// Either `query`, the count of the documents matching the query, or `changeStreamLag`, the seconds the
+	// resume token of a change stream consumer stored in the collection is behind the latest oplog entry.
+	// +optional
+	mode string
+	// The name of the change stream consumer, the value of the consumerField of the document of its resume token.
+	// +optional
+	consumerName string
+	// The field identifying the consumer in the documents of the resume tokens, default value `_id`.
+	// +optional
+	consumerField string
+	// The field of the resume token in the document of the consumer, default value `resumeToken`.
+	// +optional
+	resumeTokenField string
 	// A threshold that is used as targetAverageValue in HPA
 	// +required
 	queryValue int64
@@ -75,6 +91,12 @@ type mongoDBMetadata struct {
 // Default variables and settings
 const (
 	mongoDBDefaultTimeOut = 10 * time.Second
+
+	mongoDBModeQuery           = "query"
+	mongoDBModeChangeStreamLag = "changeStreamLag"
+
+	// mongoDBResumeTokenTimestampType is the key string type byte of the cluster time beginning the resume tokens
+	mongoDBResumeTokenTimestampType = 0x82
 )
 
 // NewMongoDBScaler creates a new mongoDB scaler
@@ -123,10 +145,36 @@ func parseMongoDBMetadata(config *scalersconfig.ScalerConfig) (*mongoDBMetadata,
 		return nil, "", fmt.Errorf("no collection given")
 	}
 
-	if val, ok := config.TriggerMetadata["query"]; ok {
-		meta.query = val
-	} else {
-		return nil, "", fmt.Errorf("no query given")
+	meta.mode = mongoDBModeQuery
+	if val, ok := config.TriggerMetadata["mode"]; ok && val != "" {
+		meta.mode = val
+	}
+	switch meta.mode {
+	case mongoDBModeQuery:
+		if val, ok := config.TriggerMetadata["query"]; ok {
+			meta.query = val
+		} else {
+			return nil, "", fmt.Errorf("no query given")
+		}
+	case mongoDBModeChangeStreamLag:
+		if _, ok := config.TriggerMetadata["query"]; ok {
+			return nil, "", fmt.Errorf("query can't be used with mode %s", mongoDBModeChangeStreamLag)
+		}
+		if val, ok := config.TriggerMetadata["consumerName"]; ok && val != "" {
+			meta.consumerName = val
+		} else {
+			return nil, "", fmt.Errorf("no consumerName given")
+		}
+		meta.consumerField = "_id"
+		if val, ok := config.TriggerMetadata["consumerField"]; ok && val != "" {
+			meta.consumerField = val
+		}
+		meta.resumeTokenField = "resumeToken"
+		if val, ok := config.TriggerMetadata["resumeTokenField"]; ok && val != "" {
+			meta.resumeTokenField = val
+		}
+	default:
+		return nil, "", fmt.Errorf("mode must be %s or %s, got %s", mongoDBModeQuery, mongoDBModeChangeStreamLag, meta.mode)
 	}
 
 	if val, ok := config.TriggerMetadata["queryValue"]; ok {
@@ -252,9 +300,89 @@ func (s *mongoDBScaler) getQueryResult(ctx context.Context) (int64, error) {
 	return docsNum, nil
 }
 
+// getChangeStreamLag returns the seconds between the cluster time of the resume token of the consumer and the
+// latest write of the replica set, the consumer has processed the changes up to its resume token
+func (s *mongoDBScaler) getChangeStreamLag(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, mongoDBDefaultTimeOut)
+	defer cancel()
+
+	checkpoint := bson.M{}
+	err := s.client.Database(s.metadata.dbName).Collection(s.metadata.collection).FindOne(ctx, bson.D{{Key: s.metadata.consumerField, Value: s.metadata.consumerName}}).Decode(&checkpoint)
+	if err != nil {
+		s.logger.Error(err, fmt.Sprintf("failed to find the resume token of %v in %v, because of %v", s.metadata.consumerName, s.metadata.collection, err))
+		return 0, err
+	}
+	tokenTime, err := mongoDBResumeTokenTime(checkpoint[s.metadata.resumeTokenField])
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the resume token of %v, because of %w", s.metadata.consumerName, err)
+	}
+
+	hello := struct {
+		LastWrite struct {
+			OpTime struct {
+				TS primitive.Timestamp `bson:"ts"`
+			} `bson:"opTime"`
+		} `bson:"lastWrite"`
+		OperationTime primitive.Timestamp `bson:"operationTime"`
+	}{}
+	if err := s.client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		s.logger.Error(err, fmt.Sprintf("failed to get the latest oplog timestamp, because of %v", err))
+		return 0, err
+	}
+	// mongos doesn't report the last write, the operation time is the cluster time of the shards
+	latest := hello.LastWrite.OpTime.TS
+	if latest.IsZero() {
+		latest = hello.OperationTime
+	}
+	if latest.IsZero() {
+		return 0, errors.New("failed to get the latest oplog timestamp, the server isn't a replica set member")
+	}
+
+	if tokenTime.After(latest) {
+		return 0, nil
+	}
+	return int64(latest.T) - int64(tokenTime.T), nil
+}
+
+// mongoDBResumeTokenTime returns the cluster time beginning the resume token, the token is either the
+// document returned by the change stream or the hex string of its _data
+func mongoDBResumeTokenTime(token any) (primitive.Timestamp, error) {
+	var data string
+	switch t := token.(type) {
+	case string:
+		data = t
+	case bson.M:
+		data, _ = t["_data"].(string)
+	case bson.D:
+		for _, e := range t {
+			if e.Key == "_data" {
+				data, _ = e.Value.(string)
+			}
+		}
+	}
+	if data == "" {
+		return primitive.Timestamp{}, errors.New("the resume token has no _data")
+	}
+
+	b, err := hex.DecodeString(data)
+	if err != nil {
+		return primitive.Timestamp{}, fmt.Errorf("the resume token isn't hex encoded: %w", err)
+	}
+	if len(b) < 9 || b[0] != mongoDBResumeTokenTimestampType {
+		return primitive.Timestamp{}, errors.New("the resume token doesn't begin with a cluster time")
+	}
+	return primitive.Timestamp{T: binary.BigEndian.Uint32(b[1:5]), I: binary.BigEndian.Uint32(b[5:9])}, nil
+}
+
 // GetMetricsAndActivity query from mongoDB,and return to external metrics
 func (s *mongoDBScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	num, err := s.getQueryResult(ctx)
+	var num int64
+	var err error
+	if s.metadata.mode == mongoDBModeChangeStreamLag {
+		num, err = s.getChangeStreamLag(ctx)
+	} else {
+		num, err = s.getQueryResult(ctx)
+	}
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("failed to inspect momgoDB, because of %w", err)
 	}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -84,6 +86,34 @@ var testMONGODBMetadata = []parseMongoDBMetadataTestData{
 		resolvedEnv: testMongoDBResolvedEnv,
 		raisesError: true,
 	},
+	// changeStreamLag mode
+	{
+		metadata:    map[string]string{"mode": "changeStreamLag", "collection": "checkpoints", "consumerName": "orders-processor", "queryValue": "30", "connectionStringFromEnv": "MongoDB_CONN_STR", "dbName": "test"},
+		authParams:  map[string]string{},
+		resolvedEnv: testMongoDBResolvedEnv,
+		raisesError: false,
+	},
+	// changeStreamLag mode without consumerName
+	{
+		metadata:    map[string]string{"mode": "changeStreamLag", "collection": "checkpoints", "queryValue": "30", "connectionStringFromEnv": "MongoDB_CONN_STR", "dbName": "test"},
+		authParams:  map[string]string{},
+		resolvedEnv: testMongoDBResolvedEnv,
+		raisesError: true,
+	},
+	// changeStreamLag mode with a query
+	{
+		metadata:    map[string]string{"mode": "changeStreamLag", "query": `{"name":"John"}`, "collection": "checkpoints", "consumerName": "orders-processor", "queryValue": "30", "connectionStringFromEnv": "MongoDB_CONN_STR", "dbName": "test"},
+		authParams:  map[string]string{},
+		resolvedEnv: testMongoDBResolvedEnv,
+		raisesError: true,
+	},
+	// invalid mode
+	{
+		metadata:    map[string]string{"mode": "oplog", "collection": "checkpoints", "queryValue": "30", "connectionStringFromEnv": "MongoDB_CONN_STR", "dbName": "test"},
+		authParams:  map[string]string{},
+		resolvedEnv: testMongoDBResolvedEnv,
+		raisesError: true,
+	},
 }
 
 var mongoDBConnectionStringTestDatas = []mongoDBConnectionStringTestData{
@@ -146,3 +176,32 @@ func TestJson2BsonDoc(t *testing.T) {
 		t.Error("the doc is nil")
 	}
 }
+
+func TestMongoDBResumeTokenTime(t *testing.T) {
+	// a resume token of the cluster time {1700000000, 3}
+	data := "826553F100000000032B022C0100296E5A1004"
+	expected := primitive.Timestamp{T: 1700000000, I: 3}
+
+	testCases := []struct {
+		name  string
+		token any
+	}{
+		{"hex string", data},
+		{"document", bson.M{"_data": data}},
+		{"ordered document", bson.D{{Key: "_data", Value: data}}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts, err := mongoDBResumeTokenTime(testCase.token)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, ts)
+		})
+	}
+
+	_, err := mongoDBResumeTokenTime(nil)
+	assert.Error(t, err)
+	_, err = mongoDBResumeTokenTime("zz")
+	assert.Error(t, err)
+	_, err = mongoDBResumeTokenTime("016553F10000000003")
+	assert.Error(t, err)
+}
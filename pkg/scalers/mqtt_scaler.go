@@ -0,0 +1,487 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	url_pkg "net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	mqttModeSys  = "sys"
+	mqttModeEMQX = "emqx"
+
+	defaultMQTTTimeout = 10 * time.Second
+	emqxPageSize       = 1000
+
+	// MQTT 3.1.1 control packet types, in the upper nibble of the fixed header
+	mqttConnect    = 0x10
+	mqttConnack    = 0x20
+	mqttPublish    = 0x30
+	mqttSubscribe  = 0x82
+	mqttSuback     = 0x90
+	mqttDisconnect = 0xe0
+)
+
+var mqttWildcardReplacer = strings.NewReplacer("+", "any", "#", "all")
+
+type mqttScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *mqttMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type mqttMetadata struct {
+	Mode string `keda:"name=mode, order=triggerMetadata, enum=sys;emqx, default=sys"`
+
+	// sys mode, the values of the $SYS topics of the broker are summed
+	BrokerAddress string   `keda:"name=brokerAddress, order=triggerMetadata;resolvedEnv, optional"`
+	Username      string   `keda:"name=username,      order=authParams;resolvedEnv, optional"`
+	Password      string   `keda:"name=password,      order=authParams;resolvedEnv, optional"`
+	SysTopics     []string `keda:"name=sysTopics,     order=triggerMetadata, default=$SYS/broker/store/messages/count"`
+
+	// emqx mode, the messages queued and in flight for the clients subscribed to the topic filter are summed
+	ManagementURL   string `keda:"name=managementURL,   order=triggerMetadata;resolvedEnv, optional"`
+	APIKey          string `keda:"name=apiKey,          order=authParams;resolvedEnv, optional"`
+	APISecret       string `keda:"name=apiSecret,       order=authParams;resolvedEnv, optional"`
+	TopicFilter     string `keda:"name=topicFilter,     order=triggerMetadata, optional"`
+	IncludeQueued   bool   `keda:"name=includeQueued,   order=triggerMetadata, default=true"`
+	IncludeInflight bool   `keda:"name=includeInflight, order=triggerMetadata, default=true"`
+
+	TargetMessageCount           int64 `keda:"name=targetMessageCount,           order=triggerMetadata, default=10"`
+	ActivationTargetMessageCount int64 `keda:"name=activationTargetMessageCount, order=triggerMetadata, default=0"`
+	UnsafeSsl                    bool  `keda:"name=unsafeSsl,                    order=triggerMetadata, default=false"`
+
+	timeout      time.Duration
+	triggerIndex int
+}
+
+type emqxSubscriptionsResponse struct {
+	Data []struct {
+		ClientID string `json:"clientid"`
+	} `json:"data"`
+	Meta emqxPageMeta `json:"meta"`
+}
+
+type emqxClientsResponse struct {
+	Data []struct {
+		ClientID    string `json:"clientid"`
+		MqueueLen   int64  `json:"mqueue_len"`
+		InflightCnt int64  `json:"inflight_cnt"`
+	} `json:"data"`
+	Meta emqxPageMeta `json:"meta"`
+}
+
+type emqxPageMeta struct {
+	HasNext bool `json:"hasnext"`
+}
+
+func (m *mqttMetadata) Validate() error {
+	switch m.Mode {
+	case mqttModeSys:
+		if m.BrokerAddress == "" {
+			return errors.New("brokerAddress is required with mode sys")
+		}
+		if len(m.SysTopics) == 0 {
+			return errors.New("sysTopics must contain at least one topic")
+		}
+		for _, topic := range m.SysTopics {
+			if strings.ContainsAny(topic, "+#") {
+				return fmt.Errorf("sysTopics can't contain wildcards, got %q", topic)
+			}
+		}
+	case mqttModeEMQX:
+		if m.ManagementURL == "" || m.TopicFilter == "" {
+			return errors.New("managementURL and topicFilter are required with mode emqx")
+		}
+		if !m.IncludeQueued && !m.IncludeInflight {
+			return errors.New("at least one of includeQueued or includeInflight must be set")
+		}
+	}
+	if m.TargetMessageCount <= 0 {
+		return errors.New("targetMessageCount must be greater than 0")
+	}
+	return nil
+}
+
+// NewMQTTScaler creates a new scaler for the messages held by an MQTT broker, read from its $SYS
+// topics or from the management API of EMQX
+func NewMQTTScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseMQTTMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing mqtt metadata: %w", err)
+	}
+
+	return &mqttScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "mqtt_scaler"),
+	}, nil
+}
+
+func parseMQTTMetadata(config *scalersconfig.ScalerConfig) (*mqttMetadata, error) {
+	meta := &mqttMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing mqtt metadata: %w", err)
+	}
+	meta.ManagementURL = strings.TrimSuffix(meta.ManagementURL, "/")
+	meta.timeout = config.GlobalHTTPTimeout
+	if meta.timeout == 0 {
+		meta.timeout = defaultMQTTTimeout
+	}
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// getMessageCount returns the messages held by the broker for the mode of the scaler
+func (s *mqttScaler) getMessageCount(ctx context.Context) (int64, error) {
+	if s.metadata.Mode == mqttModeEMQX {
+		return s.getEMQXMessageCount(ctx)
+	}
+	return s.getSysMessageCount(ctx)
+}
+
+// getSysMessageCount connects to the broker and sums the values published on the $SYS topics, the brokers
+// publish them as retained messages so they're received as soon as subscribed
+func (s *mqttScaler) getSysMessageCount(ctx context.Context) (int64, error) {
+	conn, err := s.dialBroker(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(s.metadata.timeout)); err != nil {
+		return -1, err
+	}
+	reader := bufio.NewReader(conn)
+
+	if err := writeMqttPacket(conn, mqttConnect, s.connectPacket()); err != nil {
+		return -1, fmt.Errorf("error connecting to the mqtt broker: %w", err)
+	}
+	packetType, body, err := readMqttPacket(reader)
+	if err != nil {
+		return -1, fmt.Errorf("error connecting to the mqtt broker: %w", err)
+	}
+	if packetType != mqttConnack || len(body) != 2 {
+		return -1, fmt.Errorf("unexpected mqtt packet 0x%x while connecting", packetType)
+	}
+	if body[1] != 0 {
+		return -1, fmt.Errorf("mqtt broker refused the connection with return code %d", body[1])
+	}
+	defer func() {
+		_ = writeMqttPacket(conn, mqttDisconnect, nil)
+	}()
+
+	subscribe := []byte{0, 1}
+	for _, topic := range s.metadata.SysTopics {
+		subscribe = appendMqttString(subscribe, topic)
+		subscribe = append(subscribe, 0)
+	}
+	if err := writeMqttPacket(conn, mqttSubscribe, subscribe); err != nil {
+		return -1, fmt.Errorf("error subscribing to the $SYS topics: %w", err)
+	}
+
+	values := map[string]int64{}
+	for len(values) < len(s.metadata.SysTopics) {
+		packetType, body, err := readMqttPacket(reader)
+		if err != nil {
+			var missing []string
+			for _, topic := range s.metadata.SysTopics {
+				if _, ok := values[topic]; !ok {
+					missing = append(missing, topic)
+				}
+			}
+			return -1, fmt.Errorf("error reading the $SYS topics %v: %w", missing, err)
+		}
+		switch packetType & 0xf0 {
+		case mqttSuback & 0xf0:
+			if len(body) < 2 {
+				return -1, errors.New("malformed mqtt suback packet")
+			}
+			for _, code := range body[2:] {
+				if code == 0x80 {
+					return -1, errors.New("mqtt broker refused the subscription to the $SYS topics")
+				}
+			}
+		case mqttPublish:
+			topic, payload, err := parseMqttPublish(packetType, body)
+			if err != nil {
+				return -1, err
+			}
+			if !contains(s.metadata.SysTopics, topic) {
+				continue
+			}
+			value, err := strconv.ParseFloat(strings.TrimSpace(string(payload)), 64)
+			if err != nil {
+				return -1, fmt.Errorf("error parsing the value %q of %s: %w", payload, topic, err)
+			}
+			values[topic] = int64(value)
+		}
+	}
+
+	var count int64
+	for _, value := range values {
+		count += value
+	}
+	return count, nil
+}
+
+// dialBroker opens a connection to the broker, over TLS with the mqtts and ssl schemes
+func (s *mqttScaler) dialBroker(ctx context.Context) (net.Conn, error) {
+	address := s.metadata.BrokerAddress
+	useTLS := false
+	if scheme, host, found := strings.Cut(address, "://"); found {
+		address = host
+		switch scheme {
+		case "mqtt", "tcp":
+		case "mqtts", "ssl", "tls":
+			useTLS = true
+		default:
+			return nil, fmt.Errorf("unsupported scheme %q of brokerAddress", scheme)
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: s.metadata.timeout}
+	if useTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: kedautil.CreateTLSClientConfig(s.metadata.UnsafeSsl)}
+		return tlsDialer.DialContext(ctx, "tcp", address)
+	}
+	return dialer.DialContext(ctx, "tcp", address)
+}
+
+// connectPacket returns the variable header and payload of the CONNECT packet, with a clean session
+// and a random client id so the scalers don't take over the connections of each other
+func (s *mqttScaler) connectPacket() []byte {
+	suffix := make([]byte, 8)
+	_, _ = rand.Read(suffix)
+
+	flags := byte(0x02)
+	if s.metadata.Username != "" {
+		flags |= 0x80
+	}
+	if s.metadata.Password != "" {
+		flags |= 0x40
+	}
+	keepAlive := uint16(s.metadata.timeout / time.Second)
+
+	packet := appendMqttString(nil, "MQTT")
+	packet = append(packet, 4, flags)
+	packet = binary.BigEndian.AppendUint16(packet, keepAlive)
+	packet = appendMqttString(packet, "keda-"+hex.EncodeToString(suffix))
+	if s.metadata.Username != "" {
+		packet = appendMqttString(packet, s.metadata.Username)
+	}
+	if s.metadata.Password != "" {
+		packet = appendMqttString(packet, s.metadata.Password)
+	}
+	return packet
+}
+
+func appendMqttString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+// writeMqttPacket writes a packet with its fixed header
+func writeMqttPacket(w io.Writer, packetType byte, body []byte) error {
+	packet := []byte{packetType}
+	length := len(body)
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		packet = append(packet, digit)
+		if length == 0 {
+			break
+		}
+	}
+	_, err := w.Write(append(packet, body...))
+	return err
+}
+
+// readMqttPacket reads a packet, returning the first byte of its fixed header and the rest of the packet
+func readMqttPacket(r *bufio.Reader) (byte, []byte, error) {
+	packetType, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, nil, errors.New("malformed mqtt remaining length")
+		}
+		digit, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(digit&0x7f) * multiplier
+		multiplier *= 128
+		if digit&0x80 == 0 {
+			break
+		}
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return packetType, body, nil
+}
+
+// parseMqttPublish returns the topic and the payload of a PUBLISH packet
+func parseMqttPublish(packetType byte, body []byte) (string, []byte, error) {
+	if len(body) < 2 {
+		return "", nil, errors.New("malformed mqtt publish packet")
+	}
+	topicLen := int(binary.BigEndian.Uint16(body))
+	offset := 2 + topicLen
+	// the packets with a QoS above 0 have a packet identifier
+	if (packetType>>1)&0x03 > 0 {
+		offset += 2
+	}
+	if len(body) < offset {
+		return "", nil, errors.New("malformed mqtt publish packet")
+	}
+	return string(body[2 : 2+topicLen]), body[offset:], nil
+}
+
+// getEMQXMessageCount sums the messages queued and in flight for the clients subscribed to the topic filter
+func (s *mqttScaler) getEMQXMessageCount(ctx context.Context) (int64, error) {
+	subscribers := map[string]bool{}
+	params := url_pkg.Values{}
+	params.Set("topic", s.metadata.TopicFilter)
+	err := s.getEMQXPages(ctx, "subscriptions", params, func(body []byte) (bool, error) {
+		page := emqxSubscriptionsResponse{}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return false, err
+		}
+		for _, subscription := range page.Data {
+			subscribers[subscription.ClientID] = true
+		}
+		return page.Meta.HasNext, nil
+	})
+	if err != nil {
+		return -1, err
+	}
+	if len(subscribers) == 0 {
+		return 0, nil
+	}
+
+	var count int64
+	err = s.getEMQXPages(ctx, "clients", url_pkg.Values{}, func(body []byte) (bool, error) {
+		page := emqxClientsResponse{}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return false, err
+		}
+		for _, client := range page.Data {
+			if !subscribers[client.ClientID] {
+				continue
+			}
+			if s.metadata.IncludeQueued {
+				count += client.MqueueLen
+			}
+			if s.metadata.IncludeInflight {
+				count += client.InflightCnt
+			}
+		}
+		return page.Meta.HasNext, nil
+	})
+	if err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+// getEMQXPages gets the pages of a list of the EMQX API, until the function returns there is no next page
+func (s *mqttScaler) getEMQXPages(ctx context.Context, path string, params url_pkg.Values, handlePage func(body []byte) (bool, error)) error {
+	params.Set("limit", strconv.Itoa(emqxPageSize))
+	for page, hasNext := 1, true; hasNext; page++ {
+		params.Set("page", strconv.Itoa(page))
+		url := fmt.Sprintf("%s/api/v5/%s?%s", s.metadata.ManagementURL, path, params.Encode())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", "application/json")
+		if s.metadata.APIKey != "" {
+			req.SetBasicAuth(s.metadata.APIKey, s.metadata.APISecret)
+		}
+
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("error requesting the emqx api: %w", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("error reading the emqx api response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("emqx api %s returned status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+		if hasNext, err = handlePage(body); err != nil {
+			return fmt.Errorf("error parsing the emqx api response: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *mqttScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	count, err := s.getMessageCount(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting mqtt message count")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(count))
+	return []external_metrics.ExternalMetricValue{metric}, count > s.metadata.ActivationTargetMessageCount, nil
+}
+
+func (s *mqttScaler) GetMetricSpecForScaling(_ context.Context) []v2.MetricSpec {
+	name := "mqtt-sys"
+	if s.metadata.Mode == mqttModeEMQX {
+		// the wildcards of the filter aren't allowed in the metric names
+		name = fmt.Sprintf("mqtt-%s", mqttWildcardReplacer.Replace(s.metadata.TopicFilter))
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(name)),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetMessageCount),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *mqttScaler) Close(_ context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseMQTTMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type mqttMetricIdentifier struct {
+	metadataTestData *parseMQTTMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testMQTTMetadata = []parseMQTTMetadataTestData{
+	// sys mode with the default topic
+	{map[string]string{"brokerAddress": "mqtt://broker:1883"}, map[string]string{}, false},
+	// sys mode with credentials and topics
+	{map[string]string{"brokerAddress": "broker:1883", "sysTopics": "$SYS/broker/store/messages/count,$SYS/broker/messages/inflight"}, map[string]string{"username": "user", "password": "pass"}, false},
+	// emqx mode
+	{map[string]string{"mode": "emqx", "managementURL": "http://emqx:18083/", "topicFilter": "orders/#"}, map[string]string{"apiKey": "key", "apiSecret": "secret"}, false},
+	// emqx mode with only the queued messages
+	{map[string]string{"mode": "emqx", "managementURL": "http://emqx:18083", "topicFilter": "orders/#", "includeInflight": "false", "targetMessageCount": "50"}, map[string]string{}, false},
+	// unknown mode
+	{map[string]string{"mode": "hivemq", "brokerAddress": "broker:1883"}, map[string]string{}, true},
+	// sys mode without brokerAddress
+	{map[string]string{}, map[string]string{}, true},
+	// sys topic with a wildcard
+	{map[string]string{"brokerAddress": "broker:1883", "sysTopics": "$SYS/broker/#"}, map[string]string{}, true},
+	// emqx mode without topicFilter
+	{map[string]string{"mode": "emqx", "managementURL": "http://emqx:18083"}, map[string]string{}, true},
+	// emqx mode without any count
+	{map[string]string{"mode": "emqx", "managementURL": "http://emqx:18083", "topicFilter": "orders/#", "includeQueued": "false", "includeInflight": "false"}, map[string]string{}, true},
+	// invalid targetMessageCount
+	{map[string]string{"brokerAddress": "broker:1883", "targetMessageCount": "0"}, map[string]string{}, true},
+}
+
+var mqttMetricIdentifiers = []mqttMetricIdentifier{
+	{&testMQTTMetadata[0], 0, "s0-mqtt-sys"},
+	{&testMQTTMetadata[2], 1, "s1-mqtt-orders-all"},
+}
+
+func TestMQTTParseMetadata(t *testing.T) {
+	for i, testData := range testMQTTMetadata {
+		_, err := parseMQTTMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("test case %d: expected success but got error %v", i, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("test case %d: expected error but got success", i)
+		}
+	}
+}
+
+func TestMQTTGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range mqttMetricIdentifiers {
+		meta, err := parseMQTTMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := mqttScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+// startTestMQTTBroker serves a single connection, publishing the retained values of the $SYS topics
+// once subscribed
+func startTestMQTTBroker(t *testing.T, returnCode byte, values map[string]string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+
+		packetType, body, err := readMqttPacket(reader)
+		if err != nil || packetType != mqttConnect {
+			return
+		}
+		assert.Equal(t, "MQTT", string(body[2:6]))
+		assert.Equal(t, byte(4), body[6])
+		if err := writeMqttPacket(conn, mqttConnack, []byte{0, returnCode}); err != nil || returnCode != 0 {
+			return
+		}
+
+		packetType, body, err = readMqttPacket(reader)
+		if err != nil || packetType != mqttSubscribe {
+			return
+		}
+		if err := writeMqttPacket(conn, mqttSuback, append(body[:2:2], 0)); err != nil {
+			return
+		}
+		// the topics not subscribed to are skipped
+		values["$SYS/broker/uptime"] = "100 seconds"
+		for topic, value := range values {
+			publish := appendMqttString(nil, topic)
+			publish = append(publish, value...)
+			if err := writeMqttPacket(conn, mqttPublish|0x01, publish); err != nil {
+				return
+			}
+		}
+		_, _, _ = readMqttPacket(reader)
+	}()
+	return listener.Addr().String()
+}
+
+func TestMQTTGetSysMessageCount(t *testing.T) {
+	testCases := []struct {
+		name          string
+		returnCode    byte
+		values        map[string]string
+		sysTopics     string
+		expectedValue int64
+		expectedError string
+	}{
+		{"default topic", 0, map[string]string{"$SYS/broker/store/messages/count": "42"}, "", 42, ""},
+		{"summed topics", 0, map[string]string{"$SYS/broker/store/messages/count": "42", "$SYS/broker/messages/inflight": "8"}, "$SYS/broker/store/messages/count,$SYS/broker/messages/inflight", 50, ""},
+		{"refused connection", 5, nil, "", 0, "mqtt broker refused the connection with return code 5"},
+		{"invalid value", 0, map[string]string{"$SYS/broker/store/messages/count": "many"}, "", 0, `error parsing the value "many" of $SYS/broker/store/messages/count`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata := map[string]string{"brokerAddress": startTestMQTTBroker(t, tc.returnCode, tc.values), "activationTargetMessageCount": "45"}
+			if tc.sysTopics != "" {
+				metadata["sysTopics"] = tc.sysTopics
+			}
+			meta, err := parseMQTTMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{"username": "user", "password": "pass"}})
+			assert.NoError(t, err)
+			scaler := mqttScaler{metadata: meta, logger: logr.Discard()}
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.expectedValue > 45, isActive)
+		})
+	}
+}
+
+func TestMQTTParsePublishWithPacketIdentifier(t *testing.T) {
+	body := appendMqttString(nil, "$SYS/broker/store/messages/count")
+	body = binary.BigEndian.AppendUint16(body, 7)
+	body = append(body, "12"...)
+
+	topic, payload, err := parseMqttPublish(mqttPublish|0x02, body)
+	assert.NoError(t, err)
+	assert.Equal(t, "$SYS/broker/store/messages/count", topic)
+	assert.Equal(t, "12", string(payload))
+
+	_, _, err = parseMqttPublish(mqttPublish, []byte{0, 9, 'a'})
+	assert.Error(t, err)
+}
+
+func TestMQTTGetEMQXMessageCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "key", user)
+		assert.Equal(t, "secret", password)
+
+		page := r.URL.Query().Get("page")
+		switch r.URL.Path {
+		case "/api/v5/subscriptions":
+			assert.Equal(t, "orders/#", r.URL.Query().Get("topic"))
+			if page == "1" {
+				fmt.Fprint(w, `{"data":[{"clientid":"worker-1","topic":"orders/#"},{"clientid":"worker-2","topic":"orders/#"}],"meta":{"page":1,"hasnext":true}}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":[{"clientid":"worker-3","topic":"orders/#"}],"meta":{"page":2,"hasnext":false}}`)
+		case "/api/v5/clients":
+			if page == "1" {
+				fmt.Fprint(w, `{"data":[{"clientid":"worker-1","mqueue_len":10,"inflight_cnt":2},{"clientid":"dashboard","mqueue_len":100,"inflight_cnt":100}],"meta":{"page":1,"hasnext":true}}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":[{"clientid":"worker-2","mqueue_len":5,"inflight_cnt":1},{"clientid":"worker-3","mqueue_len":0,"inflight_cnt":3}],"meta":{"page":2,"hasnext":false}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedValue int64
+	}{
+		{"queued and inflight messages", map[string]string{}, 21},
+		{"queued messages", map[string]string{"includeInflight": "false"}, 15},
+		{"inflight messages", map[string]string{"includeQueued": "false"}, 6},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["mode"] = "emqx"
+			tc.metadata["managementURL"] = server.URL
+			tc.metadata["topicFilter"] = "orders/#"
+			meta, err := parseMQTTMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{"apiKey": "key", "apiSecret": "secret"}})
+			assert.NoError(t, err)
+			scaler := mqttScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.True(t, isActive)
+		})
+	}
+}
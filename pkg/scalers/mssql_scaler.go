@@ -32,8 +32,13 @@ type mssqlScaler struct {
 	metadata   *mssqlMetadata
 	connection *sql.DB
 	logger     logr.Logger
+	// sharedConnection is the reference on the connection pool shared with the scalers of the same connection string
+	sharedConnection *sharedClientRef
 }
 
+// sharedMSSQLConnections are the connection pools shared by the scalers, by connection string
+var sharedMSSQLConnections = newSharedClients((*sql.DB).Close)
+
 // mssqlMetadata defines metadata used by KEDA to query a Microsoft SQL database
 type mssqlMetadata struct {
 	// The connection string used to connect to the MSSQL database.
@@ -83,16 +88,19 @@ func NewMSSQLScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing mssql metadata: %w", err)
 	}
 
-	conn, err := newMSSQLConnection(meta, logger)
+	conn, sharedConnection, err := sharedMSSQLConnections.acquire(sharedClientKey(getMSSQLConnectionString(meta)), func() (*sql.DB, error) {
+		return newMSSQLConnection(meta, logger)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error establishing mssql connection: %w", err)
 	}
 
 	return &mssqlScaler{
-		metricType: metricType,
-		metadata:   meta,
-		connection: conn,
-		logger:     logger,
+		metricType:       metricType,
+		metadata:         meta,
+		connection:       conn,
+		logger:           logger,
+		sharedConnection: sharedConnection,
 	}, nil
 }
 
@@ -262,6 +270,7 @@ func (s *mssqlScaler) getQueryResult(ctx context.Context) (float64, error) {
 		value = 0
 	case err != nil:
 		s.logger.Error(err, fmt.Sprintf("Could not query mssql database: %s", err))
+		s.sharedConnection.invalidate()
 		return 0, err
 	}
 
@@ -270,7 +279,12 @@ func (s *mssqlScaler) getQueryResult(ctx context.Context) (float64, error) {
 
 // Close closes the mssql database connections
 func (s *mssqlScaler) Close(context.Context) error {
-	err := s.connection.Close()
+	var err error
+	if s.sharedConnection != nil {
+		err = s.sharedConnection.release()
+	} else {
+		err = s.connection.Close()
+	}
 	if err != nil {
 		s.logger.Error(err, "Error closing mssql connection")
 		return err
@@ -2,10 +2,15 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
+	"errors"
 	"fmt"
+	"math"
 	"net"
+	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/go-logr/logr"
 	"github.com/go-sql-driver/mysql"
@@ -16,11 +21,38 @@ import (
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
+const (
+	mySQLModeQuery       = "query"
+	mySQLModeReplicaLag  = "replicaLag"
+	mySQLModeProcesslist = "processlist"
+
+	// mysqlErrParse is ER_PARSE_ERROR, returned for the statements the server doesn't support
+	mysqlErrParse = 1064
+
+	// mySQLProcesslistQuery counts the threads of the processlist but the scaler's own, the empty filters
+	// select all the threads. The threads of the other users are only listed with the PROCESS privilege
+	mySQLProcesslistQuery = `SELECT COUNT(*) FROM information_schema.PROCESSLIST
+WHERE ID <> CONNECTION_ID() AND (? = '' OR USER = ?) AND (? = '' OR DB = ?) AND (? = '' OR COMMAND = ?) AND (? = '' OR INFO LIKE ?)`
+)
+
+// mySQLSecondsBehindColumns are the columns of the replica lag, named after the source in MySQL 8.0.22 and later
+var mySQLSecondsBehindColumns = []string{"Seconds_Behind_Source", "Seconds_Behind_Master"}
+
 type mySQLScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *mySQLMetadata
+	endpoints  []*mySQLEndpoint
+	// active is the index of the endpoint which served the last successful query, the scale loop
+	// and the metrics server query the scaler concurrently
+	active atomic.Int32
+	logger logr.Logger
+}
+
+// mySQLEndpoint is a single database server the scaler can run its query against
+type mySQLEndpoint struct {
+	addr       string
+	readOnly   bool
 	connection *sql.DB
-	logger     logr.Logger
 }
 
 type mySQLMetadata struct {
@@ -30,10 +62,56 @@ type mySQLMetadata struct {
 	Host                 string  `keda:"name=host,                       order=triggerMetadata;authParams, optional"`
 	Port                 string  `keda:"name=port,                       order=triggerMetadata;authParams, optional"`
 	DBName               string  `keda:"name=dbName,                     order=triggerMetadata;authParams, optional"`
-	Query                string  `keda:"name=query,                      order=triggerMetadata"`
+	Mode                 string  `keda:"name=mode,                       order=triggerMetadata, enum=query;replicaLag;processlist, default=query"`
+	Query                string  `keda:"name=query,                      order=triggerMetadata, optional"`
 	QueryValue           float64 `keda:"name=queryValue,                 order=triggerMetadata"`
 	ActivationQueryValue float64 `keda:"name=activationQueryValue,       order=triggerMetadata, default=0"`
 	MetricName           string  `keda:"name=metricName,                 order=triggerMetadata, optional"`
+
+	// TLS
+	TLS         string `keda:"name=tls,         order=triggerMetadata;authParams, enum=enable;disable, default=disable"`
+	CA          string `keda:"name=ca,          order=authParams, optional"`
+	Cert        string `keda:"name=cert,        order=authParams, optional"`
+	Key         string `keda:"name=key,         order=authParams, optional"`
+	KeyPassword string `keda:"name=keyPassword, order=authParams, optional"`
+	UnsafeSsl   bool   `keda:"name=unsafeSsl,   order=triggerMetadata, default=false"`
+
+	// Read replicas
+	ReplicaHosts         []string `keda:"name=replicaHosts,         order=triggerMetadata;authParams, optional"`
+	AllowPrimaryFallback bool     `keda:"name=allowPrimaryFallback, order=triggerMetadata, default=false"`
+
+	// Processlist mode, the threads are counted when they match all the filters set
+	ProcesslistUser    string `keda:"name=processlistUser,    order=triggerMetadata, optional"`
+	ProcesslistDB      string `keda:"name=processlistDB,      order=triggerMetadata, optional"`
+	ProcesslistCommand string `keda:"name=processlistCommand, order=triggerMetadata, optional"`
+	ProcesslistPattern string `keda:"name=processlistPattern, order=triggerMetadata, optional"` // LIKE pattern of the statement
+}
+
+func (m *mySQLMetadata) Validate() error {
+	if m.Mode == mySQLModeQuery && m.Query == "" {
+		return fmt.Errorf("query is required in mode %s", mySQLModeQuery)
+	}
+	if m.Mode != mySQLModeQuery && m.Query != "" {
+		return fmt.Errorf("query can't be used in mode %s", m.Mode)
+	}
+	if m.Mode != mySQLModeProcesslist && (m.ProcesslistUser != "" || m.ProcesslistDB != "" || m.ProcesslistCommand != "" || m.ProcesslistPattern != "") {
+		return fmt.Errorf("the processlist filters can only be used in mode %s", mySQLModeProcesslist)
+	}
+	if m.TLS == stringEnable && (m.Cert == "") != (m.Key == "") {
+		return fmt.Errorf("both cert and key must be provided when using TLS")
+	}
+	if m.TLS != stringEnable && (m.Cert != "" || m.Key != "" || m.CA != "") {
+		return fmt.Errorf("tls must be enabled when ca, cert or key are provided")
+	}
+	for _, host := range m.ReplicaHosts {
+		if strings.TrimSpace(host) == "" {
+			return fmt.Errorf("replicaHosts must not contain empty entries")
+		}
+	}
+	if m.AllowPrimaryFallback && len(m.ReplicaHosts) == 0 {
+		return fmt.Errorf("allowPrimaryFallback requires replicaHosts to be set")
+	}
+	return nil
 }
 
 // NewMySQLScaler creates a new MySQL scaler
@@ -50,16 +128,23 @@ func NewMySQLScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing MySQL metadata: %w", err)
 	}
 
-	conn, err := newMySQLConnection(meta, logger)
+	endpoints, err := newMySQLEndpoints(meta)
 	if err != nil {
-		return nil, fmt.Errorf("error establishing MySQL connection: %w", err)
+		return nil, fmt.Errorf("error building MySQL connections: %w", err)
 	}
-	return &mySQLScaler{
+
+	s := &mySQLScaler{
 		metricType: metricType,
 		metadata:   meta,
-		connection: conn,
+		endpoints:  endpoints,
 		logger:     logger,
-	}, nil
+	}
+
+	if err := s.connect(context.Background()); err != nil {
+		_ = s.Close(context.Background())
+		return nil, fmt.Errorf("error establishing MySQL connection: %w", err)
+	}
+	return s, nil
 }
 
 func parseMySQLMetadata(config *scalersconfig.ScalerConfig) (*mySQLMetadata, error) {
@@ -96,20 +181,86 @@ func metadataToConnectionStr(meta *mySQLMetadata) string {
 	return connStr
 }
 
-// newMySQLConnection creates MySQL db connection
-func newMySQLConnection(meta *mySQLMetadata, logger logr.Logger) (*sql.DB, error) {
-	connStr := metadataToConnectionStr(meta)
-	db, err := sql.Open("mysql", connStr)
+// newMySQLTLSConfig returns the TLS configuration requested by the trigger, or nil if TLS is disabled
+func newMySQLTLSConfig(meta *mySQLMetadata) (*tls.Config, error) {
+	if meta.TLS != stringEnable {
+		return nil, nil
+	}
+	return kedautil.NewTLSConfigWithPassword(meta.Cert, meta.Key, meta.KeyPassword, meta.CA, meta.UnsafeSsl)
+}
+
+// replicaAddr returns host:port for a replica entry, using the primary port (or the MySQL default) when omitted
+func replicaAddr(host, defaultPort string) string {
+	host = strings.TrimSpace(host)
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	if defaultPort == "" {
+		defaultPort = "3306"
+	}
+	return net.JoinHostPort(host, defaultPort)
+}
+
+// newMySQLEndpoints builds the ordered list of servers the scaler queries.
+// When replicas are configured only they are used, unless allowPrimaryFallback
+// is set, in which case the primary is appended as a last resort.
+func newMySQLEndpoints(meta *mySQLMetadata) ([]*mySQLEndpoint, error) {
+	cfg, err := mysql.ParseDSN(metadataToConnectionStr(meta))
 	if err != nil {
-		logger.Error(err, fmt.Sprintf("Found error when opening connection: %s", err))
-		return nil, err
+		return nil, fmt.Errorf("error parsing connection string: %w", err)
 	}
-	err = db.Ping()
+
+	tlsConfig, err := newMySQLTLSConfig(meta)
 	if err != nil {
-		logger.Error(err, fmt.Sprintf("Found error when pinging database: %s", err))
 		return nil, err
 	}
-	return db, nil
+	if tlsConfig != nil {
+		cfg.TLS = tlsConfig
+	}
+
+	var endpoints []*mySQLEndpoint
+	addEndpoint := func(addr string, readOnly bool) error {
+		endpointCfg := cfg.Clone()
+		endpointCfg.Addr = addr
+		connector, err := mysql.NewConnector(endpointCfg)
+		if err != nil {
+			return fmt.Errorf("error creating connector for %s: %w", addr, err)
+		}
+		endpoints = append(endpoints, &mySQLEndpoint{
+			addr:       addr,
+			readOnly:   readOnly,
+			connection: sql.OpenDB(connector),
+		})
+		return nil
+	}
+
+	_, primaryPort, _ := net.SplitHostPort(cfg.Addr)
+	for _, host := range meta.ReplicaHosts {
+		if err := addEndpoint(replicaAddr(host, primaryPort), true); err != nil {
+			return nil, err
+		}
+	}
+	if len(meta.ReplicaHosts) == 0 || meta.AllowPrimaryFallback {
+		if err := addEndpoint(cfg.Addr, false); err != nil {
+			return nil, err
+		}
+	}
+	return endpoints, nil
+}
+
+// connect pings the endpoints in order and selects the first reachable one
+func (s *mySQLScaler) connect(ctx context.Context) error {
+	var lastErr error
+	for i, endpoint := range s.endpoints {
+		if err := endpoint.connection.PingContext(ctx); err != nil {
+			s.logger.Error(err, fmt.Sprintf("Found error when pinging database %s: %s", endpoint.addr, err))
+			lastErr = err
+			continue
+		}
+		s.active.Store(int32(i))
+		return nil
+	}
+	return lastErr
 }
 
 // parseMySQLDbNameFromConnectionStr returns dbname from connection string
@@ -125,23 +276,148 @@ func parseMySQLDbNameFromConnectionStr(connectionString string) string {
 
 // Close disposes of MySQL connections
 func (s *mySQLScaler) Close(context.Context) error {
-	err := s.connection.Close()
-	if err != nil {
-		s.logger.Error(err, "Error closing MySQL connection")
-		return err
+	var closeErr error
+	for _, endpoint := range s.endpoints {
+		if err := endpoint.connection.Close(); err != nil {
+			s.logger.Error(err, fmt.Sprintf("Error closing MySQL connection to %s", endpoint.addr))
+			closeErr = err
+		}
 	}
-	return nil
+	return closeErr
 }
 
-// getQueryResult returns result of the scaler query
+// getQueryResult returns result of the scaler query, failing over to the
+// next endpoint when the currently active one can't serve it
 func (s *mySQLScaler) getQueryResult(ctx context.Context) (float64, error) {
+	var lastErr error
+	active := int(s.active.Load())
+	for i := 0; i < len(s.endpoints); i++ {
+		idx := (active + i) % len(s.endpoints)
+		endpoint := s.endpoints[idx]
+
+		value, err := endpoint.query(ctx, s.getValue)
+		if err != nil {
+			s.logger.Error(err, fmt.Sprintf("Could not query MySQL database %s: %s", endpoint.addr, err))
+			lastErr = err
+			continue
+		}
+		if idx != active {
+			s.logger.V(1).Info(fmt.Sprintf("MySQL query failed over to %s", endpoint.addr))
+			s.active.Store(int32(idx))
+		}
+		return value, nil
+	}
+	return 0, lastErr
+}
+
+// mySQLQueryer runs the queries of the scaler, it's either the connection or a read-only transaction
+type mySQLQueryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// query gets the value of the endpoint, inside a read-only transaction for replicas
+// so a misrouted connection can never write to the database
+func (e *mySQLEndpoint) query(ctx context.Context, getValue func(context.Context, mySQLQueryer) (float64, error)) (float64, error) {
+	if !e.readOnly {
+		return getValue(ctx, e.connection)
+	}
+
+	tx, err := e.connection.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	return getValue(ctx, tx)
+}
+
+// getValue runs the query of the mode of the scaler
+func (s *mySQLScaler) getValue(ctx context.Context, q mySQLQueryer) (float64, error) {
 	var value float64
-	err := s.connection.QueryRowContext(ctx, s.metadata.Query).Scan(&value)
+	switch s.metadata.Mode {
+	case mySQLModeReplicaLag:
+		return getMySQLReplicaLag(ctx, q)
+	case mySQLModeProcesslist:
+		m := s.metadata
+		err := q.QueryRowContext(ctx, mySQLProcesslistQuery,
+			m.ProcesslistUser, m.ProcesslistUser, m.ProcesslistDB, m.ProcesslistDB,
+			m.ProcesslistCommand, m.ProcesslistCommand, m.ProcesslistPattern, m.ProcesslistPattern).Scan(&value)
+		return value, err
+	default:
+		err := q.QueryRowContext(ctx, s.metadata.Query).Scan(&value)
+		return value, err
+	}
+}
+
+// getMySQLReplicaLag returns the seconds the replica is behind its source, the largest of its channels.
+// SHOW SLAVE STATUS is read when SHOW REPLICA STATUS isn't supported, before MySQL 8.0.22 and MariaDB 10.5.1
+func getMySQLReplicaLag(ctx context.Context, q mySQLQueryer) (float64, error) {
+	rows, err := q.QueryContext(ctx, "SHOW REPLICA STATUS")
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if !errors.As(err, &mysqlErr) || mysqlErr.Number != mysqlErrParse {
+			return 0, err
+		}
+		rows, err = q.QueryContext(ctx, "SHOW SLAVE STATUS")
+		if err != nil {
+			return 0, err
+		}
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
 	if err != nil {
-		s.logger.Error(err, fmt.Sprintf("Could not query MySQL database: %s", err))
 		return 0, err
 	}
-	return value, nil
+	var statuses [][]sql.NullString
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return 0, err
+		}
+		statuses = append(statuses, values)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	return mySQLSecondsBehind(columns, statuses)
+}
+
+// mySQLSecondsBehind returns the largest lag of the replication statuses of the channels, the lag is NULL
+// while the replication threads aren't running
+func mySQLSecondsBehind(columns []string, statuses [][]sql.NullString) (float64, error) {
+	if len(statuses) == 0 {
+		return 0, fmt.Errorf("the server isn't a replica")
+	}
+	column := -1
+	for i, name := range columns {
+		for _, secondsBehind := range mySQLSecondsBehindColumns {
+			if strings.EqualFold(name, secondsBehind) {
+				column = i
+			}
+		}
+	}
+	if column == -1 {
+		return 0, fmt.Errorf("the replica status has none of the columns %s", strings.Join(mySQLSecondsBehindColumns, ", "))
+	}
+
+	var lag float64
+	for _, status := range statuses {
+		if !status[column].Valid {
+			return 0, fmt.Errorf("the replication isn't running, %s is NULL", columns[column])
+		}
+		seconds, err := strconv.ParseFloat(status[column].String, 64)
+		if err != nil {
+			return 0, fmt.Errorf("error parsing %s: %w", columns[column], err)
+		}
+		lag = math.Max(lag, seconds)
+	}
+	return lag, nil
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
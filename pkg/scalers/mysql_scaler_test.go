@@ -1,6 +1,7 @@
 package scalers
 
 import (
+	"database/sql"
 	"testing"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -75,6 +76,90 @@ var testMySQLMetadata = []parseMySQLMetadataTestData{
 		resolvedEnv: map[string]string{},
 		raisesError: true,
 	},
+	// TLS with client certificate
+	{
+		metadata:    map[string]string{"query": "query", "queryValue": "12", "tls": "enable"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname", "ca": "caaa", "cert": "ceert", "key": "keey"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: false,
+	},
+	// TLS with cert but no key
+	{
+		metadata:    map[string]string{"query": "query", "queryValue": "12", "tls": "enable"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname", "cert": "ceert"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
+	// cert without TLS enabled
+	{
+		metadata:    map[string]string{"query": "query", "queryValue": "12"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname", "cert": "ceert", "key": "keey"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
+	// invalid tls value
+	{
+		metadata:    map[string]string{"query": "query", "queryValue": "12", "tls": "yes"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
+	// replica hosts
+	{
+		metadata:    map[string]string{"query": "query", "queryValue": "12", "replicaHosts": "replica-1:3307, replica-2", "allowPrimaryFallback": "true"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: false,
+	},
+	// allowPrimaryFallback without replica hosts
+	{
+		metadata:    map[string]string{"query": "query", "queryValue": "12", "allowPrimaryFallback": "true"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
+	// replicaLag mode
+	{
+		metadata:    map[string]string{"mode": "replicaLag", "queryValue": "30", "replicaHosts": "replica-1"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: false,
+	},
+	// processlist mode
+	{
+		metadata:    map[string]string{"mode": "processlist", "queryValue": "10", "processlistUser": "app", "processlistCommand": "Query", "processlistPattern": "SELECT%FROM orders%"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: false,
+	},
+	// query mode without query
+	{
+		metadata:    map[string]string{"mode": "query", "queryValue": "12"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
+	// replicaLag mode with a query
+	{
+		metadata:    map[string]string{"mode": "replicaLag", "query": "query", "queryValue": "30"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
+	// processlist filters in query mode
+	{
+		metadata:    map[string]string{"query": "query", "queryValue": "12", "processlistUser": "app"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
+	// invalid mode
+	{
+		metadata:    map[string]string{"mode": "lag", "queryValue": "30"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
 }
 
 var mySQLMetricIdentifiers = []mySQLMetricIdentifier{
@@ -126,3 +211,92 @@ func TestMySQLGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestMySQLEndpoints(t *testing.T) {
+	testCases := []struct {
+		name          string
+		metadata      map[string]string
+		expectedAddrs []string
+	}{
+		{
+			name:          "primary only",
+			metadata:      map[string]string{"query": "query", "queryValue": "12", "host": "primary", "port": "3306", "username": "user", "dbName": "db"},
+			expectedAddrs: []string{"primary:3306"},
+		},
+		{
+			name:          "replicas only",
+			metadata:      map[string]string{"query": "query", "queryValue": "12", "host": "primary", "port": "3310", "username": "user", "dbName": "db", "replicaHosts": "replica-1:3307,replica-2"},
+			expectedAddrs: []string{"replica-1:3307", "replica-2:3310"},
+		},
+		{
+			name:          "replicas with primary fallback",
+			metadata:      map[string]string{"query": "query", "queryValue": "12", "connectionStringFromEnv": "MYSQL_CONN_STR", "replicaHosts": "replica-1", "allowPrimaryFallback": "true"},
+			expectedAddrs: []string{"replica-1:3306", "my.mysql.dev:3306"},
+		},
+	}
+
+	resolvedEnv := map[string]string{"MYSQL_CONN_STR": "user:pass@tcp(my.mysql.dev:3306)/stats_db"}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta, err := parseMySQLMetadata(&scalersconfig.ScalerConfig{ResolvedEnv: resolvedEnv, TriggerMetadata: testCase.metadata, AuthParams: map[string]string{}})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			endpoints, err := newMySQLEndpoints(meta)
+			if err != nil {
+				t.Fatal("Could not build endpoints:", err)
+			}
+			if len(endpoints) != len(testCase.expectedAddrs) {
+				t.Fatalf("Expected %d endpoints but got %d", len(testCase.expectedAddrs), len(endpoints))
+			}
+			for i, endpoint := range endpoints {
+				if endpoint.addr != testCase.expectedAddrs[i] {
+					t.Errorf("Expected endpoint %d to be %s but got %s", i, testCase.expectedAddrs[i], endpoint.addr)
+				}
+				expectedReadOnly := i < len(meta.ReplicaHosts)
+				if endpoint.readOnly != expectedReadOnly {
+					t.Errorf("Expected endpoint %s readOnly=%v", endpoint.addr, expectedReadOnly)
+				}
+				_ = endpoint.connection.Close()
+			}
+		})
+	}
+}
+
+func TestMySQLSecondsBehind(t *testing.T) {
+	lag := func(value string) sql.NullString {
+		return sql.NullString{String: value, Valid: true}
+	}
+	testCases := []struct {
+		name          string
+		columns       []string
+		statuses      [][]sql.NullString
+		expectedValue float64
+		expectedError bool
+	}{
+		{"replica status", []string{"Replica_IO_State", "Seconds_Behind_Source"}, [][]sql.NullString{{lag("Waiting for source"), lag("12")}}, 12, false},
+		{"slave status", []string{"Slave_IO_State", "Seconds_Behind_Master"}, [][]sql.NullString{{lag("Waiting for master"), lag("3")}}, 3, false},
+		{"largest lag of the channels", []string{"Channel_Name", "Seconds_Behind_Source"}, [][]sql.NullString{{lag("a"), lag("4")}, {lag("b"), lag("40")}}, 40, false},
+		{"replication not running", []string{"Seconds_Behind_Source"}, [][]sql.NullString{{{}}}, 0, true},
+		{"not a replica", []string{"Seconds_Behind_Source"}, nil, 0, true},
+		{"missing column", []string{"Replica_IO_State"}, [][]sql.NullString{{lag("Waiting for source")}}, 0, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			value, err := mySQLSecondsBehind(testCase.columns, testCase.statuses)
+			if testCase.expectedError {
+				if err == nil {
+					t.Error("Expected error but got success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("Expected success but got error", err)
+			}
+			if value != testCase.expectedValue {
+				t.Errorf("Expected %v but got %v", testCase.expectedValue, value)
+			}
+		})
+	}
+}
@@ -25,6 +25,16 @@ const (
 	natsHTTPProtocol                = "http"
 	natsHTTPSProtocol               = "https"
 	jetStreamLagThresholdMetricName = "lagThreshold"
+
+	// jetStreamModeConsumer scales on the lag of a consumer of the stream
+	jetStreamModeConsumer = "consumer"
+	// jetStreamModeKeyValue scales on the number of keys in a KeyValue bucket
+	jetStreamModeKeyValue = "keyValue"
+	// jetStreamModeObjectStore scales on the objects pending for a consumer of an ObjectStore bucket
+	jetStreamModeObjectStore = "objectStore"
+
+	jetStreamKeyValueStreamPrefix    = "KV_"
+	jetStreamObjectStoreStreamPrefix = "OBJ_"
 )
 
 type natsJetStreamScaler struct {
@@ -37,6 +47,8 @@ type natsJetStreamScaler struct {
 
 type natsJetStreamMetadata struct {
 	account                string
+	mode                   string
+	bucket                 string
 	stream                 string
 	consumer               string
 	consumerLeader         string
@@ -77,6 +89,11 @@ type streamDetail struct {
 	Config    streamConfig     `json:"config"`
 	State     streamState      `json:"state"`
 	Consumers []consumerDetail `json:"consumer_detail"`
+	Cluster   streamCluster    `json:"cluster"`
+}
+
+type streamCluster struct {
+	Leader string `json:"leader"`
 }
 
 type streamConfig struct {
@@ -86,6 +103,7 @@ type streamConfig struct {
 type streamState struct {
 	MsgCount     int64 `json:"messages"`
 	LastSequence int64 `json:"last_seq"`
+	NumSubjects  int64 `json:"num_subjects"`
 }
 
 type consumerDetail struct {
@@ -142,15 +160,38 @@ func parseNATSJetStreamMetadata(config *scalersconfig.ScalerConfig) (natsJetStre
 	}
 	meta.account = account
 
-	if config.TriggerMetadata["stream"] == "" {
-		return meta, errors.New("no stream name given")
+	meta.mode = jetStreamModeConsumer
+	if val, ok := config.TriggerMetadata["mode"]; ok && val != "" {
+		meta.mode = val
+	}
+
+	switch meta.mode {
+	case jetStreamModeConsumer:
+		if config.TriggerMetadata["stream"] == "" {
+			return meta, errors.New("no stream name given")
+		}
+		meta.stream = config.TriggerMetadata["stream"]
+	case jetStreamModeKeyValue, jetStreamModeObjectStore:
+		// the buckets are stored in streams named after them
+		if config.TriggerMetadata["bucket"] == "" {
+			return meta, fmt.Errorf("no bucket name given for mode %s", meta.mode)
+		}
+		meta.bucket = config.TriggerMetadata["bucket"]
+		meta.stream = jetStreamKeyValueStreamPrefix + meta.bucket
+		if meta.mode == jetStreamModeObjectStore {
+			meta.stream = jetStreamObjectStoreStreamPrefix + meta.bucket
+		}
+	default:
+		return meta, fmt.Errorf("mode must be one of %s, %s or %s, got %s", jetStreamModeConsumer, jetStreamModeKeyValue, jetStreamModeObjectStore, meta.mode)
 	}
-	meta.stream = config.TriggerMetadata["stream"]
 
-	if config.TriggerMetadata["consumer"] == "" {
-		return meta, errors.New("no consumer name given")
+	// the size of a KeyValue bucket is read from the stream, the other modes scale on the lag of a consumer
+	if meta.mode != jetStreamModeKeyValue {
+		if config.TriggerMetadata["consumer"] == "" {
+			return meta, errors.New("no consumer name given")
+		}
+		meta.consumer = config.TriggerMetadata["consumer"]
 	}
-	meta.consumer = config.TriggerMetadata["consumer"]
 
 	meta.lagThreshold = defaultJetStreamLagThreshold
 
@@ -281,20 +322,19 @@ func (s *natsJetStreamScaler) getNATSJetstreamMonitoringData(ctx context.Context
 				if jetStreamAccount.Name == s.metadata.account {
 					for _, stream := range jetStreamAccount.Streams {
 						if stream.Name == s.metadata.stream {
-							for _, consumer := range stream.Consumers {
-								if consumer.Name == s.metadata.consumer {
-									// this node is the consumer leader
-									if node == consumer.Cluster.Leader {
-										s.setNATSJetStreamMonitoringData(jetStreamAccountResp, natsJetStreamMonitoringNodeURL)
-										return nil
-									}
-								}
+							// this node is the consumer leader
+							if leader, found := s.getLeader(stream); found && node == leader {
+								s.setNATSJetStreamMonitoringData(jetStreamAccountResp, natsJetStreamMonitoringNodeURL)
+								return nil
 							}
 						}
 					}
 				}
 			}
 		}
+		if s.metadata.mode == jetStreamModeKeyValue {
+			return fmt.Errorf("leader node not found for stream %s", s.metadata.stream)
+		}
 		return fmt.Errorf("leader node not found for consumer %s", s.metadata.consumer)
 	}
 	return nil
@@ -310,14 +350,12 @@ func (s *natsJetStreamScaler) setNATSJetStreamMonitoringData(jetStreamAccountRes
 				if stream.Name == s.metadata.stream {
 					s.stream = stream
 
-					for _, consumer := range stream.Consumers {
-						if consumer.Name == s.metadata.consumer {
-							s.metadata.consumerLeader = consumer.Cluster.Leader
-							if leaderURL != "" {
-								s.metadata.monitoringLeaderURL = leaderURL
-							}
-							return true
+					if leader, found := s.getLeader(stream); found {
+						s.metadata.consumerLeader = leader
+						if leaderURL != "" {
+							s.metadata.monitoringLeaderURL = leaderURL
 						}
+						return true
 					}
 				}
 			}
@@ -326,6 +364,19 @@ func (s *natsJetStreamScaler) setNATSJetStreamMonitoringData(jetStreamAccountRes
 	return false
 }
 
+// getLeader returns the leader of the consumer, or of the stream in the keyValue mode which doesn't use a consumer
+func (s *natsJetStreamScaler) getLeader(stream *streamDetail) (string, bool) {
+	if s.metadata.mode == jetStreamModeKeyValue {
+		return stream.Cluster.Leader, true
+	}
+	for _, consumer := range stream.Consumers {
+		if consumer.Name == s.metadata.consumer {
+			return consumer.Cluster.Leader, true
+		}
+	}
+	return "", false
+}
+
 func (s *natsJetStreamScaler) invalidateNATSJetStreamCachedMonitoringData() {
 	s.metadata.consumerLeader = ""
 	s.metadata.monitoringLeaderURL = ""
@@ -427,6 +478,11 @@ func (s *natsJetStreamScaler) getNATSJetStreamMonitoringNodeURLByNode(node strin
 }
 
 func (s *natsJetStreamScaler) getMaxMsgLag() int64 {
+	// each key of a KeyValue bucket is stored in its own subject of the stream
+	if s.metadata.mode == jetStreamModeKeyValue {
+		return s.stream.State.NumSubjects
+	}
+
 	consumerName := s.metadata.consumer
 
 	for _, consumer := range s.stream.Consumers {
@@ -69,11 +69,23 @@ var testNATSJetStreamMetadata = []parseNATSJetStreamMetadataTestData{
 	{map[string]string{"stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"account": "$G", "natsServerMonitoringEndpoint": "nats.nats:8222"}, false},
 	// Misconfigured account
 	{map[string]string{"stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, true},
+	// All good keyValue without consumer
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "mode": "keyValue", "bucket": "config"}, map[string]string{}, false},
+	// Missing bucket name, should fail
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "mode": "keyValue", "stream": "mystream"}, map[string]string{}, true},
+	// All good objectStore
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "mode": "objectStore", "bucket": "files", "consumer": "processor"}, map[string]string{}, false},
+	// Missing consumer name for objectStore, should fail
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "mode": "objectStore", "bucket": "files"}, map[string]string{}, true},
+	// Misconfigured mode
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "mode": "stream", "stream": "mystream", "consumer": "pull_consumer"}, map[string]string{}, true},
 }
 
 var natsJetStreamMetricIdentifiers = []natsJetStreamMetricIdentifier{
 	{&testNATSJetStreamMetadata[0], 0, "s0-nats-jetstream-mystream"},
 	{&testNATSJetStreamMetadata[0], 1, "s1-nats-jetstream-mystream"},
+	{&testNATSJetStreamMetadata[16], 0, "s0-nats-jetstream-KV_config"},
+	{&testNATSJetStreamMetadata[18], 0, "s0-nats-jetstream-OBJ_files"},
 }
 
 func TestNATSJetStreamParseMetadata(t *testing.T) {
@@ -122,6 +134,10 @@ func TestGetNATSJetStreamEndpointHTTP(t *testing.T) {
 
 var testNATSJetStreamGoodMetadata = map[string]string{"natsServerMonitoringEndpoint": "localhost:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "useHttps": "false", "activationLagThreshold": "10"}
 
+var testNATSJetStreamKeyValueMetadata = map[string]string{"natsServerMonitoringEndpoint": "localhost:8222", "account": "$G", "mode": "keyValue", "bucket": "config", "activationLagThreshold": "10"}
+
+var testNATSJetStreamObjectStoreMetadata = map[string]string{"natsServerMonitoringEndpoint": "localhost:8222", "account": "$G", "mode": "objectStore", "bucket": "files", "consumer": "processor", "activationLagThreshold": "10"}
+
 var testNATSJetStreamMockResponses = []parseNATSJetStreamMockResponsesTestData{
 	{
 		"All Good - no messages waiting (not active)",
@@ -221,6 +237,69 @@ var testNATSJetStreamMockResponses = []parseNATSJetStreamMockResponsesTestData{
 				Streams: []*streamDetail{{Name: "mystream"}},
 			}},
 		}, false, true},
+	{
+		"All Good - keys in the bucket (keyValue)",
+		&natsJetStreamMetricIdentifier{
+			&parseNATSJetStreamMetadataTestData{
+				testNATSJetStreamKeyValueMetadata, map[string]string{}, false},
+			0, "s0-nats-jetstream-KV_config",
+		},
+		&jetStreamEndpointResponse{
+			Accounts: []accountDetail{{Name: "$G",
+				Streams: []*streamDetail{{Name: "KV_config", State: streamState{MsgCount: 40, LastSequence: 60, NumSubjects: 20}}},
+			}},
+		}, true, false},
+	{
+		"All Good - keys in the bucket (keyValue clustered)",
+		&natsJetStreamMetricIdentifier{
+			&parseNATSJetStreamMetadataTestData{
+				testNATSJetStreamKeyValueMetadata, map[string]string{}, false},
+			0, "s0-nats-jetstream-KV_config",
+		},
+		&jetStreamEndpointResponse{
+			MetaCluster: metaCluster{ClusterSize: 3},
+			Accounts: []accountDetail{{Name: "$G",
+				Streams: []*streamDetail{{Name: "KV_config", State: streamState{NumSubjects: 20}, Cluster: streamCluster{Leader: "leader"}}},
+			}},
+		}, true, false},
+	{
+		"Not Active - few keys in the bucket (keyValue)",
+		&natsJetStreamMetricIdentifier{
+			&parseNATSJetStreamMetadataTestData{
+				testNATSJetStreamKeyValueMetadata, map[string]string{}, false},
+			0, "s0-nats-jetstream-KV_config",
+		},
+		&jetStreamEndpointResponse{
+			Accounts: []accountDetail{{Name: "$G",
+				Streams: []*streamDetail{{Name: "KV_config", State: streamState{MsgCount: 40, LastSequence: 60, NumSubjects: 5}}},
+			}},
+		}, false, false},
+	{
+		"All Good - objects pending (objectStore)",
+		&natsJetStreamMetricIdentifier{
+			&parseNATSJetStreamMetadataTestData{
+				testNATSJetStreamObjectStoreMetadata, map[string]string{}, false},
+			0, "s0-nats-jetstream-OBJ_files",
+		},
+		&jetStreamEndpointResponse{
+			Accounts: []accountDetail{{Name: "$G",
+				Streams: []*streamDetail{{Name: "OBJ_files",
+					Consumers: []consumerDetail{{Name: "processor", NumPending: 50}},
+				}},
+			}},
+		}, true, false},
+	{
+		"Fail - bucket not found (keyValue)",
+		&natsJetStreamMetricIdentifier{
+			&parseNATSJetStreamMetadataTestData{
+				testNATSJetStreamKeyValueMetadata, map[string]string{}, false},
+			0, "s0-nats-jetstream-KV_config",
+		},
+		&jetStreamEndpointResponse{
+			Accounts: []accountDetail{{Name: "$G",
+				Streams: []*streamDetail{{Name: "OBJ_config", State: streamState{NumSubjects: 20}}},
+			}},
+		}, false, true},
 }
 
 var testNATSJetStreamServerMockResponses = map[string][]byte{
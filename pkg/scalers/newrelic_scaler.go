@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/newrelic/newrelic-client-go/newrelic"
 	"github.com/newrelic/newrelic-client-go/pkg/nrdb"
+	"github.com/newrelic/newrelic-client-go/pkg/region"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
@@ -23,9 +25,22 @@ const (
 	nrql              = "nrql"
 	threshold         = "threshold"
 	noDataError       = "noDataError"
+	facetParameter    = "facet"
+	resultColumn      = "resultColumn"
+	nerdGraphURL      = "nerdGraphURL"
 	scalerName        = "new-relic"
 )
 
+// newrelicTimeseriesEnd is the column holding the end of a TIMESERIES bucket
+const newrelicTimeseriesEnd = "endTimeSeconds"
+
+// newrelicReservedColumns are returned alongside the query values and never feed the metric
+var newrelicReservedColumns = map[string]bool{
+	"facet":               true,
+	"beginTimeSeconds":    true,
+	newrelicTimeseriesEnd: true,
+}
+
 type newrelicScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *newrelicMetadata
@@ -39,6 +54,9 @@ type newrelicMetadata struct {
 	queryKey            string
 	noDataError         bool
 	nrql                string
+	facet               string
+	resultColumn        string
+	nerdGraphURL        string
 	threshold           float64
 	activationThreshold float64
 	triggerIndex        int
@@ -57,9 +75,14 @@ func NewNewRelicScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing %s metadata: %w", scalerName, err)
 	}
 
-	nrClient, err := newrelic.New(
+	opts := []newrelic.ConfigOption{
 		newrelic.ConfigPersonalAPIKey(meta.queryKey),
-		newrelic.ConfigRegion(meta.region))
+		newrelic.ConfigRegion(meta.region),
+	}
+	if meta.nerdGraphURL != "" {
+		opts = append(opts, newrelic.ConfigNerdGraphBaseURL(meta.nerdGraphURL))
+	}
+	nrClient, err := newrelic.New(opts...)
 
 	if err != nil {
 		log.Fatal("error initializing client:", err)
@@ -103,12 +126,29 @@ func parseNewRelicMetadata(config *scalersconfig.ScalerConfig, logger logr.Logge
 	}
 	meta.queryKey = queryKey
 
-	region, err := GetFromAuthOrMeta(config, regionParameter)
+	regionName, err := GetFromAuthOrMeta(config, regionParameter)
 	if err != nil {
-		region = "US"
+		regionName = "US"
 		logger.Info("Using default 'US' region")
 	}
-	meta.region = region
+	parsedRegion, err := region.Parse(regionName)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", regionParameter, err)
+	}
+	meta.region = parsedRegion.String()
+
+	// nerdGraphURL overrides the NerdGraph endpoint of the region, e.g. to go through a proxy
+	if val, err := GetFromAuthOrMeta(config, nerdGraphURL); err == nil {
+		meta.nerdGraphURL = val
+	}
+
+	if val, ok := config.TriggerMetadata[facetParameter]; ok && val != "" {
+		meta.facet = val
+	}
+
+	if val, ok := config.TriggerMetadata[resultColumn]; ok && val != "" {
+		meta.resultColumn = val
+	}
 
 	if val, ok := config.TriggerMetadata[threshold]; ok && val != "" {
 		t, err := strconv.ParseFloat(val, 64)
@@ -159,23 +199,77 @@ func (s *newrelicScaler) executeNewRelicQuery(ctx context.Context) (float64, err
 		return 0, fmt.Errorf("error running NRQL %s (%s)", s.metadata.nrql, err.Error())
 	}
 	// Check for empty results set, as New Relic lib does not report these as errors
-	if len(resp.Results) == 0 {
+	val, found, err := getNewRelicResultValue(resp.Results, s.metadata.facet, s.metadata.resultColumn)
+	if err != nil {
+		return 0, fmt.Errorf("error reading NRQL %s results: %w", s.metadata.nrql, err)
+	}
+	if !found {
 		if s.metadata.noDataError {
 			return 0, fmt.Errorf("query return no results %s", s.metadata.nrql)
 		}
 		return 0, nil
 	}
-	// Only use the first result from the query, as the query should not be multi row
-	for _, v := range resp.Results[0] {
+	return val, nil
+}
+
+// getNewRelicResultValue picks the row matching the facet (if any), the latest bucket
+// of TIMESERIES queries, and returns the value of the result column on that row.
+// Without result column, the first numeric non reserved column is used.
+func getNewRelicResultValue(results []nrdb.NRDBResult, facet, column string) (float64, bool, error) {
+	var row nrdb.NRDBResult
+	latestEnd := -1.0
+	for _, r := range results {
+		if facet != "" && !newrelicFacetMatches(r["facet"], facet) {
+			continue
+		}
+		end, isTimeseries := r[newrelicTimeseriesEnd].(float64)
+		if row == nil || (isTimeseries && end > latestEnd) {
+			row = r
+			latestEnd = end
+		}
+	}
+	if row == nil {
+		return 0, false, nil
+	}
+
+	if column != "" {
+		v, ok := row[column]
+		if !ok || v == nil {
+			return 0, false, nil
+		}
 		val, ok := v.(float64)
-		if ok {
-			return val, nil
+		if !ok {
+			return 0, false, fmt.Errorf("column %s is not a number", column)
 		}
+		return val, true, nil
 	}
-	if s.metadata.noDataError {
-		return 0, fmt.Errorf("query return no results %s", s.metadata.nrql)
+
+	for k, v := range row {
+		if newrelicReservedColumns[k] {
+			continue
+		}
+		if val, ok := v.(float64); ok {
+			return val, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// newrelicFacetMatches compares the facet of a row with the expected facet,
+// faceting by several attributes returns a list that is compared comma separated
+func newrelicFacetMatches(value interface{}, facet string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == facet
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, p := range v {
+			parts = append(parts, fmt.Sprint(p))
+		}
+		return strings.Join(parts, ",") == facet
+	default:
+		return false
 	}
-	return 0, nil
 }
 
 func (s *newrelicScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
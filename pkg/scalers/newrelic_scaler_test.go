@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/newrelic/newrelic-client-go/pkg/nrdb"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
@@ -53,6 +54,14 @@ var testNewRelicMetadata = []parseNewRelicMetadataTestData{
 	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "noDataError": "false", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
 	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "noDataError": "0", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
 	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "noDataError": "1", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
+	// invalid region
+	{map[string]string{"account": "0", "region": "APAC", "threshold": "100", "queryKey": "somekey", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, true},
+	// lowercase EU region
+	{map[string]string{"account": "0", "region": "eu", "threshold": "100", "queryKey": "somekey", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
+	// faceted query with facet and result column
+	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "facet": "coredns", "resultColumn": "result", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample FACET containerName TIMESERIES"}, map[string]string{}, false},
+	// custom NerdGraph endpoint
+	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "nrql": "SELECT count(*) FROM Transaction"}, map[string]string{"nerdGraphURL": "https://nerdgraph.example.com/graphql"}, false},
 }
 
 var newrelicMetricIdentifiers = []newrelicMetricIdentifier{
@@ -91,3 +100,55 @@ func TestNewRelicGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestNewRelicGetResultValue(t *testing.T) {
+	faceted := []nrdb.NRDBResult{
+		{"facet": "web", "appName": "web", "count": float64(10)},
+		{"facet": "worker", "appName": "worker", "count": float64(3)},
+	}
+	timeseries := []nrdb.NRDBResult{
+		{"beginTimeSeconds": float64(60), "endTimeSeconds": float64(120), "result": float64(2)},
+		{"beginTimeSeconds": float64(120), "endTimeSeconds": float64(180), "result": float64(5)},
+		{"beginTimeSeconds": float64(0), "endTimeSeconds": float64(60), "result": float64(1)},
+	}
+	multiFacet := []nrdb.NRDBResult{
+		{"facet": []interface{}{"web", "prod"}, "count": float64(4)},
+		{"facet": []interface{}{"web", "dev"}, "count": float64(8)},
+	}
+
+	testCases := []struct {
+		name     string
+		results  []nrdb.NRDBResult
+		facet    string
+		column   string
+		expected float64
+		found    bool
+		isError  bool
+	}{
+		{"no results", nil, "", "", 0, false, false},
+		{"single row", []nrdb.NRDBResult{{"result": float64(7)}}, "", "", 7, true, false},
+		{"selected column", []nrdb.NRDBResult{{"a": float64(1), "b": float64(2)}}, "", "b", 2, true, false},
+		{"missing column", []nrdb.NRDBResult{{"a": float64(1)}}, "", "b", 0, false, false},
+		{"non numeric column", []nrdb.NRDBResult{{"a": "text"}}, "", "a", 0, false, true},
+		{"selected facet", faceted, "worker", "count", 3, true, false},
+		{"missing facet", faceted, "batch", "count", 0, false, false},
+		{"first facet by default", faceted, "", "count", 10, true, false},
+		{"latest timeseries bucket", timeseries, "", "", 5, true, false},
+		{"multi attribute facet", multiFacet, "web,dev", "count", 8, true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			val, found, err := getNewRelicResultValue(tc.results, tc.facet, tc.column)
+			if tc.isError && err == nil {
+				t.Error("Expected error but got success")
+			}
+			if !tc.isError && err != nil {
+				t.Error("Expected success but got error", err)
+			}
+			if found != tc.found || val != tc.expected {
+				t.Errorf("Expected %v (found %v), got %v (found %v)", tc.expected, tc.found, val, found)
+			}
+		})
+	}
+}
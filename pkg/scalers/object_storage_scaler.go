@@ -0,0 +1,470 @@
+package scalers
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/go-logr/logr"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
+	"github.com/kedacore/keda/v2/pkg/scalers/azure"
+	"github.com/kedacore/keda/v2/pkg/scalers/gcp"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	objectStorageProviderS3        = "s3"
+	objectStorageProviderGCS       = "gcs"
+	objectStorageProviderAzureBlob = "azureBlob"
+
+	objectStorageMetricObjectCount     = "objectCount"
+	objectStorageMetricOldestObjectAge = "oldestObjectAge"
+
+	s3SigningName = "s3"
+	// s3EmptyPayloadHash is the sha256 of the empty body of the requests listing the objects
+	s3EmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	// s3PageSize is the largest page of objects returned by ListObjectsV2
+	s3PageSize = 1000
+)
+
+type objectStorageScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *objectStorageMetadata
+	client     objectStorageClient
+	logger     logr.Logger
+}
+
+type objectStorageMetadata struct {
+	awsAuthorization    awsutils.AuthorizationMetadata
+	gcpAuthorization    *gcp.AuthorizationMetadata
+	azureEndpointSuffix string
+	podIdentity         kedav1alpha1.AuthPodIdentity
+
+	triggerIndex int
+
+	Provider string `keda:"name=provider, order=triggerMetadata, enum=s3;gcs;azureBlob"`
+	// Bucket is the bucket, or the container of Azure Blob, listed under the Prefix
+	Bucket string `keda:"name=bucket, order=triggerMetadata"`
+	Prefix string `keda:"name=prefix, order=triggerMetadata, optional"`
+
+	// Metric is either the number of objects or the seconds since the oldest of them was last modified,
+	// the objects are counted up to MaxObjectsToScan
+	Metric                string  `keda:"name=metric,                order=triggerMetadata, enum=objectCount;oldestObjectAge, default=objectCount"`
+	MaxObjectsToScan      int64   `keda:"name=maxObjectsToScan,      order=triggerMetadata, default=10000"`
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	// S3
+	AwsRegion   string `keda:"name=awsRegion,   order=triggerMetadata, optional"`
+	AwsEndpoint string `keda:"name=awsEndpoint, order=triggerMetadata, optional"`
+
+	// Azure Blob
+	Connection  string `keda:"name=connection,  order=authParams;resolvedEnv, optional"`
+	AccountName string `keda:"name=accountName, order=triggerMetadata;authParams, optional"`
+}
+
+func (m *objectStorageMetadata) Validate() error {
+	if m.Provider == objectStorageProviderS3 && m.AwsRegion == "" {
+		return errors.New("awsRegion is required for the s3 provider")
+	}
+	if m.Provider != objectStorageProviderS3 && (m.AwsRegion != "" || m.AwsEndpoint != "") {
+		return errors.New("awsRegion and awsEndpoint can only be used with the s3 provider")
+	}
+	if m.Provider != objectStorageProviderAzureBlob && (m.Connection != "" || m.AccountName != "") {
+		return errors.New("connection and accountName can only be used with the azureBlob provider")
+	}
+	if m.MaxObjectsToScan <= 0 {
+		return errors.New("maxObjectsToScan must be greater than 0")
+	}
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	return nil
+}
+
+// objectStorageObject is an object listed under the prefix
+type objectStorageObject struct {
+	key          string
+	lastModified time.Time
+}
+
+// objectStorageClient lists the objects of the bucket of a provider
+type objectStorageClient interface {
+	// listObjects calls fn with the objects under the prefix, until it returns false
+	listObjects(ctx context.Context, prefix string, fn func(objectStorageObject) bool) error
+	close() error
+}
+
+// NewObjectStorageScaler creates a new scaler for the number of objects under a prefix of a bucket of S3, GCS or
+// Azure Blob, or the age of the oldest of them, so the pipelines consuming files can scale with their backlog
+func NewObjectStorageScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	logger := InitializeLogger(config, "object_storage_scaler")
+
+	meta, err := parseObjectStorageMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing object storage metadata: %w", err)
+	}
+
+	var client objectStorageClient
+	switch meta.Provider {
+	case objectStorageProviderS3:
+		client, err = newS3ObjectStorageClient(ctx, meta, config.GlobalHTTPTimeout)
+	case objectStorageProviderGCS:
+		client, err = newGCSObjectStorageClient(ctx, meta)
+	case objectStorageProviderAzureBlob:
+		client, err = newAzureBlobObjectStorageClient(logger, meta, config.GlobalHTTPTimeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s client: %w", meta.Provider, err)
+	}
+
+	return &objectStorageScaler{
+		metricType: metricType,
+		metadata:   meta,
+		client:     client,
+		logger:     logger,
+	}, nil
+}
+
+func parseObjectStorageMetadata(config *scalersconfig.ScalerConfig) (*objectStorageMetadata, error) {
+	meta := &objectStorageMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing object storage metadata: %w", err)
+	}
+
+	switch meta.Provider {
+	case objectStorageProviderS3:
+		auth, err := awsutils.GetAwsAuthorization(config.TriggerUniqueKey, config.PodIdentity, config.TriggerMetadata, config.AuthParams, config.ResolvedEnv)
+		if err != nil {
+			return nil, err
+		}
+		meta.awsAuthorization = auth
+	case objectStorageProviderGCS:
+		auth, err := gcp.GetGCPAuthorization(config)
+		if err != nil {
+			return nil, err
+		}
+		meta.gcpAuthorization = auth
+	case objectStorageProviderAzureBlob:
+		endpointSuffix, err := azure.ParseAzureStorageEndpointSuffix(config.TriggerMetadata, azure.BlobEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		meta.azureEndpointSuffix = endpointSuffix
+
+		switch config.PodIdentity.Provider {
+		case "", kedav1alpha1.PodIdentityProviderNone:
+			if meta.Connection == "" {
+				return nil, fmt.Errorf("no connection setting given")
+			}
+		case kedav1alpha1.PodIdentityProviderAzureWorkload:
+			if meta.AccountName == "" {
+				return nil, fmt.Errorf("no accountName given")
+			}
+		default:
+			return nil, fmt.Errorf("pod identity %s not supported for azure storage blobs", config.PodIdentity.Provider)
+		}
+		meta.podIdentity = config.PodIdentity
+	}
+
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// getObjectsValue returns the number of objects under the prefix, or the seconds since the oldest of them was last
+// modified. The placeholders of the folders, ending with a slash, aren't objects
+func (s *objectStorageScaler) getObjectsValue(ctx context.Context) (float64, error) {
+	var count int64
+	var oldest time.Time
+	err := s.client.listObjects(ctx, s.metadata.Prefix, func(object objectStorageObject) bool {
+		if strings.HasSuffix(object.key, "/") {
+			return true
+		}
+		count++
+		if oldest.IsZero() || object.lastModified.Before(oldest) {
+			oldest = object.lastModified
+		}
+		return count < s.metadata.MaxObjectsToScan
+	})
+	if err != nil {
+		return -1, err
+	}
+	if count >= s.metadata.MaxObjectsToScan {
+		s.logger.V(1).Info(fmt.Sprintf("Counted objects up to the limit of %d", s.metadata.MaxObjectsToScan))
+	}
+
+	if s.metadata.Metric == objectStorageMetricOldestObjectAge {
+		if count == 0 {
+			return 0, nil
+		}
+		return time.Since(oldest).Seconds(), nil
+	}
+	return float64(count), nil
+}
+
+func (s *objectStorageScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.getObjectsValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "Error listing the objects", "provider", s.metadata.Provider, "bucket", s.metadata.Bucket)
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *objectStorageScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("object-storage-%s-%s", s.metadata.Provider, s.metadata.Bucket))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *objectStorageScaler) Close(context.Context) error {
+	if s.client != nil {
+		return s.client.close()
+	}
+	return nil
+}
+
+// s3ObjectStorageClient calls ListObjectsV2 of the REST API of S3, signing the requests with the
+// credentials of the shared aws.Config
+type s3ObjectStorageClient struct {
+	httpClient       *http.Client
+	bucketURL        string
+	region           string
+	credentials      aws.CredentialsProvider
+	signer           *v4.Signer
+	awsAuthorization awsutils.AuthorizationMetadata
+}
+
+// s3ListBucketResult is the relevant part of the response of ListObjectsV2
+type s3ListBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// s3Error is the body of the errors returned by S3
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func newS3ObjectStorageClient(ctx context.Context, meta *objectStorageMetadata, timeout time.Duration) (*s3ObjectStorageClient, error) {
+	cfg, err := awsutils.GetAwsConfig(ctx, meta.AwsRegion, meta.awsAuthorization)
+	if err != nil {
+		return nil, err
+	}
+
+	// the buckets of AWS are addressed by their virtual host, the ones of the S3 compatible endpoints by their path
+	var bucketURL string
+	if meta.AwsEndpoint != "" {
+		bucketURL = fmt.Sprintf("%s/%s", strings.TrimSuffix(meta.AwsEndpoint, "/"), url.PathEscape(meta.Bucket))
+	} else {
+		bucketURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", meta.Bucket, meta.AwsRegion)
+		if strings.HasPrefix(meta.AwsRegion, "cn-") {
+			bucketURL += ".cn"
+		}
+	}
+
+	return &s3ObjectStorageClient{
+		httpClient:       kedautil.CreateHTTPClient(timeout, false),
+		bucketURL:        bucketURL,
+		region:           meta.AwsRegion,
+		credentials:      cfg.Credentials,
+		signer:           v4.NewSigner(),
+		awsAuthorization: meta.awsAuthorization,
+	}, nil
+}
+
+func (c *s3ObjectStorageClient) listObjects(ctx context.Context, prefix string, fn func(objectStorageObject) bool) error {
+	params := url.Values{}
+	params.Set("list-type", "2")
+	params.Set("max-keys", fmt.Sprint(s3PageSize))
+	if prefix != "" {
+		params.Set("prefix", prefix)
+	}
+	for {
+		page, err := c.listObjectsPage(ctx, params)
+		if err != nil {
+			return err
+		}
+		for _, content := range page.Contents {
+			if !fn(objectStorageObject{key: content.Key, lastModified: content.LastModified}) {
+				return nil
+			}
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return nil
+		}
+		params.Set("continuation-token", page.NextContinuationToken)
+	}
+}
+
+// listObjectsPage sends a signed request of ListObjectsV2 and decodes its page of objects
+func (c *s3ObjectStorageClient) listObjectsPage(ctx context.Context, params url.Values) (*s3ListBucketResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/?%s", c.bucketURL, params.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Amz-Content-Sha256", s3EmptyPayloadHash)
+
+	cred, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving aws credentials: %w", err)
+	}
+	if err := c.signer.SignHTTP(ctx, cred, req, s3EmptyPayloadHash, s3SigningName, c.region, time.Now()); err != nil {
+		return nil, fmt.Errorf("error signing the request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := s3Error{}
+		if err := xml.Unmarshal(body, &apiErr); err != nil || apiErr.Code == "" {
+			return nil, fmt.Errorf("s3 ListObjectsV2 returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		}
+		return nil, fmt.Errorf("s3 ListObjectsV2 failed: %s: %s", apiErr.Code, apiErr.Message)
+	}
+
+	page := &s3ListBucketResult{}
+	if err := xml.Unmarshal(body, page); err != nil {
+		return nil, fmt.Errorf("error parsing the ListObjectsV2 response: %w", err)
+	}
+	return page, nil
+}
+
+func (c *s3ObjectStorageClient) close() error {
+	awsutils.ClearAwsConfig(c.awsAuthorization)
+	c.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// gcsObjectStorageClient lists the objects of a bucket of Google Cloud Storage
+type gcsObjectStorageClient struct {
+	client *storage.Client
+	bucket *storage.BucketHandle
+}
+
+func newGCSObjectStorageClient(ctx context.Context, meta *objectStorageMetadata) (*gcsObjectStorageClient, error) {
+	var client *storage.Client
+	var err error
+	switch {
+	case meta.gcpAuthorization.PodIdentityProviderEnabled:
+		client, err = storage.NewClient(ctx)
+	case meta.gcpAuthorization.GoogleApplicationCredentialsFile != "":
+		client, err = storage.NewClient(ctx, option.WithCredentialsFile(meta.gcpAuthorization.GoogleApplicationCredentialsFile))
+	default:
+		client, err = storage.NewClient(ctx, option.WithCredentialsJSON([]byte(meta.gcpAuthorization.GoogleApplicationCredentials)))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &gcsObjectStorageClient{client: client, bucket: client.Bucket(meta.Bucket)}, nil
+}
+
+func (c *gcsObjectStorageClient) listObjects(ctx context.Context, prefix string, fn func(objectStorageObject) bool) error {
+	query := &storage.Query{Prefix: prefix}
+	if err := query.SetAttrSelection([]string{"Name", "Updated"}); err != nil {
+		return err
+	}
+	it := c.bucket.Objects(ctx, query)
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !fn(objectStorageObject{key: attrs.Name, lastModified: attrs.Updated}) {
+			return nil
+		}
+	}
+}
+
+func (c *gcsObjectStorageClient) close() error {
+	return c.client.Close()
+}
+
+// azureBlobObjectStorageClient lists the blobs of a container of Azure Blob Storage
+type azureBlobObjectStorageClient struct {
+	client    *azblob.Client
+	container string
+}
+
+func newAzureBlobObjectStorageClient(logger logr.Logger, meta *objectStorageMetadata, timeout time.Duration) (*azureBlobObjectStorageClient, error) {
+	client, err := azure.GetStorageBlobClient(logger, meta.podIdentity, meta.Connection, meta.AccountName, meta.azureEndpointSuffix, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &azureBlobObjectStorageClient{client: client, container: meta.Bucket}, nil
+}
+
+func (c *azureBlobObjectStorageClient) listObjects(ctx context.Context, prefix string, fn func(objectStorageObject) bool) error {
+	options := &azblob.ListBlobsFlatOptions{}
+	if prefix != "" {
+		options.Prefix = &prefix
+	}
+	pager := c.client.NewListBlobsFlatPager(c.container, options)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, blob := range page.Segment.BlobItems {
+			object := objectStorageObject{}
+			if blob.Name != nil {
+				object.key = *blob.Name
+			}
+			if blob.Properties != nil && blob.Properties.LastModified != nil {
+				object.lastModified = *blob.Properties.LastModified
+			}
+			if !fn(object) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func (c *azureBlobObjectStorageClient) close() error {
+	return nil
+}
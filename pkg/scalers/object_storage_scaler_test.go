@@ -0,0 +1,220 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+var testObjectStorageAWSAuthentication = map[string]string{
+	"awsAccessKeyId":     "none",
+	"awsSecretAccessKey": "none",
+}
+
+type parseObjectStorageMetadataTestData struct {
+	metadata    map[string]string
+	authParams  map[string]string
+	podIdentity kedav1alpha1.PodIdentityProvider
+	isError     bool
+}
+
+type objectStorageMetricIdentifier struct {
+	metadataTestData *parseObjectStorageMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testObjectStorageMetadata = []parseObjectStorageMetadataTestData{
+	// s3
+	{map[string]string{"provider": "s3", "bucket": "incoming", "prefix": "orders/", "awsRegion": "eu-west-1", "targetValue": "10"}, testObjectStorageAWSAuthentication, "", false},
+	// gcs with the age of the oldest object
+	{map[string]string{"provider": "gcs", "bucket": "incoming", "metric": "oldestObjectAge", "targetValue": "300"}, map[string]string{"GoogleApplicationCredentials": "{}"}, "", false},
+	// azure blob with a connection
+	{map[string]string{"provider": "azureBlob", "bucket": "incoming", "targetValue": "10", "maxObjectsToScan": "500"}, map[string]string{"connection": "DefaultEndpointsProtocol=https;AccountName=account;AccountKey=key"}, "", false},
+	// azure blob with workload identity
+	{map[string]string{"provider": "azureBlob", "bucket": "incoming", "accountName": "account", "targetValue": "10"}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload, false},
+	// unknown provider
+	{map[string]string{"provider": "swift", "bucket": "incoming", "targetValue": "10"}, map[string]string{}, "", true},
+	// unknown metric
+	{map[string]string{"provider": "gcs", "bucket": "incoming", "metric": "size", "targetValue": "10"}, map[string]string{"GoogleApplicationCredentials": "{}"}, "", true},
+	// missing bucket
+	{map[string]string{"provider": "s3", "awsRegion": "eu-west-1", "targetValue": "10"}, testObjectStorageAWSAuthentication, "", true},
+	// missing targetValue
+	{map[string]string{"provider": "s3", "bucket": "incoming", "awsRegion": "eu-west-1"}, testObjectStorageAWSAuthentication, "", true},
+	// invalid maxObjectsToScan
+	{map[string]string{"provider": "s3", "bucket": "incoming", "awsRegion": "eu-west-1", "targetValue": "10", "maxObjectsToScan": "0"}, testObjectStorageAWSAuthentication, "", true},
+	// s3 without awsRegion
+	{map[string]string{"provider": "s3", "bucket": "incoming", "targetValue": "10"}, testObjectStorageAWSAuthentication, "", true},
+	// s3 without credentials
+	{map[string]string{"provider": "s3", "bucket": "incoming", "awsRegion": "eu-west-1", "targetValue": "10"}, map[string]string{}, "", true},
+	// awsRegion with gcs
+	{map[string]string{"provider": "gcs", "bucket": "incoming", "awsRegion": "eu-west-1", "targetValue": "10"}, map[string]string{"GoogleApplicationCredentials": "{}"}, "", true},
+	// gcs without credentials
+	{map[string]string{"provider": "gcs", "bucket": "incoming", "targetValue": "10"}, map[string]string{}, "", true},
+	// azure blob without connection
+	{map[string]string{"provider": "azureBlob", "bucket": "incoming", "targetValue": "10"}, map[string]string{}, "", true},
+	// azure blob with workload identity without accountName
+	{map[string]string{"provider": "azureBlob", "bucket": "incoming", "targetValue": "10"}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload, true},
+}
+
+var objectStorageMetricIdentifiers = []objectStorageMetricIdentifier{
+	{&testObjectStorageMetadata[0], 0, "s0-object-storage-s3-incoming"},
+	{&testObjectStorageMetadata[2], 1, "s1-object-storage-azureBlob-incoming"},
+}
+
+func TestObjectStorageParseMetadata(t *testing.T) {
+	for i, testData := range testObjectStorageMetadata {
+		_, err := parseObjectStorageMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.podIdentity}})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", i, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", i)
+		}
+	}
+}
+
+func TestObjectStorageGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range objectStorageMetricIdentifiers {
+		meta, err := parseObjectStorageMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockObjectStorageScaler := objectStorageScaler{metadata: meta}
+
+		metricSpec := mockObjectStorageScaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+type mockObjectStorageClient struct {
+	objects []objectStorageObject
+	err     error
+}
+
+func (m *mockObjectStorageClient) listObjects(_ context.Context, _ string, fn func(objectStorageObject) bool) error {
+	for _, object := range m.objects {
+		if !fn(object) {
+			return nil
+		}
+	}
+	return m.err
+}
+
+func (m *mockObjectStorageClient) close() error {
+	return nil
+}
+
+func TestObjectStorageGetMetricsAndActivity(t *testing.T) {
+	now := time.Now()
+	objects := []objectStorageObject{
+		{key: "orders/", lastModified: now.Add(-time.Hour)},
+		{key: "orders/1.json", lastModified: now.Add(-10 * time.Minute)},
+		{key: "orders/2.json", lastModified: now.Add(-30 * time.Minute)},
+		{key: "orders/3.json", lastModified: now.Add(-20 * time.Minute)},
+	}
+
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		objects        []objectStorageObject
+		err            error
+		expectedValue  float64
+		expectedActive bool
+		expectedError  string
+	}{
+		{"object count", map[string]string{}, objects, nil, 3, true, ""},
+		{"object count up to maxObjectsToScan", map[string]string{"maxObjectsToScan": "2"}, objects, nil, 2, false, ""},
+		{"age of the oldest object", map[string]string{"metric": "oldestObjectAge"}, objects, nil, 1800, true, ""},
+		{"age without objects", map[string]string{"metric": "oldestObjectAge"}, nil, nil, 0, false, ""},
+		{"listing error", map[string]string{}, nil, errors.New("access denied"), 0, false, "access denied"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["provider"] = "gcs"
+			tc.metadata["bucket"] = "incoming"
+			tc.metadata["targetValue"] = "10"
+			tc.metadata["activationTargetValue"] = "2"
+			meta, err := parseObjectStorageMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{"GoogleApplicationCredentials": "{}"}})
+			assert.NoError(t, err)
+			s := &objectStorageScaler{metadata: meta, client: &mockObjectStorageClient{objects: tc.objects, err: tc.err}, logger: logr.Discard()}
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tc.expectedValue, metrics[0].Value.AsApproximateFloat64(), 1)
+			assert.Equal(t, tc.expectedActive, isActive)
+		})
+	}
+}
+
+func TestS3ObjectStorageClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=none/"), "request isn't signed")
+		assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/s3/aws4_request")
+		assert.Equal(t, s3EmptyPayloadHash, r.Header.Get("X-Amz-Content-Sha256"))
+
+		switch r.URL.Path {
+		case "/incoming/":
+			assert.Equal(t, "2", r.URL.Query().Get("list-type"))
+			assert.Equal(t, "orders/", r.URL.Query().Get("prefix"))
+			if r.URL.Query().Get("continuation-token") == "" {
+				fmt.Fprint(w, `<ListBucketResult><IsTruncated>true</IsTruncated><NextContinuationToken>page2</NextContinuationToken>`+
+					`<Contents><Key>orders/1.json</Key><LastModified>2024-05-01T10:00:00.000Z</LastModified></Contents>`+
+					`<Contents><Key>orders/2.json</Key><LastModified>2024-05-01T09:00:00.000Z</LastModified></Contents></ListBucketResult>`)
+				return
+			}
+			assert.Equal(t, "page2", r.URL.Query().Get("continuation-token"))
+			fmt.Fprint(w, `<ListBucketResult><IsTruncated>false</IsTruncated>`+
+				`<Contents><Key>orders/3.json</Key><LastModified>2024-05-01T11:00:00.000Z</LastModified></Contents></ListBucketResult>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>`)
+		}
+	}))
+	defer server.Close()
+
+	for _, bucket := range []string{"incoming", "missing"} {
+		meta, err := parseObjectStorageMetadata(&scalersconfig.ScalerConfig{
+			TriggerMetadata:  map[string]string{"provider": "s3", "bucket": bucket, "prefix": "orders/", "awsRegion": "eu-west-1", "awsEndpoint": server.URL, "targetValue": "10"},
+			AuthParams:       testObjectStorageAWSAuthentication,
+			TriggerUniqueKey: bucket,
+		})
+		assert.NoError(t, err)
+		client, err := newS3ObjectStorageClient(context.Background(), meta, 0)
+		assert.NoError(t, err)
+
+		var keys []string
+		var oldest time.Time
+		err = client.listObjects(context.Background(), meta.Prefix, func(object objectStorageObject) bool {
+			keys = append(keys, object.key)
+			if oldest.IsZero() || object.lastModified.Before(oldest) {
+				oldest = object.lastModified
+			}
+			return true
+		})
+		if bucket == "missing" {
+			assert.EqualError(t, err, "s3 ListObjectsV2 failed: NoSuchBucket: The specified bucket does not exist")
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"orders/1.json", "orders/2.json", "orders/3.json"}, keys)
+			assert.Equal(t, time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC), oldest)
+		}
+		assert.NoError(t, client.close())
+	}
+}
@@ -0,0 +1,303 @@
+package scalers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	url_pkg "net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	awsutils "github.com/kedacore/keda/v2/pkg/scalers/aws"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	opensearchModeISM      = "ism"
+	opensearchModeSnapshot = "snapshot"
+
+	// opensearchSigningName is the signing name of the Amazon OpenSearch Service domains
+	opensearchSigningName = "es"
+)
+
+// opensearchEmptyPayloadHash is the sha256 of the empty body of the GET requests
+var opensearchEmptyPayloadHash = sha256.Sum256(nil)
+
+type opensearchScaler struct {
+	metricType  v2.MetricTargetType
+	metadata    *opensearchMetadata
+	httpClient  *http.Client
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	logger      logr.Logger
+}
+
+type opensearchMetadata struct {
+	awsAuthorization awsutils.AuthorizationMetadata
+
+	Addresses []string `keda:"name=addresses, order=authParams;triggerMetadata"`
+	Username  string   `keda:"name=username,  order=authParams;triggerMetadata, optional"`
+	Password  string   `keda:"name=password,  order=authParams;resolvedEnv;triggerMetadata, optional"`
+	UnsafeSsl bool     `keda:"name=unsafeSsl, order=triggerMetadata, default=false"`
+	AwsRegion string   `keda:"name=awsRegion, order=triggerMetadata, optional"`
+
+	Mode string `keda:"name=mode, order=triggerMetadata, enum=ism;snapshot"`
+
+	// ism mode, the managed indices whose current step has one of the statuses are counted
+	Index           string   `keda:"name=index,           order=triggerMetadata, default=*"`
+	ISMStepStatuses []string `keda:"name=ismStepStatuses, order=triggerMetadata, enum=starting;condition_not_met;failed, default=starting"`
+
+	// snapshot mode, the shards of the snapshots in progress which aren't done yet are counted
+	Repository string `keda:"name=repository, order=triggerMetadata, optional"`
+
+	TargetValue           int64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue int64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	triggerIndex int
+}
+
+// opensearchISMExplanation is the part of the explanation of a managed index read by the scaler
+type opensearchISMExplanation struct {
+	Index    string `json:"index"`
+	PolicyID string `json:"policy_id"`
+	Enabled  *bool  `json:"enabled"`
+	Step     struct {
+		Name       string `json:"name"`
+		StepStatus string `json:"step_status"`
+	} `json:"step"`
+}
+
+// opensearchSnapshotStatusResponse is the part of the status of the snapshots in progress read by the scaler
+type opensearchSnapshotStatusResponse struct {
+	Snapshots []struct {
+		Snapshot    string `json:"snapshot"`
+		Repository  string `json:"repository"`
+		State       string `json:"state"`
+		ShardsStats struct {
+			Done   int64 `json:"done"`
+			Failed int64 `json:"failed"`
+			Total  int64 `json:"total"`
+		} `json:"shards_stats"`
+	} `json:"snapshots"`
+}
+
+func (m *opensearchMetadata) Validate() error {
+	if m.AwsRegion != "" && (m.Username != "" || m.Password != "") {
+		return errors.New("username and password can't be used with the aws authentication of awsRegion")
+	}
+	if (m.Username == "") != (m.Password == "") {
+		return errors.New("both username and password must be provided")
+	}
+	if m.Repository != "" && m.Mode != opensearchModeSnapshot {
+		return errors.New("repository can only be used with mode snapshot")
+	}
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	return nil
+}
+
+// NewOpenSearchScaler creates a new scaler for the index management or snapshot backlog of an
+// OpenSearch cluster, the requests are signed with SigV4 for the Amazon OpenSearch Service domains
+func NewOpenSearchScaler(ctx context.Context, config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseOpenSearchMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing opensearch metadata: %w", err)
+	}
+
+	scaler := &opensearchScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl),
+		logger:     InitializeLogger(config, "opensearch_scaler"),
+	}
+	if meta.AwsRegion != "" {
+		cfg, err := awsutils.GetAwsConfig(ctx, meta.AwsRegion, meta.awsAuthorization)
+		if err != nil {
+			return nil, fmt.Errorf("error getting aws config: %w", err)
+		}
+		scaler.credentials = cfg.Credentials
+		scaler.signer = v4.NewSigner()
+	}
+	return scaler, nil
+}
+
+func parseOpenSearchMetadata(config *scalersconfig.ScalerConfig) (*opensearchMetadata, error) {
+	meta := &opensearchMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing opensearch metadata: %w", err)
+	}
+	for i, address := range meta.Addresses {
+		meta.Addresses[i] = strings.TrimSuffix(address, "/")
+	}
+
+	if meta.AwsRegion != "" {
+		awsAuthorization, err := awsutils.GetAwsAuthorization(config.TriggerUniqueKey, config.PodIdentity, config.TriggerMetadata, config.AuthParams, config.ResolvedEnv)
+		if err != nil {
+			return nil, err
+		}
+		meta.awsAuthorization = awsAuthorization
+	}
+
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// get sends a GET request of the path to the first address answering it, and decodes its response
+func (s *opensearchScaler) get(ctx context.Context, path string, output any) error {
+	var errs []error
+	for _, address := range s.metadata.Addresses {
+		err := s.getFromAddress(ctx, address+path, output)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (s *opensearchScaler) getFromAddress(ctx context.Context, url string, output any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.metadata.Username != "" {
+		req.SetBasicAuth(s.metadata.Username, s.metadata.Password)
+	}
+	if s.signer != nil {
+		cred, err := s.credentials.Retrieve(ctx)
+		if err != nil {
+			return fmt.Errorf("error retrieving aws credentials: %w", err)
+		}
+		if err := s.signer.SignHTTP(ctx, cred, req, hex.EncodeToString(opensearchEmptyPayloadHash[:]), opensearchSigningName, s.metadata.AwsRegion, time.Now()); err != nil {
+			return fmt.Errorf("error signing the request: %w", err)
+		}
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error requesting opensearch: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading the opensearch response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("opensearch %s returned status %d: %s", req.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, output); err != nil {
+		return fmt.Errorf("error parsing the opensearch response: %w", err)
+	}
+	return nil
+}
+
+// getISMBacklog counts the indices managed by a policy whose current step has one of the statuses
+func (s *opensearchScaler) getISMBacklog(ctx context.Context) (int64, error) {
+	// the explanations are keyed by the index names, next to the total_managed_indices count
+	response := map[string]json.RawMessage{}
+	if err := s.get(ctx, "/_plugins/_ism/explain/"+url_pkg.PathEscape(s.metadata.Index), &response); err != nil {
+		return -1, err
+	}
+
+	var count int64
+	for key, value := range response {
+		if key == "total_managed_indices" {
+			continue
+		}
+		explanation := opensearchISMExplanation{}
+		if err := json.Unmarshal(value, &explanation); err != nil {
+			return -1, fmt.Errorf("error parsing the ism explanation of %s: %w", key, err)
+		}
+		// the indices not managed by a policy, or whose policy is disabled, have no backlog
+		if explanation.PolicyID == "" || (explanation.Enabled != nil && !*explanation.Enabled) {
+			continue
+		}
+		if contains(s.metadata.ISMStepStatuses, explanation.Step.StepStatus) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// getSnapshotBacklog counts the shards of the snapshots in progress which aren't done yet
+func (s *opensearchScaler) getSnapshotBacklog(ctx context.Context) (int64, error) {
+	path := "/_snapshot/_status"
+	if s.metadata.Repository != "" {
+		path = fmt.Sprintf("/_snapshot/%s/_status", url_pkg.PathEscape(s.metadata.Repository))
+	}
+	response := opensearchSnapshotStatusResponse{}
+	if err := s.get(ctx, path, &response); err != nil {
+		return -1, err
+	}
+
+	var count int64
+	for _, snapshot := range response.Snapshots {
+		count += snapshot.ShardsStats.Total - snapshot.ShardsStats.Done - snapshot.ShardsStats.Failed
+	}
+	return count, nil
+}
+
+func (s *opensearchScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	var backlog int64
+	var err error
+	if s.metadata.Mode == opensearchModeSnapshot {
+		backlog, err = s.getSnapshotBacklog(ctx)
+	} else {
+		backlog, err = s.getISMBacklog(ctx)
+	}
+	if err != nil {
+		s.logger.Error(err, "error getting opensearch backlog")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(backlog))
+	return []external_metrics.ExternalMetricValue{metric}, backlog > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *opensearchScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	name := fmt.Sprintf("opensearch-ism-%s", strings.ReplaceAll(s.metadata.Index, "*", "all"))
+	if s.metadata.Mode == opensearchModeSnapshot {
+		repository := s.metadata.Repository
+		if repository == "" {
+			repository = "all"
+		}
+		name = fmt.Sprintf("opensearch-snapshot-%s", repository)
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(name)),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *opensearchScaler) Close(context.Context) error {
+	if s.metadata.AwsRegion != "" {
+		awsutils.ClearAwsConfig(s.metadata.awsAuthorization)
+	}
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
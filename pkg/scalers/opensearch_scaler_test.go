@@ -0,0 +1,156 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseOpenSearchMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type opensearchMetricIdentifier struct {
+	metadataTestData *parseOpenSearchMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testOpenSearchMetadata = []parseOpenSearchMetadataTestData{
+	// ism with basic auth
+	{map[string]string{"addresses": "https://opensearch:9200", "mode": "ism", "index": "logs-*", "targetValue": "10"}, map[string]string{"username": "admin", "password": "admin"}, false},
+	// snapshot of a repository with aws auth
+	{map[string]string{"addresses": "https://search-domain.eu-west-1.es.amazonaws.com", "mode": "snapshot", "repository": "s3-backups", "awsRegion": "eu-west-1", "targetValue": "100"}, map[string]string{"awsAccessKeyId": "none", "awsSecretAccessKey": "none"}, false},
+	// ism step statuses
+	{map[string]string{"addresses": "https://opensearch:9200", "mode": "ism", "ismStepStatuses": "starting,failed", "targetValue": "10"}, map[string]string{}, false},
+	// unknown ism step status
+	{map[string]string{"addresses": "https://opensearch:9200", "mode": "ism", "ismStepStatuses": "completed", "targetValue": "10"}, map[string]string{}, true},
+	// missing mode
+	{map[string]string{"addresses": "https://opensearch:9200", "targetValue": "10"}, map[string]string{}, true},
+	// missing addresses
+	{map[string]string{"mode": "ism", "targetValue": "10"}, map[string]string{}, true},
+	// missing targetValue
+	{map[string]string{"addresses": "https://opensearch:9200", "mode": "ism"}, map[string]string{}, true},
+	// repository with mode ism
+	{map[string]string{"addresses": "https://opensearch:9200", "mode": "ism", "repository": "s3-backups", "targetValue": "10"}, map[string]string{}, true},
+	// username without password
+	{map[string]string{"addresses": "https://opensearch:9200", "mode": "ism", "targetValue": "10"}, map[string]string{"username": "admin"}, true},
+	// basic auth with aws auth
+	{map[string]string{"addresses": "https://opensearch:9200", "mode": "ism", "awsRegion": "eu-west-1", "targetValue": "10"}, map[string]string{"username": "admin", "password": "admin", "awsAccessKeyId": "none", "awsSecretAccessKey": "none"}, true},
+	// aws auth without credentials
+	{map[string]string{"addresses": "https://opensearch:9200", "mode": "ism", "awsRegion": "eu-west-1", "targetValue": "10"}, map[string]string{}, true},
+}
+
+var opensearchMetricIdentifiers = []opensearchMetricIdentifier{
+	{&testOpenSearchMetadata[0], 0, "s0-opensearch-ism-logs-all"},
+	{&testOpenSearchMetadata[1], 1, "s1-opensearch-snapshot-s3-backups"},
+}
+
+func TestOpenSearchParseMetadata(t *testing.T) {
+	for i, testData := range testOpenSearchMetadata {
+		_, err := parseOpenSearchMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("test case %d: expected success but got error %v", i, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("test case %d: expected error but got success", i)
+		}
+	}
+}
+
+func TestOpenSearchGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range opensearchMetricIdentifiers {
+		meta, err := parseOpenSearchMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := opensearchScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := scaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func TestOpenSearchGetMetricsAndActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_plugins/_ism/explain/logs-*":
+			user, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "admin", user)
+			assert.Equal(t, "secret", password)
+			fmt.Fprint(w, `{
+				"logs-1": {"index": "logs-1", "policy_id": "rollover", "enabled": true, "step": {"name": "attempt_rollover", "step_status": "starting"}},
+				"logs-2": {"index": "logs-2", "policy_id": "rollover", "enabled": true, "step": {"name": "attempt_rollover", "step_status": "condition_not_met"}},
+				"logs-3": {"index": "logs-3", "policy_id": "rollover", "enabled": true, "step": {"name": "force_merge", "step_status": "failed"}},
+				"logs-4": {"index": "logs-4", "policy_id": "rollover", "enabled": false, "step": {"name": "force_merge", "step_status": "starting"}},
+				"logs-5": {"index.plugins.index_state_management.policy_id": null},
+				"total_managed_indices": 4
+			}`)
+		case "/_snapshot/_status", "/_snapshot/s3-backups/_status":
+			assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=none/"), "request isn't signed")
+			assert.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/es/aws4_request")
+			fmt.Fprint(w, `{"snapshots": [
+				{"snapshot": "nightly", "repository": "s3-backups", "state": "STARTED", "shards_stats": {"initializing": 2, "started": 3, "finalizing": 0, "done": 10, "failed": 1, "total": 16}},
+				{"snapshot": "hourly", "repository": "s3-backups", "state": "STARTED", "shards_stats": {"initializing": 0, "started": 1, "finalizing": 1, "done": 4, "failed": 0, "total": 6}}
+			]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": "no handler found"}`)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		authParams     map[string]string
+		expectedValue  int64
+		expectedActive bool
+		expectedError  string
+	}{
+		{"ism starting steps", map[string]string{"mode": "ism", "index": "logs-*"}, map[string]string{"username": "admin", "password": "secret"}, 1, false, ""},
+		{"ism starting and failed steps", map[string]string{"mode": "ism", "index": "logs-*", "ismStepStatuses": "starting,failed"}, map[string]string{"username": "admin", "password": "secret"}, 2, true, ""},
+		{"snapshot shards", map[string]string{"mode": "snapshot", "awsRegion": "eu-west-1"}, map[string]string{"awsAccessKeyId": "none", "awsSecretAccessKey": "none"}, 7, true, ""},
+		{"snapshot shards of a repository", map[string]string{"mode": "snapshot", "repository": "s3-backups", "awsRegion": "eu-west-1"}, map[string]string{"awsAccessKeyId": "none", "awsSecretAccessKey": "none"}, 7, true, ""},
+		{"unknown index", map[string]string{"mode": "ism", "index": "metrics-*"}, map[string]string{}, 0, false, "returned status 404"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["addresses"] = server.URL
+			tc.metadata["targetValue"] = "5"
+			tc.metadata["activationTargetValue"] = "1"
+			meta, err := parseOpenSearchMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: tc.authParams})
+			assert.NoError(t, err)
+			scaler := opensearchScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+			if meta.AwsRegion != "" {
+				scaler.credentials = aws.NewCredentialsCache(aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+					return aws.Credentials{AccessKeyID: "none", SecretAccessKey: "none"}, nil
+				}))
+				scaler.signer = v4.NewSigner()
+			}
+
+			metrics, isActive, err := scaler.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.Value())
+			assert.Equal(t, tc.expectedActive, isActive)
+		})
+	}
+}
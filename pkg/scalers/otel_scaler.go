@@ -0,0 +1,150 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/otlpreceiver"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type otelScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *otelMetadata
+	namespace  string
+	dataPoints func(namespace, metric string) []otlpreceiver.DataPoint
+	logger     logr.Logger
+}
+
+type otelMetadata struct {
+	Metric string `keda:"name=metric, order=triggerMetadata"`
+
+	// Attributes selects the series whose attributes, or the attributes of their resource, have the values
+	Attributes  map[string]string `keda:"name=attributes,  order=triggerMetadata, optional"`
+	Aggregation string            `keda:"name=aggregation, order=triggerMetadata, enum=sum;avg;min;max, default=sum"`
+
+	// IgnoreNullValues reports 0 while no series is selected, e.g. until the workloads push the metric
+	IgnoreNullValues bool `keda:"name=ignoreNullValues, order=triggerMetadata, default=true"`
+
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	triggerIndex int
+}
+
+func (m *otelMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return fmt.Errorf("targetValue must be greater than 0")
+	}
+	return nil
+}
+
+// NewOtelScaler creates a new scaler for the latest value of a metric pushed over OTLP, the data
+// points are kept by the OTLP receiver of the operator
+func NewOtelScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseOtelMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing otel metadata: %w", err)
+	}
+
+	if !otlpreceiver.Enabled() {
+		return nil, fmt.Errorf("the otlp receiver isn't enabled, set --otlp-receiver-bind-address on the operator")
+	}
+
+	return &otelScaler{
+		metricType: metricType,
+		metadata:   meta,
+		namespace:  config.ScalableObjectNamespace,
+		dataPoints: otlpreceiver.DataPoints,
+		logger:     InitializeLogger(config, "otel_scaler"),
+	}, nil
+}
+
+func parseOtelMetadata(config *scalersconfig.ScalerConfig) (*otelMetadata, error) {
+	meta := &otelMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing otel metadata: %w", err)
+	}
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// isSelected checks the data point has all the attributes of the selector
+func (s *otelScaler) isSelected(point otlpreceiver.DataPoint) bool {
+	for name, value := range s.metadata.Attributes {
+		if attribute, ok := point.Attributes[name]; !ok || attribute != value {
+			return false
+		}
+	}
+	return true
+}
+
+// GetMetricValue aggregates the latest values of the series of the metric selected by the attributes,
+// among the series exported to the namespace of the scaler
+func (s *otelScaler) GetMetricValue() (float64, error) {
+	var values []float64
+	for _, point := range s.dataPoints(s.namespace, s.metadata.Metric) {
+		if s.isSelected(point) && !math.IsNaN(point.Value) {
+			values = append(values, point.Value)
+		}
+	}
+	if len(values) == 0 {
+		if s.metadata.IgnoreNullValues {
+			return 0, nil
+		}
+		return -1, fmt.Errorf("no data point of the metric %s has been pushed with the attributes", s.metadata.Metric)
+	}
+
+	value := values[0]
+	for _, v := range values[1:] {
+		switch s.metadata.Aggregation {
+		case "min":
+			value = math.Min(value, v)
+		case "max":
+			value = math.Max(value, v)
+		default:
+			value += v
+		}
+	}
+	if s.metadata.Aggregation == "avg" {
+		value /= float64(len(values))
+	}
+	return value, nil
+}
+
+func (s *otelScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.GetMetricValue()
+	if err != nil {
+		s.logger.Error(err, "error getting otel metric value")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *otelScaler) GetMetricSpecForScaling(_ context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("otel-%s", s.metadata.Metric))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *otelScaler) Close(_ context.Context) error {
+	return nil
+}
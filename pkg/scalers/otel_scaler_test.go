@@ -0,0 +1,123 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/otlpreceiver"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseOtelMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type otelMetricIdentifier struct {
+	metadataTestData *parseOtelMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testOtelMetadata = []parseOtelMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true},
+	// properly formed metadata
+	{map[string]string{"metric": "queue.depth", "targetValue": "10"}, false},
+	// attributes and aggregation
+	{map[string]string{"metric": "queue.depth", "attributes": "queue=orders,k8s.namespace.name=shop", "aggregation": "max", "targetValue": "2.5"}, false},
+	// invalid aggregation
+	{map[string]string{"metric": "queue.depth", "aggregation": "last", "targetValue": "10"}, true},
+	// malformed attributes
+	{map[string]string{"metric": "queue.depth", "attributes": "queue", "targetValue": "10"}, true},
+	// missing targetValue
+	{map[string]string{"metric": "queue.depth"}, true},
+	// invalid targetValue
+	{map[string]string{"metric": "queue.depth", "targetValue": "0"}, true},
+}
+
+var otelMetricIdentifiers = []otelMetricIdentifier{
+	{&testOtelMetadata[1], 0, "s0-otel-queue-depth"},
+	{&testOtelMetadata[2], 1, "s1-otel-queue-depth"},
+}
+
+func TestOtelParseMetadata(t *testing.T) {
+	for testCaseNum, testData := range testOtelMetadata {
+		_, err := parseOtelMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", testCaseNum, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", testCaseNum)
+		}
+	}
+}
+
+func TestOtelGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range otelMetricIdentifiers {
+		meta, err := parseOtelMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockOtelScaler := otelScaler{metadata: meta}
+
+		metricSpec := mockOtelScaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func TestNewOtelScalerWithoutReceiver(t *testing.T) {
+	_, err := NewOtelScaler(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{"metric": "queue.depth", "targetValue": "10"}})
+	assert.ErrorContains(t, err, "the otlp receiver isn't enabled")
+}
+
+var testOtelDataPoints = []otlpreceiver.DataPoint{
+	{Metric: "queue.depth", Attributes: map[string]string{"queue": "orders", "k8s.pod.name": "worker-1"}, Value: 4},
+	{Metric: "queue.depth", Attributes: map[string]string{"queue": "orders", "k8s.pod.name": "worker-2"}, Value: 8},
+	{Metric: "queue.depth", Attributes: map[string]string{"queue": "payments", "k8s.pod.name": "worker-1"}, Value: 30},
+}
+
+func TestOtelGetMetricsAndActivity(t *testing.T) {
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		expectedValue  float64
+		expectedActive bool
+		expectedError  string
+	}{
+		{"sum of the series", map[string]string{}, 42, true, ""},
+		{"selected series", map[string]string{"attributes": "queue=orders"}, 12, true, ""},
+		{"average of the selected series", map[string]string{"attributes": "queue=orders", "aggregation": "avg"}, 6, false, ""},
+		{"maximum of the selected series", map[string]string{"attributes": "queue=orders", "aggregation": "max"}, 8, true, ""},
+		{"series of a pod", map[string]string{"attributes": "queue=orders,k8s.pod.name=worker-1"}, 4, false, ""},
+		{"no selected series", map[string]string{"attributes": "queue=shipping"}, 0, false, ""},
+		{"no selected series with ignoreNullValues false", map[string]string{"attributes": "queue=shipping", "ignoreNullValues": "false"}, 0, false, "no data point of the metric queue.depth has been pushed with the attributes"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["metric"] = "queue.depth"
+			tc.metadata["targetValue"] = "10"
+			tc.metadata["activationTargetValue"] = "6"
+			meta, err := parseOtelMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata})
+			assert.NoError(t, err)
+			s := &otelScaler{metadata: meta, namespace: "default", logger: logr.Discard(), dataPoints: func(namespace, metric string) []otlpreceiver.DataPoint {
+				assert.Equal(t, "default", namespace)
+				assert.Equal(t, "queue.depth", metric)
+				return testOtelDataPoints
+			}}
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.expectedActive, isActive)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// podMetricsCacheTTL is how long the PodMetrics listed for a namespace and a selector are served to the
+// cpu/memory scalers, metrics-server doesn't refresh them more often than its resolution, 15s by default
+const podMetricsCacheTTL = 10 * time.Second
+
+// sharedPodMetricsCache is the cache of the PodMetrics shared by all the cpu/memory scalers, so the ones of
+// the same pods list them once per polling cycle
+var sharedPodMetricsCache = newPodMetricsCache(podMetricsCacheTTL, listPodMetrics)
+
+// getMetricsClient returns the client of the metrics API, it's created once
+var getMetricsClient = sync.OnceValues(func() (metrics.Interface, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get in-cluster config: %v", err)
+	}
+
+	metricsClient, err := metrics.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics client: %v", err)
+	}
+	return metricsClient, nil
+})
+
+func listPodMetrics(ctx context.Context, namespace string, labelSelector labels.Selector) (*v1beta1.PodMetricsList, error) {
+	metricsClient, err := getMetricsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return metricsClient.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector.String(),
+	})
+}
+
+// podMetricsCache are the PodMetrics listed by namespace and selector, the lists are shared by their callers which
+// mustn't modify them. The errors aren't cached, the callers waiting for a list failing get its error.
+type podMetricsCache struct {
+	ttl  time.Duration
+	list func(ctx context.Context, namespace string, labelSelector labels.Selector) (*v1beta1.PodMetricsList, error)
+
+	lock    sync.Mutex
+	entries map[string]*podMetricsCacheEntry
+}
+
+type podMetricsCacheEntry struct {
+	listed time.Time
+	// ready is closed once the PodMetrics are listed
+	ready          chan struct{}
+	podMetricsList *v1beta1.PodMetricsList
+	err            error
+}
+
+func newPodMetricsCache(ttl time.Duration, list func(ctx context.Context, namespace string, labelSelector labels.Selector) (*v1beta1.PodMetricsList, error)) *podMetricsCache {
+	return &podMetricsCache{
+		ttl:     ttl,
+		list:    list,
+		entries: map[string]*podMetricsCacheEntry{},
+	}
+}
+
+// get returns the PodMetrics of the namespace and the selector, they're listed again once the last list expired, or
+// is older than the maximum age if it's positive. The calls arriving while they're listed wait for them.
+func (c *podMetricsCache) get(ctx context.Context, namespace string, labelSelector labels.Selector, maxAge time.Duration) (*v1beta1.PodMetricsList, error) {
+	key := namespace + "/" + labelSelector.String()
+	now := time.Now()
+	if maxAge <= 0 || maxAge > c.ttl {
+		maxAge = c.ttl
+	}
+
+	c.lock.Lock()
+	entry, found := c.entries[key]
+	if found && now.Sub(entry.listed) < maxAge {
+		c.lock.Unlock()
+		<-entry.ready
+		return entry.podMetricsList, entry.err
+	}
+	for otherKey, other := range c.entries {
+		if now.Sub(other.listed) >= c.ttl {
+			delete(c.entries, otherKey)
+		}
+	}
+	entry = &podMetricsCacheEntry{listed: now, ready: make(chan struct{})}
+	c.entries[key] = entry
+	c.lock.Unlock()
+
+	entry.podMetricsList, entry.err = c.list(ctx, namespace, labelSelector)
+	if entry.err != nil {
+		c.lock.Lock()
+		if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+		c.lock.Unlock()
+	}
+	close(entry.ready)
+	return entry.podMetricsList, entry.err
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+func TestPodMetricsCache(t *testing.T) {
+	var listed atomic.Int32
+	var failing atomic.Bool
+	cache := newPodMetricsCache(time.Hour, func(_ context.Context, namespace string, labelSelector labels.Selector) (*v1beta1.PodMetricsList, error) {
+		listed.Add(1)
+		if failing.Load() {
+			return nil, errors.New("metrics-server unavailable")
+		}
+		return &v1beta1.PodMetricsList{Items: []v1beta1.PodMetrics{{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: labelSelector.String()}}}}, nil
+	})
+	selector := labels.SelectorFromSet(labels.Set{"app": "web"})
+
+	// the scalers of the same pods share the list
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			podMetricsList, err := cache.get(context.Background(), "default", selector, 0)
+			assert.NoError(t, err)
+			assert.Equal(t, "app=web", podMetricsList.Items[0].Name)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), listed.Load())
+
+	// the ones of other pods don't
+	_, err := cache.get(context.Background(), "other", selector, 0)
+	assert.NoError(t, err)
+	_, err = cache.get(context.Background(), "default", labels.SelectorFromSet(labels.Set{"app": "worker"}), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), listed.Load())
+
+	// unless it's older than the maximum age
+	time.Sleep(10 * time.Millisecond)
+	_, err = cache.get(context.Background(), "default", selector, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), listed.Load())
+
+	// the errors aren't cached
+	failing.Store(true)
+	_, err = cache.get(context.Background(), "failing", selector, 0)
+	assert.Error(t, err)
+	_, err = cache.get(context.Background(), "failing", selector, 0)
+	assert.Error(t, err)
+	assert.Equal(t, int32(6), listed.Load())
+}
+
+func TestPodMetricsCacheExpiration(t *testing.T) {
+	listed := 0
+	cache := newPodMetricsCache(0, func(context.Context, string, labels.Selector) (*v1beta1.PodMetricsList, error) {
+		listed++
+		return &v1beta1.PodMetricsList{}, nil
+	})
+	selector := labels.SelectorFromSet(labels.Set{"app": "web"})
+
+	// the lists expired are listed again and forgotten
+	_, err := cache.get(context.Background(), "default", selector, 0)
+	assert.NoError(t, err)
+	_, err = cache.get(context.Background(), "other", selector, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, listed)
+	assert.Len(t, cache.entries, 1)
+}
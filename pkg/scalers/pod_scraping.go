@@ -0,0 +1,65 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxConcurrentPodScrapes bounds the pods scraped at once by the scalers reading an endpoint of each pod
+const maxConcurrentPodScrapes = 10
+
+// listScrapedPods returns the running pods of the selector, which have an IP to be scraped
+func listScrapedPods(ctx context.Context, kubeClient client.Client, namespace string, selector labels.Selector) ([]*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := kubeClient.List(ctx, podList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	var pods []*corev1.Pod
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, nil
+}
+
+// scrapePods calls scrape for each of the pods, maxConcurrentPodScrapes at once, and returns once they're all scraped
+func scrapePods(pods []*corev1.Pod, scrape func(i int, pod *corev1.Pod)) {
+	var wg sync.WaitGroup
+	scrapes := make(chan struct{}, maxConcurrentPodScrapes)
+	for i, pod := range pods {
+		wg.Add(1)
+		scrapes <- struct{}{}
+		go func(i int, pod *corev1.Pod) {
+			defer wg.Done()
+			defer func() { <-scrapes }()
+			scrape(i, pod)
+		}(i, pod)
+	}
+	wg.Wait()
+}
+
+// getPodEndpoint reads the body of the endpoint of the pod
+func getPodEndpoint(ctx context.Context, httpClient *http.Client, pod *corev1.Pod, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the pod %s returned %d", pod.Name, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
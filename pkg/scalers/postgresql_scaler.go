@@ -27,10 +27,25 @@ const (
 	// Azure AD resource ID for Azure Database for PostgreSQL is https://ossrdbms-aad.database.windows.net
 	// https://learn.microsoft.com/en-us/azure/postgresql/single-server/how-to-connect-with-managed-identity
 	azureDatabasePostgresResource = "https://ossrdbms-aad.database.windows.net/.default"
+
+	postgreSQLModeQuery          = "query"
+	postgreSQLModeReplicationLag = "replicationLag"
+
+	// postgreSQLReplicationLagQuery returns the bytes of WAL behind the current position of the logical replication
+	// slots, $1 selects the slot when it's set and $2 is the position of the slots compared: the restart_lsn is the
+	// WAL retained by the slot, the confirmed_flush_lsn the changes its consumer hasn't confirmed yet
+	postgreSQLReplicationLagQuery = `SELECT COALESCE(MAX(pg_wal_lsn_diff(pg_current_wal_lsn(),
+	CASE WHEN $2::text = 'restart' THEN s.restart_lsn ELSE COALESCE(s.confirmed_flush_lsn, s.restart_lsn) END)), 0)
+FROM pg_replication_slots s
+WHERE s.slot_type = 'logical' AND ($1::text = '' OR s.slot_name = $1::text)`
 )
 
 var (
 	passwordConnPattern = regexp.MustCompile(`%PASSWORD%`)
+
+	// sharedPostgreSQLConnections are the connection pools shared by the scalers of the same database and
+	// user, the ones authenticated with the Azure Workload Identity aren't shared as their password expires
+	sharedPostgreSQLConnections = newSharedClients((*sql.DB).Close)
 )
 
 type postgreSQLScaler struct {
@@ -39,6 +54,8 @@ type postgreSQLScaler struct {
 	connection  *sql.DB
 	podIdentity kedav1alpha1.AuthPodIdentity
 	logger      logr.Logger
+	// sharedConnection is the reference on the shared connection pool, it's nil if the pool isn't shared
+	sharedConnection *sharedClientRef
 }
 
 type postgreSQLMetadata struct {
@@ -46,6 +63,7 @@ type postgreSQLMetadata struct {
 	activationTargetQueryValue float64
 	connection                 string
 	query                      string
+	queryArgs                  []any
 	triggerIndex               int
 	azureAuthContext           azureAuthContext
 }
@@ -69,16 +87,25 @@ func NewPostgreSQLScaler(ctx context.Context, config *scalersconfig.ScalerConfig
 		return nil, fmt.Errorf("error parsing postgreSQL metadata: %w", err)
 	}
 
-	conn, err := getConnection(ctx, meta, podIdentity, logger)
+	var conn *sql.DB
+	var sharedConnection *sharedClientRef
+	if podIdentity.Provider == kedav1alpha1.PodIdentityProviderAzureWorkload {
+		conn, err = getConnection(ctx, meta, podIdentity, logger)
+	} else {
+		conn, sharedConnection, err = sharedPostgreSQLConnections.acquire(sharedClientKey(meta.connection), func() (*sql.DB, error) {
+			return getConnection(ctx, meta, podIdentity, logger)
+		})
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error establishing postgreSQL connection: %w", err)
 	}
 	return &postgreSQLScaler{
-		metricType:  metricType,
-		metadata:    meta,
-		connection:  conn,
-		podIdentity: podIdentity,
-		logger:      logger,
+		metricType:       metricType,
+		metadata:         meta,
+		connection:       conn,
+		podIdentity:      podIdentity,
+		logger:           logger,
+		sharedConnection: sharedConnection,
 	}, nil
 }
 
@@ -87,10 +114,32 @@ func parsePostgreSQLMetadata(logger logr.Logger, config *scalersconfig.ScalerCon
 
 	authPodIdentity := kedav1alpha1.AuthPodIdentity{}
 
-	if val, ok := config.TriggerMetadata["query"]; ok {
-		meta.query = val
-	} else {
-		return nil, authPodIdentity, fmt.Errorf("no query given")
+	mode := postgreSQLModeQuery
+	if val, ok := config.TriggerMetadata["mode"]; ok && val != "" {
+		mode = val
+	}
+	switch mode {
+	case postgreSQLModeQuery:
+		if val, ok := config.TriggerMetadata["query"]; ok {
+			meta.query = val
+		} else {
+			return nil, authPodIdentity, fmt.Errorf("no query given")
+		}
+	case postgreSQLModeReplicationLag:
+		if _, ok := config.TriggerMetadata["query"]; ok {
+			return nil, authPodIdentity, fmt.Errorf("query can't be used with mode %s", postgreSQLModeReplicationLag)
+		}
+		position := "confirmedFlush"
+		if val, ok := config.TriggerMetadata["replicationLagPosition"]; ok && val != "" {
+			position = val
+		}
+		if position != "confirmedFlush" && position != "restart" {
+			return nil, authPodIdentity, fmt.Errorf("replicationLagPosition must be confirmedFlush or restart, got %s", position)
+		}
+		meta.query = postgreSQLReplicationLagQuery
+		meta.queryArgs = []any{config.TriggerMetadata["slotName"], position}
+	default:
+		return nil, authPodIdentity, fmt.Errorf("mode must be %s or %s, got %s", postgreSQLModeQuery, postgreSQLModeReplicationLag, mode)
 	}
 
 	if val, ok := config.TriggerMetadata["targetQueryValue"]; ok {
@@ -222,7 +271,12 @@ func getConnection(ctx context.Context, meta *postgreSQLMetadata, podIdentity ke
 
 // Close disposes of postgres connections
 func (s *postgreSQLScaler) Close(context.Context) error {
-	err := s.connection.Close()
+	var err error
+	if s.sharedConnection != nil {
+		err = s.sharedConnection.release()
+	} else {
+		err = s.connection.Close()
+	}
 	if err != nil {
 		s.logger.Error(err, "Error closing postgreSQL connection")
 		return err
@@ -245,9 +299,10 @@ func (s *postgreSQLScaler) getActiveNumber(ctx context.Context) (float64, error)
 		}
 	}
 
-	err := s.connection.QueryRowContext(ctx, s.metadata.query).Scan(&id)
+	err := s.connection.QueryRowContext(ctx, s.metadata.query, s.metadata.queryArgs...).Scan(&id)
 	if err != nil {
 		s.logger.Error(err, fmt.Sprintf("could not query postgreSQL: %s", err))
+		s.sharedConnection.invalidate()
 		return 0, fmt.Errorf("could not query postgreSQL: %w", err)
 	}
 	return id, nil
@@ -50,7 +50,7 @@ func TestPosgresSQLGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockPostgresSQLScaler := postgreSQLScaler{"", meta, nil, kedav1alpha1.AuthPodIdentity{}, logr.Discard()}
+		mockPostgresSQLScaler := postgreSQLScaler{"", meta, nil, kedav1alpha1.AuthPodIdentity{}, logr.Discard(), nil}
 
 		metricSpec := mockPostgresSQLScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -151,6 +151,41 @@ var testPostgresMetadata = []parsePostgresMetadataTestData{
 		resolvedEnv: testPostgresResolvedEnv,
 		raisesError: false,
 	},
+	// replicationLag mode
+	{
+		metadata:    map[string]string{"mode": "replicationLag", "targetQueryValue": "104857600", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: false,
+	},
+	// replicationLag mode of a slot with the retained WAL
+	{
+		metadata:    map[string]string{"mode": "replicationLag", "slotName": "debezium", "replicationLagPosition": "restart", "targetQueryValue": "104857600", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: false,
+	},
+	// replicationLag mode with a query
+	{
+		metadata:    map[string]string{"mode": "replicationLag", "query": "query", "targetQueryValue": "104857600", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: true,
+	},
+	// invalid replicationLagPosition
+	{
+		metadata:    map[string]string{"mode": "replicationLag", "replicationLagPosition": "flush", "targetQueryValue": "104857600", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: true,
+	},
+	// invalid mode
+	{
+		metadata:    map[string]string{"mode": "lag", "targetQueryValue": "104857600", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: true,
+	},
 }
 
 func TestParsePosgresSQLMetadata(t *testing.T) {
@@ -1,6 +1,7 @@
 package scalers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -10,9 +11,15 @@ import (
 	"net/http"
 	url_pkg "net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/model/labels"
+	promvalue "github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/promql/parser"
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
@@ -37,6 +44,16 @@ const (
 	unsafeSsl               = "unsafeSsl"
 )
 
+const (
+	promProtocolQuery           = "query"
+	promProtocolRemoteRead      = "remoteRead"
+	promProtocolVictoriaMetrics = "victoriaMetrics"
+
+	// promDefaultTenantHeader is the tenant header of Cortex, Mimir and the Thanos receivers
+	promDefaultTenantHeader = "X-Scope-OrgID"
+	promRemoteReadVersion   = "0.1.0"
+)
+
 type prometheusScaler struct {
 	metricType v2.MetricTargetType
 	metadata   *prometheusMetadata
@@ -61,6 +78,91 @@ type prometheusMetadata struct {
 	CustomHeaders       map[string]string      `keda:"name=customHeaders,       order=triggerMetadata, optional"`
 	IgnoreNullValues    bool                   `keda:"name=ignoreNullValues,    order=triggerMetadata, optional, default=true"`
 	UnsafeSSL           bool                   `keda:"name=unsafeSsl,           order=triggerMetadata, optional"`
+
+	// Protocol is the API used to read the metric: the HTTP instant query API by default, the
+	// remote-read protobuf API, or the instant query API of VictoriaMetrics with its extensions
+	Protocol string `keda:"name=protocol, order=triggerMetadata, enum=query;remoteRead;victoriaMetrics, optional"`
+
+	// TenantID is sent in the TenantHeader for the multi-tenant Cortex, Mimir and Thanos, it is the
+	// accountID[:projectID] of the select path of a VictoriaMetrics cluster
+	TenantID     string `keda:"name=tenantID,     order=triggerMetadata;authParams, optional"`
+	TenantHeader string `keda:"name=tenantHeader, order=triggerMetadata, optional"`
+
+	// TenantIDs are queried one by one instead of a single tenantID, their results are aggregated with
+	// TenantAggregation. The credentials of a tenant are the bearerToken.<tenant>, or the username.<tenant>
+	// and password.<tenant> of the authParams, over the authentication of the trigger
+	TenantIDs         []string `keda:"name=tenantIDs,         order=triggerMetadata;authParams, optional"`
+	TenantAggregation string   `keda:"name=tenantAggregation, order=triggerMetadata, enum=sum;max, optional"`
+
+	// remoteRead protocol, the query is a series selector whose latest samples are read over the lookback
+	RemoteReadLookbackSeconds int    `keda:"name=remoteReadLookbackSeconds, order=triggerMetadata, default=300"`
+	SeriesAggregation         string `keda:"name=seriesAggregation,         order=triggerMetadata, enum=sum;avg;min;max, optional"`
+
+	// victoriaMetrics protocol
+	ExtraLabels         map[string]string `keda:"name=extraLabels,         order=triggerMetadata, optional"`
+	ExtraFilters        []string          `keda:"name=extraFilters,        order=triggerMetadata, optional, separator=;"`
+	DenyPartialResponse bool              `keda:"name=denyPartialResponse, order=triggerMetadata, optional"`
+
+	tenantAuth map[string]prometheusTenantAuth
+}
+
+// prometheusTenantAuth are the credentials of a tenant of tenantIDs
+type prometheusTenantAuth struct {
+	bearerToken string
+	username    string
+	password    string
+}
+
+func (m *prometheusMetadata) Validate() error {
+	if m.Protocol != promProtocolVictoriaMetrics && (len(m.ExtraLabels) > 0 || len(m.ExtraFilters) > 0 || m.DenyPartialResponse) {
+		return errors.New("extraLabels, extraFilters and denyPartialResponse can only be used with protocol victoriaMetrics")
+	}
+	if m.Protocol != promProtocolRemoteRead && m.SeriesAggregation != "" {
+		return errors.New("seriesAggregation can only be used with protocol remoteRead")
+	}
+	if m.TenantID != "" && len(m.TenantIDs) > 0 {
+		return errors.New("tenantID and tenantIDs can't be used together")
+	}
+	if m.TenantAggregation != "" && len(m.TenantIDs) == 0 {
+		return errors.New("tenantAggregation requires tenantIDs")
+	}
+	if m.TenantHeader != "" && ((m.TenantID == "" && len(m.TenantIDs) == 0) || m.Protocol == promProtocolVictoriaMetrics) {
+		return errors.New("tenantHeader requires a tenantID and can't be used with protocol victoriaMetrics")
+	}
+
+	switch m.Protocol {
+	case promProtocolRemoteRead:
+		if m.Namespace != "" || len(m.QueryParameters) > 0 {
+			return errors.New("namespace and queryParameters can't be used with protocol remoteRead")
+		}
+		if _, err := parser.ParseMetricSelector(m.Query); err != nil {
+			return fmt.Errorf("query must be a series selector with protocol remoteRead: %w", err)
+		}
+		if m.RemoteReadLookbackSeconds <= 0 {
+			return errors.New("remoteReadLookbackSeconds must be greater than 0")
+		}
+	case promProtocolVictoriaMetrics:
+		for _, tenantID := range append([]string{m.TenantID}, m.TenantIDs...) {
+			if tenantID != "" && !isVictoriaMetricsTenantID(tenantID) {
+				return fmt.Errorf("tenantID %q must be accountID[:projectID] with protocol victoriaMetrics", tenantID)
+			}
+		}
+	}
+	return nil
+}
+
+// isVictoriaMetricsTenantID checks the tenant is a numeric accountID, optionally followed by a numeric projectID
+func isVictoriaMetricsTenantID(tenantID string) bool {
+	accountID, projectID, hasProjectID := strings.Cut(tenantID, ":")
+	if _, err := strconv.ParseUint(accountID, 10, 32); err != nil {
+		return false
+	}
+	if hasProjectID {
+		if _, err := strconv.ParseUint(projectID, 10, 32); err != nil {
+			return false
+		}
+	}
+	return true
 }
 
 type promQueryResult struct {
@@ -73,6 +175,9 @@ type promQueryResult struct {
 			Value  []interface{} `json:"value"`
 		} `json:"result"`
 	} `json:"data"`
+
+	// IsPartial is set by VictoriaMetrics when some of the vmstorage nodes didn't answer
+	IsPartial bool `json:"isPartial"`
 }
 
 // NewPrometheusScaler creates a new prometheusScaler
@@ -159,9 +264,36 @@ func parsePrometheusMetadata(config *scalersconfig.ScalerConfig) (meta *promethe
 		return nil, err
 	}
 
+	meta.tenantAuth, err = parsePrometheusTenantAuth(config.AuthParams, meta.TenantIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	return meta, nil
 }
 
+// parsePrometheusTenantAuth reads the credentials of each of the tenants from the authParams suffixed by the tenant
+func parsePrometheusTenantAuth(authParams map[string]string, tenantIDs []string) (map[string]prometheusTenantAuth, error) {
+	tenantAuth := map[string]prometheusTenantAuth{}
+	for _, tenantID := range tenantIDs {
+		auth := prometheusTenantAuth{
+			bearerToken: authParams["bearerToken."+tenantID],
+			username:    authParams["username."+tenantID],
+			password:    authParams["password."+tenantID],
+		}
+		if auth.bearerToken != "" && (auth.username != "" || auth.password != "") {
+			return nil, fmt.Errorf("tenant %s can't have both a bearer token and a username and password", tenantID)
+		}
+		if auth.password != "" && auth.username == "" {
+			return nil, fmt.Errorf("the password of tenant %s requires a username", tenantID)
+		}
+		if auth != (prometheusTenantAuth{}) {
+			tenantAuth[tenantID] = auth
+		}
+	}
+	return tenantAuth, nil
+}
+
 func checkAuthConfigWithPodIdentity(config *scalersconfig.ScalerConfig, meta *prometheusMetadata) error {
 	if meta == nil || meta.PrometheusAuth.Disabled() {
 		return nil
@@ -194,9 +326,43 @@ func (s *prometheusScaler) GetMetricSpecForScaling(context.Context) []v2.MetricS
 }
 
 func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error) {
+	if len(s.metadata.TenantIDs) == 0 {
+		return s.executeTenantQuery(ctx, s.metadata.TenantID)
+	}
+
+	// the query is federated across the tenants, each of them is queried with its own credentials
+	var v float64
+	for i, tenantID := range s.metadata.TenantIDs {
+		tenantValue, err := s.executeTenantQuery(ctx, tenantID)
+		if err != nil {
+			return -1, fmt.Errorf("error querying tenant %s: %w", tenantID, err)
+		}
+		switch {
+		case i == 0:
+			v = tenantValue
+		case s.metadata.TenantAggregation == "max":
+			v = math.Max(v, tenantValue)
+		default:
+			v += tenantValue
+		}
+	}
+	return v, nil
+}
+
+func (s *prometheusScaler) executeTenantQuery(ctx context.Context, tenantID string) (float64, error) {
+	if s.metadata.Protocol == promProtocolRemoteRead {
+		return s.executeRemoteRead(ctx, tenantID)
+	}
+
+	serverAddress := s.metadata.ServerAddress
+	// the tenant of a VictoriaMetrics cluster is selected by the path of vmselect
+	if s.metadata.Protocol == promProtocolVictoriaMetrics && tenantID != "" {
+		serverAddress = fmt.Sprintf("%s/select/%s/prometheus", strings.TrimSuffix(serverAddress, "/"), tenantID)
+	}
+
 	t := time.Now().UTC().Format(time.RFC3339)
 	queryEscaped := url_pkg.QueryEscape(s.metadata.Query)
-	url := fmt.Sprintf("%s/api/v1/query?query=%s&time=%s", s.metadata.ServerAddress, queryEscaped, t)
+	url := fmt.Sprintf("%s/api/v1/query?query=%s&time=%s", serverAddress, queryEscaped, t)
 
 	// set 'namespace' parameter for namespaced Prometheus requests (e.g. for Thanos Querier)
 	if s.metadata.Namespace != "" {
@@ -209,25 +375,23 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 		url = fmt.Sprintf("%s&%s=%s", url, queryParameterKeyEscaped, queryParameterValueEscaped)
 	}
 
+	if s.metadata.Protocol == promProtocolVictoriaMetrics {
+		for labelName, labelValue := range s.metadata.ExtraLabels {
+			url = fmt.Sprintf("%s&extra_label=%s", url, url_pkg.QueryEscape(labelName+"="+labelValue))
+		}
+		for _, filter := range s.metadata.ExtraFilters {
+			url = fmt.Sprintf("%s&%s=%s", url, url_pkg.QueryEscape("extra_filters[]"), url_pkg.QueryEscape(filter))
+		}
+		if s.metadata.DenyPartialResponse {
+			url = fmt.Sprintf("%s&deny_partial_response=1", url)
+		}
+	}
+
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return -1, err
 	}
-
-	for headerName, headerValue := range s.metadata.CustomHeaders {
-		req.Header.Add(headerName, headerValue)
-	}
-
-	switch {
-	case s.metadata.PrometheusAuth.Disabled():
-		break
-	case s.metadata.PrometheusAuth.EnabledBearerAuth():
-		req.Header.Set("Authorization", s.metadata.PrometheusAuth.GetBearerToken())
-	case s.metadata.PrometheusAuth.EnabledBasicAuth():
-		req.SetBasicAuth(s.metadata.PrometheusAuth.Username, s.metadata.PrometheusAuth.Password)
-	case s.metadata.PrometheusAuth.EnabledCustomAuth():
-		req.Header.Set(s.metadata.PrometheusAuth.CustomAuthHeader, s.metadata.PrometheusAuth.CustomAuthValue)
-	}
+	s.setRequestHeaders(req, tenantID)
 
 	r, err := s.httpClient.Do(req)
 	if err != nil {
@@ -252,6 +416,10 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 		return -1, err
 	}
 
+	if result.IsPartial {
+		s.logger.V(1).Info("victoriametrics returned a partial response", "query", s.metadata.Query)
+	}
+
 	var v float64 = -1
 
 	// allow for zero element or single element result sets
@@ -296,6 +464,166 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 	return v, nil
 }
 
+// setRequestHeaders sets the custom headers, the tenant header and the authentication of the request,
+// the credentials of the tenant take precedence over the authentication of the trigger
+func (s *prometheusScaler) setRequestHeaders(req *http.Request, tenantID string) {
+	for headerName, headerValue := range s.metadata.CustomHeaders {
+		req.Header.Add(headerName, headerValue)
+	}
+
+	if tenantID != "" && s.metadata.Protocol != promProtocolVictoriaMetrics {
+		tenantHeader := s.metadata.TenantHeader
+		if tenantHeader == "" {
+			tenantHeader = promDefaultTenantHeader
+		}
+		req.Header.Set(tenantHeader, tenantID)
+	}
+
+	tenantAuth, hasTenantAuth := s.metadata.tenantAuth[tenantID]
+	switch {
+	case hasTenantAuth && tenantAuth.bearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+tenantAuth.bearerToken)
+	case hasTenantAuth:
+		req.SetBasicAuth(tenantAuth.username, tenantAuth.password)
+	case s.metadata.PrometheusAuth.Disabled():
+		break
+	case s.metadata.PrometheusAuth.EnabledBearerAuth():
+		req.Header.Set("Authorization", s.metadata.PrometheusAuth.GetBearerToken())
+	case s.metadata.PrometheusAuth.EnabledBasicAuth():
+		req.SetBasicAuth(s.metadata.PrometheusAuth.Username, s.metadata.PrometheusAuth.Password)
+	case s.metadata.PrometheusAuth.EnabledCustomAuth():
+		req.Header.Set(s.metadata.PrometheusAuth.CustomAuthHeader, s.metadata.PrometheusAuth.CustomAuthValue)
+	}
+}
+
+// executeRemoteRead reads the samples of the series selected by the query over the lookback with the
+// remote-read API, the value is the latest sample of the series, or the aggregation of the series
+func (s *prometheusScaler) executeRemoteRead(ctx context.Context, tenantID string) (float64, error) {
+	matchers, err := parser.ParseMetricSelector(s.metadata.Query)
+	if err != nil {
+		return -1, err
+	}
+	query := &prompb.Query{
+		EndTimestampMs:   time.Now().UnixMilli(),
+		StartTimestampMs: time.Now().Add(-time.Duration(s.metadata.RemoteReadLookbackSeconds) * time.Second).UnixMilli(),
+	}
+	for _, matcher := range matchers {
+		query.Matchers = append(query.Matchers, toRemoteReadLabelMatcher(matcher))
+	}
+	readRequest := &prompb.ReadRequest{
+		Queries:               []*prompb.Query{query},
+		AcceptedResponseTypes: []prompb.ReadRequest_ResponseType{prompb.ReadRequest_SAMPLES},
+	}
+	data, err := readRequest.Marshal()
+	if err != nil {
+		return -1, err
+	}
+
+	url := fmt.Sprintf("%s/api/v1/read", strings.TrimSuffix(s.metadata.ServerAddress, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(snappy.Encode(nil, data)))
+	if err != nil {
+		return -1, err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Read-Version", promRemoteReadVersion)
+	s.setRequestHeaders(req, tenantID)
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return -1, err
+	}
+	defer r.Body.Close()
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return -1, err
+	}
+
+	if !(r.StatusCode >= 200 && r.StatusCode <= 299) {
+		err := fmt.Errorf("prometheus remote read api returned error. status: %d response: %s", r.StatusCode, string(b))
+		s.logger.Error(err, "prometheus remote read api returned error")
+		return -1, err
+	}
+
+	decoded, err := snappy.Decode(nil, b)
+	if err != nil {
+		return -1, fmt.Errorf("error decompressing the prometheus remote read response: %w", err)
+	}
+	var readResponse prompb.ReadResponse
+	if err := readResponse.Unmarshal(decoded); err != nil {
+		return -1, fmt.Errorf("error parsing the prometheus remote read response: %w", err)
+	}
+
+	// the series whose latest sample is a staleness marker are gone
+	var values []float64
+	for _, result := range readResponse.Results {
+		for _, series := range result.Timeseries {
+			if len(series.Samples) == 0 {
+				continue
+			}
+			latest := series.Samples[len(series.Samples)-1]
+			if promvalue.IsStaleNaN(latest.Value) {
+				continue
+			}
+			values = append(values, latest.Value)
+		}
+	}
+
+	if len(values) == 0 {
+		if s.metadata.IgnoreNullValues {
+			return 0, nil
+		}
+		return -1, fmt.Errorf("prometheus metrics 'prometheus' target may be lost, the result is empty")
+	} else if len(values) > 1 && s.metadata.SeriesAggregation == "" {
+		return -1, fmt.Errorf("prometheus query %s returned multiple elements", s.metadata.Query)
+	}
+
+	v := aggregateRemoteReadValues(values, s.metadata.SeriesAggregation)
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		if s.metadata.IgnoreNullValues {
+			return 0, nil
+		}
+		err := fmt.Errorf("promtheus query returns %f", v)
+		s.logger.Error(err, "Error converting prometheus value")
+		return -1, err
+	}
+	return v, nil
+}
+
+func toRemoteReadLabelMatcher(matcher *labels.Matcher) *prompb.LabelMatcher {
+	labelMatcher := &prompb.LabelMatcher{Name: matcher.Name, Value: matcher.Value}
+	switch matcher.Type {
+	case labels.MatchEqual:
+		labelMatcher.Type = prompb.LabelMatcher_EQ
+	case labels.MatchNotEqual:
+		labelMatcher.Type = prompb.LabelMatcher_NEQ
+	case labels.MatchRegexp:
+		labelMatcher.Type = prompb.LabelMatcher_RE
+	case labels.MatchNotRegexp:
+		labelMatcher.Type = prompb.LabelMatcher_NRE
+	}
+	return labelMatcher
+}
+
+// aggregateRemoteReadValues aggregates the latest samples of the series, a single sample is kept as is
+func aggregateRemoteReadValues(values []float64, aggregation string) float64 {
+	v := values[0]
+	for _, sample := range values[1:] {
+		switch aggregation {
+		case "min":
+			v = math.Min(v, sample)
+		case "max":
+			v = math.Max(v, sample)
+		default:
+			v += sample
+		}
+	}
+	if aggregation == "avg" {
+		v /= float64(len(values))
+	}
+	return v
+}
+
 func (s *prometheusScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
 	val, err := s.ExecutePromQuery(ctx)
 	if err != nil {
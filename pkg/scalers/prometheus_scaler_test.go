@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -13,6 +15,9 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/golang/snappy"
+	promvalue "github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
@@ -64,6 +69,38 @@ var testPromMetadata = []parsePrometheusMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "queryParameters": "key1=value1,key2=value2"}, false},
 	// queryParameters with wrong format
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "queryParameters": "key1=value1,key2"}, true},
+	// tenantID
+	{map[string]string{"serverAddress": "http://mimir:8080/prometheus", "threshold": "100", "query": "up", "tenantID": "team-a"}, false},
+	// tenantHeader without tenantID
+	{map[string]string{"serverAddress": "http://thanos:9090", "threshold": "100", "query": "up", "tenantHeader": "THANOS-TENANT"}, true},
+	// unknown protocol
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "protocol": "graphite"}, true},
+	// remoteRead with a series selector
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": `http_requests_total{job="api",code=~"5.."}`, "protocol": "remoteRead", "seriesAggregation": "sum"}, false},
+	// remoteRead with a function
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "sum(rate(http_requests_total[5m]))", "protocol": "remoteRead"}, true},
+	// remoteRead with namespace
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "protocol": "remoteRead", "namespace": "foo"}, true},
+	// seriesAggregation with the query protocol
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "seriesAggregation": "sum"}, true},
+	// victoriaMetrics with a cluster tenant and extensions
+	{map[string]string{"serverAddress": "http://vmselect:8481", "threshold": "100", "query": "rollup_rate(http_requests_total)", "protocol": "victoriaMetrics", "tenantID": "42:7", "extraLabels": "env=prod", "extraFilters": `{job="api"};{instance!~"canary.*"}`, "denyPartialResponse": "true"}, false},
+	// victoriaMetrics with an invalid tenant
+	{map[string]string{"serverAddress": "http://vmselect:8481", "threshold": "100", "query": "up", "protocol": "victoriaMetrics", "tenantID": "team-a"}, true},
+	// extraLabels with the query protocol
+	{map[string]string{"serverAddress": "http://localhost:9090", "threshold": "100", "query": "up", "extraLabels": "env=prod"}, true},
+	// tenantIDs
+	{map[string]string{"serverAddress": "http://mimir:8080/prometheus", "threshold": "100", "query": "up", "tenantIDs": "team-a,team-b", "tenantAggregation": "max"}, false},
+	// tenantIDs with tenantID
+	{map[string]string{"serverAddress": "http://mimir:8080/prometheus", "threshold": "100", "query": "up", "tenantIDs": "team-a,team-b", "tenantID": "team-c"}, true},
+	// tenantAggregation without tenantIDs
+	{map[string]string{"serverAddress": "http://mimir:8080/prometheus", "threshold": "100", "query": "up", "tenantAggregation": "sum"}, true},
+	// invalid tenantAggregation
+	{map[string]string{"serverAddress": "http://mimir:8080/prometheus", "threshold": "100", "query": "up", "tenantIDs": "team-a,team-b", "tenantAggregation": "avg"}, true},
+	// victoriaMetrics tenantIDs
+	{map[string]string{"serverAddress": "http://vmselect:8481", "threshold": "100", "query": "up", "protocol": "victoriaMetrics", "tenantIDs": "1,2:3"}, false},
+	// victoriaMetrics with an invalid tenant of tenantIDs
+	{map[string]string{"serverAddress": "http://vmselect:8481", "threshold": "100", "query": "up", "protocol": "victoriaMetrics", "tenantIDs": "1,team-b"}, true},
 }
 
 var prometheusMetricIdentifiers = []prometheusMetricIdentifier{
@@ -544,3 +581,241 @@ IisErx3ap2o99Zn+Yotv/TGZkS+lfMLdbcOBr8a57Q==
 		})
 	}
 }
+
+func TestPrometheusScalerExecuteRemoteRead(t *testing.T) {
+	series := func(sampleValues ...float64) prompb.TimeSeries {
+		ts := prompb.TimeSeries{Labels: []prompb.Label{{Name: "__name__", Value: "http_requests_total"}}}
+		for i, v := range sampleValues {
+			ts.Samples = append(ts.Samples, prompb.Sample{Timestamp: time.Now().Add(time.Duration(i-len(sampleValues)) * time.Second).UnixMilli(), Value: v})
+		}
+		return ts
+	}
+
+	testCases := []struct {
+		name              string
+		timeseries        []prompb.TimeSeries
+		seriesAggregation string
+		expectedValue     float64
+		isError           bool
+	}{
+		{"latest sample", []prompb.TimeSeries{series(3, 5, 8)}, "", 8, false},
+		{"no series", nil, "", 0, false},
+		{"stale series", []prompb.TimeSeries{series(3, math.Float64frombits(promvalue.StaleNaN)), series(4)}, "", 4, false},
+		{"multiple series", []prompb.TimeSeries{series(3), series(4)}, "", -1, true},
+		{"summed series", []prompb.TimeSeries{series(3), series(1, 4)}, "sum", 7, false},
+		{"average of the series", []prompb.TimeSeries{series(3), series(5)}, "avg", 4, false},
+		{"maximum of the series", []prompb.TimeSeries{series(3), series(5)}, "max", 5, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, http.MethodPost, request.Method)
+				assert.Equal(t, "/prometheus/api/v1/read", request.URL.Path)
+				assert.Equal(t, "snappy", request.Header.Get("Content-Encoding"))
+				assert.Equal(t, "application/x-protobuf", request.Header.Get("Content-Type"))
+				assert.Equal(t, "0.1.0", request.Header.Get("X-Prometheus-Remote-Read-Version"))
+				assert.Equal(t, "team-a", request.Header.Get("X-Scope-OrgID"))
+
+				body, err := io.ReadAll(request.Body)
+				require.NoError(t, err)
+				decoded, err := snappy.Decode(nil, body)
+				require.NoError(t, err)
+				var readRequest prompb.ReadRequest
+				require.NoError(t, readRequest.Unmarshal(decoded))
+				require.Len(t, readRequest.Queries, 1)
+				query := readRequest.Queries[0]
+				assert.Equal(t, int64(300000), query.EndTimestampMs-query.StartTimestampMs)
+				assert.ElementsMatch(t, []*prompb.LabelMatcher{
+					{Type: prompb.LabelMatcher_EQ, Name: "job", Value: "api"},
+					{Type: prompb.LabelMatcher_RE, Name: "code", Value: "5.."},
+					{Type: prompb.LabelMatcher_EQ, Name: "__name__", Value: "http_requests_total"},
+				}, query.Matchers)
+
+				readResponse := prompb.ReadResponse{Results: []*prompb.QueryResult{{}}}
+				for i := range tc.timeseries {
+					readResponse.Results[0].Timeseries = append(readResponse.Results[0].Timeseries, &tc.timeseries[i])
+				}
+				data, err := readResponse.Marshal()
+				require.NoError(t, err)
+				writer.Header().Set("Content-Type", "application/x-protobuf")
+				writer.Header().Set("Content-Encoding", "snappy")
+				_, err = writer.Write(snappy.Encode(nil, data))
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			metadata := map[string]string{
+				"serverAddress": server.URL + "/prometheus",
+				"query":         `http_requests_total{job="api",code=~"5.."}`,
+				"threshold":     "10",
+				"protocol":      "remoteRead",
+				"tenantID":      "team-a",
+			}
+			if tc.seriesAggregation != "" {
+				metadata["seriesAggregation"] = tc.seriesAggregation
+			}
+			meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata})
+			require.NoError(t, err)
+			scaler := prometheusScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+			value, err := scaler.ExecutePromQuery(context.TODO())
+			assert.Equal(t, tc.expectedValue, value)
+			if tc.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPrometheusScalerTenantHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "team-a", request.Header.Get("THANOS-TENANT"))
+		assert.Empty(t, request.Header.Get("X-Scope-OrgID"))
+		_, err := writer.Write([]byte(`{"data":{"result":[{"value": ["1", "2"]}]}}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+		"serverAddress": server.URL,
+		"query":         "up",
+		"threshold":     "10",
+		"tenantHeader":  "THANOS-TENANT",
+	}, AuthParams: map[string]string{"tenantID": "team-a"}})
+	require.NoError(t, err)
+	scaler := prometheusScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+	value, err := scaler.ExecutePromQuery(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), value)
+}
+
+func TestPrometheusScalerExecuteVictoriaMetricsQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "/select/42:7/prometheus/api/v1/query", request.URL.Path)
+		assert.Empty(t, request.Header.Get("X-Scope-OrgID"))
+		queryParameter := request.URL.Query()
+		assert.Equal(t, "rollup_rate(http_requests_total)", queryParameter.Get("query"))
+		assert.Equal(t, []string{"env=prod"}, queryParameter["extra_label"])
+		assert.Equal(t, []string{`{job="api"}`, `{instance!~"canary.*"}`}, queryParameter["extra_filters[]"])
+		assert.Equal(t, "1", queryParameter.Get("deny_partial_response"))
+		_, err := writer.Write([]byte(`{"status":"success","isPartial":false,"data":{"resultType":"vector","result":[{"metric":{},"value":[1686063687,"12"]}]}}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+		"serverAddress":       server.URL,
+		"query":               "rollup_rate(http_requests_total)",
+		"threshold":           "10",
+		"protocol":            "victoriaMetrics",
+		"tenantID":            "42:7",
+		"extraLabels":         "env=prod",
+		"extraFilters":        `{job="api"};{instance!~"canary.*"}`,
+		"denyPartialResponse": "true",
+	}})
+	require.NoError(t, err)
+	scaler := prometheusScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+	value, err := scaler.ExecutePromQuery(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(12), value)
+}
+
+func TestPrometheusParseTenantAuth(t *testing.T) {
+	metadata := map[string]string{"serverAddress": "http://mimir:8080/prometheus", "threshold": "100", "query": "up", "tenantIDs": "team-a,team-b,team-c"}
+
+	meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{
+		"bearerToken.team-a": "token-a",
+		"username.team-b":    "team-b",
+		"password.team-b":    "password-b",
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]prometheusTenantAuth{
+		"team-a": {bearerToken: "token-a"},
+		"team-b": {username: "team-b", password: "password-b"},
+	}, meta.tenantAuth)
+
+	_, err = parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{
+		"bearerToken.team-a": "token-a",
+		"username.team-a":    "team-a",
+	}})
+	assert.Error(t, err)
+
+	_, err = parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{
+		"password.team-a": "password-a",
+	}})
+	assert.Error(t, err)
+}
+
+func TestPrometheusScalerExecuteTenantQueries(t *testing.T) {
+	tenantValues := map[string]string{"team-a": "3", "team-b": "7", "team-c": "5"}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		tenantID := request.Header.Get("X-Scope-OrgID")
+		switch tenantID {
+		case "team-a":
+			assert.Equal(t, "Bearer token-a", request.Header.Get("Authorization"))
+		case "team-b":
+			user, password, ok := request.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "team-b", user)
+			assert.Equal(t, "password-b", password)
+		default:
+			assert.Equal(t, "Bearer shared-token", request.Header.Get("Authorization"))
+		}
+		value, ok := tenantValues[tenantID]
+		if !ok {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, err := writer.Write([]byte(fmt.Sprintf(`{"data":{"result":[{"value": ["1", %q]}]}}`, value)))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name              string
+		tenantIDs         string
+		tenantAggregation string
+		expectedValue     float64
+		isError           bool
+	}{
+		{"sum of the tenants", "team-a,team-b,team-c", "", 15, false},
+		{"maximum of the tenants", "team-a,team-b,team-c", "max", 7, false},
+		{"unknown tenant", "team-a,team-d", "", -1, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata := map[string]string{
+				"serverAddress": server.URL,
+				"query":         "sum(jobs_queued)",
+				"threshold":     "10",
+				"authModes":     "bearer",
+				"tenantIDs":     tc.tenantIDs,
+			}
+			if tc.tenantAggregation != "" {
+				metadata["tenantAggregation"] = tc.tenantAggregation
+			}
+			meta, err := parsePrometheusMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{
+				"bearerToken":        "shared-token",
+				"bearerToken.team-a": "token-a",
+				"username.team-b":    "team-b",
+				"password.team-b":    "password-b",
+			}})
+			require.NoError(t, err)
+			scaler := prometheusScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+			value, err := scaler.ExecutePromQuery(context.TODO())
+			assert.Equal(t, tc.expectedValue, value)
+			if tc.isError {
+				assert.ErrorContains(t, err, "error querying tenant team-d")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +36,16 @@ type pulsarMetadata struct {
 	subscription                  string
 	msgBacklogThreshold           int64
 	activationMsgBacklogThreshold int64
+	isPartitionedTopic            bool
+
+	// maxMsgBacklogPerPartition caps the backlog counted for each partition of a partitioned topic, as
+	// the backlog of a partition can only be consumed as fast as its consumers allow
+	maxMsgBacklogPerPartition int64
+
+	// limitToKeysWithBacklog limits the backlog of a Key_Shared subscription to the keys it has in its
+	// backlog, as the messages of a key are only dispatched to a single consumer
+	limitToKeysWithBacklog bool
+	keySampleSize          int64
 
 	pulsarAuth *authentication.AuthMeta
 
@@ -48,6 +60,11 @@ const (
 	enable                     = "enable"
 	stringTrue                 = "true"
 	pulsarAuthModeHeader       = "X-Pulsar-Auth-Method-Name"
+	defaultPulsarKeySampleSize = 100
+	maxPulsarKeySampleSize     = 1000
+	pulsarKeySharedType        = "Key_Shared"
+	pulsarPartitionKeyHeader   = "X-Pulsar-partition-key"
+	pulsarOrderingKeyHeader    = "X-Pulsar-ordering-key"
 )
 
 type pulsarSubscription struct {
@@ -90,7 +107,9 @@ type pulsarStats struct {
 	Backlogsize       int                           `json:"backlogSize"`
 	Publishers        []interface{}                 `json:"publishers"`
 	Subscriptions     map[string]pulsarSubscription `json:"subscriptions"`
-	Replication       struct {
+	// Partitions are the stats of each partition of a partitioned topic
+	Partitions  map[string]pulsarStats `json:"partitions"`
+	Replication struct {
 	} `json:"replication"`
 	Deduplicationstatus string `json:"deduplicationStatus"`
 }
@@ -153,7 +172,8 @@ func parsePulsarMetadata(config *scalersconfig.ScalerConfig, _ logr.Logger) (pul
 	}
 
 	topic := strings.ReplaceAll(meta.topic, "persistent://", "")
-	if config.TriggerMetadata["isPartitionedTopic"] == stringTrue {
+	meta.isPartitionedTopic = config.TriggerMetadata["isPartitionedTopic"] == stringTrue
+	if meta.isPartitionedTopic {
 		meta.statsURL = meta.adminURL + "/admin/v2/persistent/" + topic + "/partitioned-stats"
 	} else {
 		meta.statsURL = meta.adminURL + "/admin/v2/persistent/" + topic + "/stats"
@@ -189,6 +209,44 @@ func parsePulsarMetadata(config *scalersconfig.ScalerConfig, _ logr.Logger) (pul
 		meta.msgBacklogThreshold = t
 	}
 
+	if val, ok := config.TriggerMetadata["maxMsgBacklogPerPartition"]; ok {
+		t, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing %s: %w", "maxMsgBacklogPerPartition", err)
+		}
+		if t <= 0 {
+			return meta, fmt.Errorf("maxMsgBacklogPerPartition must be greater than 0")
+		}
+		if !meta.isPartitionedTopic {
+			return meta, fmt.Errorf("maxMsgBacklogPerPartition requires isPartitionedTopic")
+		}
+		meta.maxMsgBacklogPerPartition = t
+	}
+
+	if val, ok := config.TriggerMetadata["limitToKeysWithBacklog"]; ok {
+		t, err := strconv.ParseBool(val)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing %s: %w", "limitToKeysWithBacklog", err)
+		}
+		// the messages can only be peeked from the partitions of a partitioned topic
+		if t && meta.isPartitionedTopic {
+			return meta, fmt.Errorf("limitToKeysWithBacklog isn't supported with isPartitionedTopic")
+		}
+		meta.limitToKeysWithBacklog = t
+	}
+
+	meta.keySampleSize = defaultPulsarKeySampleSize
+	if val, ok := config.TriggerMetadata["keySampleSize"]; ok {
+		t, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing %s: %w", "keySampleSize", err)
+		}
+		if t < 1 || t > maxPulsarKeySampleSize {
+			return meta, fmt.Errorf("keySampleSize must be between 1 and %d", maxPulsarKeySampleSize)
+		}
+		meta.keySampleSize = t
+	}
+
 	// For backwards compatibility, we need to map "tls: enable" to
 	if tls, ok := config.TriggerMetadata["tls"]; ok {
 		if tls == enable && (config.AuthParams["cert"] != "" || config.AuthParams["key"] != "") {
@@ -232,12 +290,11 @@ func parsePulsarMetadata(config *scalersconfig.ScalerConfig, _ logr.Logger) (pul
 	return meta, nil
 }
 
-func (s *pulsarScaler) GetStats(ctx context.Context) (*pulsarStats, error) {
-	stats := new(pulsarStats)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", s.metadata.statsURL, nil)
+// get sends a request to the admin api with the authentication of the scaler
+func (s *pulsarScaler) get(ctx context.Context, requestURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error requesting stats from admin url: %w", err)
+		return nil, err
 	}
 
 	client := s.httpClient
@@ -253,7 +310,13 @@ func (s *pulsarScaler) GetStats(ctx context.Context) (*pulsarStats, error) {
 	}
 	addAuthHeaders(req, &s.metadata)
 
-	res, err := client.Do(req)
+	return client.Do(req)
+}
+
+func (s *pulsarScaler) GetStats(ctx context.Context) (*pulsarStats, error) {
+	stats := new(pulsarStats)
+
+	res, err := s.get(ctx, s.metadata.statsURL)
 	if err != nil {
 		return nil, fmt.Errorf("error requesting stats from admin url: %w", err)
 	}
@@ -292,8 +355,81 @@ func (s *pulsarScaler) getMsgBackLog(ctx context.Context) (int64, bool, error) {
 	}
 
 	v, found := stats.Subscriptions[s.metadata.subscription]
+	if !found {
+		return 0, false, nil
+	}
+
+	msgBacklog := v.Msgbacklog
+	if s.metadata.maxMsgBacklogPerPartition > 0 {
+		msgBacklog = s.getCappedPartitionsBacklog(stats)
+	}
+
+	if s.metadata.limitToKeysWithBacklog && msgBacklog > 0 {
+		if v.Type != pulsarKeySharedType {
+			s.logger.V(1).Info("limitToKeysWithBacklog only applies to Key_Shared subscriptions", "subscription", s.metadata.subscription, "type", v.Type)
+			return msgBacklog, true, nil
+		}
+		keys, err := s.getKeysWithBacklog(ctx, v.Msgbacklog)
+		if err != nil {
+			return 0, false, err
+		}
+		// don't scale out beyond a consumer for each key with backlog
+		msgBacklog = min(msgBacklog, keys*s.metadata.msgBacklogThreshold)
+	}
 
-	return v.Msgbacklog, found, nil
+	return msgBacklog, true, nil
+}
+
+// getCappedPartitionsBacklog returns the backlog of the subscription summed over the partitions, each
+// capped at maxMsgBacklogPerPartition
+func (s *pulsarScaler) getCappedPartitionsBacklog(stats *pulsarStats) int64 {
+	var msgBacklog int64
+	for _, partition := range stats.Partitions {
+		if v, found := partition.Subscriptions[s.metadata.subscription]; found {
+			msgBacklog += min(v.Msgbacklog, s.metadata.maxMsgBacklogPerPartition)
+		}
+	}
+	return msgBacklog
+}
+
+// getKeysWithBacklog estimates the number of keys in the backlog of the subscription, the keys of the next
+// messages are sampled by peeking them and the keys of the rest of the backlog are extrapolated from them
+func (s *pulsarScaler) getKeysWithBacklog(ctx context.Context, msgBacklog int64) (int64, error) {
+	topic := strings.ReplaceAll(s.metadata.topic, "persistent://", "")
+	positionURL := s.metadata.adminURL + "/admin/v2/persistent/" + topic + "/subscription/" + url.PathEscape(s.metadata.subscription) + "/position/"
+
+	keys := map[string]bool{}
+	var sampled int64
+	for sampled < min(msgBacklog, s.metadata.keySampleSize) {
+		res, err := s.get(ctx, positionURL+strconv.FormatInt(sampled+1, 10))
+		if err != nil {
+			return 0, fmt.Errorf("error peeking message from admin url: %w", err)
+		}
+		_, _ = io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+		// the backlog may have been consumed since the stats were read
+		if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusConflict {
+			break
+		}
+		if res.StatusCode != http.StatusOK {
+			return 0, fmt.Errorf("error peeking message from admin url, response status is: %s", res.Status)
+		}
+
+		// the messages are dispatched on their ordering key when set, else on their partition key
+		key := res.Header.Get(pulsarOrderingKeyHeader)
+		if key == "" {
+			key = res.Header.Get(pulsarPartitionKeyHeader)
+		}
+		keys[key] = true
+		sampled++
+	}
+
+	if sampled == 0 {
+		return 0, nil
+	}
+	sampledKeys := int64(len(keys))
+	estimated := int64(math.Ceil(float64(sampledKeys) * float64(msgBacklog) / float64(sampled)))
+	return max(sampledKeys, min(estimated, msgBacklog)), nil
 }
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
@@ -3,12 +3,15 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 )
@@ -80,6 +83,21 @@ var parsePulsarMetadataTestDataset = []parsePulsarMetadataTestData{
 
 	// tls
 	{map[string]string{"adminURL": "https://localhost:8443", "tls": "enable", "cert": "certdata", "key": "keydata", "ca": "cadata", "topic": "persistent://public/default/my-topic", "subscription": "sub1"}, false, true, false, "https://localhost:8443", "persistent://public/default/my-topic", "sub1"},
+
+	// maxMsgBacklogPerPartition
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "isPartitionedTopic": "true", "subscription": "sub1", "maxMsgBacklogPerPartition": "100"}, false, false, true, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
+	// maxMsgBacklogPerPartition without isPartitionedTopic
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "subscription": "sub1", "maxMsgBacklogPerPartition": "100"}, true, false, false, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
+	// invalid maxMsgBacklogPerPartition
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "isPartitionedTopic": "true", "subscription": "sub1", "maxMsgBacklogPerPartition": "0"}, true, false, true, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
+	// limitToKeysWithBacklog
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "subscription": "sub1", "limitToKeysWithBacklog": "true", "keySampleSize": "50"}, false, false, false, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
+	// limitToKeysWithBacklog with isPartitionedTopic
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "isPartitionedTopic": "true", "subscription": "sub1", "limitToKeysWithBacklog": "true"}, true, false, true, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
+	// invalid limitToKeysWithBacklog
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "subscription": "sub1", "limitToKeysWithBacklog": "yes"}, true, false, false, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
+	// keySampleSize out of range
+	{map[string]string{"adminURL": "http://127.0.0.1:8080", "topic": "persistent://public/default/my-topic", "subscription": "sub1", "limitToKeysWithBacklog": "true", "keySampleSize": "5000"}, true, false, false, "http://127.0.0.1:8080", "persistent://public/default/my-topic", "sub1"},
 }
 
 var parsePulsarMetadataTestAuthTLSDataset = []parsePulsarAuthParamsTestData{
@@ -414,3 +432,59 @@ func TestPulsarGetMetric(t *testing.T) {
 		fmt.Printf("%+v\n", metric)
 	}
 }
+
+func TestPulsarGetMsgBacklogWithLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/admin/v2/persistent/public/default/partitioned/partitioned-stats":
+			fmt.Fprint(w, `{"subscriptions":{"sub1":{"msgBacklog":1250}},"partitions":{`+
+				`"persistent://public/default/partitioned-partition-0":{"subscriptions":{"sub1":{"msgBacklog":1000}}},`+
+				`"persistent://public/default/partitioned-partition-1":{"subscriptions":{"sub1":{"msgBacklog":200}}},`+
+				`"persistent://public/default/partitioned-partition-2":{"subscriptions":{"sub1":{"msgBacklog":50}}}}}`)
+		case "/admin/v2/persistent/public/default/keyed/stats":
+			fmt.Fprint(w, `{"subscriptions":{"shared":{"msgBacklog":400,"type":"Key_Shared"},"drained":{"msgBacklog":4,"type":"Key_Shared"},"failover":{"msgBacklog":400,"type":"Failover"}}}`)
+		case "/admin/v2/persistent/public/default/keyed/subscription/shared/position/1",
+			"/admin/v2/persistent/public/default/keyed/subscription/shared/position/3",
+			"/admin/v2/persistent/public/default/keyed/subscription/drained/position/1",
+			"/admin/v2/persistent/public/default/keyed/subscription/drained/position/3":
+			w.Header().Set(pulsarPartitionKeyHeader, "key-a")
+		case "/admin/v2/persistent/public/default/keyed/subscription/shared/position/2",
+			"/admin/v2/persistent/public/default/keyed/subscription/drained/position/2":
+			w.Header().Set(pulsarOrderingKeyHeader, "key-b")
+			w.Header().Set(pulsarPartitionKeyHeader, "key-a")
+		case "/admin/v2/persistent/public/default/keyed/subscription/shared/position/4",
+			"/admin/v2/persistent/public/default/keyed/subscription/drained/position/4":
+			w.Header().Set(pulsarPartitionKeyHeader, "key-b")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		metadata map[string]string
+		expected int64
+	}{
+		{"partitions backlog", map[string]string{"topic": "persistent://public/default/partitioned", "isPartitionedTopic": "true", "subscription": "sub1", "msgBacklogThreshold": "100"}, 1250},
+		{"partitions capped backlog", map[string]string{"topic": "persistent://public/default/partitioned", "isPartitionedTopic": "true", "subscription": "sub1", "msgBacklogThreshold": "100", "maxMsgBacklogPerPartition": "100"}, 250},
+		{"all the keys sampled", map[string]string{"topic": "persistent://public/default/keyed", "subscription": "drained", "msgBacklogThreshold": "1", "limitToKeysWithBacklog": "true"}, 2},
+		{"keys extrapolated", map[string]string{"topic": "persistent://public/default/keyed", "subscription": "shared", "msgBacklogThreshold": "1", "limitToKeysWithBacklog": "true", "keySampleSize": "4"}, 200},
+		{"keys extrapolated with a larger threshold", map[string]string{"topic": "persistent://public/default/keyed", "subscription": "shared", "msgBacklogThreshold": "100", "limitToKeysWithBacklog": "true", "keySampleSize": "4"}, 400},
+		{"keys of a subscription which isn't Key_Shared", map[string]string{"topic": "persistent://public/default/keyed", "subscription": "failover", "msgBacklogThreshold": "1", "limitToKeysWithBacklog": "true"}, 400},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["adminURL"] = server.URL
+			meta, err := parsePulsarMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: validPulsarWithoutAuthParams}, logr.Discard())
+			assert.NoError(t, err)
+			mockPulsarScaler := pulsarScaler{meta, server.Client(), logr.Discard()}
+
+			msgBacklog, found, err := mockPulsarScaler.getMsgBackLog(context.Background())
+			assert.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, tc.expected, msgBacklog)
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Package rabbitmq reads the offsets of the RabbitMQ streams, it implements the part of the stream protocol
+// querying the offset stored by a consumer and the statistics of a stream.
+package rabbitmq
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+const (
+	protocolVersion = 1
+	// maxFrameSize bounds the frames read from the server, the responses to the queries are a few bytes
+	maxFrameSize = 1024 * 1024
+
+	keyQueryOffset      = 0x000b
+	keyPeerProperties   = 0x0011
+	keySaslHandshake    = 0x0012
+	keySaslAuthenticate = 0x0013
+	keyTune             = 0x0014
+	keyOpen             = 0x0015
+	keyClose            = 0x0016
+	keyHeartbeat        = 0x0017
+	keyStreamStats      = 0x001c
+	keyResponse         = 0x8000
+
+	responseOK                 = 0x01
+	responseStreamDoesNotExist = 0x02
+	responseNoOffset           = 0x13
+)
+
+// ErrNoOffset is returned when the consumer hasn't stored an offset in the stream
+var ErrNoOffset = errors.New("rabbitmq: no offset stored by the consumer")
+
+// ResponseError is a response code other than OK returned by the server
+type ResponseError struct {
+	Key  uint16
+	Code uint16
+}
+
+func (e *ResponseError) Error() string {
+	switch e.Code {
+	case responseStreamDoesNotExist:
+		return "rabbitmq: stream does not exist"
+	default:
+		return fmt.Sprintf("rabbitmq: response code %d to the command %#04x", e.Code, e.Key)
+	}
+}
+
+// StreamClient sends the commands of the stream protocol, one at a time
+type StreamClient struct {
+	conn          net.Conn
+	r             *bufio.Reader
+	correlationID uint32
+}
+
+// DialStream connects to the stream port of the server and opens the virtual host,
+// the deadline of the context applies to the connection
+func DialStream(ctx context.Context, address string, tlsConfig *tls.Config, username, password, vhost string) (*StreamClient, error) {
+	dialer := &net.Dialer{}
+	var conn net.Conn
+	var err error
+	if tlsConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	c := newStreamClient(conn)
+	if err := c.open(username, password, vhost); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func newStreamClient(conn net.Conn) *StreamClient {
+	return &StreamClient{conn: conn, r: bufio.NewReader(conn)}
+}
+
+// open exchanges the peer properties, authenticates with PLAIN, accepts the tuning of the server and opens the virtual host
+func (c *StreamClient) open(username, password, vhost string) error {
+	properties := appendUint32(nil, 1)
+	properties = appendString(appendString(properties, "product"), "KEDA")
+	if _, err := c.request(keyPeerProperties, properties); err != nil {
+		return fmt.Errorf("error exchanging the peer properties: %w", err)
+	}
+	if _, err := c.request(keySaslHandshake, nil); err != nil {
+		return fmt.Errorf("error starting the authentication: %w", err)
+	}
+	credentials := append(append(append([]byte{0}, username...), 0), password...)
+	if _, err := c.request(keySaslAuthenticate, appendBytes(appendString(nil, "PLAIN"), credentials)); err != nil {
+		return fmt.Errorf("error authenticating: %w", err)
+	}
+
+	// the server tunes the connection once authenticated, the client answers with the same values
+	key, body, err := c.readFrame()
+	for err == nil && key == keyHeartbeat {
+		key, body, err = c.readFrame()
+	}
+	if err != nil {
+		return err
+	}
+	if key != keyTune || len(body) < 8 {
+		return fmt.Errorf("rabbitmq: unexpected command %#04x instead of tune", key)
+	}
+	if err := c.writeFrame(keyTune, body[:8]); err != nil {
+		return err
+	}
+
+	if _, err := c.request(keyOpen, appendString(nil, vhost)); err != nil {
+		return fmt.Errorf("error opening the virtual host %s: %w", vhost, err)
+	}
+	return nil
+}
+
+// QueryOffset returns the offset stored by the consumer in the stream, or ErrNoOffset
+func (c *StreamClient) QueryOffset(reference, stream string) (uint64, error) {
+	body, err := c.request(keyQueryOffset, appendString(appendString(nil, reference), stream))
+	var responseErr *ResponseError
+	if errors.As(err, &responseErr) && responseErr.Code == responseNoOffset {
+		return 0, ErrNoOffset
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(body) < 8 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return binary.BigEndian.Uint64(body), nil
+}
+
+// StreamStats returns the statistics of the stream, such as first_chunk_id and committed_chunk_id
+func (c *StreamClient) StreamStats(stream string) (map[string]int64, error) {
+	body, err := c.request(keyStreamStats, appendString(nil, stream))
+	if err != nil {
+		return nil, err
+	}
+
+	count, body, err := readUint32(body)
+	if err != nil {
+		return nil, err
+	}
+	stats := make(map[string]int64, count)
+	for i := uint32(0); i < count; i++ {
+		var key string
+		if key, body, err = readString(body); err != nil {
+			return nil, err
+		}
+		if len(body) < 8 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		stats[key] = int64(binary.BigEndian.Uint64(body))
+		body = body[8:]
+	}
+	return stats, nil
+}
+
+// Close closes the connection, telling the server first
+func (c *StreamClient) Close() error {
+	_ = c.conn.SetDeadline(time.Now().Add(time.Second))
+	c.correlationID++
+	closing := appendUint32(nil, c.correlationID)
+	closing = appendString(binary.BigEndian.AppendUint16(closing, responseOK), "OK")
+	_ = c.writeFrame(keyClose, closing)
+	return c.conn.Close()
+}
+
+// request sends a command and returns the body of its response following the response code,
+// the response codes but OK are returned as errors
+func (c *StreamClient) request(key uint16, payload []byte) ([]byte, error) {
+	c.correlationID++
+	id := c.correlationID
+	if err := c.writeFrame(key, append(appendUint32(nil, id), payload...)); err != nil {
+		return nil, err
+	}
+
+	for {
+		responseKey, body, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case responseKey == keyHeartbeat:
+			continue
+		case responseKey == keyClose:
+			reason := ""
+			if len(body) >= 6 {
+				reason, _, _ = readString(body[6:])
+			}
+			return nil, fmt.Errorf("rabbitmq: connection closed by the server: %s", reason)
+		case responseKey != key|keyResponse:
+			return nil, fmt.Errorf("rabbitmq: unexpected command %#04x in response to %#04x", responseKey, key)
+		}
+		if len(body) < 6 || binary.BigEndian.Uint32(body) != id {
+			return nil, fmt.Errorf("rabbitmq: unexpected response to the command %#04x", key)
+		}
+		if code := binary.BigEndian.Uint16(body[4:]); code != responseOK {
+			return nil, &ResponseError{Key: key, Code: code}
+		}
+		return body[6:], nil
+	}
+}
+
+func (c *StreamClient) writeFrame(key uint16, payload []byte) error {
+	frame := appendUint32(make([]byte, 0, 8+len(payload)), uint32(4+len(payload)))
+	frame = binary.BigEndian.AppendUint16(frame, key)
+	frame = binary.BigEndian.AppendUint16(frame, protocolVersion)
+	frame = append(frame, payload...)
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// readFrame returns the key of the command and its body following the version
+func (c *StreamClient) readFrame() (uint16, []byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(c.r, header); err != nil {
+		return 0, nil, err
+	}
+	size := binary.BigEndian.Uint32(header)
+	if size < 4 || size > maxFrameSize {
+		return 0, nil, fmt.Errorf("rabbitmq: invalid frame size %d", size)
+	}
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(c.r, frame); err != nil {
+		return 0, nil, err
+	}
+	return binary.BigEndian.Uint16(frame), frame[4:], nil
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return binary.BigEndian.AppendUint32(b, v)
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+func appendBytes(b []byte, data []byte) []byte {
+	b = appendUint32(b, uint32(len(data)))
+	return append(b, data...)
+}
+
+func readUint32(data []byte) (uint32, []byte, error) {
+	if len(data) < 4 {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+	return binary.BigEndian.Uint32(data), data[4:], nil
+}
+
+func readString(data []byte) (string, []byte, error) {
+	if len(data) < 2 {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+	length := int16(binary.BigEndian.Uint16(data))
+	data = data[2:]
+	if length <= 0 {
+		return "", data, nil
+	}
+	if len(data) < int(length) {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+	return string(data[:length]), data[length:], nil
+}
@@ -0,0 +1,88 @@
+package rabbitmq
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// serveStream answers the commands of the client, the consumer app has stored the offset 40 in the stream orders
+func serveStream(t *testing.T, conn net.Conn) {
+	server := newStreamClient(conn)
+	respond := func(key uint16, id uint32, code uint16, body []byte) {
+		payload := binary.BigEndian.AppendUint16(appendUint32(nil, id), code)
+		assert.NoError(t, server.writeFrame(key|keyResponse, append(payload, body...)))
+	}
+
+	for {
+		key, body, err := server.readFrame()
+		if err != nil {
+			return
+		}
+		if key == keyTune {
+			continue
+		}
+		id := binary.BigEndian.Uint32(body)
+		body = body[4:]
+		switch key {
+		case keyPeerProperties, keySaslHandshake:
+			respond(key, id, responseOK, appendUint32(nil, 0))
+		case keySaslAuthenticate:
+			mechanism, rest, _ := readString(body)
+			assert.Equal(t, "PLAIN", mechanism)
+			assert.Equal(t, "\x00guest\x00secret", string(rest[4:]))
+			respond(key, id, responseOK, nil)
+			// heartbeats can arrive between the responses
+			assert.NoError(t, server.writeFrame(keyHeartbeat, nil))
+			assert.NoError(t, server.writeFrame(keyTune, binary.BigEndian.AppendUint32(appendUint32(nil, 1048576), 60)))
+		case keyOpen:
+			vhost, _, _ := readString(body)
+			assert.Equal(t, "/", vhost)
+			respond(key, id, responseOK, appendUint32(nil, 0))
+		case keyQueryOffset:
+			reference, rest, _ := readString(body)
+			stream, _, _ := readString(rest)
+			switch {
+			case stream != "orders":
+				respond(key, id, responseStreamDoesNotExist, nil)
+			case reference != "app":
+				respond(key, id, responseNoOffset, nil)
+			default:
+				respond(key, id, responseOK, binary.BigEndian.AppendUint64(nil, 40))
+			}
+		case keyStreamStats:
+			stats := appendUint32(nil, 2)
+			stats = binary.BigEndian.AppendUint64(appendString(stats, "first_chunk_id"), 0)
+			stats = binary.BigEndian.AppendUint64(appendString(stats, "committed_chunk_id"), 100)
+			respond(key, id, responseOK, stats)
+		case keyClose:
+			return
+		}
+	}
+}
+
+func TestStreamClient(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	go serveStream(t, serverConn)
+
+	c := newStreamClient(clientConn)
+	assert.NoError(t, c.open("guest", "secret", "/"))
+
+	offset, err := c.QueryOffset("app", "orders")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(40), offset)
+
+	_, err = c.QueryOffset("other-app", "orders")
+	assert.ErrorIs(t, err, ErrNoOffset)
+
+	_, err = c.QueryOffset("app", "missing")
+	assert.EqualError(t, err, "rabbitmq: stream does not exist")
+
+	stats, err := c.StreamStats("orders")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"first_chunk_id": 0, "committed_chunk_id": 100}, stats)
+
+	assert.NoError(t, c.Close())
+}
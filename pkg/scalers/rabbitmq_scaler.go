@@ -2,13 +2,17 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"path"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -20,6 +24,7 @@ import (
 
 	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/azure"
+	"github.com/kedacore/keda/v2/pkg/scalers/rabbitmq"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
@@ -37,15 +42,20 @@ const (
 	rabbitActivationValueTriggerConfigName = "activationValue"
 	rabbitModeQueueLength                  = "QueueLength"
 	rabbitModeMessageRate                  = "MessageRate"
+	rabbitModeStreamOffsetLag              = "StreamOffsetLag"
 	defaultRabbitMQQueueLength             = 20
 	rabbitMetricType                       = "External"
 	rabbitRootVhostPath                    = "/%2F"
 	rmqTLSEnable                           = "enable"
+	rabbitQueueTypeQuorum                  = "quorum"
+	defaultRabbitMQStreamPort              = "5552"
+	defaultRabbitMQStreamTLSPort           = "5551"
 )
 
 const (
 	httpProtocol    = "http"
 	amqpProtocol    = "amqp"
+	streamProtocol  = "stream"
 	autoProtocol    = "auto"
 	defaultProtocol = autoProtocol
 )
@@ -70,11 +80,11 @@ type rabbitMQScaler struct {
 type rabbitMQMetadata struct {
 	queueName             string
 	connectionName        string        // name used for the AMQP connection
-	mode                  string        // QueueLength or MessageRate
+	mode                  string        // QueueLength, MessageRate or StreamOffsetLag
 	value                 float64       // trigger value (queue length or publish/sec. rate)
 	activationValue       float64       // activation value
 	host                  string        // connection string for either HTTP or AMQP protocol
-	protocol              string        // either http or amqp protocol
+	protocol              string        // either http, amqp or stream protocol
 	vhostName             string        // override the vhost from the connection info
 	useRegex              bool          // specify if the queueName contains a rexeg
 	excludeUnacknowledged bool          // specify if the QueueLength value should exclude Unacknowledged messages (Ready messages only)
@@ -83,6 +93,11 @@ type rabbitMQMetadata struct {
 	timeout               time.Duration // custom http timeout for a specific trigger
 	triggerIndex          int           // scaler index
 
+	// the name of the consumer whose offset is stored in the stream, for StreamOffsetLag
+	consumerName string
+	// activate the scaler when a member of the quorum queue has a memory or disk alarm
+	activateOnResourceAlarm bool
+
 	// TLS
 	ca          string
 	cert        string
@@ -104,6 +119,14 @@ type queueInfo struct {
 	MessagesUnacknowledged int         `json:"messages_unacknowledged"`
 	MessageStat            messageStat `json:"message_stats"`
 	Name                   string      `json:"name"`
+	Type                   string      `json:"type"`
+	Members                []string    `json:"members"`
+}
+
+type nodeInfo struct {
+	Name          string `json:"name"`
+	MemAlarm      bool   `json:"mem_alarm"`
+	DiskFreeAlarm bool   `json:"disk_free_alarm"`
 }
 
 type regexQueueInfo struct {
@@ -177,8 +200,8 @@ func resolveProtocol(config *scalersconfig.ScalerConfig, meta *rabbitMQMetadata)
 	if val, ok := config.TriggerMetadata["protocol"]; ok {
 		meta.protocol = val
 	}
-	if meta.protocol != amqpProtocol && meta.protocol != httpProtocol && meta.protocol != autoProtocol {
-		return fmt.Errorf("the protocol has to be either `%s`, `%s`, or `%s` but is `%s`", amqpProtocol, httpProtocol, autoProtocol, meta.protocol)
+	if meta.protocol != amqpProtocol && meta.protocol != httpProtocol && meta.protocol != streamProtocol && meta.protocol != autoProtocol {
+		return fmt.Errorf("the protocol has to be either `%s`, `%s`, `%s`, or `%s` but is `%s`", amqpProtocol, httpProtocol, streamProtocol, autoProtocol, meta.protocol)
 	}
 	return nil
 }
@@ -288,13 +311,15 @@ func parseRabbitMQMetadata(config *scalersconfig.ScalerConfig) (*rabbitMQMetadat
 			meta.protocol = amqpProtocol
 		case "http", "https":
 			meta.protocol = httpProtocol
+		case "rabbitmq-stream", "rabbitmq-stream+tls":
+			meta.protocol = streamProtocol
 		default:
 			return nil, fmt.Errorf("unknown host URL scheme `%s`", parsedURL.Scheme)
 		}
 	}
 
-	if meta.protocol == amqpProtocol && config.AuthParams["workloadIdentityResource"] != "" {
-		return nil, fmt.Errorf("workload identity is not supported for amqp protocol currently")
+	if meta.protocol != httpProtocol && config.AuthParams["workloadIdentityResource"] != "" {
+		return nil, fmt.Errorf("workload identity is not supported for %s protocol currently", meta.protocol)
 	}
 
 	// Resolve queueName
@@ -322,6 +347,13 @@ func parseRabbitMQMetadata(config *scalersconfig.ScalerConfig) (*rabbitMQMetadat
 		return nil, fmt.Errorf("configure excludeUnacknowledged=true with http protocol only")
 	}
 
+	if meta.activateOnResourceAlarm && meta.protocol != httpProtocol {
+		return nil, fmt.Errorf("configure activateOnResourceAlarm=true with http protocol only")
+	}
+
+	// Resolve consumerName
+	meta.consumerName = config.TriggerMetadata["consumerName"]
+
 	_, err = parseTrigger(&meta, config)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse trigger: %w", err)
@@ -368,6 +400,15 @@ func parseRabbitMQHttpProtocolMetadata(config *scalersconfig.ScalerConfig, meta
 		meta.pageSize = 100
 	}
 
+	// Resolve activateOnResourceAlarm
+	if val, ok := config.TriggerMetadata["activateOnResourceAlarm"]; ok {
+		activateOnResourceAlarm, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("activateOnResourceAlarm has invalid value")
+		}
+		meta.activateOnResourceAlarm = activateOnResourceAlarm
+	}
+
 	// Resolve operation
 	meta.operation = defaultOperation
 	if val, ok := config.TriggerMetadata["operation"]; ok {
@@ -389,6 +430,9 @@ func parseTrigger(meta *rabbitMQMetadata, config *scalersconfig.ScalerConfig) (*
 
 	// If nothing is specified for the trigger then return the default
 	if !deprecatedQueueLengthPresent && !modePresent && !valuePresent {
+		if meta.protocol == streamProtocol {
+			return nil, fmt.Errorf("protocol %s only supports mode %s", meta.protocol, rabbitModeStreamOffsetLag)
+		}
 		return meta, nil
 	}
 
@@ -415,6 +459,9 @@ func parseTrigger(meta *rabbitMQMetadata, config *scalersconfig.ScalerConfig) (*
 		meta.mode = rabbitModeQueueLength
 		meta.value = queueLength
 
+		if meta.protocol == streamProtocol {
+			return nil, fmt.Errorf("protocol %s only supports mode %s", meta.protocol, rabbitModeStreamOffsetLag)
+		}
 		return meta, nil
 	}
 
@@ -431,8 +478,10 @@ func parseTrigger(meta *rabbitMQMetadata, config *scalersconfig.ScalerConfig) (*
 		meta.mode = rabbitModeQueueLength
 	case rabbitModeMessageRate:
 		meta.mode = rabbitModeMessageRate
+	case rabbitModeStreamOffsetLag:
+		meta.mode = rabbitModeStreamOffsetLag
 	default:
-		return nil, fmt.Errorf("trigger mode %s must be one of %s, %s", mode, rabbitModeQueueLength, rabbitModeMessageRate)
+		return nil, fmt.Errorf("trigger mode %s must be one of %s, %s, %s", mode, rabbitModeQueueLength, rabbitModeMessageRate, rabbitModeStreamOffsetLag)
 	}
 	triggerValue, err := strconv.ParseFloat(value, 64)
 	if err != nil {
@@ -444,6 +493,18 @@ func parseTrigger(meta *rabbitMQMetadata, config *scalersconfig.ScalerConfig) (*
 		return nil, fmt.Errorf("protocol %s not supported; must be http to use mode %s", meta.protocol, rabbitModeMessageRate)
 	}
 
+	if meta.mode == rabbitModeStreamOffsetLag && meta.protocol != streamProtocol {
+		return nil, fmt.Errorf("protocol %s not supported; must be %s to use mode %s", meta.protocol, streamProtocol, rabbitModeStreamOffsetLag)
+	}
+
+	if meta.protocol == streamProtocol && meta.mode != rabbitModeStreamOffsetLag {
+		return nil, fmt.Errorf("protocol %s only supports mode %s", meta.protocol, rabbitModeStreamOffsetLag)
+	}
+
+	if meta.mode == rabbitModeStreamOffsetLag && meta.consumerName == "" {
+		return nil, fmt.Errorf("consumerName must be specified to use mode %s", rabbitModeStreamOffsetLag)
+	}
+
 	return meta, nil
 }
 
@@ -494,36 +555,153 @@ func (s *rabbitMQScaler) Close(context.Context) error {
 	return nil
 }
 
-func (s *rabbitMQScaler) getQueueStatus(ctx context.Context) (int64, float64, error) {
+func (s *rabbitMQScaler) getQueueStatus(ctx context.Context) (int64, float64, bool, error) {
 	if s.metadata.protocol == httpProtocol {
 		info, err := s.getQueueInfoViaHTTP(ctx)
 		if err != nil {
-			return -1, -1, err
+			return -1, -1, false, err
+		}
+
+		var alarm bool
+		if s.metadata.activateOnResourceAlarm && info.Type == rabbitQueueTypeQuorum {
+			alarm, err = s.getResourceAlarmViaHTTP(ctx, info.Members)
+			if err != nil {
+				return -1, -1, false, err
+			}
 		}
 
 		if s.metadata.excludeUnacknowledged {
 			// messages count includes only ready
-			return int64(info.MessagesReady), info.MessageStat.PublishDetail.Rate, nil
+			return int64(info.MessagesReady), info.MessageStat.PublishDetail.Rate, alarm, nil
 		}
 		// messages count includes count of ready and unack-ed
-		return int64(info.Messages), info.MessageStat.PublishDetail.Rate, nil
+		return int64(info.Messages), info.MessageStat.PublishDetail.Rate, alarm, nil
+	}
+
+	if s.metadata.protocol == streamProtocol {
+		lag, err := s.getStreamOffsetLag(ctx)
+		if err != nil {
+			return -1, -1, false, err
+		}
+		return lag, 0, false, nil
 	}
 
 	// QueueDeclarePassive assumes that the queue exists and fails if it doesn't
 	items, err := s.channel.QueueDeclarePassive(s.metadata.queueName, false, false, false, false, amqp.Table{})
 	if err != nil {
-		return -1, -1, err
+		return -1, -1, false, err
 	}
 
-	return int64(items.Messages), 0, nil
+	return int64(items.Messages), 0, false, nil
+}
+
+// getStreamOffsetLag returns the number of messages of the stream after the offset stored by the consumer,
+// the committed chunk id is the offset of the first message of the last chunk so the lag is a lower bound
+func (s *rabbitMQScaler) getStreamOffsetLag(ctx context.Context) (int64, error) {
+	hostURL, err := url.Parse(s.metadata.host)
+	if err != nil {
+		return -1, err
+	}
+
+	var tlsConfig *tls.Config
+	port := defaultRabbitMQStreamPort
+	if hostURL.Scheme == "rabbitmq-stream+tls" || s.metadata.enableTLS {
+		port = defaultRabbitMQStreamTLSPort
+		tlsConfig, err = kedautil.NewTLSConfigWithPassword(s.metadata.cert, s.metadata.key, s.metadata.keyPassword, s.metadata.ca, s.metadata.unsafeSsl)
+		if err != nil {
+			return -1, err
+		}
+		tlsConfig.ServerName = hostURL.Hostname()
+	}
+	if hostURL.Port() != "" {
+		port = hostURL.Port()
+	}
+
+	vhost := strings.TrimPrefix(hostURL.Path, "/")
+	if s.metadata.vhostName != "" {
+		vhost = s.metadata.vhostName
+	}
+	if vhost == "" {
+		vhost = "/"
+	}
+	password, _ := hostURL.User.Password()
+
+	ctx, cancel := context.WithTimeout(ctx, s.metadata.timeout)
+	defer cancel()
+	client, err := rabbitmq.DialStream(ctx, net.JoinHostPort(hostURL.Hostname(), port), tlsConfig, hostURL.User.Username(), password, vhost)
+	if err != nil {
+		return -1, err
+	}
+	defer client.Close()
+
+	stats, err := client.StreamStats(s.metadata.queueName)
+	if err != nil {
+		return -1, err
+	}
+	committed, ok := stats["committed_chunk_id"]
+	if !ok {
+		return -1, fmt.Errorf("the statistics of the stream %s have no committed_chunk_id", s.metadata.queueName)
+	}
+	if committed < 0 {
+		// nothing has been committed to the stream yet
+		return 0, nil
+	}
+
+	offset, err := client.QueryOffset(s.metadata.consumerName, s.metadata.queueName)
+	if errors.Is(err, rabbitmq.ErrNoOffset) {
+		// the consumer hasn't consumed the stream yet, all the retained messages are pending
+		return committed - stats["first_chunk_id"] + 1, nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return max(committed-int64(offset), 0), nil
+}
+
+// getResourceAlarmViaHTTP reports whether one of the nodes has a memory or disk alarm
+func (s *rabbitMQScaler) getResourceAlarmViaHTTP(ctx context.Context, nodes []string) (bool, error) {
+	parsedURL, err := url.Parse(s.metadata.host)
+	if err != nil {
+		return false, err
+	}
+	_, parsedURL.Path = getVhostAndPathFromURL(parsedURL.Path, s.metadata.vhostName)
+
+	var nodesInfo []nodeInfo
+	if err := getManagementJSON(ctx, s, fmt.Sprintf("%s/api/nodes?columns=name,mem_alarm,disk_free_alarm", parsedURL.String()), &nodesInfo); err != nil {
+		return false, err
+	}
+	for _, node := range nodesInfo {
+		if (node.MemAlarm || node.DiskFreeAlarm) && slices.Contains(nodes, node.Name) {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 func getJSON(ctx context.Context, s *rabbitMQScaler, url string) (queueInfo, error) {
 	var result queueInfo
 
+	if s.metadata.useRegex {
+		var queues regexQueueInfo
+		if err := getManagementJSON(ctx, s, url, &queues); err != nil {
+			return queueInfo{}, err
+		}
+		if queues.TotalPages > 1 {
+			return queueInfo{}, fmt.Errorf("regex matches more queues than can be recovered at once")
+		}
+		result, err := getComposedQueue(s, queues.Queues)
+		return result, err
+	}
+
+	err := getManagementJSON(ctx, s, url, &result)
+	return result, err
+}
+
+// getManagementJSON decodes the response of a GET request to the management API
+func getManagementJSON(ctx context.Context, s *rabbitMQScaler, url string, result any) error {
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return result, err
+		return err
 	}
 
 	if s.metadata.workloadIdentityResource != "" {
@@ -533,7 +711,7 @@ func getJSON(ctx context.Context, s *rabbitMQScaler, url string) (queueInfo, err
 
 		err = s.azureOAuth.Refresh()
 		if err != nil {
-			return result, err
+			return err
 		}
 
 		request.Header.Set("Authorization", "Bearer "+s.azureOAuth.OAuthToken())
@@ -541,31 +719,17 @@ func getJSON(ctx context.Context, s *rabbitMQScaler, url string) (queueInfo, err
 
 	r, err := s.httpClient.Do(request)
 	if err != nil {
-		return result, err
+		return err
 	}
 
 	defer r.Body.Close()
 
 	if r.StatusCode == 200 {
-		if s.metadata.useRegex {
-			var queues regexQueueInfo
-			err = json.NewDecoder(r.Body).Decode(&queues)
-			if err != nil {
-				return queueInfo{}, err
-			}
-			if queues.TotalPages > 1 {
-				return queueInfo{}, fmt.Errorf("regex matches more queues than can be recovered at once")
-			}
-			result, err := getComposedQueue(s, queues.Queues)
-			return result, err
-		}
-
-		err = json.NewDecoder(r.Body).Decode(&result)
-		return result, err
+		return json.NewDecoder(r.Body).Decode(result)
 	}
 
 	body, _ := io.ReadAll(r.Body)
-	return result, fmt.Errorf("error requesting rabbitMQ API status: %s, response: %s, from: %s", r.Status, body, url)
+	return fmt.Errorf("error requesting rabbitMQ API status: %s, response: %s, from: %s", r.Status, body, url)
 }
 
 func getVhostAndPathFromURL(rawPath, vhostName string) (resolvedVhostPath, resolvedPath string) {
@@ -630,20 +794,23 @@ func (s *rabbitMQScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpe
 
 // GetMetricsAndActivity returns value for a supported metric and an error if there is a problem getting the metric
 func (s *rabbitMQScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
-	messages, publishRate, err := s.getQueueStatus(ctx)
+	messages, publishRate, alarm, err := s.getQueueStatus(ctx)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, false, s.anonymizeRabbitMQError(err)
 	}
 
 	var metric external_metrics.ExternalMetricValue
 	var isActive bool
-	if s.metadata.mode == rabbitModeQueueLength {
-		metric = GenerateMetricInMili(metricName, float64(messages))
-		isActive = float64(messages) > s.metadata.activationValue
-	} else {
+	if s.metadata.mode == rabbitModeMessageRate {
 		metric = GenerateMetricInMili(metricName, publishRate)
 		isActive = publishRate > s.metadata.activationValue || float64(messages) > s.metadata.activationValue
+	} else {
+		// the queue length, or the offset lag of the stream
+		metric = GenerateMetricInMili(metricName, float64(messages))
+		isActive = float64(messages) > s.metadata.activationValue
 	}
+	// the consumers drain the quorum queue while its members are under memory or disk pressure
+	isActive = isActive || alarm
 
 	return []external_metrics.ExternalMetricValue{metric}, isActive, nil
 }
@@ -652,6 +819,17 @@ func getComposedQueue(s *rabbitMQScaler, q []queueInfo) (queueInfo, error) {
 	var queue = queueInfo{}
 	queue.Name = "composed-queue"
 	queue.MessagesUnacknowledged = 0
+	for _, value := range q {
+		if value.Type != rabbitQueueTypeQuorum {
+			continue
+		}
+		for _, member := range value.Members {
+			if !slices.Contains(queue.Members, member) {
+				queue.Members = append(queue.Members, member)
+			}
+		}
+		queue.Type = rabbitQueueTypeQuorum
+	}
 	if len(q) > 0 {
 		switch s.metadata.operation {
 		case sumOperation:
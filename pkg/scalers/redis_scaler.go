@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"sync/atomic"
 
 	"github.com/go-logr/logr"
 	"github.com/redis/go-redis/v9"
@@ -33,6 +34,9 @@ var (
 	// ErrRedisUnequalHostsAndPorts is returned when the number of hosts and ports are unequal.
 	ErrRedisUnequalHostsAndPorts = errors.New("not enough hosts or ports given. number of hosts should be equal to the number of ports")
 
+	// ErrRedisListNameAndKeyPattern is returned when both "listName" and "keyPattern" are set.
+	ErrRedisListNameAndKeyPattern = errors.New("listName and keyPattern are mutually exclusive")
+
 	// ErrRedisParse is returned when "listName" is missing from the config.
 	ErrRedisParse = errors.New("error parsing redis metadata")
 )
@@ -65,7 +69,10 @@ type redisConnectionInfo struct {
 type redisMetadata struct {
 	ListLength           int64               `keda:"name=listLength,       order=triggerMetadata, optional, default=5"`
 	ActivationListLength int64               `keda:"name=activationListLength,       order=triggerMetadata, optional"`
-	ListName             string              `keda:"name=listName,       order=triggerMetadata"`
+	ListName             string              `keda:"name=listName,       order=triggerMetadata, optional"`
+	KeyPattern           string              `keda:"name=keyPattern,       order=triggerMetadata, optional"`
+	ScanCount            int64               `keda:"name=scanCount,       order=triggerMetadata, optional, default=100"`
+	MaxScanIterations    int                 `keda:"name=maxScanIterations,       order=triggerMetadata, optional, default=1000"`
 	DatabaseIndex        int                 `keda:"name=databaseIndex,       order=triggerMetadata, optional"`
 	MetadataEnableTLS    string              `keda:"name=enableTLS,       order=triggerMetadata, optional"`
 	AuthParamEnableTLS   string              `keda:"name=tls,       order=authParams, optional"`
@@ -110,6 +117,19 @@ func (r *redisMetadata) Validate() error {
 		return err
 	}
 
+	if r.ListName == "" && r.KeyPattern == "" {
+		return ErrRedisNoListName
+	}
+	if r.ListName != "" && r.KeyPattern != "" {
+		return ErrRedisListNameAndKeyPattern
+	}
+	if r.ScanCount <= 0 {
+		return errors.New("scanCount must be greater than 0")
+	}
+	if r.MaxScanIterations <= 0 {
+		return errors.New("maxScanIterations must be greater than 0")
+	}
+
 	err = r.ConnectionInfo.SetEnableTLS(r.MetadataEnableTLS, r.AuthParamEnableTLS)
 	if err == nil {
 		r.MetadataEnableTLS, r.AuthParamEnableTLS = "", ""
@@ -169,6 +189,21 @@ func createClusteredRedisScaler(ctx context.Context, meta *redisMetadata, script
 	}
 
 	listLengthFn := func(ctx context.Context) (int64, error) {
+		if meta.KeyPattern != "" {
+			// keys matching the pattern are spread across slots, so every
+			// master has to be scanned and the per-node lengths summed up
+			var total atomic.Int64
+			err := client.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+				length, err := getRedisPatternLength(ctx, node, script, meta, logger)
+				total.Add(length)
+				return err
+			})
+			if err != nil {
+				return -1, err
+			}
+			return total.Load(), nil
+		}
+
 		cmd := client.Eval(ctx, script, []string{meta.ListName})
 		if cmd.Err() != nil {
 			return -1, cmd.Err()
@@ -214,6 +249,14 @@ func createRedisScalerWithClient(client *redis.Client, meta *redisMetadata, scri
 	}
 
 	listLengthFn := func(ctx context.Context) (int64, error) {
+		if meta.KeyPattern != "" {
+			length, err := getRedisPatternLength(ctx, client, script, meta, logger)
+			if err != nil {
+				return -1, err
+			}
+			return length, nil
+		}
+
 		cmd := client.Eval(ctx, script, []string{meta.ListName})
 		if cmd.Err() != nil {
 			return -1, cmd.Err()
@@ -231,6 +274,49 @@ func createRedisScalerWithClient(client *redis.Client, meta *redisMetadata, scri
 	}
 }
 
+// getRedisPatternLength walks the keyspace of a single redis node with SCAN and sums up the
+// lengths of every key matching the key pattern. The number of SCAN round trips is bounded by
+// maxScanIterations; when the budget is exhausted the length counted so far is returned.
+func getRedisPatternLength(ctx context.Context, client *redis.Client, script string, meta *redisMetadata, logger logr.Logger) (int64, error) {
+	var (
+		total  int64
+		cursor uint64
+	)
+
+	for i := 0; i < meta.MaxScanIterations; i++ {
+		keys, next, err := client.Scan(ctx, cursor, meta.KeyPattern, meta.ScanCount).Result()
+		if err != nil {
+			return total, err
+		}
+
+		if len(keys) > 0 {
+			pipe := client.Pipeline()
+			cmds := make([]*redis.Cmd, 0, len(keys))
+			for _, key := range keys {
+				cmds = append(cmds, pipe.Eval(ctx, script, []string{key}))
+			}
+			if _, err := pipe.Exec(ctx); err != nil {
+				return total, err
+			}
+			for _, cmd := range cmds {
+				length, err := cmd.Int64()
+				if err != nil {
+					return total, err
+				}
+				total += length
+			}
+		}
+
+		if next == 0 {
+			return total, nil
+		}
+		cursor = next
+	}
+
+	logger.Info("scan budget exhausted before the whole keyspace was visited, reporting partial length", "keyPattern", meta.KeyPattern, "maxScanIterations", meta.MaxScanIterations)
+	return total, nil
+}
+
 func parseRedisMetadata(config *scalersconfig.ScalerConfig) (*redisMetadata, error) {
 	meta := &redisMetadata{}
 	if err := config.TypedConfig(meta); err != nil {
@@ -247,7 +333,11 @@ func (s *redisScaler) Close(context.Context) error {
 
 // GetMetricSpecForScaling returns the metric spec for the HPA
 func (s *redisScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
-	metricName := util.NormalizeString(fmt.Sprintf("redis-%s", s.metadata.ListName))
+	listName := s.metadata.ListName
+	if s.metadata.KeyPattern != "" {
+		listName = s.metadata.KeyPattern
+	}
+	metricName := util.NormalizeString(fmt.Sprintf("redis-%s", listName))
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
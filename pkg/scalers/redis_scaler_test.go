@@ -71,11 +71,24 @@ var testRedisMetadata = []parseRedisMetadataTestData{
 	// enableTLS is defined both in authParams and metadata
 	{map[string]string{"listName": "mylist", "listLength": "0", "enableTLS": "true"}, true, map[string]string{"address": "localhost:6379", "tls": "disable"}, true},
 	// host only is defined in the authParams
-	{map[string]string{"listName": "mylist", "listLength": "0"}, true, map[string]string{"host": "localhost"}, false}}
+	{map[string]string{"listName": "mylist", "listLength": "0"}, true, map[string]string{"host": "localhost"}, false},
+	// properly formed keyPattern
+	{map[string]string{"keyPattern": "queue:{*}", "listLength": "10", "addressFromEnv": "REDIS_HOST"}, false, map[string]string{}, false},
+	// keyPattern with scan budget
+	{map[string]string{"keyPattern": "queue:*", "scanCount": "500", "maxScanIterations": "10", "addressFromEnv": "REDIS_HOST"}, false, map[string]string{}, false},
+	// listName and keyPattern together
+	{map[string]string{"listName": "mylist", "keyPattern": "queue:*", "addressFromEnv": "REDIS_HOST"}, true, map[string]string{}, false},
+	// negative scanCount
+	{map[string]string{"keyPattern": "queue:*", "scanCount": "-1", "addressFromEnv": "REDIS_HOST"}, true, map[string]string{}, false},
+	// zero maxScanIterations
+	{map[string]string{"keyPattern": "queue:*", "maxScanIterations": "0", "addressFromEnv": "REDIS_HOST"}, true, map[string]string{}, false},
+	// improperly formed maxScanIterations
+	{map[string]string{"keyPattern": "queue:*", "maxScanIterations": "AA", "addressFromEnv": "REDIS_HOST"}, true, map[string]string{}, false}}
 
 var redisMetricIdentifiers = []redisMetricIdentifier{
 	{&testRedisMetadata[1], 0, "s0-redis-mylist"},
 	{&testRedisMetadata[1], 1, "s1-redis-mylist"},
+	{&testRedisMetadata[19], 0, "s0-redis-queue-{*}"},
 }
 
 func TestRedisParseMetadata(t *testing.T) {
@@ -170,6 +183,38 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 			wantMeta: nil,
 			wantErr:  ErrRedisParse,
 		},
+		{
+			name: "list name and key pattern",
+			metadata: map[string]string{
+				"hosts":      "a, b, c",
+				"ports":      "1, 2, 3",
+				"listName":   "mylist",
+				"keyPattern": "queue:{*}",
+			},
+			wantMeta: nil,
+			wantErr:  ErrRedisListNameAndKeyPattern,
+		},
+		{
+			name: "key pattern across cluster slots",
+			metadata: map[string]string{
+				"keyPattern":        "queue:{*}",
+				"scanCount":         "250",
+				"maxScanIterations": "20",
+			},
+			authParams: map[string]string{
+				"addresses": ":7001, :7002",
+			},
+			wantMeta: &redisMetadata{
+				ListLength:        5,
+				KeyPattern:        "queue:{*}",
+				ScanCount:         250,
+				MaxScanIterations: 20,
+				ConnectionInfo: redisConnectionInfo{
+					Addresses: []string{":7001", ":7002"},
+				},
+			},
+			wantErr: nil,
+		},
 		{
 			name: "invalid list length",
 			metadata: map[string]string{
@@ -190,8 +235,10 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 				"addresses": ":7001, :7002",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{":7001", ":7002"},
 				},
@@ -208,8 +255,10 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 				"ports": "1, 2, 3",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{"a:1", "b:2", "c:3"},
 					Hosts:     []string{"a", "b", "c"},
@@ -229,8 +278,10 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 				"username": "username",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{"a:1", "b:2", "c:3"},
 					Hosts:     []string{"a", "b", "c"},
@@ -250,8 +301,10 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 			},
 			authParams: map[string]string{},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{"a:1", "b:2", "c:3"},
 					Hosts:     []string{"a", "b", "c"},
@@ -272,8 +325,10 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{"a:1", "b:2", "c:3"},
 					Hosts:     []string{"a", "b", "c"},
@@ -294,8 +349,10 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 				"password": "password",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{"a:1", "b:2", "c:3"},
 					Hosts:     []string{"a", "b", "c"},
@@ -316,8 +373,10 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{"a:1", "b:2", "c:3"},
 					Hosts:     []string{"a", "b", "c"},
@@ -337,8 +396,10 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 				"addresses": ":7001, :7002",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{":7001", ":7002"},
 					EnableTLS: true,
@@ -358,8 +419,10 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 				"addresses": ":7001, :7002",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{":7001", ":7002"},
 					EnableTLS: true,
@@ -442,8 +505,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"addresses": ":7001, :7002",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{":7001", ":7002"},
 				},
@@ -460,8 +525,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"ports": "1, 2, 3",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{"a:1", "b:2", "c:3"},
 					Hosts:     []string{"a", "b", "c"},
@@ -480,8 +547,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"ports": "1, 2, 3",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{"a:1", "b:2", "c:3"},
 					Hosts:     []string{"a", "b", "c"},
@@ -501,8 +570,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"username": "username",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{"a:1", "b:2", "c:3"},
 					Hosts:     []string{"a", "b", "c"},
@@ -522,8 +593,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			},
 			authParams: map[string]string{},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{"a:1", "b:2", "c:3"},
 					Hosts:     []string{"a", "b", "c"},
@@ -544,8 +617,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{"a:1", "b:2", "c:3"},
 					Hosts:     []string{"a", "b", "c"},
@@ -566,8 +641,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"password": "password",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{"a:1", "b:2", "c:3"},
 					Hosts:     []string{"a", "b", "c"},
@@ -588,8 +665,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{"a:1", "b:2", "c:3"},
 					Hosts:     []string{"a", "b", "c"},
@@ -610,8 +689,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"sentinelUsername": "sentinelUsername",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses:        []string{"a:1", "b:2", "c:3"},
 					Hosts:            []string{"a", "b", "c"},
@@ -631,8 +712,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			},
 			authParams: map[string]string{},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses:        []string{"a:1", "b:2", "c:3"},
 					Hosts:            []string{"a", "b", "c"},
@@ -653,8 +736,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses:        []string{"a:1", "b:2", "c:3"},
 					Hosts:            []string{"a", "b", "c"},
@@ -675,8 +760,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"sentinelPassword": "sentinelPassword",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses:        []string{"a:1", "b:2", "c:3"},
 					Hosts:            []string{"a", "b", "c"},
@@ -697,8 +784,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses:        []string{"a:1", "b:2", "c:3"},
 					Hosts:            []string{"a", "b", "c"},
@@ -719,8 +808,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"sentinelMaster": "sentinelMaster",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses:      []string{"a:1", "b:2", "c:3"},
 					Hosts:          []string{"a", "b", "c"},
@@ -740,8 +831,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			},
 			authParams: map[string]string{},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses:      []string{"a:1", "b:2", "c:3"},
 					Hosts:          []string{"a", "b", "c"},
@@ -762,8 +855,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 			authParams:  map[string]string{},
 			resolvedEnv: testRedisResolvedEnv,
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses:      []string{"a:1", "b:2", "c:3"},
 					Hosts:          []string{"a", "b", "c"},
@@ -783,8 +878,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"addresses": ":7001, :7002",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{":7001", ":7002"},
 					EnableTLS: true,
@@ -804,8 +901,10 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 				"addresses": ":7001, :7002",
 			},
 			wantMeta: &redisMetadata{
-				ListLength: 5,
-				ListName:   "mylist",
+				ListLength:        5,
+				ScanCount:         100,
+				MaxScanIterations: 1000,
+				ListName:          "mylist",
 				ConnectionInfo: redisConnectionInfo{
 					Addresses: []string{":7001", ":7002"},
 					EnableTLS: true,
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/redis/go-redis/v9"
@@ -22,8 +23,12 @@ const (
 	xPendingFactor scaleFactor = iota + 1
 	xLengthFactor
 	lagFactor
+	xPendingIdleFactor
 )
 
+// xPendingPageSize is the number of entries fetched per XPENDING call when counting idle entries
+const xPendingPageSize = 1000
+
 const (
 	// metadata names
 	lagMetadata                      = "lagCount"
@@ -52,6 +57,8 @@ type redisStreamsMetadata struct {
 	TargetPendingEntriesCount int64               `keda:"name=pendingEntriesCount,       order=triggerMetadata, optional, default=5"`
 	TargetStreamLength        int64               `keda:"name=streamLength,       order=triggerMetadata, optional, default=5"`
 	TargetLag                 int64               `keda:"name=lagCount,       order=triggerMetadata, optional"`
+	MinIdleTime               int64               `keda:"name=minIdleTime,       order=triggerMetadata, optional"`
+	MaxDeliveryCount          int64               `keda:"name=maxDeliveryCount,       order=triggerMetadata, optional"`
 	StreamName                string              `keda:"name=stream,       order=triggerMetadata"`
 	ConsumerGroupName         string              `keda:"name=consumerGroup,       order=triggerMetadata, optional"`
 	DatabaseIndex             int                 `keda:"name=databaseIndex,       order=triggerMetadata, optional"`
@@ -77,9 +84,22 @@ func (r *redisStreamsMetadata) Validate() error {
 		return ErrRedisMissingStreamName
 	}
 
+	if r.MinIdleTime < 0 {
+		return errors.New("minIdleTime must be a positive number of milliseconds")
+	}
+
+	if r.MaxDeliveryCount < 0 {
+		return errors.New("maxDeliveryCount must be a positive number")
+	}
+
 	if r.ConsumerGroupName != "" {
 		r.TargetStreamLength = 0
-		if r.TargetLag != 0 {
+		if r.TargetLag != 0 && (r.MinIdleTime != 0 || r.MaxDeliveryCount != 0) {
+			return errors.New("lagCount can't be used together with minIdleTime or maxDeliveryCount")
+		}
+		if r.MinIdleTime != 0 || r.MaxDeliveryCount != 0 {
+			r.scaleFactor = xPendingIdleFactor
+		} else if r.TargetLag != 0 {
 			r.scaleFactor = lagFactor
 			r.TargetPendingEntriesCount = 0
 
@@ -91,6 +111,9 @@ func (r *redisStreamsMetadata) Validate() error {
 			r.scaleFactor = xPendingFactor
 		}
 	} else {
+		if r.MinIdleTime != 0 || r.MaxDeliveryCount != 0 {
+			return errors.New("consumerGroup is required when using minIdleTime or maxDeliveryCount")
+		}
 		r.scaleFactor = xLengthFactor
 		r.TargetPendingEntriesCount = 0
 	}
@@ -194,6 +217,10 @@ func createEntriesCountFn(client redis.Cmdable, meta *redisStreamsMetadata) (ent
 			}
 			return pendingEntries.Count, nil
 		}
+	case xPendingIdleFactor:
+		entriesCountFn = func(ctx context.Context) (int64, error) {
+			return getIdlePendingEntriesCount(ctx, client, meta)
+		}
 	case xLengthFactor:
 		entriesCountFn = func(ctx context.Context) (int64, error) {
 			entriesLength, err := client.XLen(ctx, meta.StreamName).Result()
@@ -278,6 +305,54 @@ func createEntriesCountFn(client redis.Cmdable, meta *redisStreamsMetadata) (ent
 	return
 }
 
+// getIdlePendingEntriesCount counts the entries of the consumer group's PEL which haven't been
+// acknowledged for at least minIdleTime, paging through XPENDING with the IDLE filter (Redis 6.2+).
+// The entries delivered maxDeliveryCount times are poison pills the consumers keep failing on,
+// they aren't counted so they don't keep the workload scaled out
+func getIdlePendingEntriesCount(ctx context.Context, client redis.Cmdable, meta *redisStreamsMetadata) (int64, error) {
+	var count int64
+	start := "-"
+	for {
+		entries, err := client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: meta.StreamName,
+			Group:  meta.ConsumerGroupName,
+			Idle:   time.Duration(meta.MinIdleTime) * time.Millisecond,
+			Start:  start,
+			End:    "+",
+			Count:  xPendingPageSize,
+		}).Result()
+		if err != nil {
+			// the stream or the consumer group hasn't been created yet, so nothing is pending
+			if strings.HasPrefix(err.Error(), "NOGROUP") {
+				return 0, nil
+			}
+			return -1, err
+		}
+
+		count += countPendingEntries(entries, meta.MaxDeliveryCount)
+		if len(entries) < xPendingPageSize {
+			return count, nil
+		}
+		// exclusive range start, so the last entry of this page isn't counted twice
+		start = "(" + entries[len(entries)-1].ID
+	}
+}
+
+// countPendingEntries counts the pending entries delivered less than maxDeliveryCount times, or all
+// of them when maxDeliveryCount isn't set
+func countPendingEntries(entries []redis.XPendingExt, maxDeliveryCount int64) int64 {
+	if maxDeliveryCount == 0 {
+		return int64(len(entries))
+	}
+	var count int64
+	for _, entry := range entries {
+		if entry.RetryCount < maxDeliveryCount {
+			count++
+		}
+	}
+	return count
+}
+
 var (
 	// ErrRedisMissingStreamName is returned when "stream" is missing.
 	ErrRedisMissingStreamName = errors.New("missing redis stream name")
@@ -304,7 +379,7 @@ func (s *redisStreamsScaler) GetMetricSpecForScaling(context.Context) []v2.Metri
 	var metricValue int64
 
 	switch s.metadata.scaleFactor {
-	case xPendingFactor:
+	case xPendingFactor, xPendingIdleFactor:
 		metricValue = s.metadata.TargetPendingEntriesCount
 	case xLengthFactor:
 		metricValue = s.metadata.TargetStreamLength
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
@@ -155,6 +156,20 @@ func TestParseRedisStreamsMetadataForInvalidCases(t *testing.T) {
 		{"invalid databaseIndex", map[string]string{"stream": "my-stream", "consumerGroup": "my-stream-consumer-group", "pendingEntriesCount": "15", "address": "REDIS_SERVER", "databaseIndex": "junk", "enableTLS": "false"}, resolvedEnvMap},
 
 		{"invalid enableTLS", map[string]string{"stream": "my-stream", "consumerGroup": "my-stream-consumer-group", "pendingEntriesCount": "15", "address": "REDIS_SERVER", "databaseIndex": "1", "enableTLS": "no"}, resolvedEnvMap},
+
+		{"invalid minIdleTime", map[string]string{"stream": "my-stream", "consumerGroup": "my-stream-consumer-group", "pendingEntriesCount": "15", "address": "REDIS_SERVER", "minIdleTime": "junk"}, resolvedEnvMap},
+
+		{"negative minIdleTime", map[string]string{"stream": "my-stream", "consumerGroup": "my-stream-consumer-group", "pendingEntriesCount": "15", "address": "REDIS_SERVER", "minIdleTime": "-1"}, resolvedEnvMap},
+
+		{"minIdleTime without consumerGroup", map[string]string{"stream": "my-stream", "pendingEntriesCount": "15", "address": "REDIS_SERVER", "minIdleTime": "60000"}, resolvedEnvMap},
+
+		{"minIdleTime with lagCount", map[string]string{"stream": "my-stream", "consumerGroup": "my-stream-consumer-group", "lagCount": "15", "activationLagCount": "1", "address": "REDIS_SERVER", "minIdleTime": "60000"}, resolvedEnvMap},
+
+		{"negative maxDeliveryCount", map[string]string{"stream": "my-stream", "consumerGroup": "my-stream-consumer-group", "pendingEntriesCount": "15", "address": "REDIS_SERVER", "maxDeliveryCount": "-1"}, resolvedEnvMap},
+
+		{"maxDeliveryCount without consumerGroup", map[string]string{"stream": "my-stream", "pendingEntriesCount": "15", "address": "REDIS_SERVER", "maxDeliveryCount": "5"}, resolvedEnvMap},
+
+		{"maxDeliveryCount with lagCount", map[string]string{"stream": "my-stream", "consumerGroup": "my-stream-consumer-group", "lagCount": "15", "activationLagCount": "1", "address": "REDIS_SERVER", "maxDeliveryCount": "5"}, resolvedEnvMap},
 	}
 
 	for _, tc := range testCases {
@@ -265,6 +280,52 @@ func TestParseRedisClusterStreamsMetadata(t *testing.T) {
 			wantMeta: nil,
 			wantErr:  ErrRedisStreamParse,
 		},
+		{
+			name: "pending entries idle time",
+			metadata: map[string]string{
+				"stream":              "my-stream",
+				"pendingEntriesCount": "5",
+				"minIdleTime":         "30000",
+				"consumerGroup":       "consumer1",
+			},
+			authParams: map[string]string{
+				"addresses": ":7001, :7002",
+			},
+			wantMeta: &redisStreamsMetadata{
+				StreamName:                "my-stream",
+				TargetPendingEntriesCount: 5,
+				MinIdleTime:               30000,
+				ConsumerGroupName:         "consumer1",
+				ConnectionInfo: redisConnectionInfo{
+					Addresses: []string{":7001", ":7002"},
+				},
+				scaleFactor: xPendingIdleFactor,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "pending entries max delivery count",
+			metadata: map[string]string{
+				"stream":              "my-stream",
+				"pendingEntriesCount": "5",
+				"maxDeliveryCount":    "3",
+				"consumerGroup":       "consumer1",
+			},
+			authParams: map[string]string{
+				"addresses": ":7001, :7002",
+			},
+			wantMeta: &redisStreamsMetadata{
+				StreamName:                "my-stream",
+				TargetPendingEntriesCount: 5,
+				MaxDeliveryCount:          3,
+				ConsumerGroupName:         "consumer1",
+				ConnectionInfo: redisConnectionInfo{
+					Addresses: []string{":7001", ":7002"},
+				},
+				scaleFactor: xPendingIdleFactor,
+			},
+			wantErr: nil,
+		},
 		{
 			name: "address is defined in auth params",
 			metadata: map[string]string{
@@ -2055,3 +2116,17 @@ func TestActivityCount(t *testing.T) {
 		assert.Equal(t, isActive, true, "redis scaler should be active when lag is greater than activation")
 	})
 }
+
+func TestCountPendingEntries(t *testing.T) {
+	entries := []redis.XPendingExt{
+		{ID: "1-0", RetryCount: 1},
+		{ID: "2-0", RetryCount: 2},
+		{ID: "3-0", RetryCount: 3},
+		{ID: "4-0", RetryCount: 12},
+	}
+
+	assert.Equal(t, int64(4), countPendingEntries(entries, 0))
+	assert.Equal(t, int64(2), countPendingEntries(entries, 3))
+	assert.Equal(t, int64(0), countPendingEntries(entries, 1))
+	assert.Equal(t, int64(0), countPendingEntries(nil, 3))
+}
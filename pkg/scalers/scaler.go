@@ -29,6 +29,7 @@ import (
 	v2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -62,6 +63,33 @@ type PushScaler interface {
 	Run(ctx context.Context, active chan<- bool)
 }
 
+// WatchScaler is a Scaler evaluated from the pods, the triggers are evaluated again as soon as
+// the pods change instead of only on the polling interval
+type WatchScaler interface {
+	Scaler
+
+	// GetWatchedPods returns the namespace and the selector of the pods the scaler is evaluated from
+	GetWatchedPods(ctx context.Context) (string, labels.Selector, error)
+}
+
+// ActivationTrendScaler is a Scaler activating the scale target only once it was active on the last consecutive
+// evaluations of the scale loop, so the single spikes don't activate it
+type ActivationTrendScaler interface {
+	Scaler
+
+	// GetActivationTrendWindow returns the number of the consecutive evaluations, up to 1 activates it on the first one
+	GetActivationTrendWindow() int
+}
+
+// StatefulScaler is a Scaler whose metric depends on the values of its previous evaluations, it isn't
+// closed between them as the lazy scalers are
+type StatefulScaler interface {
+	Scaler
+
+	// IsStateful returns whether the metric of the trigger depends on the previous evaluations
+	IsStateful() bool
+}
+
 var (
 	// ErrScalerUnsupportedUtilizationMetricType is returned when v2.UtilizationMetricType
 	// is provided as the metric target type for scaler.
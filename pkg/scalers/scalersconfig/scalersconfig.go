@@ -41,6 +41,9 @@ type ScalerConfig struct {
 	// Name of the trigger
 	TriggerName string
 
+	// Type of the trigger, eg: kafka
+	TriggerType string
+
 	// Marks whether we should query metrics only during the polling interval
 	// Any requests for metrics in between are read from the cache
 	TriggerUseCachedMetrics bool
@@ -68,4 +71,7 @@ type ScalerConfig struct {
 
 	// When we use the scaler for composite scaler, we shouldn't require the value because it'll be ignored
 	AsMetricSource bool
+
+	// validation tracks the parsed parameters while the config is validated, see ValidateTypedConfig
+	validation *typedConfigValidation
 }
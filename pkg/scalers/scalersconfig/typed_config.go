@@ -153,6 +153,58 @@ func (sc *ScalerConfig) TypedConfig(typedConfig any) (err error) {
 	return
 }
 
+// typedConfigValidation tracks the parsed parameters of a typed config while it is validated
+type typedConfigValidation struct {
+	// names are the keys of the TriggerMetadata the parameters are looked up with
+	names map[string]bool
+	// unresolved is set once a parameter may be provided by the ResolvedEnv or AuthParams which aren't known
+	unresolved bool
+}
+
+// ValidateTypedConfig validates the TriggerMetadata against the typedConfig, populating it like TypedConfig does.
+// The ResolvedEnv and AuthParams are unknown when they are nil, like when a trigger is validated before its scaler
+// is built: the parameters which may be provided by them are unresolved, the missing ones aren't reported and the
+// custom validation is skipped as it may depend on them. The keys of the TriggerMetadata which aren't looked up by
+// the typedConfig, nor listed in knownParams as parsed besides it, are reported as unknown parameters.
+func (sc *ScalerConfig) ValidateTypedConfig(typedConfig any, knownParams ...string) error {
+	validating := *sc
+	validating.validation = &typedConfigValidation{names: map[string]bool{}}
+	for _, param := range knownParams {
+		validating.validation.names[param] = true
+	}
+
+	errs := []error{}
+	if err := validating.TypedConfig(typedConfig); err != nil {
+		errs = append(errs, err)
+	}
+	keys := maps.Keys(sc.TriggerMetadata)
+	slices.Sort(keys)
+	for _, key := range keys {
+		if !validating.validation.names[key] {
+			errs = append(errs, fmt.Errorf("unknown parameter %q", key))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// lookedUp records the keys of the TriggerMetadata the parameter is looked up with
+func (v *typedConfigValidation) lookedUp(params Params) {
+	for _, name := range params.Names {
+		if slices.Contains(params.Order, TriggerMetadata) {
+			v.names[name] = true
+		}
+		if slices.Contains(params.Order, ResolvedEnv) {
+			v.names[fmt.Sprintf("%sFromEnv", name)] = true
+		}
+	}
+}
+
+// isUnresolved returns true if the parameter may be provided by the ResolvedEnv or AuthParams which aren't known
+func (sc *ScalerConfig) isUnresolved(params Params) bool {
+	return (slices.Contains(params.Order, ResolvedEnv) && sc.ResolvedEnv == nil) ||
+		(slices.Contains(params.Order, AuthParams) && sc.AuthParams == nil)
+}
+
 // parseTypedConfig is a function that is used to unmarshal the TriggerMetadata, ResolvedEnv and AuthParams
 // this can be called recursively to parse nested structures
 func (sc *ScalerConfig) parseTypedConfig(typedConfig any, parentOptional bool) error {
@@ -177,11 +229,14 @@ func (sc *ScalerConfig) parseTypedConfig(typedConfig any, parentOptional bool) e
 			continue
 		}
 		tagParams.Optional = tagParams.Optional || parentOptional
+		if sc.validation != nil {
+			sc.validation.lookedUp(tagParams)
+		}
 		if err := sc.setValue(fieldValue, tagParams); err != nil {
 			errs = append(errs, err)
 		}
 	}
-	if validator, ok := typedConfig.(CustomValidator); ok {
+	if validator, ok := typedConfig.(CustomValidator); ok && (sc.validation == nil || !sc.validation.unresolved) {
 		if err := validator.Validate(); err != nil {
 			errs = append(errs, err)
 		}
@@ -195,6 +250,12 @@ func (sc *ScalerConfig) setValue(field reflect.Value, params Params) error {
 	if exists && params.IsDeprecated() {
 		return fmt.Errorf("parameter %q is deprecated%v", params.Name(), params.DeprecatedMessage())
 	}
+	if !exists && sc.validation != nil && sc.isUnresolved(params) {
+		sc.validation.unresolved = true
+		if params.Default == "" {
+			return nil
+		}
+	}
 	if !exists && params.Default != "" {
 		exists = true
 		valFromConfig = params.Default
@@ -17,6 +17,7 @@ limitations under the License.
 package scalersconfig
 
 import (
+	"fmt"
 	"net/url"
 	"testing"
 
@@ -583,3 +584,58 @@ func TestMultiName(t *testing.T) {
 	Expect(err).To(BeNil())
 	Expect(ts.Property).To(Equal("bbb"))
 }
+
+type validatedStruct struct {
+	Host     string `keda:"name=host,     order=triggerMetadata;resolvedEnv"`
+	Port     int    `keda:"name=port,     order=triggerMetadata, default=6379"`
+	Mode     string `keda:"name=mode,     order=triggerMetadata, enum=single;cluster, optional"`
+	Password string `keda:"name=password, order=authParams, optional"`
+}
+
+func (v *validatedStruct) Validate() error {
+	if v.Mode == "cluster" && v.Password == "" {
+		return fmt.Errorf("password is required for cluster mode")
+	}
+	return nil
+}
+
+// TestValidateTypedConfig tests the validation of the trigger metadata with unknown resolved env and auth params
+func TestValidateTypedConfig(t *testing.T) {
+	RegisterTestingT(t)
+
+	sc := &ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"hostFromEnv": "REDIS_HOST",
+			"mode":        "cluster",
+		},
+	}
+	err := sc.ValidateTypedConfig(&validatedStruct{})
+	Expect(err).To(BeNil())
+
+	sc.ResolvedEnv = map[string]string{"REDIS_HOST": "redis"}
+	sc.AuthParams = map[string]string{}
+	err = sc.ValidateTypedConfig(&validatedStruct{})
+	Expect(err).To(MatchError(`password is required for cluster mode`))
+
+	sc = &ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"host":     "redis",
+			"prot":     "6379",
+			"mode":     "replicated",
+			"password": "secret",
+			"extra":    "value",
+		},
+	}
+	err = sc.ValidateTypedConfig(&validatedStruct{}, "extra")
+	Expect(err).To(MatchError(ContainSubstring(`parameter "mode" value "replicated" must be one of [single cluster]`)))
+	Expect(err).To(MatchError(ContainSubstring(`unknown parameter "password"`)))
+	Expect(err).To(MatchError(ContainSubstring(`unknown parameter "prot"`)))
+	Expect(err).ToNot(MatchError(ContainSubstring(`extra`)))
+
+	sc = &ScalerConfig{
+		TriggerMetadata: map[string]string{},
+		ResolvedEnv:     map[string]string{},
+	}
+	err = sc.ValidateTypedConfig(&validatedStruct{})
+	Expect(err).To(MatchError(`missing required parameter "host" in [triggerMetadata resolvedEnv]`))
+}
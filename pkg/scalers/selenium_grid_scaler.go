@@ -9,6 +9,8 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -36,12 +38,16 @@ type seleniumGridScalerMetadata struct {
 	BrowserVersion      string `keda:"name=browserVersion,           order=triggerMetadata, optional, default=latest"`
 	UnsafeSsl           bool   `keda:"name=unsafeSsl,                order=triggerMetadata, optional, default=false"`
 	PlatformName        string `keda:"name=platformName,             order=triggerMetadata, optional, default=linux"`
+	Capabilities        string `keda:"name=capabilities,             order=triggerMetadata, optional"`
 
 	// auth
 	Username string `keda:"name=username, order=authParams;resolvedEnv;triggerMetadata, optional"`
 	Password string `keda:"name=password, order=authParams;resolvedEnv;triggerMetadata, optional"`
 
 	TargetValue int64
+
+	// capabilities are the decoded extra capabilities of the nodes scaled by this trigger
+	capabilities map[string]interface{}
 }
 
 type seleniumResponse struct {
@@ -80,6 +86,10 @@ const (
 	DefaultPlatformName   string = "linux"
 )
 
+// seleniumBrowserOptionPrefixes are extension capabilities configuring the browser or the grid itself,
+// they are never used to select a slot
+var seleniumBrowserOptionPrefixes = []string{"goog:", "moz:", "ms:", "safari:", "se:", "webauthn:"}
+
 func NewSeleniumGridScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
@@ -118,6 +128,12 @@ func parseSeleniumGridScalerMetadata(config *scalersconfig.ScalerConfig) (*selen
 	if meta.SessionBrowserName == "" {
 		meta.SessionBrowserName = meta.BrowserName
 	}
+
+	if meta.Capabilities != "" {
+		if err := json.Unmarshal([]byte(meta.Capabilities), &meta.capabilities); err != nil {
+			return nil, fmt.Errorf("capabilities must be a JSON object: %w", err)
+		}
+	}
 	return meta, nil
 }
 
@@ -141,7 +157,12 @@ func (s *seleniumGridScaler) GetMetricsAndActivity(ctx context.Context, metricNa
 }
 
 func (s *seleniumGridScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
-	metricName := kedautil.NormalizeString(fmt.Sprintf("seleniumgrid-%s", s.metadata.BrowserName))
+	metricName := fmt.Sprintf("seleniumgrid-%s", s.metadata.BrowserName)
+	if len(s.metadata.capabilities) > 0 {
+		// distinguish the metrics of node pools having the same browser with different capabilities
+		metricName = fmt.Sprintf("%s-%s", metricName, capabilitiesMetricSuffix(s.metadata.capabilities))
+	}
+	metricName = kedautil.NormalizeString(metricName)
 	externalMetric := &v2.ExternalMetricSource{
 		Metric: v2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, metricName),
@@ -186,14 +207,70 @@ func (s *seleniumGridScaler) getSessionsCount(ctx context.Context, logger logr.L
 	if err != nil {
 		return -1, err
 	}
-	v, err := getCountFromSeleniumResponse(b, s.metadata.BrowserName, s.metadata.BrowserVersion, s.metadata.SessionBrowserName, s.metadata.PlatformName, logger)
+	v, err := getCountFromSeleniumResponse(b, s.metadata.BrowserName, s.metadata.BrowserVersion, s.metadata.SessionBrowserName, s.metadata.PlatformName, s.metadata.capabilities, logger)
 	if err != nil {
 		return -1, err
 	}
 	return v, nil
 }
 
-func getCountFromSeleniumResponse(b []byte, browserName string, browserVersion string, sessionBrowserName string, platformName string, logger logr.Logger) (int64, error) {
+// capabilitiesMetricSuffix returns the capabilities as sorted key-value pairs
+func capabilitiesMetricSuffix(capabilities map[string]interface{}) string {
+	keys := make([]string, 0, len(capabilities))
+	for k := range capabilities {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s-%v", k, capabilities[k]))
+	}
+	return strings.Join(parts, "-")
+}
+
+// capabilitiesMatch checks the requested capabilities against the capabilities of the nodes.
+// Every node capability given in the request must have the same value. For queued requests,
+// custom extension capabilities the nodes don't provide can't be satisfied either.
+func capabilitiesMatch(requested map[string]interface{}, capabilities map[string]interface{}, queued bool) bool {
+	for k, v := range capabilities {
+		if requestedValue, ok := requested[k]; ok && !reflect.DeepEqual(requestedValue, v) {
+			return false
+		}
+	}
+	if !queued {
+		return true
+	}
+	for k := range requested {
+		if !strings.Contains(k, ":") || isSeleniumBrowserOption(k) {
+			continue
+		}
+		if _, ok := capabilities[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// requestCapabilitiesMatch decodes the full capability set of a request or session and matches it
+func requestCapabilitiesMatch(raw string, capabilities map[string]interface{}, queued bool) bool {
+	var requested map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &requested); err != nil {
+		return false
+	}
+	return capabilitiesMatch(requested, capabilities, queued)
+}
+
+func isSeleniumBrowserOption(capability string) bool {
+	for _, prefix := range seleniumBrowserOptionPrefixes {
+		if strings.HasPrefix(capability, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func getCountFromSeleniumResponse(b []byte, browserName string, browserVersion string, sessionBrowserName string, platformName string, capabilities map[string]interface{}, logger logr.Logger) (int64, error) {
 	var count int64
 	var seleniumResponse = seleniumResponse{}
 
@@ -205,6 +282,9 @@ func getCountFromSeleniumResponse(b []byte, browserName string, browserVersion s
 	for _, sessionQueueRequest := range sessionQueueRequests {
 		var capability = capability{}
 		if err := json.Unmarshal([]byte(sessionQueueRequest), &capability); err == nil {
+			if len(capabilities) > 0 && !requestCapabilitiesMatch(sessionQueueRequest, capabilities, true) {
+				continue
+			}
 			if capability.BrowserName == browserName {
 				var platformNameMatches = capability.PlatformName == "" || strings.EqualFold(capability.PlatformName, platformName)
 				if strings.HasPrefix(capability.BrowserVersion, browserVersion) && platformNameMatches {
@@ -222,6 +302,9 @@ func getCountFromSeleniumResponse(b []byte, browserName string, browserVersion s
 	for _, session := range sessions {
 		var capability = capability{}
 		if err := json.Unmarshal([]byte(session.Capabilities), &capability); err == nil {
+			if len(capabilities) > 0 && !requestCapabilitiesMatch(session.Capabilities, capabilities, false) {
+				continue
+			}
 			var platformNameMatches = capability.PlatformName == "" || strings.EqualFold(capability.PlatformName, platformName)
 			if capability.BrowserName == sessionBrowserName {
 				if strings.HasPrefix(capability.BrowserVersion, browserVersion) && platformNameMatches {
@@ -1,6 +1,7 @@
 package scalers
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -16,6 +17,7 @@ func Test_getCountFromSeleniumResponse(t *testing.T) {
 		sessionBrowserName string
 		browserVersion     string
 		platformName       string
+		capabilities       map[string]interface{}
 	}
 	tests := []struct {
 		name    string
@@ -501,10 +503,69 @@ func Test_getCountFromSeleniumResponse(t *testing.T) {
 			want:    2,
 			wantErr: false,
 		},
+		{
+			name: "capabilities should only count matching queued requests and sessions",
+			args: args{
+				b: []byte(`{
+					"data": {
+						"grid":{
+							"maxSession": 2,
+							"nodeCount": 2
+						},
+						"sessionsInfo": {
+							"sessionQueueRequests": ["{\n  \"browserName\": \"chrome\",\n \"platformName\": \"linux\",\n \"myApp:team\": \"checkout\",\n \"goog:chromeOptions\": {}\n}","{\n  \"browserName\": \"chrome\",\n \"platformName\": \"linux\",\n \"myApp:team\": \"search\"\n}","{\n  \"browserName\": \"chrome\",\n \"platformName\": \"linux\",\n \"myApp:gpu\": true\n}","{\n  \"browserName\": \"chrome\",\n \"platformName\": \"linux\"\n}"],
+							"sessions": [
+								{
+									"id": "0f9c5a941aa4d755a54b84be1f6535b1",
+									"capabilities": "{\n  \"browserName\": \"chrome\",\n  \"browserVersion\": \"91.0.4472.114\",\n  \"platformName\": \"linux\",\n  \"myApp:team\": \"checkout\",\n  \"se:cdp\": \"http:\\u002f\\u002flocalhost:35839\"\n}",
+									"nodeId": "d44dcbc5-0b2c-4d5e-abf4-6f6aa5e0983c"
+								},
+								{
+									"id": "0f9c5a941aa4d755a54b84be1f6535b2",
+									"capabilities": "{\n  \"browserName\": \"chrome\",\n  \"browserVersion\": \"91.0.4472.114\",\n  \"platformName\": \"linux\",\n  \"myApp:team\": \"search\"\n}",
+									"nodeId": "d44dcbc5-0b2c-4d5e-abf4-6f6aa5e0983d"
+								}
+							]
+						}
+					}
+				}`),
+				browserName:        "chrome",
+				sessionBrowserName: "chrome",
+				browserVersion:     "latest",
+				platformName:       "linux",
+				capabilities:       map[string]interface{}{"myApp:team": "checkout"},
+			},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name: "capabilities with non string values should match queued requests",
+			args: args{
+				b: []byte(`{
+					"data": {
+						"grid":{
+							"maxSession": 1,
+							"nodeCount": 1
+						},
+						"sessionsInfo": {
+							"sessionQueueRequests": ["{\n  \"browserName\": \"chrome\",\n \"platformName\": \"linux\",\n \"myApp:gpu\": true\n}","{\n  \"browserName\": \"chrome\",\n \"platformName\": \"linux\",\n \"myApp:gpu\": false\n}"],
+							"sessions": []
+						}
+					}
+				}`),
+				browserName:        "chrome",
+				sessionBrowserName: "chrome",
+				browserVersion:     "latest",
+				platformName:       "linux",
+				capabilities:       map[string]interface{}{"myApp:gpu": true},
+			},
+			want:    1,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getCountFromSeleniumResponse(tt.args.b, tt.args.browserName, tt.args.browserVersion, tt.args.sessionBrowserName, tt.args.platformName, logr.Discard())
+			got, err := getCountFromSeleniumResponse(tt.args.b, tt.args.browserName, tt.args.browserVersion, tt.args.sessionBrowserName, tt.args.platformName, tt.args.capabilities, logr.Discard())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getCountFromSeleniumResponse() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -751,6 +812,42 @@ func Test_parseSeleniumGridScalerMetadata(t *testing.T) {
 				PlatformName:        "Windows 11",
 			},
 		},
+		{
+			name: "valid url, browsername and capabilities should return metadata",
+			args: args{
+				config: &scalersconfig.ScalerConfig{
+					TriggerMetadata: map[string]string{
+						"url":          "http://selenium-hub:4444/graphql",
+						"browserName":  "chrome",
+						"capabilities": `{"myApp:team": "checkout", "myApp:gpu": true}`,
+					},
+				},
+			},
+			wantErr: false,
+			want: &seleniumGridScalerMetadata{
+				URL:                "http://selenium-hub:4444/graphql",
+				BrowserName:        "chrome",
+				SessionBrowserName: "chrome",
+				TargetValue:        1,
+				BrowserVersion:     "latest",
+				PlatformName:       "linux",
+				Capabilities:       `{"myApp:team": "checkout", "myApp:gpu": true}`,
+				capabilities:       map[string]interface{}{"myApp:team": "checkout", "myApp:gpu": true},
+			},
+		},
+		{
+			name: "capabilities which are not a JSON object should throw error",
+			args: args{
+				config: &scalersconfig.ScalerConfig{
+					TriggerMetadata: map[string]string{
+						"url":          "http://selenium-hub:4444/graphql",
+						"browserName":  "chrome",
+						"capabilities": `["myApp:team"]`,
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -765,3 +862,36 @@ func Test_parseSeleniumGridScalerMetadata(t *testing.T) {
 		})
 	}
 }
+
+func Test_seleniumGridScalerMetricName(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities map[string]interface{}
+		want         string
+	}{
+		{
+			name: "without capabilities",
+			want: "s0-seleniumgrid-chrome",
+		},
+		{
+			name:         "with capabilities",
+			capabilities: map[string]interface{}{"myApp:team": "checkout", "myApp:gpu": true},
+			want:         "s0-seleniumgrid-chrome-myApp-gpu-true-myApp-team-checkout",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := seleniumGridScaler{
+				metadata: &seleniumGridScalerMetadata{
+					BrowserName:  "chrome",
+					TargetValue:  1,
+					capabilities: tt.capabilities,
+				},
+			}
+			metricSpec := s.GetMetricSpecForScaling(context.Background())
+			if got := metricSpec[0].External.Metric.Name; got != tt.want {
+				t.Errorf("GetMetricSpecForScaling() metric name = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
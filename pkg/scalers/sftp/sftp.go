@@ -0,0 +1,354 @@
+// Package sftp lists the directories of the SFTP servers, it implements the part of the version 3
+// of the SSH File Transfer Protocol reading the directories over the sftp subsystem of an SSH session.
+package sftp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	protocolVersion = 3
+	// maxPacketSize bounds the packets read from the server, the servers send at most 256KiB
+	maxPacketSize = 256 * 1024
+
+	fxpInit    = 1
+	fxpVersion = 2
+	fxpClose   = 4
+	fxpOpendir = 11
+	fxpReaddir = 12
+	fxpStatus  = 101
+	fxpHandle  = 102
+	fxpName    = 104
+
+	fxOK         = 0
+	fxEOF        = 1
+	fxNoSuchFile = 2
+
+	attrSize        = 0x00000001
+	attrUIDGID      = 0x00000002
+	attrPermissions = 0x00000004
+	attrACModTime   = 0x00000008
+	attrExtended    = 0x80000000
+
+	modeType    = 0170000
+	modeRegular = 0100000
+	modeDir     = 0040000
+)
+
+// FileInfo is an entry of a directory
+type FileInfo struct {
+	Name    string
+	Size    int64
+	ModTime time.Time
+	// Mode is the type and permissions of the entry, as the st_mode of stat
+	Mode uint32
+}
+
+// IsRegular reports whether the entry is a regular file, the servers not reporting the permissions
+// are assumed to list regular files
+func (f FileInfo) IsRegular() bool {
+	return f.Mode == 0 || f.Mode&modeType == modeRegular
+}
+
+// IsDir reports whether the entry is a directory
+func (f FileInfo) IsDir() bool {
+	return f.Mode&modeType == modeDir
+}
+
+// StatusError is a status other than OK returned by the server
+type StatusError struct {
+	Code    uint32
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("sftp: status %d: %s", e.Code, e.Message)
+}
+
+// Client sends the requests of the sftp subsystem, one at a time
+type Client struct {
+	lock    sync.Mutex
+	r       io.Reader
+	w       io.WriteCloser
+	session *ssh.Session
+	nextID  uint32
+}
+
+// NewClient starts the sftp subsystem in a new session of the SSH connection
+func NewClient(conn *ssh.Client) (*Client, error) {
+	session, err := conn.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	w, err := session.StdinPipe()
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	r, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	if err := session.RequestSubsystem("sftp"); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("error starting the sftp subsystem: %w", err)
+	}
+
+	c, err := newClient(r, w)
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	c.session = session
+	return c, nil
+}
+
+// newClient negotiates the version of the protocol with the server
+func newClient(r io.Reader, w io.WriteCloser) (*Client, error) {
+	c := &Client{r: r, w: w}
+	if err := c.writePacket(fxpInit, binary.BigEndian.AppendUint32(nil, protocolVersion)); err != nil {
+		return nil, err
+	}
+	packetType, data, err := c.readPacket()
+	if err != nil {
+		return nil, err
+	}
+	if packetType != fxpVersion || len(data) < 4 {
+		return nil, fmt.Errorf("sftp: unexpected packet %d instead of the version", packetType)
+	}
+	if version := binary.BigEndian.Uint32(data); version < protocolVersion {
+		return nil, fmt.Errorf("sftp: unsupported version %d", version)
+	}
+	return c, nil
+}
+
+// ReadDir returns the entries of the directory, but . and ..
+func (c *Client) ReadDir(path string) ([]FileInfo, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	packetType, data, err := c.request(fxpOpendir, appendString(nil, path))
+	if err != nil {
+		return nil, err
+	}
+	if packetType != fxpHandle {
+		return nil, fmt.Errorf("sftp: unexpected packet %d instead of the handle of %s", packetType, path)
+	}
+	handle, _, err := readString(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []FileInfo
+	for {
+		packetType, data, err = c.request(fxpReaddir, appendString(nil, handle))
+		if err != nil {
+			var statusErr *StatusError
+			if errors.As(err, &statusErr) && statusErr.Code == fxEOF {
+				break
+			}
+			_ = c.closeHandle(handle)
+			return nil, err
+		}
+		if packetType != fxpName {
+			_ = c.closeHandle(handle)
+			return nil, fmt.Errorf("sftp: unexpected packet %d instead of the names of %s", packetType, path)
+		}
+		names, err := parseNames(data)
+		if err != nil {
+			_ = c.closeHandle(handle)
+			return nil, err
+		}
+		for _, name := range names {
+			if name.Name != "." && name.Name != ".." {
+				entries = append(entries, name)
+			}
+		}
+	}
+	return entries, c.closeHandle(handle)
+}
+
+// Close ends the session of the sftp subsystem
+func (c *Client) Close() error {
+	err := c.w.Close()
+	if c.session != nil {
+		if closeErr := c.session.Close(); closeErr != nil && !errors.Is(closeErr, io.EOF) {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (c *Client) closeHandle(handle string) error {
+	_, _, err := c.request(fxpClose, appendString(nil, handle))
+	return err
+}
+
+// request sends a request and returns the response with the same id, the statuses but OK are returned as errors
+func (c *Client) request(packetType byte, payload []byte) (byte, []byte, error) {
+	c.nextID++
+	id := c.nextID
+	if err := c.writePacket(packetType, append(binary.BigEndian.AppendUint32(nil, id), payload...)); err != nil {
+		return 0, nil, err
+	}
+
+	responseType, data, err := c.readPacket()
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(data) < 4 || binary.BigEndian.Uint32(data) != id {
+		return 0, nil, fmt.Errorf("sftp: unexpected response to the request %d", id)
+	}
+	data = data[4:]
+
+	if responseType == fxpStatus {
+		if len(data) < 4 {
+			return 0, nil, errors.New("sftp: short status")
+		}
+		code := binary.BigEndian.Uint32(data)
+		if code == fxOK {
+			return responseType, nil, nil
+		}
+		message, _, _ := readString(data[4:])
+		return 0, nil, &StatusError{Code: code, Message: message}
+	}
+	return responseType, data, nil
+}
+
+func (c *Client) writePacket(packetType byte, payload []byte) error {
+	packet := binary.BigEndian.AppendUint32(make([]byte, 0, 5+len(payload)), uint32(1+len(payload)))
+	packet = append(packet, packetType)
+	packet = append(packet, payload...)
+	_, err := c.w.Write(packet)
+	return err
+}
+
+func (c *Client) readPacket() (byte, []byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(c.r, header); err != nil {
+		return 0, nil, err
+	}
+	length := binary.BigEndian.Uint32(header)
+	if length == 0 || length > maxPacketSize {
+		return 0, nil, fmt.Errorf("sftp: invalid packet length %d", length)
+	}
+	packet := make([]byte, length)
+	if _, err := io.ReadFull(c.r, packet); err != nil {
+		return 0, nil, err
+	}
+	return packet[0], packet[1:], nil
+}
+
+// parseNames parses the entries of a name packet, following its id
+func parseNames(data []byte) ([]FileInfo, error) {
+	if len(data) < 4 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	count := binary.BigEndian.Uint32(data)
+	data = data[4:]
+
+	var names []FileInfo
+	for i := uint32(0); i < count; i++ {
+		var name FileInfo
+		var err error
+		if name.Name, data, err = readString(data); err != nil {
+			return nil, err
+		}
+		// the long name is the line of ls -l, the attributes describe the entry
+		if _, data, err = readString(data); err != nil {
+			return nil, err
+		}
+		if data, err = parseAttributes(data, &name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// parseAttributes parses the attributes of an entry and returns the data following them
+func parseAttributes(data []byte, info *FileInfo) ([]byte, error) {
+	var flags uint32
+	var err error
+	if flags, data, err = readUint32(data); err != nil {
+		return nil, err
+	}
+	if flags&attrSize != 0 {
+		if len(data) < 8 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		info.Size = int64(binary.BigEndian.Uint64(data))
+		data = data[8:]
+	}
+	if flags&attrUIDGID != 0 {
+		if len(data) < 8 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		data = data[8:]
+	}
+	if flags&attrPermissions != 0 {
+		if info.Mode, data, err = readUint32(data); err != nil {
+			return nil, err
+		}
+	}
+	if flags&attrACModTime != 0 {
+		var mtime uint32
+		if len(data) < 4 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		// the access time precedes the modification time
+		if mtime, data, err = readUint32(data[4:]); err != nil {
+			return nil, err
+		}
+		info.ModTime = time.Unix(int64(mtime), 0)
+	}
+	if flags&attrExtended != 0 {
+		var count uint32
+		if count, data, err = readUint32(data); err != nil {
+			return nil, err
+		}
+		for i := uint32(0); i < 2*count; i++ {
+			if _, data, err = readString(data); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return data, nil
+}
+
+func readUint32(data []byte) (uint32, []byte, error) {
+	if len(data) < 4 {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+	return binary.BigEndian.Uint32(data), data[4:], nil
+}
+
+func readString(data []byte) (string, []byte, error) {
+	length, data, err := readUint32(data)
+	if err != nil {
+		return "", nil, err
+	}
+	if uint32(len(data)) < length {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+	return string(data[:length]), data[length:], nil
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(s)))
+	return append(b, s...)
+}
+
+// IsNotExist reports whether the error is the status of a missing file or directory
+func IsNotExist(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.Code == fxNoSuchFile
+}
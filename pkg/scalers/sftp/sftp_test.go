@@ -0,0 +1,107 @@
+package sftp
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEntry struct {
+	name  string
+	size  uint64
+	mode  uint32
+	mtime uint32
+}
+
+// serveSFTP answers the requests of the client with the entries of /incoming, in pages of two entries
+func serveSFTP(t *testing.T, r io.Reader, w io.Writer, entries []testEntry) {
+	c := &Client{r: r}
+	write := func(packetType byte, payload []byte) {
+		packet := binary.BigEndian.AppendUint32(nil, uint32(1+len(payload)))
+		packet = append(packet, packetType)
+		_, err := w.Write(append(packet, payload...))
+		assert.NoError(t, err)
+	}
+	status := func(id, code uint32, message string) {
+		payload := binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(nil, id), code)
+		payload = appendString(appendString(payload, message), "")
+		write(fxpStatus, payload)
+	}
+
+	next := 0
+	for {
+		packetType, data, err := c.readPacket()
+		if err != nil {
+			return
+		}
+		if packetType == fxpInit {
+			write(fxpVersion, binary.BigEndian.AppendUint32(nil, protocolVersion))
+			continue
+		}
+		id := binary.BigEndian.Uint32(data)
+		arg, _, _ := readString(data[4:])
+		switch packetType {
+		case fxpOpendir:
+			if arg != "/incoming" {
+				status(id, fxNoSuchFile, "No such file")
+				continue
+			}
+			write(fxpHandle, appendString(binary.BigEndian.AppendUint32(nil, id), "h1"))
+		case fxpReaddir:
+			if next >= len(entries) {
+				status(id, fxEOF, "End of file")
+				continue
+			}
+			page := entries[next:min(next+2, len(entries))]
+			next += len(page)
+			payload := binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(nil, id), uint32(len(page)))
+			for _, entry := range page {
+				payload = appendString(appendString(payload, entry.name), "-rw-r--r-- 1 user group "+entry.name)
+				payload = binary.BigEndian.AppendUint32(payload, attrSize|attrUIDGID|attrPermissions|attrACModTime)
+				payload = binary.BigEndian.AppendUint64(payload, entry.size)
+				payload = binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(payload, 1000), 1000)
+				payload = binary.BigEndian.AppendUint32(payload, entry.mode)
+				payload = binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(payload, entry.mtime), entry.mtime)
+			}
+			write(fxpName, payload)
+		case fxpClose:
+			status(id, fxOK, "")
+		}
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	entries := []testEntry{
+		{".", 0, modeDir | 0755, 1700000000},
+		{"..", 0, modeDir | 0755, 1700000000},
+		{"orders-1.csv", 128, modeRegular | 0644, 1700000100},
+		{"archive", 0, modeDir | 0755, 1700000200},
+		{"orders-2.csv", 256, modeRegular | 0644, 1700000300},
+	}
+
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	go serveSFTP(t, serverReader, serverWriter, entries)
+
+	c, err := newClient(clientReader, clientWriter)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	files, err := c.ReadDir("/incoming")
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+	assert.Equal(t, "orders-1.csv", files[0].Name)
+	assert.Equal(t, int64(128), files[0].Size)
+	assert.Equal(t, time.Unix(1700000100, 0), files[0].ModTime)
+	assert.True(t, files[0].IsRegular())
+	assert.True(t, files[1].IsDir())
+	assert.False(t, files[1].IsRegular())
+	assert.Equal(t, "orders-2.csv", files[2].Name)
+
+	_, err = c.ReadDir("/missing")
+	assert.True(t, IsNotExist(err))
+	assert.EqualError(t, err, "sftp: status 2: No such file")
+}
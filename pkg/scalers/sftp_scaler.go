@@ -0,0 +1,246 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scalers/sftp"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+type sftpScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *sftpMetadata
+	clientCfg  *ssh.ClientConfig
+	lock       sync.Mutex
+	conn       *ssh.Client
+	sftpClient *sftp.Client
+	logger     logr.Logger
+}
+
+type sftpMetadata struct {
+	Host     string `keda:"name=host,     order=triggerMetadata;authParams"`
+	Port     int    `keda:"name=port,     order=triggerMetadata;authParams, default=22"`
+	Username string `keda:"name=username, order=triggerMetadata;authParams;resolvedEnv"`
+
+	// The private key in PEM format, or the password of the servers which don't accept the keys
+	PrivateKey           string `keda:"name=privateKey,           order=authParams;resolvedEnv, optional"`
+	PrivateKeyPassphrase string `keda:"name=privateKeyPassphrase, order=authParams;resolvedEnv, optional"`
+	Password             string `keda:"name=password,             order=authParams;resolvedEnv, optional"`
+
+	// HostKey is the public key of the server in the authorized_keys format, it's only skipped with InsecureIgnoreHostKey
+	HostKey               string `keda:"name=hostKey,               order=triggerMetadata;authParams, optional"`
+	InsecureIgnoreHostKey bool   `keda:"name=insecureIgnoreHostKey, order=triggerMetadata, default=false"`
+
+	// The regular files of the Directory whose name matches the Pattern are counted, once they're
+	// older than MinFileAge seconds so the files still being uploaded are left out
+	Directory  string `keda:"name=directory,  order=triggerMetadata"`
+	Pattern    string `keda:"name=pattern,    order=triggerMetadata, default=*"`
+	MinFileAge int    `keda:"name=minFileAge, order=triggerMetadata, default=0"`
+
+	TargetFileCount           int64 `keda:"name=targetFileCount,           order=triggerMetadata, default=10"`
+	ActivationTargetFileCount int64 `keda:"name=activationTargetFileCount, order=triggerMetadata, default=0"`
+
+	triggerIndex int
+}
+
+func (m *sftpMetadata) Validate() error {
+	if m.PrivateKey == "" && m.Password == "" {
+		return errors.New("either privateKey or password must be provided")
+	}
+	if m.PrivateKeyPassphrase != "" && m.PrivateKey == "" {
+		return errors.New("privateKeyPassphrase requires privateKey")
+	}
+	if m.HostKey == "" && !m.InsecureIgnoreHostKey {
+		return errors.New("hostKey must be provided unless insecureIgnoreHostKey is set")
+	}
+	if _, err := path.Match(m.Pattern, ""); err != nil {
+		return fmt.Errorf("invalid pattern %s: %w", m.Pattern, err)
+	}
+	if m.MinFileAge < 0 {
+		return errors.New("minFileAge must not be negative")
+	}
+	if m.TargetFileCount <= 0 {
+		return errors.New("targetFileCount must be greater than 0")
+	}
+	return nil
+}
+
+// NewSftpScaler creates a new scaler for the files matching a pattern in a directory of an SFTP server
+func NewSftpScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseSftpMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing sftp metadata: %w", err)
+	}
+
+	clientCfg, err := newSftpClientConfig(meta)
+	if err != nil {
+		return nil, err
+	}
+	clientCfg.Timeout = config.GlobalHTTPTimeout
+
+	return &sftpScaler{
+		metricType: metricType,
+		metadata:   meta,
+		clientCfg:  clientCfg,
+		logger:     InitializeLogger(config, "sftp_scaler"),
+	}, nil
+}
+
+func parseSftpMetadata(config *scalersconfig.ScalerConfig) (*sftpMetadata, error) {
+	meta := &sftpMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing sftp metadata: %w", err)
+	}
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// newSftpClientConfig returns the SSH configuration authenticating with the private key, or else the password
+func newSftpClientConfig(meta *sftpMetadata) (*ssh.ClientConfig, error) {
+	cfg := &ssh.ClientConfig{User: meta.Username}
+
+	switch {
+	case meta.PrivateKey != "" && meta.PrivateKeyPassphrase != "":
+		signer, err := ssh.ParsePrivateKeyWithPassphrase([]byte(meta.PrivateKey), []byte(meta.PrivateKeyPassphrase))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the private key: %w", err)
+		}
+		cfg.Auth = []ssh.AuthMethod{ssh.PublicKeys(signer)}
+	case meta.PrivateKey != "":
+		signer, err := ssh.ParsePrivateKey([]byte(meta.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the private key: %w", err)
+		}
+		cfg.Auth = []ssh.AuthMethod{ssh.PublicKeys(signer)}
+	default:
+		cfg.Auth = []ssh.AuthMethod{ssh.Password(meta.Password)}
+	}
+
+	if meta.HostKey != "" {
+		hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(meta.HostKey))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the host key: %w", err)
+		}
+		cfg.HostKeyCallback = ssh.FixedHostKey(hostKey)
+		cfg.HostKeyAlgorithms = []string{hostKey.Type()}
+	} else {
+		// #nosec G106 -- the host key is only ignored when the trigger sets insecureIgnoreHostKey
+		cfg.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+	}
+	return cfg, nil
+}
+
+// connect connects to the server unless the scaler is connected already, the connection is kept
+// between the polls and dropped when it fails
+func (s *sftpScaler) connect() (*sftp.Client, error) {
+	if s.sftpClient != nil {
+		return s.sftpClient, nil
+	}
+
+	addr := net.JoinHostPort(s.metadata.Host, fmt.Sprint(s.metadata.Port))
+	conn, err := ssh.Dial("tcp", addr, s.clientCfg)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %w", addr, err)
+	}
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	s.conn = conn
+	s.sftpClient = client
+	return client, nil
+}
+
+func (s *sftpScaler) disconnect() error {
+	if s.sftpClient == nil {
+		return nil
+	}
+	_ = s.sftpClient.Close()
+	err := s.conn.Close()
+	s.sftpClient = nil
+	s.conn = nil
+	return err
+}
+
+// getFileCount counts the regular files of the directory matching the pattern and older than minFileAge
+func (s *sftpScaler) getFileCount() (int64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	client, err := s.connect()
+	if err != nil {
+		return -1, err
+	}
+	files, err := client.ReadDir(s.metadata.Directory)
+	if err != nil {
+		if !sftp.IsNotExist(err) {
+			_ = s.disconnect()
+		}
+		return -1, fmt.Errorf("error reading the directory %s: %w", s.metadata.Directory, err)
+	}
+	return countSftpFiles(files, s.metadata, time.Now()), nil
+}
+
+func countSftpFiles(files []sftp.FileInfo, meta *sftpMetadata, now time.Time) int64 {
+	minFileAge := time.Duration(meta.MinFileAge) * time.Second
+	var count int64
+	for _, file := range files {
+		if !file.IsRegular() {
+			continue
+		}
+		if matched, _ := path.Match(meta.Pattern, file.Name); !matched {
+			continue
+		}
+		if minFileAge > 0 && now.Sub(file.ModTime) < minFileAge {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+func (s *sftpScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	count, err := s.getFileCount()
+	if err != nil {
+		s.logger.Error(err, "Error counting the files", "host", s.metadata.Host)
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(count))
+	return []external_metrics.ExternalMetricValue{metric}, count > s.metadata.ActivationTargetFileCount, nil
+}
+
+func (s *sftpScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("sftp-%s", s.metadata.Host))),
+		},
+		Target: GetMetricTarget(s.metricType, s.metadata.TargetFileCount),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *sftpScaler) Close(context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.disconnect()
+}
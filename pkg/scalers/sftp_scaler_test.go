@@ -0,0 +1,128 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scalers/sftp"
+)
+
+const testSftpHostKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"
+
+type parseSftpMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type sftpMetricIdentifier struct {
+	metadataTestData *parseSftpMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testSftpMetadata = []parseSftpMetadataTestData{
+	// password with the host key
+	{map[string]string{"host": "files.example.com", "username": "keda", "directory": "/incoming", "hostKey": testSftpHostKey}, map[string]string{"password": "secret"}, false},
+	// pattern, minFileAge and targets
+	{map[string]string{"host": "files.example.com", "port": "2222", "username": "keda", "directory": "/incoming", "pattern": "orders-*.csv", "minFileAge": "60", "targetFileCount": "5", "activationTargetFileCount": "1", "insecureIgnoreHostKey": "true"}, map[string]string{"password": "secret"}, false},
+	// host and username from the authentication
+	{map[string]string{"directory": "/incoming", "insecureIgnoreHostKey": "true"}, map[string]string{"host": "files.example.com", "username": "keda", "password": "secret"}, false},
+	// missing host
+	{map[string]string{"username": "keda", "directory": "/incoming", "insecureIgnoreHostKey": "true"}, map[string]string{"password": "secret"}, true},
+	// missing directory
+	{map[string]string{"host": "files.example.com", "username": "keda", "insecureIgnoreHostKey": "true"}, map[string]string{"password": "secret"}, true},
+	// missing credentials
+	{map[string]string{"host": "files.example.com", "username": "keda", "directory": "/incoming", "insecureIgnoreHostKey": "true"}, map[string]string{}, true},
+	// passphrase without private key
+	{map[string]string{"host": "files.example.com", "username": "keda", "directory": "/incoming", "insecureIgnoreHostKey": "true"}, map[string]string{"password": "secret", "privateKeyPassphrase": "passphrase"}, true},
+	// missing host key
+	{map[string]string{"host": "files.example.com", "username": "keda", "directory": "/incoming"}, map[string]string{"password": "secret"}, true},
+	// invalid pattern
+	{map[string]string{"host": "files.example.com", "username": "keda", "directory": "/incoming", "pattern": "orders-[", "insecureIgnoreHostKey": "true"}, map[string]string{"password": "secret"}, true},
+	// negative minFileAge
+	{map[string]string{"host": "files.example.com", "username": "keda", "directory": "/incoming", "minFileAge": "-1", "insecureIgnoreHostKey": "true"}, map[string]string{"password": "secret"}, true},
+	// invalid targetFileCount
+	{map[string]string{"host": "files.example.com", "username": "keda", "directory": "/incoming", "targetFileCount": "0", "insecureIgnoreHostKey": "true"}, map[string]string{"password": "secret"}, true},
+}
+
+var sftpMetricIdentifiers = []sftpMetricIdentifier{
+	{&testSftpMetadata[0], 0, "s0-sftp-files-example-com"},
+	{&testSftpMetadata[1], 1, "s1-sftp-files-example-com"},
+}
+
+func TestSftpParseMetadata(t *testing.T) {
+	for i, testData := range testSftpMetadata {
+		_, err := parseSftpMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", i, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", i)
+		}
+	}
+}
+
+func TestSftpClientConfig(t *testing.T) {
+	meta, err := parseSftpMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testSftpMetadata[0].metadata, AuthParams: testSftpMetadata[0].authParams})
+	assert.NoError(t, err)
+	cfg, err := newSftpClientConfig(meta)
+	assert.NoError(t, err)
+	assert.Equal(t, "keda", cfg.User)
+	assert.Equal(t, []string{"ssh-ed25519"}, cfg.HostKeyAlgorithms)
+
+	meta.PrivateKey = "not a key"
+	_, err = newSftpClientConfig(meta)
+	assert.ErrorContains(t, err, "error parsing the private key")
+
+	meta.PrivateKey = ""
+	meta.HostKey = "not a key"
+	_, err = newSftpClientConfig(meta)
+	assert.ErrorContains(t, err, "error parsing the host key")
+}
+
+func TestSftpGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range sftpMetricIdentifiers {
+		meta, err := parseSftpMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockSftpScaler := sftpScaler{metadata: meta}
+
+		metricSpec := mockSftpScaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func TestCountSftpFiles(t *testing.T) {
+	now := time.Now()
+	files := []sftp.FileInfo{
+		{Name: "orders-1.csv", ModTime: now.Add(-time.Hour), Mode: 0100644},
+		{Name: "orders-2.csv", ModTime: now.Add(-10 * time.Second), Mode: 0100644},
+		{Name: "orders-3.csv.part", ModTime: now.Add(-time.Hour), Mode: 0100644},
+		{Name: "orders-archive.csv", ModTime: now.Add(-time.Hour), Mode: 0040755},
+		{Name: "invoice-1.csv", ModTime: now.Add(-time.Hour), Mode: 0100644},
+	}
+
+	testCases := []struct {
+		name     string
+		pattern  string
+		minAge   int
+		expected int64
+	}{
+		{"all the files", "*", 0, 4},
+		{"matching files", "orders-*.csv", 0, 2},
+		{"matching files older than minFileAge", "orders-*.csv", 60, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meta := &sftpMetadata{Pattern: tc.pattern, MinFileAge: tc.minAge}
+			assert.Equal(t, tc.expected, countSftpFiles(files, meta, now))
+		})
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+)
+
+// sharedClients are the clients shared by the scalers connecting to the same endpoint with the same
+// credentials, so they aren't recreated each time a ScaledObject is updated. A client is closed once
+// the last scaler using it is closed. A client failing is invalidated by its scalers, the scalers
+// created afterwards, as the ones rebuilt after the error, get a new one.
+type sharedClients[T any] struct {
+	lock        sync.Mutex
+	clients     map[string]*sharedClient[T]
+	closeClient func(T) error
+}
+
+type sharedClient[T any] struct {
+	client T
+	err    error
+	// ready is closed once the client is created
+	ready chan struct{}
+	refs  int
+}
+
+// sharedClientRef is the reference of a scaler on a shared client, a nil one references no client
+type sharedClientRef struct {
+	releaseClient    func() error
+	invalidateClient func()
+}
+
+// release releases the client, it has to be called once the scaler is closed
+func (r *sharedClientRef) release() error {
+	if r == nil {
+		return nil
+	}
+	return r.releaseClient()
+}
+
+// invalidate stops sharing the client with the scalers created afterwards, it's called once it failed
+func (r *sharedClientRef) invalidate() {
+	if r == nil {
+		return
+	}
+	r.invalidateClient()
+}
+
+func newSharedClients[T any](closeClient func(T) error) *sharedClients[T] {
+	return &sharedClients[T]{
+		clients:     map[string]*sharedClient[T]{},
+		closeClient: closeClient,
+	}
+}
+
+// acquire returns the client shared for the key, it's created by the function if none is, and
+// the reference of the scaler on it
+func (s *sharedClients[T]) acquire(key string, create func() (T, error)) (T, *sharedClientRef, error) {
+	s.lock.Lock()
+	entry, found := s.clients[key]
+	if !found {
+		entry = &sharedClient[T]{ready: make(chan struct{})}
+		s.clients[key] = entry
+	}
+	entry.refs++
+	s.lock.Unlock()
+
+	if found {
+		<-entry.ready
+	} else {
+		entry.client, entry.err = create()
+		if entry.err != nil {
+			// the scalers created afterwards try again
+			s.lock.Lock()
+			delete(s.clients, key)
+			s.lock.Unlock()
+		}
+		close(entry.ready)
+	}
+
+	if entry.err != nil {
+		s.lock.Lock()
+		entry.refs--
+		s.lock.Unlock()
+		var client T
+		return client, nil, entry.err
+	}
+	ref := &sharedClientRef{
+		releaseClient: sync.OnceValue(func() error {
+			return s.release(key, entry)
+		}),
+		invalidateClient: func() {
+			s.invalidate(key, entry)
+		},
+	}
+	return entry.client, ref, nil
+}
+
+func (s *sharedClients[T]) release(key string, entry *sharedClient[T]) error {
+	s.lock.Lock()
+	entry.refs--
+	last := entry.refs == 0
+	if last && s.clients[key] == entry {
+		delete(s.clients, key)
+	}
+	s.lock.Unlock()
+
+	if !last {
+		return nil
+	}
+	return s.closeClient(entry.client)
+}
+
+// invalidate removes the client from the clients shared, it's still closed once the last scaler using it is closed
+func (s *sharedClients[T]) invalidate(key string, entry *sharedClient[T]) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.clients[key] == entry {
+		delete(s.clients, key)
+	}
+}
+
+// len returns the number of clients shared
+func (s *sharedClients[T]) len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.clients)
+}
+
+// sharedClientKey returns the key of the clients of an endpoint with credentials, it's hashed so
+// the credentials aren't retained
+func sharedClientKey(parts ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalers
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSharedClient struct {
+	endpoint string
+	closed   bool
+}
+
+func TestSharedClients(t *testing.T) {
+	clients := newSharedClients(func(c *testSharedClient) error {
+		c.closed = true
+		return nil
+	})
+	created := 0
+	create := func(endpoint string) func() (*testSharedClient, error) {
+		return func() (*testSharedClient, error) {
+			created++
+			return &testSharedClient{endpoint: endpoint}, nil
+		}
+	}
+
+	// the scalers of the same endpoint and credentials share the client
+	a, refA, err := clients.acquire(sharedClientKey("kafka:9092", "user", "password"), create("kafka:9092"))
+	assert.NoError(t, err)
+	b, refB, err := clients.acquire(sharedClientKey("kafka:9092", "user", "password"), create("kafka:9092"))
+	assert.NoError(t, err)
+	assert.Same(t, a, b)
+
+	// the ones of other credentials don't
+	c, refC, err := clients.acquire(sharedClientKey("kafka:9092", "user", "other"), create("kafka:9092"))
+	assert.NoError(t, err)
+	assert.NotSame(t, a, c)
+	assert.Equal(t, 2, created)
+	assert.Equal(t, 2, clients.len())
+
+	// the client is closed once the last scaler using it is released
+	assert.NoError(t, refA.release())
+	assert.False(t, a.closed)
+	// releasing twice doesn't release the references of the other scalers
+	assert.NoError(t, refA.release())
+	assert.False(t, a.closed)
+	assert.NoError(t, refB.release())
+	assert.True(t, a.closed)
+	assert.NoError(t, refC.release())
+	assert.True(t, c.closed)
+	assert.Equal(t, 0, clients.len())
+
+	// a client is created again once all the scalers released it
+	d, refD, err := clients.acquire(sharedClientKey("kafka:9092", "user", "password"), create("kafka:9092"))
+	assert.NoError(t, err)
+	assert.NotSame(t, a, d)
+	assert.Equal(t, 3, created)
+	assert.NoError(t, refD.release())
+}
+
+func TestSharedClientsCreationError(t *testing.T) {
+	clients := newSharedClients(func(*testSharedClient) error { return nil })
+	key := sharedClientKey("postgresql")
+
+	_, ref, err := clients.acquire(key, func() (*testSharedClient, error) {
+		return nil, errors.New("connection refused")
+	})
+	assert.Error(t, err)
+	assert.Nil(t, ref)
+	assert.Equal(t, 0, clients.len())
+
+	// the creation is tried again by the next scaler
+	client, ref, err := clients.acquire(key, func() (*testSharedClient, error) {
+		return &testSharedClient{endpoint: "postgresql"}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "postgresql", client.endpoint)
+	assert.NoError(t, ref.release())
+}
+
+func TestSharedClientsInvalidation(t *testing.T) {
+	clients := newSharedClients(func(c *testSharedClient) error {
+		c.closed = true
+		return nil
+	})
+	key := sharedClientKey("kafka:9092")
+	create := func() (*testSharedClient, error) {
+		return &testSharedClient{endpoint: "kafka:9092"}, nil
+	}
+
+	a, refA, err := clients.acquire(key, create)
+	assert.NoError(t, err)
+	b, refB, err := clients.acquire(key, create)
+	assert.NoError(t, err)
+	assert.Same(t, a, b)
+
+	// the scalers created once the client failed get a new one
+	refA.invalidate()
+	assert.Equal(t, 0, clients.len())
+	c, refC, err := clients.acquire(key, create)
+	assert.NoError(t, err)
+	assert.NotSame(t, a, c)
+
+	// the failed client is closed once the last scaler using it is released
+	assert.NoError(t, refA.release())
+	assert.False(t, a.closed)
+	assert.NoError(t, refB.release())
+	assert.True(t, a.closed)
+
+	// invalidating it again doesn't remove the new client
+	refB.invalidate()
+	assert.Equal(t, 1, clients.len())
+	assert.NoError(t, refC.release())
+	assert.True(t, c.closed)
+
+	// a scaler without shared client has a nil reference
+	var ref *sharedClientRef
+	ref.invalidate()
+	assert.NoError(t, ref.release())
+}
+
+func TestSharedClientsConcurrentCreation(t *testing.T) {
+	clients := newSharedClients(func(*testSharedClient) error { return nil })
+	var lock sync.Mutex
+	created := 0
+
+	wg := sync.WaitGroup{}
+	acquired := make([]*testSharedClient, 10)
+	for i := range acquired {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			client, _, err := clients.acquire(sharedClientKey("mssql"), func() (*testSharedClient, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				created++
+				return &testSharedClient{endpoint: "mssql"}, nil
+			})
+			assert.NoError(t, err)
+			acquired[i] = client
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, created)
+	for _, client := range acquired {
+		assert.Same(t, acquired[0], client)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -22,9 +23,14 @@ const (
 	solaceScalerID      = "solace"
 
 	// REST ENDPOINT String Patterns
-	solaceSempQueryFieldURLSuffix = "?select=msgs,msgSpoolUsage,averageRxMsgRate"
+	solaceSempQueryFieldURLSuffix = "?select=msgs,msgSpoolUsage,averageRxMsgRate,partitionCount"
 	solaceSempEndpointURLTemplate = "%s/%s/%s/monitor/msgVpns/%s/%ss/%s" + solaceSempQueryFieldURLSuffix
 
+	// The partitions of a partitioned queue are the queues whose partitionQueueName is the queue
+	solaceSempPartitionsURLTemplate = "%s/%s/%s/monitor/msgVpns/%s/%ss?%s"
+	solaceSempPartitionsFields      = "queueName,msgs,msgSpoolUsage"
+	solaceSempPartitionsPageSize    = "100"
+
 	// SEMP REST API Context
 	solaceAPIName            = "SEMP"
 	solaceAPIVersion         = "v2"
@@ -44,6 +50,9 @@ const (
 	solaceMetaMsgVpn    = "messageVpn"
 	solaceMetaQueueName = "queueName"
 
+	// Weights of the backlog of the partitions
+	solaceMetaPartitionWeights = "partitionWeights"
+
 	// Metric Targets
 	solaceMetaMsgCountTarget      = "messageCountTarget"
 	solaceMetaMsgSpoolUsageTarget = "messageSpoolUsageTarget"
@@ -85,6 +94,8 @@ type SolaceMetadata struct {
 
 	// Full SEMP URL to target queue (CONSTRUCTED IN CODE)
 	EndpointURL string
+	// Full SEMP URL to the partitions of the target queue (CONSTRUCTED IN CODE)
+	PartitionsEndpointURL string
 
 	// Solace Message VPN
 	MessageVpn string `keda:"name=messageVpn,   order=triggerMetadata"`
@@ -104,6 +115,10 @@ type SolaceMetadata struct {
 	ActivationMsgCountTarget      int `keda:"name=activationMessageCountTarget,      order=triggerMetadata, optional, default=0"`
 	ActivationMsgSpoolUsageTarget int `keda:"name=activationMessageSpoolUsageTarget, order=triggerMetadata, optional, default=0"`      // Spool Use Target in Megabytes
 	ActivationMsgRxRateTarget     int `keda:"name=activationMessageReceiveRateTarget,     order=triggerMetadata, optional, default=0"` // Ingress Rate Target per consumer in msgs/second
+
+	// Weights of the backlog of the partitions of a partitioned queue by partition number, e.g. 0=2,1=0.5
+	// The partitions which aren't listed have a weight of 1
+	PartitionWeights map[int]float64 `keda:"name=partitionWeights, order=triggerMetadata, optional"`
 }
 
 func (s *SolaceMetadata) Validate() error {
@@ -112,6 +127,12 @@ func (s *SolaceMetadata) Validate() error {
 		return fmt.Errorf("no target value found in the scaler configuration")
 	}
 
+	for partition, weight := range s.PartitionWeights {
+		if partition < 0 || weight < 0 {
+			return fmt.Errorf("invalid partition weight %d=%v, partitions and weights must not be negative", partition, weight)
+		}
+	}
+
 	// Convert Megabyte values to Bytes
 	s.MsgSpoolUsageTarget = s.MsgSpoolUsageTarget * 1024 * 1024
 	s.ActivationMsgSpoolUsageTarget = s.ActivationMsgSpoolUsageTarget * 1024 * 1024
@@ -135,6 +156,8 @@ type solaceSEMPCollections struct {
 type solaceSEMPData struct {
 	MsgSpoolUsage int `json:"msgSpoolUsage"`
 	MsgRcvRate    int `json:"averageRxMsgRate"`
+	// Partitioned queues have partitions, the other queues have 0
+	PartitionCount int `json:"partitionCount"`
 }
 
 // SEMP API Messages Struct
@@ -144,7 +167,26 @@ type solaceSEMPMessages struct {
 
 // SEMP API Metadata Struct
 type solaceSEMPMetadata struct {
-	ResponseCode int `json:"responseCode"`
+	ResponseCode int              `json:"responseCode"`
+	Paging       solaceSEMPPaging `json:"paging"`
+}
+
+// SEMP API Paging Struct
+type solaceSEMPPaging struct {
+	NextPageURI string `json:"nextPageUri"`
+}
+
+// SEMP API Response Root Struct of the partitions, the collections are in the order of the data
+type solaceSEMPPartitionsResponse struct {
+	Collections []solaceSEMPCollections   `json:"collections"`
+	Data        []solaceSEMPPartitionData `json:"data"`
+	Meta        solaceSEMPMetadata        `json:"meta"`
+}
+
+// SEMP API Response Partition Data Struct
+type solaceSEMPPartitionData struct {
+	QueueName     string `json:"queueName"`
+	MsgSpoolUsage int    `json:"msgSpoolUsage"`
 }
 
 // NewSolaceScaler is the constructor for SolaceScaler
@@ -192,6 +234,20 @@ func parseSolaceMetadata(config *scalersconfig.ScalerConfig) (*SolaceMetadata, e
 		solaceAPIObjectTypeQueue,
 		url.QueryEscape(meta.QueueName),
 	)
+	partitionsQuery := url.Values{
+		"where":  {"partitionQueueName==" + meta.QueueName},
+		"select": {solaceSempPartitionsFields},
+		"count":  {solaceSempPartitionsPageSize},
+	}
+	meta.PartitionsEndpointURL = fmt.Sprintf(
+		solaceSempPartitionsURLTemplate,
+		meta.SolaceMetaSempBaseURL,
+		solaceAPIName,
+		solaceAPIVersion,
+		meta.MessageVpn,
+		solaceAPIObjectTypeQueue,
+		partitionsQuery.Encode(),
+	)
 
 	return meta, nil
 }
@@ -246,17 +302,80 @@ func (s *SolaceScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec
 }
 
 // returns SolaceMetricValues struct populated from broker  SEMP endpoint
+// the backlog of a partitioned queue is the backlog of its partition with the largest weighted backlog
 func (s *SolaceScaler) getSolaceQueueMetricsFromSEMP(ctx context.Context) (SolaceMetricValues, error) {
-	var scaledMetricEndpointURL = s.metadata.EndpointURL
-	var httpClient = s.httpClient
 	var sempResponse solaceSEMPResponse
 	var metricValues SolaceMetricValues
 
+	if err := s.getFromSEMP(ctx, s.metadata.EndpointURL, &sempResponse); err != nil {
+		return SolaceMetricValues{}, err
+	}
+	if sempResponse.Meta.ResponseCode < 200 || sempResponse.Meta.ResponseCode > 299 {
+		return SolaceMetricValues{}, fmt.Errorf("solace semp api returned error status: %d", sempResponse.Meta.ResponseCode)
+	}
+
+	// Set Return Values
+	metricValues.msgCount = sempResponse.Collections.Msgs.Count
+	metricValues.msgSpoolUsage = sempResponse.Data.MsgSpoolUsage
+	metricValues.msgRcvRate = sempResponse.Data.MsgRcvRate
+
+	if sempResponse.Data.PartitionCount > 0 {
+		msgCount, msgSpoolUsage, err := s.getSolacePartitionsBacklogFromSEMP(ctx)
+		if err != nil {
+			return SolaceMetricValues{}, err
+		}
+		metricValues.msgCount = msgCount
+		metricValues.msgSpoolUsage = msgSpoolUsage
+	}
+	return metricValues, nil
+}
+
+// returns the largest weighted message count and spool usage of the partitions of the queue
+func (s *SolaceScaler) getSolacePartitionsBacklogFromSEMP(ctx context.Context) (int, int, error) {
+	var maxMsgCount, maxMsgSpoolUsage float64
+
+	nextPageURL := s.metadata.PartitionsEndpointURL
+	for nextPageURL != "" {
+		var sempResponse solaceSEMPPartitionsResponse
+		if err := s.getFromSEMP(ctx, nextPageURL, &sempResponse); err != nil {
+			return 0, 0, err
+		}
+		if sempResponse.Meta.ResponseCode < 200 || sempResponse.Meta.ResponseCode > 299 {
+			return 0, 0, fmt.Errorf("solace semp api returned error status for the partitions: %d", sempResponse.Meta.ResponseCode)
+		}
+		if len(sempResponse.Collections) != len(sempResponse.Data) {
+			return 0, 0, fmt.Errorf("solace semp api returned %d collections for %d partitions", len(sempResponse.Collections), len(sempResponse.Data))
+		}
+
+		for i, partition := range sempResponse.Data {
+			weight := s.partitionWeight(partition.QueueName)
+			maxMsgCount = max(maxMsgCount, weight*float64(sempResponse.Collections[i].Msgs.Count))
+			maxMsgSpoolUsage = max(maxMsgSpoolUsage, weight*float64(partition.MsgSpoolUsage))
+		}
+		nextPageURL = sempResponse.Meta.Paging.NextPageURI
+	}
+	return int(math.Round(maxMsgCount)), int(math.Round(maxMsgSpoolUsage)), nil
+}
+
+// partitionWeight returns the weight of the partition queue named #pq/<queueName>/<partition number>
+func (s *SolaceScaler) partitionWeight(partitionQueueName string) float64 {
+	partition, err := strconv.Atoi(partitionQueueName[strings.LastIndex(partitionQueueName, "/")+1:])
+	if err != nil {
+		return 1
+	}
+	if weight, ok := s.metadata.PartitionWeights[partition]; ok {
+		return weight
+	}
+	return 1
+}
+
+// decodes the response of a GET request to the SEMP API
+func (s *SolaceScaler) getFromSEMP(ctx context.Context, endpointURL string, sempResponse any) error {
 	//	RETRIEVE METRICS FROM SOLACE SEMP API
 	//	Define HTTP Request
-	request, err := http.NewRequestWithContext(ctx, "GET", scaledMetricEndpointURL, nil)
+	request, err := http.NewRequestWithContext(ctx, "GET", endpointURL, nil)
 	if err != nil {
-		return SolaceMetricValues{}, fmt.Errorf("failed attempting request to solace semp api: %w", err)
+		return fmt.Errorf("failed attempting request to solace semp api: %w", err)
 	}
 
 	//	Add HTTP Auth and Headers
@@ -264,31 +383,23 @@ func (s *SolaceScaler) getSolaceQueueMetricsFromSEMP(ctx context.Context) (Solac
 	request.Header.Set("Content-Type", "application/json")
 
 	//	Call Solace SEMP API
-	response, err := httpClient.Do(request)
+	response, err := s.httpClient.Do(request)
 	if err != nil {
-		return SolaceMetricValues{}, fmt.Errorf("call to solace semp api failed: %w", err)
+		return fmt.Errorf("call to solace semp api failed: %w", err)
 	}
 	defer response.Body.Close()
 
 	// Check HTTP Status Code
 	if response.StatusCode < 200 || response.StatusCode > 299 {
 		sempError := fmt.Errorf("semp request http status code: %s - %s", strconv.Itoa(response.StatusCode), response.Status)
-		return SolaceMetricValues{}, sempError
+		return sempError
 	}
 
-	// Decode SEMP Response and Test
-	if err := json.NewDecoder(response.Body).Decode(&sempResponse); err != nil {
-		return SolaceMetricValues{}, fmt.Errorf("failed to read semp response body: %w", err)
-	}
-	if sempResponse.Meta.ResponseCode < 200 || sempResponse.Meta.ResponseCode > 299 {
-		return SolaceMetricValues{}, fmt.Errorf("solace semp api returned error status: %d", sempResponse.Meta.ResponseCode)
+	// Decode SEMP Response
+	if err := json.NewDecoder(response.Body).Decode(sempResponse); err != nil {
+		return fmt.Errorf("failed to read semp response body: %w", err)
 	}
-
-	// Set Return Values
-	metricValues.msgCount = sempResponse.Collections.Msgs.Count
-	metricValues.msgSpoolUsage = sempResponse.Data.MsgSpoolUsage
-	metricValues.msgRcvRate = sempResponse.Data.MsgRcvRate
-	return metricValues, nil
+	return nil
 }
 
 // INTERFACE METHOD
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
@@ -267,6 +268,51 @@ var testParseSolaceMetadata = []testSolaceMetadata{
 		1,
 		false,
 	},
+	// +Case - partitionWeights
+	{
+		"#017 - partitionWeights",
+		map[string]string{
+			solaceMetaSempBaseURL:      soltestValidBaseURL,
+			solaceMetaMsgVpn:           soltestValidVpn,
+			solaceMetaUsername:         soltestValidUsername,
+			solaceMetaPassword:         soltestValidPassword,
+			solaceMetaQueueName:        soltestValidQueueName,
+			solaceMetaMsgCountTarget:   soltestValidMsgCountTarget,
+			solaceMetaPartitionWeights: "0=2, 3=0.5",
+		},
+		1,
+		false,
+	},
+	// -Case - partitionWeights negative weight
+	{
+		"#018 - partitionWeights negative weight",
+		map[string]string{
+			solaceMetaSempBaseURL:      soltestValidBaseURL,
+			solaceMetaMsgVpn:           soltestValidVpn,
+			solaceMetaUsername:         soltestValidUsername,
+			solaceMetaPassword:         soltestValidPassword,
+			solaceMetaQueueName:        soltestValidQueueName,
+			solaceMetaMsgCountTarget:   soltestValidMsgCountTarget,
+			solaceMetaPartitionWeights: "0=-1",
+		},
+		1,
+		true,
+	},
+	// -Case - partitionWeights non-numeric partition
+	{
+		"#019 - partitionWeights non-numeric partition",
+		map[string]string{
+			solaceMetaSempBaseURL:      soltestValidBaseURL,
+			solaceMetaMsgVpn:           soltestValidVpn,
+			solaceMetaUsername:         soltestValidUsername,
+			solaceMetaPassword:         soltestValidPassword,
+			solaceMetaQueueName:        soltestValidQueueName,
+			solaceMetaMsgCountTarget:   soltestValidMsgCountTarget,
+			solaceMetaPartitionWeights: "first=2",
+		},
+		1,
+		true,
+	},
 }
 
 var testSolaceEnvCreds = []testSolaceMetadata{
@@ -618,3 +664,62 @@ func TestSolaceGetMetricSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestSolacePartitionedQueueMetrics(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/SEMP/v2/monitor/msgVpns/"+soltestValidVpn+"/queues/"+soltestValidQueueName:
+			fmt.Fprint(w, `{"collections":{"msgs":{"count":60}},"data":{"msgSpoolUsage":6000,"averageRxMsgRate":5,"partitionCount":3},"meta":{"responseCode":200}}`)
+		case r.URL.Query().Get("cursor") == "":
+			if r.URL.Query().Get("where") != "partitionQueueName=="+soltestValidQueueName {
+				t.Errorf("unexpected partitions filter: %s", r.URL.Query().Get("where"))
+			}
+			fmt.Fprintf(w, `{"collections":[{"msgs":{"count":10}},{"msgs":{"count":30}}],`+
+				`"data":[{"queueName":"#pq/queue3/00000","msgSpoolUsage":1000},{"queueName":"#pq/queue3/00001","msgSpoolUsage":3000}],`+
+				`"meta":{"responseCode":200,"paging":{"nextPageUri":"%s/SEMP/v2/monitor/msgVpns/%s/queues?cursor=next"}}}`, server.URL, soltestValidVpn)
+		default:
+			fmt.Fprint(w, `{"collections":[{"msgs":{"count":20}}],"data":[{"queueName":"#pq/queue3/00002","msgSpoolUsage":2000}],"meta":{"responseCode":200}}`)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		weights               string
+		expectedMsgCount      int
+		expectedMsgSpoolUsage int
+	}{
+		{"", 30, 3000},
+		{"0=4", 40, 4000},
+		{"1=0.5, 2=0.25", 15, 1500},
+	}
+
+	for _, testCase := range testCases {
+		metadata := map[string]string{
+			solaceMetaSempBaseURL:    server.URL,
+			solaceMetaMsgVpn:         soltestValidVpn,
+			solaceMetaQueueName:      soltestValidQueueName,
+			solaceMetaMsgCountTarget: soltestValidMsgCountTarget,
+		}
+		if testCase.weights != "" {
+			metadata[solaceMetaPartitionWeights] = testCase.weights
+		}
+		solaceMeta, err := parseSolaceMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: metadata, AuthParams: testDataSolaceAuthParamsVALID})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		testSolaceScaler := SolaceScaler{metadata: solaceMeta, httpClient: http.DefaultClient}
+
+		metricValues, err := testSolaceScaler.getSolaceQueueMetricsFromSEMP(context.Background())
+		if err != nil {
+			t.Fatal("Could not get metrics:", err)
+		}
+		if metricValues.msgCount != testCase.expectedMsgCount || metricValues.msgSpoolUsage != testCase.expectedMsgSpoolUsage {
+			t.Errorf("weights %q: expected %d messages and %d bytes but got %d and %d", testCase.weights,
+				testCase.expectedMsgCount, testCase.expectedMsgSpoolUsage, metricValues.msgCount, metricValues.msgSpoolUsage)
+		}
+		if metricValues.msgRcvRate != 5 {
+			t.Errorf("expected the receive rate of the queue but got %d", metricValues.msgRcvRate)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	splunkObservabilityStreamURL    = "https://stream.%s.signalfx.com"
+	splunkObservabilityExecuteAPI   = "v2/signalflow/execute"
+	splunkObservabilityMaxEventSize = 1024 * 1024
+)
+
+type splunkObservabilityScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *splunkObservabilityMetadata
+	httpClient *http.Client
+	streamURL  string
+	logger     logr.Logger
+}
+
+type splunkObservabilityMetadata struct {
+	AccessToken string `keda:"name=accessToken, order=authParams"`
+	Realm       string `keda:"name=realm,       order=triggerMetadata;authParams"`
+
+	// Query is the SignalFlow program, its published streams are read over the last Duration seconds
+	Query    string `keda:"name=query,    order=triggerMetadata"`
+	Duration int    `keda:"name=duration, order=triggerMetadata, default=300"`
+
+	// QueryAggregator aggregates the latest values of the output series, when there are several
+	QueryAggregator string `keda:"name=queryAggregator, order=triggerMetadata, enum=max;min;avg;sum, optional"`
+
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	triggerIndex int
+}
+
+func (m *splunkObservabilityMetadata) Validate() error {
+	if m.Duration <= 0 {
+		return errors.New("duration must be greater than 0")
+	}
+	if m.TargetValue <= 0 {
+		return errors.New("targetValue must be greater than 0")
+	}
+	return nil
+}
+
+// splunkObservabilityEvent is a server-sent event of the stream of the SignalFlow computation
+type splunkObservabilityEvent struct {
+	name string
+	data string
+}
+
+// Model of the relevant part of the messages of the SignalFlow stream
+type splunkObservabilityDataMessage struct {
+	Data []struct {
+		TsID  string  `json:"tsId"`
+		Value float64 `json:"value"`
+	} `json:"data"`
+	LogicalTimestampMs int64 `json:"logicalTimestampMs"`
+}
+
+type splunkObservabilityControlMessage struct {
+	Event     string `json:"event"`
+	AbortInfo struct {
+		AbortReason string `json:"sf_job_abortReason"`
+		AbortState  string `json:"sf_job_abortState"`
+	} `json:"abortInfo"`
+}
+
+type splunkObservabilityErrorMessage struct {
+	Error   int    `json:"error"`
+	Message string `json:"message"`
+}
+
+// NewSplunkObservabilityScaler creates a new scaler for the latest output of a SignalFlow program of Splunk Observability
+func NewSplunkObservabilityScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseSplunkObservabilityMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing splunk observability metadata: %w", err)
+	}
+
+	return &splunkObservabilityScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		streamURL:  fmt.Sprintf(splunkObservabilityStreamURL, meta.Realm),
+		logger:     InitializeLogger(config, "splunk_observability_scaler"),
+	}, nil
+}
+
+func parseSplunkObservabilityMetadata(config *scalersconfig.ScalerConfig) (*splunkObservabilityMetadata, error) {
+	meta := &splunkObservabilityMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing splunk observability metadata: %w", err)
+	}
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// GetMetricValue executes the SignalFlow program over the last duration and returns the latest value of its output,
+// the latest values of several series are aggregated by the queryAggregator
+func (s *splunkObservabilityScaler) GetMetricValue(ctx context.Context) (float64, error) {
+	now := time.Now()
+	params := url.Values{}
+	params.Set("start", strconv.FormatInt(now.Add(-time.Duration(s.metadata.Duration)*time.Second).UnixMilli(), 10))
+	params.Set("stop", strconv.FormatInt(now.UnixMilli(), 10))
+	params.Set("immediate", "true")
+	executeURL := fmt.Sprintf("%s/%s?%s", strings.TrimRight(s.streamURL, "/"), splunkObservabilityExecuteAPI, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, executeURL, strings.NewReader(s.metadata.Query))
+	if err != nil {
+		return -1, err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("X-SF-Token", s.metadata.AccessToken)
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return -1, err
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(r.Body)
+		return -1, fmt.Errorf("signalflow api returned %d: %s", r.StatusCode, strings.TrimSpace(string(b)))
+	}
+
+	// the latest value of each series, the stream ends once the computation reached the stop timestamp
+	latest := map[string]float64{}
+	latestTimestamps := map[string]int64{}
+	err = readSplunkObservabilityEvents(r.Body, func(event splunkObservabilityEvent) (bool, error) {
+		switch event.name {
+		case "data":
+			message := splunkObservabilityDataMessage{}
+			if err := json.Unmarshal([]byte(event.data), &message); err != nil {
+				return false, fmt.Errorf("unable to parse signalflow data message: %w", err)
+			}
+			for _, point := range message.Data {
+				if timestamp, ok := latestTimestamps[point.TsID]; ok && message.LogicalTimestampMs < timestamp {
+					continue
+				}
+				latest[point.TsID] = point.Value
+				latestTimestamps[point.TsID] = message.LogicalTimestampMs
+			}
+		case "control-message":
+			message := splunkObservabilityControlMessage{}
+			if err := json.Unmarshal([]byte(event.data), &message); err != nil {
+				return false, fmt.Errorf("unable to parse signalflow control message: %w", err)
+			}
+			switch message.Event {
+			case "END_OF_CHANNEL":
+				return true, nil
+			case "CHANNEL_ABORT":
+				return false, fmt.Errorf("signalflow computation aborted with %s: %s", message.AbortInfo.AbortState, message.AbortInfo.AbortReason)
+			}
+		case "error":
+			message := splunkObservabilityErrorMessage{}
+			if err := json.Unmarshal([]byte(event.data), &message); err != nil {
+				return false, fmt.Errorf("unable to parse signalflow error message: %w", err)
+			}
+			return false, fmt.Errorf("signalflow computation failed with %d: %s", message.Error, message.Message)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return -1, err
+	}
+
+	if len(latest) == 0 {
+		return -1, errors.New("signalflow program did not output any data point")
+	}
+	values := make([]float64, 0, len(latest))
+	for _, v := range latest {
+		values = append(values, v)
+	}
+	if len(values) > 1 && s.metadata.QueryAggregator == "" {
+		return -1, fmt.Errorf("signalflow program output %d series, queryAggregator must be provided", len(values))
+	}
+
+	value := values[0]
+	for _, v := range values[1:] {
+		switch s.metadata.QueryAggregator {
+		case "min":
+			value = math.Min(value, v)
+		case "max":
+			value = math.Max(value, v)
+		default:
+			value += v
+		}
+	}
+	if s.metadata.QueryAggregator == "avg" {
+		value /= float64(len(values))
+	}
+	return value, nil
+}
+
+// readSplunkObservabilityEvents reads the server-sent events of the stream until handle is done
+func readSplunkObservabilityEvents(body io.Reader, handle func(splunkObservabilityEvent) (bool, error)) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), splunkObservabilityMaxEventSize)
+
+	event := splunkObservabilityEvent{}
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// a blank line dispatches the event
+			if event.name != "" || len(data) > 0 {
+				event.data = strings.Join(data, "\n")
+				done, err := handle(event)
+				if err != nil || done {
+					return err
+				}
+			}
+			event = splunkObservabilityEvent{}
+			data = nil
+			continue
+		}
+		field, fieldValue, _ := strings.Cut(line, ":")
+		fieldValue = strings.TrimPrefix(fieldValue, " ")
+		switch field {
+		case "event":
+			event.name = fieldValue
+		case "data":
+			data = append(data, fieldValue)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading signalflow stream: %w", err)
+	}
+	return errors.New("signalflow stream ended before the end of the computation")
+}
+
+func (s *splunkObservabilityScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.GetMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error executing signalflow program")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *splunkObservabilityScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString("splunk-observability")),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{
+		External: externalMetric,
+		Type:     externalMetricType,
+	}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *splunkObservabilityScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
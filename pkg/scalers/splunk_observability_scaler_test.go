@@ -0,0 +1,172 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseSplunkObservabilityMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type splunkObservabilityMetricIdentifier struct {
+	metadataTestData *parseSplunkObservabilityMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var validSplunkObservabilityAuthParams = map[string]string{
+	"accessToken": "fake",
+}
+
+var testSplunkObservabilityMetadata = []parseSplunkObservabilityMetadataTestData{
+	// Valid metadata, pass.
+	{map[string]string{"realm": "us1", "query": "data('cpu.utilization').mean().publish()", "targetValue": "50"}, validSplunkObservabilityAuthParams, false},
+	// Valid metadata with duration and queryAggregator, pass.
+	{map[string]string{"realm": "us1", "query": "data('cpu.utilization').publish()", "duration": "60", "queryAggregator": "max", "targetValue": "50", "activationTargetValue": "10"}, validSplunkObservabilityAuthParams, false},
+	// No params, fail.
+	{map[string]string{}, map[string]string{}, true},
+	// Missing accessToken, fail.
+	{map[string]string{"realm": "us1", "query": "data('cpu.utilization').mean().publish()", "targetValue": "50"}, map[string]string{}, true},
+	// Missing realm, fail.
+	{map[string]string{"query": "data('cpu.utilization').mean().publish()", "targetValue": "50"}, validSplunkObservabilityAuthParams, true},
+	// Missing query, fail.
+	{map[string]string{"realm": "us1", "targetValue": "50"}, validSplunkObservabilityAuthParams, true},
+	// Invalid duration, fail.
+	{map[string]string{"realm": "us1", "query": "data('cpu.utilization').mean().publish()", "duration": "0", "targetValue": "50"}, validSplunkObservabilityAuthParams, true},
+	// Invalid queryAggregator, fail.
+	{map[string]string{"realm": "us1", "query": "data('cpu.utilization').publish()", "queryAggregator": "last", "targetValue": "50"}, validSplunkObservabilityAuthParams, true},
+	// Missing targetValue, fail.
+	{map[string]string{"realm": "us1", "query": "data('cpu.utilization').mean().publish()"}, validSplunkObservabilityAuthParams, true},
+}
+
+var splunkObservabilityMetricIdentifiers = []splunkObservabilityMetricIdentifier{
+	{&testSplunkObservabilityMetadata[0], 0, "s0-splunk-observability"},
+	{&testSplunkObservabilityMetadata[1], 1, "s1-splunk-observability"},
+}
+
+func TestSplunkObservabilityParseMetadata(t *testing.T) {
+	for _, testData := range testSplunkObservabilityMetadata {
+		_, err := parseSplunkObservabilityMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestSplunkObservabilityGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range splunkObservabilityMetricIdentifiers {
+		meta, err := parseSplunkObservabilityMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockSplunkObservabilityScaler := splunkObservabilityScaler{metadata: meta}
+
+		metricSpec := mockSplunkObservabilityScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+const splunkObservabilityTestStream = `event: control-message
+data: {"event": "STREAM_START", "channel": "R0", "timestampMs": 1700000000000}
+
+event: metadata
+data: {"tsId": "AAAAAA", "properties": {"host": "a"}}
+
+event: data
+data: {"data": [{"tsId": "AAAAAA", "value": 30}, {"tsId": "AAAAAB", "value": 10}],
+data:  "logicalTimestampMs": 1700000000000}
+
+event: data
+data: {"data": [{"tsId": "AAAAAA", "value": 40}], "logicalTimestampMs": 1700000060000}
+
+event: control-message
+data: {"event": "END_OF_CHANNEL", "channel": "R0", "timestampMs": 1700000060000}
+
+`
+
+func TestSplunkObservabilityGetMetricsAndActivity(t *testing.T) {
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		stream         string
+		expectedValue  float64
+		expectedActive bool
+		expectedError  string
+	}{
+		{"maximum of the latest values", map[string]string{"queryAggregator": "max"}, splunkObservabilityTestStream, 40, true, ""},
+		{"sum of the latest values", map[string]string{"queryAggregator": "sum"}, splunkObservabilityTestStream, 50, true, ""},
+		{"average of the latest values", map[string]string{"queryAggregator": "avg"}, splunkObservabilityTestStream, 25, true, ""},
+		{"minimum of the latest values", map[string]string{"queryAggregator": "min"}, splunkObservabilityTestStream, 10, false, ""},
+		{"several series without queryAggregator", map[string]string{}, splunkObservabilityTestStream, 0, false, "signalflow program output 2 series, queryAggregator must be provided"},
+		{"no data", map[string]string{}, "event: control-message\ndata: {\"event\": \"END_OF_CHANNEL\"}\n\n", 0, false, "signalflow program did not output any data point"},
+		{"aborted computation", map[string]string{}, "event: control-message\ndata: {\"event\": \"CHANNEL_ABORT\", \"abortInfo\": {\"sf_job_abortState\": \"FAILED\", \"sf_job_abortReason\": \"too many series\"}}\n\n", 0, false, "signalflow computation aborted with FAILED: too many series"},
+		{"stream ended early", map[string]string{}, "event: control-message\ndata: {\"event\": \"STREAM_START\"}\n\n", 0, false, "signalflow stream ended before the end of the computation"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/"+splunkObservabilityExecuteAPI, r.URL.Path)
+				assert.Equal(t, "fake", r.Header.Get("X-SF-Token"))
+				assert.Equal(t, "true", r.URL.Query().Get("immediate"))
+				assert.NotEmpty(t, r.URL.Query().Get("start"))
+				program, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, "data('cpu.utilization').publish()", string(program))
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = fmt.Fprint(w, tc.stream)
+			}))
+			defer server.Close()
+
+			tc.metadata["realm"] = "us1"
+			tc.metadata["query"] = "data('cpu.utilization').publish()"
+			tc.metadata["targetValue"] = "50"
+			tc.metadata["activationTargetValue"] = "20"
+			meta, err := parseSplunkObservabilityMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: validSplunkObservabilityAuthParams})
+			assert.NoError(t, err)
+			s := &splunkObservabilityScaler{metadata: meta, httpClient: server.Client(), streamURL: server.URL, logger: logr.Discard()}
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.expectedActive, isActive)
+		})
+	}
+}
+
+func TestSplunkObservabilityAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code": 400, "message": "invalid program"}`))
+	}))
+	defer server.Close()
+
+	meta, err := parseSplunkObservabilityMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testSplunkObservabilityMetadata[0].metadata, AuthParams: validSplunkObservabilityAuthParams})
+	assert.NoError(t, err)
+	s := &splunkObservabilityScaler{metadata: meta, httpClient: server.Client(), streamURL: server.URL, logger: logr.Discard()}
+
+	_, err = s.GetMetricValue(context.Background())
+	assert.EqualError(t, err, `signalflow api returned 400: {"code": 400, "message": "invalid program"}`)
+}
@@ -0,0 +1,372 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protowire"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	temporalScaleOnBacklog                = "backlog"
+	temporalScaleOnScheduleToStartLatency = "scheduleToStartLatency"
+
+	// temporalDescribeTaskQueueMethod is the method of the WorkflowService of the Temporal frontend describing a task queue
+	temporalDescribeTaskQueueMethod = "/temporal.api.workflowservice.v1.WorkflowService/DescribeTaskQueue"
+)
+
+// temporalTaskQueueTypes are the values of the TaskQueueType enum of the Temporal API by queue type
+var temporalTaskQueueTypes = map[string]uint64{
+	"workflow": 1,
+	"activity": 2,
+	"nexus":    3,
+}
+
+type temporalScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *temporalMetadata
+	connection *grpc.ClientConn
+	logger     logr.Logger
+}
+
+type temporalMetadata struct {
+	triggerIndex int
+
+	Endpoint    string   `keda:"name=endpoint,          order=triggerMetadata;resolvedEnv"`
+	Namespace   string   `keda:"name=namespace,         order=triggerMetadata;resolvedEnv, default=default"`
+	TaskQueue   string   `keda:"name=taskQueue,         order=triggerMetadata;resolvedEnv"`
+	QueueTypes  []string `keda:"name=queueTypes,        order=triggerMetadata, enum=workflow;activity;nexus, optional"`
+	BuildIDs    []string `keda:"name=buildIds,          order=triggerMetadata, optional"`
+	AllActive   bool     `keda:"name=selectAllActive,   order=triggerMetadata, default=false"`
+	Unversioned bool     `keda:"name=selectUnversioned, order=triggerMetadata, default=true"`
+
+	ScaleOn                                string  `keda:"name=scaleOn,                                order=triggerMetadata, enum=backlog;scheduleToStartLatency, default=backlog"`
+	TargetQueueSize                        int64   `keda:"name=targetQueueSize,                        order=triggerMetadata, default=5"`
+	ActivationTargetQueueSize              int64   `keda:"name=activationTargetQueueSize,              order=triggerMetadata, default=0"`
+	TargetScheduleToStartLatency           float64 `keda:"name=targetScheduleToStartLatency,           order=triggerMetadata, optional"`
+	ActivationTargetScheduleToStartLatency float64 `keda:"name=activationTargetScheduleToStartLatency, order=triggerMetadata, default=0"`
+
+	// Authentication
+	APIKey        string `keda:"name=apiKey,        order=authParams;resolvedEnv, optional"`
+	Cert          string `keda:"name=cert,          order=authParams, optional"`
+	Key           string `keda:"name=key,           order=authParams, optional"`
+	KeyPassword   string `keda:"name=keyPassword,   order=authParams, optional"`
+	CA            string `keda:"name=ca,            order=authParams, optional"`
+	TLSServerName string `keda:"name=tlsServerName, order=triggerMetadata;authParams, optional"`
+	UnsafeSsl     bool   `keda:"name=unsafeSsl,     order=triggerMetadata, default=false"`
+}
+
+func (m *temporalMetadata) Validate() error {
+	if len(m.QueueTypes) == 0 {
+		m.QueueTypes = []string{"workflow", "activity"}
+	}
+	if (m.Cert == "") != (m.Key == "") {
+		return errors.New("both cert and key must be provided")
+	}
+	if m.TargetQueueSize <= 0 {
+		return errors.New("targetQueueSize must be greater than 0")
+	}
+	if m.ScaleOn == temporalScaleOnScheduleToStartLatency && m.TargetScheduleToStartLatency <= 0 {
+		return errors.New("targetScheduleToStartLatency must be greater than 0 when scaling on the schedule-to-start latency")
+	}
+	return nil
+}
+
+// temporalTaskQueueStats are the statistics of the task queue summed over its versions and types
+type temporalTaskQueueStats struct {
+	backlogCount int64
+	// backlogAge is the age of the oldest task of the backlog, the schedule-to-start latency of the next task dispatched
+	backlogAge time.Duration
+}
+
+// NewTemporalScaler creates a new temporal scaler
+func NewTemporalScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseTemporalMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing temporal metadata: %w", err)
+	}
+
+	connection, err := newTemporalConnection(meta)
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporal connection: %w", err)
+	}
+
+	return &temporalScaler{
+		metricType: metricType,
+		metadata:   meta,
+		connection: connection,
+		logger:     InitializeLogger(config, "temporal_scaler"),
+	}, nil
+}
+
+func parseTemporalMetadata(config *scalersconfig.ScalerConfig) (*temporalMetadata, error) {
+	meta := &temporalMetadata{triggerIndex: config.TriggerIndex}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// newTemporalConnection returns the connection to the Temporal frontend, it's secured with TLS when the client
+// certificate, the CA or the API key are provided
+func newTemporalConnection(meta *temporalMetadata) (*grpc.ClientConn, error) {
+	if meta.Cert == "" && meta.CA == "" && meta.APIKey == "" && !meta.UnsafeSsl {
+		// nosemgrep: go.grpc.ssrf.grpc-tainted-url-host.grpc-tainted-url-host
+		return grpc.NewClient(meta.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	tlsConfig, err := kedautil.NewTLSConfigWithPassword(meta.Cert, meta.Key, meta.KeyPassword, meta.CA, meta.UnsafeSsl)
+	if err != nil {
+		return nil, err
+	}
+	if meta.TLSServerName != "" {
+		tlsConfig.ServerName = meta.TLSServerName
+	}
+	// nosemgrep: go.grpc.ssrf.grpc-tainted-url-host.grpc-tainted-url-host
+	return grpc.NewClient(meta.Endpoint, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+}
+
+// Close closes the connection to the Temporal frontend
+func (s *temporalScaler) Close(context.Context) error {
+	if s.connection != nil {
+		return s.connection.Close()
+	}
+	return nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *temporalScaler) GetMetricSpecForScaling(context.Context) []v2.MetricSpec {
+	target := GetMetricTarget(s.metricType, s.metadata.TargetQueueSize)
+	if s.metadata.ScaleOn == temporalScaleOnScheduleToStartLatency {
+		target = GetMetricTargetMili(s.metricType, s.metadata.TargetScheduleToStartLatency)
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("temporal-%s-%s", s.metadata.Namespace, s.metadata.TaskQueue))),
+		},
+		Target: target,
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+// GetMetricsAndActivity returns the backlog of the task queue or its schedule-to-start latency in seconds
+func (s *temporalScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	stats, err := s.getTaskQueueStats(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, false, fmt.Errorf("error describing temporal task queue %s: %w", s.metadata.TaskQueue, err)
+	}
+
+	if s.metadata.ScaleOn == temporalScaleOnScheduleToStartLatency {
+		latency := stats.backlogAge.Seconds()
+		return []external_metrics.ExternalMetricValue{GenerateMetricInMili(metricName, latency)}, latency > s.metadata.ActivationTargetScheduleToStartLatency, nil
+	}
+	metric := GenerateMetricInMili(metricName, float64(stats.backlogCount))
+	return []external_metrics.ExternalMetricValue{metric}, stats.backlogCount > s.metadata.ActivationTargetQueueSize, nil
+}
+
+// getTaskQueueStats describes the task queue in the enhanced mode of the Temporal API, which reports the statistics
+// of its versions by type. The messages of the API are encoded on the wire, the scaler only needs a few fields of them.
+func (s *temporalScaler) getTaskQueueStats(ctx context.Context) (*temporalTaskQueueStats, error) {
+	if s.metadata.APIKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+s.metadata.APIKey, "temporal-namespace", s.metadata.Namespace)
+	}
+
+	request := s.describeTaskQueueRequest()
+	var response []byte
+	if err := s.connection.Invoke(ctx, temporalDescribeTaskQueueMethod, &request, &response, grpc.ForceCodec(temporalWireCodec{})); err != nil {
+		return nil, err
+	}
+	return parseTemporalDescribeTaskQueueResponse(response)
+}
+
+// describeTaskQueueRequest returns the DescribeTaskQueueRequest of the task queue reporting its statistics
+func (s *temporalScaler) describeTaskQueueRequest() []byte {
+	var taskQueue []byte
+	taskQueue = appendProtoString(taskQueue, 1, s.metadata.TaskQueue)
+	// TASK_QUEUE_KIND_NORMAL
+	taskQueue = appendProtoVarint(taskQueue, 2, 1)
+
+	var versions []byte
+	for _, buildID := range s.metadata.BuildIDs {
+		versions = appendProtoString(versions, 1, buildID)
+	}
+	versions = appendProtoBool(versions, 2, s.metadata.Unversioned)
+	versions = appendProtoBool(versions, 3, s.metadata.AllActive)
+
+	var taskQueueTypes []byte
+	for _, queueType := range s.metadata.QueueTypes {
+		taskQueueTypes = protowire.AppendVarint(taskQueueTypes, temporalTaskQueueTypes[queueType])
+	}
+
+	var request []byte
+	request = appendProtoString(request, 1, s.metadata.Namespace)
+	request = appendProtoBytes(request, 2, taskQueue)
+	// DESCRIBE_TASK_QUEUE_MODE_ENHANCED
+	request = appendProtoVarint(request, 5, 1)
+	request = appendProtoBytes(request, 6, versions)
+	request = appendProtoBytes(request, 7, taskQueueTypes)
+	request = appendProtoBool(request, 8, true)
+	return request
+}
+
+// parseTemporalDescribeTaskQueueResponse sums the statistics of the versions_info of the DescribeTaskQueueResponse,
+// the map of the TaskQueueVersionInfo by build id whose types_info are the map of the TaskQueueTypeInfo by type
+func parseTemporalDescribeTaskQueueResponse(response []byte) (*temporalTaskQueueStats, error) {
+	stats := &temporalTaskQueueStats{}
+	err := rangeProtoMessages(response, 3, func(versionsInfoEntry []byte) error {
+		return rangeProtoMessages(versionsInfoEntry, 2, func(versionInfo []byte) error {
+			return rangeProtoMessages(versionInfo, 1, func(typesInfoEntry []byte) error {
+				return rangeProtoMessages(typesInfoEntry, 2, func(typeInfo []byte) error {
+					return rangeProtoMessages(typeInfo, 2, stats.add)
+				})
+			})
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing DescribeTaskQueueResponse: %w", err)
+	}
+	return stats, nil
+}
+
+// add adds the TaskQueueStats, the approximate_backlog_count and the approximate_backlog_age are its first fields
+func (s *temporalTaskQueueStats) add(taskQueueStats []byte) error {
+	return rangeProtoFields(taskQueueStats, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			backlogCount, _ := protowire.ConsumeVarint(value)
+			s.backlogCount += int64(backlogCount)
+		case num == 2 && typ == protowire.BytesType:
+			backlogAge, err := parseProtoDuration(value)
+			if err != nil {
+				return err
+			}
+			s.backlogAge = max(s.backlogAge, backlogAge)
+		}
+		return nil
+	})
+}
+
+// temporalWireCodec sends and receives the messages of the Temporal API already encoded by the scaler
+type temporalWireCodec struct{}
+
+func (temporalWireCodec) Marshal(v any) ([]byte, error) {
+	message, ok := v.(*[]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return *message, nil
+}
+
+func (temporalWireCodec) Unmarshal(data []byte, v any) error {
+	message, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	*message = append([]byte{}, data...)
+	return nil
+}
+
+// Name returns the name of the protobuf codec, the messages are sent as protobuf
+func (temporalWireCodec) Name() string {
+	return "proto"
+}
+
+func appendProtoString(b []byte, num protowire.Number, value string) []byte {
+	if value == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, value)
+}
+
+func appendProtoBytes(b []byte, num protowire.Number, value []byte) []byte {
+	if len(value) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, value)
+}
+
+func appendProtoVarint(b []byte, num protowire.Number, value uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, value)
+}
+
+func appendProtoBool(b []byte, num protowire.Number, value bool) []byte {
+	if !value {
+		return b
+	}
+	return appendProtoVarint(b, num, 1)
+}
+
+// rangeProtoFields calls f with the number, the type and the encoded value of each field of the message
+func rangeProtoFields(message []byte, f func(num protowire.Number, typ protowire.Type, value []byte) error) error {
+	for len(message) > 0 {
+		num, typ, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		message = message[n:]
+
+		n = protowire.ConsumeFieldValue(num, typ, message)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		value := message[:n]
+		if typ == protowire.BytesType {
+			value, _ = protowire.ConsumeBytes(value)
+		}
+		if err := f(num, typ, value); err != nil {
+			return err
+		}
+		message = message[n:]
+	}
+	return nil
+}
+
+// rangeProtoMessages calls f with each message of the field of the message
+func rangeProtoMessages(message []byte, field protowire.Number, f func(message []byte) error) error {
+	return rangeProtoFields(message, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if num != field || typ != protowire.BytesType {
+			return nil
+		}
+		return f(value)
+	})
+}
+
+// parseProtoDuration parses a google.protobuf.Duration
+func parseProtoDuration(message []byte) (time.Duration, error) {
+	var seconds, nanos int64
+	err := rangeProtoFields(message, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if typ != protowire.VarintType {
+			return nil
+		}
+		v, _ := protowire.ConsumeVarint(value)
+		switch num {
+		case 1:
+			seconds = int64(v)
+		case 2:
+			nanos = int64(int32(v))
+		}
+		return nil
+	})
+	return time.Duration(seconds)*time.Second + time.Duration(nanos), err
+}
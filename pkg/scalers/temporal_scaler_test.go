@@ -0,0 +1,196 @@
+package scalers
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseTemporalMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type temporalMetricIdentifier struct {
+	metadataTestData *parseTemporalMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testTemporalMetadata = []parseTemporalMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed metadata
+	{map[string]string{"endpoint": "temporal-frontend:7233", "namespace": "orders", "taskQueue": "checkout"}, map[string]string{}, false},
+	// default namespace
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "checkout", "queueTypes": "activity"}, map[string]string{}, false},
+	// no endpoint
+	{map[string]string{"taskQueue": "checkout"}, map[string]string{}, true},
+	// no taskQueue
+	{map[string]string{"endpoint": "temporal-frontend:7233"}, map[string]string{}, true},
+	// invalid queueTypes
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "checkout", "queueTypes": "workflow,timer"}, map[string]string{}, true},
+	// invalid targetQueueSize
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "checkout", "targetQueueSize": "0"}, map[string]string{}, true},
+	// scheduleToStartLatency without target
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "checkout", "scaleOn": "scheduleToStartLatency"}, map[string]string{}, true},
+	// scheduleToStartLatency
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "checkout", "scaleOn": "scheduleToStartLatency", "targetScheduleToStartLatency": "2.5"}, map[string]string{}, false},
+	// invalid scaleOn
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "checkout", "scaleOn": "pollers"}, map[string]string{}, true},
+	// mTLS
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "checkout"}, map[string]string{"cert": "ceert", "key": "keey", "ca": "caaa"}, false},
+	// cert without key
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "checkout"}, map[string]string{"cert": "ceert"}, true},
+	// API key
+	{map[string]string{"endpoint": "orders.tmprl.cloud:7233", "taskQueue": "checkout"}, map[string]string{"apiKey": "secret"}, false},
+}
+
+var temporalMetricIdentifiers = []temporalMetricIdentifier{
+	{&testTemporalMetadata[1], 0, "s0-temporal-orders-checkout"},
+	{&testTemporalMetadata[2], 1, "s1-temporal-default-checkout"},
+}
+
+func TestTemporalParseMetadata(t *testing.T) {
+	for testCaseNum, testData := range testTemporalMetadata {
+		_, err := parseTemporalMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", testCaseNum, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", testCaseNum)
+		}
+	}
+
+	meta, err := parseTemporalMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testTemporalMetadata[1].metadata})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"workflow", "activity"}, meta.QueueTypes)
+	assert.True(t, meta.Unversioned)
+	assert.Equal(t, int64(5), meta.TargetQueueSize)
+}
+
+func TestTemporalGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range temporalMetricIdentifiers {
+		meta, err := parseTemporalMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockTemporalScaler := temporalScaler{metadata: meta}
+
+		metricSpec := mockTemporalScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Errorf("Wrong External metric source name: %s, expected: %s", metricName, testData.name)
+		}
+	}
+}
+
+// temporalTaskQueueTypeInfo returns a map entry of the types_info of a TaskQueueVersionInfo
+func temporalTaskQueueTypeInfo(queueType uint64, backlogCount uint64, backlogAge time.Duration) []byte {
+	var duration []byte
+	duration = appendProtoVarint(duration, 1, uint64(backlogAge/time.Second))
+	duration = appendProtoVarint(duration, 2, uint64(backlogAge%time.Second))
+	var stats []byte
+	stats = appendProtoVarint(stats, 1, backlogCount)
+	stats = appendProtoBytes(stats, 2, duration)
+	var typeInfo []byte
+	typeInfo = appendProtoBytes(typeInfo, 2, stats)
+	var entry []byte
+	entry = appendProtoVarint(entry, 1, queueType)
+	return appendProtoBytes(entry, 2, typeInfo)
+}
+
+func TestTemporalGetMetricsAndActivity(t *testing.T) {
+	var versionInfo []byte
+	versionInfo = appendProtoBytes(versionInfo, 1, temporalTaskQueueTypeInfo(1, 3, 1500*time.Millisecond))
+	versionInfo = appendProtoBytes(versionInfo, 1, temporalTaskQueueTypeInfo(2, 7, 4*time.Second))
+	var versionsInfoEntry []byte
+	versionsInfoEntry = appendProtoString(versionsInfoEntry, 1, "")
+	versionsInfoEntry = appendProtoBytes(versionsInfoEntry, 2, versionInfo)
+	var response []byte
+	response = appendProtoBytes(response, 3, versionsInfoEntry)
+
+	var method string
+	var request []byte
+	var md metadata.MD
+	server := grpc.NewServer(grpc.ForceServerCodec(temporalWireCodec{}), grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+		method, _ = grpc.MethodFromServerStream(stream)
+		md, _ = metadata.FromIncomingContext(stream.Context())
+		if err := stream.RecvMsg(&request); err != nil {
+			return err
+		}
+		return stream.SendMsg(&response)
+	}))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	meta, err := parseTemporalMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{
+		"endpoint": listener.Addr().String(), "namespace": "orders", "taskQueue": "checkout", "activationTargetQueueSize": "10",
+	}})
+	assert.NoError(t, err)
+	connection, err := newTemporalConnection(meta)
+	assert.NoError(t, err)
+	s := &temporalScaler{metadata: meta, connection: connection}
+	defer s.Close(context.Background())
+
+	// the backlog is summed over the types of the task queue
+	metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "s0-temporal-orders-checkout")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), metrics[0].Value.Value())
+	assert.False(t, isActive)
+	assert.Equal(t, temporalDescribeTaskQueueMethod, method)
+	assert.Equal(t, s.describeTaskQueueRequest(), request)
+	assert.Empty(t, md.Get("authorization"))
+
+	// the schedule-to-start latency is the age of the oldest backlog
+	s.metadata.ScaleOn = temporalScaleOnScheduleToStartLatency
+	s.metadata.APIKey = "secret"
+	metrics, isActive, err = s.GetMetricsAndActivity(context.Background(), "s0-temporal-orders-checkout")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4000), metrics[0].Value.MilliValue())
+	assert.True(t, isActive)
+	assert.Equal(t, []string{"Bearer secret"}, md.Get("authorization"))
+}
+
+func TestTemporalDescribeTaskQueueRequest(t *testing.T) {
+	s := &temporalScaler{metadata: &temporalMetadata{
+		Namespace:  "orders",
+		TaskQueue:  "checkout",
+		QueueTypes: []string{"activity"},
+		BuildIDs:   []string{"v1"},
+		AllActive:  true,
+	}}
+
+	fields := map[protowire.Number][]byte{}
+	assert.NoError(t, rangeProtoFields(s.describeTaskQueueRequest(), func(num protowire.Number, _ protowire.Type, value []byte) error {
+		fields[num] = value
+		return nil
+	}))
+	assert.Equal(t, "orders", string(fields[1]))
+	assert.Equal(t, protowire.AppendVarint(nil, 1), fields[5])
+	// the activity task queue type is packed
+	assert.Equal(t, []byte{2}, fields[7])
+	assert.Equal(t, protowire.AppendVarint(nil, 1), fields[8])
+
+	versions := map[protowire.Number][]byte{}
+	assert.NoError(t, rangeProtoFields(fields[6], func(num protowire.Number, _ protowire.Type, value []byte) error {
+		versions[num] = value
+		return nil
+	}))
+	assert.Equal(t, "v1", string(versions[1]))
+	assert.NotContains(t, versions, protowire.Number(2))
+	assert.Contains(t, versions, protowire.Number(3))
+}
@@ -0,0 +1,115 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+	"github.com/kedacore/keda/v2/pkg/webhookreceiver"
+)
+
+type webhookPushScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *webhookPushMetadata
+	namespace  string
+	value      func(namespace, metric string) (float64, bool)
+	logger     logr.Logger
+}
+
+type webhookPushMetadata struct {
+	Metric string `keda:"name=metric, order=triggerMetadata"`
+
+	// IgnoreNullValues reports 0 while no value is pushed, e.g. until the system pushes the metric
+	// or once its last value has expired, instead of failing so the fallback applies
+	IgnoreNullValues bool `keda:"name=ignoreNullValues, order=triggerMetadata, default=false"`
+
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	triggerIndex int
+}
+
+func (m *webhookPushMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return fmt.Errorf("targetValue must be greater than 0")
+	}
+	return nil
+}
+
+// NewWebhookPushScaler creates a new scaler for the last value of a metric pushed over HTTP to the
+// webhook receiver of the operator, in the namespace of the ScaledObject or ScaledJob
+func NewWebhookPushScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseWebhookPushMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing webhook push metadata: %w", err)
+	}
+
+	if !webhookreceiver.Enabled() {
+		return nil, fmt.Errorf("the webhook receiver isn't enabled, set --webhook-receiver-bind-address on the operator")
+	}
+
+	return &webhookPushScaler{
+		metricType: metricType,
+		metadata:   meta,
+		namespace:  config.ScalableObjectNamespace,
+		value:      webhookreceiver.Value,
+		logger:     InitializeLogger(config, "webhook_push_scaler"),
+	}, nil
+}
+
+func parseWebhookPushMetadata(config *scalersconfig.ScalerConfig) (*webhookPushMetadata, error) {
+	meta := &webhookPushMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing webhook push metadata: %w", err)
+	}
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// GetMetricValue returns the last value pushed of the metric
+func (s *webhookPushScaler) GetMetricValue() (float64, error) {
+	value, ok := s.value(s.namespace, s.metadata.Metric)
+	if !ok {
+		if s.metadata.IgnoreNullValues {
+			return 0, nil
+		}
+		return -1, fmt.Errorf("no value of the metric %s has been pushed to %s%s/%s", s.metadata.Metric, webhookreceiver.Path, s.namespace, s.metadata.Metric)
+	}
+	return value, nil
+}
+
+func (s *webhookPushScaler) GetMetricsAndActivity(_ context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.GetMetricValue()
+	if err != nil {
+		s.logger.Error(err, "error getting webhook push metric value")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *webhookPushScaler) GetMetricSpecForScaling(_ context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("webhook-push-%s", s.metadata.Metric))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *webhookPushScaler) Close(_ context.Context) error {
+	return nil
+}
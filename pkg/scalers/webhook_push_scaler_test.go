@@ -0,0 +1,112 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseWebhookPushMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type webhookPushMetricIdentifier struct {
+	metadataTestData *parseWebhookPushMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testWebhookPushMetadata = []parseWebhookPushMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true},
+	// properly formed metadata
+	{map[string]string{"metric": "pending_orders", "targetValue": "10"}, false},
+	// activation and ignoreNullValues
+	{map[string]string{"metric": "pending_orders", "targetValue": "2.5", "activationTargetValue": "1", "ignoreNullValues": "true"}, false},
+	// missing targetValue
+	{map[string]string{"metric": "pending_orders"}, true},
+	// invalid targetValue
+	{map[string]string{"metric": "pending_orders", "targetValue": "0"}, true},
+	// invalid ignoreNullValues
+	{map[string]string{"metric": "pending_orders", "targetValue": "10", "ignoreNullValues": "maybe"}, true},
+}
+
+var webhookPushMetricIdentifiers = []webhookPushMetricIdentifier{
+	{&testWebhookPushMetadata[1], 0, "s0-webhook-push-pending_orders"},
+	{&testWebhookPushMetadata[2], 1, "s1-webhook-push-pending_orders"},
+}
+
+func TestWebhookPushParseMetadata(t *testing.T) {
+	for testCaseNum, testData := range testWebhookPushMetadata {
+		_, err := parseWebhookPushMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", testCaseNum, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", testCaseNum)
+		}
+	}
+}
+
+func TestWebhookPushGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range webhookPushMetricIdentifiers {
+		meta, err := parseWebhookPushMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockWebhookPushScaler := webhookPushScaler{metadata: meta}
+
+		metricSpec := mockWebhookPushScaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func TestNewWebhookPushScalerWithoutReceiver(t *testing.T) {
+	_, err := NewWebhookPushScaler(&scalersconfig.ScalerConfig{TriggerMetadata: map[string]string{"metric": "pending_orders", "targetValue": "10"}})
+	assert.ErrorContains(t, err, "the webhook receiver isn't enabled")
+}
+
+func TestWebhookPushGetMetricsAndActivity(t *testing.T) {
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		pushed         map[string]float64
+		expectedValue  float64
+		expectedActive bool
+		expectedError  string
+	}{
+		{"pushed value", map[string]string{}, map[string]float64{"pending_orders": 12}, 12, true, ""},
+		{"pushed value under activation", map[string]string{}, map[string]float64{"pending_orders": 4}, 4, false, ""},
+		{"no value", map[string]string{}, map[string]float64{"other": 12}, 0, false, "no value of the metric pending_orders has been pushed to /metrics/shop/pending_orders"},
+		{"no value with ignoreNullValues", map[string]string{"ignoreNullValues": "true"}, map[string]float64{}, 0, false, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["metric"] = "pending_orders"
+			tc.metadata["targetValue"] = "10"
+			tc.metadata["activationTargetValue"] = "5"
+			meta, err := parseWebhookPushMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: tc.metadata})
+			assert.NoError(t, err)
+			s := &webhookPushScaler{metadata: meta, namespace: "shop", logger: logr.Discard(), value: func(namespace, metric string) (float64, bool) {
+				assert.Equal(t, "shop", namespace)
+				value, ok := tc.pushed[metric]
+				return value, ok
+			}}
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.expectedActive, isActive)
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package scalers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	orchestratorAirflow = "airflow"
+	orchestratorDagster = "dagster"
+	orchestratorPrefect = "prefect"
+
+	// dagsterQueuedRunsQuery counts the runs waiting in the run queue of Dagster
+	dagsterQueuedRunsQuery = `query QueuedRuns($filter: RunsFilter) {
+  runsOrError(filter: $filter) {
+    __typename
+    ... on Runs { count }
+    ... on InvalidPipelineRunsFilterError { message }
+    ... on PythonError { message }
+  }
+}`
+)
+
+type workflowOrchestratorScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *workflowOrchestratorMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type workflowOrchestratorMetadata struct {
+	Auth *authentication.Config `keda:"optional"`
+
+	Orchestrator string `keda:"name=orchestrator, order=triggerMetadata, enum=airflow;dagster;prefect"`
+	// URL is the Airflow or Dagster webserver, or the Prefect API, e.g. http://prefect-server:4200/api
+	URL string `keda:"name=url, order=triggerMetadata;resolvedEnv"`
+	// Pool is the Airflow pool or the Prefect work pool of the queued runs
+	Pool string `keda:"name=pool, order=triggerMetadata, optional"`
+	// Queue is the Airflow executor queue or the Prefect work queue of the queued runs
+	Queue string `keda:"name=queue, order=triggerMetadata, optional"`
+	// JobName is the Dagster job of the queued runs
+	JobName   string `keda:"name=jobName,   order=triggerMetadata, optional"`
+	UnsafeSsl bool   `keda:"name=unsafeSsl, order=triggerMetadata, default=false"`
+
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	triggerIndex int
+}
+
+func (m *workflowOrchestratorMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return fmt.Errorf("targetValue must be greater than 0")
+	}
+	if !m.Auth.Disabled() && m.Auth.EnabledOAuth() {
+		return fmt.Errorf("authMode oauth isn't supported")
+	}
+	switch m.Orchestrator {
+	case orchestratorAirflow, orchestratorPrefect:
+		if m.JobName != "" {
+			return fmt.Errorf("jobName isn't supported by %s", m.Orchestrator)
+		}
+	case orchestratorDagster:
+		if m.Pool != "" || m.Queue != "" {
+			return fmt.Errorf("pool and queue aren't supported by dagster, use jobName")
+		}
+	}
+	return nil
+}
+
+// NewWorkflowOrchestratorScaler creates a new scaler for the queued task runs of Airflow, the queued
+// runs of Dagster or the flow runs waiting in a Prefect work queue
+func NewWorkflowOrchestratorScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseWorkflowOrchestratorMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing workflow orchestrator metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	if !meta.Auth.Disabled() && (meta.Auth.CA != "" || meta.Auth.EnabledTLS()) {
+		transport, err := authentication.CreateHTTPRoundTripper(authentication.NetHTTP, meta.Auth.ToAuthMeta())
+		if err != nil {
+			return nil, fmt.Errorf("error creating the workflow orchestrator http transport: %w", err)
+		}
+		httpClient.Transport = transport
+	}
+
+	return &workflowOrchestratorScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "workflow_orchestrator_scaler"),
+	}, nil
+}
+
+func parseWorkflowOrchestratorMetadata(config *scalersconfig.ScalerConfig) (*workflowOrchestratorMetadata, error) {
+	meta := &workflowOrchestratorMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing workflow orchestrator metadata: %w", err)
+	}
+	meta.URL = strings.TrimSuffix(meta.URL, "/")
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// do sends the request to the orchestrator and decodes its json response into v
+func (s *workflowOrchestratorScaler) do(ctx context.Context, method string, url string, body any, v any) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	switch {
+	case s.metadata.Auth.Disabled():
+	case s.metadata.Auth.EnabledBearerAuth():
+		req.Header.Set("Authorization", s.metadata.Auth.GetBearerToken())
+	case s.metadata.Auth.EnabledBasicAuth():
+		req.SetBasicAuth(s.metadata.Auth.Username, s.metadata.Auth.Password)
+	case s.metadata.Auth.EnabledCustomAuth():
+		req.Header.Set(s.metadata.Auth.CustomAuthHeader, s.metadata.Auth.CustomAuthValue)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s api returned %d", resp.Request.URL.Path, s.metadata.Orchestrator, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("error decoding the %s response: %w", s.metadata.Orchestrator, err)
+	}
+	return nil
+}
+
+// getAirflowQueuedTaskInstances returns the queued task instances of all the dag runs, through the
+// stable REST API, e.g. to scale the workers of a celery queue
+func (s *workflowOrchestratorScaler) getAirflowQueuedTaskInstances(ctx context.Context) (float64, error) {
+	query := neturl.Values{}
+	query.Set("state", "queued")
+	query.Set("limit", "1")
+	if s.metadata.Pool != "" {
+		query.Set("pool", s.metadata.Pool)
+	}
+	if s.metadata.Queue != "" {
+		query.Set("queue", s.metadata.Queue)
+	}
+	url := fmt.Sprintf("%s/api/v1/dags/~/dagRuns/~/taskInstances?%s", s.metadata.URL, query.Encode())
+
+	var response struct {
+		TotalEntries float64 `json:"total_entries"`
+	}
+	if err := s.do(ctx, http.MethodGet, url, nil, &response); err != nil {
+		return -1, err
+	}
+	return response.TotalEntries, nil
+}
+
+// getDagsterQueuedRuns returns the runs waiting in the run queue, through the GraphQL API
+func (s *workflowOrchestratorScaler) getDagsterQueuedRuns(ctx context.Context) (float64, error) {
+	filter := map[string]any{"statuses": []string{"QUEUED"}}
+	if s.metadata.JobName != "" {
+		filter["pipelineName"] = s.metadata.JobName
+	}
+	request := map[string]any{
+		"query":     dagsterQueuedRunsQuery,
+		"variables": map[string]any{"filter": filter},
+	}
+
+	var response struct {
+		Data struct {
+			RunsOrError struct {
+				Typename string   `json:"__typename"`
+				Count    *float64 `json:"count"`
+				Message  string   `json:"message"`
+			} `json:"runsOrError"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := s.do(ctx, http.MethodPost, s.metadata.URL+"/graphql", request, &response); err != nil {
+		return -1, err
+	}
+	if len(response.Errors) > 0 {
+		return -1, fmt.Errorf("dagster graphql error: %s", response.Errors[0].Message)
+	}
+	runs := response.Data.RunsOrError
+	if runs.Typename != "Runs" {
+		return -1, fmt.Errorf("dagster returned %s: %s", runs.Typename, runs.Message)
+	}
+	if runs.Count == nil {
+		return -1, fmt.Errorf("dagster didn't return the count of the runs")
+	}
+	return *runs.Count, nil
+}
+
+// getPrefectWorkQueueDepth returns the flow runs which are due or pending in the work pool and the work
+// queue, the runs scheduled in the future aren't counted
+func (s *workflowOrchestratorScaler) getPrefectWorkQueueDepth(ctx context.Context) (float64, error) {
+	filter := map[string]any{
+		"flow_runs": map[string]any{
+			"state":               map[string]any{"type": map[string]any{"any_": []string{"SCHEDULED", "PENDING"}}},
+			"expected_start_time": map[string]any{"before_": time.Now().UTC().Format(time.RFC3339)},
+		},
+	}
+	if s.metadata.Pool != "" {
+		filter["work_pools"] = map[string]any{"name": map[string]any{"any_": []string{s.metadata.Pool}}}
+	}
+	if s.metadata.Queue != "" {
+		filter["work_pool_queues"] = map[string]any{"name": map[string]any{"any_": []string{s.metadata.Queue}}}
+	}
+
+	var count float64
+	if err := s.do(ctx, http.MethodPost, s.metadata.URL+"/flow_runs/count", filter, &count); err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+// GetMetricValue returns the queued runs of the orchestrator
+func (s *workflowOrchestratorScaler) GetMetricValue(ctx context.Context) (float64, error) {
+	switch s.metadata.Orchestrator {
+	case orchestratorAirflow:
+		return s.getAirflowQueuedTaskInstances(ctx)
+	case orchestratorDagster:
+		return s.getDagsterQueuedRuns(ctx)
+	case orchestratorPrefect:
+		return s.getPrefectWorkQueueDepth(ctx)
+	}
+	return -1, fmt.Errorf("unknown orchestrator %s", s.metadata.Orchestrator)
+}
+
+func (s *workflowOrchestratorScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.GetMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting workflow orchestrator metric value")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *workflowOrchestratorScaler) GetMetricSpecForScaling(_ context.Context) []v2.MetricSpec {
+	name := s.metadata.Orchestrator
+	for _, n := range []string{s.metadata.Pool, s.metadata.Queue, s.metadata.JobName} {
+		if n != "" {
+			name += "-" + n
+		}
+	}
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("workflow-orchestrator-%s", name))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *workflowOrchestratorScaler) Close(_ context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseWorkflowOrchestratorMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type workflowOrchestratorMetricIdentifier struct {
+	metadataTestData *parseWorkflowOrchestratorMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testWorkflowOrchestratorMetadata = []parseWorkflowOrchestratorMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// airflow celery queue
+	{map[string]string{"orchestrator": "airflow", "url": "http://airflow-webserver:8080", "queue": "default", "targetValue": "10", "authModes": "basic"}, map[string]string{"username": "admin", "password": "admin"}, false},
+	// dagster job
+	{map[string]string{"orchestrator": "dagster", "url": "http://dagster-webserver:3000", "jobName": "etl", "targetValue": "2"}, map[string]string{}, false},
+	// prefect work queue
+	{map[string]string{"orchestrator": "prefect", "url": "http://prefect-server:4200/api", "pool": "kubernetes", "queue": "default", "targetValue": "5"}, map[string]string{}, false},
+	// unknown orchestrator
+	{map[string]string{"orchestrator": "argo", "url": "http://argo-server:2746", "targetValue": "5"}, map[string]string{}, true},
+	// missing url
+	{map[string]string{"orchestrator": "airflow", "targetValue": "10"}, map[string]string{}, true},
+	// queue isn't supported by dagster
+	{map[string]string{"orchestrator": "dagster", "url": "http://dagster-webserver:3000", "queue": "default", "targetValue": "2"}, map[string]string{}, true},
+	// jobName isn't supported by airflow
+	{map[string]string{"orchestrator": "airflow", "url": "http://airflow-webserver:8080", "jobName": "etl", "targetValue": "10"}, map[string]string{}, true},
+	// invalid targetValue
+	{map[string]string{"orchestrator": "prefect", "url": "http://prefect-server:4200/api", "targetValue": "0"}, map[string]string{}, true},
+}
+
+var workflowOrchestratorMetricIdentifiers = []workflowOrchestratorMetricIdentifier{
+	{&testWorkflowOrchestratorMetadata[1], 0, "s0-workflow-orchestrator-airflow-default"},
+	{&testWorkflowOrchestratorMetadata[3], 1, "s1-workflow-orchestrator-prefect-kubernetes-default"},
+}
+
+func TestWorkflowOrchestratorParseMetadata(t *testing.T) {
+	for testCaseNum, testData := range testWorkflowOrchestratorMetadata {
+		_, err := parseWorkflowOrchestratorMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", testCaseNum, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", testCaseNum)
+		}
+	}
+}
+
+func TestWorkflowOrchestratorGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range workflowOrchestratorMetricIdentifiers {
+		meta, err := parseWorkflowOrchestratorMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockWorkflowOrchestratorScaler := workflowOrchestratorScaler{metadata: meta}
+
+		metricSpec := mockWorkflowOrchestratorScaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+func TestWorkflowOrchestratorGetMetricsAndActivity(t *testing.T) {
+	var apiStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/dags/~/dagRuns/~/taskInstances":
+			user, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "admin", user)
+			assert.Equal(t, "admin", password)
+			assert.Equal(t, "queued", r.URL.Query().Get("state"))
+			assert.Equal(t, "default", r.URL.Query().Get("queue"))
+			_, _ = w.Write([]byte(`{"task_instances": [{"task_id": "extract"}], "total_entries": 12}`))
+		case "/graphql":
+			var request struct {
+				Variables struct {
+					Filter map[string]any `json:"filter"`
+				} `json:"variables"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, []any{"QUEUED"}, request.Variables.Filter["statuses"])
+			if request.Variables.Filter["pipelineName"] == "missing" {
+				_, _ = w.Write([]byte(`{"data": {"runsOrError": {"__typename": "PythonError", "message": "job not found"}}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data": {"runsOrError": {"__typename": "Runs", "count": 3}}}`))
+		case "/api/flow_runs/count":
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			var filter map[string]map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&filter))
+			assert.Contains(t, filter["flow_runs"], "expected_start_time")
+			assert.Equal(t, map[string]any{"any_": []any{"kubernetes"}}, filter["work_pools"]["name"])
+			_, _ = w.Write([]byte(`7`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer apiStub.Close()
+
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		authParams     map[string]string
+		expectedValue  float64
+		expectedActive bool
+		expectedError  string
+	}{
+		{"airflow", map[string]string{"orchestrator": "airflow", "url": apiStub.URL + "/", "queue": "default", "authModes": "basic"}, map[string]string{"username": "admin", "password": "admin"}, 12, true, ""},
+		{"dagster", map[string]string{"orchestrator": "dagster", "url": apiStub.URL, "jobName": "etl"}, map[string]string{}, 3, false, ""},
+		{"dagster error", map[string]string{"orchestrator": "dagster", "url": apiStub.URL, "jobName": "missing"}, map[string]string{}, 0, false, "dagster returned PythonError: job not found"},
+		{"prefect", map[string]string{"orchestrator": "prefect", "url": apiStub.URL + "/api", "pool": "kubernetes", "authModes": "bearer"}, map[string]string{"bearerToken": "token"}, 7, true, ""},
+		{"prefect wrong url", map[string]string{"orchestrator": "prefect", "url": apiStub.URL, "pool": "kubernetes"}, map[string]string{}, 0, false, "/flow_runs/count: prefect api returned 404"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["targetValue"] = "10"
+			tc.metadata["activationTargetValue"] = "5"
+			s, err := NewWorkflowOrchestratorScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata:   tc.metadata,
+				AuthParams:        tc.authParams,
+				GlobalHTTPTimeout: 3000 * time.Millisecond,
+			})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.expectedActive, isActive)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/expfmt"
+	v2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// workqueueQueueLabel is the label of the workqueue metrics with the name of the queue,
+	// controller-runtime names the queue of a controller after it
+	workqueueQueueLabel = "name"
+	// workqueueAcceptHeader requests the text exposition format, parsed without the protobuf negotiation
+	workqueueAcceptHeader = "text/plain;version=0.0.4"
+)
+
+// workqueueMetrics are the metric families read for each metric of the trigger
+var workqueueMetrics = map[string]string{
+	"depth":                          "workqueue_depth",
+	"unfinishedWorkSeconds":          "workqueue_unfinished_work_seconds",
+	"longestRunningProcessorSeconds": "workqueue_longest_running_processor_seconds",
+}
+
+type workqueueScaler struct {
+	metricType v2.MetricTargetType
+	metadata   *workqueueMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type workqueueMetadata struct {
+	Auth *authentication.Config `keda:"optional"`
+
+	// URL is the metrics endpoint of the controller, e.g. http://my-operator-metrics.my-namespace:8080/metrics
+	URL       string `keda:"name=url,       order=triggerMetadata;resolvedEnv"`
+	QueueName string `keda:"name=queueName, order=triggerMetadata"`
+	Metric    string `keda:"name=metric,    order=triggerMetadata, enum=depth;unfinishedWorkSeconds;longestRunningProcessorSeconds, default=depth"`
+
+	// IgnoreNullValues reports 0 while the queue isn't exposed, e.g. by the replicas which aren't the leader
+	IgnoreNullValues bool `keda:"name=ignoreNullValues, order=triggerMetadata, default=true"`
+	UnsafeSsl        bool `keda:"name=unsafeSsl,        order=triggerMetadata, default=false"`
+
+	TargetValue           float64 `keda:"name=targetValue,           order=triggerMetadata"`
+	ActivationTargetValue float64 `keda:"name=activationTargetValue, order=triggerMetadata, default=0"`
+
+	triggerIndex int
+}
+
+func (m *workqueueMetadata) Validate() error {
+	if m.TargetValue <= 0 {
+		return fmt.Errorf("targetValue must be greater than 0")
+	}
+	if !m.Auth.Disabled() && m.Auth.EnabledOAuth() {
+		return fmt.Errorf("authMode oauth isn't supported")
+	}
+	return nil
+}
+
+// NewWorkqueueScaler creates a new scaler for the backlog of a workqueue of a controller, scraped
+// from the client-go workqueue metrics it exposes
+func NewWorkqueueScaler(config *scalersconfig.ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %w", err)
+	}
+
+	meta, err := parseWorkqueueMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing workqueue metadata: %w", err)
+	}
+
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.UnsafeSsl)
+	if !meta.Auth.Disabled() && (meta.Auth.CA != "" || meta.Auth.EnabledTLS()) {
+		transport, err := authentication.CreateHTTPRoundTripper(authentication.NetHTTP, meta.Auth.ToAuthMeta())
+		if err != nil {
+			return nil, fmt.Errorf("error creating the workqueue http transport: %w", err)
+		}
+		httpClient.Transport = transport
+	}
+
+	return &workqueueScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "workqueue_scaler"),
+	}, nil
+}
+
+func parseWorkqueueMetadata(config *scalersconfig.ScalerConfig) (*workqueueMetadata, error) {
+	meta := &workqueueMetadata{}
+	if err := config.TypedConfig(meta); err != nil {
+		return nil, fmt.Errorf("error parsing workqueue metadata: %w", err)
+	}
+	meta.triggerIndex = config.TriggerIndex
+	return meta, nil
+}
+
+// scrape reads the metrics exposed by the controller
+func (s *workqueueScaler) scrape(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", workqueueAcceptHeader)
+	switch {
+	case s.metadata.Auth.Disabled():
+	case s.metadata.Auth.EnabledBearerAuth():
+		req.Header.Set("Authorization", s.metadata.Auth.GetBearerToken())
+	case s.metadata.Auth.EnabledBasicAuth():
+		req.SetBasicAuth(s.metadata.Auth.Username, s.metadata.Auth.Password)
+	case s.metadata.Auth.EnabledCustomAuth():
+		req.Header.Set(s.metadata.Auth.CustomAuthHeader, s.metadata.Auth.CustomAuthValue)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: metrics endpoint returned %d", resp.Request.URL.Path, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// GetMetricValue returns the value of the metric of the queue, summed over its series
+func (s *workqueueScaler) GetMetricValue(ctx context.Context) (float64, error) {
+	body, err := s.scrape(ctx)
+	if err != nil {
+		return -1, err
+	}
+	return getWorkqueueValue(body, workqueueMetrics[s.metadata.Metric], s.metadata.QueueName, s.metadata.IgnoreNullValues)
+}
+
+// getWorkqueueValue sums the samples of the metric family whose queue label is the queue
+func getWorkqueueValue(body []byte, family string, queueName string, ignoreNullValues bool) (float64, error) {
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(strings.NewReader(strings.ReplaceAll(string(body), "\r\n", "\n")))
+	if err != nil {
+		return -1, fmt.Errorf("error parsing the metrics: %w", err)
+	}
+
+	found := false
+	value := 0.0
+	for _, metric := range families[family].GetMetric() {
+		for _, label := range metric.GetLabel() {
+			if label.GetName() != workqueueQueueLabel || label.GetValue() != queueName {
+				continue
+			}
+			switch {
+			case metric.GetGauge() != nil:
+				value += metric.GetGauge().GetValue()
+			case metric.GetUntyped() != nil:
+				value += metric.GetUntyped().GetValue()
+			default:
+				return -1, fmt.Errorf("%s isn't a gauge", family)
+			}
+			found = true
+		}
+	}
+	if !found {
+		if ignoreNullValues {
+			return 0, nil
+		}
+		return -1, fmt.Errorf("%s{%s=%q} isn't exposed", family, workqueueQueueLabel, queueName)
+	}
+	return value, nil
+}
+
+func (s *workqueueScaler) GetMetricsAndActivity(ctx context.Context, metricName string) ([]external_metrics.ExternalMetricValue, bool, error) {
+	value, err := s.GetMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting workqueue metric value")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+	return []external_metrics.ExternalMetricValue{metric}, value > s.metadata.ActivationTargetValue, nil
+}
+
+func (s *workqueueScaler) GetMetricSpecForScaling(_ context.Context) []v2.MetricSpec {
+	externalMetric := &v2.ExternalMetricSource{
+		Metric: v2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.triggerIndex, kedautil.NormalizeString(fmt.Sprintf("workqueue-%s-%s", s.metadata.QueueName, s.metadata.Metric))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.TargetValue),
+	}
+	metricSpec := v2.MetricSpec{External: externalMetric, Type: externalMetricType}
+	return []v2.MetricSpec{metricSpec}
+}
+
+func (s *workqueueScaler) Close(_ context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
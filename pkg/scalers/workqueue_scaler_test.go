@@ -0,0 +1,133 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+type parseWorkqueueMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type workqueueMetricIdentifier struct {
+	metadataTestData *parseWorkqueueMetadataTestData
+	triggerIndex     int
+	name             string
+}
+
+var testWorkqueueMetadata = []parseWorkqueueMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed metadata
+	{map[string]string{"url": "http://operator-metrics:8080/metrics", "queueName": "deployment", "targetValue": "10"}, map[string]string{}, false},
+	// unfinished work seconds
+	{map[string]string{"url": "http://operator-metrics:8080/metrics", "queueName": "deployment", "metric": "unfinishedWorkSeconds", "targetValue": "30"}, map[string]string{}, false},
+	// invalid metric
+	{map[string]string{"url": "http://operator-metrics:8080/metrics", "queueName": "deployment", "metric": "adds", "targetValue": "10"}, map[string]string{}, true},
+	// missing queueName
+	{map[string]string{"url": "http://operator-metrics:8080/metrics", "targetValue": "10"}, map[string]string{}, true},
+	// invalid targetValue
+	{map[string]string{"url": "http://operator-metrics:8080/metrics", "queueName": "deployment", "targetValue": "0"}, map[string]string{}, true},
+	// bearer auth
+	{map[string]string{"url": "https://operator-metrics:8443/metrics", "queueName": "deployment", "targetValue": "10", "authModes": "bearer"}, map[string]string{"bearerToken": "token"}, false},
+	// bearer auth without a token
+	{map[string]string{"url": "https://operator-metrics:8443/metrics", "queueName": "deployment", "targetValue": "10", "authModes": "bearer"}, map[string]string{}, true},
+}
+
+var workqueueMetricIdentifiers = []workqueueMetricIdentifier{
+	{&testWorkqueueMetadata[1], 0, "s0-workqueue-deployment-depth"},
+	{&testWorkqueueMetadata[2], 1, "s1-workqueue-deployment-unfinishedWorkSeconds"},
+}
+
+func TestWorkqueueParseMetadata(t *testing.T) {
+	for testCaseNum, testData := range testWorkqueueMetadata {
+		_, err := parseWorkqueueMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for unit test # %v: %v", testCaseNum, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for unit test # %v", testCaseNum)
+		}
+	}
+}
+
+func TestWorkqueueGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range workqueueMetricIdentifiers {
+		meta, err := parseWorkqueueMetadata(&scalersconfig.ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, TriggerIndex: testData.triggerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockWorkqueueScaler := workqueueScaler{metadata: meta}
+
+		metricSpec := mockWorkqueueScaler.GetMetricSpecForScaling(context.Background())
+		assert.Equal(t, testData.name, metricSpec[0].External.Metric.Name)
+	}
+}
+
+const testWorkqueueMetrics = `# HELP workqueue_depth Current depth of workqueue
+# TYPE workqueue_depth gauge
+workqueue_depth{controller="deployment",name="deployment"} 12
+workqueue_depth{controller="replicaset",name="replicaset"} 3
+# HELP workqueue_unfinished_work_seconds How many seconds of work has been done that is in progress and hasn't been observed by work_duration.
+# TYPE workqueue_unfinished_work_seconds gauge
+workqueue_unfinished_work_seconds{controller="deployment",name="deployment"} 42.5
+# HELP workqueue_adds_total Total number of adds handled by workqueue
+# TYPE workqueue_adds_total counter
+workqueue_adds_total{controller="deployment",name="deployment"} 1234
+`
+
+func TestWorkqueueGetMetricsAndActivity(t *testing.T) {
+	var apiStub = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(testWorkqueueMetrics))
+	}))
+	defer apiStub.Close()
+
+	testCases := []struct {
+		name           string
+		metadata       map[string]string
+		expectedValue  float64
+		expectedActive bool
+		expectedError  string
+	}{
+		{"depth", map[string]string{"queueName": "deployment"}, 12, true, ""},
+		{"depth of another queue", map[string]string{"queueName": "replicaset"}, 3, false, ""},
+		{"unfinished work seconds", map[string]string{"queueName": "deployment", "metric": "unfinishedWorkSeconds"}, 42.5, true, ""},
+		{"queue not exposed", map[string]string{"queueName": "statefulset"}, 0, false, ""},
+		{"queue not exposed with ignoreNullValues false", map[string]string{"queueName": "statefulset", "ignoreNullValues": "false"}, 0, false, `workqueue_depth{name="statefulset"} isn't exposed`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metadata["url"] = apiStub.URL
+			tc.metadata["targetValue"] = "10"
+			tc.metadata["activationTargetValue"] = "5"
+			tc.metadata["authModes"] = "bearer"
+			s, err := NewWorkqueueScaler(&scalersconfig.ScalerConfig{
+				TriggerMetadata:   tc.metadata,
+				AuthParams:        map[string]string{"bearerToken": "token"},
+				GlobalHTTPTimeout: 3000 * time.Millisecond,
+			})
+			assert.NoError(t, err)
+
+			metrics, isActive, err := s.GetMetricsAndActivity(context.Background(), "MetricName")
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, metrics[0].Value.AsApproximateFloat64())
+			assert.Equal(t, tc.expectedActive, isActive)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+// activationTrends are the numbers of the last consecutive evaluations of the scale loop the metrics of the
+// triggers of the ScaledObjects were active on, by identifier, trigger index and metric name. They're kept by
+// the scale handler, so they survive the scalers rebuilt and aren't counted on the requests of the HPA.
+type activationTrends struct {
+	lock        sync.Mutex
+	activePolls map[string]int
+}
+
+func newActivationTrends() *activationTrends {
+	return &activationTrends{activePolls: map[string]int{}}
+}
+
+// isActive returns whether the metric of the trigger was active on all the evaluations of the activation trend
+// window of the scaler, the scalers without window are active as soon as their metric is
+func (t *activationTrends) isActive(identifier string, triggerIndex int, metricName string, scaler scalers.Scaler, isActive bool) bool {
+	trendScaler, ok := scaler.(scalers.ActivationTrendScaler)
+	if t == nil || !ok || trendScaler.GetActivationTrendWindow() <= 1 {
+		return isActive
+	}
+	window := trendScaler.GetActivationTrendWindow()
+
+	key := fmt.Sprintf("%s/%d/%s", identifier, triggerIndex, metricName)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if !isActive {
+		delete(t.activePolls, key)
+		return false
+	}
+	if t.activePolls[key] < window {
+		t.activePolls[key]++
+	}
+	return t.activePolls[key] == window
+}
+
+// delete forgets the activation trends of the triggers of the ScaledObject
+func (t *activationTrends) delete(identifier string) {
+	if t == nil {
+		return
+	}
+	prefix := identifier + "/"
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for key := range t.activePolls {
+		if strings.HasPrefix(key, prefix) {
+			delete(t.activePolls, key)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+)
+
+type testActivationTrendScaler struct {
+	*mock_scalers.MockScaler
+	window int
+}
+
+func (s *testActivationTrendScaler) GetActivationTrendWindow() int {
+	return s.window
+}
+
+func TestActivationTrends(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	trends := newActivationTrends()
+	identifier := "scaledobject.default.worker"
+
+	// the scalers without window are active as soon as their metric is
+	plain := mock_scalers.NewMockScaler(ctrl)
+	assert.True(t, trends.isActive(identifier, 0, "s0-metric", plain, true))
+	assert.False(t, trends.isActive(identifier, 0, "s0-metric", plain, false))
+
+	// the metric has to be active on the last 3 evaluations
+	scaler := &testActivationTrendScaler{MockScaler: mock_scalers.NewMockScaler(ctrl), window: 3}
+	for i, expected := range []bool{false, false, true, true, false, false, false, true} {
+		isActive := i != 4
+		assert.Equal(t, expected, trends.isActive(identifier, 1, "cpu", scaler, isActive), i)
+	}
+
+	// the triggers of the other ScaledObjects are counted apart
+	assert.False(t, trends.isActive("scaledobject.default.other", 1, "cpu", scaler, true))
+
+	// the trends are forgotten once the ScaledObject is deleted
+	trends.delete(identifier)
+	assert.False(t, trends.isActive(identifier, 1, "cpu", scaler, true))
+	assert.Len(t, trends.activePolls, 2)
+
+	var disabled *activationTrends
+	assert.True(t, disabled.isActive(identifier, 1, "cpu", scaler, true))
+	disabled.delete(identifier)
+}
@@ -19,6 +19,7 @@ package cache
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/expr-lang/expr/vm"
@@ -30,6 +31,7 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 )
 
 var log = logf.Log.WithName("scalers_cache")
@@ -40,6 +42,32 @@ type ScalersCache struct {
 	ScalableObjectGeneration int64
 	Recorder                 record.EventRecorder
 	CompiledFormula          *vm.Program
+	// CreatedAt is when the scalers of the cache were built
+	CreatedAt time.Time
+
+	// scalersLock guards the replacement of the Scalers rebuilt after an error, as they're read concurrently
+	scalersLock sync.RWMutex
+
+	statsLock sync.Mutex
+	stats     map[int]*ScalerStats
+
+	// usersLock guards users, the number of evaluations using the scalers, and evicted, whether the cache
+	// is closed once they're done
+	usersLock sync.Mutex
+	users     int
+	evicted   bool
+}
+
+// ScalerStats describes the lifecycle of a scaler in the cache
+type ScalerStats struct {
+	// LastEvaluation is the time the scaler has last been asked for its metrics
+	LastEvaluation time.Time
+	// LastError is the error of the last evaluation
+	LastError error
+	// RebuiltAt is the time the scaler has last been rebuilt after an error
+	RebuiltAt time.Time
+	// Rebuilds is the number of times the scaler has been rebuilt within the cache
+	Rebuilds int
 }
 
 type ScalerBuilder struct {
@@ -50,6 +78,8 @@ type ScalerBuilder struct {
 
 // GetScalers returns array of scalers and scaler config stored in the cache
 func (c *ScalersCache) GetScalers() ([]scalers.Scaler, []scalersconfig.ScalerConfig) {
+	c.scalersLock.RLock()
+	defer c.scalersLock.RUnlock()
 	scalersList := make([]scalers.Scaler, 0, len(c.Scalers))
 	configsList := make([]scalersconfig.ScalerConfig, 0, len(c.Scalers))
 	for _, s := range c.Scalers {
@@ -63,18 +93,54 @@ func (c *ScalersCache) GetScalers() ([]scalers.Scaler, []scalersconfig.ScalerCon
 // GetPushScalers returns array of push scalers stored in the cache
 func (c *ScalersCache) GetPushScalers() []scalers.PushScaler {
 	var result []scalers.PushScaler
-	for _, s := range c.Scalers {
-		if ps, ok := s.Scaler.(scalers.PushScaler); ok {
+	scalersList, _ := c.GetScalers()
+	for _, s := range scalersList {
+		if ps, ok := s.(scalers.PushScaler); ok {
 			result = append(result, ps)
 		}
 	}
 	return result
 }
 
+// Acquire marks the scalers as used until the function returned is called, an evicted cache isn't closed
+// while they're used
+func (c *ScalersCache) Acquire() func() {
+	c.usersLock.Lock()
+	c.users++
+	c.usersLock.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.usersLock.Lock()
+			c.users--
+			unused := c.evicted && c.users == 0
+			c.usersLock.Unlock()
+			if unused {
+				go c.Close(context.Background())
+			}
+		})
+	}
+}
+
+// Evict closes the cache once its scalers aren't used anymore. It's closed under a context of its own, as
+// the request which evicted it may be done by then.
+func (c *ScalersCache) Evict() {
+	c.usersLock.Lock()
+	c.evicted = true
+	unused := c.users == 0
+	c.usersLock.Unlock()
+	if unused {
+		go c.Close(context.Background())
+	}
+}
+
 // Close closes all scalers in the cache
 func (c *ScalersCache) Close(ctx context.Context) {
+	c.scalersLock.Lock()
 	scalers := c.Scalers
 	c.Scalers = nil
+	c.scalersLock.Unlock()
 	for _, s := range scalers {
 		err := s.Scaler.Close(ctx)
 		if err != nil {
@@ -86,8 +152,9 @@ func (c *ScalersCache) Close(ctx context.Context) {
 // GetMetricSpecForScaling returns metrics specs for all scalers in the cache
 func (c *ScalersCache) GetMetricSpecForScaling(ctx context.Context) []v2.MetricSpec {
 	var spec []v2.MetricSpec
-	for _, s := range c.Scalers {
-		spec = append(spec, s.Scaler.GetMetricSpecForScaling(ctx)...)
+	scalersList, _ := c.GetScalers()
+	for _, s := range scalersList {
+		spec = append(spec, s.GetMetricSpecForScaling(ctx)...)
 	}
 	return spec
 }
@@ -98,7 +165,7 @@ func (c *ScalersCache) GetMetricSpecForScalingForScaler(ctx context.Context, ind
 
 	scalersList, _ := c.GetScalers()
 	if index < 0 || index >= len(scalersList) {
-		return nil, fmt.Errorf("scaler with id %d not found. Len = %d", index, len(c.Scalers))
+		return nil, fmt.Errorf("scaler with id %d not found. Len = %d", index, len(scalersList))
 	}
 
 	metricSpecs := scalersList[index].GetMetricSpecForScaling(ctx)
@@ -123,44 +190,105 @@ func (c *ScalersCache) GetMetricSpecForScalingForScaler(ctx context.Context, ind
 // GetMetricsAndActivityForScaler returns metric value, activity and latency for a scaler identified by the metric name
 // and by the input index (from the list of scalers in this ScaledObject)
 func (c *ScalersCache) GetMetricsAndActivityForScaler(ctx context.Context, index int, metricName string) ([]external_metrics.ExternalMetricValue, bool, time.Duration, error) {
-	if index < 0 || index >= len(c.Scalers) {
-		return nil, false, -1, fmt.Errorf("scaler with id %d not found. Len = %d", index, len(c.Scalers))
+	scalersList, configsList := c.GetScalers()
+	if index < 0 || index >= len(scalersList) {
+		return nil, false, -1, fmt.Errorf("scaler with id %d not found. Len = %d", index, len(scalersList))
 	}
+	ctx, span := tracing.StartSpan(ctx, "Scaler.GetMetricsAndActivity",
+		tracing.TriggerTypeKey.String(configsList[index].TriggerType),
+		tracing.TriggerIndexKey.Int(index),
+		tracing.MetricNameKey.String(metricName),
+	)
 	startTime := time.Now()
-	metric, activity, err := c.Scalers[index].Scaler.GetMetricsAndActivity(ctx, metricName)
+	metric, activity, err := scalersList[index].GetMetricsAndActivity(ctx, metricName)
 	if err == nil {
+		tracing.EndSpan(span, nil)
+		c.recordEvaluation(index, startTime, nil)
 		return metric, activity, time.Since(startTime), nil
 	}
 
+	// the scaler is rebuilt and called again, keep the first error on the trace
+	span.RecordError(err)
 	ns, err := c.refreshScaler(ctx, index)
 	if err != nil {
+		tracing.EndSpan(span, err)
+		c.recordEvaluation(index, startTime, err)
 		return nil, false, -1, err
 	}
 	startTime = time.Now()
 	metric, activity, err = ns.GetMetricsAndActivity(ctx, metricName)
+	tracing.EndSpan(span, err)
+	c.recordEvaluation(index, startTime, err)
 	return metric, activity, time.Since(startTime), err
 }
 
-func (c *ScalersCache) refreshScaler(ctx context.Context, id int) (scalers.Scaler, error) {
-	if id < 0 || id >= len(c.Scalers) {
-		return nil, fmt.Errorf("scaler with id %d not found, len = %d, cache has been probably already invalidated", id, len(c.Scalers))
+// GetScalerStats returns the stats of the scaler identified by the input index,
+// they are empty if the scaler hasn't been evaluated nor rebuilt yet
+func (c *ScalersCache) GetScalerStats(index int) ScalerStats {
+	c.statsLock.Lock()
+	defer c.statsLock.Unlock()
+	if stats, ok := c.stats[index]; ok {
+		return *stats
 	}
+	return ScalerStats{}
+}
+
+func (c *ScalersCache) recordEvaluation(index int, evaluatedAt time.Time, err error) {
+	c.statsLock.Lock()
+	defer c.statsLock.Unlock()
+	stats := c.getOrCreateStats(index)
+	stats.LastEvaluation = evaluatedAt
+	stats.LastError = err
+}
+
+func (c *ScalersCache) recordRebuild(index int) {
+	c.statsLock.Lock()
+	defer c.statsLock.Unlock()
+	stats := c.getOrCreateStats(index)
+	stats.RebuiltAt = time.Now()
+	stats.Rebuilds++
+}
 
+// getOrCreateStats must be called with the stats lock held
+func (c *ScalersCache) getOrCreateStats(index int) *ScalerStats {
+	if c.stats == nil {
+		c.stats = map[int]*ScalerStats{}
+	}
+	stats, ok := c.stats[index]
+	if !ok {
+		stats = &ScalerStats{}
+		c.stats[index] = stats
+	}
+	return stats
+}
+
+func (c *ScalersCache) refreshScaler(ctx context.Context, id int) (scalers.Scaler, error) {
+	c.scalersLock.RLock()
+	if count := len(c.Scalers); id < 0 || id >= count {
+		c.scalersLock.RUnlock()
+		return nil, fmt.Errorf("scaler with id %d not found, len = %d, cache has been probably already invalidated", id, count)
+	}
 	sb := c.Scalers[id]
+	c.scalersLock.RUnlock()
+
 	defer sb.Scaler.Close(ctx)
 	ns, sConfig, err := sb.Factory()
 	if err != nil {
 		return nil, err
 	}
 
-	if id < 0 || id >= len(c.Scalers) {
-		return nil, fmt.Errorf("scaler with id %d not found, len = %d, cache has been probably already invalidated", id, len(c.Scalers))
+	c.scalersLock.Lock()
+	if count := len(c.Scalers); id < 0 || id >= count {
+		c.scalersLock.Unlock()
+		return nil, fmt.Errorf("scaler with id %d not found, len = %d, cache has been probably already invalidated", id, count)
 	}
 	c.Scalers[id] = ScalerBuilder{
 		Scaler:       ns,
 		ScalerConfig: *sConfig,
 		Factory:      sb.Factory,
 	}
+	c.scalersLock.Unlock()
+	c.recordRebuild(id)
 
 	return ns, nil
 }
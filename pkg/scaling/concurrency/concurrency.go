@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Options configure how many triggers are evaluated concurrently
+type Options struct {
+	// Workers is the number of triggers evaluated concurrently across all the ScaledObjects and ScaledJobs,
+	// they aren't bounded if it's 0
+	Workers int
+	// TriggerTypeLimits are the numbers of triggers of a type evaluated concurrently, so a slow or
+	// fragile backend doesn't hold all the workers
+	TriggerTypeLimits map[string]int
+}
+
+// Limiter bounds the concurrent evaluations of the triggers
+type Limiter struct {
+	workers      chan struct{}
+	triggerTypes map[string]chan struct{}
+}
+
+var (
+	lock    sync.RWMutex
+	limiter *Limiter
+)
+
+// NewLimiter returns a limiter enforcing the options
+func NewLimiter(opts Options) (*Limiter, error) {
+	if opts.Workers < 0 {
+		return nil, fmt.Errorf("the number of trigger evaluation workers can't be negative, got %d", opts.Workers)
+	}
+	l := &Limiter{
+		triggerTypes: make(map[string]chan struct{}, len(opts.TriggerTypeLimits)),
+	}
+	if opts.Workers > 0 {
+		l.workers = make(chan struct{}, opts.Workers)
+	}
+	for triggerType, limit := range opts.TriggerTypeLimits {
+		if limit <= 0 {
+			return nil, fmt.Errorf("the concurrency limit of the %s triggers must be positive, got %d", triggerType, limit)
+		}
+		l.triggerTypes[triggerType] = make(chan struct{}, limit)
+	}
+	return l, nil
+}
+
+// Configure bounds the concurrent evaluations of the triggers by all the scale handlers
+func Configure(opts Options) error {
+	l, err := NewLimiter(opts)
+	if err != nil {
+		return err
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	limiter = l
+	return nil
+}
+
+// Acquire waits for the evaluation of a trigger of the type to be allowed by the configured limits.
+// The returned function has to be called once the trigger is evaluated.
+func Acquire(ctx context.Context, triggerType string) (func(), error) {
+	lock.RLock()
+	l := limiter
+	lock.RUnlock()
+	return l.Acquire(ctx, triggerType)
+}
+
+// Acquire waits for the evaluation of a trigger of the type to be allowed, a nil limiter allows all of them.
+// The slot of the trigger type is taken before the worker, so the triggers waiting for a busy backend don't
+// hold workers the other triggers could use.
+func (l *Limiter) Acquire(ctx context.Context, triggerType string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	triggerTypeSlots := l.triggerTypes[triggerType]
+	if err := acquire(ctx, triggerTypeSlots); err != nil {
+		return nil, fmt.Errorf("error waiting for the evaluation of the %s trigger: %w", triggerType, err)
+	}
+	if err := acquire(ctx, l.workers); err != nil {
+		release(triggerTypeSlots)
+		return nil, fmt.Errorf("error waiting for a worker to evaluate the %s trigger: %w", triggerType, err)
+	}
+	return func() {
+		release(l.workers)
+		release(triggerTypeSlots)
+	}, nil
+}
+
+func acquire(ctx context.Context, slots chan struct{}) error {
+	if slots == nil {
+		return nil
+	}
+	select {
+	case slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func release(slots chan struct{}) {
+	if slots != nil {
+		<-slots
+	}
+}
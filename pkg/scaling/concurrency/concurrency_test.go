@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLimiterValidation(t *testing.T) {
+	_, err := NewLimiter(Options{Workers: -1})
+	assert.Error(t, err)
+
+	_, err = NewLimiter(Options{TriggerTypeLimits: map[string]int{"kafka": 0}})
+	assert.Error(t, err)
+
+	_, err = NewLimiter(Options{Workers: 2, TriggerTypeLimits: map[string]int{"kafka": 1}})
+	assert.NoError(t, err)
+}
+
+func TestLimiterBoundsEvaluations(t *testing.T) {
+	l, err := NewLimiter(Options{Workers: 3, TriggerTypeLimits: map[string]int{"kafka": 1}})
+	assert.NoError(t, err)
+
+	var running, maxRunning, kafkaRunning, maxKafkaRunning int32
+	track := func(current, highest *int32) {
+		value := atomic.AddInt32(current, 1)
+		for {
+			previous := atomic.LoadInt32(highest)
+			if value <= previous || atomic.CompareAndSwapInt32(highest, previous, value) {
+				return
+			}
+		}
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 12; i++ {
+		triggerType := "prometheus"
+		if i%2 == 0 {
+			triggerType = "kafka"
+		}
+		wg.Add(1)
+		go func(triggerType string) {
+			defer wg.Done()
+			release, err := l.Acquire(context.Background(), triggerType)
+			assert.NoError(t, err)
+			track(&running, &maxRunning)
+			if triggerType == "kafka" {
+				track(&kafkaRunning, &maxKafkaRunning)
+			}
+			time.Sleep(10 * time.Millisecond)
+			if triggerType == "kafka" {
+				atomic.AddInt32(&kafkaRunning, -1)
+			}
+			atomic.AddInt32(&running, -1)
+			release()
+		}(triggerType)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, maxRunning, int32(3))
+	assert.Equal(t, int32(1), maxKafkaRunning)
+}
+
+func TestLimiterAcquireCanceled(t *testing.T) {
+	l, err := NewLimiter(Options{Workers: 1})
+	assert.NoError(t, err)
+
+	release, err := l.Acquire(context.Background(), "prometheus")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx, "prometheus")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the worker is available again once released
+	release()
+	release, err = l.Acquire(context.Background(), "prometheus")
+	assert.NoError(t, err)
+	release()
+}
+
+func TestNilLimiterIsUnbounded(t *testing.T) {
+	var l *Limiter
+	release, err := l.Acquire(context.Background(), "kafka")
+	assert.NoError(t, err)
+	release()
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
+)
+
+// trackActivation measures the time from the activation of the ScaledObject scaled to zero until a replica
+// of its scale target is ready, so the cold starts of the scale target can be monitored. The replicas are
+// checked on every polling interval, which bounds the precision of the measurement.
+// An activation is forgotten if the ScaledObject is deactivated before a replica is ready.
+func (e *scaleExecutor) trackActivation(scaledObject *kedav1alpha1.ScaledObject, isActive bool, currentReplicas, readyReplicas int32, now time.Time) {
+	if e.activations == nil {
+		return
+	}
+	// the UID tells apart a ScaledObject recreated with the same name
+	key := string(scaledObject.UID)
+	activatedAt, pending := e.activations.Load(key)
+	switch {
+	case pending && readyReplicas > 0:
+		e.activations.Delete(key)
+		metricscollector.RecordScaledObjectActivationLatency(scaledObject.Namespace, scaledObject.Name, now.Sub(activatedAt.(time.Time)))
+	case pending && !isActive && currentReplicas == 0:
+		e.activations.Delete(key)
+	case !pending && isActive && currentReplicas == 0:
+		e.activations.Store(key, now)
+	}
+}
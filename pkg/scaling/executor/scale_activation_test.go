@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestTrackActivation(t *testing.T) {
+	e := &scaleExecutor{activations: &sync.Map{}}
+	scaledObject := &v1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace", UID: "1234"}}
+	activatedAt := time.Now()
+
+	pending := func() bool {
+		_, ok := e.activations.Load("1234")
+		return ok
+	}
+
+	// an inactive ScaledObject scaled to zero isn't tracked
+	e.trackActivation(scaledObject, false, 0, 0, activatedAt)
+	assert.False(t, pending())
+
+	// the activation is tracked from the first time it is active at zero replicas
+	e.trackActivation(scaledObject, true, 0, 0, activatedAt)
+	e.trackActivation(scaledObject, true, 1, 0, activatedAt.Add(5*time.Second))
+	start, _ := e.activations.Load("1234")
+	assert.Equal(t, activatedAt, start)
+
+	// it is measured once a replica is ready
+	e.trackActivation(scaledObject, true, 1, 1, activatedAt.Add(10*time.Second))
+	assert.False(t, pending())
+
+	// it is forgotten if the ScaledObject is deactivated before a replica is ready
+	e.trackActivation(scaledObject, true, 0, 0, activatedAt)
+	e.trackActivation(scaledObject, false, 0, 0, activatedAt.Add(time.Minute))
+	assert.False(t, pending())
+
+	// an active ScaledObject with replicas isn't tracked
+	e.trackActivation(scaledObject, true, 2, 2, activatedAt)
+	assert.False(t, pending())
+}
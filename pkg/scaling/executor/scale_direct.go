@@ -0,0 +1,289 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/go-logr/logr"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+)
+
+const (
+	// directScalingTolerance is the tolerance of the usage ratio the replicas aren't changed within,
+	// the default of the HPA controller
+	directScalingTolerance = 0.1
+
+	// the defaults of the behavior of the HPA
+	defaultScaleDownStabilizationWindowSeconds = 300
+	defaultScalingPolicyPeriodSeconds          = 15
+	// maxScalingPolicyPeriodSeconds is the longest period of the scaling policies the HPA accepts
+	maxScalingPolicyPeriodSeconds = 1800
+)
+
+// directScalingHistory are the replicas recommended for a ScaledObject scaled directly and its scaling events,
+// the behavior is applied over them the way the HPA controller does. The scale loop of the ScaledObject is
+// serialized, it is never accessed concurrently.
+type directScalingHistory struct {
+	recommendations []timestampedReplicas
+	scaleUpEvents   []timestampedReplicas
+	scaleDownEvents []timestampedReplicas
+}
+
+// timestampedReplicas is a replica count, or a change of it, at a point in time
+type timestampedReplicas struct {
+	timestamp time.Time
+	replicas  int32
+}
+
+// scaleDirectly scales the target of the ScaledObject scaled directly to the replicas computed from the usage ratio
+// of its triggers and its behavior, it returns the replicas the target is scaled to
+func (e *scaleExecutor) scaleDirectly(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale,
+	currentReplicas int32, usageRatio float64, now time.Time) int32 {
+	history := e.getDirectScalingHistory(scaledObject)
+	scaleUp, scaleDown := getDirectScalingRules(scaledObject)
+	desiredReplicas := history.getDesiredReplicas(scaleUp, scaleDown, currentReplicas, getUsageReplicas(currentReplicas, usageRatio),
+		*scaledObject.GetHPAMinReplicas(), scaledObject.GetHPAMaxReplicas(), now)
+	if desiredReplicas == currentReplicas {
+		logger.V(1).Info("ScaleTarget no change", "usageRatio", usageRatio)
+		return currentReplicas
+	}
+
+	_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale, desiredReplicas)
+	if err != nil {
+		logger.Error(err, "error scaling target directly", "desired replicas", desiredReplicas)
+		e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetScalingFailed, "Failed to scale %s %s/%s from %d to %d",
+			scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, desiredReplicas)
+		return currentReplicas
+	}
+	history.recordScaling(currentReplicas, desiredReplicas, now)
+
+	logger.Info("Successfully scaled target directly",
+		"Original Replicas Count", currentReplicas,
+		"New Replicas Count", desiredReplicas,
+		"usageRatio", usageRatio)
+	e.recorder.AnnotatedEventf(scaledObject, replicasAnnotations(currentReplicas, desiredReplicas), corev1.EventTypeNormal, eventreason.KEDAScaleTargetScaled,
+		"Scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, desiredReplicas)
+	return desiredReplicas
+}
+
+// getDirectScalingHistory returns the history of the ScaledObject scaled directly, the recommendations and the events
+// older than the windows and the periods aren't taken into account, the history of a ScaledObject scaled directly again
+// after a while starts over
+func (e *scaleExecutor) getDirectScalingHistory(scaledObject *kedav1alpha1.ScaledObject) *directScalingHistory {
+	if e.directScalings == nil {
+		return &directScalingHistory{}
+	}
+	// the UID tells apart a ScaledObject recreated with the same name
+	history, _ := e.directScalings.LoadOrStore(string(scaledObject.UID), &directScalingHistory{})
+	return history.(*directScalingHistory)
+}
+
+// forgetDirectScaling forgets the history of the ScaledObject once it's deleted or no longer scaled directly
+func (e *scaleExecutor) forgetDirectScaling(scaledObject *kedav1alpha1.ScaledObject) {
+	if e.directScalings != nil {
+		e.directScalings.Delete(string(scaledObject.UID))
+	}
+}
+
+// getUsageReplicas returns the replicas bringing the usage ratio to 1, like the HPA it keeps the current replicas
+// while the ratio is within the tolerance
+func getUsageReplicas(currentReplicas int32, usageRatio float64) int32 {
+	if math.Abs(1.0-usageRatio) <= directScalingTolerance {
+		return currentReplicas
+	}
+	return int32(math.Ceil(usageRatio * float64(currentReplicas)))
+}
+
+// getDirectScalingRules returns the scaleUp and scaleDown rules of the behavior of the ScaledObject, with the defaults
+// of the HPA for the fields not set
+func getDirectScalingRules(scaledObject *kedav1alpha1.ScaledObject) (*autoscalingv2.HPAScalingRules, *autoscalingv2.HPAScalingRules) {
+	var behavior *autoscalingv2.HorizontalPodAutoscalerBehavior
+	if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig != nil {
+		behavior = scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior
+	}
+	var scaleUp, scaleDown *autoscalingv2.HPAScalingRules
+	if behavior != nil {
+		scaleUp, scaleDown = behavior.ScaleUp, behavior.ScaleDown
+	}
+
+	return withDefaultScalingRules(scaleUp, 0, []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PodsScalingPolicy, Value: 4, PeriodSeconds: defaultScalingPolicyPeriodSeconds},
+			{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: defaultScalingPolicyPeriodSeconds},
+		}),
+		withDefaultScalingRules(scaleDown, defaultScaleDownStabilizationWindowSeconds, []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: defaultScalingPolicyPeriodSeconds},
+		})
+}
+
+func withDefaultScalingRules(rules *autoscalingv2.HPAScalingRules, stabilizationWindowSeconds int32, policies []autoscalingv2.HPAScalingPolicy) *autoscalingv2.HPAScalingRules {
+	result := &autoscalingv2.HPAScalingRules{}
+	if rules != nil {
+		result = rules.DeepCopy()
+	}
+	if result.StabilizationWindowSeconds == nil {
+		result.StabilizationWindowSeconds = &stabilizationWindowSeconds
+	}
+	if result.SelectPolicy == nil {
+		selectPolicy := autoscalingv2.MaxChangePolicySelect
+		result.SelectPolicy = &selectPolicy
+	}
+	if len(result.Policies) == 0 {
+		result.Policies = policies
+	}
+	return result
+}
+
+// getDesiredReplicas returns the replicas the target is scaled to, the replicas recommended are stabilized over the
+// stabilization windows then the scaling is limited by the policies and the replica counts, like the HPA does
+func (h *directScalingHistory) getDesiredReplicas(scaleUp, scaleDown *autoscalingv2.HPAScalingRules, currentReplicas, recommendedReplicas, minReplicas, maxReplicas int32, now time.Time) int32 {
+	upCutoff := now.Add(-time.Duration(*scaleUp.StabilizationWindowSeconds) * time.Second)
+	downCutoff := now.Add(-time.Duration(*scaleDown.StabilizationWindowSeconds) * time.Second)
+	upRecommendation, downRecommendation := recommendedReplicas, recommendedReplicas
+	recommendations := h.recommendations[:0]
+	for _, recommendation := range h.recommendations {
+		if recommendation.timestamp.After(upCutoff) {
+			upRecommendation = min(upRecommendation, recommendation.replicas)
+		}
+		if recommendation.timestamp.After(downCutoff) {
+			downRecommendation = max(downRecommendation, recommendation.replicas)
+		}
+		if recommendation.timestamp.After(upCutoff) || recommendation.timestamp.After(downCutoff) {
+			recommendations = append(recommendations, recommendation)
+		}
+	}
+	h.recommendations = append(recommendations, timestampedReplicas{timestamp: now, replicas: recommendedReplicas})
+
+	// the target is scaled up to the lowest replicas recommended over the scaleUp window
+	// and down to the highest ones over the scaleDown window
+	desiredReplicas := max(currentReplicas, upRecommendation)
+	desiredReplicas = min(desiredReplicas, downRecommendation)
+
+	minimumAllowedReplicas, maximumAllowedReplicas := minReplicas, maxReplicas
+	switch {
+	case desiredReplicas > currentReplicas:
+		scaleUpLimit := max(getScaleUpLimit(currentReplicas, h.scaleUpEvents, scaleUp, now), currentReplicas)
+		maximumAllowedReplicas = min(scaleUpLimit, maxReplicas)
+	case desiredReplicas < currentReplicas:
+		scaleDownLimit := min(getScaleDownLimit(currentReplicas, h.scaleDownEvents, scaleDown, now), currentReplicas)
+		minimumAllowedReplicas = max(scaleDownLimit, minReplicas)
+	}
+	return max(min(desiredReplicas, maximumAllowedReplicas), minimumAllowedReplicas)
+}
+
+// recordScaling records the scaling of the target, the policies limit the replicas changed per period. The events
+// older than the longest period of the policies are forgotten.
+func (h *directScalingHistory) recordScaling(currentReplicas, desiredReplicas int32, now time.Time) {
+	cutoff := now.Add(-time.Duration(maxScalingPolicyPeriodSeconds) * time.Second)
+	switch {
+	case desiredReplicas > currentReplicas:
+		h.scaleUpEvents = append(pruneScalingEvents(h.scaleUpEvents, cutoff), timestampedReplicas{timestamp: now, replicas: desiredReplicas - currentReplicas})
+	case desiredReplicas < currentReplicas:
+		h.scaleDownEvents = append(pruneScalingEvents(h.scaleDownEvents, cutoff), timestampedReplicas{timestamp: now, replicas: currentReplicas - desiredReplicas})
+	}
+}
+
+func pruneScalingEvents(events []timestampedReplicas, cutoff time.Time) []timestampedReplicas {
+	pruned := events[:0]
+	for _, event := range events {
+		if event.timestamp.After(cutoff) {
+			pruned = append(pruned, event)
+		}
+	}
+	return pruned
+}
+
+// getReplicasChangePerPeriod returns the replicas changed by the events over the period ending now
+func getReplicasChangePerPeriod(periodSeconds int32, events []timestampedReplicas, now time.Time) int32 {
+	cutoff := now.Add(-time.Duration(periodSeconds) * time.Second)
+	replicas := int32(0)
+	for _, event := range events {
+		if event.timestamp.After(cutoff) {
+			replicas += event.replicas
+		}
+	}
+	return replicas
+}
+
+// getScaleUpLimit returns the maximum replicas the policies allow scaling up to
+func getScaleUpLimit(currentReplicas int32, events []timestampedReplicas, rules *autoscalingv2.HPAScalingRules, now time.Time) int32 {
+	if *rules.SelectPolicy == autoscalingv2.DisabledPolicySelect {
+		return currentReplicas
+	}
+	// Max selects the policy allowing the largest change
+	minChange := *rules.SelectPolicy == autoscalingv2.MinChangePolicySelect
+	limit := int32(math.MinInt32)
+	if minChange {
+		limit = math.MaxInt32
+	}
+	for _, policy := range rules.Policies {
+		periodStartReplicas := currentReplicas - getReplicasChangePerPeriod(policy.PeriodSeconds, events, now)
+		var policyLimit int32
+		switch policy.Type {
+		case autoscalingv2.PodsScalingPolicy:
+			policyLimit = periodStartReplicas + policy.Value
+		case autoscalingv2.PercentScalingPolicy:
+			policyLimit = int32(math.Ceil(float64(periodStartReplicas) * (1 + float64(policy.Value)/100)))
+		default:
+			continue
+		}
+		if minChange {
+			limit = min(limit, policyLimit)
+		} else {
+			limit = max(limit, policyLimit)
+		}
+	}
+	return limit
+}
+
+// getScaleDownLimit returns the minimum replicas the policies allow scaling down to
+func getScaleDownLimit(currentReplicas int32, events []timestampedReplicas, rules *autoscalingv2.HPAScalingRules, now time.Time) int32 {
+	if *rules.SelectPolicy == autoscalingv2.DisabledPolicySelect {
+		return currentReplicas
+	}
+	// Max selects the policy allowing the largest change
+	minChange := *rules.SelectPolicy == autoscalingv2.MinChangePolicySelect
+	limit := int32(math.MaxInt32)
+	if minChange {
+		limit = math.MinInt32
+	}
+	for _, policy := range rules.Policies {
+		periodStartReplicas := currentReplicas + getReplicasChangePerPeriod(policy.PeriodSeconds, events, now)
+		var policyLimit int32
+		switch policy.Type {
+		case autoscalingv2.PodsScalingPolicy:
+			policyLimit = periodStartReplicas - policy.Value
+		case autoscalingv2.PercentScalingPolicy:
+			policyLimit = int32(float64(periodStartReplicas) * (1 - float64(policy.Value)/100))
+		default:
+			continue
+		}
+		if minChange {
+			limit = max(limit, policyLimit)
+		} else {
+			limit = min(limit, policyLimit)
+		}
+	}
+	return limit
+}
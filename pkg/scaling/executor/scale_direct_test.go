@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestGetUsageReplicas(t *testing.T) {
+	// the replicas aren't changed within the tolerance
+	assert.Equal(t, int32(4), getUsageReplicas(4, 1.08))
+	assert.Equal(t, int32(4), getUsageReplicas(4, 0.92))
+
+	// otherwise they bring the usage back to the target
+	assert.Equal(t, int32(6), getUsageReplicas(4, 1.5))
+	assert.Equal(t, int32(2), getUsageReplicas(4, 0.4))
+}
+
+func TestGetDirectScalingRules(t *testing.T) {
+	// the defaults of the HPA are used without behavior
+	scaleUp, scaleDown := getDirectScalingRules(&v1alpha1.ScaledObject{})
+	assert.Equal(t, int32(0), *scaleUp.StabilizationWindowSeconds)
+	assert.Equal(t, autoscalingv2.MaxChangePolicySelect, *scaleUp.SelectPolicy)
+	assert.Len(t, scaleUp.Policies, 2)
+	assert.Equal(t, int32(300), *scaleDown.StabilizationWindowSeconds)
+	assert.Len(t, scaleDown.Policies, 1)
+
+	// and for the fields not set
+	window := int32(60)
+	scaledObject := &v1alpha1.ScaledObject{Spec: v1alpha1.ScaledObjectSpec{Advanced: &v1alpha1.AdvancedConfig{
+		HorizontalPodAutoscalerConfig: &v1alpha1.HorizontalPodAutoscalerConfig{Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+			ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: &window},
+		}},
+	}}}
+	_, scaleDown = getDirectScalingRules(scaledObject)
+	assert.Equal(t, int32(60), *scaleDown.StabilizationWindowSeconds)
+	assert.Equal(t, autoscalingv2.MaxChangePolicySelect, *scaleDown.SelectPolicy)
+	assert.Len(t, scaleDown.Policies, 1)
+	// the behavior of the ScaledObject isn't modified
+	assert.Nil(t, scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior.ScaleDown.SelectPolicy)
+}
+
+func TestDeleteDirectScalingHistory(t *testing.T) {
+	e := &scaleExecutor{directScalings: &sync.Map{}}
+	scaledObject := &v1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{UID: "uid-1"}}
+	other := &v1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{UID: "uid-2"}}
+
+	history := e.getDirectScalingHistory(scaledObject)
+	history.recordScaling(4, 6, time.Now())
+	assert.Same(t, history, e.getDirectScalingHistory(scaledObject))
+	e.getDirectScalingHistory(other)
+
+	// the history of a deleted ScaledObject is forgotten, not the ones of the others
+	e.DeleteScaledObject(scaledObject)
+	_, found := e.directScalings.Load("uid-1")
+	assert.False(t, found)
+	_, found = e.directScalings.Load("uid-2")
+	assert.True(t, found)
+	assert.Empty(t, e.getDirectScalingHistory(scaledObject).scaleUpEvents)
+}
+
+func TestDirectScalingHistoryStabilization(t *testing.T) {
+	scaleUp, scaleDown := getDirectScalingRules(&v1alpha1.ScaledObject{})
+	history := &directScalingHistory{}
+	now := time.Now()
+
+	// the target is scaled up right away
+	assert.Equal(t, int32(6), history.getDesiredReplicas(scaleUp, scaleDown, 4, 6, 1, 10, now))
+	history.recordScaling(4, 6, now)
+
+	// but down to the highest replicas recommended over the scaleDown window
+	assert.Equal(t, int32(6), history.getDesiredReplicas(scaleUp, scaleDown, 6, 3, 1, 10, now.Add(time.Minute)))
+	assert.Equal(t, int32(3), history.getDesiredReplicas(scaleUp, scaleDown, 6, 3, 1, 10, now.Add(6*time.Minute)))
+
+	// the replicas are kept within the replica counts
+	assert.Equal(t, int32(10), history.getDesiredReplicas(scaleUp, scaleDown, 12, 12, 1, 10, now.Add(7*time.Minute)))
+	assert.Equal(t, int32(2), (&directScalingHistory{}).getDesiredReplicas(scaleUp, scaleDown, 1, 1, 2, 10, now))
+}
+
+func TestDirectScalingHistoryPolicies(t *testing.T) {
+	window := int32(0)
+	selectMin := autoscalingv2.MinChangePolicySelect
+	disabled := autoscalingv2.DisabledPolicySelect
+	scaleUp := &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: &window, SelectPolicy: &selectMin, Policies: []autoscalingv2.HPAScalingPolicy{
+		{Type: autoscalingv2.PodsScalingPolicy, Value: 2, PeriodSeconds: 60},
+		{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 60},
+	}}
+	scaleDown := &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: &window, SelectPolicy: &disabled}
+	history := &directScalingHistory{}
+	now := time.Now()
+
+	// the policy allowing the smallest change is selected
+	assert.Equal(t, int32(6), history.getDesiredReplicas(scaleUp, scaleDown, 4, 20, 1, 30, now))
+	history.recordScaling(4, 6, now)
+
+	// the replicas added over the period are taken into account
+	assert.Equal(t, int32(6), history.getDesiredReplicas(scaleUp, scaleDown, 6, 20, 1, 30, now.Add(30*time.Second)))
+	assert.Equal(t, int32(8), history.getDesiredReplicas(scaleUp, scaleDown, 6, 20, 1, 30, now.Add(2*time.Minute)))
+
+	// the target isn't scaled down with the policies disabled
+	assert.Equal(t, int32(8), history.getDesiredReplicas(scaleUp, scaleDown, 8, 1, 1, 30, now.Add(3*time.Minute)))
+}
+
+func TestGetScaleDownLimit(t *testing.T) {
+	selectMax := autoscalingv2.MaxChangePolicySelect
+	rules := &autoscalingv2.HPAScalingRules{SelectPolicy: &selectMax, Policies: []autoscalingv2.HPAScalingPolicy{
+		{Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 60},
+		{Type: autoscalingv2.PercentScalingPolicy, Value: 50, PeriodSeconds: 60},
+	}}
+	now := time.Now()
+
+	// the policy allowing the largest change is selected
+	assert.Equal(t, int32(3), getScaleDownLimit(6, nil, rules, now))
+
+	// from the replicas at the start of the period
+	events := []timestampedReplicas{{timestamp: now.Add(-30 * time.Second), replicas: 4}, {timestamp: now.Add(-2 * time.Minute), replicas: 10}}
+	assert.Equal(t, int32(5), getScaleDownLimit(6, events, rules, now))
+}
@@ -19,6 +19,7 @@ package executor
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,6 +30,8 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/audit"
+	"github.com/kedacore/keda/v2/pkg/notification"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 )
 
@@ -39,13 +42,19 @@ const (
 
 // ScaleExecutor contains methods RequestJobScale and RequestScale
 type ScaleExecutor interface {
-	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, isError bool, scaleTo int64, maxScale int64)
+	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, isError bool, scaleTo int64, maxScale int64, options *ScaleExecutorOptions)
 	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, options *ScaleExecutorOptions)
+	DeleteScaledObject(scaledObject *kedav1alpha1.ScaledObject)
 }
 
-// ScaleExecutorOptions contains the optional parameters for the RequestScale method.
+// ScaleExecutorOptions contains the optional parameters for the RequestScale and RequestJobScale methods.
 type ScaleExecutorOptions struct {
 	ActiveTriggers []string
+	// UsageRatio is the highest ratio of the metrics of the resource triggers to their targets, the replicas of the
+	// ScaledObjects scaled directly are computed from it
+	UsageRatio *float64
+	// AuditInputs are the trigger values recorded to the audit log with the scaling decision
+	AuditInputs audit.Inputs
 }
 
 type scaleExecutor struct {
@@ -54,6 +63,11 @@ type scaleExecutor struct {
 	reconcilerScheme *runtime.Scheme
 	logger           logr.Logger
 	recorder         record.EventRecorder
+	// activations are the times the ScaledObjects scaled to zero have been activated at,
+	// until a replica of their scale target is ready
+	activations *sync.Map
+	// directScalings are the histories of the ScaledObjects scaled directly, without a HPA
+	directScalings *sync.Map
 }
 
 // NewScaleExecutor creates a ScaleExecutor object
@@ -64,9 +78,16 @@ func NewScaleExecutor(client runtimeclient.Client, scaleClient scale.ScalesGette
 		reconcilerScheme: reconcilerScheme,
 		logger:           logf.Log.WithName("scaleexecutor"),
 		recorder:         recorder,
+		activations:      &sync.Map{},
+		directScalings:   &sync.Map{},
 	}
 }
 
+// DeleteScaledObject forgets the scaling history of the deleted ScaledObject
+func (e *scaleExecutor) DeleteScaledObject(scaledObject *kedav1alpha1.ScaledObject) {
+	e.forgetDirectScaling(scaledObject)
+}
+
 func (e *scaleExecutor) updateLastActiveTime(ctx context.Context, logger logr.Logger, object interface{}) error {
 	now := metav1.Now()
 	transform := func(runtimeObj runtimeclient.Object, target interface{}) error {
@@ -131,3 +152,61 @@ func (e *scaleExecutor) setFallbackCondition(ctx context.Context, logger logr.Lo
 	}
 	return e.setCondition(ctx, logger, object, status, reason, message, fallback)
 }
+
+func newScaledObjectDecision(scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool, currentReplicas int32, options *ScaleExecutorOptions) *audit.Decision {
+	minReplicas := int32(0)
+	if scaledObject.Spec.MinReplicaCount != nil {
+		minReplicas = *scaledObject.Spec.MinReplicaCount
+	}
+	decision := &audit.Decision{
+		Kind:            "ScaledObject",
+		Namespace:       scaledObject.Namespace,
+		Name:            scaledObject.Name,
+		ScaleTarget:     scaledObject.Spec.ScaleTargetRef.Name,
+		IsActive:        isActive,
+		IsError:         isError,
+		MinReplicas:     int64(minReplicas),
+		MaxReplicas:     int64(scaledObject.GetHPAMaxReplicas()),
+		CurrentReplicas: int64(currentReplicas),
+		DesiredReplicas: int64(currentReplicas),
+		Action:          audit.ActionNone,
+	}
+	if options != nil {
+		decision.Inputs = options.AuditInputs
+	}
+	return decision
+}
+
+// notifyMaxReplicas notifies when the triggers are still active while the scale target
+// already runs the maximum replica count, the workload may not keep up with the load
+func notifyMaxReplicas(decision *audit.Decision) {
+	if !decision.IsActive || decision.MaxReplicas <= 0 || decision.CurrentReplicas < decision.MaxReplicas {
+		return
+	}
+	notification.Notify(&notification.Notification{
+		Reason:    notification.ReasonMaxReplicas,
+		Kind:      decision.Kind,
+		Namespace: decision.Namespace,
+		Name:      decision.Name,
+		Message:   fmt.Sprintf("The triggers are active while %d replicas are running, which is the maxReplicaCount", decision.CurrentReplicas),
+	})
+}
+
+func newScaledJobDecision(scaledJob *kedav1alpha1.ScaledJob, isActive bool, isError bool, runningJobCount int64, options *ScaleExecutorOptions) *audit.Decision {
+	decision := &audit.Decision{
+		Kind:            "ScaledJob",
+		Namespace:       scaledJob.Namespace,
+		Name:            scaledJob.Name,
+		IsActive:        isActive,
+		IsError:         isError,
+		MinReplicas:     scaledJob.MinReplicaCount(),
+		MaxReplicas:     scaledJob.MaxReplicaCount(),
+		CurrentReplicas: runningJobCount,
+		DesiredReplicas: runningJobCount,
+		Action:          audit.ActionNone,
+	}
+	if options != nil {
+		decision.Inputs = options.AuditInputs
+	}
+	return decision
+}
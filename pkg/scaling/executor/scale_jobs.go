@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/audit"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	version "github.com/kedacore/keda/v2/version"
 )
@@ -38,7 +39,7 @@ const (
 	defaultFailedJobsHistoryLimit     = int32(100)
 )
 
-func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive, isError bool, scaleTo int64, maxScale int64) {
+func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive, isError bool, scaleTo int64, maxScale int64, options *ScaleExecutorOptions) {
 	logger := e.logger.WithValues("scaledJob.Name", scaledJob.Name, "scaledJob.Namespace", scaledJob.Namespace)
 
 	runningJobCount := e.getRunningJobCount(ctx, scaledJob)
@@ -52,6 +53,7 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 		effectiveMaxScale = 0
 	}
 
+	decision := newScaledJobDecision(scaledJob, isActive, isError, runningJobCount, options)
 	if isActive {
 		logger.V(1).Info("At least one scaler is active")
 		now := metav1.Now()
@@ -60,10 +62,18 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 		if err != nil {
 			logger.Error(err, "Failed to update last active time")
 		}
-		e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale)
+		if createdJobs := e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale); createdJobs > 0 {
+			decision.Action = audit.ActionCreateJobs
+			decision.DesiredReplicas = runningJobCount + createdJobs
+		}
 	} else {
 		logger.V(1).Info("No change in activity")
 	}
+	if isError && decision.Action == audit.ActionNone {
+		decision.Action = audit.ActionTriggerError
+	}
+	audit.Record(decision)
+	notifyMaxReplicas(decision)
 
 	if isError {
 		// some triggers responded with error
@@ -113,10 +123,11 @@ func (e *scaleExecutor) getScalingDecision(scaledJob *kedav1alpha1.ScaledJob, ru
 	return effectiveMaxScale, scaleTo
 }
 
-func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, maxScale int64) {
+// createJobs returns the number of jobs requested to be created
+func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, maxScale int64) int64 {
 	if maxScale <= 0 {
 		logger.Info("No need to create jobs - all requested jobs already exist", "jobs", maxScale)
-		return
+		return 0
 	}
 	logger.Info("Creating jobs", "Effective number of max jobs", maxScale)
 	if scaleTo > maxScale {
@@ -134,6 +145,7 @@ func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scal
 
 	logger.Info("Created jobs", "Number of jobs", scaleTo)
 	e.recorder.Eventf(scaledJob, corev1.EventTypeNormal, eventreason.KEDAJobsCreated, "Created %d jobs", scaleTo)
+	return scaleTo
 }
 
 func (e *scaleExecutor) generateJobs(logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64) []*batchv1.Job {
@@ -461,10 +473,3 @@ type eagerScalingStrategy struct {
 func (s eagerScalingStrategy) GetEffectiveMaxScale(maxScale, runningJobCount, pendingJobCount, maxReplicaCount, _ int64) (int64, int64) {
 	return min(maxReplicaCount-runningJobCount-pendingJobCount, maxScale), maxReplicaCount
 }
-
-func min(x, y int64) int64 {
-	if x > y {
-		return y
-	}
-	return x
-}
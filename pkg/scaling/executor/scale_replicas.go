@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"math"
+
+	"github.com/go-logr/logr"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/audit"
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
+)
+
+// hpaTolerance is the default tolerance of the HPA, it doesn't scale while the ratio
+// of the metric to its target stays within it
+const hpaTolerance = 0.1
+
+// recordReplicas records the replicas desired by KEDA and by the HPA next to the ready replicas of the
+// scale target, so the scaling lag and the saturation against the maxReplicaCount can be monitored
+func (e *scaleExecutor) recordReplicas(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, decision *audit.Decision, readyReplicas int32) {
+	hpaDesiredReplicas := int32(-1)
+	var metricSpecs []autoscalingv2.MetricSpec
+	if scaledObject.Status.HpaName != "" {
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		err := e.client.Get(ctx, client.ObjectKey{Name: scaledObject.Status.HpaName, Namespace: scaledObject.Namespace}, hpa)
+		if err != nil {
+			logger.V(1).Info("Unable to get the HPA to record its desired replicas", "error", err)
+		} else {
+			hpaDesiredReplicas = hpa.Status.DesiredReplicas
+			metricSpecs = hpa.Spec.Metrics
+		}
+	}
+
+	metricscollector.RecordScaledObjectReplicas(scaledObject.Namespace, scaledObject.Name,
+		getDesiredReplicas(decision, metricSpecs), hpaDesiredReplicas, readyReplicas, int32(decision.MaxReplicas))
+}
+
+// getDesiredReplicas returns the replicas KEDA scales the target to, above the activation they are
+// computed from the metrics of the triggers and the targets of the HPA with the algorithm of the HPA.
+// It returns -1 when they can't be computed, eg. with only cpu and memory triggers.
+func getDesiredReplicas(decision *audit.Decision, metricSpecs []autoscalingv2.MetricSpec) int32 {
+	// KEDA scaled the target itself or the HPA doesn't run at zero replicas
+	if decision.DesiredReplicas != decision.CurrentReplicas || decision.CurrentReplicas == 0 {
+		return int32(decision.DesiredReplicas)
+	}
+
+	currentReplicas := float64(decision.CurrentReplicas)
+	desired := int64(-1)
+	for _, spec := range metricSpecs {
+		if spec.External == nil {
+			continue
+		}
+		value, found := getDecisionMetricValue(decision, spec.External.Metric.Name)
+		if !found {
+			continue
+		}
+
+		var usageRatio float64
+		target := spec.External.Target
+		switch {
+		case target.Type == autoscalingv2.AverageValueMetricType && target.AverageValue != nil && target.AverageValue.AsApproximateFloat64() > 0:
+			usageRatio = value / (target.AverageValue.AsApproximateFloat64() * currentReplicas)
+		case target.Type == autoscalingv2.ValueMetricType && target.Value != nil && target.Value.AsApproximateFloat64() > 0:
+			usageRatio = value / target.Value.AsApproximateFloat64()
+		default:
+			continue
+		}
+
+		replicas := decision.CurrentReplicas
+		if math.Abs(1.0-usageRatio) > hpaTolerance {
+			replicas = int64(math.Ceil(usageRatio * currentReplicas))
+		}
+		if replicas > desired {
+			desired = replicas
+		}
+	}
+	if desired < 0 {
+		return -1
+	}
+
+	minReplicas := decision.MinReplicas
+	if minReplicas < 1 {
+		minReplicas = 1
+	}
+	if desired < minReplicas {
+		desired = minReplicas
+	}
+	if decision.MaxReplicas > 0 && desired > decision.MaxReplicas {
+		desired = decision.MaxReplicas
+	}
+	return int32(desired)
+}
+
+// getDecisionMetricValue returns the value of the metric in the inputs of the decision,
+// the composite metric of the scaling modifiers is the result of the formula
+func getDecisionMetricValue(decision *audit.Decision, metricName string) (float64, bool) {
+	if metricName == kedav1alpha1.CompositeMetricName && decision.FormulaResult != nil {
+		return *decision.FormulaResult, true
+	}
+	for _, trigger := range decision.Triggers {
+		if value, found := trigger.Metrics[metricName]; found {
+			return value, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/audit"
+)
+
+func externalMetricSpec(name string, targetType autoscalingv2.MetricTargetType, target string) autoscalingv2.MetricSpec {
+	quantity := resource.MustParse(target)
+	spec := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: name},
+			Target: autoscalingv2.MetricTarget{Type: targetType},
+		},
+	}
+	if targetType == autoscalingv2.ValueMetricType {
+		spec.External.Target.Value = &quantity
+	} else {
+		spec.External.Target.AverageValue = &quantity
+	}
+	return spec
+}
+
+func TestGetDesiredReplicas(t *testing.T) {
+	formulaResult := 90.0
+	queue := []audit.TriggerInput{{Metrics: map[string]float64{"s0-queue": 50}}}
+
+	tests := []struct {
+		name        string
+		decision    audit.Decision
+		metricSpecs []autoscalingv2.MetricSpec
+		expected    int32
+	}{
+		{
+			name:     "scaled by KEDA",
+			decision: audit.Decision{CurrentReplicas: 0, DesiredReplicas: 1, MaxReplicas: 10},
+			expected: 1,
+		},
+		{
+			name:     "at zero replicas",
+			decision: audit.Decision{CurrentReplicas: 0, DesiredReplicas: 0, MaxReplicas: 10},
+			expected: 0,
+		},
+		{
+			name:        "average value",
+			decision:    audit.Decision{Inputs: audit.Inputs{Triggers: queue}, CurrentReplicas: 2, DesiredReplicas: 2, MaxReplicas: 10},
+			metricSpecs: []autoscalingv2.MetricSpec{externalMetricSpec("s0-queue", autoscalingv2.AverageValueMetricType, "5")},
+			expected:    10,
+		},
+		{
+			name:        "value",
+			decision:    audit.Decision{Inputs: audit.Inputs{Triggers: queue}, CurrentReplicas: 2, DesiredReplicas: 2, MaxReplicas: 10},
+			metricSpecs: []autoscalingv2.MetricSpec{externalMetricSpec("s0-queue", autoscalingv2.ValueMetricType, "20")},
+			expected:    5,
+		},
+		{
+			name:        "within the tolerance",
+			decision:    audit.Decision{Inputs: audit.Inputs{Triggers: queue}, CurrentReplicas: 5, DesiredReplicas: 5, MaxReplicas: 10},
+			metricSpecs: []autoscalingv2.MetricSpec{externalMetricSpec("s0-queue", autoscalingv2.AverageValueMetricType, "10.5")},
+			expected:    5,
+		},
+		{
+			name:        "capped to the max replicas",
+			decision:    audit.Decision{Inputs: audit.Inputs{Triggers: queue}, CurrentReplicas: 2, DesiredReplicas: 2, MaxReplicas: 4},
+			metricSpecs: []autoscalingv2.MetricSpec{externalMetricSpec("s0-queue", autoscalingv2.AverageValueMetricType, "1")},
+			expected:    4,
+		},
+		{
+			name:        "raised to the min replicas",
+			decision:    audit.Decision{Inputs: audit.Inputs{Triggers: queue}, CurrentReplicas: 4, DesiredReplicas: 4, MinReplicas: 3, MaxReplicas: 10},
+			metricSpecs: []autoscalingv2.MetricSpec{externalMetricSpec("s0-queue", autoscalingv2.AverageValueMetricType, "100")},
+			expected:    3,
+		},
+		{
+			name: "highest of the metrics",
+			decision: audit.Decision{Inputs: audit.Inputs{Triggers: []audit.TriggerInput{
+				{Metrics: map[string]float64{"s0-queue": 50}},
+				{Metrics: map[string]float64{"s1-lag": 70}},
+			}}, CurrentReplicas: 2, DesiredReplicas: 2, MaxReplicas: 10},
+			metricSpecs: []autoscalingv2.MetricSpec{
+				externalMetricSpec("s0-queue", autoscalingv2.AverageValueMetricType, "10"),
+				externalMetricSpec("s1-lag", autoscalingv2.AverageValueMetricType, "10"),
+			},
+			expected: 7,
+		},
+		{
+			name:        "scaling modifiers formula",
+			decision:    audit.Decision{Inputs: audit.Inputs{Triggers: queue, FormulaResult: &formulaResult}, CurrentReplicas: 2, DesiredReplicas: 2, MaxReplicas: 10},
+			metricSpecs: []autoscalingv2.MetricSpec{externalMetricSpec(v1alpha1.CompositeMetricName, autoscalingv2.AverageValueMetricType, "10")},
+			expected:    9,
+		},
+		{
+			name:        "resource metrics only",
+			decision:    audit.Decision{Inputs: audit.Inputs{Triggers: queue}, CurrentReplicas: 2, DesiredReplicas: 2, MaxReplicas: 10},
+			metricSpecs: []autoscalingv2.MetricSpec{{Type: autoscalingv2.ResourceMetricSourceType}},
+			expected:    -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, getDesiredReplicas(&test.decision, test.metricSpecs))
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/audit"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	kedastatus "github.com/kedacore/keda/v2/pkg/status"
 )
@@ -38,10 +39,15 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 	logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name,
 		"scaledObject.Namespace", scaledObject.Namespace,
 		"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
+	// the history of a ScaledObject scaled by its HPA again starts over once it's scaled directly
+	if !scaledObject.IsUsingDirectScaling() {
+		e.forgetDirectScaling(scaledObject)
+	}
 	// Get the current replica count. As a special case, Deployments and StatefulSets fetch directly from the object so they can use the informer cache
 	// to reduce API calls. Everything else uses the scale subresource.
 	var currentScale *autoscalingv1.Scale
 	var currentReplicas int32
+	var readyReplicas int32
 	targetName := scaledObject.Spec.ScaleTargetRef.Name
 	targetGVKR := scaledObject.Status.ScaleTargetGVKR
 	switch {
@@ -53,6 +59,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			return
 		}
 		currentReplicas = *deployment.Spec.Replicas
+		readyReplicas = deployment.Status.ReadyReplicas
 	case targetGVKR.Group == "apps" && targetGVKR.Kind == "StatefulSet":
 		statefulSet := &appsv1.StatefulSet{}
 		err := e.client.Get(ctx, client.ObjectKey{Name: targetName, Namespace: scaledObject.Namespace}, statefulSet)
@@ -61,6 +68,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			return
 		}
 		currentReplicas = *statefulSet.Spec.Replicas
+		readyReplicas = statefulSet.Status.ReadyReplicas
 	default:
 		var err error
 		currentScale, err = e.getScaleTargetScale(ctx, scaledObject)
@@ -69,6 +77,8 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			return
 		}
 		currentReplicas = currentScale.Spec.Replicas
+		// the scale subresource doesn't tell the readiness of the replicas
+		readyReplicas = currentScale.Status.Replicas
 	}
 	// if the ScaledObject's triggers aren't in the error state,
 	// but ScaledObject.Status.ReadyCondition is set not set to 'true' -> set it back to 'true'
@@ -90,6 +100,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		logger.Error(err, "error getting the paused replica count on the current ScaledObject.")
 		return
 	}
+	decision := newScaledObjectDecision(scaledObject, isActive, isError, currentReplicas, options)
 	status := scaledObject.Status.DeepCopy()
 	if pausedCount != nil {
 		// Scale the target to the paused replica count
@@ -113,6 +124,9 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			}
 			logger.Info("Successfully scaled target to paused replicas count", "paused replicas", *pausedCount)
 		}
+		decision.Action = audit.ActionPaused
+		decision.DesiredReplicas = int64(*pausedCount)
+		audit.Record(decision)
 		return
 	}
 
@@ -122,6 +136,8 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		minReplicas = *scaledObject.Spec.MinReplicaCount
 	}
 
+	e.trackActivation(scaledObject, isActive, currentReplicas, readyReplicas, time.Now())
+
 	if isActive {
 		switch {
 		case scaledObject.Spec.IdleReplicaCount != nil && currentReplicas < minReplicas,
@@ -135,25 +151,45 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// replica count is equal to 0
 
 			// Scale the ScaleTarget up
-			e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale, options.ActiveTriggers)
+			decision.Action = audit.ActionActivate
+			decision.DesiredReplicas = int64(e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale, options.ActiveTriggers))
 		case isError:
 			// some triggers are active, but some responded with error
 
 			// Set ScaledObject.Status.ReadyCondition to Unknown
 			msg := "Some triggers defined in ScaledObject are not working correctly"
 			logger.V(1).Info(msg)
+			decision.Action = audit.ActionTriggerError
 			if !readyCondition.IsUnknown() {
 				if err := e.setReadyCondition(ctx, logger, scaledObject, metav1.ConditionUnknown, "PartialTriggerError", msg); err != nil {
 					logger.Error(err, "error setting ready condition")
 				}
 			}
+		case scaledObject.IsUsingDirectScaling():
+			// triggers are active, and the replica count is computed by KEDA instead of the HPA
+			decision.Action = audit.ActionScaleDirectly
+			decision.UsageRatio = options.UsageRatio
+			if options.UsageRatio != nil {
+				decision.DesiredReplicas = int64(e.scaleDirectly(ctx, logger, scaledObject, currentScale, currentReplicas, *options.UsageRatio, time.Now()))
+			}
+
+			// update LastActiveTime to now
+			err := e.updateLastActiveTime(ctx, logger, scaledObject)
+			if err != nil {
+				logger.Error(err, "Error updating last active time")
+				audit.Record(decision)
+				return
+			}
 		default:
 			// triggers are active, but we didn't need to scale (replica count > 0)
+			decision.Action = audit.ActionDelegateToHPA
+			decision.HPA = scaledObject.Status.HpaName
 
 			// update LastActiveTime to now
 			err := e.updateLastActiveTime(ctx, logger, scaledObject)
 			if err != nil {
 				logger.Error(err, "Error updating last active time")
+				audit.Record(decision)
 				return
 			}
 		}
@@ -166,6 +202,8 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// there is a fallback replicas count defined
 
 			// Scale to the fallback replicas count
+			decision.Action = audit.ActionFallback
+			decision.DesiredReplicas = int64(scaledObject.Spec.Fallback.Replicas)
 			e.doFallbackScaling(ctx, scaledObject, currentScale, logger, currentReplicas)
 		case isError && scaledObject.Spec.Fallback == nil:
 			// there are no active triggers, but a scaler responded with an error
@@ -175,6 +213,7 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// Set ScaledObject.Status.ReadyCondition to false
 			msg := "Triggers defined in ScaledObject are not working correctly"
 			logger.V(1).Info(msg)
+			decision.Action = audit.ActionTriggerError
 			if !readyCondition.IsFalse() {
 				if err := e.setReadyCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "TriggerError", msg); err != nil {
 					logger.Error(err, "error setting ready condition")
@@ -191,7 +230,10 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// there is no minimum configured or minimum is set to ZERO
 
 			// Try to scale the deployment down, HPA will handle other scale in operations
-			e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale)
+			if scaleToReplicas, deactivated := e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale); deactivated {
+				decision.Action = audit.ActionDeactivate
+				decision.DesiredReplicas = int64(scaleToReplicas)
+			}
 		case currentReplicas < minReplicas && scaledObject.Spec.IdleReplicaCount == nil:
 			// there are no active triggers
 			// AND
@@ -200,6 +242,8 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// Idle Replicas mode is disabled
 
 			// ScaleTarget replicas count to correct value
+			decision.Action = audit.ActionScaleToMinReplica
+			decision.DesiredReplicas = int64(*scaledObject.Spec.MinReplicaCount)
 			_, err := e.updateScaleOnScaleTarget(ctx, scaledObject, currentScale, *scaledObject.Spec.MinReplicaCount)
 			if err == nil {
 				logger.Info("Successfully set ScaleTarget replicas count to ScaledObject minReplicaCount",
@@ -213,6 +257,9 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			logger.V(1).Info("ScaleTarget no change")
 		}
 	}
+	audit.Record(decision)
+	notifyMaxReplicas(decision)
+	e.recordReplicas(ctx, logger, scaledObject, decision, readyReplicas)
 
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
 	if condition.IsUnknown() || condition.IsTrue() != isActive {
@@ -244,7 +291,8 @@ func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *ked
 
 // An object will be scaled down to 0 only if it's passed its cooldown period
 // or if LastActiveTime is nil
-func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) {
+// It returns the replicas count the ScaleTarget is scaled to, and whether it has passed its cooldown period
+func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) (int32, bool) {
 	var cooldownPeriod time.Duration
 
 	if scaledObject.Spec.CooldownPeriod != nil {
@@ -277,32 +325,33 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 			}
 			logger.Info(msg, "Original Replicas Count", currentReplicas, "New Replicas Count", scaleToReplicas)
 
-			e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetDeactivated,
+			e.recorder.AnnotatedEventf(scaledObject, replicasAnnotations(currentReplicas, scaleToReplicas), corev1.EventTypeNormal, eventreason.KEDAScaleTargetDeactivated,
 				"Deactivated %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, scaleToReplicas)
 			if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "ScalerNotActive", "Scaling is not performed because triggers are not active"); err != nil {
 				logger.Error(err, "Error in setting active condition")
-				return
 			}
 		} else {
 			e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetDeactivationFailed,
 				"Failed to deactivated %s %s/%s", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, scaleToReplicas)
 		}
-	} else {
-		logger.V(1).Info("ScaleTarget cooling down",
-			"LastActiveTime", scaledObject.Status.LastActiveTime,
-			"CoolDownPeriod", cooldownPeriod)
+		return scaleToReplicas, true
+	}
 
-		activeCondition := scaledObject.Status.Conditions.GetActiveCondition()
-		if !activeCondition.IsFalse() || activeCondition.Reason != "ScalerCooldown" {
-			if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "ScalerCooldown", "Scaler cooling down because triggers are not active"); err != nil {
-				logger.Error(err, "Error in setting active condition")
-				return
-			}
+	logger.V(1).Info("ScaleTarget cooling down",
+		"LastActiveTime", scaledObject.Status.LastActiveTime,
+		"CoolDownPeriod", cooldownPeriod)
+
+	activeCondition := scaledObject.Status.Conditions.GetActiveCondition()
+	if !activeCondition.IsFalse() || activeCondition.Reason != "ScalerCooldown" {
+		if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "ScalerCooldown", "Scaler cooling down because triggers are not active"); err != nil {
+			logger.Error(err, "Error in setting active condition")
 		}
 	}
+	return 0, false
 }
 
-func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, activeTriggers []string) {
+// It returns the replicas count the ScaleTarget is scaled to
+func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale, activeTriggers []string) int32 {
 	var replicas int32
 	if scaledObject.Spec.MinReplicaCount != nil && *scaledObject.Spec.MinReplicaCount > 0 {
 		replicas = *scaledObject.Spec.MinReplicaCount
@@ -316,16 +365,24 @@ func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Log
 		logger.Info("Successfully updated ScaleTarget",
 			"Original Replicas Count", currentReplicas,
 			"New Replicas Count", replicas)
-		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s %s/%s from %d to %d, triggered by %s", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas, strings.Join(activeTriggers, ";"))
+		e.recorder.AnnotatedEventf(scaledObject, replicasAnnotations(currentReplicas, replicas), corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s %s/%s from %d to %d, triggered by %s", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas, strings.Join(activeTriggers, ";"))
 
 		// Scale was successful. Update lastScaleTime and lastActiveTime on the scaledObject
 		if err := e.updateLastActiveTime(ctx, logger, scaledObject); err != nil {
 			logger.Error(err, "Error in Updating lastScaleTime and lastActiveTime on the scaledObject")
-			return
 		}
 	} else {
 		e.recorder.Eventf(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScaleTargetActivationFailed, "Failed to scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
 	}
+	return replicas
+}
+
+// replicasAnnotations describes the scaling of the scale target in the annotations of its events
+func replicasAnnotations(currentReplicas, desiredReplicas int32) map[string]string {
+	return map[string]string{
+		eventreason.CurrentReplicasAnnotation: strconv.Itoa(int(currentReplicas)),
+		eventreason.DesiredReplicasAnnotation: strconv.Itoa(int(desiredReplicas)),
+	}
 }
 
 func (e *scaleExecutor) getScaleTargetScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (*autoscalingv1.Scale, error) {
@@ -520,5 +520,5 @@ func TestEventWitTriggerInfo(t *testing.T) {
 	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false, &ScaleExecutorOptions{ActiveTriggers: []string{"testTrigger"}})
 
 	eventstring := <-recorder.Events
-	assert.Equal(t, "Normal KEDAScaleTargetActivated Scaled  namespace/name from 2 to 5, triggered by testTrigger", eventstring)
+	assert.Equal(t, "Normal KEDAScaleTargetActivated Scaled  namespace/name from 2 to 5, triggered by testTrigger map[keda.sh/current-replicas:2 keda.sh/desired-replicas:5]", eventstring)
 }
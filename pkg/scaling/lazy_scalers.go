@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+var (
+	lazyScalersMinPollingInterval     time.Duration
+	lazyScalersMinPollingIntervalLock sync.RWMutex
+)
+
+// ConfigureLazyScalers enables the lazy scalers for the scale handlers created afterwards: the scalers of the
+// inactive scalable objects polled at least every minPollingInterval are built only for their evaluations and
+// closed afterwards, returning their connections, until they're active. Zero disables them.
+func ConfigureLazyScalers(minPollingInterval time.Duration) error {
+	if minPollingInterval < 0 {
+		return fmt.Errorf("the minimum polling interval of the lazy scalers must not be negative, got %v", minPollingInterval)
+	}
+	lazyScalersMinPollingIntervalLock.Lock()
+	defer lazyScalersMinPollingIntervalLock.Unlock()
+	lazyScalersMinPollingInterval = minPollingInterval
+	return nil
+}
+
+func getLazyScalersMinPollingInterval() time.Duration {
+	lazyScalersMinPollingIntervalLock.RLock()
+	defer lazyScalersMinPollingIntervalLock.RUnlock()
+	return lazyScalersMinPollingInterval
+}
+
+// isLazy returns whether the scalers of the scalable object are closed while it's inactive. The ScaledObjects keeping
+// replicas while inactive aren't, as the HPA still requests their metrics, neither are the ones with push scalers.
+func (h *scaleHandler) isLazy(scalableObject interface{}, pollingInterval time.Duration) bool {
+	if h.lazyMinPollingInterval <= 0 || pollingInterval < h.lazyMinPollingInterval {
+		return false
+	}
+	var triggers []kedav1alpha1.ScaleTriggers
+	switch obj := scalableObject.(type) {
+	case *kedav1alpha1.ScaledObject:
+		if obj.Spec.MinReplicaCount != nil && *obj.Spec.MinReplicaCount > 0 {
+			return false
+		}
+		triggers = obj.Spec.Triggers
+	case *kedav1alpha1.ScaledJob:
+		triggers = obj.Spec.Triggers
+	default:
+		return false
+	}
+	for _, trigger := range triggers {
+		if trigger.Type == "external-push" {
+			return false
+		}
+	}
+	return true
+}
+
+// releaseScalersCache closes the scalers of the scalable object, they're built again on its next evaluation. The
+// scalers keeping state across their evaluations aren't released, it would be lost.
+func (h *scaleHandler) releaseScalersCache(ctx context.Context, key string, logger logr.Logger) {
+	h.scalerCachesLock.Lock()
+	cache, ok := h.scalerCaches[key]
+	if ok && isStateful(cache) {
+		h.scalerCachesLock.Unlock()
+		return
+	}
+	if ok {
+		delete(h.scalerCaches, key)
+		h.scalerCachesLRU.remove(key)
+		h.metricsSnapshots.delete(key)
+	}
+	h.scalerCachesLock.Unlock()
+
+	if ok {
+		logger.V(1).Info("Releasing the scalers of the inactive scalable object until its next evaluation")
+		cache.Close(ctx)
+	}
+}
+
+// isStateful returns whether any of the scalers of the cache keeps state across its evaluations
+func isStateful(scalersCache *cache.ScalersCache) bool {
+	for _, builder := range scalersCache.Scalers {
+		if stateful, ok := builder.Scaler.(scalers.StatefulScaler); ok && stateful.IsStateful() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"k8s.io/utils/ptr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+func TestIsLazy(t *testing.T) {
+	h := &scaleHandler{lazyMinPollingInterval: 5 * time.Minute}
+	scaledObject := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{{Type: "kafka"}},
+		},
+	}
+
+	assert.True(t, h.isLazy(scaledObject, 10*time.Minute))
+	assert.False(t, h.isLazy(scaledObject, time.Minute))
+	assert.True(t, h.isLazy(&kedav1alpha1.ScaledJob{}, 5*time.Minute))
+
+	// the HPA still requests the metrics of the ScaledObjects keeping replicas
+	withReplicas := scaledObject.DeepCopy()
+	withReplicas.Spec.MinReplicaCount = ptr.To[int32](1)
+	assert.False(t, h.isLazy(withReplicas, 10*time.Minute))
+
+	// the push scalers run as long as the scale loop
+	withPushScaler := scaledObject.DeepCopy()
+	withPushScaler.Spec.Triggers = append(withPushScaler.Spec.Triggers, kedav1alpha1.ScaleTriggers{Type: "external-push"})
+	assert.False(t, h.isLazy(withPushScaler, 10*time.Minute))
+
+	disabled := &scaleHandler{}
+	assert.False(t, disabled.isLazy(scaledObject, 10*time.Minute))
+
+	assert.Error(t, ConfigureLazyScalers(-time.Minute))
+	assert.NoError(t, ConfigureLazyScalers(0))
+}
+
+func TestReleaseScalersCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	scaler.EXPECT().Close(gomock.Any()).Return(nil)
+
+	key := "scaledobject.default.worker"
+	h := &scaleHandler{
+		scalerCaches:     map[string]*cache.ScalersCache{key: {Scalers: []cache.ScalerBuilder{{Scaler: scaler}}}},
+		scalerCachesLock: &sync.RWMutex{},
+		scalerCachesLRU:  newScalersCacheLRU(10),
+		metricsSnapshots: newMetricsSnapshots(),
+	}
+	h.scalerCachesLRU.add(key, 1)
+
+	h.releaseScalersCache(context.Background(), key, logr.Discard())
+	assert.Empty(t, h.scalerCaches)
+	assert.Equal(t, 0, h.scalerCachesLRU.triggers)
+
+	// the scalers released aren't counted as rebuilt once evaluated again
+	_, rebuilt := h.scalerCachesLRU.add(key, 1)
+	assert.False(t, rebuilt)
+
+	// the scale loop may release scalers already cleared
+	h.releaseScalersCache(context.Background(), "scaledobject.default.other", logr.Discard())
+}
+
+type testStatefulScaler struct {
+	*mock_scalers.MockScaler
+	stateful bool
+}
+
+func (s *testStatefulScaler) IsStateful() bool {
+	return s.stateful
+}
+
+func TestReleaseScalersCacheStateful(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	stateless := mock_scalers.NewMockScaler(ctrl)
+	stateful := &testStatefulScaler{MockScaler: mock_scalers.NewMockScaler(ctrl), stateful: true}
+
+	key := "scaledobject.default.envoy"
+	h := &scaleHandler{
+		scalerCaches:     map[string]*cache.ScalersCache{key: {Scalers: []cache.ScalerBuilder{{Scaler: stateless}, {Scaler: stateful}}}},
+		scalerCachesLock: &sync.RWMutex{},
+		scalerCachesLRU:  newScalersCacheLRU(10),
+		metricsSnapshots: newMetricsSnapshots(),
+	}
+	h.scalerCachesLRU.add(key, 2)
+
+	// the state of the previous evaluations would be lost
+	h.releaseScalersCache(context.Background(), key, logr.Discard())
+	assert.Len(t, h.scalerCaches, 1)
+
+	// the scalers configured without state are released
+	stateful.stateful = false
+	stateless.EXPECT().Close(gomock.Any()).Return(nil)
+	stateful.EXPECT().Close(gomock.Any()).Return(nil)
+	h.releaseScalersCache(context.Background(), key, logr.Discard())
+	assert.Empty(t, h.scalerCaches)
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/metricscollector"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+	"github.com/kedacore/keda/v2/pkg/scaling/concurrency"
+	"github.com/kedacore/keda/v2/pkg/scaling/modifiers"
+)
+
+const (
+	// metricsSnapshotTTL is how long the metrics of a ScaledObject evaluated for a request of the HPA are served to
+	// its next requests, the HPA requests the metrics of the triggers one by one on each of its syncs
+	metricsSnapshotTTL = time.Second
+	// metricsSnapshotTimeout bounds the evaluation of a snapshot, it's shared by the requests so it doesn't
+	// end with the request it was taken for
+	metricsSnapshotTimeout = time.Minute
+)
+
+// metricResult is the value of a metric of a trigger
+type metricResult struct {
+	metrics           []external_metrics.ExternalMetricValue
+	metricTriggerPair map[string]string
+	metricName        string
+	triggerName       string
+	triggerIndex      int
+	metricSpec        v2.MetricSpec
+	err               error
+}
+
+// metricsSnapshot are the values of all the external metrics of the triggers of a ScaledObject, evaluated at once
+type metricsSnapshot struct {
+	// cache are the scalers the metrics are evaluated from, the snapshot is taken again once they're rebuilt
+	cache *cache.ScalersCache
+	// taken is when the evaluation of the metrics completed, it's zero while they're evaluated
+	taken time.Time
+	// ready is closed once the metrics are evaluated
+	ready   chan struct{}
+	results []metricResult
+	// isScalerError is whether the metric specs of a scaler couldn't be read
+	isScalerError bool
+}
+
+// metricsSnapshots are the last snapshots of the metrics of the ScaledObjects, by identifier
+type metricsSnapshots struct {
+	lock      sync.Mutex
+	snapshots map[string]*metricsSnapshot
+}
+
+func newMetricsSnapshots() *metricsSnapshots {
+	return &metricsSnapshots{snapshots: map[string]*metricsSnapshot{}}
+}
+
+// get returns the snapshot of the metrics of the ScaledObject, it's taken by the function if the last one expired
+// or was taken from other scalers. The requests arriving while it's taken wait for it. The snapshot is taken
+// under a context of its own, detached from the request it's taken for, so a request cancelled doesn't fail
+// the others waiting for it.
+func (s *metricsSnapshots) get(ctx context.Context, key string, scalersCache *cache.ScalersCache, take func(ctx context.Context) ([]metricResult, bool)) (*metricsSnapshot, error) {
+	if s == nil {
+		results, isScalerError := take(ctx)
+		return &metricsSnapshot{cache: scalersCache, results: results, isScalerError: isScalerError}, nil
+	}
+
+	s.lock.Lock()
+	snapshot, found := s.snapshots[key]
+	if found && snapshot.cache == scalersCache && (snapshot.taken.IsZero() || time.Since(snapshot.taken) < metricsSnapshotTTL) {
+		s.lock.Unlock()
+		return snapshot.wait(ctx)
+	}
+	snapshot = &metricsSnapshot{
+		cache: scalersCache,
+		ready: make(chan struct{}),
+	}
+	s.snapshots[key] = snapshot
+	s.lock.Unlock()
+
+	// the scalers aren't closed while the snapshot is taken, even once the request is done
+	release := scalersCache.Acquire()
+	go func() {
+		defer release()
+		takeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), metricsSnapshotTimeout)
+		defer cancel()
+		snapshot.results, snapshot.isScalerError = take(takeCtx)
+		s.lock.Lock()
+		snapshot.taken = time.Now()
+		s.lock.Unlock()
+		close(snapshot.ready)
+	}()
+	return snapshot.wait(ctx)
+}
+
+// wait returns the snapshot once its metrics are evaluated, unless the request is cancelled first
+func (snapshot *metricsSnapshot) wait(ctx context.Context) (*metricsSnapshot, error) {
+	select {
+	case <-snapshot.ready:
+		return snapshot, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// delete forgets the snapshot of the metrics of the ScaledObject
+func (s *metricsSnapshots) delete(key string) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.snapshots, key)
+}
+
+// takeMetricsSnapshot evaluates all the external metrics of the triggers of the ScaledObject in parallel,
+// the second return value is whether the metric specs of a scaler couldn't be read
+func (h *scaleHandler) takeMetricsSnapshot(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, cache *cache.ScalersCache, logger logr.Logger) ([]metricResult, bool) {
+	isScalerError := false
+	scaledObjectIdentifier := scaledObject.GenerateIdentifier()
+
+	allScalers, scalerConfigs := cache.GetScalers()
+	var results []metricResult
+	var resultsLock sync.Mutex
+	wg := sync.WaitGroup{}
+	for triggerIndex := 0; triggerIndex < len(allScalers); triggerIndex++ {
+		triggerName := strings.Replace(fmt.Sprintf("%T", allScalers[triggerIndex]), "*scalers.", "", 1)
+		if scalerConfigs[triggerIndex].TriggerName != "" {
+			triggerName = scalerConfigs[triggerIndex].TriggerName
+		}
+
+		metricSpecs, err := cache.GetMetricSpecForScalingForScaler(ctx, triggerIndex)
+		if err != nil {
+			isScalerError = true
+			logger.Error(err, "error getting metric spec for the scaler", "scaler", triggerName)
+			cache.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+		}
+
+		for _, spec := range metricSpecs {
+			// skip cpu/memory resource scaler
+			if spec.External == nil {
+				continue
+			}
+			wg.Add(1)
+			go func(triggerIndex int, triggerName string, scalerConfig scalersconfig.ScalerConfig, spec v2.MetricSpec) {
+				defer wg.Done()
+				metricName := spec.External.Metric.Name
+				result := metricResult{
+					metricName:   metricName,
+					triggerName:  triggerName,
+					triggerIndex: triggerIndex,
+					metricSpec:   spec,
+				}
+
+				// Pair metric values with their trigger names. This is applied only when
+				// ScalingModifiers.Formula is defined in SO.
+				var err error
+				result.metricTriggerPair, err = modifiers.GetPairTriggerAndMetric(scaledObject, metricName, scalerConfig.TriggerName)
+				if err != nil {
+					logger.Error(err, "error pairing triggers & metrics for compositeScaler")
+				}
+
+				// if cache is defined for this scaler/metric, let's try to hit it first
+				metricsFoundInCache := false
+				if scalerConfig.TriggerUseCachedMetrics {
+					var metricsRecord metricscache.MetricsRecord
+					if metricsRecord, metricsFoundInCache = h.scaledObjectsMetricCache.ReadRecord(scaledObjectIdentifier, metricName); metricsFoundInCache {
+						logger.V(1).Info("Reading metrics from cache", "scaler", triggerName, "metricName", metricName, "metricsRecord", metricsRecord)
+						result.metrics = metricsRecord.Metric
+						result.err = metricsRecord.ScalerError
+					}
+					metricscollector.RecordScalerCacheHit(scaledObject.Namespace, scaledObject.Name, scalerConfig.TriggerType, triggerIndex, true, metricsFoundInCache)
+				}
+
+				if !metricsFoundInCache {
+					var release func()
+					if release, result.err = concurrency.Acquire(ctx, scalerConfig.TriggerType); result.err == nil {
+						var latency time.Duration
+						result.metrics, _, latency, result.err = cache.GetMetricsAndActivityForScaler(ctx, triggerIndex, metricName)
+						release()
+						if latency != -1 {
+							metricscollector.RecordScalerLatency(scaledObject.Namespace, scaledObject.Name, triggerName, triggerIndex, metricName, true, latency)
+						}
+						metricscollector.RecordScalerCall(scaledObject.Namespace, scaledObject.Name, scalerConfig.TriggerType, triggerIndex, true, latency, result.err)
+					}
+					logger.V(1).Info("Getting metrics from trigger", "trigger", triggerName, "metricName", metricName, "metrics", result.metrics, "scalerError", result.err)
+				}
+
+				resultsLock.Lock()
+				results = append(results, result)
+				resultsLock.Unlock()
+			}(triggerIndex, triggerName, scalerConfigs[triggerIndex], spec)
+		}
+	}
+	wg.Wait()
+	return results, isScalerError
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+func TestMetricsSnapshotsGet(t *testing.T) {
+	snapshots := newMetricsSnapshots()
+	scalersCache := &cache.ScalersCache{}
+	release := make(chan struct{})
+	takes := 0
+	take := func(ctx context.Context) ([]metricResult, bool) {
+		takes++
+		<-release
+		// the snapshot isn't evaluated under the context of the request it's taken for
+		assert.NoError(t, ctx.Err())
+		return []metricResult{{metricName: "metric"}}, false
+	}
+
+	// the request the snapshot is taken for is cancelled, the snapshot is still taken for the next ones
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := snapshots.get(ctx, "key", scalersCache, take)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	waited := make(chan *metricsSnapshot)
+	go func() {
+		snapshot, err := snapshots.get(context.Background(), "key", scalersCache, take)
+		assert.NoError(t, err)
+		waited <- snapshot
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	snapshot := <-waited
+	assert.Len(t, snapshot.results, 1)
+	assert.Equal(t, 1, takes)
+
+	// the snapshot expires from the completion of its evaluation
+	snapshots.lock.Lock()
+	taken := snapshot.taken
+	snapshots.lock.Unlock()
+	assert.False(t, taken.IsZero())
+	_, err = snapshots.get(context.Background(), "key", scalersCache, take)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, takes)
+}
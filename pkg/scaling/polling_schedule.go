@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// maxPollingJitter is the maximum jitter of the polling ticks, as a fraction of their pollingInterval
+const maxPollingJitter = 0.5
+
+// goldenRatioConjugate spreads the phases of the scalable objects of a host evenly whatever their number
+var goldenRatioConjugate = (math.Sqrt(5) - 1) / 2
+
+// pollingHostMetadataKeys are the metadata of the triggers which the backend host is read from, in order.
+// The hosts referenced from the authentication parameters aren't known without resolving them.
+var pollingHostMetadataKeys = []string{"host", "hosts", "serverAddress", "address", "addresses", "bootstrapServers", "brokerAddress", "brokerList", "endpoint", "url"}
+
+// PollingScheduleOptions are the options of the polling schedule of the scale loops
+type PollingScheduleOptions struct {
+	// Spread spreads the polling ticks of the scalable objects over their pollingInterval instead of ticking
+	// from the time their scale loop started, so the scale loops started at once don't keep polling at once
+	Spread bool
+	// SpreadPerHost spreads the polling ticks of the scalable objects evenly among the ones of the same backend host
+	SpreadPerHost bool
+	// Jitter is the maximum random delay or advance of each polling tick, as a fraction of the pollingInterval,
+	// it's deterministic for a scalable object and a tick
+	Jitter float64
+}
+
+var (
+	pollingScheduleOptions     PollingScheduleOptions
+	pollingScheduleOptionsLock sync.RWMutex
+)
+
+// ConfigurePollingSchedule sets the polling schedule of the scale loops of the scale handlers created afterwards
+func ConfigurePollingSchedule(opts PollingScheduleOptions) error {
+	if opts.Jitter < 0 || opts.Jitter > maxPollingJitter {
+		return fmt.Errorf("the polling jitter must be between 0 and %v, got %v", maxPollingJitter, opts.Jitter)
+	}
+	pollingScheduleOptionsLock.Lock()
+	defer pollingScheduleOptionsLock.Unlock()
+	pollingScheduleOptions = opts
+	return nil
+}
+
+func getPollingScheduleOptions() PollingScheduleOptions {
+	pollingScheduleOptionsLock.RLock()
+	defer pollingScheduleOptionsLock.RUnlock()
+	return pollingScheduleOptions
+}
+
+// pollingSchedules are the polling schedules of the scale loops, the scale loops of the scalable objects of a host
+// are assigned distinct slots which their phases are spread from
+type pollingSchedules struct {
+	opts PollingScheduleOptions
+
+	lock sync.Mutex
+	// hostSlots are the slots taken by the scale loops of each host
+	hostSlots map[string]map[int]bool
+}
+
+// pollingSchedule is the polling schedule of a scale loop
+type pollingSchedule struct {
+	key    string
+	jitter float64
+	// phase is the offset of the ticks in their pollingInterval, as a fraction of it, it's negative if they aren't spread
+	phase float64
+}
+
+// newPollingSchedules returns the polling schedules of the options, they're nil, and so tick from the time
+// the scale loops started, if neither spread nor jitter is enabled
+func newPollingSchedules(opts PollingScheduleOptions) *pollingSchedules {
+	if !opts.Spread && !opts.SpreadPerHost && opts.Jitter == 0 {
+		return nil
+	}
+	return &pollingSchedules{
+		opts:      opts,
+		hostSlots: map[string]map[int]bool{},
+	}
+}
+
+// start returns the polling schedule of the scale loop of the scalable object, the function returned releases
+// its slot once the scale loop stops
+func (s *pollingSchedules) start(withTriggers *kedav1alpha1.WithTriggers) (*pollingSchedule, func()) {
+	if s == nil {
+		return nil, func() {}
+	}
+	key := withTriggers.GenerateIdentifier()
+	schedule := &pollingSchedule{key: key, jitter: s.opts.Jitter, phase: -1}
+	if !s.opts.Spread && !s.opts.SpreadPerHost {
+		return schedule, func() {}
+	}
+
+	host := ""
+	if s.opts.SpreadPerHost {
+		host = getTriggersHost(withTriggers.Spec.Triggers)
+	}
+	if host == "" {
+		schedule.phase = hashFraction(key)
+		return schedule, func() {}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	slots, found := s.hostSlots[host]
+	if !found {
+		slots = map[int]bool{}
+		s.hostSlots[host] = slots
+	}
+	slot := 0
+	for slots[slot] {
+		slot++
+	}
+	slots[slot] = true
+	_, schedule.phase = math.Modf(hashFraction(host) + float64(slot)*goldenRatioConjugate)
+
+	return schedule, func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		delete(slots, slot)
+		if len(slots) == 0 {
+			delete(s.hostSlots, host)
+		}
+	}
+}
+
+// next returns the wait from now until the next polling tick. Without schedule it's the pollingInterval, the ticks
+// spread are aligned on their phase and never closer than half of the pollingInterval from now, before the jitter.
+func (s *pollingSchedule) next(now time.Time, pollingInterval time.Duration) time.Duration {
+	if s == nil || pollingInterval <= 0 {
+		return pollingInterval
+	}
+	wait := pollingInterval
+	if s.phase >= 0 {
+		phase := time.Duration(s.phase * float64(pollingInterval))
+		elapsed := time.Duration((now.UnixNano() - int64(phase)) % int64(pollingInterval))
+		if elapsed < 0 {
+			elapsed += pollingInterval
+		}
+		wait = pollingInterval - elapsed
+		if wait < pollingInterval/2 {
+			wait += pollingInterval
+		}
+	}
+	if s.jitter > 0 {
+		tick := now.Add(wait).UnixNano() / int64(pollingInterval)
+		wait += time.Duration((2*hashFraction(s.key, strconv.FormatInt(tick, 10)) - 1) * s.jitter * float64(pollingInterval))
+	}
+	return wait
+}
+
+// getTriggersHost returns the backend host of the first trigger whose metadata references one, or empty
+func getTriggersHost(triggers []kedav1alpha1.ScaleTriggers) string {
+	for _, trigger := range triggers {
+		for _, metadataKey := range pollingHostMetadataKeys {
+			if host := parseHost(trigger.Metadata[metadataKey]); host != "" {
+				return host
+			}
+		}
+	}
+	return ""
+}
+
+// parseHost returns the host of the first address of a list of urls or host:port addresses, or empty
+func parseHost(addresses string) string {
+	address := strings.TrimSpace(strings.Split(addresses, ",")[0])
+	if address == "" {
+		return ""
+	}
+	if strings.Contains(address, "://") {
+		if u, err := url.Parse(address); err == nil {
+			return strings.ToLower(u.Hostname())
+		}
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return strings.ToLower(host)
+	}
+	return strings.ToLower(address)
+}
+
+// hashFraction returns a deterministic fraction in [0, 1) of the parts
+func hashFraction(parts ...string) float64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(strings.Join(parts, "/")))
+	return float64(hash.Sum64()>>11) / (1 << 53)
+}
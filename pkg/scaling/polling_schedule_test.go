@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestPollingScheduleDisabled(t *testing.T) {
+	schedules := newPollingSchedules(PollingScheduleOptions{})
+	assert.Nil(t, schedules)
+
+	schedule, stop := schedules.start(testPollingWithTriggers("worker", "kafka:9092"))
+	defer stop()
+	assert.Equal(t, 30*time.Second, schedule.next(time.Now(), 30*time.Second))
+}
+
+func TestPollingScheduleSpread(t *testing.T) {
+	schedules := newPollingSchedules(PollingScheduleOptions{Spread: true})
+	interval := 30 * time.Second
+	now := time.Unix(1700000000, 0)
+
+	a, stopA := schedules.start(testPollingWithTriggers("a", "kafka:9092"))
+	defer stopA()
+	b, stopB := schedules.start(testPollingWithTriggers("b", "kafka:9092"))
+	defer stopB()
+
+	// the ticks are aligned on the phase of the scalable object, whenever its scale loop started
+	tickA := now.Add(a.next(now, interval))
+	assert.Equal(t, tickA, now.Add(7*time.Second).Add(a.next(now.Add(7*time.Second), interval)))
+	assert.Equal(t, tickA.Add(interval), tickA.Add(a.next(tickA, interval)))
+	assert.GreaterOrEqual(t, tickA.Sub(now), interval/2)
+	assert.Less(t, tickA.Sub(now), interval+interval/2)
+
+	// the phases are deterministic and differ between scalable objects
+	again, stopAgain := newPollingSchedules(PollingScheduleOptions{Spread: true}).start(testPollingWithTriggers("a", "kafka:9092"))
+	defer stopAgain()
+	assert.Equal(t, a.phase, again.phase)
+	assert.NotEqual(t, a.phase, b.phase)
+}
+
+func TestPollingScheduleSpreadPerHost(t *testing.T) {
+	schedules := newPollingSchedules(PollingScheduleOptions{SpreadPerHost: true})
+
+	var phases []float64
+	var stops []func()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		schedule, stop := schedules.start(testPollingWithTriggers(name, "kafka-0.kafka:9092,kafka-1.kafka:9092"))
+		phases = append(phases, schedule.phase)
+		stops = append(stops, stop)
+	}
+
+	// the phases of the scalable objects of a host are apart from each other
+	for i := range phases {
+		for j := i + 1; j < len(phases); j++ {
+			distance := phases[i] - phases[j]
+			if distance < 0 {
+				distance = -distance
+			}
+			if distance > 0.5 {
+				distance = 1 - distance
+			}
+			assert.Greater(t, distance, 0.1)
+		}
+	}
+
+	// the slot released is taken by the next scale loop of the host
+	stops[1]()
+	schedule, stop := schedules.start(testPollingWithTriggers("e", "https://KAFKA-0.kafka:443"))
+	assert.Equal(t, phases[1], schedule.phase)
+	stop()
+	for _, stop := range append(stops[:1], stops[2:]...) {
+		stop()
+	}
+	assert.Empty(t, schedules.hostSlots)
+}
+
+func TestPollingScheduleJitter(t *testing.T) {
+	schedules := newPollingSchedules(PollingScheduleOptions{Jitter: 0.2})
+	schedule, stop := schedules.start(testPollingWithTriggers("worker", ""))
+	defer stop()
+	interval := 10 * time.Second
+	now := time.Unix(1700000000, 0)
+
+	jittered := false
+	for i := 0; i < 10; i++ {
+		wait := schedule.next(now, interval)
+		assert.Equal(t, wait, schedule.next(now, interval))
+		assert.GreaterOrEqual(t, wait, 8*time.Second)
+		assert.LessOrEqual(t, wait, 12*time.Second)
+		jittered = jittered || wait != interval
+		now = now.Add(wait)
+	}
+	assert.True(t, jittered)
+
+	assert.Error(t, ConfigurePollingSchedule(PollingScheduleOptions{Jitter: 0.6}))
+	assert.NoError(t, ConfigurePollingSchedule(PollingScheduleOptions{}))
+}
+
+func TestGetTriggersHost(t *testing.T) {
+	tests := map[string]string{
+		"kafka-0.kafka:9092,kafka-1.kafka:9092": "kafka-0.kafka",
+		"http://Prometheus.monitoring:9090/api": "prometheus.monitoring",
+		"postgres.db":                           "postgres.db",
+		"":                                      "",
+	}
+	for address, host := range tests {
+		assert.Equal(t, host, getTriggersHost(testPollingWithTriggers("worker", address).Spec.Triggers), address)
+	}
+}
+
+func testPollingWithTriggers(name, address string) *kedav1alpha1.WithTriggers {
+	return &kedav1alpha1.WithTriggers{
+		TypeMeta:     metav1.TypeMeta{Kind: "ScaledObject"},
+		ObjectMeta:   metav1.ObjectMeta{Name: name, Namespace: "default"},
+		InternalKind: "ScaledObject",
+		Spec: kedav1alpha1.WithTriggersSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{{Type: "kafka", Metadata: map[string]string{"bootstrapServers": address}}},
+		},
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -34,22 +36,28 @@ import (
 	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/metrics/pkg/apis/external_metrics"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/audit"
 	"github.com/kedacore/keda/v2/pkg/common/message"
+	"github.com/kedacore/keda/v2/pkg/eventemitter"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/fallback"
 	"github.com/kedacore/keda/v2/pkg/metricscollector"
+	"github.com/kedacore/keda/v2/pkg/metricshistory"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache/metricscache"
+	"github.com/kedacore/keda/v2/pkg/scaling/concurrency"
 	"github.com/kedacore/keda/v2/pkg/scaling/executor"
 	"github.com/kedacore/keda/v2/pkg/scaling/modifiers"
 	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 	"github.com/kedacore/keda/v2/pkg/scaling/scaledjob"
+	"github.com/kedacore/keda/v2/pkg/tracing"
 )
 
 var log = logf.Log.WithName("scale_handler")
@@ -61,8 +69,11 @@ type ScaleHandler interface {
 	DeleteScalableObject(ctx context.Context, scalableObject interface{}) error
 	GetScalersCache(ctx context.Context, scalableObject interface{}) (*cache.ScalersCache, error)
 	ClearScalersCache(ctx context.Context, scalableObject interface{}) error
+	GetScalersState() []ScalableObjectScalers
+	EvaluateTrigger(ctx context.Context, evaluation *kedav1alpha1.TriggerEvaluation) kedav1alpha1.TriggerEvaluationStatus
 
 	GetScaledObjectMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricName string) (*external_metrics.ExternalMetricValueList, error)
+	GetUncachedScaledObjectMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricName string) (*external_metrics.ExternalMetricValueList, error)
 }
 
 type scaleHandler struct {
@@ -73,12 +84,21 @@ type scaleHandler struct {
 	recorder                 record.EventRecorder
 	scalerCaches             map[string]*cache.ScalersCache
 	scalerCachesLock         *sync.RWMutex
+	scalerCachesLRU          *scalersCacheLRU
 	scaledObjectsMetricCache metricscache.MetricsCache
 	secretsLister            corev1listers.SecretLister
+	eventEmitter             eventemitter.EventHandler
+	triggerStates            *sync.Map
+	podWatcher               *podWatcher
+	metricsSnapshots         *metricsSnapshots
+	activationTrends         *activationTrends
+	pollingSchedules         *pollingSchedules
+	lazyMinPollingInterval   time.Duration
 }
 
-// NewScaleHandler creates a ScaleHandler object
-func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder, secretsLister corev1listers.SecretLister) ScaleHandler {
+// NewScaleHandler creates a ScaleHandler object, the triggers evaluated from the pods are evaluated again on their
+// changes notified by the informers, they're only polled if the informers are nil
+func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder, secretsLister corev1listers.SecretLister, eventEmitter eventemitter.EventHandler, informers ctrlcache.Informers) ScaleHandler {
 	return &scaleHandler{
 		client:                   client,
 		scaleLoopContexts:        &sync.Map{},
@@ -87,8 +107,16 @@ func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, recon
 		recorder:                 recorder,
 		scalerCaches:             map[string]*cache.ScalersCache{},
 		scalerCachesLock:         &sync.RWMutex{},
+		scalerCachesLRU:          newScalersCacheLRU(getScalersCacheMaxTriggers()),
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+		metricsSnapshots:         newMetricsSnapshots(),
+		activationTrends:         newActivationTrends(),
 		secretsLister:            secretsLister,
+		eventEmitter:             eventEmitter,
+		triggerStates:            &sync.Map{},
+		podWatcher:               newPodWatcher(informers),
+		pollingSchedules:         newPollingSchedules(getPollingScheduleOptions()),
+		lazyMinPollingInterval:   getLazyScalersMinPollingInterval(),
 	}
 }
 
@@ -150,6 +178,13 @@ func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject
 			cancel()
 		}
 		h.scaleLoopContexts.Delete(key)
+		h.clearTriggerStates(key)
+		if _, isScaledObject := scalableObject.(*kedav1alpha1.ScaledObject); isScaledObject {
+			metricshistory.Delete(withTriggers.Namespace, withTriggers.Name)
+			h.metricsSnapshots.delete(key)
+			h.activationTrends.delete(key)
+			h.scaleExecutor.DeleteScaledObject(scalableObject.(*kedav1alpha1.ScaledObject))
+		}
 		err := h.ClearScalersCache(ctx, scalableObject)
 		if err != nil {
 			log.Error(err, "error clearing scalers cache", "scalableObject", scalableObject, "key", key)
@@ -169,6 +204,14 @@ func (h *scaleHandler) startScaleLoop(ctx context.Context, withTriggers *kedav1a
 	pollingInterval := withTriggers.GetPollingInterval()
 	logger.V(1).Info("Watching with pollingInterval", "PollingInterval", pollingInterval)
 
+	// the triggers evaluated from the pods are also evaluated as soon as the pods change
+	podsChanged, stopWatching := h.podWatcher.watch(withTriggers.GenerateIdentifier())
+	defer stopWatching()
+
+	// the ticks of the scale loops are spread over the pollingInterval and jittered if enabled
+	schedule, stopSchedule := h.pollingSchedules.start(withTriggers)
+	defer stopSchedule()
+
 	next := time.Now()
 
 	for {
@@ -177,34 +220,71 @@ func (h *scaleHandler) startScaleLoop(ctx context.Context, withTriggers *kedav1a
 		delay := time.Since(next)
 		metricscollector.RecordScalableObjectLatency(withTriggers.Namespace, withTriggers.Name, isScaledObject, delay)
 
-		tmr := time.NewTimer(pollingInterval)
-		next = time.Now().Add(pollingInterval)
+		wait := schedule.next(time.Now(), pollingInterval)
+		tmr := time.NewTimer(wait)
+		next = time.Now().Add(wait)
 
-		h.checkScalers(ctx, scalableObject, scalingMutex)
+		isActive := h.checkScalers(ctx, scalableObject, scalingMutex)
+		checked := time.Now()
+		h.updatePodWatch(ctx, withTriggers.GenerateIdentifier(), logger)
+		if !isActive && h.isLazy(scalableObject, pollingInterval) {
+			h.releaseScalersCache(ctx, withTriggers.GenerateIdentifier(), logger)
+		}
 
 		select {
 		case <-tmr.C:
 			tmr.Stop()
-		case <-ctx.Done():
-			logger.V(1).Info("Context canceled")
-			err := h.ClearScalersCache(ctx, scalableObject)
-			if err != nil {
-				logger.Error(err, "error clearing scalers cache")
+			continue
+		case <-podsChanged:
+			tmr.Stop()
+			if waitPodChangesEvaluationInterval(ctx, checked) {
+				logger.V(1).Info("Evaluating the triggers on the changes of the watched pods")
+				next = time.Now()
+				continue
 			}
+		case <-ctx.Done():
 			tmr.Stop()
-			return
 		}
+		logger.V(1).Info("Context canceled")
+		err := h.ClearScalersCache(ctx, scalableObject)
+		if err != nil {
+			logger.Error(err, "error clearing scalers cache")
+		}
+		return
 	}
 }
 
+// updatePodWatch selects again the pods watched for the scalable object once its scalers were rebuilt, by the
+// evaluation of its triggers. The pods selected are kept while its scalers aren't cached.
+func (h *scaleHandler) updatePodWatch(ctx context.Context, key string, logger logr.Logger) {
+	if h.podWatcher == nil {
+		return
+	}
+	h.scalerCachesLock.RLock()
+	cache, found := h.scalerCaches[key]
+	if !found {
+		h.scalerCachesLock.RUnlock()
+		return
+	}
+	release := cache.Acquire()
+	h.scalerCachesLock.RUnlock()
+	defer release()
+	h.podWatcher.update(ctx, key, cache, logger)
+}
+
 // startPushScalers starts all push scalers defined in the input scalableOjbect
 func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, scalableObject interface{}, scalingMutex sync.Locker) {
 	logger := log.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
-	cache, err := h.GetScalersCache(ctx, scalableObject)
+	cache, release, err := h.useScalersCache(ctx, scalableObject)
 	if err != nil {
 		logger.Error(err, "Error getting scalers", "object", scalableObject)
 		return
 	}
+	// the push scalers run until the context is done, the cache isn't closed under them meanwhile
+	go func() {
+		<-ctx.Done()
+		release()
+	}()
 
 	for _, ps := range cache.GetPushScalers() {
 		go func(s scalers.PushScaler) {
@@ -230,39 +310,57 @@ func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav
 }
 
 // checkScalers contains the main logic for the ScaleHandler scaling logic.
-// It'll check each trigger active status then call RequestScale
-func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker) {
+// It'll check each trigger active status then call RequestScale, it returns whether the scalableObject is active
+// or couldn't be checked
+func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker) bool {
 	scalingMutex.Lock()
 	defer scalingMutex.Unlock()
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
-		err := h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
-		if err != nil {
-			log.Error(err, "error getting scaledObject", "object", scalableObject)
-			return
-		}
-		isActive, isError, metricsRecords, activeTriggers, err := h.getScaledObjectState(ctx, obj)
-		if err != nil {
-			log.Error(err, "error getting state of scaledObject", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name)
-			return
-		}
+		ctx, span := tracing.StartSpan(ctx, "ScaledObject.checkScalers", tracing.ScalableObjectAttributes("ScaledObject", obj.Namespace, obj.Name)...)
+		isActive, err := h.checkScaledObjectScalers(ctx, obj)
+		tracing.EndSpan(span, err)
+		return isActive || err != nil
+	case *kedav1alpha1.ScaledJob:
+		ctx, span := tracing.StartSpan(ctx, "ScaledJob.checkScalers", tracing.ScalableObjectAttributes("ScaledJob", obj.Namespace, obj.Name)...)
+		isActive, err := h.checkScaledJobScalers(ctx, obj)
+		tracing.EndSpan(span, err)
+		return isActive || err != nil
+	}
+	return true
+}
 
-		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError, &executor.ScaleExecutorOptions{ActiveTriggers: activeTriggers})
+func (h *scaleHandler) checkScaledObjectScalers(ctx context.Context, obj *kedav1alpha1.ScaledObject) (bool, error) {
+	err := h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
+	if err != nil {
+		log.Error(err, "error getting scaledObject", "object", obj)
+		return false, err
+	}
+	isActive, isError, metricsRecords, activeTriggers, usageRatio, auditInputs, err := h.getScaledObjectState(ctx, obj)
+	if err != nil {
+		log.Error(err, "error getting state of scaledObject", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name)
+		return false, err
+	}
 
-		if len(metricsRecords) > 0 {
-			log.V(1).Info("Storing metrics to cache", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name, "metricsRecords", metricsRecords)
-			h.scaledObjectsMetricCache.StoreRecords(obj.GenerateIdentifier(), metricsRecords)
-		}
-	case *kedav1alpha1.ScaledJob:
-		err := h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
-		if err != nil {
-			log.Error(err, "error getting scaledJob", "scaledJob.Namespace", obj.Namespace, "scaledJob.Name", obj.Name)
-			return
-		}
+	h.scaleExecutor.RequestScale(ctx, obj, isActive, isError, &executor.ScaleExecutorOptions{ActiveTriggers: activeTriggers, UsageRatio: usageRatio, AuditInputs: auditInputs})
+
+	if len(metricsRecords) > 0 {
+		log.V(1).Info("Storing metrics to cache", "scaledObject.Namespace", obj.Namespace, "scaledObject.Name", obj.Name, "metricsRecords", metricsRecords)
+		h.scaledObjectsMetricCache.StoreRecords(obj.GenerateIdentifier(), metricsRecords)
+	}
+	return isActive || isError, nil
+}
 
-		isActive, isError, scaleTo, maxScale := h.isScaledJobActive(ctx, obj)
-		h.scaleExecutor.RequestJobScale(ctx, obj, isActive, isError, scaleTo, maxScale)
+func (h *scaleHandler) checkScaledJobScalers(ctx context.Context, obj *kedav1alpha1.ScaledJob) (bool, error) {
+	err := h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
+	if err != nil {
+		log.Error(err, "error getting scaledJob", "scaledJob.Namespace", obj.Namespace, "scaledJob.Name", obj.Name)
+		return false, err
 	}
+
+	isActive, isError, scaleTo, maxScale, auditInputs := h.isScaledJobActive(ctx, obj)
+	h.scaleExecutor.RequestJobScale(ctx, obj, isActive, isError, scaleTo, maxScale, &executor.ScaleExecutorOptions{AuditInputs: auditInputs})
+	return isActive || isError, nil
 }
 
 /// --------------------------------------------------------------------------- ///
@@ -272,39 +370,50 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 // GetScalersCache returns cache for input scalableObject, if the object is not found in the cache, it returns a new one
 // if the input object is ScaledObject, it also compares the Generation of the input of object with the one stored in the cache,
 // this is needed for out of scalerLoop invocations of this method (in package `controllers/keda`).
+// The cache isn't acquired, it may be closed once it's evicted, the evaluations of the scale handler go through useScalersCache.
 func (h *scaleHandler) GetScalersCache(ctx context.Context, scalableObject interface{}) (*cache.ScalersCache, error) {
-	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scalableObject)
+	cache, release, err := h.useScalersCache(ctx, scalableObject)
 	if err != nil {
 		return nil, err
 	}
+	release()
+	return cache, nil
+}
+
+// useScalersCache returns cache for input scalableObject like GetScalersCache, it's acquired until the function returned
+// is called, so it isn't closed while it's used if it's evicted meanwhile
+func (h *scaleHandler) useScalersCache(ctx context.Context, scalableObject interface{}) (*cache.ScalersCache, func(), error) {
+	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scalableObject)
+	if err != nil {
+		return nil, nil, err
+	}
 	key := withTriggers.GenerateIdentifier()
 	generation := withTriggers.Generation
 
 	return h.performGetScalersCache(ctx, key, scalableObject, &generation, "", "", "")
 }
 
-// getScalersCacheForScaledObject returns cache for input ScaledObject, referenced by name and namespace
+// getScalersCacheForScaledObject returns cache for input ScaledObject, referenced by name and namespace, it's acquired until
+// the function returned is called.
 // we don't need to compare the Generation, because this method should be called only inside scale loop, where we have up to date object.
-func (h *scaleHandler) getScalersCacheForScaledObject(ctx context.Context, scaledObjectName, scaledObjectNamespace string) (*cache.ScalersCache, error) {
+func (h *scaleHandler) getScalersCacheForScaledObject(ctx context.Context, scaledObjectName, scaledObjectNamespace string) (*cache.ScalersCache, func(), error) {
 	key := kedav1alpha1.GenerateIdentifier("ScaledObject", scaledObjectNamespace, scaledObjectName)
 
 	return h.performGetScalersCache(ctx, key, nil, nil, "ScaledObject", scaledObjectNamespace, scaledObjectName)
 }
 
-// performGetScalersCache returns cache for input scalableObject, it is common code used by GetScalersCache() and getScalersCacheForScaledObject() methods
-func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, scalableObject interface{}, scalableObjectGeneration *int64, scalableObjectKind, scalableObjectNamespace, scalableObjectName string) (*cache.ScalersCache, error) {
+// performGetScalersCache returns cache for input scalableObject, it is common code used by useScalersCache() and getScalersCacheForScaledObject() methods.
+// The cache is acquired under the lock of the caches, so it can't be evicted and closed before it is.
+func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, scalableObject interface{}, scalableObjectGeneration *int64, scalableObjectKind, scalableObjectNamespace, scalableObjectName string) (*cache.ScalersCache, func(), error) {
 	h.scalerCachesLock.RLock()
 
 	if cache, ok := h.scalerCaches[key]; ok {
 		// generation was specified -> let's include it in the check as well
-		if scalableObjectGeneration != nil {
-			if cache.ScalableObjectGeneration == *scalableObjectGeneration {
-				h.scalerCachesLock.RUnlock()
-				return cache, nil
-			}
-		} else {
+		if scalableObjectGeneration == nil || cache.ScalableObjectGeneration == *scalableObjectGeneration {
+			release := cache.Acquire()
 			h.scalerCachesLock.RUnlock()
-			return cache, nil
+			h.scalerCachesLRU.touch(key)
+			return cache, release, nil
 		}
 	}
 
@@ -317,7 +426,7 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 			err := h.client.Get(ctx, types.NamespacedName{Name: scalableObjectName, Namespace: scalableObjectNamespace}, scaledObject)
 			if err != nil {
 				log.Error(err, "failed to get ScaledObject", "name", scalableObjectName, "namespace", scalableObjectNamespace)
-				return nil, err
+				return nil, nil, err
 			}
 			scalableObject = scaledObject
 		case "ScaledJob":
@@ -325,16 +434,55 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 			err := h.client.Get(ctx, types.NamespacedName{Name: scalableObjectName, Namespace: scalableObjectNamespace}, scaledJob)
 			if err != nil {
 				log.Error(err, "failed to get ScaledJob", "name", scalableObjectName, "namespace", scalableObjectNamespace)
-				return nil, err
+				return nil, nil, err
 			}
 			scalableObject = scaledJob
 		default:
 			err := fmt.Errorf("unknown ScalableObjectKind, got=%q", scalableObjectKind)
 			log.Error(err, "unknown kind", "name", scalableObjectName, "namespace", scalableObjectNamespace)
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
+	newCache, err := h.buildScalersCache(ctx, scalableObject)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	h.scalerCachesLock.Lock()
+	defer h.scalerCachesLock.Unlock()
+
+	if oldCache, ok := h.scalerCaches[key]; ok {
+		// Scalers Close() could be impacted by timeouts, blocking the mutex
+		// until the timeout happens. Instead of locking the mutex, we take
+		// the old cache item and we close it in another goroutine, not locking
+		// the cache: https://github.com/kedacore/keda/issues/5083
+		// It's closed once the evaluations still using it are done.
+		oldCache.Evict()
+	}
+
+	h.scalerCaches[key] = newCache
+
+	evictedKeys, rebuilt := h.scalerCachesLRU.add(key, len(newCache.Scalers))
+	if rebuilt {
+		metricscollector.RecordScalersCacheRebuild(newCache.ScaledObject != nil)
+	}
+	for _, evictedKey := range evictedKeys {
+		evictedCache, ok := h.scalerCaches[evictedKey]
+		if !ok {
+			continue
+		}
+		log.V(1).WithValues("key", evictedKey).Info("Evicting entry from ScalersCache")
+		delete(h.scalerCaches, evictedKey)
+		h.metricsSnapshots.delete(evictedKey)
+		evictedCache.Evict()
+		metricscollector.RecordScalersCacheEviction(evictedCache.ScaledObject != nil)
+	}
+	return newCache, newCache.Acquire(), nil
+}
+
+// buildScalersCache builds the scalers of the input scalableObject, the returned cache isn't stored
+func (h *scaleHandler) buildScalersCache(ctx context.Context, scalableObject interface{}) (*cache.ScalersCache, error) {
 	withTriggers, err := kedav1alpha1.AsDuckWithTriggers(scalableObject)
 	if err != nil {
 		return nil, err
@@ -361,6 +509,7 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 		Scalers:                  scalers,
 		ScalableObjectGeneration: withTriggers.Generation,
 		Recorder:                 h.recorder,
+		CreatedAt:                time.Now(),
 	}
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
@@ -376,20 +525,7 @@ func (h *scaleHandler) performGetScalersCache(ctx context.Context, key string, s
 		newCache.ScaledObject = obj
 	default:
 	}
-
-	h.scalerCachesLock.Lock()
-	defer h.scalerCachesLock.Unlock()
-
-	if oldCache, ok := h.scalerCaches[key]; ok {
-		// Scalers Close() could be impacted by timeouts, blocking the mutex
-		// until the timeout happens. Instead of locking the mutex, we take
-		// the old cache item and we close it in another goroutine, not locking
-		// the cache: https://github.com/kedacore/keda/issues/5083
-		go oldCache.Close(ctx)
-	}
-
-	h.scalerCaches[key] = newCache
-	return h.scalerCaches[key], nil
+	return newCache, nil
 }
 
 // ClearScalersCache invalidates chache for the input scalableObject
@@ -410,6 +546,7 @@ func (h *scaleHandler) ClearScalersCache(ctx context.Context, scalableObject int
 		cache.Close(ctx)
 		delete(h.scalerCaches, key)
 	}
+	h.scalerCachesLRU.remove(key)
 
 	return nil
 }
@@ -422,15 +559,44 @@ func (h *scaleHandler) ClearScalersCache(ctx context.Context, scalableObject int
 // It could either query the metric value directly from the scaler or from a cache, that's being stored for the scaler.
 func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricsName string) (*external_metrics.ExternalMetricValueList, error) {
 	logger := log.WithValues("scaledObject.Namespace", scaledObjectNamespace, "scaledObject.Name", scaledObjectName)
-	var matchingMetrics []external_metrics.ExternalMetricValue
-	var fallbackMetrics []external_metrics.ExternalMetricValue
+	cache, release, err := h.getScalersCacheForScaledObject(ctx, scaledObjectName, scaledObjectNamespace)
+	metricscollector.RecordScaledObjectError(scaledObjectNamespace, scaledObjectName, err)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting scalers %w", err)
+	}
+	defer release()
 
-	cache, err := h.getScalersCacheForScaledObject(ctx, scaledObjectName, scaledObjectNamespace)
+	return h.getScaledObjectMetrics(ctx, cache, h.metricsSnapshots, scaledObjectName, scaledObjectNamespace, metricsName, logger)
+}
+
+// GetUncachedScaledObjectMetrics returns metrics for specified metric name for a ScaledObject like GetScaledObjectMetrics,
+// without caching its scalers, they're built for the call and closed after it. It serves the ScaledObjects of the shards
+// the replica doesn't hold, there's no scale loop on it clearing their scalers.
+func (h *scaleHandler) GetUncachedScaledObjectMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricsName string) (*external_metrics.ExternalMetricValueList, error) {
+	logger := log.WithValues("scaledObject.Namespace", scaledObjectNamespace, "scaledObject.Name", scaledObjectName)
+
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	err := h.client.Get(ctx, types.NamespacedName{Name: scaledObjectName, Namespace: scaledObjectNamespace}, scaledObject)
+	var cache *cache.ScalersCache
+	if err == nil {
+		cache, err = h.buildScalersCache(ctx, scaledObject)
+	}
 	metricscollector.RecordScaledObjectError(scaledObjectNamespace, scaledObjectName, err)
 
 	if err != nil {
 		return nil, fmt.Errorf("error getting scalers %w", err)
 	}
+	defer cache.Close(ctx)
+
+	// the snapshots are kept per cache, they'd never be reused
+	return h.getScaledObjectMetrics(ctx, cache, nil, scaledObjectName, scaledObjectNamespace, metricsName, logger)
+}
+
+// getScaledObjectMetrics returns metrics for specified metric name for a ScaledObject evaluated from the scalers of the cache
+func (h *scaleHandler) getScaledObjectMetrics(ctx context.Context, cache *cache.ScalersCache, snapshots *metricsSnapshots, scaledObjectName, scaledObjectNamespace, metricsName string, logger logr.Logger) (*external_metrics.ExternalMetricValueList, error) {
+	var matchingMetrics []external_metrics.ExternalMetricValue
+	var fallbackMetrics []external_metrics.ExternalMetricValue
 
 	var scaledObject *kedav1alpha1.ScaledObject
 	if cache.ScaledObject != nil {
@@ -452,98 +618,31 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 	metricTriggerPairList := make(map[string]string)
 	isFallbackActive := false
 
-	// let's check metrics for all scalers in a ScaledObject
-	// as we can have multiple metrics in parallel for scaling modifiers
-	// we parallelize the scalers process to speed up the
-	// querying of the metric sources
-	type metricResult struct {
-		metrics           []external_metrics.ExternalMetricValue
-		metricTriggerPair map[string]string
-		metricName        string
-		triggerName       string
-		triggerIndex      int
-		metricSpec        v2.MetricSpec
-		err               error
+	// all the metrics of the ScaledObject are evaluated at once, in parallel, for the first of the requests
+	// of the HPA, the next ones reuse them
+	snapshot, err := snapshots.get(ctx, scaledObjectIdentifier, cache, func(ctx context.Context) ([]metricResult, bool) {
+		return h.takeMetricsSnapshot(ctx, scaledObject, cache, logger)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating the metrics of the scaledObject: %w", err)
 	}
-	allScalers, scalerConfigs := cache.GetScalers()
-	// the matching metrics length has to be the same as required metrics length
-	matchingMetricsChan := make(chan metricResult, len(metricsArray))
-	wg := sync.WaitGroup{}
-	for triggerIndex := 0; triggerIndex < len(allScalers); triggerIndex++ {
-		triggerName := strings.Replace(fmt.Sprintf("%T", allScalers[triggerIndex]), "*scalers.", "", 1)
-		if scalerConfigs[triggerIndex].TriggerName != "" {
-			triggerName = scalerConfigs[triggerIndex].TriggerName
-		}
-
-		metricSpecs, err := cache.GetMetricSpecForScalingForScaler(ctx, triggerIndex)
-		if err != nil {
-			isScalerError = true
-			logger.Error(err, "error getting metric spec for the scaler", "scaler", triggerName)
-			cache.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
-		}
-
-		if len(metricsArray) == 0 {
-			err = fmt.Errorf("no metrics found getting metricsArray array %s", metricsName)
-			logger.Error(err, "error metricsArray is empty")
-			cache.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
-		}
-		for _, spec := range metricSpecs {
-			// skip cpu/memory resource scaler
-			if spec.External == nil {
-				continue
-			}
-			// Filter only the desired metric or if composite scaler is active,
-			// metricsArray contains all external metrics
-			if modifiers.ArrayContainsElement(spec.External.Metric.Name, metricsArray) {
-				// if compositeScaler is used, override with current metric, otherwise do nothing
-				metricName := spec.External.Metric.Name
-				wg.Add(1)
-				go func(results chan metricResult, wg *sync.WaitGroup, metricName string, triggerIndex int, scalerConfig scalersconfig.ScalerConfig, spec v2.MetricSpec) {
-					result := metricResult{}
-
-					// Pair metric values with their trigger names. This is applied only when
-					// ScalingModifiers.Formula is defined in SO.
-					result.metricTriggerPair, err = modifiers.GetPairTriggerAndMetric(scaledObject, metricName, scalerConfig.TriggerName)
-					if err != nil {
-						logger.Error(err, "error pairing triggers & metrics for compositeScaler")
-					}
-					var metrics []external_metrics.ExternalMetricValue
-
-					// if cache is defined for this scaler/metric, let's try to hit it first
-					metricsFoundInCache := false
-					if scalerConfig.TriggerUseCachedMetrics {
-						var metricsRecord metricscache.MetricsRecord
-						if metricsRecord, metricsFoundInCache = h.scaledObjectsMetricCache.ReadRecord(scaledObjectIdentifier, metricName); metricsFoundInCache {
-							logger.V(1).Info("Reading metrics from cache", "scaler", triggerName, "metricName", metricName, "metricsRecord", metricsRecord)
-							metrics = metricsRecord.Metric
-							err = metricsRecord.ScalerError
-						}
-					}
-
-					if !metricsFoundInCache {
-						var latency time.Duration
-						metrics, _, latency, err = cache.GetMetricsAndActivityForScaler(ctx, triggerIndex, metricName)
-						if latency != -1 {
-							metricscollector.RecordScalerLatency(scaledObjectNamespace, scaledObject.Name, triggerName, triggerIndex, metricName, true, latency)
-						}
-						logger.V(1).Info("Getting metrics from trigger", "trigger", triggerName, "metricName", metricName, "metrics", metrics, "scalerError", err)
-					}
-					result.metricName = metricName
-					result.triggerName = triggerName
-					result.triggerIndex = triggerIndex
-					result.metricSpec = spec
-					result.metrics = metrics
-					result.err = err
-					results <- result
-					wg.Done()
-				}(matchingMetricsChan, &wg, metricName, triggerIndex, scalerConfigs[triggerIndex], spec)
-			}
-		}
+	if snapshot.isScalerError {
+		isScalerError = true
+	}
+	if len(metricsArray) == 0 {
+		err = fmt.Errorf("no metrics found getting metricsArray array %s", metricsName)
+		logger.Error(err, "error metricsArray is empty")
+		cache.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
 	}
 
-	wg.Wait()
-	close(matchingMetricsChan)
-	for result := range matchingMetricsChan {
+	fallbackCondition := scaledObject.Status.Conditions.GetFallbackCondition()
+	wasFallbackActive := fallbackCondition.IsTrue()
+	for _, result := range snapshot.results {
+		// Filter only the desired metric or if composite scaler is active,
+		// metricsArray contains all external metrics
+		if !modifiers.ArrayContainsElement(result.metricName, metricsArray) {
+			continue
+		}
 		for key, value := range result.metricTriggerPair {
 			metricTriggerPairList[key] = value
 		}
@@ -556,6 +655,10 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 			for _, metric := range metrics {
 				metricValue := metric.Value.AsApproximateFloat64()
 				metricscollector.RecordScalerMetric(scaledObjectNamespace, scaledObjectName, result.triggerName, result.triggerIndex, metric.MetricName, true, metricValue)
+				// the values served on fallback aren't retained, they'd become the last known good values
+				if !fallbackActive {
+					metricshistory.Record(scaledObjectNamespace, scaledObjectName, metric.MetricName, metricValue, metric.Timestamp.Time)
+				}
 			}
 		}
 		if fallbackActive {
@@ -565,6 +668,9 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 		metricscollector.RecordScalerError(scaledObjectNamespace, scaledObjectName, result.triggerName, result.triggerIndex, result.metricName, true, err)
 		matchingMetrics = append(matchingMetrics, metrics...)
 	}
+	fallbackCondition = scaledObject.Status.Conditions.GetFallbackCondition()
+	h.emitFallbackEvents(scaledObject, scaledObjectNamespace, scaledObjectName, wasFallbackActive, fallbackCondition.IsTrue())
+
 	// invalidate the cache for the ScaledObject, if we hit an error in any scaler
 	// in this case we try to build all scalers (and resolve all secrets/creds) again in the next call
 	if isScalerError {
@@ -586,6 +692,13 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 
 	// handle scalingModifiers here and simply return the matchingMetrics
 	matchingMetrics = modifiers.HandleScalingModifiers(scaledObject, matchingMetrics, metricTriggerPairList, isFallbackActive, fallbackMetrics, cache, logger)
+	if scaledObject.IsUsingModifiers() && !isFallbackActive {
+		for _, metric := range matchingMetrics {
+			if metric.MetricName == kedav1alpha1.CompositeMetricName {
+				metricshistory.Record(scaledObjectNamespace, scaledObjectName, metric.MetricName, metric.Value.AsApproximateFloat64(), metric.Timestamp.Time)
+			}
+		}
+	}
 	return &external_metrics.ExternalMetricValueList{
 		Items: matchingMetrics,
 	}, nil
@@ -595,8 +708,12 @@ func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObjectN
 // is active as the first return value,
 // the second return value indicates whether there was any error during querying scalers,
 // the third return value is a map of metrics record - a metric value for each scaler and its metric
-// the fourth return value contains error if is not able to access scalers cache
-func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, map[string]metricscache.MetricsRecord, []string, error) {
+// the fourth return value contains the names of the active triggers
+// the fifth return value is the highest ratio of the metrics of the resource triggers to their targets, set for the
+// ScaledObjects scaled directly
+// the sixth return value contains the trigger values for the audit log
+// the seventh return value contains error if is not able to access scalers cache
+func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, map[string]metricscache.MetricsRecord, []string, *float64, audit.Inputs, error) {
 	logger := log.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
 
 	isScaledObjectActive := false
@@ -605,12 +722,16 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	metricTriggerPairList := make(map[string]string)
 	var matchingMetrics []external_metrics.ExternalMetricValue
 	var activeTriggers []string
+	var usageRatio *float64
+	auditInputs := audit.Inputs{}
+	metricsHealth := map[string]metricHealth{}
 
-	cache, err := h.GetScalersCache(ctx, scaledObject)
+	cache, release, err := h.useScalersCache(ctx, scaledObject)
 	metricscollector.RecordScaledObjectError(scaledObject.Namespace, scaledObject.Name, err)
 	if err != nil {
-		return false, true, map[string]metricscache.MetricsRecord{}, []string{}, fmt.Errorf("error getting scalers cache %w", err)
+		return false, true, map[string]metricscache.MetricsRecord{}, []string{}, nil, auditInputs, fmt.Errorf("error getting scalers cache %w", err)
 	}
+	defer release()
 
 	// count the number of non-external triggers (cpu/mem) in order to check for
 	// scale to zero requirements if atleast one cpu/mem trigger is given.
@@ -618,7 +739,7 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	// evaluated in the loop below.
 	cpuMemCount := 0
 	for _, trigger := range scaledObject.Spec.Triggers {
-		if trigger.Type == "cpu" || trigger.Type == "memory" {
+		if kedav1alpha1.IsResourceTrigger(trigger.Type) {
 			cpuMemCount++
 		}
 	}
@@ -652,6 +773,14 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 		for k, v := range result.Records {
 			metricsRecord[k] = v
 		}
+		for k, v := range result.Health {
+			metricsHealth[k] = v
+		}
+		if result.UsageRatio != nil && (usageRatio == nil || *result.UsageRatio > *usageRatio) {
+			usageRatio = result.UsageRatio
+		}
+		auditInputs.Triggers = append(auditInputs.Triggers, result.AuditInput)
+		h.emitTriggerEvents(scaledObject, scaledObject.GenerateIdentifier(), true, scalerConfigs[result.AuditInput.Index], result.AuditInput, result.Err)
 
 		metricscollector.RecordScaledObjectError(scaledObject.Namespace, scaledObject.Name, result.Err)
 	}
@@ -666,6 +795,12 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 		logger.V(1).Info("scaler error encountered, clearing scaler cache")
 	}
 
+	sort.Slice(auditInputs.Triggers, func(i, j int) bool {
+		return auditInputs.Triggers[i].Index < auditInputs.Triggers[j].Index
+	})
+
+	h.updateTriggersHealth(ctx, logger, scaledObject, metricsHealth)
+
 	// apply scaling modifiers
 	matchingMetrics = modifiers.HandleScalingModifiers(scaledObject, matchingMetrics, metricTriggerPairList, false, nil, cache, logger)
 
@@ -679,13 +814,15 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 			if scaledObject.Spec.Advanced.ScalingModifiers.ActivationTarget != "" {
 				targetValue, err := strconv.ParseFloat(scaledObject.Spec.Advanced.ScalingModifiers.ActivationTarget, 64)
 				if err != nil {
-					return false, true, metricsRecord, []string{}, fmt.Errorf("scalingModifiers.ActivationTarget parsing error %w", err)
+					return false, true, metricsRecord, []string{}, usageRatio, auditInputs, fmt.Errorf("scalingModifiers.ActivationTarget parsing error %w", err)
 				}
 				activationValue = targetValue
 			}
 
+			auditInputs.Formula = scaledObject.Spec.Advanced.ScalingModifiers.Formula
 			for _, metric := range matchingMetrics {
 				value := metric.Value.AsApproximateFloat64()
+				auditInputs.FormulaResult = &value
 				metricscollector.RecordScalerMetric(scaledObject.Namespace, scaledObject.Name, kedav1alpha1.CompositeMetricName, 0, metric.MetricName, true, value)
 				metricscollector.RecordScalerActive(scaledObject.Namespace, scaledObject.Name, kedav1alpha1.CompositeMetricName, 0, metric.MetricName, true, value > activationValue)
 				if !isScaledObjectActive {
@@ -704,7 +841,7 @@ func (h *scaleHandler) getScaledObjectState(ctx context.Context, scaledObject *k
 	if len(scaledObject.Spec.Triggers) <= cpuMemCount && !isScaledObjectError {
 		isScaledObjectActive = true
 	}
-	return isScaledObjectActive, isScaledObjectError, metricsRecord, activeTriggers, err
+	return isScaledObjectActive, isScaledObjectError, metricsRecord, activeTriggers, usageRatio, auditInputs, err
 }
 
 // scalerState is used as return
@@ -718,14 +855,65 @@ type scalerState struct {
 	Metrics     []external_metrics.ExternalMetricValue
 	Pairs       map[string]string
 	Records     map[string]metricscache.MetricsRecord
+	Health      map[string]metricHealth
+	AuditInput  audit.TriggerInput
 	Err         error
+	// UsageRatio is the highest ratio of the metrics to their targets, set for the ScaledObjects scaled directly
+	UsageRatio *float64
+}
+
+// getMetricTarget returns the target of the external metric, -1 if it has none
+func getMetricTarget(spec v2.MetricSpec) float64 {
+	switch {
+	case spec.External == nil:
+		return -1
+	case spec.External.Target.AverageValue != nil:
+		return spec.External.Target.AverageValue.AsApproximateFloat64()
+	case spec.External.Target.Value != nil:
+		return spec.External.Target.Value.AsApproximateFloat64()
+	default:
+		return -1
+	}
+}
+
+// getUsageRatio returns the ratio of the metric of a resource trigger to its target, the metrics of the
+// resource triggers are averages or utilizations of the pods. It returns -1 if the spec has no such target.
+func getUsageRatio(spec v2.MetricSpec, metrics []external_metrics.ExternalMetricValue) float64 {
+	var target float64
+	switch {
+	case spec.Resource != nil && spec.Resource.Target.AverageUtilization != nil:
+		target = float64(*spec.Resource.Target.AverageUtilization)
+	case spec.Resource != nil && spec.Resource.Target.AverageValue != nil:
+		target = spec.Resource.Target.AverageValue.AsApproximateFloat64()
+	case spec.External != nil && spec.External.Target.AverageValue != nil:
+		target = spec.External.Target.AverageValue.AsApproximateFloat64()
+	case spec.External != nil && spec.External.Target.Value != nil:
+		target = spec.External.Target.Value.AsApproximateFloat64()
+	}
+	if target <= 0 || len(metrics) == 0 {
+		return -1
+	}
+
+	usageRatio := float64(-1)
+	for _, metric := range metrics {
+		usageRatio = math.Max(usageRatio, metric.Value.AsApproximateFloat64()/target)
+	}
+	return usageRatio
+}
+
+// setUsageRatio keeps the highest ratio of the metrics to their targets
+func (s *scalerState) setUsageRatio(spec v2.MetricSpec, metrics []external_metrics.ExternalMetricValue) {
+	usageRatio := getUsageRatio(spec, metrics)
+	if usageRatio >= 0 && (s.UsageRatio == nil || usageRatio > *s.UsageRatio) {
+		s.UsageRatio = &usageRatio
+	}
 }
 
 // getScalerState returns getStateScalerResult with the state
 // for an specific scaler. The state contains if it's active or
 // with erros, but also the records for the cache and he metrics
 // for the custom formulas
-func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler, triggerIndex int, scalerConfig scalersconfig.ScalerConfig,
+func (h *scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler, triggerIndex int, scalerConfig scalersconfig.ScalerConfig,
 	cache *cache.ScalersCache, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) scalerState {
 	result := scalerState{
 		IsActive:    false,
@@ -734,6 +922,7 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 		Metrics:     []external_metrics.ExternalMetricValue{},
 		Pairs:       map[string]string{},
 		Records:     map[string]metricscache.MetricsRecord{},
+		Health:      map[string]metricHealth{},
 	}
 
 	result.TriggerName = strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
@@ -741,6 +930,15 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 		result.TriggerName = scalerConfig.TriggerName
 	}
 
+	release, err := concurrency.Acquire(ctx, scalerConfig.TriggerType)
+	if err != nil {
+		result.Err = err
+		logger.Error(err, "error waiting to evaluate the scaler", "scaler", result.TriggerName)
+		result.AuditInput = newAuditTriggerInput(scalerConfig, triggerIndex, nil, false, err)
+		return result
+	}
+	defer release()
+
 	metricSpecs, err := cache.GetMetricSpecForScalingForScaler(ctx, triggerIndex)
 	if err != nil {
 		result.Err = err
@@ -752,7 +950,8 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 		switch {
 		case spec.Resource != nil:
 			metricName := spec.Resource.Name.String()
-			_, isMetricActive, _, err := cache.GetMetricsAndActivityForScaler(ctx, triggerIndex, metricName)
+			metrics, isMetricActive, latency, err := cache.GetMetricsAndActivityForScaler(ctx, triggerIndex, metricName)
+			metricscollector.RecordScalerCall(scaledObject.Namespace, scaledObject.Name, scalerConfig.TriggerType, triggerIndex, true, latency, err)
 			if err != nil {
 				result.Err = err
 				logger.Error(err, "error getting metric source", "source", result.TriggerName, "metricName", metricName)
@@ -760,6 +959,12 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 				continue
 			}
 
+			// the replicas of the ScaledObjects scaled directly are computed from the values of the resource
+			if scaledObject.IsUsingDirectScaling() {
+				result.setUsageRatio(spec, metrics)
+			}
+			isMetricActive = h.activationTrends.isActive(scaledObject.GenerateIdentifier(), triggerIndex, metricName, scaler, isMetricActive)
+
 			metricscollector.RecordScalerError(scaledObject.Namespace, scaledObject.Name, result.TriggerName, triggerIndex, metricName, true, err)
 			metricscollector.RecordScalerActive(scaledObject.Namespace, scaledObject.Name, result.TriggerName, triggerIndex, metricName, true, isMetricActive)
 			result.IsActive = isMetricActive
@@ -772,6 +977,10 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 			if latency != -1 {
 				metricscollector.RecordScalerLatency(scaledObject.Namespace, scaledObject.Name, result.TriggerName, triggerIndex, metricName, true, latency)
 			}
+			metricscollector.RecordScalerCall(scaledObject.Namespace, scaledObject.Name, scalerConfig.TriggerType, triggerIndex, true, latency, err)
+			if err == nil {
+				isMetricActive = h.activationTrends.isActive(scaledObject.GenerateIdentifier(), triggerIndex, metricName, scaler, isMetricActive)
+			}
 			result.Metrics = append(result.Metrics, metrics...)
 			logger.V(1).Info("Getting metrics and activity from scaler", "scaler", result.TriggerName, "metricName", metricName, "metrics", metrics, "activity", isMetricActive, "scalerError", err)
 
@@ -782,6 +991,7 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 					ScalerError: err,
 				}
 			}
+			result.Health[metricName] = newMetricHealth(metricName, metrics, isMetricActive, err)
 
 			if err != nil {
 				result.Err = err
@@ -794,9 +1004,13 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 				}
 			} else {
 				result.IsActive = isMetricActive
+				if scaledObject.IsUsingDirectScaling() {
+					result.setUsageRatio(spec, metrics)
+				}
 				for _, metric := range metrics {
 					metricValue := metric.Value.AsApproximateFloat64()
 					metricscollector.RecordScalerMetric(scaledObject.Namespace, scaledObject.Name, result.TriggerName, triggerIndex, metric.MetricName, true, metricValue)
+					metricscollector.RecordTriggerMetricValue(scaledObject.Namespace, scaledObject.Name, scalerConfig.TriggerType, result.TriggerName, triggerIndex, metric.MetricName, metricValue, getMetricTarget(spec))
 				}
 				if !scaledObject.IsUsingModifiers() {
 					if isMetricActive {
@@ -819,102 +1033,181 @@ func (*scaleHandler) getScalerState(ctx context.Context, scaler scalers.Scaler,
 			logger.Error(errors.New("error parsing metric for the scaler"), "both resource and external metrics are nil", "scaler", result.TriggerName)
 		}
 	}
+	result.AuditInput = newAuditTriggerInput(scalerConfig, triggerIndex, result.Metrics, result.IsActive, result.Err)
 	return result
 }
 
+// newAuditTriggerInput returns the outcome of a trigger as recorded to the audit log
+func newAuditTriggerInput(scalerConfig scalersconfig.ScalerConfig, triggerIndex int, metrics []external_metrics.ExternalMetricValue, isActive bool, err error) audit.TriggerInput {
+	input := audit.TriggerInput{
+		Name:     scalerConfig.TriggerName,
+		Type:     scalerConfig.TriggerType,
+		Index:    triggerIndex,
+		IsActive: isActive,
+	}
+	if len(metrics) > 0 {
+		input.Metrics = make(map[string]float64, len(metrics))
+		for _, metric := range metrics {
+			input.Metrics[metric.MetricName] = metric.Value.AsApproximateFloat64()
+		}
+	}
+	if err != nil {
+		input.Error = err.Error()
+	}
+	return input
+}
+
 // / --------------------------------------------------------------------------- ///
 // / ----------             ScaledJob related methods               --------- ///
 // / --------------------------------------------------------------------------- ///
 
 // getScaledJobMetrics returns metrics for specified metric name for a ScaledJob identified by its name and namespace.
 // It could either query the metric value directly from the scaler or from a cache, that's being stored for the scaler.
-func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) ([]scaledjob.ScalerMetrics, bool) {
-	logger := log.WithValues("scaledJob.Namespace", scaledJob.Namespace, "scaledJob.Name", scaledJob.Name)
-
-	cache, err := h.GetScalersCache(ctx, scaledJob)
+func (h *scaleHandler) getScaledJobMetrics(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) ([]scaledjob.ScalerMetrics, []audit.TriggerInput, bool) {
+	cache, release, err := h.useScalersCache(ctx, scaledJob)
 	metricscollector.RecordScaledJobError(scaledJob.Namespace, scaledJob.Name, err)
 	if err != nil {
 		log.Error(err, "error getting scalers cache", "scaledJob.Namespace", scaledJob.Namespace, "scaledJob.Name", scaledJob.Name)
-		return nil, true
+		return nil, nil, true
 	}
+	defer release()
 	var isError bool
 	var scalersMetrics []scaledjob.ScalerMetrics
-	scalers, scalerConfigs := cache.GetScalers()
-	for scalerIndex, scaler := range scalers {
-		scalerName := strings.Replace(fmt.Sprintf("%T", scalers[scalerIndex]), "*scalers.", "", 1)
-		if scalerConfigs[scalerIndex].TriggerName != "" {
-			scalerName = scalerConfigs[scalerIndex].TriggerName
+	var auditTriggers []audit.TriggerInput
+	allScalers, scalerConfigs := cache.GetScalers()
+	// the triggers are evaluated in parallel, their results are merged in the order of the triggers
+	results := make([]scaledJobTriggerResult, len(allScalers))
+	wg := sync.WaitGroup{}
+	for scalerIndex := range allScalers {
+		wg.Add(1)
+		go func(scaler scalers.Scaler, scalerIndex int, scalerConfig scalersconfig.ScalerConfig) {
+			results[scalerIndex] = h.getScaledJobTriggerMetrics(ctx, scaledJob, cache, scaler, scalerIndex, scalerConfig)
+			wg.Done()
+		}(allScalers[scalerIndex], scalerIndex, scalerConfigs[scalerIndex])
+	}
+	wg.Wait()
+	for scalerIndex, result := range results {
+		if result.err != nil {
+			isError = true
 		}
-		isActive := false
-		scalerType := fmt.Sprintf("%T:", scaler)
+		scalersMetrics = append(scalersMetrics, result.metrics...)
+		auditTriggers = append(auditTriggers, result.auditInput)
+		h.emitTriggerEvents(scaledJob, scaledJob.GenerateIdentifier(), false, scalerConfigs[scalerIndex], result.auditInput, result.err)
+	}
+	return scalersMetrics, auditTriggers, isError
+}
 
-		scalerLogger := log.WithValues("scaledJob.Name", scaledJob.Name, "Scaler", scalerType)
+// scaledJobTriggerResult is the outcome of a trigger of a ScaledJob
+type scaledJobTriggerResult struct {
+	metrics    []scaledjob.ScalerMetrics
+	auditInput audit.TriggerInput
+	err        error
+}
 
-		metricSpecs := scaler.GetMetricSpecForScaling(ctx)
+// getScaledJobTriggerMetrics returns the metrics of a trigger of a ScaledJob, the error is the last one
+// of its metrics
+func (*scaleHandler) getScaledJobTriggerMetrics(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, cache *cache.ScalersCache,
+	scaler scalers.Scaler, scalerIndex int, scalerConfig scalersconfig.ScalerConfig) scaledJobTriggerResult {
+	logger := log.WithValues("scaledJob.Namespace", scaledJob.Namespace, "scaledJob.Name", scaledJob.Name)
+	scalerName := strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
+	if scalerConfig.TriggerName != "" {
+		scalerName = scalerConfig.TriggerName
+	}
+	isActive := false
+	scalerType := fmt.Sprintf("%T:", scaler)
 
-		for _, spec := range metricSpecs {
-			// skip scaler that doesn't return any metric specs (usually External scaler with incorrect metadata)
-			// or skip cpu/memory resource scaler
-			if len(metricSpecs) < 1 || spec.External == nil {
-				continue
-			}
-			metricName := spec.External.Metric.Name
-			metrics, isTriggerActive, latency, err := cache.GetMetricsAndActivityForScaler(ctx, scalerIndex, metricName)
-			metricscollector.RecordScaledJobError(scaledJob.Namespace, scaledJob.Name, err)
-			if latency != -1 {
-				metricscollector.RecordScalerLatency(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, latency)
-			}
-			if err != nil {
-				scalerLogger.Error(err, "Error getting scaler metrics and activity, but continue")
-				cache.Recorder.Event(scaledJob, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
-				isError = true
-				continue
-			}
-			if isTriggerActive {
-				isActive = true
-			}
-			queueLength, maxValue, targetAverageValue := scaledjob.CalculateQueueLengthAndMaxValue(metrics, metricSpecs, scaledJob.MaxReplicaCount())
+	scalerLogger := log.WithValues("scaledJob.Name", scaledJob.Name, "Scaler", scalerType)
 
-			scalerLogger.V(1).Info("Scaler Metric value", "isTriggerActive", isTriggerActive, metricSpecs[0].External.Metric.Name, queueLength, "targetAverageValue", targetAverageValue)
+	release, err := concurrency.Acquire(ctx, scalerConfig.TriggerType)
+	if err != nil {
+		scalerLogger.Error(err, "Error waiting to evaluate the scaler, but continue")
+		return scaledJobTriggerResult{
+			auditInput: newAuditTriggerInput(scalerConfig, scalerIndex, nil, false, err),
+			err:        err,
+		}
+	}
+	defer release()
 
-			scalersMetrics = append(scalersMetrics, scaledjob.ScalerMetrics{
-				QueueLength: queueLength,
-				MaxValue:    maxValue,
-				IsActive:    isActive,
-			})
-			for _, metric := range metrics {
-				metricValue := metric.Value.AsApproximateFloat64()
-				metricscollector.RecordScalerMetric(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metric.MetricName, false, metricValue)
-			}
+	var scalersMetrics []scaledjob.ScalerMetrics
+	metricSpecs := scaler.GetMetricSpecForScaling(ctx)
+	var triggerMetrics []external_metrics.ExternalMetricValue
+	var triggerErr error
 
-			if isTriggerActive {
-				if spec.External != nil {
-					logger.V(1).Info("Scaler for scaledJob is active", "scaler", scalerName, "metricName", metricName)
-				}
-				if spec.Resource != nil {
-					logger.V(1).Info("Scaler for scaledJob is active", "scaler", scalerName, "metricName", spec.Resource.Name)
-				}
-			}
+	for _, spec := range metricSpecs {
+		// skip scaler that doesn't return any metric specs (usually External scaler with incorrect metadata)
+		// or skip resource metric specs, the cpu/memory scalers of the ScaledJobs return external metric specs
+		if len(metricSpecs) < 1 || spec.External == nil {
+			continue
+		}
+		metricName := spec.External.Metric.Name
+		metrics, isTriggerActive, latency, err := cache.GetMetricsAndActivityForScaler(ctx, scalerIndex, metricName)
+		metricscollector.RecordScaledJobError(scaledJob.Namespace, scaledJob.Name, err)
+		if latency != -1 {
+			metricscollector.RecordScalerLatency(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, latency)
+		}
+		metricscollector.RecordScalerCall(scaledJob.Namespace, scaledJob.Name, scalerConfig.TriggerType, scalerIndex, false, latency, err)
+		if err != nil {
+			scalerLogger.Error(err, "Error getting scaler metrics and activity, but continue")
+			cache.Recorder.Event(scaledJob, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+			triggerErr = err
+			continue
+		}
+		if isTriggerActive {
+			isActive = true
+		}
+		triggerMetrics = append(triggerMetrics, metrics...)
+		queueLength, maxValue, targetAverageValue := scaledjob.CalculateQueueLengthAndMaxValue(metrics, metricSpecs, scaledJob.MaxReplicaCount())
+
+		scalerLogger.V(1).Info("Scaler Metric value", "isTriggerActive", isTriggerActive, metricSpecs[0].External.Metric.Name, queueLength, "targetAverageValue", targetAverageValue)
+
+		scalersMetrics = append(scalersMetrics, scaledjob.ScalerMetrics{
+			QueueLength: queueLength,
+			MaxValue:    maxValue,
+			IsActive:    isActive,
+		})
+		for _, metric := range metrics {
+			metricValue := metric.Value.AsApproximateFloat64()
+			metricscollector.RecordScalerMetric(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metric.MetricName, false, metricValue)
+		}
 
-			metricscollector.RecordScalerError(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, err)
-			metricscollector.RecordScalerActive(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, isTriggerActive)
+		if isTriggerActive {
+			if spec.External != nil {
+				logger.V(1).Info("Scaler for scaledJob is active", "scaler", scalerName, "metricName", metricName)
+			}
+			if spec.Resource != nil {
+				logger.V(1).Info("Scaler for scaledJob is active", "scaler", scalerName, "metricName", spec.Resource.Name)
+			}
 		}
+
+		metricscollector.RecordScalerError(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, err)
+		metricscollector.RecordScalerActive(scaledJob.Namespace, scaledJob.Name, scalerName, scalerIndex, metricName, false, isTriggerActive)
+	}
+	return scaledJobTriggerResult{
+		metrics:    scalersMetrics,
+		auditInput: newAuditTriggerInput(scalerConfig, scalerIndex, triggerMetrics, isActive, triggerErr),
+		err:        triggerErr,
 	}
-	return scalersMetrics, isError
 }
 
 // isScaledJobActive returns whether the input ScaledJob:
 // is active as the first return value,
-// the second and the third return values indicate queueLength and maxValue for scale
-func (h *scaleHandler) isScaledJobActive(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (bool, bool, int64, int64) {
+// the second and the third return values indicate queueLength and maxValue for scale,
+// the fourth return value contains the trigger values for the audit log
+func (h *scaleHandler) isScaledJobActive(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (bool, bool, int64, int64, audit.Inputs) {
 	logger := logf.Log.WithName("scalemetrics")
 
-	scalersMetrics, isError := h.getScaledJobMetrics(ctx, scaledJob)
+	scalersMetrics, auditTriggers, isError := h.getScaledJobMetrics(ctx, scaledJob)
 	isActive, queueLength, maxValue, maxFloatValue :=
 		scaledjob.IsScaledJobActive(scalersMetrics, scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation, scaledJob.MinReplicaCount(), scaledJob.MaxReplicaCount())
 
 	logger.V(1).WithValues("scaledJob.Name", scaledJob.Name).Info("Checking if ScaleJob Scalers are active", "isActive", isActive, "maxValue", maxFloatValue, "MultipleScalersCalculation", scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation)
-	return isActive, isError, queueLength, maxValue
+	queueLengthValue := float64(queueLength)
+	auditInputs := audit.Inputs{
+		Triggers:      auditTriggers,
+		Formula:       scaledJob.Spec.ScalingStrategy.MultipleScalersCalculation,
+		FormulaResult: &queueLengthValue,
+	}
+	return isActive, isError, queueLength, maxValue, auditInputs
 }
 
 // getTrueMetricArray is a help function made for composite scaler to determine
@@ -61,6 +61,9 @@ func TestGetScaledObjectMetrics_DirectCall(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	recorder := record.NewFakeRecorder(1)
 	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockClient.EXPECT().Status().Return(mockStatusWriter).AnyTimes()
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
 
 	metricsSpecs := []v2.MetricSpec{createMetricSpec(10, metricName)}
@@ -150,6 +153,9 @@ func TestGetScaledObjectMetrics_FromCache(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	recorder := record.NewFakeRecorder(1)
 	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockClient.EXPECT().Status().Return(mockStatusWriter).AnyTimes()
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
 
 	metricsSpecs := []v2.MetricSpec{createMetricSpec(10, metricName)}
@@ -254,6 +260,9 @@ func TestGetScaledObjectMetrics_InParallel(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	recorder := record.NewFakeRecorder(1)
 	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockClient.EXPECT().Status().Return(mockStatusWriter).AnyTimes()
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
 
 	scalerCollection := []*mock_scalers.MockScaler{}
@@ -369,9 +378,147 @@ func TestGetScaledObjectMetrics_InParallel(t *testing.T) {
 	scalerCache.Close(context.Background())
 }
 
+func TestGetScaledObjectMetrics_Batched(t *testing.T) {
+	scaledObjectName := testNameGlobal
+	scaledObjectNamespace := testNamespaceGlobal
+	metricNames := []string{"test-metric-name-1", "test-metric-name-2"}
+
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockClient.EXPECT().Status().Return(mockStatusWriter).AnyTimes()
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      scaledObjectName,
+			Namespace: scaledObjectNamespace,
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			ExternalMetricNames: metricNames,
+		},
+	}
+	scalerCache := cache.ScalersCache{
+		ScaledObject: &scaledObject,
+		Recorder:     recorder,
+	}
+	scalerCollection := []*mock_scalers.MockScaler{}
+	for i, metricName := range metricNames {
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2.MetricSpec{createMetricSpec(10, metricName)}).AnyTimes()
+		scalerCollection = append(scalerCollection, scaler)
+		scalerCache.Scalers = append(scalerCache.Scalers, cache.ScalerBuilder{
+			Scaler:       scaler,
+			ScalerConfig: scalersconfig.ScalerConfig{TriggerIndex: i},
+		})
+	}
+	expectMetrics := func() {
+		for i, metricName := range metricNames {
+			scalerCollection[i].EXPECT().GetMetricsAndActivity(gomock.Any(), metricName).Return([]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili(metricName, float64(i+1))}, true, nil)
+		}
+	}
+
+	caches := map[string]*cache.ScalersCache{}
+	caches[scaledObject.GenerateIdentifier()] = &scalerCache
+
+	sh := scaleHandler{
+		client:                   mockClient,
+		scaleLoopContexts:        &sync.Map{},
+		globalHTTPTimeout:        time.Duration(1000),
+		recorder:                 recorder,
+		scalerCaches:             caches,
+		scalerCachesLock:         &sync.RWMutex{},
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+		metricsSnapshots:         newMetricsSnapshots(),
+	}
+
+	// the first request evaluates all the triggers, the next one reuses their metrics
+	expectMetrics()
+	for _, metricName := range metricNames {
+		metrics, err := sh.GetScaledObjectMetrics(context.TODO(), scaledObjectName, scaledObjectNamespace, metricName)
+		assert.Nil(t, err)
+		assert.Len(t, metrics.Items, 1)
+		assert.Equal(t, metricName, metrics.Items[0].MetricName)
+	}
+
+	// the triggers are evaluated again once the snapshot expired
+	sh.metricsSnapshots.snapshots[scaledObject.GenerateIdentifier()].taken = time.Now().Add(-metricsSnapshotTTL)
+	expectMetrics()
+	metrics, err := sh.GetScaledObjectMetrics(context.TODO(), scaledObjectName, scaledObjectNamespace, metricNames[1])
+	assert.Nil(t, err)
+	assert.Len(t, metrics.Items, 1)
+
+	for i := range metricNames {
+		scalerCollection[i].EXPECT().Close(gomock.Any())
+	}
+	scalerCache.Close(context.Background())
+}
+
+func TestGetUncachedScaledObjectMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	recorder := record.NewFakeRecorder(1)
+	mockClient := mock_client.NewMockClient(ctrl)
+
+	scaledObject := kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testNameGlobal,
+			Namespace: testNamespaceGlobal,
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name: "test",
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{{
+				Type:     "cron",
+				Metadata: map[string]string{"timezone": "Etc/UTC", "start": "0 0 * * *", "end": "59 23 * * *", "desiredReplicas": "3"},
+			}},
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &kedav1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	sh := scaleHandler{
+		client:                   mockClient,
+		recorder:                 recorder,
+		scalerCaches:             map[string]*cache.ScalersCache{},
+		scalerCachesLock:         &sync.RWMutex{},
+		scalerCachesLRU:          newScalersCacheLRU(0),
+		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
+		metricsSnapshots:         newMetricsSnapshots(),
+	}
+
+	mockClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: testNameGlobal, Namespace: testNamespaceGlobal}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ types.NamespacedName, obj *kedav1alpha1.ScaledObject, _ ...interface{}) error {
+			scaledObject.DeepCopyInto(obj)
+			return nil
+		})
+	mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf(&appsv1.Deployment{})).Return(nil)
+
+	metrics, err := sh.GetUncachedScaledObjectMetrics(context.TODO(), testNameGlobal, testNamespaceGlobal, "s0-cron-Etc-UTC-00xxx-5923xxx")
+	assert.Nil(t, err)
+	assert.Len(t, metrics.Items, 1)
+
+	// the scalers of the ScaledObject were built for the call only
+	assert.Empty(t, sh.scalerCaches)
+	assert.Empty(t, sh.metricsSnapshots.snapshots)
+}
+
 func TestCheckScaledObjectScalersWithError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockClient.EXPECT().Status().Return(mockStatusWriter).AnyTimes()
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
 	recorder := record.NewFakeRecorder(1)
 
@@ -423,7 +570,7 @@ func TestCheckScaledObjectScalersWithError(t *testing.T) {
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
 
-	isActive, isError, _, activeTriggers, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	isActive, isError, _, activeTriggers, _, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
 	scalerCache.Close(context.Background())
 
 	assert.Equal(t, false, isActive)
@@ -540,7 +687,7 @@ func TestCheckScaledObjectScalersWithTriggerAuthError(t *testing.T) {
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
 
-	isActive, isError, _, activeTriggers, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	isActive, isError, _, activeTriggers, _, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
 	scalerCache.Close(context.Background())
 
 	assert.Equal(t, false, isActive)
@@ -555,6 +702,9 @@ func TestCheckScaledObjectScalersWithTriggerAuthError(t *testing.T) {
 func TestCheckScaledObjectFindFirstActiveNotIgnoreOthers(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockClient.EXPECT().Status().Return(mockStatusWriter).AnyTimes()
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
 	recorder := record.NewFakeRecorder(1)
 
@@ -620,7 +770,7 @@ func TestCheckScaledObjectFindFirstActiveNotIgnoreOthers(t *testing.T) {
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
 
-	isActive, isError, _, activeTriggers, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
+	isActive, isError, _, activeTriggers, _, _, _ := sh.getScaledObjectState(context.TODO(), &scaledObject)
 	scalerCache.Close(context.Background())
 
 	assert.Equal(t, true, isActive)
@@ -657,7 +807,7 @@ func TestIsScaledJobActive(t *testing.T) {
 		scaledObjectsMetricCache: metricscache.NewMetricsCache(),
 	}
 	// nosemgrep: context-todo
-	isActive, isError, queueLength, maxValue := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
+	isActive, isError, queueLength, maxValue, _ := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
 	assert.Equal(t, true, isActive)
 	assert.Equal(t, false, isError)
 	assert.Equal(t, int64(20), queueLength)
@@ -715,7 +865,7 @@ func TestIsScaledJobActive(t *testing.T) {
 		}
 		fmt.Printf("index: %d", index)
 		// nosemgrep: context-todo
-		isActive, isError, queueLength, maxValue = sh.isScaledJobActive(context.TODO(), scaledJob)
+		isActive, isError, queueLength, maxValue, _ = sh.isScaledJobActive(context.TODO(), scaledJob)
 		//	assert.Equal(t, 5, index)
 		assert.Equal(t, scalerTestData.ResultIsActive, isActive)
 		assert.Equal(t, scalerTestData.ResultIsError, isError)
@@ -757,7 +907,7 @@ func TestIsScaledJobActiveIfQueueEmptyButMinReplicaCountGreaterZero(t *testing.T
 	}
 
 	// nosemgrep: context-todo
-	isActive, isError, queueLength, maxValue := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
+	isActive, isError, queueLength, maxValue, _ := sh.isScaledJobActive(context.TODO(), scaledJobSingle)
 	assert.Equal(t, true, isActive)
 	assert.Equal(t, false, isError)
 	assert.Equal(t, int64(0), queueLength)
@@ -910,6 +1060,9 @@ func TestScalingModifiersFormula(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	recorder := record.NewFakeRecorder(1)
 	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	mockClient.EXPECT().Status().Return(mockStatusWriter).AnyTimes()
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	mockExecutor := mock_executor.NewMockScaleExecutor(ctrl)
 
 	metricsSpecs1 := []v2.MetricSpec{createMetricSpec(2, metricName1)}
@@ -1031,3 +1184,32 @@ func expectNoStatusPatch(ctrl *gomock.Controller) {
 	statusWriter := mock_client.NewMockStatusWriter(ctrl)
 	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 }
+
+func TestGetUsageRatio(t *testing.T) {
+	utilization := int32(50)
+	averageValue := resource.MustParse("200m")
+	metrics := []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("cpu", 75)}
+
+	// the metrics of the resource triggers are compared to their targets
+	spec := v2.MetricSpec{Resource: &v2.ResourceMetricSource{Name: v1.ResourceCPU, Target: v2.MetricTarget{AverageUtilization: &utilization}}}
+	assert.Equal(t, 1.5, getUsageRatio(spec, metrics))
+	spec = v2.MetricSpec{Resource: &v2.ResourceMetricSource{Name: v1.ResourceCPU, Target: v2.MetricTarget{AverageValue: &averageValue}}}
+	assert.Equal(t, 0.25, getUsageRatio(spec, []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("cpu", 0.05)}))
+	spec = v2.MetricSpec{External: &v2.ExternalMetricSource{Target: v2.MetricTarget{AverageValue: &averageValue}}}
+	assert.Equal(t, 0.25, getUsageRatio(spec, []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-ephemeral-storage", 0.05)}))
+	// the HPA of the ScaledObjects scales on the ephemeral storage with a Value target
+	spec = v2.MetricSpec{External: &v2.ExternalMetricSource{Target: v2.MetricTarget{Value: &averageValue}}}
+	assert.Equal(t, 0.25, getUsageRatio(spec, []external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("s0-ephemeral-storage", 0.05)}))
+
+	// the ones without a target or without metrics don't have a ratio
+	assert.Equal(t, float64(-1), getUsageRatio(spec, nil))
+	spec = v2.MetricSpec{External: &v2.ExternalMetricSource{}}
+	assert.Equal(t, float64(-1), getUsageRatio(spec, metrics))
+
+	// the highest ratio of the metrics of a scaler is kept
+	state := scalerState{}
+	state.setUsageRatio(v2.MetricSpec{Resource: &v2.ResourceMetricSource{Target: v2.MetricTarget{AverageUtilization: &utilization}}}, metrics)
+	state.setUsageRatio(v2.MetricSpec{Resource: &v2.ResourceMetricSource{Target: v2.MetricTarget{AverageUtilization: &utilization}}},
+		[]external_metrics.ExternalMetricValue{scalers.GenerateMetricInMili("cpu", 25)})
+	assert.Equal(t, 1.5, *state.UsageRatio)
+}
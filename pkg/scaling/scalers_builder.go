@@ -19,6 +19,7 @@ package scaling
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -58,6 +59,7 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 				ScalableObjectNamespace: withTriggers.Namespace,
 				ScalableObjectType:      withTriggers.Kind,
 				TriggerName:             trigger.Name,
+				TriggerType:             trigger.Type,
 				TriggerMetadata:         trigger.Metadata,
 				TriggerUseCachedMetrics: trigger.UseCachedMetrics,
 				ResolvedEnv:             resolvedEnv,
@@ -89,7 +91,14 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 		// nosemgrep: invalid-usage-of-modified-variable
 		scaler, config, err := factory()
 		if err != nil {
-			h.recorder.Event(withTriggers, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+			annotations := map[string]string{
+				eventreason.TriggerIndexAnnotation: strconv.Itoa(triggerIndex),
+				eventreason.TriggerTypeAnnotation:  trigger.Type,
+			}
+			if trigger.Name != "" {
+				annotations[eventreason.TriggerNameAnnotation] = trigger.Name
+			}
+			h.recorder.AnnotatedEventf(withTriggers, annotations, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, "%s", err.Error())
 			logger.Error(err, "error resolving auth params", "triggerIndex", triggerIndex)
 			if scaler != nil {
 				scaler.Close(ctx)
@@ -134,12 +143,16 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewAwsKinesisStreamScaler(ctx, config)
 	case "aws-sqs-queue":
 		return scalers.NewAwsSqsQueueScaler(ctx, config)
+	case "aws-step-functions":
+		return scalers.NewAwsStepFunctionsScaler(ctx, config)
 	case "azure-app-insights":
 		return scalers.NewAzureAppInsightsScaler(config)
 	case "azure-blob":
 		return scalers.NewAzureBlobScaler(config)
 	case "azure-data-explorer":
 		return scalers.NewAzureDataExplorerScaler(config)
+	case "azure-eventgrid":
+		return scalers.NewAzureEventGridScaler(config)
 	case "azure-eventhub":
 		return scalers.NewAzureEventHubScaler(config)
 	case "azure-log-analytics":
@@ -154,6 +167,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewAzureServiceBusScaler(ctx, config)
 	case "cassandra":
 		return scalers.NewCassandraScaler(config)
+	case "clickhouse":
+		return scalers.NewClickHouseScaler(config)
 	case "couchdb":
 		return scalers.NewCouchDBScaler(ctx, config)
 	case "cpu":
@@ -166,6 +181,10 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewDynatraceScaler(config)
 	case "elasticsearch":
 		return scalers.NewElasticsearchScaler(config)
+	case "envoy":
+		return scalers.NewEnvoyScaler(client, config)
+	case "ephemeral-storage":
+		return scalers.NewCPUMemoryScaler(corev1.ResourceEphemeralStorage, config, client)
 	case "etcd":
 		return scalers.NewEtcdScaler(config)
 	case "external":
@@ -185,12 +204,24 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewGcsScaler(config)
 	case "github-runner":
 		return scalers.NewGitHubRunnerScaler(config)
+	case "github-webhook":
+		return scalers.NewGitHubWebhookScaler(config)
+	case "gitlab-runner":
+		return scalers.NewGitLabRunnerScaler(config)
 	case "graphite":
 		return scalers.NewGraphiteScaler(config)
+	case "grpc-probe":
+		return scalers.NewGrpcProbeScaler(config)
+	case "hashicorp-kv":
+		return scalers.NewHashicorpKVScaler(config)
+	case "hpc-scheduler":
+		return scalers.NewHPCSchedulerScaler(config)
 	case "huawei-cloudeye":
 		return scalers.NewHuaweiCloudeyeScaler(config)
 	case "ibmmq":
 		return scalers.NewIBMMQScaler(config)
+	case "iceberg":
+		return scalers.NewIcebergScaler(config)
 	case "influxdb":
 		return scalers.NewInfluxDBScaler(config)
 	case "kafka":
@@ -199,6 +230,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewKubernetesWorkloadScaler(client, config)
 	case "liiklus":
 		return scalers.NewLiiklusScaler(config)
+	case "llm-inference":
+		return scalers.NewLLMInferenceScaler(client, config)
 	case "loki":
 		return scalers.NewLokiScaler(config)
 	case "memory":
@@ -209,16 +242,24 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewMongoDBScaler(ctx, config)
 	case "mssql":
 		return scalers.NewMSSQLScaler(config)
+	case "mqtt":
+		return scalers.NewMQTTScaler(config)
 	case "mysql":
 		return scalers.NewMySQLScaler(config)
 	case "nats-jetstream":
 		return scalers.NewNATSJetStreamScaler(config)
 	case "new-relic":
 		return scalers.NewNewRelicScaler(config)
+	case "object-storage":
+		return scalers.NewObjectStorageScaler(ctx, config)
+	case "opensearch":
+		return scalers.NewOpenSearchScaler(ctx, config)
 	case "openstack-metric":
 		return scalers.NewOpenstackMetricScaler(ctx, config)
 	case "openstack-swift":
 		return scalers.NewOpenstackSwiftScaler(config)
+	case "otel":
+		return scalers.NewOtelScaler(config)
 	case "postgresql":
 		return scalers.NewPostgreSQLScaler(ctx, config)
 	case "predictkube":
@@ -243,14 +284,26 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewRedisStreamsScaler(ctx, false, false, config)
 	case "selenium-grid":
 		return scalers.NewSeleniumGridScaler(config)
+	case "sftp":
+		return scalers.NewSftpScaler(config)
 	case "solace-event-queue":
 		return scalers.NewSolaceScaler(config)
 	case "solr":
 		return scalers.NewSolrScaler(config)
 	case "splunk":
 		return scalers.NewSplunkScaler(config)
+	case "splunk-observability":
+		return scalers.NewSplunkObservabilityScaler(config)
 	case "stan":
 		return scalers.NewStanScaler(config)
+	case "temporal":
+		return scalers.NewTemporalScaler(config)
+	case "webhook-push":
+		return scalers.NewWebhookPushScaler(config)
+	case "workflow-orchestrator":
+		return scalers.NewWorkflowOrchestratorScaler(config)
+	case "workqueue":
+		return scalers.NewWorkqueueScaler(config)
 	default:
 		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
 	}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+var (
+	scalersCacheMaxTriggers     int
+	scalersCacheMaxTriggersLock sync.RWMutex
+)
+
+// ConfigureScalersCache sets the maximum number of triggers whose scalers are kept in the scalers cache of the
+// scale handlers created afterwards, the scalers of the least recently used scalable objects are evicted, and
+// built again on their next use, once it's exceeded. Zero means unbounded.
+func ConfigureScalersCache(maxTriggers int) error {
+	if maxTriggers < 0 {
+		return fmt.Errorf("the maximum number of triggers of the scalers cache must not be negative, got %d", maxTriggers)
+	}
+	scalersCacheMaxTriggersLock.Lock()
+	defer scalersCacheMaxTriggersLock.Unlock()
+	scalersCacheMaxTriggers = maxTriggers
+	return nil
+}
+
+func getScalersCacheMaxTriggers() int {
+	scalersCacheMaxTriggersLock.RLock()
+	defer scalersCacheMaxTriggersLock.RUnlock()
+	return scalersCacheMaxTriggers
+}
+
+// scalersCacheLRU is the order of use of the scalers cached for the scalable objects, by identifier,
+// it determines which ones are evicted once the scalers cached exceed the maximum number of triggers
+type scalersCacheLRU struct {
+	maxTriggers int
+
+	lock sync.Mutex
+	// triggers is the number of triggers whose scalers are cached
+	triggers int
+	// order has the least recently used scalable object at its back
+	order   *list.List
+	entries map[string]*list.Element
+	// evicted are the scalable objects whose scalers were evicted and not built again yet
+	evicted map[string]bool
+}
+
+type scalersCacheLRUEntry struct {
+	key      string
+	triggers int
+}
+
+func newScalersCacheLRU(maxTriggers int) *scalersCacheLRU {
+	return &scalersCacheLRU{
+		maxTriggers: maxTriggers,
+		order:       list.New(),
+		entries:     map[string]*list.Element{},
+		evicted:     map[string]bool{},
+	}
+}
+
+// touch marks the scalers of the scalable object as the most recently used
+func (l *scalersCacheLRU) touch(key string) {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if element, found := l.entries[key]; found {
+		l.order.MoveToFront(element)
+	}
+}
+
+// add records the scalers built for the scalable object, it returns the scalable objects whose scalers have to be
+// evicted to keep the cache under its limit, and whether the scalers were built again after their eviction.
+// The scalers just built are never evicted, even if their triggers alone exceed the limit.
+func (l *scalersCacheLRU) add(key string, triggers int) ([]string, bool) {
+	if l == nil {
+		return nil, false
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	rebuilt := l.evicted[key]
+	delete(l.evicted, key)
+	if element, found := l.entries[key]; found {
+		entry := element.Value.(*scalersCacheLRUEntry)
+		l.triggers += triggers - entry.triggers
+		entry.triggers = triggers
+		l.order.MoveToFront(element)
+	} else {
+		l.entries[key] = l.order.PushFront(&scalersCacheLRUEntry{key: key, triggers: triggers})
+		l.triggers += triggers
+	}
+
+	var evicted []string
+	for l.maxTriggers > 0 && l.triggers > l.maxTriggers && l.order.Len() > 1 {
+		entry := l.order.Remove(l.order.Back()).(*scalersCacheLRUEntry)
+		delete(l.entries, entry.key)
+		l.triggers -= entry.triggers
+		l.evicted[entry.key] = true
+		evicted = append(evicted, entry.key)
+	}
+	return evicted, rebuilt
+}
+
+// remove forgets the scalers of the scalable object, once it's deleted or its scalers are cleared
+func (l *scalersCacheLRU) remove(key string) {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.evicted, key)
+	if element, found := l.entries[key]; found {
+		l.triggers -= element.Value.(*scalersCacheLRUEntry).triggers
+		l.order.Remove(element)
+		delete(l.entries, key)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+func TestScalersCacheLRU(t *testing.T) {
+	lru := newScalersCacheLRU(5)
+
+	evicted, rebuilt := lru.add("scaledobject.default.a", 2)
+	assert.Empty(t, evicted)
+	assert.False(t, rebuilt)
+	evicted, _ = lru.add("scaledobject.default.b", 2)
+	assert.Empty(t, evicted)
+
+	// the least recently used scalers are evicted once the limit is exceeded
+	lru.touch("scaledobject.default.a")
+	evicted, _ = lru.add("scaledjob.default.c", 1)
+	assert.Empty(t, evicted)
+	evicted, _ = lru.add("scaledobject.default.d", 2)
+	assert.Equal(t, []string{"scaledobject.default.b"}, evicted)
+	assert.Equal(t, 5, lru.triggers)
+
+	// the scalers evicted are counted as rebuilt on their next build
+	evicted, rebuilt = lru.add("scaledobject.default.b", 2)
+	assert.True(t, rebuilt)
+	assert.Equal(t, []string{"scaledobject.default.a"}, evicted)
+	evicted, rebuilt = lru.add("scaledobject.default.b", 3)
+	assert.False(t, rebuilt)
+	assert.Equal(t, []string{"scaledjob.default.c"}, evicted)
+
+	// the scalers just built are kept even if they exceed the limit alone
+	evicted, _ = lru.add("scaledobject.default.e", 10)
+	assert.Equal(t, []string{"scaledobject.default.d", "scaledobject.default.b"}, evicted)
+	assert.Equal(t, 10, lru.triggers)
+
+	// the scalers cleared aren't counted as rebuilt
+	lru.remove("scaledobject.default.a")
+	lru.remove("scaledobject.default.e")
+	_, rebuilt = lru.add("scaledobject.default.a", 1)
+	assert.False(t, rebuilt)
+	assert.Equal(t, 1, lru.triggers)
+}
+
+func TestScalersCacheLRUUnbounded(t *testing.T) {
+	lru := newScalersCacheLRU(0)
+	for _, key := range []string{"scaledobject.default.a", "scaledobject.default.b", "scaledobject.default.c"} {
+		evicted, _ := lru.add(key, 1000)
+		assert.Empty(t, evicted)
+	}
+
+	assert.Error(t, ConfigureScalersCache(-1))
+	assert.NoError(t, ConfigureScalersCache(0))
+}
+
+func TestScalersCacheEvictedOnceReleased(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	closed := make(chan struct{})
+	scalersCache := &cache.ScalersCache{Scalers: []cache.ScalerBuilder{{Scaler: scaler}}}
+
+	// the scalers evicted while they're evaluated are closed once the evaluations are done
+	release := scalersCache.Acquire()
+	scalersCache.Evict()
+	select {
+	case <-closed:
+		t.Fatal("the scalers were closed while used")
+	case <-time.After(100 * time.Millisecond):
+	}
+	scaler.EXPECT().Close(gomock.Any()).Do(func(context.Context) { close(closed) })
+	release()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("the scalers weren't closed once released")
+	}
+	release()
+}
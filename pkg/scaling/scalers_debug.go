@@ -0,0 +1,253 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/kedacore/keda/v2/pkg/metricshistory"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+// ScalersDebugPath is the path the state of the scalers cache is served on
+const ScalersDebugPath = "/debug/scalers"
+
+// Connection states of a cached scaler, they are derived from its last evaluation
+const (
+	ScalerConnectionUnknown   = "Unknown"
+	ScalerConnectionConnected = "Connected"
+	ScalerConnectionFailing   = "Failing"
+)
+
+const (
+	// a scaler rebuilt within this window is reported as recently rebuilt
+	recentRebuildWindow = 10 * time.Minute
+	configHashLength    = 16
+)
+
+// ScalableObjectScalers is the state of the cached scalers of a ScaledObject or a ScaledJob
+type ScalableObjectScalers struct {
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Generation int64  `json:"generation"`
+	// CreatedAt is when the scalers were built, the cache is built again after any scaler error
+	CreatedAt time.Time     `json:"createdAt"`
+	Scalers   []ScalerState `json:"scalers"`
+	// MetricsHistory are the last samples of the metrics of a ScaledObject, when the metrics history is enabled
+	MetricsHistory map[string][]metricshistory.Sample `json:"metricsHistory,omitempty"`
+}
+
+// ScalerState is the state of a cached scaler
+type ScalerState struct {
+	TriggerIndex int    `json:"triggerIndex"`
+	TriggerName  string `json:"triggerName,omitempty"`
+	TriggerType  string `json:"triggerType"`
+	// ConfigHash is a hash of the resolved metadata, authentication and environment of the trigger,
+	// it changes when the credentials used by the scaler change
+	ConfigHash      string     `json:"configHash"`
+	ConnectionState string     `json:"connectionState"`
+	LastEvaluation  *time.Time `json:"lastEvaluation,omitempty"`
+	LastError       string     `json:"lastError,omitempty"`
+	Rebuilds        int        `json:"rebuilds"`
+	RebuiltAt       *time.Time `json:"rebuiltAt,omitempty"`
+	RecentlyRebuilt bool       `json:"recentlyRebuilt"`
+}
+
+// GetScalersState returns the state of all the cached scalers sorted by the kind, namespace and name of their object
+func (h *scaleHandler) GetScalersState() []ScalableObjectScalers {
+	h.scalerCachesLock.RLock()
+	defer h.scalerCachesLock.RUnlock()
+
+	now := time.Now()
+	result := make([]ScalableObjectScalers, 0, len(h.scalerCaches))
+	for key, scalersCache := range h.scalerCaches {
+		// the identifier is kind.namespace.name, neither the kind nor the namespace contain a dot
+		parts := strings.SplitN(key, ".", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		object := ScalableObjectScalers{
+			Kind:       parts[0],
+			Namespace:  parts[1],
+			Name:       parts[2],
+			Generation: scalersCache.ScalableObjectGeneration,
+			CreatedAt:  scalersCache.CreatedAt,
+		}
+		// the configs are copied, the scalers rebuilt after an error are replaced concurrently
+		_, configs := scalersCache.GetScalers()
+		object.Scalers = make([]ScalerState, 0, len(configs))
+		for index, scalerConfig := range configs {
+			object.Scalers = append(object.Scalers, newScalerState(index, scalerConfig, scalersCache.GetScalerStats(index), now))
+		}
+		if scalersCache.ScaledObject != nil {
+			object.MetricsHistory = metricshistory.Get(object.Namespace, object.Name)
+		}
+		result = append(result, object)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func newScalerState(index int, scalerConfig scalersconfig.ScalerConfig, stats cache.ScalerStats, now time.Time) ScalerState {
+	state := ScalerState{
+		TriggerIndex:    index,
+		TriggerName:     scalerConfig.TriggerName,
+		TriggerType:     scalerConfig.TriggerType,
+		ConfigHash:      hashScalerConfig(scalerConfig),
+		ConnectionState: ScalerConnectionUnknown,
+		Rebuilds:        stats.Rebuilds,
+	}
+	if !stats.LastEvaluation.IsZero() {
+		lastEvaluation := stats.LastEvaluation
+		state.LastEvaluation = &lastEvaluation
+		state.ConnectionState = ScalerConnectionConnected
+		if stats.LastError != nil {
+			state.ConnectionState = ScalerConnectionFailing
+			state.LastError = redactTriggerMetadata(stats.LastError.Error(), scalerConfig)
+		}
+	}
+	if !stats.RebuiltAt.IsZero() {
+		rebuiltAt := stats.RebuiltAt
+		state.RebuiltAt = &rebuiltAt
+		state.RecentlyRebuilt = now.Sub(rebuiltAt) < recentRebuildWindow
+	}
+	return state
+}
+
+// configHashKey keys the hashes of the configurations, so the secrets they contain can't be
+// brute-forced from a published hash and the metadata readable on the ScaledObject.
+// The hashes are only comparable within a process.
+var configHashKey = func() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}()
+
+// hashScalerConfig hashes the resolved configuration of the trigger, so it can be compared
+// without exposing the secrets it contains
+func hashScalerConfig(scalerConfig scalersconfig.ScalerConfig) string {
+	// the keys of the maps are sorted by the encoding, so the hash is stable
+	data, _ := json.Marshal([]interface{}{
+		scalerConfig.TriggerType,
+		scalerConfig.TriggerMetadata,
+		scalerConfig.AuthParams,
+		scalerConfig.ResolvedEnv,
+		scalerConfig.PodIdentity,
+	})
+	mac := hmac.New(sha256.New, configHashKey)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))[:configHashLength]
+}
+
+// NewScalersDebugHandler returns the handler serving the state of the scalers cache as JSON,
+// the state can be restricted to a namespace with the namespace query parameter
+func NewScalersDebugHandler(scaleHandler ScaleHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		states := scaleHandler.GetScalersState()
+		if namespace := r.URL.Query().Get("namespace"); namespace != "" {
+			filtered := make([]ScalableObjectScalers, 0, len(states))
+			for _, state := range states {
+				if state.Namespace == namespace {
+					filtered = append(filtered, state)
+				}
+			}
+			states = filtered
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(states); err != nil {
+			log.Error(err, "error encoding the state of the scalers cache")
+		}
+	})
+}
+
+// scalersDebugServer serves the state of the scalers cache over TLS, on every replica as each one
+// caches the scalers of the objects it evaluates
+type scalersDebugServer struct {
+	bindAddress string
+	tlsConfig   *tls.Config
+	handler     http.Handler
+}
+
+// NewScalersDebugServer returns the runnable serving the handler on ScalersDebugPath over TLS, the
+// state of the scalers cache reveals the configuration of the triggers so it isn't served in clear
+func NewScalersDebugServer(bindAddress string, tlsConfig *tls.Config, handler http.Handler) manager.Runnable {
+	return &scalersDebugServer{bindAddress: bindAddress, tlsConfig: tlsConfig, handler: handler}
+}
+
+func (s *scalersDebugServer) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.bindAddress)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle(ScalersDebugPath, s.handler)
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig:         s.tlsConfig,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Error(err, "error stopping the scalers debug server")
+		}
+	}()
+	if err := server.ServeTLS(listener, "", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *scalersDebugServer) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
+)
+
+// EvaluateTrigger builds the scaler of the trigger of the TriggerEvaluation the same way it
+// would be built for a ScaledObject, queries its metrics once and closes it.
+// The failures are reported in the returned status, with the resolved values redacted.
+func (h *scaleHandler) EvaluateTrigger(ctx context.Context, evaluation *kedav1alpha1.TriggerEvaluation) kedav1alpha1.TriggerEvaluationStatus {
+	now := metav1.Now()
+	status := kedav1alpha1.TriggerEvaluationStatus{ObservedGeneration: evaluation.Generation, EvaluationTime: &now}
+	trigger := evaluation.Spec.Trigger
+
+	var podTemplateSpec *corev1.PodTemplateSpec
+	var containerName string
+	if evaluation.Spec.ScaleTargetRef != nil {
+		var err error
+		podTemplateSpec, containerName, err = resolver.ResolveScaleTargetPodSpec(ctx, h.client, scaleTargetScaledObject(evaluation))
+		if err != nil {
+			status.Error = fmt.Sprintf("error resolving the scale target: %s", err)
+			return status
+		}
+	}
+
+	withTriggers := &kedav1alpha1.WithTriggers{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kedav1alpha1.GroupVersion.String(), Kind: "TriggerEvaluation"},
+		ObjectMeta: evaluation.ObjectMeta,
+		Spec:       kedav1alpha1.WithTriggersSpec{Triggers: []kedav1alpha1.ScaleTriggers{trigger}},
+	}
+	builders, err := h.buildScalers(ctx, withTriggers, podTemplateSpec, containerName, false)
+	if err != nil {
+		status.Error = redactTriggerMetadata(err.Error(), scalersconfig.ScalerConfig{TriggerMetadata: trigger.Metadata})
+		return status
+	}
+	defer builders[0].Scaler.Close(ctx)
+	evaluateScaler(ctx, builders[0], &status)
+	return status
+}
+
+// evaluateScaler queries the metrics of the scaler and fills the status with them, the trigger is
+// active if any of its metrics is and its activity is unknown if any of them failed
+func evaluateScaler(ctx context.Context, builder cache.ScalerBuilder, status *kedav1alpha1.TriggerEvaluationStatus) {
+	status.ResolvedMetadata = resolvedTriggerMetadata(builder.ScalerConfig)
+
+	isActive := false
+	for _, spec := range builder.Scaler.GetMetricSpecForScaling(ctx) {
+		// the resource metrics are computed by the HPA, the scaler doesn't return them
+		if spec.External == nil {
+			continue
+		}
+		metricName := spec.External.Metric.Name
+		metric := kedav1alpha1.TriggerEvaluationMetric{MetricName: metricName, Target: metricTargetQuantity(spec)}
+
+		values, metricActive, err := builder.Scaler.GetMetricsAndActivity(ctx, metricName)
+		if err != nil {
+			metric.Error = redactTriggerMetadata(err.Error(), builder.ScalerConfig)
+			if status.Error == "" {
+				status.Error = metric.Error
+			}
+		} else {
+			metric.IsActive = metricActive
+			isActive = isActive || metricActive
+			if len(values) > 0 {
+				value := values[0].Value.DeepCopy()
+				metric.Value = &value
+			}
+		}
+		status.Metrics = append(status.Metrics, metric)
+	}
+	if status.Error == "" {
+		status.IsActive = &isActive
+	}
+}
+
+// resolvedTriggerMetadata returns the metadata of the trigger with the parameters resolved from the
+// environment and the authentication, those are redacted as they usually hold credentials
+func resolvedTriggerMetadata(scalerConfig scalersconfig.ScalerConfig) map[string]string {
+	result := make(map[string]string, len(scalerConfig.TriggerMetadata)+len(scalerConfig.AuthParams))
+	for key, value := range scalerConfig.TriggerMetadata {
+		result[key] = value
+		if parameter, found := strings.CutSuffix(key, "FromEnv"); found {
+			if _, resolved := scalerConfig.ResolvedEnv[value]; resolved {
+				result[parameter] = redactedValue
+			}
+		}
+	}
+	for key := range scalerConfig.AuthParams {
+		result[key] = redactedValue
+	}
+	return result
+}
+
+// metricTargetQuantity returns the target of the external metric, nil if it has none
+func metricTargetQuantity(spec v2.MetricSpec) *resource.Quantity {
+	var target *resource.Quantity
+	switch {
+	case spec.External.Target.AverageValue != nil:
+		target = spec.External.Target.AverageValue
+	case spec.External.Target.Value != nil:
+		target = spec.External.Target.Value
+	default:
+		return nil
+	}
+	result := target.DeepCopy()
+	return &result
+}
+
+// scaleTargetScaledObject returns a ScaledObject scaling the scale target of the TriggerEvaluation,
+// so its pod template is resolved like for a ScaledObject, the target defaults to a Deployment
+func scaleTargetScaledObject(evaluation *kedav1alpha1.TriggerEvaluation) *kedav1alpha1.ScaledObject {
+	scaleTargetRef := *evaluation.Spec.ScaleTargetRef
+	apiVersion, kind := scaleTargetRef.APIVersion, scaleTargetRef.Kind
+	if apiVersion == "" {
+		apiVersion = "apps/v1"
+	}
+	if kind == "" {
+		kind = "Deployment"
+	}
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	return &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: evaluation.Name, Namespace: evaluation.Namespace},
+		Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &scaleTargetRef},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &kedav1alpha1.GroupVersionKindResource{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+		},
+	}
+}
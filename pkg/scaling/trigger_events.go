@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	eventingv1alpha1 "github.com/kedacore/keda/v2/apis/eventing/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/audit"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/notification"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+)
+
+const (
+	redactedValue = "[REDACTED]"
+	// values shorter than this are not redacted, replacing them would mangle
+	// the message (eg. "1" or "true") without hiding anything sensitive
+	minRedactedLength = 4
+)
+
+// triggerState is the last observed state of a trigger, it is used to emit
+// the CloudEvents only on the transitions
+type triggerState struct {
+	isActive          bool
+	isFailing         bool
+	consecutiveErrors int
+}
+
+type triggerEventTypes struct {
+	activated   eventingv1alpha1.CloudEventType
+	deactivated eventingv1alpha1.CloudEventType
+	failed      eventingv1alpha1.CloudEventType
+}
+
+var (
+	scaledObjectTriggerEventTypes = triggerEventTypes{
+		activated:   eventingv1alpha1.ScaledObjectTriggerActivatedType,
+		deactivated: eventingv1alpha1.ScaledObjectTriggerDeactivatedType,
+		failed:      eventingv1alpha1.ScaledObjectTriggerFailedType,
+	}
+	scaledJobTriggerEventTypes = triggerEventTypes{
+		activated:   eventingv1alpha1.ScaledJobTriggerActivatedType,
+		deactivated: eventingv1alpha1.ScaledJobTriggerDeactivatedType,
+		failed:      eventingv1alpha1.ScaledJobTriggerFailedType,
+	}
+)
+
+// emitTriggerEvents compares the outcome of the trigger with its previous one and emits
+// the activation, deactivation and failure events of the trigger on the transitions,
+// the trigger is notified once it has failed for the configured number of consecutive times
+func (h *scaleHandler) emitTriggerEvents(object runtime.Object, identifier string, isScaledObject bool, scalerConfig scalersconfig.ScalerConfig, input audit.TriggerInput, err error) {
+	if h.triggerStates == nil || (h.eventEmitter == nil && !notification.Enabled()) {
+		return
+	}
+
+	eventTypes := scaledJobTriggerEventTypes
+	if isScaledObject {
+		eventTypes = scaledObjectTriggerEventTypes
+	}
+
+	key := fmt.Sprintf("%s/%d", identifier, input.Index)
+	previous := triggerState{}
+	if value, ok := h.triggerStates.Load(key); ok {
+		previous = value.(triggerState)
+	}
+
+	// the activity of a failing trigger is unknown, so it is kept as it was
+	current := triggerState{isActive: previous.isActive, isFailing: err != nil}
+	if err == nil {
+		current.isActive = input.IsActive
+	} else {
+		current.consecutiveErrors = previous.consecutiveErrors + 1
+	}
+	h.triggerStates.Store(key, current)
+
+	trigger := describeTrigger(input)
+	namespace := scalerConfig.ScalableObjectNamespace
+	// the notifications are rate limited, so they are sent again while the trigger keeps failing
+	if current.consecutiveErrors >= notification.TriggerErrorThreshold() {
+		notification.Notify(&notification.Notification{
+			Reason:    notification.ReasonTriggerError,
+			Kind:      scalerConfig.ScalableObjectType,
+			Namespace: namespace,
+			Name:      scalerConfig.ScalableObjectName,
+			Trigger:   fmt.Sprintf("%d", input.Index),
+			Message:   fmt.Sprintf("%s failed %d times in a row: %s", trigger, current.consecutiveErrors, redactTriggerMetadata(err.Error(), scalerConfig)),
+		})
+	}
+
+	if h.eventEmitter == nil {
+		return
+	}
+	annotations := triggerAnnotations(input)
+	switch {
+	case current.isFailing && !previous.isFailing:
+		message := fmt.Sprintf("%s failed: %s", trigger, redactTriggerMetadata(err.Error(), scalerConfig))
+		h.eventEmitter.EmitWithAnnotations(object, namespace, corev1.EventTypeWarning, eventTypes.failed, eventreason.KEDAScalerFailed, message, annotations)
+	case current.isActive && !previous.isActive:
+		h.eventEmitter.EmitWithAnnotations(object, namespace, corev1.EventTypeNormal, eventTypes.activated, eventreason.KEDATriggerActivated, fmt.Sprintf("%s is active", trigger), annotations)
+	case !current.isActive && previous.isActive:
+		h.eventEmitter.EmitWithAnnotations(object, namespace, corev1.EventTypeNormal, eventTypes.deactivated, eventreason.KEDATriggerDeactivated, fmt.Sprintf("%s is no longer active", trigger), annotations)
+	}
+}
+
+// emitFallbackEvents emits the fallback events of the ScaledObject when its Fallback condition changes
+func (h *scaleHandler) emitFallbackEvents(object runtime.Object, namespace, name string, wasFallbackActive, isFallbackActive bool) {
+	if wasFallbackActive == isFallbackActive {
+		return
+	}
+	if isFallbackActive {
+		notification.Notify(&notification.Notification{
+			Reason:    notification.ReasonFallback,
+			Kind:      "ScaledObject",
+			Namespace: namespace,
+			Name:      name,
+			Message:   "At least one trigger is falling back on this scaled object",
+		})
+	}
+	if h.eventEmitter == nil {
+		return
+	}
+	if isFallbackActive {
+		h.eventEmitter.Emit(object, namespace, corev1.EventTypeWarning, eventingv1alpha1.ScaledObjectFallbackEnteredType, eventreason.KEDAFallbackEntered, "At least one trigger is falling back on this scaled object")
+	} else {
+		h.eventEmitter.Emit(object, namespace, corev1.EventTypeNormal, eventingv1alpha1.ScaledObjectFallbackExitedType, eventreason.KEDAFallbackExited, "No fallbacks are active on this scaled object")
+	}
+}
+
+// clearTriggerStates forgets the trigger states of the ScaledObject or ScaledJob
+func (h *scaleHandler) clearTriggerStates(identifier string) {
+	if h.triggerStates == nil {
+		return
+	}
+	prefix := identifier + "/"
+	h.triggerStates.Range(func(key, _ interface{}) bool {
+		if strings.HasPrefix(key.(string), prefix) {
+			h.triggerStates.Delete(key)
+		}
+		return true
+	})
+}
+
+func describeTrigger(input audit.TriggerInput) string {
+	if input.Name != "" {
+		return fmt.Sprintf("trigger %q (%s, index %d)", input.Name, input.Type, input.Index)
+	}
+	return fmt.Sprintf("trigger %d (%s)", input.Index, input.Type)
+}
+
+// triggerAnnotations describes the trigger and the values of its metrics in the annotations of its events
+func triggerAnnotations(input audit.TriggerInput) map[string]string {
+	annotations := map[string]string{
+		eventreason.TriggerIndexAnnotation: strconv.Itoa(input.Index),
+		eventreason.TriggerTypeAnnotation:  input.Type,
+	}
+	if input.Name != "" {
+		annotations[eventreason.TriggerNameAnnotation] = input.Name
+	}
+	if len(input.Metrics) > 0 {
+		if values, err := json.Marshal(input.Metrics); err == nil {
+			annotations[eventreason.MetricValuesAnnotation] = string(values)
+		}
+	}
+	return annotations
+}
+
+// redactTriggerMetadata replaces the values of the trigger metadata, the authentication
+// parameters and the resolved environment found in the message, as scaler errors
+// often quote connection strings or credentials
+func redactTriggerMetadata(message string, scalerConfig scalersconfig.ScalerConfig) string {
+	var values []string
+	for _, params := range []map[string]string{scalerConfig.TriggerMetadata, scalerConfig.AuthParams, scalerConfig.ResolvedEnv} {
+		for _, value := range params {
+			if len(value) >= minRedactedLength {
+				values = append(values, value)
+			}
+		}
+	}
+	// the longest values first, so a value containing another one is redacted as a whole
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+	for _, value := range values {
+		message = strings.ReplaceAll(message, value, redactedValue)
+	}
+	return message
+}
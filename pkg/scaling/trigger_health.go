@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedastatus "github.com/kedacore/keda/v2/pkg/status"
+)
+
+// metricHealth is the outcome of the last poll of a metric of a trigger
+type metricHealth struct {
+	value    *resource.Quantity
+	isActive bool
+	err      error
+}
+
+func newMetricHealth(metricName string, metrics []external_metrics.ExternalMetricValue, isActive bool, err error) metricHealth {
+	health := metricHealth{isActive: isActive, err: err}
+	if err != nil {
+		return health
+	}
+	for _, metric := range metrics {
+		if metric.MetricName == metricName || len(metrics) == 1 {
+			value := metric.Value.DeepCopy()
+			health.value = &value
+			break
+		}
+	}
+	return health
+}
+
+// updateTriggersHealth records the outcome of the last poll of the metrics to the health of
+// the ScaledObject status, the status is patched only when it has changed
+func (h *scaleHandler) updateTriggersHealth(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, metricsHealth map[string]metricHealth) {
+	if len(metricsHealth) == 0 {
+		return
+	}
+
+	status := scaledObject.Status.DeepCopy()
+	// with a fallback the failures are counted when the metrics are served to the HPA,
+	// counting them here as well would reach the failure threshold twice as fast
+	setHealthStatus(status, metricsHealth, scaledObject.Spec.Fallback == nil, metav1.Now())
+	if equality.Semantic.DeepEqual(scaledObject.Status, *status) {
+		return
+	}
+	if err := kedastatus.UpdateScaledObjectStatus(ctx, h.client, logger, scaledObject, status); err != nil {
+		logger.Error(err, "error updating the health of the triggers")
+	}
+}
+
+// setHealthStatus updates the health of the metrics in the status, the value, activation and
+// time of a metric are the ones of its last successful poll
+func setHealthStatus(status *kedav1alpha1.ScaledObjectStatus, metricsHealth map[string]metricHealth, countFailures bool, now metav1.Time) {
+	if status.Health == nil {
+		status.Health = make(map[string]kedav1alpha1.HealthStatus, len(metricsHealth))
+	}
+	for metricName, health := range metricsHealth {
+		healthStatus := status.Health[metricName]
+		if health.err == nil {
+			isActive := health.isActive
+			healthStatus.LastValue = health.value
+			healthStatus.LastSuccessTime = &now
+			healthStatus.IsActive = &isActive
+		}
+
+		if countFailures {
+			failures := int32(0)
+			if health.err != nil {
+				if healthStatus.NumberOfFailures != nil {
+					failures = *healthStatus.NumberOfFailures
+				}
+				failures++
+			}
+			healthStatus.NumberOfFailures = &failures
+			healthStatus.Status = kedav1alpha1.HealthStatusHappy
+			if health.err != nil {
+				healthStatus.Status = kedav1alpha1.HealthStatusFailing
+			}
+		}
+		status.Health[metricName] = healthStatus
+	}
+}
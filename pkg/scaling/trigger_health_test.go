@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+)
+
+func TestSetHealthStatus(t *testing.T) {
+	now := metav1.Now()
+	value := resource.MustParse("12")
+	status := &kedav1alpha1.ScaledObjectStatus{}
+
+	setHealthStatus(status, map[string]metricHealth{
+		"s0-queue": {value: &value, isActive: true},
+	}, true, now)
+	health := status.Health["s0-queue"]
+	assert.Equal(t, kedav1alpha1.HealthStatusHappy, health.Status)
+	assert.Equal(t, int32(0), *health.NumberOfFailures)
+	assert.Equal(t, "12", health.LastValue.String())
+	assert.Equal(t, now, *health.LastSuccessTime)
+	assert.True(t, *health.IsActive)
+
+	// the failures are counted and the last successful poll is kept
+	failure := map[string]metricHealth{"s0-queue": {err: errors.New("connection refused")}}
+	setHealthStatus(status, failure, true, metav1.Now())
+	setHealthStatus(status, failure, true, metav1.Now())
+	health = status.Health["s0-queue"]
+	assert.Equal(t, kedav1alpha1.HealthStatusFailing, health.Status)
+	assert.Equal(t, int32(2), *health.NumberOfFailures)
+	assert.Equal(t, "12", health.LastValue.String())
+	assert.Equal(t, now, *health.LastSuccessTime)
+	assert.True(t, *health.IsActive)
+
+	// the failures are left to the fallback
+	setHealthStatus(status, failure, false, metav1.Now())
+	assert.Equal(t, int32(2), *status.Health["s0-queue"].NumberOfFailures)
+}
+
+func TestNewMetricHealth(t *testing.T) {
+	metrics := []external_metrics.ExternalMetricValue{
+		{MetricName: "s0-other", Value: resource.MustParse("1")},
+		{MetricName: "s0-queue", Value: resource.MustParse("5")},
+	}
+	assert.Equal(t, "5", newMetricHealth("s0-queue", metrics, true, nil).value.String())
+	assert.Nil(t, newMetricHealth("s0-queue", metrics, true, errors.New("error")).value)
+	assert.Nil(t, newMetricHealth("s0-queue", nil, false, nil).value)
+}
+
+func TestUpdateTriggersHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_client.NewMockClient(ctrl)
+	mockStatusWriter := mock_client.NewMockStatusWriter(ctrl)
+	sh := scaleHandler{client: mockClient}
+
+	zero := int32(0)
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "so", Namespace: "default"},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			Health: map[string]kedav1alpha1.HealthStatus{
+				"s0-queue": {NumberOfFailures: &zero, Status: kedav1alpha1.HealthStatusHappy},
+			},
+		},
+	}
+
+	mockClient.EXPECT().Status().Return(mockStatusWriter)
+	mockStatusWriter.EXPECT().Patch(gomock.Any(), scaledObject, gomock.Any())
+	sh.updateTriggersHealth(context.TODO(), logr.Discard(), scaledObject, map[string]metricHealth{"s0-queue": {err: errors.New("error")}})
+	assert.Equal(t, kedav1alpha1.HealthStatusFailing, scaledObject.Status.Health["s0-queue"].Status)
+
+	// nothing to patch without polled metrics or with an unchanged health
+	sh.updateTriggersHealth(context.TODO(), logr.Discard(), scaledObject, nil)
+	scaledObject.Spec.Fallback = &kedav1alpha1.Fallback{}
+	sh.updateTriggersHealth(context.TODO(), logr.Discard(), scaledObject, map[string]metricHealth{"s0-queue": {err: errors.New("error")}})
+}
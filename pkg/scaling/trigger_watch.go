@@ -0,0 +1,239 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+// podChangesEvaluationInterval is the minimum interval between the evaluations of the triggers of a
+// scalable object caused by the changes of its pods, the changes in between are coalesced
+const podChangesEvaluationInterval = time.Second
+
+// podWatcher notifies the scale loops of the scalable objects whose watch scalers are evaluated from
+// the pods changed, so their triggers are evaluated without waiting for the polling interval.
+// The pods are read from the informer of the manager cache the scalers listing pods already read through,
+// it holds all the pods of the namespaces watched by the operator. It's only started by the first scalable
+// object with a watch scaler, so the operators without any don't pay its memory.
+type podWatcher struct {
+	informers ctrlcache.Informers
+
+	// started is whether the handler of the pod events is registered
+	started bool
+	lock    sync.RWMutex
+	watches map[string]*podWatch
+}
+
+// podWatch are the pods the watch scalers of a scalable object are evaluated from
+type podWatch struct {
+	// scalers are the scalers the selections were read from, they're read again once the scalers are rebuilt
+	scalers    []scalers.Scaler
+	selections []podSelection
+	changed    chan struct{}
+}
+
+type podSelection struct {
+	namespace string
+	selector  labels.Selector
+}
+
+// newPodWatcher returns a watcher of the pods of the informers, the triggers are only polled if they are nil
+func newPodWatcher(informers ctrlcache.Informers) *podWatcher {
+	if informers == nil {
+		return nil
+	}
+	return &podWatcher{
+		informers: informers,
+		watches:   map[string]*podWatch{},
+	}
+}
+
+// watch starts watching the pods the watch scalers of the scalable object are evaluated from, the channel returned
+// receives their changes. The pods are selected by update, nothing is received until then. The channel is nil, and
+// so never receives, if the triggers are only polled. The function returned stops watching them.
+func (w *podWatcher) watch(key string) (<-chan struct{}, func()) {
+	if w == nil {
+		return nil, func() {}
+	}
+	watch := &podWatch{changed: make(chan struct{}, 1)}
+	w.lock.Lock()
+	w.watches[key] = watch
+	w.lock.Unlock()
+	return watch.changed, func() {
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		if w.watches[key] == watch {
+			delete(w.watches, key)
+		}
+	}
+}
+
+// update selects the pods watched for the scalable object from its watch scalers, once they're built or rebuilt
+// with the cache or by the cache after an error. The pods selected by the previous scalers are kept watched if
+// the selections can't be read.
+func (w *podWatcher) update(ctx context.Context, key string, cache *cache.ScalersCache, logger logr.Logger) {
+	if w == nil {
+		return
+	}
+	var watchScalers []scalers.Scaler
+	allScalers, _ := cache.GetScalers()
+	for _, scaler := range allScalers {
+		if _, ok := scaler.(scalers.WatchScaler); ok {
+			watchScalers = append(watchScalers, scaler)
+		}
+	}
+
+	w.lock.RLock()
+	watch, found := w.watches[key]
+	unchanged := found && slices.Equal(watch.scalers, watchScalers)
+	w.lock.RUnlock()
+	if !found || unchanged {
+		return
+	}
+
+	var selections []podSelection
+	for _, scaler := range watchScalers {
+		namespace, selector, err := scaler.(scalers.WatchScaler).GetWatchedPods(ctx)
+		if err != nil {
+			logger.Error(err, "error getting the pods watched by the scaler, it's only polled")
+			continue
+		}
+		selections = append(selections, podSelection{namespace: namespace, selector: selector})
+	}
+	if len(selections) > 0 {
+		if err := w.start(ctx); err != nil {
+			// the selections are read again on the next update
+			logger.Error(err, "error watching the pods, the triggers are only polled")
+			return
+		}
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	watch.scalers = watchScalers
+	watch.selections = selections
+}
+
+// start registers the handler of the pod events once, it's tried again on the next update if it fails
+func (w *podWatcher) start(ctx context.Context) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.started {
+		return nil
+	}
+	informer, err := w.informers.GetInformer(ctx, &corev1.Pod{})
+	if err != nil {
+		return fmt.Errorf("error getting the pod informer: %w", err)
+	}
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: w.notify,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if podChanged(oldObj, newObj) {
+				w.notify(oldObj)
+				w.notify(newObj)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			w.notify(obj)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error handling the pod events: %w", err)
+	}
+	w.started = true
+	return nil
+}
+
+// notify notifies the scale loops watching the pod it changed
+func (w *podWatcher) notify(obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return
+	}
+	podLabels := labels.Set(pod.Labels)
+
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	for _, watch := range w.watches {
+		if !watch.matches(pod.Namespace, podLabels) {
+			continue
+		}
+		// the scale loop is already notified if the channel is full
+		select {
+		case watch.changed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (watch *podWatch) matches(namespace string, podLabels labels.Set) bool {
+	for _, selection := range watch.selections {
+		if selection.namespace == namespace && selection.selector.Matches(podLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// podChanged returns whether the update of a pod can change the triggers evaluated from it, the updates
+// of its status other than its phase don't
+func podChanged(oldObj, newObj interface{}) bool {
+	oldPod, ok := oldObj.(*corev1.Pod)
+	if !ok {
+		return true
+	}
+	newPod, ok := newObj.(*corev1.Pod)
+	if !ok {
+		return true
+	}
+	return oldPod.Status.Phase != newPod.Status.Phase ||
+		!labels.Equals(oldPod.Labels, newPod.Labels) ||
+		(oldPod.DeletionTimestamp == nil) != (newPod.DeletionTimestamp == nil)
+}
+
+// waitPodChangesEvaluationInterval waits for the evaluation interval of the pod changes to elapse since the last
+// evaluation, it returns false if the context is done in the meantime
+func waitPodChangesEvaluationInterval(ctx context.Context, lastEvaluation time.Time) bool {
+	wait := podChangesEvaluationInterval - time.Since(lastEvaluation)
+	if wait <= 0 {
+		return true
+	}
+	tmr := time.NewTimer(wait)
+	defer tmr.Stop()
+	select {
+	case <-tmr.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/pkg/scalers"
+	"github.com/kedacore/keda/v2/pkg/scalers/scalersconfig"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+func TestPodWatcher(t *testing.T) {
+	ctx := context.Background()
+	informers := &informertest.FakeInformers{}
+	watcher := newPodWatcher(informers)
+
+	workloadScaler, err := scalers.NewKubernetesWorkloadScaler(fake.NewClientBuilder().Build(), &scalersconfig.ScalerConfig{
+		TriggerMetadata:         map[string]string{"podSelector": "app=worker", "value": "1"},
+		ScalableObjectNamespace: "default",
+	})
+	assert.NoError(t, err)
+	scalersCache := &cache.ScalersCache{
+		Scalers: []cache.ScalerBuilder{{Scaler: workloadScaler}},
+	}
+
+	changed, stopWatching := watcher.watch("scaledobject.default.worker")
+	assert.NotNil(t, changed)
+	watcher.update(ctx, "scaledobject.default.worker", scalersCache, logr.Discard())
+
+	informer, err := informers.FakeInformerFor(ctx, &corev1.Pod{})
+	assert.NoError(t, err)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Namespace: "default", Labels: map[string]string{"app": "worker"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+
+	// the pods watched created are notified
+	informer.Add(pod)
+	assertNotified(t, changed, true)
+
+	// the pods of other selectors or namespaces aren't
+	informer.Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", Labels: map[string]string{"app": "web"}}})
+	informer.Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Namespace: "other", Labels: map[string]string{"app": "worker"}}})
+	assertNotified(t, changed, false)
+
+	// the updates of their status other than their phase aren't
+	updated := pod.DeepCopy()
+	updated.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}}
+	informer.Update(pod, updated)
+	assertNotified(t, changed, false)
+
+	// the changes of their phase are
+	running := updated.DeepCopy()
+	running.Status.Phase = corev1.PodRunning
+	informer.Update(updated, running)
+	assertNotified(t, changed, true)
+
+	// the changes are coalesced while the scale loop doesn't receive them
+	informer.Delete(running)
+	informer.Add(pod)
+	assertNotified(t, changed, true)
+	assertNotified(t, changed, false)
+
+	// the pods are selected again once the scaler is rebuilt
+	rebuiltScaler, err := scalers.NewKubernetesWorkloadScaler(fake.NewClientBuilder().Build(), &scalersconfig.ScalerConfig{
+		TriggerMetadata:         map[string]string{"podSelector": "app=web", "value": "1"},
+		ScalableObjectNamespace: "default",
+	})
+	assert.NoError(t, err)
+	scalersCache.Scalers[0].Scaler = rebuiltScaler
+	watcher.update(ctx, "scaledobject.default.worker", scalersCache, logr.Discard())
+	informer.Delete(pod)
+	assertNotified(t, changed, false)
+	informer.Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default", Labels: map[string]string{"app": "web"}}})
+	assertNotified(t, changed, true)
+
+	// the pods aren't watched anymore once stopped
+	stopWatching()
+	informer.Delete(pod)
+	assertNotified(t, changed, false)
+}
+
+func TestPodWatcherWithoutWatchScalers(t *testing.T) {
+	ctx := context.Background()
+	informers := &informertest.FakeInformers{}
+	watcher := newPodWatcher(informers)
+	changed, stopWatching := watcher.watch("scaledobject.default.worker")
+	watcher.update(ctx, "scaledobject.default.worker", &cache.ScalersCache{}, logr.Discard())
+	assert.False(t, watcher.started, "the pods aren't watched without watch scalers")
+	stopWatching()
+	assertNotified(t, changed, false)
+
+	// the triggers are only polled without informers
+	var disabled *podWatcher
+	changed, stopWatching = disabled.watch("scaledobject.default.worker")
+	disabled.update(ctx, "scaledobject.default.worker", &cache.ScalersCache{}, logr.Discard())
+	assert.Nil(t, changed)
+	stopWatching()
+	assert.Nil(t, newPodWatcher(nil))
+}
+
+func TestWaitPodChangesEvaluationInterval(t *testing.T) {
+	assert.True(t, waitPodChangesEvaluationInterval(context.Background(), time.Now().Add(-podChangesEvaluationInterval)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, waitPodChangesEvaluationInterval(ctx, time.Now()))
+}
+
+func assertNotified(t *testing.T, changed <-chan struct{}, notified bool) {
+	t.Helper()
+	select {
+	case <-changed:
+		assert.True(t, notified, "the change of the pods shouldn't be notified")
+	default:
+		assert.False(t, notified, "the change of the pods should be notified")
+	}
+}
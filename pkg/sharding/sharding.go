@@ -0,0 +1,371 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharding spreads the ScaledObjects and ScaledJobs across the replicas of the operator.
+// The objects are hashed into a fixed number of shards, each shard is held by a replica through a
+// lease and the replicas announce themselves through their own member lease, so each of them
+// claims its share of the shards and hands the extra ones over when more replicas join.
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typedcoordinationv1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/utils/ptr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("sharding")
+
+const (
+	setLabel   = "sharding.keda.sh/set"
+	roleLabel  = "sharding.keda.sh/role"
+	shardLabel = "sharding.keda.sh/shard"
+	roleMember = "member"
+	roleShard  = "shard"
+
+	// the member leases of the replicas gone for this many lease durations are deleted
+	memberGarbageCollectionDurations = 10
+	releaseTimeout                   = 10 * time.Second
+)
+
+// Options configure the sharding
+type Options struct {
+	// Shards is the number of shards the ScaledObjects and ScaledJobs are spread across
+	Shards int
+	// Name prefixes the names of the leases
+	Name string
+	// Namespace is the namespace of the leases
+	Namespace string
+	// Identity identifies the replica holding the leases, it must be unique across the replicas
+	Identity string
+	// LeaseDuration is how long a shard is held once its lease has been renewed
+	LeaseDuration time.Duration
+	// RenewPeriod is how often the leases are renewed and the shards are balanced, it must be shorter than LeaseDuration
+	RenewPeriod time.Duration
+}
+
+// Coordinator claims the shards of the replica, a nil coordinator owns all the objects
+type Coordinator struct {
+	client    typedcoordinationv1.LeasesGetter
+	opts      Options
+	now       func() time.Time
+	lock      sync.RWMutex
+	owned     map[int]time.Time
+	listeners []func(shards []int)
+}
+
+// NewCoordinator returns a coordinator holding the leases through the client, it has to be started
+func NewCoordinator(client typedcoordinationv1.LeasesGetter, opts Options) (*Coordinator, error) {
+	if opts.Shards <= 0 {
+		return nil, fmt.Errorf("the number of shards must be positive, got %d", opts.Shards)
+	}
+	if opts.Name == "" || opts.Namespace == "" || opts.Identity == "" {
+		return nil, fmt.Errorf("the name, namespace and identity of the sharding must be set")
+	}
+	if opts.RenewPeriod <= 0 || opts.RenewPeriod >= opts.LeaseDuration {
+		return nil, fmt.Errorf("the renew period %s of the shards must be positive and shorter than their lease duration %s", opts.RenewPeriod, opts.LeaseDuration)
+	}
+	return &Coordinator{
+		client: client,
+		opts:   opts,
+		now:    time.Now,
+		owned:  map[int]time.Time{},
+	}, nil
+}
+
+// ShardOf returns the shard of the object
+func ShardOf(namespace, name string, shards int) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(namespace + "/" + name))
+	return int(hash.Sum32() % uint32(shards))
+}
+
+// Owns returns true if the object belongs to a shard held by the replica
+func (c *Coordinator) Owns(namespace, name string) bool {
+	if c == nil {
+		return true
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	renewed, ok := c.owned[ShardOf(namespace, name, c.opts.Shards)]
+	return ok && c.now().Before(renewed.Add(c.opts.LeaseDuration))
+}
+
+// Shards returns the number of shards
+func (c *Coordinator) Shards() int {
+	return c.opts.Shards
+}
+
+// Notify registers a listener called with the shards the replica acquires or releases,
+// so the objects of these shards can be reconciled again. It is called before the start.
+func (c *Coordinator) Notify(listener func(shards []int)) {
+	c.listeners = append(c.listeners, listener)
+}
+
+// Start claims the shards until the context is done, it implements manager.Runnable
+func (c *Coordinator) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.opts.RenewPeriod)
+	defer ticker.Stop()
+	for {
+		c.sync(ctx)
+		select {
+		case <-ctx.Done():
+			c.release()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection returns false, all the replicas claim shards
+func (c *Coordinator) NeedLeaderElection() bool {
+	return false
+}
+
+// sync renews the leases of the replica and balances the shards across the live replicas
+func (c *Coordinator) sync(ctx context.Context) {
+	now := c.now()
+	held := c.expire(now)
+	leases := c.client.Leases(c.opts.Namespace)
+
+	if err := c.renewMember(ctx, now); err != nil {
+		log.Error(err, "error renewing the member lease", "identity", c.opts.Identity)
+	}
+	list, err := leases.List(ctx, metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", setLabel, c.opts.Name)})
+	if err != nil {
+		log.Error(err, "error listing the leases of the shards")
+		c.update(held)
+		return
+	}
+
+	members := 0
+	shardLeases := map[int]*coordinationv1.Lease{}
+	for i := range list.Items {
+		lease := &list.Items[i]
+		switch lease.Labels[roleLabel] {
+		case roleMember:
+			if c.live(lease, now) {
+				members++
+			} else if c.expiredFor(lease, now) > memberGarbageCollectionDurations*c.opts.LeaseDuration {
+				if err := leases.Delete(ctx, lease.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+					log.V(1).Info("error deleting the lease of a gone member", "lease", lease.Name, "error", err.Error())
+				}
+			}
+		case roleShard:
+			shard, err := strconv.Atoi(lease.Labels[shardLabel])
+			if err == nil && shard >= 0 && shard < c.opts.Shards {
+				shardLeases[shard] = lease
+			}
+		}
+	}
+	target := (c.opts.Shards + max(members, 1) - 1) / max(members, 1)
+
+	// the shards held by the replica are renewed, including the ones it held
+	// before a restart, and the extra ones are released to the other replicas
+	renewed := 0
+	for shard := 0; shard < c.opts.Shards; shard++ {
+		lease, ok := shardLeases[shard]
+		if !ok || !c.live(lease, now) || ptr.Deref(lease.Spec.HolderIdentity, "") != c.opts.Identity {
+			delete(held, shard)
+			continue
+		}
+		if renewed >= target {
+			lease.Spec.HolderIdentity = nil
+			if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+				log.Error(err, "error releasing the lease of a shard", "shard", shard)
+			}
+			delete(held, shard)
+			continue
+		}
+		lease.Spec.RenewTime = ptr.To(metav1.NewMicroTime(now))
+		if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+			log.Error(err, "error renewing the lease of a shard", "shard", shard)
+			delete(held, shard)
+			continue
+		}
+		renewed++
+		held[shard] = now
+	}
+
+	for shard := 0; shard < c.opts.Shards && renewed < target; shard++ {
+		lease, ok := shardLeases[shard]
+		if ok && c.live(lease, now) {
+			continue
+		}
+		if err := c.acquire(ctx, shard, lease, now); err != nil {
+			if !apierrors.IsConflict(err) && !apierrors.IsAlreadyExists(err) {
+				log.Error(err, "error acquiring the lease of a shard", "shard", shard)
+			}
+			continue
+		}
+		renewed++
+		held[shard] = now
+	}
+	c.update(held)
+}
+
+// expire drops the shards whose lease may have been taken over, it returns the shards
+// still held along with the last time their lease was renewed
+func (c *Coordinator) expire(now time.Time) map[int]time.Time {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	held := map[int]time.Time{}
+	for shard, renewed := range c.owned {
+		if now.Before(renewed.Add(c.opts.LeaseDuration)) {
+			held[shard] = renewed
+		}
+	}
+	return held
+}
+
+// update records the shards held by the replica and notifies the listeners of the changes
+func (c *Coordinator) update(held map[int]time.Time) {
+	c.lock.Lock()
+	var changed []int
+	for shard := range c.owned {
+		if _, ok := held[shard]; !ok {
+			changed = append(changed, shard)
+		}
+	}
+	for shard := range held {
+		if _, ok := c.owned[shard]; !ok {
+			changed = append(changed, shard)
+		}
+	}
+	c.owned = held
+	c.lock.Unlock()
+
+	if len(changed) == 0 {
+		return
+	}
+	slices.Sort(changed)
+	log.Info("The shards of the replica changed", "shards", c.heldShards(), "changed", changed)
+	for _, listener := range c.listeners {
+		listener(changed)
+	}
+}
+
+func (c *Coordinator) heldShards() []int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	shards := make([]int, 0, len(c.owned))
+	for shard := range c.owned {
+		shards = append(shards, shard)
+	}
+	slices.Sort(shards)
+	return shards
+}
+
+func (c *Coordinator) acquire(ctx context.Context, shard int, lease *coordinationv1.Lease, now time.Time) error {
+	leases := c.client.Leases(c.opts.Namespace)
+	if lease == nil {
+		lease = &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-shard-%d", c.opts.Name, shard),
+			Namespace: c.opts.Namespace,
+			Labels:    map[string]string{setLabel: c.opts.Name, roleLabel: roleShard, shardLabel: strconv.Itoa(shard)},
+		}}
+		c.hold(lease, now)
+		_, err := leases.Create(ctx, lease, metav1.CreateOptions{})
+		return err
+	}
+	lease.Spec.LeaseTransitions = ptr.To(ptr.Deref(lease.Spec.LeaseTransitions, 0) + 1)
+	c.hold(lease, now)
+	_, err := leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+func (c *Coordinator) renewMember(ctx context.Context, now time.Time) error {
+	leases := c.client.Leases(c.opts.Namespace)
+	name := c.memberLeaseName()
+	lease, err := leases.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: c.opts.Namespace,
+			Labels:    map[string]string{setLabel: c.opts.Name, roleLabel: roleMember},
+		}}
+		c.hold(lease, now)
+		_, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	c.hold(lease, now)
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// release hands the shards of the replica over to the other replicas on shutdown
+func (c *Coordinator) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+	leases := c.client.Leases(c.opts.Namespace)
+	for _, shard := range c.heldShards() {
+		lease, err := leases.Get(ctx, fmt.Sprintf("%s-shard-%d", c.opts.Name, shard), metav1.GetOptions{})
+		if err != nil || ptr.Deref(lease.Spec.HolderIdentity, "") != c.opts.Identity {
+			continue
+		}
+		lease.Spec.HolderIdentity = nil
+		if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+			log.Error(err, "error releasing the lease of a shard", "shard", shard)
+		}
+	}
+	if err := leases.Delete(ctx, c.memberLeaseName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, "error deleting the member lease", "identity", c.opts.Identity)
+	}
+	c.update(map[int]time.Time{})
+}
+
+func (c *Coordinator) memberLeaseName() string {
+	return fmt.Sprintf("%s-member-%s", c.opts.Name, c.opts.Identity)
+}
+
+func (c *Coordinator) hold(lease *coordinationv1.Lease, now time.Time) {
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != c.opts.Identity {
+		lease.Spec.AcquireTime = ptr.To(metav1.NewMicroTime(now))
+	}
+	lease.Spec.HolderIdentity = ptr.To(c.opts.Identity)
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(c.opts.LeaseDuration.Seconds()))
+	lease.Spec.RenewTime = ptr.To(metav1.NewMicroTime(now))
+}
+
+// live returns true if the lease is held and hasn't expired
+func (c *Coordinator) live(lease *coordinationv1.Lease, now time.Time) bool {
+	return ptr.Deref(lease.Spec.HolderIdentity, "") != "" && c.expiredFor(lease, now) <= 0
+}
+
+// expiredFor returns how long ago the lease expired, it is negative if it hasn't
+func (c *Coordinator) expiredFor(lease *coordinationv1.Lease, now time.Time) time.Duration {
+	if lease.Spec.RenewTime == nil {
+		return now.Sub(lease.CreationTimestamp.Time)
+	}
+	duration := c.opts.LeaseDuration
+	if lease.Spec.LeaseDurationSeconds != nil {
+		duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+	return now.Sub(lease.Spec.RenewTime.Add(duration))
+}
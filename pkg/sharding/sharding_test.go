@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCoordinator(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	now := time.Now()
+	newCoordinator := func(identity string) (*Coordinator, *[][]int) {
+		c, err := NewCoordinator(client.CoordinationV1(), Options{
+			Shards:        4,
+			Name:          "keda-operator",
+			Namespace:     "keda",
+			Identity:      identity,
+			LeaseDuration: 15 * time.Second,
+			RenewPeriod:   5 * time.Second,
+		})
+		assert.NoError(t, err)
+		c.now = func() time.Time { return now }
+		var notified [][]int
+		c.Notify(func(shards []int) { notified = append(notified, shards) })
+		return c, &notified
+	}
+	a, aNotified := newCoordinator("a")
+	b, bNotified := newCoordinator("b")
+	ctx := context.Background()
+
+	// the first replica holds all the shards
+	a.sync(ctx)
+	assert.Equal(t, []int{0, 1, 2, 3}, a.heldShards())
+	assert.Equal(t, [][]int{{0, 1, 2, 3}}, *aNotified)
+
+	// the shards are handed over to the replica joining
+	now = now.Add(5 * time.Second)
+	b.sync(ctx)
+	assert.Empty(t, b.heldShards())
+	a.sync(ctx)
+	assert.Equal(t, []int{0, 1}, a.heldShards())
+	assert.Equal(t, []int{2, 3}, (*aNotified)[1])
+	now = now.Add(5 * time.Second)
+	b.sync(ctx)
+	assert.Equal(t, []int{2, 3}, b.heldShards())
+	assert.Equal(t, [][]int{{2, 3}}, *bNotified)
+
+	// each object is owned by a single replica
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("scaledobject-%d", i)
+		assert.NotEqual(t, a.Owns("default", name), b.Owns("default", name), name)
+	}
+
+	// the shards are taken over once the lease of the replica gone expires
+	now = now.Add(5 * time.Second)
+	a.sync(ctx)
+	now = now.Add(20 * time.Second)
+	assert.Empty(t, a.expire(now), "the shards of a replica not renewing them expire")
+	b.sync(ctx)
+	assert.Equal(t, []int{0, 1, 2, 3}, b.heldShards())
+
+	// the shards are released on shutdown
+	b.release()
+	assert.Empty(t, b.heldShards())
+	now = now.Add(5 * time.Second)
+	a.sync(ctx)
+	assert.Equal(t, []int{0, 1, 2, 3}, a.heldShards())
+}
+
+func TestNilCoordinator(t *testing.T) {
+	var c *Coordinator
+	assert.True(t, c.Owns("default", "scaledobject"))
+}
+
+func TestShardOf(t *testing.T) {
+	counts := make([]int, 4)
+	for i := 0; i < 1000; i++ {
+		shard := ShardOf("default", fmt.Sprintf("scaledobject-%d", i), 4)
+		assert.Equal(t, shard, ShardOf("default", fmt.Sprintf("scaledobject-%d", i), 4))
+		counts[shard]++
+	}
+	for _, count := range counts {
+		assert.Greater(t, count, 150)
+	}
+}
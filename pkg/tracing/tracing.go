@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	"github.com/kedacore/keda/v2/version"
+)
+
+const tracerName = "github.com/kedacore/keda/v2"
+
+// Span attribute keys shared by the KEDA components
+const (
+	ScalableObjectKindKey      = attribute.Key("keda.scalableobject.kind")
+	ScalableObjectNameKey      = attribute.Key("keda.scalableobject.name")
+	ScalableObjectNamespaceKey = attribute.Key("keda.scalableobject.namespace")
+	TriggerTypeKey             = attribute.Key("keda.trigger.type")
+	TriggerNameKey             = attribute.Key("keda.trigger.name")
+	TriggerIndexKey            = attribute.Key("keda.trigger.index")
+	MetricNameKey              = attribute.Key("keda.metric.name")
+)
+
+var enabled bool
+
+// NewTracerProvider registers a global tracer provider exporting the spans with OTLP over gRPC.
+// The exporter is configured by the standard OTEL_EXPORTER_OTLP_* environment variables,
+// the trace context is propagated with the W3C Trace Context and Baggage headers.
+// It returns a function flushing and stopping the provider on shutdown.
+func NewTracerProvider(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version.Version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("error creating trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	setTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func setTracerProvider(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	enabled = true
+}
+
+// Enabled returns whether the tracing has been set up for this component
+func Enabled() bool {
+	return enabled
+}
+
+// StartSpan starts a span as a child of the span in the context, if any.
+// The global tracer provider is a no-op one unless the tracing is enabled.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records the error, if any, on the span and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ScalableObjectAttributes returns the attributes identifying a ScaledObject or a ScaledJob
+func ScalableObjectAttributes(kind, namespace, name string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		ScalableObjectKindKey.String(kind),
+		ScalableObjectNamespaceKey.String(namespace),
+		ScalableObjectNameKey.String(name),
+	}
+}
+
+// GrpcServerOptions returns the options tracing the gRPC calls received by a server,
+// none if the tracing is disabled
+func GrpcServerOptions() []grpc.ServerOption {
+	if !enabled {
+		return nil
+	}
+	return []grpc.ServerOption{grpc.StatsHandler(otelgrpc.NewServerHandler())}
+}
+
+// GrpcDialOptions returns the options tracing the gRPC calls made by a client,
+// none if the tracing is disabled
+func GrpcDialOptions() []grpc.DialOption {
+	if !enabled {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithStatsHandler(otelgrpc.NewClientHandler())}
+}
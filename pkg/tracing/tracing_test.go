@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type memoryExporter struct {
+	lock  sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (e *memoryExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestSpans(t *testing.T) {
+	if len(GrpcServerOptions()) != 0 || len(GrpcDialOptions()) != 0 {
+		t.Fatal("expected no gRPC options when the tracing is disabled")
+	}
+
+	exporter := &memoryExporter{}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	setTracerProvider(provider)
+	defer func() {
+		enabled = false
+		_ = provider.Shutdown(context.Background())
+	}()
+
+	ctx, parent := StartSpan(context.Background(), "ScaledObject.checkScalers", ScalableObjectAttributes("ScaledObject", "default", "so")...)
+	_, child := StartSpan(ctx, "Scaler.GetMetricsAndActivity", TriggerIndexKey.Int(0))
+	EndSpan(child, errors.New("connection refused"))
+	EndSpan(parent, nil)
+
+	if len(exporter.spans) != 2 {
+		t.Fatalf("expected 2 spans but got %d", len(exporter.spans))
+	}
+	childSpan, parentSpan := exporter.spans[0], exporter.spans[1]
+	if childSpan.Parent().SpanID() != parentSpan.SpanContext().SpanID() {
+		t.Error("expected the scaler span to be a child of the ScaledObject span")
+	}
+	if childSpan.Status().Code != codes.Error || childSpan.Status().Description != "connection refused" {
+		t.Errorf("expected the error status on the scaler span but got %v", childSpan.Status())
+	}
+	if parentSpan.Status().Code != codes.Unset {
+		t.Errorf("expected no status on the ScaledObject span but got %v", parentSpan.Status())
+	}
+	if len(parentSpan.Attributes()) != 3 {
+		t.Errorf("expected 3 attributes on the ScaledObject span but got %v", parentSpan.Attributes())
+	}
+
+	// the W3C trace context is propagated
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if carrier.Get("traceparent") == "" {
+		t.Error("expected the traceparent header to be propagated")
+	}
+
+	if len(GrpcServerOptions()) != 1 || len(GrpcDialOptions()) != 1 {
+		t.Error("expected gRPC options when the tracing is enabled")
+	}
+}
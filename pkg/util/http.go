@@ -18,12 +18,40 @@ package util
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"net/http"
+	"sync"
 	"time"
 )
 
 var disableKeepAlives bool
 
+// sharedHTTPTransportKey identifies the transports shared by the HTTP clients, they're recreated
+// once the root CAs are reloaded
+type sharedHTTPTransportKey struct {
+	unsafeSsl bool
+	rootCAs   *x509.CertPool
+}
+
+// sharedHTTPTransportIdleConnTimeout closes the connections left idle by the clients sharing a transport,
+// as they can't close them
+const sharedHTTPTransportIdleConnTimeout = 90 * time.Second
+
+var (
+	sharedHTTPTransportsLock sync.Mutex
+	sharedHTTPTransports     = map[sharedHTTPTransportKey]*sharedHTTPTransport{}
+)
+
+// sharedHTTPTransport pools the connections of the clients sharing it, it doesn't expose the closing
+// of the idle connections to the clients so a client closed doesn't close the connections of the others
+type sharedHTTPTransport struct {
+	transport *http.Transport
+}
+
+func (t *sharedHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.transport.RoundTrip(req)
+}
+
 func init() {
 	disableKeepAlives = getKeepAliveValue()
 }
@@ -44,13 +72,15 @@ type HTTPDoer interface {
 
 // CreateHTTPClient returns a new HTTP client with the timeout set to
 // timeoutMS milliseconds, or 300 milliseconds if timeoutMS <= 0.
-// unsafeSsl parameter allows to avoid tls cert validation if it's required
+// unsafeSsl parameter allows to avoid tls cert validation if it's required.
+// The clients share their transport, and so their connections, with the
+// clients of the same unsafeSsl.
 func CreateHTTPClient(timeout time.Duration, unsafeSsl bool) *http.Client {
 	// default the timeout to 300ms
 	if timeout <= 0 {
 		timeout = 300 * time.Millisecond
 	}
-	transport := CreateHTTPTransport(unsafeSsl)
+	transport := getSharedHTTPTransport(unsafeSsl)
 	httpClient := &http.Client{
 		Timeout:   timeout,
 		Transport: transport,
@@ -58,6 +88,28 @@ func CreateHTTPClient(timeout time.Duration, unsafeSsl bool) *http.Client {
 	return httpClient
 }
 
+// getSharedHTTPTransport returns the transport shared by the HTTP clients of the unsafeSsl
+func getSharedHTTPTransport(unsafeSsl bool) *sharedHTTPTransport {
+	key := sharedHTTPTransportKey{unsafeSsl: unsafeSsl, rootCAs: getRootCAs()}
+
+	sharedHTTPTransportsLock.Lock()
+	defer sharedHTTPTransportsLock.Unlock()
+	shared, found := sharedHTTPTransports[key]
+	if !found {
+		transport := CreateHTTPTransportWithTLSConfig(&tls.Config{
+			InsecureSkipVerify: unsafeSsl,
+			RootCAs:            key.rootCAs,
+			MinVersion:         GetMinTLSVersion(),
+		})
+		if transport.IdleConnTimeout == 0 {
+			transport.IdleConnTimeout = sharedHTTPTransportIdleConnTimeout
+		}
+		shared = &sharedHTTPTransport{transport: transport}
+		sharedHTTPTransports[key] = shared
+	}
+	return shared
+}
+
 // CreateHTTPTransport returns a new HTTP Transport with Proxy, Keep alives
 // unsafeSsl parameter allows to avoid tls cert validation if it's required
 func CreateHTTPTransport(unsafeSsl bool) *http.Transport {
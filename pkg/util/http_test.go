@@ -34,3 +34,17 @@ func TestCreateHTTPClientWhenValidTimeout(t *testing.T) {
 
 	assert.Equal(t, 1*time.Minute, client.Timeout)
 }
+
+func TestCreateHTTPClientSharesTransport(t *testing.T) {
+	client := CreateHTTPClient(1*time.Minute, false)
+	other := CreateHTTPClient(2*time.Minute, false)
+	unsafe := CreateHTTPClient(1*time.Minute, true)
+
+	assert.Same(t, client.Transport, other.Transport)
+	assert.NotSame(t, client.Transport, unsafe.Transport)
+
+	// closing the idle connections of a client doesn't close the ones of the transport shared
+	client.CloseIdleConnections()
+	_, ok := client.Transport.(interface{ CloseIdleConnections() })
+	assert.False(t, ok)
+}
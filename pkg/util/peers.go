@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// ForwardedHeader marks the pushes a receiver forwarded to the other replicas of the operator, they
+// aren't forwarded again
+const ForwardedHeader = "X-Keda-Forwarded"
+
+// PeerResolver returns the addresses, as host:port, of the other replicas of the operator
+type PeerResolver func(ctx context.Context) ([]string, error)
+
+// NewPeerResolver returns the resolver of the replicas the headless service resolves to, reached on the
+// port. It's nil when there is no service.
+func NewPeerResolver(service string, port string) PeerResolver {
+	if service == "" {
+		return nil
+	}
+	return func(ctx context.Context) ([]string, error) {
+		hosts, err := net.DefaultResolver.LookupHost(ctx, service)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving the replicas of %s: %w", service, err)
+		}
+		local, err := localAddresses()
+		if err != nil {
+			return nil, err
+		}
+		peers := make([]string, 0, len(hosts))
+		for _, host := range hosts {
+			if ip := net.ParseIP(host); ip != nil && local[ip.String()] {
+				continue
+			}
+			peers = append(peers, net.JoinHostPort(host, port))
+		}
+		return peers, nil
+	}
+}
+
+// localAddresses returns the addresses of the interfaces of this replica
+func localAddresses() (map[string]bool, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, fmt.Errorf("error listing the interface addresses: %w", err)
+	}
+	local := make(map[string]bool, len(addrs))
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok {
+			local[ipNet.IP.String()] = true
+		}
+	}
+	return local, nil
+}
+
+// ForwardToPeers calls forward for each of the replicas concurrently, the errors are joined
+func ForwardToPeers(ctx context.Context, resolve PeerResolver, forward func(ctx context.Context, peer string) error) error {
+	peers, err := resolve(ctx)
+	if err != nil {
+		return err
+	}
+	var wg sync.WaitGroup
+	errs := make([]error, len(peers))
+	for i, peer := range peers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := forward(ctx, peer); err != nil {
+				errs[i] = fmt.Errorf("error forwarding to %s: %w", peer, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
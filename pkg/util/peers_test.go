@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPeerResolver(t *testing.T) {
+	assert.Nil(t, NewPeerResolver("", "8080"))
+
+	// the addresses of this replica aren't peers
+	peers, err := NewPeerResolver("127.0.0.1", "8080")(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, peers)
+
+	peers, err = NewPeerResolver("192.0.2.10", "8080")(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.10:8080"}, peers)
+}
+
+func TestForwardToPeers(t *testing.T) {
+	resolve := func(context.Context) ([]string, error) {
+		return []string{"10.0.0.1:8080", "10.0.0.2:8080"}, nil
+	}
+	var lock sync.Mutex
+	forwarded := []string{}
+	err := ForwardToPeers(context.Background(), resolve, func(_ context.Context, peer string) error {
+		lock.Lock()
+		defer lock.Unlock()
+		forwarded = append(forwarded, peer)
+		if peer == "10.0.0.2:8080" {
+			return fmt.Errorf("connection refused")
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "error forwarding to 10.0.0.2:8080: connection refused")
+	assert.ElementsMatch(t, []string{"10.0.0.1:8080", "10.0.0.2:8080"}, forwarded)
+}
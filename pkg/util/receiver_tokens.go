@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ReceiverTokenKey is the key of the token in the Secret of a namespace
+const ReceiverTokenKey = "token"
+
+// tokensResync is how often the Secrets of the tokens are listed again
+const tokensResync = time.Hour
+
+// ErrTokensNotSynced is returned until the Secrets of the tokens are listed
+var ErrTokensNotSynced = errors.New("the tokens aren't listed yet")
+
+// NamespaceTokens reads the tokens the pushes to the receivers of each namespace are authenticated
+// with from the Secret of the namespace, so a token only allows pushing the metrics of its namespace.
+// Only the Secrets of the tokens are watched, the pushes are authenticated without calling the API server.
+type NamespaceTokens struct {
+	secrets    corev1listers.SecretLister
+	synced     cache.InformerSynced
+	secretName string
+}
+
+// NewNamespaceTokens returns the tokens, read once the informer of their Secrets is started
+func NewNamespaceTokens(kubeClient kubernetes.Interface, secretName string) (*NamespaceTokens, kubeinformers.SharedInformerFactory) {
+	factory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, tokensResync,
+		kubeinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", secretName).String()
+		}))
+	informer := factory.Core().V1().Secrets()
+	return &NamespaceTokens{
+		secrets:    informer.Lister(),
+		synced:     informer.Informer().HasSynced,
+		secretName: secretName,
+	}, factory
+}
+
+// Get returns the token of the namespace, nil when the namespace has no Secret or no token in it
+func (t *NamespaceTokens) Get(namespace string) ([]byte, error) {
+	if !t.synced() {
+		return nil, ErrTokensNotSynced
+	}
+	secret, err := t.secrets.Secrets(namespace).Get(t.secretName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading the secret %s/%s: %w", namespace, t.secretName, err)
+	}
+	if len(secret.Data[ReceiverTokenKey]) == 0 {
+		return nil, nil
+	}
+	return secret.Data[ReceiverTokenKey], nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// startNamespaceTokens returns the tokens of the Secrets of the client, once they're listed
+func startNamespaceTokens(t *testing.T, client kubernetes.Interface) *NamespaceTokens {
+	tokens, informers := NewNamespaceTokens(client, "keda-receiver")
+	stop := make(chan struct{})
+	t.Cleanup(func() {
+		close(stop)
+		informers.Shutdown()
+	})
+	informers.Start(stop)
+	informers.WaitForCacheSync(stop)
+	return tokens
+}
+
+func TestNamespaceTokens(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "keda-receiver", Namespace: "default"}, Data: map[string][]byte{ReceiverTokenKey: []byte("token")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other-secret", Namespace: "none"}, Data: map[string][]byte{ReceiverTokenKey: []byte("other")}},
+	)
+
+	// the pushes aren't authenticated until the tokens are listed
+	tokens, _ := NewNamespaceTokens(client, "keda-receiver")
+	_, err := tokens.Get("default")
+	assert.ErrorIs(t, err, ErrTokensNotSynced)
+
+	tokens = startNamespaceTokens(t, client)
+	token, err := tokens.Get("default")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("token"), token)
+	token, err = tokens.Get("none")
+	assert.NoError(t, err)
+	assert.Nil(t, token)
+
+	// the tokens are watched
+	_, err = client.CoreV1().Secrets("default").Update(context.Background(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "keda-receiver", Namespace: "default"}, Data: map[string][]byte{ReceiverTokenKey: []byte("rotated")}}, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		token, _ := tokens.Get("default")
+		return string(token) == "rotated"
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"sync"
+	"time"
+)
+
+// servingCertificateReloadPeriod is how often the certificate is read again, to pick its rotations up
+const servingCertificateReloadPeriod = time.Minute
+
+// ServingCertificate is the certificate a server of the operator serves, read from its files as they're rotated.
+// The certificate may not be written yet when the server starts, the handshakes fail until it is.
+type ServingCertificate struct {
+	certFile string
+	keyFile  string
+	lock     sync.Mutex
+	current  *tls.Certificate
+	readAt   time.Time
+}
+
+// NewServingCertificate returns the certificate of the files, read on the first handshake
+func NewServingCertificate(certFile, keyFile string) *ServingCertificate {
+	return &ServingCertificate{certFile: certFile, keyFile: keyFile}
+}
+
+func (c *ServingCertificate) get() (*tls.Certificate, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.current != nil && time.Since(c.readAt) < servingCertificateReloadPeriod {
+		return c.current, nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		if c.current != nil {
+			logger.Error(err, "error reading the certificate, the previous one is served")
+			return c.current, nil
+		}
+		return nil, err
+	}
+	c.current, c.readAt = &cert, time.Now()
+	return c.current, nil
+}
+
+// ServerConfig returns the TLS config of the receiver
+func (c *ServingCertificate) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return c.get()
+		},
+	}
+}
+
+// PeerConfig returns the TLS config the pushes are forwarded to the other replicas with. The peers
+// are reached on their pod IP which their certificate doesn't name, they're trusted when they serve
+// the certificate of this replica as all the replicas serve the same one.
+func (c *ServingCertificate) PeerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: true, //nolint:gosec // the certificate of the peer is verified below
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			cert, err := c.get()
+			if err != nil {
+				return err
+			}
+			if len(rawCerts) == 0 || len(cert.Certificate) == 0 || !bytes.Equal(rawCerts[0], cert.Certificate[0]) {
+				return errors.New("the peer doesn't serve the certificate of the receiver")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookreceiver
+
+import (
+	"sync"
+	"time"
+)
+
+// metricKey identifies a metric pushed, the metrics are pushed to the namespace of their scalers
+type metricKey struct {
+	namespace string
+	metric    string
+}
+
+// pushedValue is the last value pushed of a metric
+type pushedValue struct {
+	value      float64
+	receivedAt time.Time
+}
+
+// valueStore keeps the last value pushed of each metric
+type valueStore struct {
+	lock       sync.Mutex
+	ttl        time.Duration
+	maxMetrics int
+	values     map[metricKey]pushedValue
+}
+
+func newValueStore(ttl time.Duration, maxMetrics int) *valueStore {
+	return &valueStore{ttl: ttl, maxMetrics: maxMetrics, values: map[metricKey]pushedValue{}}
+}
+
+// record updates the last value of the metric, the new metrics past maxMetrics are rejected
+func (s *valueStore) record(key metricKey, value float64, now time.Time) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.expire(now)
+	if _, ok := s.values[key]; !ok && s.maxMetrics > 0 && len(s.values) >= s.maxMetrics {
+		return false
+	}
+	s.values[key] = pushedValue{value: value, receivedAt: now}
+	return true
+}
+
+// remove drops the value of the metric
+func (s *valueStore) remove(key metricKey) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.values, key)
+}
+
+// get returns the last value of the metric, unless it hasn't been pushed for longer than the ttl
+func (s *valueStore) get(key metricKey, now time.Time) (float64, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.expire(now)
+	v, ok := s.values[key]
+	return v.value, ok
+}
+
+// expire drops the metrics which haven't been pushed for longer than the ttl
+func (s *valueStore) expire(now time.Time) {
+	for key, v := range s.values {
+		if now.Sub(v.receivedAt) > s.ttl {
+			delete(s.values, key)
+		}
+	}
+}
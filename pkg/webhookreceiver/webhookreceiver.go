@@ -0,0 +1,276 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookreceiver
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+var log = logf.Log.WithName("webhook_receiver")
+
+// Path is the path prefix the values are pushed on, as /metrics/<namespace>/<metric>
+const Path = "/metrics/"
+
+const (
+	// maxPayloadSize is the largest payload of a push, it only carries a value
+	maxPayloadSize = 64 << 10
+	// forwardTimeout bounds the forwarding of a push to the other replicas
+	forwardTimeout = 10 * time.Second
+)
+
+// Options configures the webhook receiver
+type Options struct {
+	// BindAddress is the address the receiver listens on
+	BindAddress string
+	// TokenSecret is the name of the Secret of each namespace whose token key holds the bearer token
+	// the pushes to the namespace are authenticated with, the namespaces without it can't be pushed to
+	TokenSecret string
+	// KubeClient watches the Secrets of the tokens
+	KubeClient kubernetes.Interface
+	// CertFile and KeyFile are the certificate and the key the receiver serves TLS with, they're read
+	// again as they're rotated
+	CertFile string
+	KeyFile  string
+	// ValueTTL is how long the value of a metric is kept since it was pushed
+	ValueTTL time.Duration
+	// MaxMetrics is the number of metrics kept, the pushes of the new metrics past it are rejected
+	MaxMetrics int
+	// PeersService is the headless service resolving to the replicas of the operator, the pushes are
+	// forwarded to the other replicas so the one evaluating a scaler has them. Empty disables the forwarding.
+	PeersService string
+}
+
+// pushRequest is the payload of a push
+type pushRequest struct {
+	Value *float64 `json:"value"`
+}
+
+var (
+	lock  sync.RWMutex
+	store *valueStore
+)
+
+// NewReceiver starts receiving the values of the metrics pushed over HTTPS by the systems which
+// can't be polled, the last value of each metric is kept so the scalers can read it. The values
+// are kept in memory: they're pushed again following a restart. A push received by a replica is
+// forwarded to the others of the PeersService, each replica keeps all the values whichever
+// evaluates the scalers. It returns a function stopping the receiver on shutdown.
+func NewReceiver(opts Options) (func() error, error) {
+	if opts.TokenSecret == "" || opts.KubeClient == nil {
+		return nil, fmt.Errorf("the secret of the tokens is required to authenticate the pushes")
+	}
+	if opts.CertFile == "" || opts.KeyFile == "" {
+		return nil, fmt.Errorf("a certificate is required to serve the pushes over tls")
+	}
+	if opts.ValueTTL <= 0 {
+		return nil, fmt.Errorf("the value ttl must be greater than 0")
+	}
+	listener, err := net.Listen("tcp", opts.BindAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error listening on %s: %w", opts.BindAddress, err)
+	}
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		return nil, err
+	}
+	return start(listener, opts, kedautil.NewPeerResolver(opts.PeersService, port)), nil
+}
+
+func start(listener net.Listener, opts Options, peers kedautil.PeerResolver) func() error {
+	s := newValueStore(opts.ValueTTL, opts.MaxMetrics)
+	lock.Lock()
+	store = s
+	lock.Unlock()
+
+	cert := kedautil.NewServingCertificate(opts.CertFile, opts.KeyFile)
+	tokens, informers := kedautil.NewNamespaceTokens(opts.KubeClient, opts.TokenSecret)
+	stopInformers := make(chan struct{})
+	informers.Start(stopInformers)
+	h := &handler{tokens: tokens, store: s}
+	if peers != nil {
+		h.peers = peers
+		h.client = &http.Client{Timeout: forwardTimeout, Transport: &http.Transport{TLSClientConfig: cert.PeerConfig()}}
+	}
+	mux := http.NewServeMux()
+	mux.Handle(Path, h)
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig:         cert.ServerConfig(),
+	}
+	go func() {
+		if err := server.ServeTLS(listener, "", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error(err, "webhook receiver stopped")
+		}
+	}()
+
+	return func() error {
+		lock.Lock()
+		store = nil
+		lock.Unlock()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := server.Shutdown(ctx)
+		close(stopInformers)
+		informers.Shutdown()
+		return err
+	}
+}
+
+// Enabled returns whether the webhook receiver has been set up for this component
+func Enabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return store != nil
+}
+
+// Value returns the last value pushed of the metric of the namespace, unless it has expired
+func Value(namespace, metric string) (float64, bool) {
+	lock.RLock()
+	s := store
+	lock.RUnlock()
+	if s == nil {
+		return 0, false
+	}
+	return s.get(metricKey{namespace: namespace, metric: metric}, time.Now())
+}
+
+type handler struct {
+	tokens *kedautil.NamespaceTokens
+	store  *valueStore
+	// peers resolves the other replicas the pushes are forwarded to, nil without forwarding
+	peers  kedautil.PeerResolver
+	client *http.Client
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	key, ok := keyOf(r.URL.Path)
+	if !ok {
+		http.Error(w, "the path must be "+Path+"<namespace>/<metric>", http.StatusNotFound)
+		return
+	}
+
+	token, err := h.tokens.Get(key.namespace)
+	if err != nil {
+		log.V(1).Info("error reading the token of the namespace", "namespace", key.namespace, "error", err.Error())
+		http.Error(w, "error reading the token of the namespace", http.StatusServiceUnavailable)
+		return
+	}
+	if token == nil || subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), append([]byte("Bearer "), token...)) != 1 {
+		http.Error(w, "invalid bearer token", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		h.store.remove(key)
+		log.V(1).Info("removed pushed metric", "namespace", key.namespace, "metric", key.metric)
+		h.forwardOnce(r, nil)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize+1))
+	if err != nil {
+		http.Error(w, "error reading the payload", http.StatusBadRequest)
+		return
+	}
+	if len(payload) > maxPayloadSize {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	push := pushRequest{}
+	if err := json.Unmarshal(payload, &push); err != nil || push.Value == nil {
+		http.Error(w, `the payload must be {"value": <number>}`, http.StatusBadRequest)
+		return
+	}
+
+	if !h.store.record(key, *push.Value, time.Now()) {
+		http.Error(w, "the maximum number of metrics is reached", http.StatusTooManyRequests)
+		return
+	}
+	log.V(1).Info("received pushed metric", "namespace", key.namespace, "metric", key.metric, "value", *push.Value)
+	h.forwardOnce(r, payload)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// forwardOnce forwards the push to the other replicas, unless it was forwarded by another replica
+func (h *handler) forwardOnce(r *http.Request, payload []byte) {
+	if h.peers == nil || r.Header.Get(kedautil.ForwardedHeader) != "" {
+		return
+	}
+	go h.forward(r.Method, r.URL.Path, r.Header.Get("Authorization"), payload)
+}
+
+// forward sends the push to the other replicas, with its token so they authenticate it as pushed
+// by the system. The values are overwritten, a replica receiving it twice is fine.
+func (h *handler) forward(method string, path string, authorization string, payload []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), forwardTimeout)
+	defer cancel()
+	err := kedautil.ForwardToPeers(ctx, h.peers, func(ctx context.Context, peer string) error {
+		req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("https://%s%s", peer, path), bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", authorization)
+		req.Header.Set(kedautil.ForwardedHeader, "true")
+		resp, err := h.client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error(err, "error forwarding the pushed metric", "path", path)
+	}
+}
+
+// keyOf returns the namespace and the metric of the path of a push
+func keyOf(path string) (metricKey, bool) {
+	rest, found := strings.CutPrefix(path, Path)
+	if !found {
+		return metricKey{}, false
+	}
+	namespace, metric, found := strings.Cut(rest, "/")
+	if !found || namespace == "" || metric == "" || strings.Contains(metric, "/") {
+		return metricKey{}, false
+	}
+	return metricKey{namespace: namespace, metric: metric}, true
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2024 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookreceiver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	testToken       = "It's a Secret to Everybody"
+	testTokenSecret = "keda-webhook-receiver"
+)
+
+// startTokens returns the tokens of the Secrets of the client, once they're listed
+func startTokens(t *testing.T, client kubernetes.Interface) *kedautil.NamespaceTokens {
+	tokens, informers := kedautil.NewNamespaceTokens(client, testTokenSecret)
+	stop := make(chan struct{})
+	t.Cleanup(func() {
+		close(stop)
+		informers.Shutdown()
+	})
+	informers.Start(stop)
+	informers.WaitForCacheSync(stop)
+	return tokens
+}
+
+// testTokens returns the tokens of the namespaces default and other, the namespace none has no token
+func testTokens(t *testing.T) *kedautil.NamespaceTokens {
+	return startTokens(t, fake.NewSimpleClientset(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: testTokenSecret, Namespace: "default"}, Data: map[string][]byte{kedautil.ReceiverTokenKey: []byte(testToken)}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: testTokenSecret, Namespace: "other"}, Data: map[string][]byte{kedautil.ReceiverTokenKey: []byte("other")}},
+	))
+}
+
+// testCertificate writes a self-signed certificate of 127.0.0.1, it returns its files and the pool trusting it
+func testCertificate(t *testing.T) (string, string, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "keda-operator"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return certFile, keyFile, pool
+}
+
+func push(h http.Handler, method string, path string, payload string, token string) int {
+	req := httptest.NewRequest(method, path, strings.NewReader(payload))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestHandlerAuthentication(t *testing.T) {
+	h := &handler{tokens: testTokens(t), store: newValueStore(time.Minute, 0)}
+
+	assert.Equal(t, http.StatusNoContent, push(h, http.MethodPost, "/metrics/default/queue", `{"value": 1}`, testToken))
+	assert.Equal(t, http.StatusUnauthorized, push(h, http.MethodPost, "/metrics/default/queue", `{"value": 1}`, ""))
+	assert.Equal(t, http.StatusUnauthorized, push(h, http.MethodPost, "/metrics/default/queue", `{"value": 1}`, "guess"))
+	// a token only allows pushing to its namespace
+	assert.Equal(t, http.StatusUnauthorized, push(h, http.MethodPost, "/metrics/other/queue", `{"value": 1}`, testToken))
+	assert.Equal(t, http.StatusNoContent, push(h, http.MethodPost, "/metrics/other/queue", `{"value": 1}`, "other"))
+	assert.Equal(t, http.StatusUnauthorized, push(h, http.MethodDelete, "/metrics/other/queue", "", testToken))
+	// the namespaces without a token can't be pushed to
+	assert.Equal(t, http.StatusUnauthorized, push(h, http.MethodPost, "/metrics/none/queue", `{"value": 1}`, ""))
+	assert.Equal(t, http.StatusMethodNotAllowed, push(h, http.MethodGet, "/metrics/default/queue", "", testToken))
+}
+
+func TestHandlerPush(t *testing.T) {
+	s := newValueStore(time.Minute, 0)
+	h := &handler{tokens: testTokens(t), store: s}
+
+	assert.Equal(t, http.StatusNoContent, push(h, http.MethodPost, "/metrics/default/queue", `{"value": 12.5}`, testToken))
+	value, ok := s.get(metricKey{namespace: "default", metric: "queue"}, time.Now())
+	assert.True(t, ok)
+	assert.Equal(t, 12.5, value)
+
+	// the metrics are kept by namespace
+	_, ok = s.get(metricKey{namespace: "other", metric: "queue"}, time.Now())
+	assert.False(t, ok)
+
+	// the payloads and the paths are validated
+	assert.Equal(t, http.StatusBadRequest, push(h, http.MethodPost, "/metrics/default/queue", `{"count": 1}`, testToken))
+	assert.Equal(t, http.StatusBadRequest, push(h, http.MethodPost, "/metrics/default/queue", `{"value": "1"}`, testToken))
+	assert.Equal(t, http.StatusNotFound, push(h, http.MethodPost, "/metrics/default", `{"value": 1}`, testToken))
+	assert.Equal(t, http.StatusNotFound, push(h, http.MethodPost, "/metrics/default/queue/1", `{"value": 1}`, testToken))
+
+	assert.Equal(t, http.StatusNoContent, push(h, http.MethodDelete, "/metrics/default/queue", "", testToken))
+	_, ok = s.get(metricKey{namespace: "default", metric: "queue"}, time.Now())
+	assert.False(t, ok)
+}
+
+func TestValueStoreLimits(t *testing.T) {
+	s := newValueStore(time.Minute, 1)
+	now := time.Now()
+
+	assert.True(t, s.record(metricKey{namespace: "default", metric: "a"}, 1, now))
+	// the new metrics past maxMetrics are rejected, not the updates
+	assert.False(t, s.record(metricKey{namespace: "default", metric: "b"}, 1, now))
+	assert.True(t, s.record(metricKey{namespace: "default", metric: "a"}, 2, now))
+
+	// the values expire once they haven't been pushed for the ttl
+	_, ok := s.get(metricKey{namespace: "default", metric: "a"}, now.Add(2*time.Minute))
+	assert.False(t, ok)
+	assert.True(t, s.record(metricKey{namespace: "default", metric: "b"}, 1, now.Add(2*time.Minute)))
+}
+
+func TestReceiver(t *testing.T) {
+	certFile, keyFile, pool := testCertificate(t)
+	kubeClient := fake.NewSimpleClientset(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: testTokenSecret, Namespace: "default"}, Data: map[string][]byte{kedautil.ReceiverTokenKey: []byte(testToken)}})
+
+	// the pushes must be authenticated and served over tls
+	_, err := NewReceiver(Options{BindAddress: "127.0.0.1:0", CertFile: certFile, KeyFile: keyFile, ValueTTL: time.Minute})
+	assert.Error(t, err)
+	_, err = NewReceiver(Options{BindAddress: "127.0.0.1:0", TokenSecret: testTokenSecret, KubeClient: kubeClient, ValueTTL: time.Minute})
+	assert.Error(t, err)
+	assert.False(t, Enabled())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	shutdown := start(listener, Options{TokenSecret: testTokenSecret, KubeClient: kubeClient, CertFile: certFile, KeyFile: keyFile, ValueTTL: time.Minute}, nil)
+	assert.True(t, Enabled())
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}}}
+	// the pushes are authenticated once the tokens are listed
+	assert.Eventually(t, func() bool {
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("https://%s%sdefault/queue", listener.Addr(), Path), strings.NewReader(`{"value": 3}`))
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+testToken)
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusNoContent
+	}, 5*time.Second, 10*time.Millisecond)
+	value, ok := Value("default", "queue")
+	assert.True(t, ok)
+	assert.Equal(t, float64(3), value)
+
+	assert.NoError(t, shutdown())
+	assert.False(t, Enabled())
+	_, ok = Value("default", "queue")
+	assert.False(t, ok)
+}
+
+func TestHandlerForwarding(t *testing.T) {
+	certFile, keyFile, _ := testCertificate(t)
+	cert := kedautil.NewServingCertificate(certFile, keyFile)
+
+	// the replica evaluating the scalers, a push forwarded isn't forwarded again
+	peer := &handler{tokens: testTokens(t), store: newValueStore(time.Minute, 0), peers: func(context.Context) ([]string, error) {
+		t.Error("forwarded push forwarded again")
+		return nil, nil
+	}}
+	tlsCert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	assert.NoError(t, err)
+	server := httptest.NewUnstartedServer(peer)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{tlsCert}, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	// the replica the system pushed to
+	s := newValueStore(time.Minute, 0)
+	h := &handler{tokens: testTokens(t), store: s, client: &http.Client{Transport: &http.Transport{TLSClientConfig: cert.PeerConfig()}}, peers: func(context.Context) ([]string, error) {
+		return []string{server.Listener.Addr().String()}, nil
+	}}
+	assert.Equal(t, http.StatusNoContent, push(h, http.MethodPost, "/metrics/default/queue", `{"value": 7}`, testToken))
+	value, ok := s.get(metricKey{namespace: "default", metric: "queue"}, time.Now())
+	assert.True(t, ok)
+	assert.Equal(t, float64(7), value)
+	assert.Eventually(t, func() bool {
+		value, ok := peer.store.get(metricKey{namespace: "default", metric: "queue"}, time.Now())
+		return ok && value == 7
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, http.StatusNoContent, push(h, http.MethodDelete, "/metrics/default/queue", "", testToken))
+	assert.Eventually(t, func() bool {
+		_, ok := peer.store.get(metricKey{namespace: "default", metric: "queue"}, time.Now())
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the pushes aren't forwarded to the servers which don't serve the certificate of the receiver
+	var received atomic.Bool
+	other := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		received.Store(true)
+	}))
+	defer other.Close()
+	h = &handler{tokens: testTokens(t), store: s, client: h.client, peers: func(context.Context) ([]string, error) {
+		return []string{other.Listener.Addr().String()}, nil
+	}}
+	assert.Equal(t, http.StatusNoContent, push(h, http.MethodPost, "/metrics/default/queue", `{"value": 7}`, testToken))
+	assert.Never(t, received.Load, 200*time.Millisecond, 10*time.Millisecond)
+}